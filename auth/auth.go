@@ -0,0 +1,153 @@
+// Package auth provides session tokens that identify an authenticated client
+// or worker. Tokens are JSON Web Tokens signed with HMAC-SHA256.
+package auth
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ClientRole is the role put in tokens of clients. Workers use their own
+// role codes, which start at 1.
+const ClientRole = 0
+
+var (
+	// InvalidToken indicates a token that is malformed, signed with another
+	// secret or altered after signing.
+	InvalidToken = errors.New("invalid token")
+
+	// ExpiredToken indicates a correctly signed token whose lifetime is over.
+	ExpiredToken = errors.New("token has expired")
+
+	// EmptySecret indicates that tokens cannot be signed because no secret is configured.
+	EmptySecret = errors.New("token signing secret is empty")
+)
+
+// Claims are the facts asserted by a token.
+type Claims struct {
+	SubjectID uuid.UUID // ID of the authenticated client or worker
+	Role      int       // ClientRole for clients, the worker role for workers
+	IssuedAt  time.Time // When the token was issued
+	ExpiresAt time.Time // When the token stops being accepted
+}
+
+// tokenHeader is the fixed header of every issued token.
+const tokenHeader = `{"alg":"HS256","typ":"JWT"}`
+
+// tokenPayload is the JSON form of Claims inside a token.
+type tokenPayload struct {
+	Subject   string `json:"sub"`
+	Role      int    `json:"role"`
+	IssuedAt  int64  `json:"iat"`
+	ExpiresAt int64  `json:"exp"`
+}
+
+// jwtIssuer implements the TokenIssuer interface with HS256 signed JSON Web Tokens.
+type jwtIssuer struct {
+	secret []byte        // Key used to sign and verify tokens
+	expiry time.Duration // Lifetime of issued tokens
+}
+
+// NewTokenIssuer creates a TokenIssuer that signs tokens with the secret and
+// issues them for the given lifetime.
+func NewTokenIssuer(secret string, expiry time.Duration) TokenIssuer {
+	return &jwtIssuer{secret: []byte(secret), expiry: expiry}
+}
+
+// GenerateToken issues a token for the subject with the given role, valid for
+// the configured lifetime from now.
+func (j *jwtIssuer) GenerateToken(subjectID uuid.UUID, role int) (string, error) {
+	if len(j.secret) == 0 {
+		return "", EmptySecret
+	}
+
+	now := time.Now()
+	payload, err := json.Marshal(tokenPayload{
+		Subject:   subjectID.String(),
+		Role:      role,
+		IssuedAt:  now.Unix(),
+		ExpiresAt: now.Add(j.expiry).Unix(),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	unsigned := encodeSegment([]byte(tokenHeader)) + "." + encodeSegment(payload)
+	return unsigned + "." + encodeSegment(j.sign(unsigned)), nil
+}
+
+// ParseToken checks the header, signature and expiry of a token and returns its claims.
+func (j *jwtIssuer) ParseToken(token string) (*Claims, error) {
+	if len(j.secret) == 0 {
+		return nil, EmptySecret
+	}
+
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("%w: expected three segments", InvalidToken)
+	}
+
+	header, err := decodeSegment(parts[0])
+	if err != nil || string(header) != tokenHeader {
+		return nil, fmt.Errorf("%w: unsupported header", InvalidToken)
+	}
+
+	signature, err := decodeSegment(parts[2])
+	if err != nil || !hmac.Equal(signature, j.sign(parts[0]+"."+parts[1])) {
+		return nil, fmt.Errorf("%w: signature mismatch", InvalidToken)
+	}
+
+	rawPayload, err := decodeSegment(parts[1])
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", InvalidToken, err)
+	}
+
+	var payload tokenPayload
+	err = json.Unmarshal(rawPayload, &payload)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", InvalidToken, err)
+	}
+
+	subjectID, err := uuid.Parse(payload.Subject)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", InvalidToken, err)
+	}
+
+	claims := &Claims{
+		SubjectID: subjectID,
+		Role:      payload.Role,
+		IssuedAt:  time.Unix(payload.IssuedAt, 0),
+		ExpiresAt: time.Unix(payload.ExpiresAt, 0),
+	}
+
+	if !time.Now().Before(claims.ExpiresAt) {
+		return nil, ExpiredToken
+	}
+
+	return claims, nil
+}
+
+// sign computes the HMAC-SHA256 signature of the header and payload segments.
+func (j *jwtIssuer) sign(unsigned string) []byte {
+	mac := hmac.New(sha256.New, j.secret)
+	mac.Write([]byte(unsigned))
+	return mac.Sum(nil)
+}
+
+// encodeSegment encodes a token segment with unpadded URL-safe base64.
+func encodeSegment(data []byte) string {
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// decodeSegment decodes a token segment encoded with unpadded URL-safe base64.
+func decodeSegment(segment string) ([]byte, error) {
+	return base64.RawURLEncoding.DecodeString(segment)
+}
@@ -0,0 +1,15 @@
+package auth
+
+import "github.com/google/uuid"
+
+// TokenIssuer defines the interface for issuing and checking session tokens.
+type TokenIssuer interface {
+	// GenerateToken issues a signed token for the subject with the given role.
+	// Returns the encoded token and any error that occurred.
+	GenerateToken(subjectID uuid.UUID, role int) (string, error)
+
+	// ParseToken checks the signature and expiry of a token and returns its claims.
+	// Returns InvalidToken if the token is malformed or tampered with and
+	// ExpiredToken if it has expired.
+	ParseToken(token string) (*Claims, error)
+}
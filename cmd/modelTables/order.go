@@ -13,7 +13,7 @@ import (
 )
 
 // Orders renders a slice of Order entities in a formatted table on the console.
-// It displays order information including creation date, status, address, and user rating
+// It displays order information including creation date, status, address, quoted price and user rating
 // in an aligned tabular format for better readability.
 //
 // The function automatically adjusts column widths to accommodate data while
@@ -34,7 +34,7 @@ func Orders(orders []models.Order) error {
 		if len(order.Address) > maxAddressLen {
 			maxAddressLen = len(order.Address)
 		}
-		statusLen := len(models.OrderStatuses[order.Status])
+		statusLen := len(order.DisplayStatus())
 		if statusLen > maxStatusLen {
 			maxStatusLen = statusLen
 		}
@@ -46,16 +46,16 @@ func Orders(orders []models.Order) error {
 	t.Init(os.Stdout, 2, 4, 5, ' ', 0)
 
 	// Write the table header
-	_, err = fmt.Fprintf(t, "\n %s\t%s\t%s\t%s\t%s",
-		"№", "Дата создания", "Статус", "Адрес", "Оценка")
+	_, err = fmt.Fprintf(t, "\n %s\t%s\t%s\t%s\t%s\t%s",
+		"№", "Дата создания", "Статус", "Адрес", "Стоимость", "Оценка")
 	if err != nil {
 		fmt.Println(err)
 	}
 
 	// Write each order as a table row
 	for i, order := range orders {
-		_, err = fmt.Fprintf(t, "\n %d\t%s\t%s\t%s\t%d",
-			i+1, order.CreationDate.Format("2006-01-02"), cmdUtils.TruncateString(models.OrderStatuses[order.Status], 20), cmdUtils.TruncateString(order.Address, 20), order.Rate)
+		_, err = fmt.Fprintf(t, "\n %d\t%s\t%s\t%s\t%.2f\t%d",
+			i+1, order.CreationDate.Format("2006-01-02"), cmdUtils.TruncateString(order.DisplayStatus(), 20), cmdUtils.TruncateString(order.Address, 20), order.QuotedTotal, order.Rate)
 		if err != nil {
 			return err
 		}
@@ -13,32 +13,53 @@ import (
 )
 
 // Tasks renders a slice of Task entities in a formatted table on the console.
-// It displays task information including name, price per unit, and category
-// in an aligned tabular format for better readability.
+// Category names are taken from the built-in list of categories; use
+// TasksWithCategoryNames to display names resolved from the database.
+//
+// Parameters:
+//   - tasks: A slice of models.Task entities to display in the table
+//
+// Returns:
+//   - error: Any error that occurs during formatting or output operations
+func Tasks(tasks []models.Task) error {
+	tasksWithCategory := make([]models.TaskWithCategory, 0, len(tasks))
+	for _, task := range tasks {
+		tasksWithCategory = append(tasksWithCategory, models.TaskWithCategory{
+			Task:         task,
+			CategoryName: models.GetCategoryName(task.Category),
+		})
+	}
+
+	return TasksWithCategoryNames(tasksWithCategory)
+}
+
+// TasksWithCategoryNames renders tasks with their category names in a formatted
+// table on the console. It displays task information including name, price per
+// unit, and category in an aligned tabular format for better readability.
 //
 // The function automatically adjusts column widths to accommodate data while
 // truncating excessively long strings to maintain display consistency. It uses
 // the tabwriter package to ensure proper alignment of columns.
 //
 // Parameters:
-//   - tasks: A slice of models.Task entities to display in the table
+//   - tasks: A slice of tasks with resolved category names to display in the table
 //
 // Returns:
 //   - error: Any error that occurs during formatting or output operations
-func Tasks(tasks []models.Task) error {
+func TasksWithCategoryNames(tasks []models.TaskWithCategory) error {
 	var err error
 
 	// Calculate maximum widths for variable-length fields
 	maxNameLen, maxPriceLen, maxCategoryLen := 0, 0, 0
 	for _, task := range tasks {
-		if len(task.Name) > maxNameLen {
-			maxNameLen = len(task.Name)
+		if len(task.Task.Name) > maxNameLen {
+			maxNameLen = len(task.Task.Name)
 		}
-		priceLen := len(fmt.Sprintf("%.2f", task.PricePerSingle))
+		priceLen := len(fmt.Sprintf("%.2f", task.Task.PricePerSingle))
 		if priceLen > maxPriceLen {
 			maxPriceLen = priceLen
 		}
-		categoryLen := len(models.GetCategoryName(task.Category))
+		categoryLen := len(task.CategoryName)
 		if categoryLen > maxCategoryLen {
 			maxCategoryLen = categoryLen
 		}
@@ -60,7 +81,7 @@ func Tasks(tasks []models.Task) error {
 	// Write each task as a table row
 	for i, task := range tasks {
 		_, err = fmt.Fprintf(t, "\n %d\t%s\t%.2f\t%s\t",
-			i+1, cmdUtils.TruncateString(task.Name, 27), task.PricePerSingle, cmdUtils.TruncateString(models.GetCategoryName(task.Category), 27))
+			i+1, cmdUtils.TruncateString(task.Task.Name, 27), task.Task.PricePerSingle, cmdUtils.TruncateString(task.CategoryName, 27))
 		if err != nil {
 			return err
 		}
@@ -0,0 +1,51 @@
+// Package modelTables provides functionality for displaying domain models
+// in tabular format for command-line interfaces in the PikaClean application.
+// It offers formatted tabular output for various entities such as orders,
+// users, workers, and tasks, making data easily readable in terminal displays.
+package modelTables
+
+import (
+	"fmt"
+	"os"
+	"teamdev/cmd/cmdUtils"
+	"teamdev/internal/models"
+	"text/tabwriter"
+)
+
+// Users renders a slice of clients in a formatted table on the console.
+// It displays the name, phone number, email address and address of every client.
+// Long addresses are truncated to keep the table readable.
+//
+// Parameters:
+//   - users: A slice of password-free client views to display in the table
+//
+// Returns:
+//   - error: Any error that occurs during formatting or output operations
+func Users(users []models.UserPublic) error {
+	var err error
+
+	// Initialize tabwriter for formatted columnar output
+	t := new(tabwriter.Writer)
+	t.Init(os.Stdout, 1, 4, 2, ' ', 0)
+
+	// Write the table header
+	_, err = fmt.Fprintf(t, "\n %s\t%s\t%s\t%s\t%s\n",
+		"№", "Имя", "Телефон", "Email", "Адрес")
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	// Write each client as a table row
+	for i, user := range users {
+		fmt.Fprintf(t, " %d\t%s %s\t%s\t%s\t%s\n",
+			i+1, user.Name, user.Surname, user.PhoneNumber, user.Email, cmdUtils.TruncateString(user.Address, 40))
+	}
+
+	// Flush buffered output to standard output
+	err = t.Flush()
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -14,7 +14,7 @@ import (
 
 // Workers renders a slice of Worker entities in a formatted table on the console.
 // It displays worker information including name, role, phone number, email address,
-// and average rating in an aligned tabular format for better readability.
+// average rating and last login time in an aligned tabular format for better readability.
 //
 // The function uses the tabwriter package to ensure proper alignment of columns,
 // and leverages the worker service to obtain additional data such as average ratings.
@@ -33,8 +33,8 @@ func Workers(services registry.Services, workers []models.Worker) error {
 	t.Init(os.Stdout, 1, 4, 2, ' ', 0)
 
 	// Write the table header
-	_, err = fmt.Fprintf(t, "\n %s\t%s\t%s\t%s\t%s\t%s\n",
-		"№", "Имя", "Роль", "Телефон", "Email", "Ср. оценка")
+	_, err = fmt.Fprintf(t, "\n %s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+		"№", "Имя", "Роль", "Телефон", "Email", "Ср. оценка", "Последний вход")
 	if err != nil {
 		fmt.Println(err)
 	}
@@ -44,8 +44,60 @@ func Workers(services registry.Services, workers []models.Worker) error {
 		// Obtain the worker's average rating from completed orders
 		workersRate, _ := services.WorkerService.GetAverageOrderRate(&worker)
 
-		fmt.Fprintf(t, " %d\t%s\t%s\t%s\t%s\t%f\n",
-			i+1, worker.FullName(), worker.DisplayRole(), worker.PhoneNumber, worker.Email, workersRate)
+		lastLogin := "-"
+		if worker.LastLoginAt != nil {
+			lastLogin = worker.LastLoginAt.Format("2006-01-02 15:04")
+		}
+
+		fmt.Fprintf(t, " %d\t%s\t%s\t%s\t%s\t%f\t%s\n",
+			i+1, worker.FullName(), worker.DisplayRole(), worker.PhoneNumber, worker.Email, workersRate, lastLogin)
+	}
+
+	// Flush buffered output to standard output
+	err = t.Flush()
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// WorkersWithWorkload renders a slice of Worker entities together with the
+// number of their new and in-progress orders, helping to pick a worker who is
+// not too busy when assigning an order.
+//
+// Parameters:
+//   - services: Registry Services container providing access to business logic services
+//   - workers: A slice of models.Worker entities to display in the table
+//
+// Returns:
+//   - error: Any error that occurs during formatting or output operations
+func WorkersWithWorkload(services registry.Services, workers []models.Worker) error {
+	var err error
+
+	// Initialize tabwriter for formatted columnar output
+	t := new(tabwriter.Writer)
+	t.Init(os.Stdout, 1, 4, 2, ' ', 0)
+
+	// Write the table header
+	_, err = fmt.Fprintf(t, "\n %s\t%s\t%s\t%s\t%s\n",
+		"№", "Имя", "Телефон", "Ср. оценка", "Заказов в работе")
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	// Write each worker as a table row
+	for i, worker := range workers {
+		workersRate, _ := services.WorkerService.GetAverageOrderRate(&worker)
+
+		// Obtain the number of the worker's active orders
+		workload, err := services.WorkerService.GetWorkerWorkload(worker.ID)
+		if err != nil {
+			return err
+		}
+
+		fmt.Fprintf(t, " %d\t%s\t%s\t%f\t%d\n",
+			i+1, worker.FullName(), worker.PhoneNumber, workersRate, workload)
 	}
 
 	// Flush buffered output to standard output
@@ -32,3 +32,9 @@ var MenuStyle = lipgloss.NewStyle().
 var HeaderStyle = lipgloss.NewStyle().
 	Bold(true).
 	Foreground(lipgloss.Color("170"))
+
+// AlertStyle defines the styling for warnings that need immediate attention,
+// such as overdue orders. It uses bold red text (196).
+var AlertStyle = lipgloss.NewStyle().
+	Bold(true).
+	Foreground(lipgloss.Color("196"))
@@ -4,13 +4,13 @@
 package orderViews
 
 import (
+	"teamdev/cmd/cmdUtils"
 	"teamdev/internal/models"
 	"teamdev/internal/registry"
 )
 
-// CancelOrder updates an existing order to the cancelled status.
-// It uses the application's order service to change the status while
-// maintaining other order properties unchanged.
+// CancelOrder asks for the reason of the cancellation and cancels an existing order.
+// The reason is stored with the order and shown in its details.
 //
 // Parameters:
 //   - services: Service container providing access to business logic services
@@ -20,10 +20,15 @@ import (
 //   - error: Any error that occurred during the cancellation process,
 //     or nil if the operation was successful
 func CancelOrder(services registry.Services, order *models.Order) error {
-	_, err := services.OrderService.Update(order.ID, models.CancelledOrderStatus, order.Rate, order.WorkerID)
+	reason := cmdUtils.EndlessReadRow("Введите причину отмены")
+
+	err := services.OrderService.CancelOrder(order.ID, reason)
 	if err != nil {
 		return err
 	}
 
+	order.Status = models.CancelledOrderStatus
+	order.CancellationReason = reason
+
 	return nil
 }
@@ -15,21 +15,18 @@ import (
 // Parameters:
 //   - services: Service container providing access to business logic services
 //   - order: The order to be viewed and potentially modified
+//   - editor: The worker changing the status
 //
 // Returns:
 //   - error: Any error that occurred during task retrieval or status update,
 //     or nil if the operation was successful
-func OrderMenuChangeStatus(services registry.Services, order *models.Order) error {
-	tasks, err := services.OrderService.GetTasksInOrder(order.ID)
+func OrderMenuChangeStatus(services registry.Services, order *models.Order, editor *models.Worker) error {
+	details, err := services.OrderService.GetOrderDetails(order.ID)
 	if err != nil {
 		return err
 	}
 
-	fmt.Printf("\nУслуги в заказе:\n")
-	for i, task := range tasks {
-		taskAmount, _ := services.OrderService.GetTaskQuantity(order.ID, task.ID)
-		fmt.Printf("%d.\t%s\t%d\n", i+1, task.Name, taskAmount)
-	}
+	printOrderedTasks(details)
 
 	fmt.Printf("\n-----------\n1 -- изменить статус заказа\n0 -- выход\n\n")
 
@@ -45,7 +42,7 @@ func OrderMenuChangeStatus(services registry.Services, order *models.Order) erro
 		}
 
 		if action == 1 {
-			return changeStatus(services, order)
+			return changeStatus(services, order, editor)
 		}
 	}
 }
@@ -56,12 +53,16 @@ func OrderMenuChangeStatus(services registry.Services, order *models.Order) erro
 // Parameters:
 //   - services: Service container providing access to business logic services
 //   - order: The order whose status should be changed
+//   - editor: The worker changing the status
 //
 // Returns:
 //   - error: Any error that occurred during input processing or status update,
 //     or nil if the operation was successful
-func changeStatus(services registry.Services, order *models.Order) error {
-	fmt.Print("Введите новый статус заказа:\n2 -- в работе\n3 -- выполнен\n0 -- выход\n\n")
+func changeStatus(services registry.Services, order *models.Order, editor *models.Worker) error {
+	fmt.Printf("Текущий статус заказа: %s\n", order.DisplayStatus())
+	fmt.Printf("Введите новый статус заказа:\n%d -- %s\n%d -- %s\n0 -- выход\n\n",
+		models.InProgressOrderStatus, models.OrderStatuses[models.InProgressOrderStatus],
+		models.CompletedOrderStatus, models.OrderStatuses[models.CompletedOrderStatus])
 
 	var newStatus int
 	_, err := fmt.Scanf("%d", &newStatus)
@@ -73,12 +74,12 @@ func changeStatus(services registry.Services, order *models.Order) error {
 		return nil
 	}
 
-	if newStatus < 2 || newStatus > 3 {
+	if newStatus != models.InProgressOrderStatus && newStatus != models.CompletedOrderStatus {
 		fmt.Println("Неверный статус заказа")
 		return nil
 	}
 
-	_, err = services.OrderService.Update(order.ID, newStatus, order.Rate, order.WorkerID)
+	_, err = services.OrderService.Update(editor, order.ID, newStatus, order.Rate, order.WorkerID)
 	if err != nil {
 		return err
 	}
@@ -10,7 +10,7 @@ import (
 )
 
 // GetTasksInOrder displays all tasks included in a specific order along with their quantities.
-// It retrieves tasks from the order service and displays them in a formatted list.
+// It retrieves the order details from the order service and displays the tasks in a formatted list.
 //
 // Parameters:
 //   - services: Service container providing access to business logic services
@@ -20,16 +20,35 @@ import (
 //   - error: Any error that occurred during task retrieval,
 //     or nil if the operation was successful
 func GetTasksInOrder(services registry.Services, order *models.Order) error {
-	tasks, err := services.OrderService.GetTasksInOrder(order.ID)
+	details, err := services.OrderService.GetOrderDetails(order.ID)
 	if err != nil {
 		return err
 	}
 
+	printOrderedTasks(details)
+
+	return nil
+}
+
+// printOrderedTasks displays the tasks of an order with their quantities,
+// followed by the total price of the order. For a cancelled order the date
+// and reason of the cancellation are shown as well.
+//
+// Parameters:
+//   - details: Order details with the tasks to display
+func printOrderedTasks(details *models.OrderDetails) {
 	fmt.Printf("\nУслуги в заказе:\n")
-	for i, task := range tasks {
-		taskAmount, _ := services.OrderService.GetTaskQuantity(order.ID, task.ID)
-		fmt.Printf("%d.\t%s\t%d\n", i+1, task.Name, taskAmount)
+	for i, orderedTask := range details.Tasks {
+		fmt.Printf("%d.\t%s\t%d\n", i+1, orderedTask.Task.Name, orderedTask.Quantity)
 	}
+	fmt.Printf("Итого: %.2f\n", details.TotalPrice)
 
-	return nil
+	if details.Order.Status == models.CancelledOrderStatus {
+		if details.Order.CancelledAt != nil {
+			fmt.Printf("Отменен: %s\n", details.Order.CancelledAt.Format("2006-01-02"))
+		}
+		if details.Order.CancellationReason != "" {
+			fmt.Printf("Причина отмены: %s\n", details.Order.CancellationReason)
+		}
+	}
 }
@@ -4,12 +4,31 @@
 package orderViews
 
 import (
+	"errors"
 	"fmt"
 	"teamdev/cmd/modelTables"
+	"teamdev/cmd/views/stringConst"
 	"teamdev/internal/models"
 	"teamdev/internal/registry"
+	"teamdev/internal/services/service_errors"
 )
 
+// AssignmentErrorMessage converts an error returned when assigning a worker to
+// an order into the text shown to the manager.
+//
+// Parameters:
+//   - err: Error returned by the assignment
+//
+// Returns:
+//   - string: Message for known assignment errors, the error text otherwise
+func AssignmentErrorMessage(err error) string {
+	if errors.Is(err, service_errors.WorkerAtCapacity) {
+		return stringConst.WorkerAtCapacityMessage
+	}
+
+	return err.Error()
+}
+
 // GetUnassignedOrder displays details of an order that hasn't been assigned to a worker yet
 // and provides options for managing it. It shows all tasks in the order and presents
 // a menu for canceling the order or assigning a worker to it.
@@ -17,21 +36,18 @@ import (
 // Parameters:
 //   - services: Service container providing access to business logic services
 //   - order: The unassigned order to be viewed and potentially modified
+//   - manager: Manager viewing the order, left out of the assignment candidates
 //
 // Returns:
 //   - error: Any error that occurred during task retrieval or order modification,
 //     or nil if the operation was successful
-func GetUnassignedOrder(services registry.Services, order *models.Order) error {
-	tasks, err := services.OrderService.GetTasksInOrder(order.ID)
+func GetUnassignedOrder(services registry.Services, order *models.Order, manager *models.Worker) error {
+	details, err := services.OrderService.GetOrderDetails(order.ID)
 	if err != nil {
 		return err
 	}
 
-	fmt.Printf("\nУслуги в заказе:\n")
-	for i, task := range tasks {
-		taskAmount, _ := services.OrderService.GetTaskQuantity(order.ID, task.ID)
-		fmt.Printf("%d.\t%s\t%d\n", i+1, task.Name, taskAmount)
-	}
+	printOrderedTasks(details)
 
 	fmt.Printf("\n-----------\n1 -- отменить заказ\n2 -- назначит работника\n0 -- выход\n\n")
 
@@ -49,7 +65,7 @@ func GetUnassignedOrder(services registry.Services, order *models.Order) error {
 		if action == 1 {
 			return CancelOrder(services, order)
 		} else if action == 2 {
-			return assignWorker(services, order)
+			return assignWorker(services, order, manager)
 		}
 	}
 }
@@ -61,17 +77,18 @@ func GetUnassignedOrder(services registry.Services, order *models.Order) error {
 // Parameters:
 //   - services: Service container providing access to business logic services
 //   - order: The order to which a worker should be assigned
+//   - manager: Manager making the assignment, who is not offered as a candidate
 //
 // Returns:
 //   - error: Any error that occurred during worker retrieval or order update,
 //     or nil if the operation was successful
-func assignWorker(services registry.Services, order *models.Order) error {
-	workers, err := services.WorkerService.GetWorkersByRole(models.MasterRole)
+func assignWorker(services registry.Services, order *models.Order, manager *models.Worker) error {
+	workers, err := services.WorkerService.GetWorkersByRole(models.MasterRole, manager.ID)
 	if err != nil {
 		return err
 	}
 
-	err = modelTables.Workers(services, workers)
+	err = modelTables.WorkersWithWorkload(services, workers)
 	if err != nil {
 		return err
 	}
@@ -95,10 +112,9 @@ func assignWorker(services registry.Services, order *models.Order) error {
 			continue
 		}
 
-		order.WorkerID = workers[workerNumber-1].ID
-		_, err = services.OrderService.Update(order.ID, order.Status, order.Rate, order.WorkerID)
+		err = services.OrderService.AssignWorker(manager, order.ID, workers[workerNumber-1].ID)
 		if err != nil {
-			fmt.Println(err)
+			fmt.Println(AssignmentErrorMessage(err))
 		} else {
 			fmt.Println("Работник назначен")
 			return nil
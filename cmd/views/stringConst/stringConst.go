@@ -9,6 +9,15 @@ const EmailRequest = "Введите адрес электронной почт
 // PasswordRequest is displayed when the system needs the user's password
 const PasswordRequest = "Введите пароль"
 
+// OldPasswordRequest is displayed when the system needs the current password before changing it
+const OldPasswordRequest = "Введите текущий пароль"
+
+// NewPasswordRequest is displayed when the system needs the new password
+const NewPasswordRequest = "Введите новый пароль"
+
+// SecondFactorRequest is displayed when the system needs the second factor code
+const SecondFactorRequest = "Введите код подтверждения"
+
 // NameRequest is displayed when the system needs the user's first name
 const NameRequest = "Введите имя"
 
@@ -30,3 +39,13 @@ const PriceRequest = "Введите цену"
 
 // CategoryRequest is displayed when the system needs a category selection for a task
 const CategoryRequest = "Введите категорию"
+
+// FileNameRequest is displayed when the system needs a path of a file to write to
+const FileNameRequest = "Введите имя файла"
+
+// YearRequest is displayed when the system needs a calendar year
+const YearRequest = "Введите год"
+
+// WorkerAtCapacityMessage is displayed when a master cannot be assigned because
+// they already have the maximum number of active orders
+const WorkerAtCapacityMessage = "Мастер уже выполняет максимальное число заказов"
@@ -11,7 +11,7 @@ import (
 
 // Create handles the creation of a new cleaning task by collecting required information
 // from the user and persisting it through the task service. It prompts the user for
-// the task name, price, and category, then attempts to create the task in the database.
+// the task name and price and to choose a category, then attempts to create the task in the database.
 //
 // Parameters:
 //   - services: Service container providing access to business logic services
@@ -22,9 +22,12 @@ import (
 func Create(services registry.Services) error {
 	var name = utils.EndlessReadWord(stringConst.NameRequest)
 	var price = utils.EndlessReadFloat64(stringConst.PriceRequest)
-	var category = utils.EndlessReadInt(stringConst.CategoryRequest)
+	category, err := ChooseTaskCategory(services)
+	if err != nil {
+		return err
+	}
 
-	_, err := services.TaskService.Create(name, price, category)
+	_, err = services.TaskService.Create(name, price, category)
 	if err != nil {
 		println(err.Error())
 	}
@@ -5,14 +5,15 @@ package taskViews
 
 import (
 	"fmt"
+	utils "teamdev/cmd/cmdUtils"
 	"teamdev/cmd/modelTables"
 	"teamdev/internal/models"
 	"teamdev/internal/registry"
 )
 
 // AllTasks displays all available cleaning tasks in a tabular format.
-// It retrieves all tasks from the service layer and passes them to the
-// modelTables formatter for display.
+// It retrieves all tasks with their category names from the service layer
+// and passes them to the modelTables formatter for display.
 //
 // Parameters:
 //   - services: Service container providing access to business logic services
@@ -21,11 +22,31 @@ import (
 //   - error: Any error that occurred during task retrieval or display,
 //     or nil if the operation was successful
 func AllTasks(services registry.Services) error {
-	tasks, err := services.TaskService.GetAllTasks()
+	_, err := showAllTasks(services)
+	return err
+}
+
+// showAllTasks displays all available cleaning tasks with their category names
+// and returns the tasks in the order they were displayed.
+//
+// Parameters:
+//   - services: Service container providing access to business logic services
+//
+// Returns:
+//   - []models.Task: Displayed tasks in display order
+//   - error: Any error that occurred during task retrieval or display
+func showAllTasks(services registry.Services) ([]models.Task, error) {
+	tasksWithCategory, err := services.TaskService.GetAllTasksWithCategoryNames()
 	if err != nil {
-		return err
+		return nil, err
 	}
-	return modelTables.Tasks(tasks)
+
+	tasks := make([]models.Task, 0, len(tasksWithCategory))
+	for _, task := range tasksWithCategory {
+		tasks = append(tasks, task.Task)
+	}
+
+	return tasks, modelTables.TasksWithCategoryNames(tasksWithCategory)
 }
 
 // TasksByCategory retrieves tasks belonging to a specific category.
@@ -48,34 +69,66 @@ func TasksByCategory(service registry.Services, category int) ([]models.Task, er
 	return tasks, err
 }
 
-// ChooseTaskCategory displays a list of available task categories and
+// ChooseTaskCategory displays the categories stored in the system and
 // prompts the user to select one. It validates the selection and returns
 // the selected category ID.
 //
+// Parameters:
+//   - services: Service container providing access to business logic services
+//
 // Returns:
 //   - int: The ID of the selected task category
-func ChooseTaskCategory() int {
+//   - error: Any error that occurred during category retrieval
+func ChooseTaskCategory(services registry.Services) (int, error) {
+	categories, err := services.CategoryService.GetAll()
+	if err != nil {
+		return 0, err
+	}
+
 	fmt.Println("Выберите категорию задачи:")
 
-	for i, category := range models.TaskCategories {
-		fmt.Printf("%d. %s\n", i+1, category)
+	for i, category := range categories {
+		fmt.Printf("%d. %s\n", i+1, category.Name)
 	}
 
-	var category int
+	var number int
 	for {
-		fmt.Scanf("%d", &category)
-		//print(len(models.TaskCategories), category)
-		if category < 1 || category > len(models.TaskCategories) {
+		fmt.Scanf("%d", &number)
+		if number < 1 || number > len(categories) {
 			fmt.Println("Неверный номер категории")
 		} else {
-			return category
+			return categories[number-1].ID, nil
 		}
 	}
 }
 
+// SearchTasks prompts for a part of a task name and displays the tasks
+// whose names contain it.
+//
+// Parameters:
+//   - services: Service container providing access to business logic services
+//
+// Returns:
+//   - []models.Task: List of found tasks
+//   - error: Any error that occurred during task retrieval or display
+func SearchTasks(services registry.Services) ([]models.Task, error) {
+	query := utils.EndlessReadRow("Введите часть названия услуги")
+
+	tasks, err := services.TaskService.SearchTasksByName(query)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(tasks) == 0 {
+		fmt.Println("Услуги не найдены")
+	}
+
+	return tasks, modelTables.Tasks(tasks)
+}
+
 // Tasks provides a menu-driven interface for viewing tasks, allowing the user
-// to view all tasks or filter them by category. It returns the selected list
-// of tasks after successful display.
+// to view all tasks, filter them by category or search them by name. It returns
+// the selected list of tasks after successful display.
 //
 // Parameters:
 //   - services: Service container providing access to business logic services
@@ -85,7 +138,7 @@ func ChooseTaskCategory() int {
 //   - error: Any error that occurred during task retrieval or display,
 //     or nil if the operation was successful
 func Tasks(services registry.Services) ([]models.Task, error) {
-	const menu = "1 -- просмотреть все услуги \n2 -- смотреть по категории\nВыберите действие: "
+	const menu = "1 -- просмотреть все услуги \n2 -- смотреть по категории\n3 -- найти услугу по названию\nВыберите действие: "
 	var action int
 	var tasks []models.Task
 
@@ -99,12 +152,17 @@ func Tasks(services registry.Services) ([]models.Task, error) {
 
 		switch action {
 		case 1:
-			tasks, err = services.TaskService.GetAllTasks()
-			err = AllTasks(services)
+			tasks, err = showAllTasks(services)
 		case 2:
-			category := ChooseTaskCategory()
+			var category int
+			category, err = ChooseTaskCategory(services)
+			if err != nil {
+				break
+			}
 			tasks, err = TasksByCategory(services, category)
 			err = modelTables.Tasks(tasks)
+		case 3:
+			tasks, err = SearchTasks(services)
 		default:
 			fmt.Println("Такого пункта в меню нету")
 		}
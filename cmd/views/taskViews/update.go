@@ -26,7 +26,10 @@ import (
 func Update(services registry.Services, task models.Task) (*models.Task, error) {
 	var name = utils.EndlessReadRow(stringConst.NameRequest)
 	var price = utils.EndlessReadFloat64(stringConst.PriceRequest)
-	var category = utils.EndlessReadInt(stringConst.CategoryRequest)
+	category, err := ChooseTaskCategory(services)
+	if err != nil {
+		return nil, err
+	}
 
 	updatedTask, err := services.TaskService.Update(task.ID, category, name, price)
 
@@ -13,22 +13,6 @@ import (
 	"time"
 )
 
-// orderSumPrice calculates the total price of an order by summing the cost of all
-// ordered tasks, taking into account their quantities and individual prices.
-//
-// Parameters:
-//   - orderedTasks: Slice of ordered tasks with their quantities
-//
-// Returns:
-//   - float64: Total price of the order in the local currency
-func orderSumPrice(orderedTasks []models.OrderedTask) float64 {
-	var sum float64
-	for _, task := range orderedTasks {
-		sum += task.Task.PricePerSingle * float64(task.Quantity)
-	}
-	return sum
-}
-
 // addTaskToCart adds a task to the order cart, increasing quantity if the task
 // already exists or appending it as a new item if not. This function ensures
 // that duplicate tasks are consolidated with increased quantities rather than
@@ -54,7 +38,8 @@ func addTaskToCart(orderedTask models.OrderedTask, tasks []models.OrderedTask) [
 // createOrder guides users through the process of creating a new cleaning order.
 // It collects the delivery address, deadline, and allows selection of multiple
 // cleaning tasks with quantities. The function validates input at each step
-// and displays an order summary upon successful creation.
+// and displays an order summary upon successful creation. The cart is saved as
+// a draft after every change, and a saved draft can be resumed in a later session.
 //
 // Parameters:
 //   - service: Service container providing access to business logic services
@@ -66,13 +51,29 @@ func addTaskToCart(orderedTask models.OrderedTask, tasks []models.OrderedTask) [
 func createOrder(service registry.Services, user *models.User) error {
 	var yesno string
 	var address string
+	var orderedTasks []models.OrderedTask
 
-	fmt.Printf("Адрес заказа совпадает с Вашим?: (y/n) ")
-	fmt.Scanf("%s", &yesno)
-	if yesno == "n" {
-		address = utils.EndlessReadWord("Введите адрес заказа: ")
-	} else {
-		address = user.Address
+	draft, draftErr := service.OrderService.GetDraft(user.ID)
+	if draftErr == nil {
+		fmt.Printf("Найден незавершенный заказ от %s. Продолжить его?: (y/n) ", draft.UpdatedAt.Format("2006-01-02 15:04"))
+		fmt.Scanf("%s", &yesno)
+		if yesno == "y" {
+			address = draft.Address
+			orderedTasks = draft.Tasks
+			for i, task := range orderedTasks {
+				fmt.Printf("%d. %s %d\n", i+1, task.Task.Name, task.Quantity)
+			}
+		}
+	}
+
+	if address == "" {
+		fmt.Printf("Адрес заказа совпадает с Вашим?: (y/n) ")
+		fmt.Scanf("%s", &yesno)
+		if yesno == "n" {
+			address = utils.EndlessReadWord("Введите адрес заказа: ")
+		} else {
+			address = user.Address
+		}
 	}
 
 	var err error
@@ -88,7 +89,6 @@ func createOrder(service registry.Services, user *models.User) error {
 	}
 
 	var tasks []models.Task
-	var orderedTasks []models.OrderedTask
 
 	tasks, err = taskViews.Tasks(service)
 	if err != nil {
@@ -125,19 +125,47 @@ func createOrder(service registry.Services, user *models.User) error {
 		}
 
 		orderedTasks = addTaskToCart(models.OrderedTask{Task: &tasks[taskNum-1], Quantity: amount}, orderedTasks)
+		if draftErr = service.OrderService.SaveDraft(user.ID, address, orderedTasks); draftErr != nil {
+			fmt.Println("Не удалось сохранить черновик заказа")
+		}
 	}
 
-	_, err = service.OrderService.CreateOrder(user.ID, address, deadline, orderedTasks)
+	order, err := service.OrderService.CreateOrder(user.ID, address, deadline, orderedTasks)
 
 	if err == nil {
 		fmt.Println("Заказ успешно создан\nДобавлены следующие услуги:")
-		for i, task := range orderedTasks {
-			fmt.Printf("%d. %s %d\n", i+1, task.Task.Name, task.Quantity)
+		receipt, receiptErr := service.OrderService.BuildReceipt(order.Order.ID)
+		if receiptErr != nil {
+			for i, task := range order.Tasks {
+				fmt.Printf("%d. %s %d\n", i+1, task.Task.Name, task.Quantity)
+			}
+		} else {
+			printReceipt(receipt)
 		}
 		fmt.Printf("Адрес: %s\nКрайний срок: %s\n", address, deadline.Format(dateLayout))
-		fmt.Printf("Стоимость заказа: %.2f рублей\n", orderSumPrice(orderedTasks))
+		if receiptErr != nil {
+			fmt.Printf("Стоимость заказа: %.2f рублей\n", order.Order.QuotedTotal)
+		}
 		fmt.Printf("Ожидайте звонка оператора\n-------------------\n")
 	}
 
 	return err
 }
+
+// printReceipt prints the price breakdown of an order. Tax is printed as
+// a separate line only when the line items do not already include it.
+//
+// Parameters:
+//   - receipt: Price breakdown to print
+func printReceipt(receipt *models.Receipt) {
+	for i, line := range receipt.Lines {
+		fmt.Printf("%d. %s %d x %.2f = %.2f\n", i+1, line.TaskName, line.Quantity, line.UnitPrice, line.Total)
+	}
+
+	if receipt.TaxIncluded {
+		fmt.Printf("Стоимость заказа: %.2f рублей (в т.ч. налог %.2f)\n", receipt.GrandTotal, receipt.Tax)
+	} else {
+		fmt.Printf("Сумма: %.2f рублей\nНалог: %.2f рублей\n", receipt.Subtotal, receipt.Tax)
+		fmt.Printf("Стоимость заказа: %.2f рублей\n", receipt.GrandTotal)
+	}
+}
@@ -12,7 +12,8 @@ import (
 
 // Get retrieves and displays detailed information about the current user.
 // It fetches the user's profile from the database and presents the information
-// in a formatted output including email, name, surname, phone number, and address.
+// in a formatted output including email, name, surname, phone number, and address,
+// followed by a summary of the user's orders.
 // This function is typically used in the account management section of the application.
 //
 // Parameters:
@@ -30,6 +31,17 @@ func Get(service registry.Services, user *models.User) error {
 
 	fmt.Print("\nUser info:\n")
 	fmt.Printf("Email: %s\nИмя: %s\nФамилия: %s\nТелефон: %s\nАдрес: %s\n", userFromDB.Email, userFromDB.Name, userFromDB.Surname, userFromDB.PhoneNumber, userFromDB.Address)
+
+	stats, err := service.UserService.GetOrderStatistics(user.ID)
+	if err != nil {
+		return err
+	}
+
+	fmt.Print("\nЗаказы:\n")
+	fmt.Printf("Всего: %d\nВыполнено: %d\nОтменено: %d\nПотрачено: %.2f\n", stats.TotalOrders, stats.CompletedOrders, stats.CancelledOrders, stats.TotalSpent)
+	if stats.AverageRating > 0 {
+		fmt.Printf("Средняя оценка: %.1f\n", stats.AverageRating)
+	}
 	fmt.Print("----------------\n")
 	return nil
 }
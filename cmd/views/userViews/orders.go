@@ -6,10 +6,12 @@ package userViews
 
 import (
 	"fmt"
+	utils "teamdev/cmd/cmdUtils"
 	"teamdev/cmd/modelTables"
 	"teamdev/cmd/views/orderViews"
 	"teamdev/internal/models"
 	"teamdev/internal/registry"
+	"time"
 )
 
 // getOrderNumber reads an order number from the command line input.
@@ -42,24 +44,93 @@ func validateOrderNumber(orderNumber int, orders []models.Order) bool {
 	return orderNumber >= 0 && orderNumber < len(orders)
 }
 
-// getCompletedOrders displays a list of completed orders for the current user
-// and allows them to rate these orders. Only orders with status 3 (completed)
-// are displayed. The user can select an order by number to change its rating.
+// ordersPageSize is the number of orders shown on one page of the order history.
+const ordersPageSize = 10
+
+// getOrderHistory displays all orders of the current user, newest first,
+// one page at a time. The user can move between pages until they exit.
 //
 // Parameters:
 //   - services: Service container providing access to business logic services
-//   - user: Current authenticated user whose completed orders will be displayed
+//   - user: Current authenticated user whose orders will be displayed
 //
 // Returns:
 //   - error: Any error that occurred during the operation,
 //     or nil if the operation was successful
-func getCompletedOrders(services registry.Services, user *models.User) error {
-	params := map[string]string{
-		"status":  "3",
-		"user_id": user.ID.String(),
+func getOrderHistory(services registry.Services, user *models.User) error {
+	page := 0
+	for {
+		orders, total, err := services.OrderService.GetOrdersByUserIDPaged(user.ID, ordersPageSize, page*ordersPageSize)
+		if err != nil {
+			return err
+		}
+
+		if total == 0 {
+			fmt.Println("У Вас пока нет заказов")
+			return nil
+		}
+
+		err = modelTables.Orders(orders)
+		if err != nil {
+			return err
+		}
+
+		pages := (total + ordersPageSize - 1) / ordersPageSize
+		fmt.Printf("\n\nСтраница %d из %d\n"+
+			"-----------\n"+
+			"Введите 1, чтобы перейти на следующую страницу\n"+
+			"Введите 2, чтобы перейти на предыдущую страницу\n"+
+			"Введите 0, чтобы выйти\n\n", page+1, pages)
+
+		switch getOrderNumber() {
+		case 0:
+			return nil
+		case 1:
+			if page+1 < pages {
+				page++
+			} else {
+				fmt.Println("Это последняя страница")
+			}
+		case 2:
+			if page > 0 {
+				page--
+			} else {
+				fmt.Println("Это первая страница")
+			}
+		default:
+			fmt.Println("Неверный пункт меню")
+		}
 	}
+}
 
-	orders, err := services.OrderService.Filter(params)
+// userOrdersWithStatus retrieves the orders of a user that are in one of the given statuses.
+//
+// Parameters:
+//   - services: Service container providing access to business logic services
+//   - user: The user whose orders should be retrieved
+//   - statuses: Order statuses to keep
+//
+// Returns:
+//   - []models.Order: Matching orders of the user
+//   - error: Any error that occurred during retrieval
+func userOrdersWithStatus(services registry.Services, user *models.User, statuses ...models.OrderStatus) ([]models.Order, error) {
+	return services.OrderService.GetUserOrdersByStatus(user.ID, statuses...)
+}
+
+// getCompletedOrders displays a list of completed and cancelled orders for the current user
+// and allows them to rate the completed ones. Only orders with status 3 (completed)
+// or 4 (cancelled) are displayed. The user can select a completed order by number
+// to change its rating, or a cancelled one to see why it was cancelled.
+//
+// Parameters:
+//   - services: Service container providing access to business logic services
+//   - user: Current authenticated user whose completed orders will be displayed
+//
+// Returns:
+//   - error: Any error that occurred during the operation,
+//     or nil if the operation was successful
+func getCompletedOrders(services registry.Services, user *models.User) error {
+	orders, err := userOrdersWithStatus(services, user, models.CompletedOrderStatus, models.CancelledOrderStatus)
 
 	if err != nil {
 		return err
@@ -85,6 +156,14 @@ func getCompletedOrders(services registry.Services, user *models.User) error {
 			continue
 		}
 
+		if orders[orderNumber-1].Status == models.CancelledOrderStatus {
+			err = orderViews.GetTasksInOrder(services, &orders[orderNumber-1])
+			if err != nil {
+				fmt.Println(err)
+			}
+			continue
+		}
+
 		err = rateOrder(services, &orders[orderNumber-1])
 		if err != nil {
 			return err
@@ -93,9 +172,9 @@ func getCompletedOrders(services registry.Services, user *models.User) error {
 }
 
 // getOrdersInWork displays a list of orders currently in progress for the current user
-// and allows them to view order details or cancel orders. Only orders with status 1 or 2
+// and allows them to view order details, cancel or reschedule orders. Only orders with status 1 or 2
 // (in progress) are displayed. The user can select an order by number to view its tasks
-// or cancel it.
+// or cancel or reschedule it.
 //
 // Parameters:
 //   - services: Service container providing access to business logic services
@@ -105,12 +184,7 @@ func getCompletedOrders(services registry.Services, user *models.User) error {
 //   - error: Any error that occurred during the operation,
 //     or nil if the operation was successful
 func getOrdersInWork(services registry.Services, user *models.User) error {
-	params := map[string]string{
-		"status":  "1,2",
-		"user_id": user.ID.String(),
-	}
-
-	orders, err := services.OrderService.Filter(params)
+	orders, err := userOrdersWithStatus(services, user, models.NewOrderStatus, models.InProgressOrderStatus)
 
 	if err != nil {
 		return err
@@ -147,7 +221,8 @@ func getOrdersInWork(services registry.Services, user *models.User) error {
 		}
 
 		fmt.Printf("\n-----------\n" +
-			"Введите 1, чтобы отменить заказ\n\n" +
+			"Введите 1, чтобы отменить заказ\n" +
+			"Введите 2, чтобы перенести заказ\n\n" +
 			"Введите 0, чтобы выйти\n\n")
 
 		for {
@@ -169,8 +244,116 @@ func getOrdersInWork(services registry.Services, user *models.User) error {
 
 				return nil
 			}
+
+			if action == 2 {
+				err = rescheduleOrder(services, &orders[orderNumber-1])
+				if err != nil {
+					return err
+				}
+
+				return nil
+			}
+		}
+	}
+}
+
+// rescheduleOrder asks the user for a new deadline and address of an order
+// that is still in work. An empty address keeps the current one.
+//
+// Parameters:
+//   - services: Service container providing access to business logic services
+//   - order: The order to be rescheduled
+//
+// Returns:
+//   - error: Any error that occurred during the operation,
+//     or nil if the operation was successful
+func rescheduleOrder(services registry.Services, order *models.Order) error {
+	const dateLayout = "2006-01-02"
+	var deadline time.Time
+	var err error
+	for {
+		deadline, err = time.Parse(dateLayout, utils.EndlessReadWord("Введите новый крайний срок выполнения: (yyyy-mm-dd) "))
+		if err != nil {
+			fmt.Println("Неверный формат даты")
+		} else {
+			break
 		}
 	}
+
+	address := requestForChange("адрес", order.Address, false)
+
+	err = services.OrderService.Reschedule(order.ID, deadline, address)
+	if err != nil {
+		return err
+	}
+
+	order.Deadline = deadline
+	order.Address = address
+	fmt.Printf("Заказ перенесен на %s\n", deadline.Format(dateLayout))
+	return nil
+}
+
+// repeatOrder lists all orders of the current user and places a new order
+// with the address and tasks of the chosen one and a newly entered deadline.
+//
+// Parameters:
+//   - services: Service container providing access to business logic services
+//   - user: Current authenticated user whose orders can be repeated
+//
+// Returns:
+//   - error: Any error that occurred during the operation,
+//     or nil if the operation was successful
+func repeatOrder(services registry.Services, user *models.User) error {
+	orders, err := services.OrderService.GetAllOrdersByUserID(user.ID)
+	if err != nil {
+		return err
+	}
+
+	if len(orders) == 0 {
+		fmt.Println("У Вас пока нет заказов")
+		return nil
+	}
+
+	err = modelTables.Orders(orders)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("\n-----------\n" +
+		"Введите номер заказа, чтобы повторить его\n" +
+		"Введите 0, чтобы выйти\n\n")
+
+	var orderNumber int
+	for {
+		orderNumber = getOrderNumber()
+		if orderNumber == 0 {
+			return nil
+		}
+
+		if validateOrderNumber(orderNumber, orders) {
+			break
+		}
+		fmt.Println("Неверный номер заказа")
+	}
+
+	const dateLayout = "2006-01-02"
+	var deadline time.Time
+	for {
+		deadline, err = time.Parse(dateLayout, utils.EndlessReadWord("Введите крайний срок выполнения: (yyyy-mm-dd) "))
+		if err != nil {
+			fmt.Println("Неверный формат даты")
+		} else {
+			break
+		}
+	}
+
+	order, err := services.OrderService.CloneOrder(orders[orderNumber-1].ID, deadline)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Заказ успешно повторен, крайний срок: %s\n", order.Deadline.Format(dateLayout))
+	return nil
 }
 
 // rateOrder allows a user to provide a satisfaction rating for a completed order.
@@ -193,7 +376,7 @@ func rateOrder(services registry.Services, order *models.Order) error {
 	}
 
 	order.Rate = rate
-	_, err = services.OrderService.Update(order.ID, order.Status, rate, order.WorkerID)
+	_, err = services.OrderService.Update(nil, order.ID, order.Status, rate, order.WorkerID)
 	if err != nil {
 		return err
 	}
@@ -34,7 +34,7 @@ func registration(services registry.Services) (*models.User, error) {
 	var password = utils.EndlessReadWord(stringConst.PasswordRequest)
 	var name = utils.EndlessReadWord(stringConst.NameRequest)
 	var surname = utils.EndlessReadWord(stringConst.SurnameRequest)
-	var phoneNumber = utils.EndlessReadWord(stringConst.PhoneRequest)
+	var phoneNumber = utils.EndlessReadRow(stringConst.PhoneRequest)
 	var address = utils.EndlessReadRow(stringConst.AddressRequest)
 
 	user, err = services.UserService.Register(&models.User{
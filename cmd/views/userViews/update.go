@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"strings"
 	"teamdev/cmd/cmdUtils"
+	"teamdev/cmd/views/stringConst"
 	"teamdev/internal/models"
 	"teamdev/internal/registry"
 )
@@ -53,13 +54,12 @@ func Update(services registry.Services, user *models.User) error {
 	userFromDB, err := services.UserService.GetUserByID(user.ID)
 
 	var email = requestForChange("email", userFromDB.Email, true)
-	var password = requestForChange("пароль", userFromDB.Password, true)
 	var name = requestForChange("имя", userFromDB.Name, true)
 	var surname = requestForChange("фамилию", userFromDB.Surname, true)
 	var phoneNumber = requestForChange("номер телефона", userFromDB.PhoneNumber, true)
 	var address = requestForChange("адрес", userFromDB.Address, false)
 
-	_, err = services.UserService.Update(user.ID, name, surname, email, address, phoneNumber, password)
+	_, err = services.UserService.Update(user.ID, name, surname, email, address, phoneNumber)
 
 	if err != nil {
 		return err
@@ -67,3 +67,26 @@ func Update(services registry.Services, user *models.User) error {
 
 	return nil
 }
+
+// changePassword asks the user for the current and the new password
+// and replaces the password if the current one is correct.
+//
+// Parameters:
+//   - services: Service container providing access to business logic services
+//   - user: Current authenticated user whose password will be changed
+//
+// Returns:
+//   - error: Any error that occurred during the change, such as a wrong
+//     current password or an invalid new password
+func changePassword(services registry.Services, user *models.User) error {
+	var oldPassword = cmdUtils.EndlessReadWord(stringConst.OldPasswordRequest)
+	var newPassword = cmdUtils.EndlessReadWord(stringConst.NewPasswordRequest)
+
+	err := services.UserService.ChangePassword(user.ID, oldPassword, newPassword)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println("Пароль успешно изменен")
+	return nil
+}
@@ -82,12 +82,24 @@ func userMainMenu(services registry.Services, user *models.User) error {
 					return Update(services, user)
 				},
 			},
+			{
+				Name: "изменить пароль",
+				Handler: func() error {
+					return changePassword(services, user)
+				},
+			},
 			{
 				Name: "создать заказ",
 				Handler: func() error {
 					return createOrder(services, user)
 				},
 			},
+			{
+				Name: "посмотреть историю заказов",
+				Handler: func() error {
+					return getOrderHistory(services, user)
+				},
+			},
 			{
 				Name: "посмотреть законченные заказы",
 				Handler: func() error {
@@ -100,6 +112,12 @@ func userMainMenu(services registry.Services, user *models.User) error {
 					return getOrdersInWork(services, user)
 				},
 			},
+			{
+				Name: "повторить заказ",
+				Handler: func() error {
+					return repeatOrder(services, user)
+				},
+			},
 		},
 	)
 
@@ -6,38 +6,43 @@ package workerViews
 
 import (
 	"fmt"
+	"github.com/google/uuid"
 	"teamdev/cmd/modelTables"
+	"teamdev/cmd/views/orderViews"
 	"teamdev/internal/models"
 	"teamdev/internal/registry"
 )
 
 // assignWorker handles the process of assigning a worker to a cleaning order.
-// It retrieves available workers with the Master role, displays them in a table,
-// and prompts the administrator to select a worker to assign to the given order.
+// It retrieves available workers with the Master role, displays them in a table
+// together with their current workload, and prompts the administrator to select
+// a worker to assign to the given order or to pick the least busy one.
 // The function updates the order with the selected worker's ID.
 //
 // Parameters:
 //   - services: Service container providing access to business logic services,
 //     particularly WorkerService and OrderService
 //   - order: Order entity to which a worker will be assigned
+//   - manager: Manager making the assignment
 //
 // Returns:
 //   - error: Any error that occurred during the assignment process,
 //     such as database errors or display errors
-func assignWorker(services registry.Services, order *models.Order) error {
-	workers, err := services.WorkerService.GetWorkersByRole(models.MasterRole)
+func assignWorker(services registry.Services, order *models.Order, manager *models.Worker) error {
+	workers, err := services.WorkerService.GetWorkersByRole(models.MasterRole, uuid.Nil)
 	if err != nil {
 		return err
 	}
 
-	err = modelTables.Workers(services, workers)
+	err = modelTables.WorkersWithWorkload(services, workers)
 	if err != nil {
 		return err
 	}
 
 	var workerNumber int
 	for {
-		fmt.Print("Введите номер работника, чтобы назначить его на заказ или 0, чтобы выйти\n")
+		fmt.Print("Введите номер работника, чтобы назначить его на заказ,\n" +
+			"-1, чтобы назначить наименее загруженного мастера, или 0, чтобы выйти\n")
 
 		_, err = fmt.Scanf("%d", &workerNumber)
 		if err != nil {
@@ -49,15 +54,31 @@ func assignWorker(services registry.Services, order *models.Order) error {
 			return nil
 		}
 
+		if workerNumber == -1 {
+			worker, err := services.WorkerService.GetLeastBusyWorker()
+			if err != nil {
+				fmt.Println(err)
+				continue
+			}
+
+			err = services.OrderService.AssignWorker(manager, order.ID, worker.ID)
+			if err != nil {
+				fmt.Println(orderViews.AssignmentErrorMessage(err))
+			} else {
+				fmt.Printf("Назначен работник %s\n", worker.FullName())
+				return nil
+			}
+			continue
+		}
+
 		if workerNumber < 1 || workerNumber > len(workers) {
 			fmt.Println("Неверный номер")
 			continue
 		}
 
-		order.WorkerID = workers[workerNumber-1].ID
-		_, err = services.OrderService.Update(order.ID, order.Status, order.Rate, order.WorkerID)
+		err = services.OrderService.AssignWorker(manager, order.ID, workers[workerNumber-1].ID)
 		if err != nil {
-			fmt.Println(err)
+			fmt.Println(orderViews.AssignmentErrorMessage(err))
 		} else {
 			fmt.Println("Работник назначен")
 			return nil
@@ -20,11 +20,12 @@ import (
 // Parameters:
 //   - services: Service container providing access to business logic services,
 //     particularly the WorkerService for worker creation
+//   - manager: Manager creating the worker
 //
 // Returns:
 //   - error: Any error that occurred during the worker creation process,
 //     such as validation failures or database errors
-func create(services registry.Services) error {
+func create(services registry.Services, manager *models.Worker) error {
 	var worker *models.Worker
 	var err error
 
@@ -32,7 +33,7 @@ func create(services registry.Services) error {
 	var password = utils.EndlessReadWord(stringConst.PasswordRequest)
 	var name = utils.EndlessReadWord(stringConst.NameRequest)
 	var surname = utils.EndlessReadWord(stringConst.SurnameRequest)
-	var phoneNumber = utils.EndlessReadWord(stringConst.PhoneRequest)
+	var phoneNumber = utils.EndlessReadRow(stringConst.PhoneRequest)
 	var address = utils.EndlessReadRow(stringConst.AddressRequest)
 	var roleStr = utils.EndlessReadWord(stringConst.RoleRequest)
 	var role int
@@ -44,7 +45,7 @@ func create(services registry.Services) error {
 		role = models.MasterRole
 	}
 
-	worker, err = services.WorkerService.Create(&models.Worker{
+	worker, err = services.WorkerService.Create(manager, &models.Worker{
 		Email:       email,
 		Name:        name,
 		Surname:     surname,
@@ -23,7 +23,7 @@ import (
 //   - error: Any error that occurred during worker information retrieval,
 //     such as database errors or if the worker doesn't exist
 func Get(service registry.Services, worker *models.Worker) error {
-	workerFromDB, err := service.WorkerService.GetWorkerByID(worker.ID)
+	workerFromDB, err := service.WorkerService.GetWorkerByID(worker, worker.ID)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,44 @@
+// Package workerViews provides user interface functions for the PikaClean application
+// focused on worker-related operations including profile viewing, updating, and management.
+// This file contains functionality for checking the health of the system.
+package workerViews
+
+import (
+	"context"
+	"fmt"
+	"teamdev/internal/registry"
+	"time"
+)
+
+// healthCheckTimeout bounds how long the manager waits for the database to answer.
+const healthCheckTimeout = 5 * time.Second
+
+// systemHealth pings the database behind the services and reports whether it is
+// reachable together with the time the check took.
+//
+// Parameters:
+//   - services: Service container providing access to business logic services
+//
+// Returns:
+//   - error: Always nil; an unreachable database is reported to the manager, not returned
+func systemHealth(services registry.Services) error {
+	if services.HealthCheck == nil {
+		fmt.Println("Проверка состояния недоступна")
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), healthCheckTimeout)
+	defer cancel()
+
+	start := time.Now()
+	err := services.HealthCheck(ctx)
+	elapsed := time.Since(start)
+
+	if err != nil {
+		fmt.Printf("База данных: недоступна (%v)\n", err)
+		return nil
+	}
+
+	fmt.Printf("База данных: доступна, ответ за %d мс\n", elapsed.Milliseconds())
+	return nil
+}
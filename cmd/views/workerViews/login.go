@@ -13,6 +13,7 @@ import (
 
 // login handles the worker authentication process through the command line interface.
 // It prompts for email and password credentials, then validates them against the database.
+// When a second factor is required, the verification code is requested as well.
 // If successful, the authenticated worker entity is returned for use in subsequent operations.
 //
 // Parameters:
@@ -27,10 +28,18 @@ func login(services registry.Services) (*models.Worker, error) {
 	var email = utils.EndlessReadWord(stringConst.EmailRequest)
 	var password = utils.EndlessReadWord(stringConst.PasswordRequest)
 
-	worker, err := services.WorkerService.Login(email, password)
+	worker, secondFactorRequired, err := services.WorkerService.Login(email, password)
 	if err != nil {
 		return nil, err
 	}
 
+	if secondFactorRequired {
+		var code = utils.EndlessReadWord(stringConst.SecondFactorRequest)
+		err = services.WorkerService.VerifySecondFactor(worker.ID, code)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	return worker, nil
 }
@@ -6,10 +6,18 @@ package workerViews
 
 import (
 	"fmt"
+	"github.com/charmbracelet/bubbles/table"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/google/uuid"
+	"slices"
+	"strings"
+	utils "teamdev/cmd/cmdUtils"
 	"teamdev/cmd/modelTables"
+	"teamdev/cmd/ui"
 	"teamdev/cmd/views/orderViews"
 	"teamdev/internal/models"
 	"teamdev/internal/registry"
+	"time"
 )
 
 // getOrderNumber reads an order number from standard input.
@@ -47,10 +55,11 @@ func validateOrderNumber(orderNumber int, orders []models.Order) bool {
 //
 // Parameters:
 //   - services: Service container providing access to business logic services
+//   - manager: Manager assigning the orders
 //
 // Returns:
 //   - error: Any error that occurred during operation
-func unassignedOrders(services registry.Services) error {
+func unassignedOrders(services registry.Services, manager *models.Worker) error {
 	params := map[string]string{
 		"worker_id": "null",
 	}
@@ -81,23 +90,39 @@ func unassignedOrders(services registry.Services) error {
 		return nil
 	}
 
-	return orderViews.GetUnassignedOrder(services, &orders[orderNumber-1])
+	return orderViews.GetUnassignedOrder(services, &orders[orderNumber-1], manager)
 }
 
-// completedOrders displays all completed orders and allows
-// viewing their details.
+// autoAssignOrders assigns all unassigned new orders to the least loaded
+// masters and reports how many orders were assigned.
 //
 // Parameters:
 //   - services: Service container providing access to business logic services
+//   - manager: Manager running the assignment
 //
 // Returns:
 //   - error: Any error that occurred during operation
-func completedOrders(services registry.Services) error {
-	params := map[string]string{
-		"status": "3",
+func autoAssignOrders(services registry.Services, manager *models.Worker) error {
+	assignments, err := services.OrderService.AutoAssignUnassigned(manager)
+	if err != nil {
+		return err
 	}
 
-	orders, err := services.OrderService.Filter(params)
+	fmt.Printf("Назначено заказов: %d\n", len(assignments))
+	return nil
+}
+
+// completedOrders displays all completed orders and allows
+// viewing their details and reopening them.
+//
+// Parameters:
+//   - services: Service container providing access to business logic services
+//   - manager: Manager reopening the orders
+//
+// Returns:
+//   - error: Any error that occurred during operation
+func completedOrders(services registry.Services, manager *models.Worker) error {
+	orders, err := services.OrderService.GetOrdersByStatus(models.CompletedOrderStatus)
 
 	if err != nil {
 		return err
@@ -128,10 +153,96 @@ func completedOrders(services registry.Services) error {
 		fmt.Println(err)
 	}
 
-	fmt.Println("Нажмите Enter, чтобы продолжить")
-	fmt.Scanln()
+	fmt.Printf("\n-----------\n" +
+		"Введите 1, чтобы вернуть заказ в работу\n\n" +
+		"Введите 0, чтобы выйти\n\n")
 
-	return nil
+	for {
+		var action int
+		_, err = fmt.Scanf("%d", &action)
+		if err != nil {
+			fmt.Println(err)
+		}
+
+		if action == 0 {
+			return nil
+		}
+
+		if action == 1 {
+			_, err = services.OrderService.ReopenOrder(manager, orders[orderNumber-1].ID)
+			if err != nil {
+				return err
+			}
+
+			fmt.Println("Заказ возвращен в работу")
+			return nil
+		}
+	}
+}
+
+// overdueOrders displays new and in-progress orders whose deadline has passed,
+// the most overdue first, under a highlighted warning with their number.
+// The table is closed with q or esc.
+//
+// Parameters:
+//   - services: Service container providing access to business logic services
+//
+// Returns:
+//   - error: Any error that occurred during retrieval or display of the orders
+func overdueOrders(services registry.Services) error {
+	orders, err := services.OrderService.GetOverdueOrders()
+	if err != nil {
+		return err
+	}
+
+	if len(orders) == 0 {
+		fmt.Println("Просроченных заказов нет")
+		return nil
+	}
+
+	fmt.Println(ui.AlertStyle.Render(fmt.Sprintf("Просрочено заказов: %d", len(orders))))
+
+	columns := []table.Column{
+		{Title: "№", Width: 4},
+		{Title: "Срок", Width: 16},
+		{Title: "Просрочен на", Width: 14},
+		{Title: "Статус", Width: 14},
+		{Title: "Адрес", Width: 30},
+		{Title: "Стоимость", Width: 10},
+	}
+
+	now := time.Now()
+	rows := make([]table.Row, len(orders))
+	for i, order := range orders {
+		rows[i] = table.Row{
+			fmt.Sprintf("%d", i+1),
+			order.Deadline.Format("2006-01-02 15:04"),
+			formatOverdue(now.Sub(order.Deadline)),
+			order.DisplayStatus(),
+			order.Address,
+			fmt.Sprintf("%.2f", order.QuotedTotal),
+		}
+	}
+
+	_, err = tea.NewProgram(ui.NewTable(columns, rows)).Run()
+	return err
+}
+
+// formatOverdue renders how long ago a deadline passed in days and hours,
+// for example "2 д 5 ч".
+//
+// Parameters:
+//   - overdue: Time elapsed since the deadline
+//
+// Returns:
+//   - string: Human-readable duration
+func formatOverdue(overdue time.Duration) string {
+	hours := int(overdue.Hours())
+	if hours < 24 {
+		return fmt.Sprintf("%d ч", hours)
+	}
+
+	return fmt.Sprintf("%d д %d ч", hours/24, hours%24)
 }
 
 // inProgressOrders displays all in-progress orders (status 1 or 2)
@@ -139,15 +250,12 @@ func completedOrders(services registry.Services) error {
 //
 // Parameters:
 //   - services: Service container providing access to business logic services
+//   - manager: Manager viewing the orders
 //
 // Returns:
 //   - error: Any error that occurred during operation
-func inProgressOrders(services registry.Services) error {
-	params := map[string]string{
-		"status": "1,2",
-	}
-
-	orders, err := services.OrderService.Filter(params)
+func inProgressOrders(services registry.Services, manager *models.Worker) error {
+	orders, err := services.OrderService.GetOrdersByStatus(models.NewOrderStatus, models.InProgressOrderStatus)
 
 	if err != nil {
 		return err
@@ -178,8 +286,16 @@ func inProgressOrders(services registry.Services) error {
 		fmt.Println(err)
 	}
 
+	err = printOrderTags(services, orders[orderNumber-1].ID)
+	if err != nil {
+		fmt.Println(err)
+	}
+
 	fmt.Printf("\n-----------\n" +
-		"Введите 1, чтобы отменить заказ\n\n" +
+		"Введите 1, чтобы отменить заказ\n" +
+		"Введите 2, чтобы снять мастера с заказа\n" +
+		"Введите 3, чтобы добавить метку\n" +
+		"Введите 4, чтобы удалить метку\n\n" +
 		"Введите 0, чтобы выйти\n\n")
 
 	for {
@@ -201,7 +317,146 @@ func inProgressOrders(services registry.Services) error {
 
 			return nil
 		}
+
+		if action == 2 {
+			err = services.OrderService.UnassignWorker(manager, orders[orderNumber-1].ID)
+			if err != nil {
+				return err
+			}
+
+			fmt.Println("Мастер снят с заказа")
+			return nil
+		}
+
+		if action == 3 {
+			tag := utils.EndlessReadRow("Введите метку")
+			err = services.OrderService.AddTag(orders[orderNumber-1].ID, tag)
+			if err != nil {
+				return err
+			}
+
+			fmt.Println("Метка добавлена")
+			return nil
+		}
+
+		if action == 4 {
+			tag := utils.EndlessReadRow("Введите метку")
+			err = services.OrderService.RemoveTag(orders[orderNumber-1].ID, tag)
+			if err != nil {
+				return err
+			}
+
+			fmt.Println("Метка удалена")
+			return nil
+		}
+	}
+}
+
+// printOrderTags prints the tags of an order on one line.
+//
+// Parameters:
+//   - services: Service container providing access to business logic services
+//   - orderID: UUID of the order whose tags should be printed
+//
+// Returns:
+//   - error: Any error that occurred while retrieving the tags
+func printOrderTags(services registry.Services, orderID uuid.UUID) error {
+	tags, err := services.OrderService.GetTags(orderID)
+	if err != nil {
+		return err
+	}
+
+	if len(tags) == 0 {
+		fmt.Println("Метки: нет")
+		return nil
+	}
+
+	fmt.Println("Метки:", strings.Join(tags, ", "))
+	return nil
+}
+
+// ordersByTag displays the orders flagged with a tag entered by the manager
+// and allows viewing the contents of a found order.
+//
+// Parameters:
+//   - services: Service container providing access to business logic services
+//
+// Returns:
+//   - error: Any error that occurred during operation
+func ordersByTag(services registry.Services) error {
+	tag := utils.EndlessReadRow("Введите метку")
+
+	orders, err := services.OrderService.GetOrdersByTag(tag)
+	if err != nil {
+		return err
+	}
+
+	if len(orders) == 0 {
+		fmt.Println("Заказы не найдены")
+		return nil
+	}
+
+	err = modelTables.Orders(orders)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("\n-----------\n" +
+		"Введите номер заказа, чтобы просмотреть его содержимое\n" +
+		"Введите 0, чтобы выйти\n\n")
+
+	orderNumber := getOrderNumber()
+
+	if orderNumber == 0 {
+		return nil
+	}
+
+	if !validateOrderNumber(orderNumber, orders) {
+		fmt.Println("Неверный номер заказа")
+		return nil
+	}
+
+	err = orderViews.GetTasksInOrder(services, &orders[orderNumber-1])
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	err = printOrderTags(services, orders[orderNumber-1].ID)
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	fmt.Println("Нажмите Enter, чтобы продолжить")
+	fmt.Scanln()
+
+	return nil
+}
+
+// workerOrdersWithStatus retrieves the orders assigned to a worker
+// that are in one of the given statuses.
+//
+// Parameters:
+//   - services: Service container providing access to business logic services
+//   - worker: The worker whose orders should be retrieved
+//   - statuses: Order statuses to keep
+//
+// Returns:
+//   - []models.Order: Matching orders of the worker
+//   - error: Any error that occurred during retrieval
+func workerOrdersWithStatus(services registry.Services, worker *models.Worker, statuses ...models.OrderStatus) ([]models.Order, error) {
+	orders, err := services.OrderService.GetAllOrdersByWorkerID(worker.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	var filtered []models.Order
+	for _, order := range orders {
+		if slices.Contains(statuses, models.OrderStatus(order.Status)) {
+			filtered = append(filtered, order)
+		}
 	}
+
+	return filtered, nil
 }
 
 // completedOrdersByWorker displays all completed orders assigned
@@ -214,12 +469,7 @@ func inProgressOrders(services registry.Services) error {
 // Returns:
 //   - error: Any error that occurred during operation
 func completedOrdersByWorker(services registry.Services, worker *models.Worker) error {
-	params := map[string]string{
-		"status":    "3",
-		"worker_id": worker.ID.String(),
-	}
-
-	orders, err := services.OrderService.Filter(params)
+	orders, err := workerOrdersWithStatus(services, worker, models.CompletedOrderStatus)
 
 	if err != nil {
 		return err
@@ -266,17 +516,56 @@ func completedOrdersByWorker(services registry.Services, worker *models.Worker)
 // Returns:
 //   - error: Any error that occurred during operation
 func inProgressOrdersByWorker(services registry.Services, worker *models.Worker) error {
-	params := map[string]string{
-		"status":    "1,2",
-		"worker_id": worker.ID.String(),
+	orders, err := workerOrdersWithStatus(services, worker, models.NewOrderStatus, models.InProgressOrderStatus)
+
+	if err != nil {
+		return err
 	}
 
-	orders, err := services.OrderService.Filter(params)
+	err = modelTables.Orders(orders)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("\n-----------\n" +
+		"Введите номер заказа, чтобы просмотреть его содержимое\n" +
+		"Введите 0, чтобы выйти\n\n")
+
+	orderNumber := getOrderNumber()
 
+	if orderNumber == 0 {
+		return nil
+	}
+
+	if !validateOrderNumber(orderNumber, orders) {
+		fmt.Println("Неверный номер заказа")
+		return nil
+	}
+
+	return orderViews.OrderMenuChangeStatus(services, &orders[orderNumber-1], worker)
+}
+
+// searchOrders finds orders by an order ID or reference, a customer name
+// or part of an address, and allows viewing the contents of a found order.
+//
+// Parameters:
+//   - services: Service container providing access to business logic services
+//
+// Returns:
+//   - error: Any error that occurred during operation
+func searchOrders(services registry.Services) error {
+	query := utils.EndlessReadRow("Введите номер заказа, имя клиента или адрес")
+
+	orders, err := services.OrderService.Search(query)
 	if err != nil {
 		return err
 	}
 
+	if len(orders) == 0 {
+		fmt.Println("Заказы не найдены")
+		return nil
+	}
+
 	err = modelTables.Orders(orders)
 	if err != nil {
 		return err
@@ -297,5 +586,13 @@ func inProgressOrdersByWorker(services registry.Services, worker *models.Worker)
 		return nil
 	}
 
-	return orderViews.OrderMenuChangeStatus(services, &orders[orderNumber-1])
+	err = orderViews.GetTasksInOrder(services, &orders[orderNumber-1])
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	fmt.Println("Нажмите Enter, чтобы продолжить")
+	fmt.Scanln()
+
+	return nil
 }
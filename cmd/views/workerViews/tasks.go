@@ -4,12 +4,15 @@
 package workerViews
 
 import (
+	"errors"
 	"fmt"
+	utils "teamdev/cmd/cmdUtils"
 	"teamdev/cmd/menu"
 	"teamdev/cmd/modelTables"
 	"teamdev/cmd/views/taskViews"
 	"teamdev/internal/models"
 	"teamdev/internal/registry"
+	"teamdev/internal/services/service_errors"
 )
 
 // pickTaskForEditing displays a list of tasks and allows a manager to select
@@ -53,7 +56,7 @@ func pickTaskForEditing(services registry.Services, tasks []models.Task) error {
 
 // managerTasks displays a menu with options for task management operations
 // that are available to managers. It allows viewing all tasks, viewing tasks by category,
-// and creating new tasks.
+// creating new tasks and archiving a whole category.
 //
 // Parameters:
 //   - services: Service container providing access to business logic services
@@ -77,7 +80,10 @@ func managerTasks(services registry.Services) error {
 			{
 				Name: "Просмотреть по категории",
 				Handler: func() error {
-					category := taskViews.ChooseTaskCategory()
+					category, err := taskViews.ChooseTaskCategory(services)
+					if err != nil {
+						return err
+					}
 					tasks, err := taskViews.TasksByCategory(services, category)
 					if err != nil {
 						fmt.Println(err.Error())
@@ -91,6 +97,18 @@ func managerTasks(services registry.Services) error {
 					return taskViews.Create(services)
 				},
 			},
+			{
+				Name: "Архивировать категорию",
+				Handler: func() error {
+					return archiveCategory(services)
+				},
+			},
+			{
+				Name: "Изменить цены категории",
+				Handler: func() error {
+					return adjustCategoryPrices(services)
+				},
+			},
 		},
 	)
 
@@ -101,3 +119,65 @@ func managerTasks(services registry.Services) error {
 
 	return nil
 }
+
+// archiveCategory archives all tasks of a category chosen by the manager. When
+// some of the tasks are part of open orders, the manager is asked to confirm.
+//
+// Parameters:
+//   - services: Service container providing access to business logic services
+//
+// Returns:
+//   - error: Any error that occurred during operation
+func archiveCategory(services registry.Services) error {
+	category, err := taskViews.ChooseTaskCategory(services)
+	if err != nil {
+		return err
+	}
+
+	archived, err := services.TaskService.ArchiveCategory(category, false)
+	if errors.Is(err, service_errors.TasksInOpenOrders) {
+		fmt.Printf("Услуги категории есть в открытых заказах. Все равно архивировать?\n1 -- да\n0 -- нет\n")
+		var action int
+		_, err = fmt.Scanf("%d", &action)
+		if err != nil || action != 1 {
+			return nil
+		}
+		archived, err = services.TaskService.ArchiveCategory(category, true)
+	}
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Архивировано услуг: %d\n", archived)
+	return nil
+}
+
+// adjustCategoryPrices changes the prices of all tasks of a category chosen by
+// the manager by a percentage, for example 10 for a seasonal surcharge or -5 for a discount.
+// Discounts of 100 percent or more are asked again.
+//
+// Parameters:
+//   - services: Service container providing access to business logic services
+//
+// Returns:
+//   - error: Any error that occurred during operation
+func adjustCategoryPrices(services registry.Services) error {
+	category, err := taskViews.ChooseTaskCategory(services)
+	if err != nil {
+		return err
+	}
+	percent := utils.EndlessReadFloat64("Введите изменение цен в процентах (например, 10 или -5): ")
+	// a discount of 100% or more would make the prices zero or negative
+	for percent <= -100 {
+		fmt.Println("Скидка должна быть меньше 100%")
+		percent = utils.EndlessReadFloat64("Введите изменение цен в процентах (например, 10 или -5): ")
+	}
+
+	adjusted, err := services.TaskService.AdjustCategoryPrices(category, 1+percent/100)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Изменено цен: %d\n", adjusted)
+	return nil
+}
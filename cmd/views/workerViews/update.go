@@ -8,6 +8,7 @@ import (
 	"github.com/google/uuid"
 	"strings"
 	"teamdev/cmd/cmdUtils"
+	"teamdev/cmd/views/stringConst"
 	"teamdev/internal/models"
 	"teamdev/internal/registry"
 )
@@ -36,7 +37,7 @@ func requestForChange(fieldName string, fieldValue string, word bool) string {
 }
 
 // Update modifies a worker's profile information based on user input.
-// It allows changing various fields including email, password, name, surname,
+// It allows changing various fields including email, name, surname,
 // phone number, address, and role (if the editor has manager privileges).
 //
 // Parameters:
@@ -47,14 +48,13 @@ func requestForChange(fieldName string, fieldValue string, word bool) string {
 // Returns:
 //   - error: Any error that occurred during the update operation
 func Update(services registry.Services, workerID uuid.UUID, editor *models.Worker) error {
-	worker, err := services.WorkerService.GetWorkerByID(workerID)
+	worker, err := services.WorkerService.GetWorkerByID(editor, workerID)
 
 	if err != nil {
 		return err
 	}
 
 	var email = requestForChange("email", worker.Email, true)
-	var password = requestForChange("пароль", worker.Password, true)
 	var name = requestForChange("имя", worker.Name, true)
 	var surname = requestForChange("фамилию", worker.Surname, true)
 	var phoneNumber = requestForChange("номер телефона", worker.PhoneNumber, true)
@@ -75,7 +75,7 @@ func Update(services registry.Services, workerID uuid.UUID, editor *models.Worke
 		role = worker.Role
 	}
 
-	_, err = services.WorkerService.Update(worker.ID, name, surname, email, address, phoneNumber, role, password)
+	_, err = services.WorkerService.Update(editor, worker.ID, name, surname, email, address, phoneNumber, role)
 
 	if err != nil {
 		return err
@@ -83,3 +83,64 @@ func Update(services registry.Services, workerID uuid.UUID, editor *models.Worke
 
 	return nil
 }
+
+// changePassword asks the worker for the current and the new password
+// and replaces the password if the current one is correct.
+//
+// Parameters:
+//   - services: Service container providing access to business logic services
+//   - worker: The authenticated worker whose password will be changed
+//
+// Returns:
+//   - error: Any error that occurred during the change, such as a wrong
+//     current password or an invalid new password
+func changePassword(services registry.Services, worker *models.Worker) error {
+	var oldPassword = cmdUtils.EndlessReadWord(stringConst.OldPasswordRequest)
+	var newPassword = cmdUtils.EndlessReadWord(stringConst.NewPasswordRequest)
+
+	err := services.WorkerService.ChangePassword(worker.ID, oldPassword, newPassword)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println("Пароль успешно изменен")
+	return nil
+}
+
+// notificationSettings shows whether the worker receives notifications
+// and lets them turn notifications on or off.
+//
+// Parameters:
+//   - services: Service container providing access to business logic services
+//   - worker: The authenticated worker whose preference will be changed
+//
+// Returns:
+//   - error: Any error that occurred while reading or saving the preference
+func notificationSettings(services registry.Services, worker *models.Worker) error {
+	workerFromDB, err := services.WorkerService.GetWorkerByID(worker, worker.ID)
+	if err != nil {
+		return err
+	}
+
+	if workerFromDB.NotificationsOptOut {
+		fmt.Println("Уведомления отключены")
+	} else {
+		fmt.Println("Уведомления включены")
+	}
+
+	fmt.Printf("Получать уведомления?: (y/n) ")
+	var answer string
+	_, err = fmt.Scanf("%s", &answer)
+	if err != nil || (answer != "y" && answer != "n") {
+		fmt.Println("Настройка не изменена")
+		return nil
+	}
+
+	err = services.WorkerService.SetNotificationsOptOut(worker.ID, answer == "n")
+	if err != nil {
+		return err
+	}
+
+	fmt.Println("Настройка уведомлений сохранена")
+	return nil
+}
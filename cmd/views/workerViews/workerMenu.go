@@ -77,34 +77,94 @@ func managerMainMenu(services registry.Services, worker *models.Worker) error {
 					return Update(services, worker.ID, worker)
 				},
 			},
+			{
+				Name: "Изменить пароль",
+				Handler: func() error {
+					return changePassword(services, worker)
+				},
+			},
+			{
+				Name: "Настроить уведомления",
+				Handler: func() error {
+					return notificationSettings(services, worker)
+				},
+			},
 			{
 				Name: "Список работников",
 				Handler: func() error {
 					return getAllWorkers(services, worker)
 				},
 			},
+			{
+				Name: "Показатели мастеров",
+				Handler: func() error {
+					return performanceReport(services)
+				},
+			},
+			{
+				Name: "Популярные услуги",
+				Handler: func() error {
+					return popularTasksReport(services)
+				},
+			},
+			{
+				Name: "Выгрузить список работников в CSV",
+				Handler: func() error {
+					return exportWorkers(services)
+				},
+			},
+			{
+				Name: "Список клиентов",
+				Handler: func() error {
+					return getAllUsers(services)
+				},
+			},
 			{
 				Name: "Добавить работника",
 				Handler: func() error {
-					return create(services)
+					return create(services, worker)
 				},
 			},
 			{
 				Name: "Посмотреть неназначенные заказы",
 				Handler: func() error {
-					return unassignedOrders(services)
+					return unassignedOrders(services, worker)
+				},
+			},
+			{
+				Name: "Автоматически назначить неназначенные заказы",
+				Handler: func() error {
+					return autoAssignOrders(services, worker)
 				},
 			},
 			{
 				Name: "Посмотреть заказы в работе",
 				Handler: func() error {
-					return inProgressOrders(services)
+					return inProgressOrders(services, worker)
+				},
+			},
+			{
+				Name: "Просроченные заказы",
+				Handler: func() error {
+					return overdueOrders(services)
 				},
 			},
 			{
 				Name: "Посмотреть законченные заказы",
 				Handler: func() error {
-					return completedOrders(services)
+					return completedOrders(services, worker)
+				},
+			},
+			{
+				Name: "Поиск заказов",
+				Handler: func() error {
+					return searchOrders(services)
+				},
+			},
+			{
+				Name: "Заказы по метке",
+				Handler: func() error {
+					return ordersByTag(services)
 				},
 			},
 			{
@@ -113,6 +173,12 @@ func managerMainMenu(services registry.Services, worker *models.Worker) error {
 					return managerTasks(services)
 				},
 			},
+			{
+				Name: "Состояние системы",
+				Handler: func() error {
+					return systemHealth(services)
+				},
+			},
 		})
 
 	// Показать меню
@@ -150,6 +216,18 @@ func workerMainMenu(services registry.Services, worker *models.Worker) error {
 					return Update(services, worker.ID, worker)
 				},
 			},
+			{
+				Name: "Изменить пароль",
+				Handler: func() error {
+					return changePassword(services, worker)
+				},
+			},
+			{
+				Name: "Настроить уведомления",
+				Handler: func() error {
+					return notificationSettings(services, worker)
+				},
+			},
 			{
 				Name: "Посмотреть законченные заказы",
 				Handler: func() error {
@@ -162,6 +240,12 @@ func workerMainMenu(services registry.Services, worker *models.Worker) error {
 					return inProgressOrdersByWorker(services, worker)
 				},
 			},
+			{
+				Name: "Выгрузить доходы за год в CSV",
+				Handler: func() error {
+					return exportEarnings(services, worker)
+				},
+			},
 		},
 	)
 
@@ -5,14 +5,20 @@ package workerViews
 
 import (
 	"fmt"
+	"github.com/charmbracelet/bubbles/table"
+	tea "github.com/charmbracelet/bubbletea"
+	"os"
+	utils "teamdev/cmd/cmdUtils"
 	"teamdev/cmd/modelTables"
+	"teamdev/cmd/ui"
+	"teamdev/cmd/views/stringConst"
 	"teamdev/internal/models"
 	"teamdev/internal/registry"
 )
 
 // getAllWorkers displays a list of all workers in the system and allows a manager
-// to select a worker profile to update. It retrieves the worker list from the
-// service layer and displays it in a formatted table. Then it provides an interactive
+// to select a worker profile to update. It retrieves the team roster from the
+// service layer and displays it in a formatted table, managers first. Then it provides an interactive
 // menu for the manager to select workers for profile modification.
 //
 // Parameters:
@@ -22,11 +28,12 @@ import (
 // Returns:
 //   - error: Any error that occurred during retrieval or display of worker list
 func getAllWorkers(services registry.Services, manager *models.Worker) error {
-	workers, err := services.WorkerService.GetAllWorkers()
+	managers, masters, err := services.WorkerService.GetRoster()
 
 	if err != nil {
 		return err
 	}
+	workers := append(managers, masters...)
 
 	err = modelTables.Workers(services, workers)
 	if err != nil {
@@ -53,3 +60,162 @@ func getAllWorkers(services registry.Services, manager *models.Worker) error {
 		err = Update(services, workers[action-1].ID, manager)
 	}
 }
+
+// getAllUsers displays the list of all clients to a manager. Passwords are
+// never retrieved for this view.
+//
+// Parameters:
+//   - services: Service container providing access to business logic services
+//
+// Returns:
+//   - error: Any error that occurred during retrieval or display of the client list
+func getAllUsers(services registry.Services) error {
+	users, err := services.UserService.GetAllUsers()
+	if err != nil {
+		return err
+	}
+
+	if len(users) == 0 {
+		fmt.Println("Нет клиентов")
+		return nil
+	}
+
+	return modelTables.Users(users)
+}
+
+// exportWorkers writes the roster of all workers as a CSV file chosen by the manager.
+// Passwords are never included in the exported file.
+//
+// Parameters:
+//   - services: Service container providing access to business logic services
+//
+// Returns:
+//   - error: Any error that occurred during export or writing the file
+func exportWorkers(services registry.Services) error {
+	data, err := services.WorkerService.ExportCSV()
+	if err != nil {
+		return err
+	}
+
+	fileName := utils.EndlessReadWord(stringConst.FileNameRequest)
+	err = os.WriteFile(fileName, data, 0644)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Список работников сохранен в файл %s\n", fileName)
+	return nil
+}
+
+// exportEarnings writes the statement of orders the worker completed in a chosen
+// year, with the year total, as a CSV file chosen by the worker.
+//
+// Parameters:
+//   - services: Service container providing access to business logic services
+//   - worker: The authenticated worker whose earnings are exported
+//
+// Returns:
+//   - error: Any error that occurred during export or writing the file
+func exportEarnings(services registry.Services, worker *models.Worker) error {
+	year := utils.EndlessReadInt(stringConst.YearRequest)
+
+	data, err := services.WorkerService.ExportEarningsCSV(worker.ID, year)
+	if err != nil {
+		return err
+	}
+
+	fileName := utils.EndlessReadWord(stringConst.FileNameRequest)
+	err = os.WriteFile(fileName, data, 0644)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Доходы за %d год сохранены в файл %s\n", year, fileName)
+	return nil
+}
+
+// performanceReport shows the productivity of every master in an interactive
+// table, best rated first. The table is closed with q or esc.
+//
+// Parameters:
+//   - services: Service container providing access to business logic services
+//
+// Returns:
+//   - error: Any error that occurred during retrieval or display of the report
+func performanceReport(services registry.Services) error {
+	report, err := services.WorkerService.GetPerformanceReport()
+	if err != nil {
+		return err
+	}
+
+	if len(report) == 0 {
+		fmt.Println("Нет мастеров")
+		return nil
+	}
+
+	columns := []table.Column{
+		{Title: "№", Width: 4},
+		{Title: "Имя", Width: 24},
+		{Title: "Выполнено", Width: 10},
+		{Title: "Отменено", Width: 10},
+		{Title: "Ср. оценка", Width: 10},
+		{Title: "Выручка", Width: 12},
+	}
+
+	rows := make([]table.Row, len(report))
+	for i, row := range report {
+		rows[i] = table.Row{
+			fmt.Sprintf("%d", i+1),
+			row.Worker.FullName(),
+			fmt.Sprintf("%d", row.CompletedOrders),
+			fmt.Sprintf("%d", row.CancelledOrders),
+			fmt.Sprintf("%.2f", row.AverageRating),
+			fmt.Sprintf("%.2f", row.Revenue),
+		}
+	}
+
+	_, err = tea.NewProgram(ui.NewTable(columns, rows)).Run()
+	return err
+}
+
+// popularTasksReport asks for the number of services to show and displays the
+// most ordered ones in an interactive table. The table is closed with q or esc.
+//
+// Parameters:
+//   - services: Service container providing access to business logic services
+//
+// Returns:
+//   - error: Any error that occurred during retrieval or display of the report
+func popularTasksReport(services registry.Services) error {
+	limit := utils.EndlessReadInt("Введите количество услуг в отчете: ")
+
+	report, err := services.TaskService.GetMostOrderedTasks(limit)
+	if err != nil {
+		return err
+	}
+
+	if len(report) == 0 {
+		fmt.Println("Услуги еще не заказывали")
+		return nil
+	}
+
+	columns := []table.Column{
+		{Title: "№", Width: 4},
+		{Title: "Услуга", Width: 40},
+		{Title: "Количество", Width: 10},
+		{Title: "Заказов", Width: 10},
+	}
+
+	rows := make([]table.Row, len(report))
+	for i, row := range report {
+		rows[i] = table.Row{
+			fmt.Sprintf("%d", i+1),
+			row.Task.Name,
+			fmt.Sprintf("%d", row.TotalQuantity),
+			fmt.Sprintf("%d", row.OrderCount),
+		}
+	}
+
+	_, err = tea.NewProgram(ui.NewTable(columns, rows)).Run()
+	return err
+}
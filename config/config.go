@@ -3,7 +3,50 @@
 // and other sources, allowing the application to be configured for different environments.
 package config
 
-import "os"
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"teamdev/internal/models"
+	"teamdev/password_hash"
+)
+
+// defaultMaxActiveOrders is the number of active orders a master may hold at once
+// when MAX_ACTIVE_ORDERS is not set.
+const defaultMaxActiveOrders = 5
+
+// defaultDeadlineLeadHours is the minimum number of hours between placing an
+// order and its deadline when DEADLINE_LEAD_HOURS is not set.
+const defaultDeadlineLeadHours = 24
+
+// defaultDeadlineHorizonDays is the maximum number of days between placing an
+// order and its deadline when DEADLINE_HORIZON_DAYS is not set.
+const defaultDeadlineHorizonDays = 90
+
+// defaultLoginMaxFailures is the number of failed logins that locks an email
+// when LOGIN_MAX_FAILURES is not set.
+const defaultLoginMaxFailures = 5
+
+// defaultLoginLockoutMinutes is the window in which failed logins are counted and
+// the duration of the lock when LOGIN_LOCKOUT_MINUTES is not set.
+const defaultLoginLockoutMinutes = 15
+
+// defaultTokenExpiryMinutes is the lifetime of session tokens when
+// TOKEN_EXPIRY_MINUTES is not set.
+const defaultTokenExpiryMinutes = 60
+
+// defaultMaxOpenConns is the maximum number of open database connections
+// when POSTGRES_MAX_OPEN_CONNS is not set.
+const defaultMaxOpenConns = 10
+
+// defaultMaxIdleConns is the maximum number of idle database connections
+// when POSTGRES_MAX_IDLE_CONNS is not set.
+const defaultMaxIdleConns = 2
+
+// defaultConnMaxLifetimeMinutes is how long a database connection is reused
+// when POSTGRES_CONN_MAX_LIFETIME_MINUTES is not set.
+const defaultConnMaxLifetimeMinutes = 30
 
 // Config represents the main application configuration.
 // It contains all settings needed to run the PikaClean application,
@@ -14,8 +57,28 @@ type Config struct {
 	Port     string            `mapstructure:"port"`     // Server listen port
 	LogLevel string            `mapstructure:"loglevel"` // Logging verbosity level (debug, info)
 	LogFile  string            `mapstructure:"logfile"`  // Path to log file
-	Mode     string            `mapstructure:"mode"`     // Application mode (development, production)
+	Mode     string            `mapstructure:"mode"`     // Application mode (cmd, http)
 	DBType   string            `mapstructure:"dbtype"`   // Database type (postgres, etc.)
+
+	MaxActiveOrders int                 `mapstructure:"max_active_orders"` // Maximum number of active orders per master (0 means unlimited)
+	ExchangeRates   map[string]float64  `mapstructure:"exchange_rates"`    // Exchange rates by currency code (empty means prices are not converted)
+	TaxRate         float64             `mapstructure:"tax_rate"`          // Tax rate applied on receipts as a fraction
+	TaxInclusive    bool                `mapstructure:"tax_inclusive"`     // Whether receipts show tax-inclusive line items
+	RoundingMode    models.RoundingMode `mapstructure:"rounding_mode"`     // How prices are rounded to whole cents
+	RoundTaskPrices bool                `mapstructure:"round_task_prices"` // Whether task prices with more than two decimals are rounded instead of rejected
+
+	SecondFactorRequired bool `mapstructure:"second_factor_required"` // Whether worker login requires a second factor
+	PasswordHashCost     int  `mapstructure:"password_hash_cost"`     // bcrypt cost of new password hashes, weaker hashes are upgraded on login
+	LoginMaxFailures     int  `mapstructure:"login_max_failures"`     // Failed logins within the lockout window that lock an email (0 disables the lockout)
+	LoginLockoutMinutes  int  `mapstructure:"login_lockout_minutes"`  // Window in which failed logins are counted and how long the lock lasts
+
+	TokenSecret        string `mapstructure:"token_secret"`         // Secret signing session tokens (empty means no tokens are issued)
+	TokenExpiryMinutes int    `mapstructure:"token_expiry_minutes"` // Lifetime of session tokens
+
+	DeadlineLeadHours   int `mapstructure:"deadline_lead_hours"`   // Minimum hours between placing an order and its deadline (0 disables the check)
+	DeadlineHorizonDays int `mapstructure:"deadline_horizon_days"` // Maximum days between placing an order and its deadline (0 disables the check)
+
+	ServiceArea []string `mapstructure:"service_area"` // City names or postal code prefixes where orders are accepted (empty means everywhere)
 }
 
 // ParseConfig loads configuration values from environment variables into the Config struct.
@@ -23,7 +86,7 @@ type Config struct {
 // application suitable for containerized deployments and different environments.
 //
 // Returns:
-//   - error: Currently always returns nil, but could be extended to validate configuration
+//   - error: Error if a numeric setting cannot be parsed or is out of range
 func (c *Config) ParseConfig() error {
 	// Set database connection parameters from environment variables
 	c.DBFlags.Host = os.Getenv("POSTGRES_HOST")
@@ -38,5 +101,291 @@ func (c *Config) ParseConfig() error {
 	c.Mode = os.Getenv("MODE")
 	c.DBType = os.Getenv("DBTYPE")
 
+	maxOpenConns, err := intFromEnv("POSTGRES_MAX_OPEN_CONNS", defaultMaxOpenConns)
+	if err != nil {
+		return err
+	}
+	if maxOpenConns < 0 {
+		return fmt.Errorf("POSTGRES_MAX_OPEN_CONNS must not be negative")
+	}
+	c.DBFlags.MaxOpenConns = maxOpenConns
+
+	maxIdleConns, err := intFromEnv("POSTGRES_MAX_IDLE_CONNS", defaultMaxIdleConns)
+	if err != nil {
+		return err
+	}
+	if maxIdleConns < 0 {
+		return fmt.Errorf("POSTGRES_MAX_IDLE_CONNS must not be negative")
+	}
+	if maxOpenConns > 0 && maxIdleConns > maxOpenConns {
+		return fmt.Errorf("POSTGRES_MAX_IDLE_CONNS must not exceed POSTGRES_MAX_OPEN_CONNS")
+	}
+	c.DBFlags.MaxIdleConns = maxIdleConns
+
+	connMaxLifetimeMinutes, err := intFromEnv("POSTGRES_CONN_MAX_LIFETIME_MINUTES", defaultConnMaxLifetimeMinutes)
+	if err != nil {
+		return err
+	}
+	if connMaxLifetimeMinutes < 0 {
+		return fmt.Errorf("POSTGRES_CONN_MAX_LIFETIME_MINUTES must not be negative")
+	}
+	c.DBFlags.ConnMaxLifetimeMinutes = connMaxLifetimeMinutes
+
+	maxActiveOrders, err := intFromEnv("MAX_ACTIVE_ORDERS", defaultMaxActiveOrders)
+	if err != nil {
+		return err
+	}
+	if maxActiveOrders < 0 {
+		return fmt.Errorf("MAX_ACTIVE_ORDERS must not be negative")
+	}
+	c.MaxActiveOrders = maxActiveOrders
+
+	exchangeRates, err := ratesFromEnv("EXCHANGE_RATES")
+	if err != nil {
+		return err
+	}
+	c.ExchangeRates = exchangeRates
+
+	taxRate, err := floatFromEnv("TAX_RATE", 0)
+	if err != nil {
+		return err
+	}
+	if taxRate < 0 {
+		return fmt.Errorf("TAX_RATE must not be negative")
+	}
+	c.TaxRate = taxRate
+
+	taxInclusive, err := boolFromEnv("TAX_INCLUSIVE", false)
+	if err != nil {
+		return err
+	}
+	c.TaxInclusive = taxInclusive
+
+	roundingMode, err := roundingModeFromEnv("ROUNDING_MODE", models.RoundHalfUp)
+	if err != nil {
+		return err
+	}
+	c.RoundingMode = roundingMode
+
+	roundTaskPrices, err := boolFromEnv("ROUND_TASK_PRICES", false)
+	if err != nil {
+		return err
+	}
+	c.RoundTaskPrices = roundTaskPrices
+
+	secondFactorRequired, err := boolFromEnv("SECOND_FACTOR_REQUIRED", false)
+	if err != nil {
+		return err
+	}
+	c.SecondFactorRequired = secondFactorRequired
+
+	passwordHashCost, err := intFromEnv("PASSWORD_HASH_COST", password_hash.DefaultCost)
+	if err != nil {
+		return err
+	}
+	if passwordHashCost < password_hash.MinCost || passwordHashCost > password_hash.MaxCost {
+		return fmt.Errorf("PASSWORD_HASH_COST must be between %d and %d", password_hash.MinCost, password_hash.MaxCost)
+	}
+	c.PasswordHashCost = passwordHashCost
+
+	loginMaxFailures, err := intFromEnv("LOGIN_MAX_FAILURES", defaultLoginMaxFailures)
+	if err != nil {
+		return err
+	}
+	if loginMaxFailures < 0 {
+		return fmt.Errorf("LOGIN_MAX_FAILURES must not be negative")
+	}
+	c.LoginMaxFailures = loginMaxFailures
+
+	loginLockoutMinutes, err := intFromEnv("LOGIN_LOCKOUT_MINUTES", defaultLoginLockoutMinutes)
+	if err != nil {
+		return err
+	}
+	if loginLockoutMinutes < 0 {
+		return fmt.Errorf("LOGIN_LOCKOUT_MINUTES must not be negative")
+	}
+	c.LoginLockoutMinutes = loginLockoutMinutes
+
+	c.TokenSecret = os.Getenv("TOKEN_SECRET")
+
+	tokenExpiryMinutes, err := intFromEnv("TOKEN_EXPIRY_MINUTES", defaultTokenExpiryMinutes)
+	if err != nil {
+		return err
+	}
+	if tokenExpiryMinutes <= 0 {
+		return fmt.Errorf("TOKEN_EXPIRY_MINUTES must be positive")
+	}
+	c.TokenExpiryMinutes = tokenExpiryMinutes
+
+	deadlineLeadHours, err := intFromEnv("DEADLINE_LEAD_HOURS", defaultDeadlineLeadHours)
+	if err != nil {
+		return err
+	}
+	if deadlineLeadHours < 0 {
+		return fmt.Errorf("DEADLINE_LEAD_HOURS must not be negative")
+	}
+	c.DeadlineLeadHours = deadlineLeadHours
+
+	deadlineHorizonDays, err := intFromEnv("DEADLINE_HORIZON_DAYS", defaultDeadlineHorizonDays)
+	if err != nil {
+		return err
+	}
+	if deadlineHorizonDays < 0 {
+		return fmt.Errorf("DEADLINE_HORIZON_DAYS must not be negative")
+	}
+	c.DeadlineHorizonDays = deadlineHorizonDays
+
+	c.ServiceArea = listFromEnv("SERVICE_AREA")
+
 	return nil
 }
+
+// intFromEnv reads an integer setting from the environment.
+//
+// Parameters:
+//   - name: Name of the environment variable
+//   - defaultValue: Value used when the variable is not set
+//
+// Returns:
+//   - int: Parsed value or the default one
+//   - error: Error if the variable is set but is not an integer
+func intFromEnv(name string, defaultValue int) (int, error) {
+	value := os.Getenv(name)
+	if value == "" {
+		return defaultValue, nil
+	}
+
+	parsed, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, fmt.Errorf("%s must be an integer: %w", name, err)
+	}
+
+	return parsed, nil
+}
+
+// floatFromEnv reads a floating point setting from the environment.
+//
+// Parameters:
+//   - name: Name of the environment variable
+//   - defaultValue: Value used when the variable is not set
+//
+// Returns:
+//   - float64: Parsed value or the default one
+//   - error: Error if the variable is set but is not a number
+func floatFromEnv(name string, defaultValue float64) (float64, error) {
+	value := os.Getenv(name)
+	if value == "" {
+		return defaultValue, nil
+	}
+
+	parsed, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return 0, fmt.Errorf("%s must be a number: %w", name, err)
+	}
+
+	return parsed, nil
+}
+
+// boolFromEnv reads a boolean setting from the environment.
+//
+// Parameters:
+//   - name: Name of the environment variable
+//   - defaultValue: Value used when the variable is not set
+//
+// Returns:
+//   - bool: Parsed value or the default one
+//   - error: Error if the variable is set but is not a boolean
+func boolFromEnv(name string, defaultValue bool) (bool, error) {
+	value := os.Getenv(name)
+	if value == "" {
+		return defaultValue, nil
+	}
+
+	parsed, err := strconv.ParseBool(value)
+	if err != nil {
+		return false, fmt.Errorf("%s must be a boolean: %w", name, err)
+	}
+
+	return parsed, nil
+}
+
+// ratesFromEnv reads currency exchange rates from the environment. The value is a
+// comma-separated list of CODE=RATE pairs, for example "USD=0.011,EUR=0.010".
+//
+// Parameters:
+//   - name: Name of the environment variable
+//
+// Returns:
+//   - map[string]float64: Parsed rates, empty if the variable is not set
+//   - error: Error if a pair is malformed or a rate is not a positive number
+func ratesFromEnv(name string) (map[string]float64, error) {
+	rates := make(map[string]float64)
+
+	value := os.Getenv(name)
+	if value == "" {
+		return rates, nil
+	}
+
+	for _, pair := range strings.Split(value, ",") {
+		code, rawRate, found := strings.Cut(strings.TrimSpace(pair), "=")
+		if !found || code == "" {
+			return nil, fmt.Errorf("%s must contain CODE=RATE pairs", name)
+		}
+
+		rate, err := strconv.ParseFloat(rawRate, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%s has an invalid rate for %s: %w", name, code, err)
+		}
+		if rate <= 0 {
+			return nil, fmt.Errorf("%s must contain positive rates", name)
+		}
+
+		rates[strings.ToUpper(code)] = rate
+	}
+
+	return rates, nil
+}
+
+// listFromEnv reads a comma-separated list setting from the environment.
+// Items are trimmed and empty items are skipped.
+//
+// Parameters:
+//   - name: Name of the environment variable, e.g. "Москва,Химки,141"
+//
+// Returns:
+//   - []string: Items of the list, empty if the variable is not set
+func listFromEnv(name string) []string {
+	var items []string
+	for _, item := range strings.Split(os.Getenv(name), ",") {
+		item = strings.TrimSpace(item)
+		if item != "" {
+			items = append(items, item)
+		}
+	}
+
+	return items
+}
+
+// roundingModeFromEnv reads a rounding mode setting from the environment.
+// Accepted values are the keys of models.RoundingModes.
+//
+// Parameters:
+//   - name: Name of the environment variable
+//   - defaultValue: Value used when the variable is not set
+//
+// Returns:
+//   - models.RoundingMode: Parsed value or the default one
+//   - error: Error if the variable is set but names no known mode
+func roundingModeFromEnv(name string, defaultValue models.RoundingMode) (models.RoundingMode, error) {
+	value := strings.TrimSpace(os.Getenv(name))
+	if value == "" {
+		return defaultValue, nil
+	}
+
+	mode, ok := models.RoundingModes[strings.ToLower(value)]
+	if !ok {
+		return 0, fmt.Errorf("%s must be one of half_up, half_even, up, down", name)
+	}
+
+	return mode, nil
+}
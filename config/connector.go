@@ -22,11 +22,15 @@ type DbConnectionFlags struct {
 	Password string `mapstructure:"password"` // Password for database authentication
 	Port     string `mapstructure:"port"`     // Port number the database server is listening on
 	DBName   string `mapstructure:"dbname"`   // Name of the database to connect to
+
+	MaxOpenConns           int `mapstructure:"max_open_conns"`            // Maximum number of open connections in the pool (0 means unlimited)
+	MaxIdleConns           int `mapstructure:"max_idle_conns"`            // Maximum number of idle connections kept in the pool
+	ConnMaxLifetimeMinutes int `mapstructure:"conn_max_lifetime_minutes"` // Minutes after which a connection is recycled (0 means never)
 }
 
 // InitPostgresDB establishes a connection to a PostgreSQL database using the
-// parameters provided in DbConnectionFlags. It also configures the connection
-// pool with appropriate settings for the application's needs.
+// parameters provided in DbConnectionFlags and verifies it with a ping.
+// Pool limits are applied by the postgres repository package.
 //
 // Parameters:
 //   - logger: Logger for recording connection events and errors
@@ -54,8 +58,6 @@ func (p *DbConnectionFlags) InitPostgresDB(logger *log.Logger) (*sql.DB, error)
 		return nil, err
 	}
 
-	db.SetMaxOpenConns(10)
-
 	logger.Info("POSTGRES! Successfully init postgreSQL")
 	return db, nil
 }
@@ -17,28 +17,49 @@ create table public.users
 -- drop table if exists workers cascade;
 create table public.workers
 (
-    id           uuid primary key default uuid_generate_v4(),
-    name         text,
-    surname      text,
-    email        text unique,
-    phone_number text,
-    address      text,
-    password     text,
-    role         int
+    id                    uuid primary key default uuid_generate_v4(),
+    name                  text,
+    surname               text,
+    email                 text unique,
+    phone_number          text,
+    address               text,
+    password              text,
+    role                  int,
+    last_login_at         timestamp default null,
+    notifications_opt_out boolean not null default false
+);
+
+
+-- drop table if exists worker_availability cascade;
+create table public.worker_availability
+(
+    id        serial primary key,
+    worker_id uuid references workers (id) on delete cascade,
+    from_time timestamp not null,
+    to_time   timestamp not null,
+    available boolean not null default true
 );
 
+create index worker_availability_worker_id_from_time_idx on public.worker_availability (worker_id, from_time);
+
 
 -- drop table if exists orders cascade;
 create table public.orders
 (
-    id            uuid primary key                                default uuid_generate_v4(),
-    worker_id     uuid references workers (id) on delete set null default null,
-    user_id       uuid references users (id) on delete set null   default null,
-    status        int2                                            default 0,
-    address       text,
-    deadline      timestamp,
-    creation_date timestamp                                       default now(),
-    rate          int2                                            default 0
+    id                  uuid primary key                                default uuid_generate_v4(),
+    worker_id           uuid references workers (id) on delete set null default null,
+    user_id             uuid references users (id) on delete set null   default null,
+    status              int2                                            default 0,
+    address             text,
+    deadline            timestamp,
+    creation_date       timestamp                                       default now(),
+    rate                int2                                            default 0,
+    quoted_total        float8                                          default 0,
+    assigned_at         timestamp                                       default null,
+    completed_at        timestamp                                       default null,
+    cancelled_at        timestamp                                       default null,
+    cancellation_reason text                                            not null default '',
+    deleted_at          timestamp                                       default null
 );
 
 
@@ -48,16 +69,52 @@ create table public.tasks
     id               uuid primary key default uuid_generate_v4(),
     name             text,
     price_per_single float8,
-    category         int2
+    category         int2,
+    archived         boolean default false,
+    updated_at       timestamp default now()
+);
+
+-- drop table if exists task_price_tiers cascade;
+create table public.task_price_tiers
+(
+    task_id      uuid references tasks (id) on delete cascade,
+    min_quantity int2,
+    price        float8,
+    primary key (task_id, min_quantity)
 );
 
 -- drop table if exists order_contains_tasks cascade;
 create table public.order_contains_tasks
 (
-    id       uuid primary key default uuid_generate_v4(),
-    order_id uuid references orders (id),
+    id             uuid primary key default uuid_generate_v4(),
+    order_id       uuid references orders (id),
+    task_id        uuid references tasks (id),
+    quantity       int2             default 1,
+    price_at_order float8           default null
+);
+
+-- drop table if exists order_tags cascade;
+create table public.order_tags
+(
+    order_id uuid references orders (id) on delete cascade,
+    tag      text,
+    primary key (order_id, tag)
+);
+
+-- drop table if exists draft_orders cascade;
+create table public.draft_orders
+(
+    user_id    uuid primary key references users (id) on delete cascade,
+    address    text,
+    updated_at timestamp default now()
+);
+
+-- drop table if exists draft_order_contains_tasks cascade;
+create table public.draft_order_contains_tasks
+(
+    user_id  uuid references draft_orders (user_id) on delete cascade,
     task_id  uuid references tasks (id),
-    quantity int2             default 1
+    quantity int2 default 1
 );
 
 -- drop table if exists categories cascade;
@@ -66,9 +123,6 @@ CREATE TABLE IF NOT EXISTS public.categories
     id   SERIAL UNIQUE,
     name VARCHAR
 );
-SELECT setval(pg_get_serial_sequence('categories', 'id'), COALESCE(MAX(id), 1) + 1, false)
-FROM public.categories;
-
 INSERT INTO public.categories (id, name)
 VALUES (1, 'Мытье окон'),
        (2, 'Генеральная уборка'),
@@ -78,6 +132,8 @@ VALUES (1, 'Мытье окон'),
        (6, 'Поддерживающая уборка'),
        (7, 'Глубинная Эко Чистка'),
        (8, 'Уход за твердыми полами');
+SELECT setval(pg_get_serial_sequence('categories', 'id'), COALESCE(MAX(id), 1) + 1, false)
+FROM public.categories;
 
 --2. insert into
 INSERT INTO public.tasks (id, name, price_per_single, category) VALUES
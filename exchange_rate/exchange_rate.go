@@ -0,0 +1,51 @@
+// Package exchange_rate provides currency exchange rates used to display
+// task prices in currencies other than the one they are stored in.
+package exchange_rate
+
+import (
+	"errors"
+	"strings"
+)
+
+// UnknownCurrency indicates that no exchange rate is known for the requested currency.
+var UnknownCurrency = errors.New("unknown currency")
+
+// identityRateProvider implements the RateProvider interface by returning
+// a rate of one for every currency. It is used when no rates are configured.
+type identityRateProvider struct {
+}
+
+// NewIdentityRateProvider creates a RateProvider that leaves prices unchanged.
+func NewIdentityRateProvider() RateProvider {
+	return &identityRateProvider{}
+}
+
+// GetRate always returns a rate of one.
+func (i *identityRateProvider) GetRate(code string) (float64, error) {
+	return 1, nil
+}
+
+// staticRateProvider implements the RateProvider interface using a fixed table
+// of rates keyed by upper-case currency code.
+type staticRateProvider struct {
+	rates map[string]float64
+}
+
+// NewStaticRateProvider creates a RateProvider backed by the given rates.
+// Currency codes are matched case-insensitively.
+func NewStaticRateProvider(rates map[string]float64) RateProvider {
+	normalized := make(map[string]float64, len(rates))
+	for code, rate := range rates {
+		normalized[strings.ToUpper(code)] = rate
+	}
+	return &staticRateProvider{rates: normalized}
+}
+
+// GetRate returns the configured rate for the currency or UnknownCurrency.
+func (s *staticRateProvider) GetRate(code string) (float64, error) {
+	rate, ok := s.rates[strings.ToUpper(code)]
+	if !ok {
+		return 0, UnknownCurrency
+	}
+	return rate, nil
+}
@@ -0,0 +1,8 @@
+package exchange_rate
+
+// RateProvider defines the interface for obtaining currency exchange rates.
+type RateProvider interface {
+	// GetRate returns the number of units of the target currency per one unit
+	// of the base price currency. Returns UnknownCurrency if no rate is known.
+	GetRate(code string) (float64, error)
+}
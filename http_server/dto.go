@@ -0,0 +1,133 @@
+// Package http_server provides the HTTP/REST front end of the PikaClean application.
+// This file contains the JSON documents accepted and returned by the endpoints.
+package http_server
+
+import (
+	"teamdev/internal/models"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// errorResponse is returned by every endpoint that fails.
+type errorResponse struct {
+	Error string `json:"error"` // Description of the failure
+}
+
+// loginRequest is the body of POST /login.
+type loginRequest struct {
+	Email    string `json:"email"`
+	Password string `json:"password"`
+}
+
+// loginResponse is the result of a successful POST /login.
+type loginResponse struct {
+	User  userResponse `json:"user"`
+	Token string       `json:"token,omitempty"` // Session token, omitted if tokens are not configured
+}
+
+// userResponse is a client without the password hash.
+type userResponse struct {
+	ID          uuid.UUID `json:"id"`
+	Name        string    `json:"name"`
+	Surname     string    `json:"surname"`
+	Address     string    `json:"address"`
+	PhoneNumber string    `json:"phone_number"`
+	Email       string    `json:"email"`
+}
+
+// newUserResponse converts the password-free view of a client to its JSON document.
+func newUserResponse(user models.UserPublic) userResponse {
+	return userResponse{
+		ID:          user.ID,
+		Name:        user.Name,
+		Surname:     user.Surname,
+		Address:     user.Address,
+		PhoneNumber: user.PhoneNumber,
+		Email:       user.Email,
+	}
+}
+
+// taskResponse is a task of the catalog.
+type taskResponse struct {
+	ID             uuid.UUID `json:"id"`
+	Name           string    `json:"name"`
+	PricePerSingle float64   `json:"price_per_single"`
+	Category       int       `json:"category"`
+}
+
+// newTaskResponse converts a task to its JSON document.
+func newTaskResponse(task models.Task) taskResponse {
+	return taskResponse{
+		ID:             task.ID,
+		Name:           task.Name,
+		PricePerSingle: task.PricePerSingle,
+		Category:       task.Category,
+	}
+}
+
+// orderedTaskDocument is a task of an order with its quantity, used both in
+// requests and in responses.
+type orderedTaskDocument struct {
+	TaskID   uuid.UUID `json:"task_id"`
+	Quantity int       `json:"quantity"`
+}
+
+// createOrderRequest is the body of POST /orders. The client placing the order
+// is taken from the session token.
+type createOrderRequest struct {
+	Address  string                `json:"address"`
+	Deadline time.Time             `json:"deadline"`
+	Tasks    []orderedTaskDocument `json:"tasks"`
+}
+
+// orderedTasks converts the tasks of the request to the form expected by the order service.
+func (r createOrderRequest) orderedTasks() []models.OrderedTask {
+	orderedTasks := make([]models.OrderedTask, len(r.Tasks))
+	for i, task := range r.Tasks {
+		orderedTasks[i] = models.OrderedTask{Task: &models.Task{ID: task.TaskID}, Quantity: task.Quantity}
+	}
+
+	return orderedTasks
+}
+
+// orderResponse is an order, optionally with its tasks.
+type orderResponse struct {
+	ID           uuid.UUID             `json:"id"`
+	UserID       uuid.UUID             `json:"user_id"`
+	WorkerID     uuid.UUID             `json:"worker_id"`
+	Status       int                   `json:"status"`
+	StatusName   string                `json:"status_name"`
+	Address      string                `json:"address"`
+	CreationDate time.Time             `json:"creation_date"`
+	Deadline     time.Time             `json:"deadline"`
+	Rate         int                   `json:"rate"`
+	QuotedTotal  float64               `json:"quoted_total"`
+	Tasks        []orderedTaskDocument `json:"tasks,omitempty"`
+}
+
+// newOrderResponse converts an order and its tasks to its JSON document.
+// Tasks are omitted from the document when none are given.
+func newOrderResponse(order models.Order, tasks []models.OrderedTask) orderResponse {
+	response := orderResponse{
+		ID:           order.ID,
+		UserID:       order.UserID,
+		WorkerID:     order.WorkerID,
+		Status:       order.Status,
+		StatusName:   order.DisplayStatus(),
+		Address:      order.Address,
+		CreationDate: order.CreationDate,
+		Deadline:     order.Deadline,
+		Rate:         order.Rate,
+		QuotedTotal:  order.QuotedTotal,
+	}
+
+	for _, task := range tasks {
+		if task.Task == nil {
+			continue
+		}
+		response.Tasks = append(response.Tasks, orderedTaskDocument{TaskID: task.Task.ID, Quantity: task.Quantity})
+	}
+
+	return response
+}
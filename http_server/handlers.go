@@ -0,0 +1,124 @@
+// Package http_server provides the HTTP/REST front end of the PikaClean application.
+// This file contains the handlers of the endpoints.
+package http_server
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"teamdev/internal/services/service_errors"
+
+	"github.com/google/uuid"
+)
+
+// login authenticates a client by email and password.
+//
+// POST /login with a loginRequest body. Responds with the client and, if tokens
+// are configured, a session token on success, 401 for wrong credentials and 429
+// while the email is locked out.
+func (s *Server) login(w http.ResponseWriter, r *http.Request) {
+	var request loginRequest
+	status, err := decodeJSON(r, &request)
+	if err != nil {
+		s.writeError(w, status, err)
+		return
+	}
+
+	user, token, err := s.userService.LoginWithToken(request.Email, request.Password)
+	if errors.Is(err, service_errors.LoginLocked) {
+		s.writeError(w, http.StatusTooManyRequests, err)
+		return
+	} else if err != nil {
+		s.logger.Info("HTTP: Login failed", "email", request.Email, "error", err)
+		s.writeError(w, http.StatusUnauthorized, errors.New("wrong email or password"))
+		return
+	}
+
+	s.writeJSON(w, http.StatusOK, loginResponse{User: newUserResponse(user.Public()), Token: token})
+}
+
+// listTasks returns the catalog of offered tasks.
+//
+// GET /tasks. Responds with an array of tasks.
+func (s *Server) listTasks(w http.ResponseWriter, r *http.Request) {
+	tasks, err := s.taskService.GetAllTasks()
+	if err != nil {
+		s.logger.Error("HTTP: GetAllTasks failed", "error", err)
+		s.writeError(w, statusForError(err), err)
+		return
+	}
+
+	response := make([]taskResponse, len(tasks))
+	for i, task := range tasks {
+		response[i] = newTaskResponse(task)
+	}
+
+	s.writeJSON(w, http.StatusOK, response)
+}
+
+// createOrder places a new order for the client identified by the session token.
+//
+// POST /orders with a createOrderRequest body. Responds with 201 and the created
+// order, 401 without a valid client token, or 400 if the order is rejected by
+// validation.
+func (s *Server) createOrder(w http.ResponseWriter, r *http.Request) {
+	claims, err := s.authenticateClient(r)
+	if err != nil {
+		s.writeError(w, statusForAuthError(err), err)
+		return
+	}
+
+	var request createOrderRequest
+	status, err := decodeJSON(r, &request)
+	if err != nil {
+		s.writeError(w, status, err)
+		return
+	}
+
+	order, err := s.orderService.CreateOrder(claims.SubjectID, request.Address, request.Deadline, request.orderedTasks())
+	if err != nil {
+		s.logger.Error("HTTP: CreateOrder failed", "user_id", claims.SubjectID, "error", err)
+		s.writeError(w, statusForError(err), err)
+		return
+	}
+
+	s.writeJSON(w, http.StatusCreated, newOrderResponse(order.Order, order.Tasks))
+}
+
+// listUserOrders returns all orders of a client. Clients may only list their
+// own orders.
+//
+// GET /users/{id}/orders. Responds with an array of orders without their tasks,
+// 401 without a valid client token and 403 for the orders of another client.
+func (s *Server) listUserOrders(w http.ResponseWriter, r *http.Request) {
+	claims, err := s.authenticateClient(r)
+	if err != nil {
+		s.writeError(w, statusForAuthError(err), err)
+		return
+	}
+
+	userID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		s.writeError(w, http.StatusBadRequest, fmt.Errorf("malformed user id: %w", err))
+		return
+	}
+
+	if userID != claims.SubjectID {
+		s.writeError(w, http.StatusForbidden, errForbidden)
+		return
+	}
+
+	orders, err := s.orderService.GetAllOrdersByUserID(userID)
+	if err != nil {
+		s.logger.Error("HTTP: GetAllOrdersByUserID failed", "user_id", userID, "error", err)
+		s.writeError(w, statusForError(err), err)
+		return
+	}
+
+	response := make([]orderResponse, len(orders))
+	for i, order := range orders {
+		response[i] = newOrderResponse(order, nil)
+	}
+
+	s.writeJSON(w, http.StatusOK, response)
+}
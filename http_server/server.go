@@ -0,0 +1,207 @@
+// Package http_server provides the HTTP/REST front end of the PikaClean
+// application. It exposes a subset of the business logic services as JSON
+// endpoints: client login, the task catalog, placing orders and listing the
+// orders of a client. Endpoints acting for a client require the session token
+// issued on login as a Bearer token.
+package http_server
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"teamdev/auth"
+	"teamdev/internal/services/service_errors"
+	"teamdev/internal/services/service_interfaces"
+
+	"github.com/charmbracelet/log"
+)
+
+// maxRequestBodyBytes limits the size of request bodies.
+const maxRequestBodyBytes = 1 << 20
+
+// internalErrorMessage is sent instead of the details of server-side failures,
+// which are only logged.
+const internalErrorMessage = "internal server error"
+
+var (
+	// errUnauthenticated is reported when a request lacks a valid session token.
+	errUnauthenticated = errors.New("missing or invalid session token")
+
+	// errForbidden is reported when the token does not allow the request.
+	errForbidden = errors.New("access denied")
+)
+
+// Server routes HTTP requests to the business logic services.
+// It implements http.Handler, so it can be passed to http.Server or httptest.
+type Server struct {
+	userService  service_interfaces.IUserService  // Authenticates clients
+	orderService service_interfaces.IOrderService // Places and lists orders
+	taskService  service_interfaces.ITaskService  // Provides the task catalog
+	tokens       auth.TokenIssuer                 // Checks session tokens, nil rejects every authenticated request
+	logger       *log.Logger                      // Logger for request failures
+	mux          *http.ServeMux                   // Router of the endpoints
+}
+
+// NewServer creates a Server with all endpoints registered.
+//
+// Parameters:
+//   - userService: Service used to authenticate clients
+//   - orderService: Service used to place and list orders
+//   - taskService: Service used to list the task catalog
+//   - tokens: Issuer checking the session tokens of clients, nil if tokens are not configured
+//   - logger: Logger for request failures
+//
+// Returns:
+//   - *Server: Ready-to-serve HTTP handler
+func NewServer(
+	userService service_interfaces.IUserService,
+	orderService service_interfaces.IOrderService,
+	taskService service_interfaces.ITaskService,
+	tokens auth.TokenIssuer,
+	logger *log.Logger,
+) *Server {
+	s := &Server{
+		userService:  userService,
+		orderService: orderService,
+		taskService:  taskService,
+		tokens:       tokens,
+		logger:       logger,
+		mux:          http.NewServeMux(),
+	}
+
+	s.mux.HandleFunc("POST /login", s.login)
+	s.mux.HandleFunc("GET /tasks", s.listTasks)
+	s.mux.HandleFunc("POST /orders", s.createOrder)
+	s.mux.HandleFunc("GET /users/{id}/orders", s.listUserOrders)
+
+	return s
+}
+
+// ServeHTTP dispatches the request to the matching endpoint. Request bodies
+// larger than maxRequestBodyBytes are cut off.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	r.Body = http.MaxBytesReader(w, r.Body, maxRequestBodyBytes)
+	s.mux.ServeHTTP(w, r)
+}
+
+// authenticateClient checks the Bearer token of the request and returns its
+// claims. Only tokens issued to clients are accepted.
+//
+// Parameters:
+//   - r: Incoming request
+//
+// Returns:
+//   - *auth.Claims: Claims of a valid client token
+//   - error: errUnauthenticated if the token is missing, invalid or expired,
+//     errForbidden if it was not issued to a client
+func (s *Server) authenticateClient(r *http.Request) (*auth.Claims, error) {
+	token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !ok || token == "" || s.tokens == nil {
+		return nil, errUnauthenticated
+	}
+
+	claims, err := s.tokens.ParseToken(token)
+	if err != nil {
+		s.logger.Info("HTTP: Rejected session token", "error", err)
+		return nil, errUnauthenticated
+	}
+
+	if claims.Role != auth.ClientRole {
+		return nil, errForbidden
+	}
+
+	return claims, nil
+}
+
+// decodeJSON decodes the request body into the value.
+//
+// Parameters:
+//   - r: Incoming request
+//   - value: Pointer to the document to fill
+//
+// Returns:
+//   - int: 413 if the body is too large, 400 if it is malformed
+//   - error: Description of the decoding failure, nil if successful
+func decodeJSON(r *http.Request, value any) (int, error) {
+	err := json.NewDecoder(r.Body).Decode(value)
+	if err == nil {
+		return 0, nil
+	}
+
+	var tooLarge *http.MaxBytesError
+	if errors.As(err, &tooLarge) {
+		return http.StatusRequestEntityTooLarge, fmt.Errorf("request body exceeds %d bytes", tooLarge.Limit)
+	}
+
+	return http.StatusBadRequest, fmt.Errorf("malformed request body: %w", err)
+}
+
+// badRequestErrors are service errors caused by the content of the request
+// rather than by the server, reported with 400 Bad Request.
+var badRequestErrors = []error{
+	service_errors.InvalidAddressOrder,
+	service_errors.InvalidDeadlineOrder,
+	service_errors.EmptyTasksOrder,
+	service_errors.InvalidReference,
+	service_errors.TasksWithoutQuantity,
+	service_errors.NegativeQuantity,
+	service_errors.NonPositiveTotal,
+}
+
+// statusForError maps an error returned by a service to an HTTP status code.
+//
+// Parameters:
+//   - err: Error returned by a service
+//
+// Returns:
+//   - int: 400 for errors caused by the request, 500 otherwise
+func statusForError(err error) int {
+	for _, target := range badRequestErrors {
+		if errors.Is(err, target) {
+			return http.StatusBadRequest
+		}
+	}
+
+	return http.StatusInternalServerError
+}
+
+// statusForAuthError maps an error of authenticateClient to an HTTP status code.
+//
+// Parameters:
+//   - err: Error returned by authenticateClient
+//
+// Returns:
+//   - int: 403 if the token does not allow the request, 401 otherwise
+func statusForAuthError(err error) int {
+	if errors.Is(err, errForbidden) {
+		return http.StatusForbidden
+	}
+
+	return http.StatusUnauthorized
+}
+
+// writeJSON writes the value as a JSON response with the given status code.
+func (s *Server) writeJSON(w http.ResponseWriter, status int, value any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+
+	err := json.NewEncoder(w).Encode(value)
+	if err != nil {
+		s.logger.Error("HTTP: Failed to encode response", "error", err)
+	}
+}
+
+// writeError writes an error response of the form {"error": "..."}. Server-side
+// failures are logged and answered with a generic message, so that internal
+// details do not reach the client.
+func (s *Server) writeError(w http.ResponseWriter, status int, err error) {
+	message := err.Error()
+	if status >= http.StatusInternalServerError {
+		s.logger.Error("HTTP: Request failed", "status", status, "error", err)
+		message = internalErrorMessage
+	}
+
+	s.writeJSON(w, status, errorResponse{Error: message})
+}
@@ -0,0 +1,10 @@
+// Package models provides data structures representing the core domain entities
+// of the PikaClean application, including workers, users, tasks, and orders.
+package models
+
+// CatalogImportSummary reports the outcome of importing a task catalog.
+type CatalogImportSummary struct {
+	Created int // Number of tasks added to the catalog
+	Updated int // Number of existing tasks whose price and category were overwritten
+	Skipped int // Number of entries left out because a task with the same name exists
+}
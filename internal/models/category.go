@@ -9,3 +9,7 @@ type Category struct {
 	ID   int    // Unique identifier for the category
 	Name string // Descriptive name of the category
 }
+
+// UncategorizedCategoryName is the name of the category that receives the tasks
+// of a category deleted by force. It is created on first use.
+const UncategorizedCategoryName = "Без категории"
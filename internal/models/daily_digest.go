@@ -0,0 +1,16 @@
+// Package models provides data structures representing the core domain entities
+// of the PikaClean application, including workers, users, tasks, and orders.
+package models
+
+import "time"
+
+// DailyDigest summarizes order activity for a single day.
+// It holds everything needed to compose the daily summary sent to managers.
+type DailyDigest struct {
+	Day             time.Time // Start of the summarized day
+	NewOrders       int       // Number of orders created during the day
+	CompletedOrders int       // Number of orders completed during the day
+	CancelledOrders int       // Number of orders cancelled during the day
+	Revenue         float64   // Total of orders completed during the day
+	OverdueOrders   int       // Number of active orders whose deadline passed by the end of the day
+}
@@ -0,0 +1,12 @@
+// Package models provides data structures representing the core domain entities
+// of the PikaClean application, including workers, users, tasks, and orders.
+package models
+
+import "time"
+
+// DeadlineSettings bounds how soon and how far in the future an order deadline
+// may be set. A zero value leaves the corresponding bound unchecked.
+type DeadlineSettings struct {
+	MinLeadTime time.Duration // Shortest time between placing an order and its deadline
+	MaxHorizon  time.Duration // Longest time between placing an order and its deadline
+}
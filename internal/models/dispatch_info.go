@@ -0,0 +1,16 @@
+// Package models provides data structures representing the core domain entities
+// of the PikaClean application, including workers, users, tasks, and orders.
+package models
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+// DispatchInfo contains everything a master needs to carry out an assigned order.
+type DispatchInfo struct {
+	OrderID  uuid.UUID     // Order being dispatched
+	Address  string        // Location where the cleaning should be performed
+	Deadline time.Time     // When the order should be completed by
+	Tasks    []OrderedTask // Tasks to perform with their quantities
+}
@@ -0,0 +1,17 @@
+// Package models provides data structures representing the core domain entities
+// of the PikaClean application, including workers, users, tasks, and orders.
+package models
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+// DraftOrder represents an order a customer started but has not placed yet.
+// A customer has at most one draft, which is kept between sessions.
+type DraftOrder struct {
+	UserID    uuid.UUID     // ID of the customer the draft belongs to
+	Address   string        // Address entered so far
+	Tasks     []OrderedTask // Selected tasks with their quantities
+	UpdatedAt time.Time     // When the draft was last saved
+}
@@ -0,0 +1,12 @@
+// Package models provides data structures representing the core domain entities
+// of the PikaClean application, including workers, users, tasks, and orders.
+package models
+
+import "time"
+
+// LockoutSettings control how failed logins block further attempts for the
+// same email. A zero MaxFailures disables the lockout.
+type LockoutSettings struct {
+	MaxFailures int           // Number of failed attempts within Window that locks the email
+	Window      time.Duration // Period in which failures are counted and how long the lock lasts
+}
@@ -3,6 +3,7 @@
 package models
 
 import (
+	"fmt"
 	"github.com/google/uuid"
 	"time"
 )
@@ -11,16 +12,43 @@ import (
 // It contains information about who placed the order, who is assigned to fulfill it,
 // when it should be completed, and its current status in the workflow.
 type Order struct {
-	ID           uuid.UUID // Unique identifier for the order
-	WorkerID     uuid.UUID // ID of the worker assigned to fulfill the order
-	UserID       uuid.UUID // ID of the user who placed the order
-	Status       int       // Current status of the order (see status constants)
-	Address      string    // Location where cleaning services should be performed
-	CreationDate time.Time // When the order was created in the system
-	Deadline     time.Time // When the order should be completed by
-	Rate         int       // Customer satisfaction rating (0-5)
+	ID                 uuid.UUID  // Unique identifier for the order
+	WorkerID           uuid.UUID  // ID of the worker assigned to fulfill the order
+	UserID             uuid.UUID  // ID of the user who placed the order
+	Status             int        // Current status of the order (see status constants)
+	Address            string     // Location where cleaning services should be performed
+	CreationDate       time.Time  // When the order was created in the system
+	Deadline           time.Time  // When the order should be completed by
+	Rate               int        // Customer satisfaction rating (0-5)
+	QuotedTotal        float64    // Total price agreed at creation time, kept even if task prices change
+	AssignedAt         *time.Time // When a worker was assigned to the order, nil if never assigned
+	CompletedAt        *time.Time // When the order was completed, nil if not completed
+	CancelledAt        *time.Time // When the order was cancelled, nil if not cancelled
+	DeletedAt          *time.Time // When the order was deleted, nil if not deleted
+	CancellationReason string     // Why the order was cancelled, empty if no reason was given
 }
 
+// OrderWithTasks is an order together with the tasks it contains
+// and the ordered quantity of each task.
+type OrderWithTasks struct {
+	Order Order         // Order details
+	Tasks []OrderedTask // Tasks included in the order with their quantities
+}
+
+// OrderDetails bundles everything needed to present an order: the order itself,
+// its tasks with quantities, the assigned worker and the total price.
+type OrderDetails struct {
+	Order      Order         // Order details
+	Tasks      []OrderedTask // Tasks included in the order with their quantities
+	Worker     *Worker       // Assigned worker, nil if the order is unassigned
+	TotalPrice float64       // Total price of the order at current task prices
+}
+
+// OrderStatus is the status of an order in the workflow. The status constants
+// below are untyped, so they can be used both where an OrderStatus and where
+// a plain int status is expected.
+type OrderStatus int
+
 // NoStatus indicates an order with an undefined status.
 const NoStatus = 0
 
@@ -41,7 +69,17 @@ const CancelledOrderStatus = 4
 var OrderStatuses = map[int]string{
 	NoStatus:              "Не определен", // Undefined
 	NewOrderStatus:        "Новый",        // New
-	InProgressOrderStatus: "В процессе",   // In progress
-	CompletedOrderStatus:  "Завершен",     // Completed
-	CancelledOrderStatus:  "Отменен",      // Cancelled
+	InProgressOrderStatus: "В работе",     // In progress
+	CompletedOrderStatus:  "Выполнен",     // Completed
+	CancelledOrderStatus:  "Отменён",      // Cancelled
+}
+
+// DisplayStatus returns the human-readable name of the order's status.
+// Unknown status codes are shown together with the code itself.
+func (o Order) DisplayStatus() string {
+	if status, ok := OrderStatuses[o.Status]; ok {
+		return status
+	}
+
+	return fmt.Sprintf("Неизвестный статус (%d)", o.Status)
 }
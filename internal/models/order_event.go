@@ -0,0 +1,20 @@
+// Package models provides data structures representing the core domain entities
+// of the PikaClean application, including workers, users, tasks, and orders.
+package models
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+// OrderCompletedEvent is the type of the event published when an order is completed.
+const OrderCompletedEvent = "order.completed"
+
+// OrderEvent describes a change of an order that is published to external
+// systems, such as accounting.
+type OrderEvent struct {
+	Type       string    // Kind of the event, e.g. OrderCompletedEvent
+	OrderID    uuid.UUID // Order the event is about
+	GrandTotal float64   // Final amount to be paid for the order
+	OccurredAt time.Time // When the change happened
+}
@@ -0,0 +1,12 @@
+// Package models provides data structures representing the core domain entities
+// of the PikaClean application, including workers, users, tasks, and orders.
+package models
+
+import "time"
+
+// OrderRating is the customer rating of a completed order together with the
+// moment the order was completed.
+type OrderRating struct {
+	Rate        int       // Customer satisfaction rating (1-5)
+	CompletedAt time.Time // When the rated order was completed
+}
@@ -0,0 +1,25 @@
+// Package models provides data structures representing the core domain entities
+// of the PikaClean application, including workers, users, tasks, and orders.
+package models
+
+// PriceTier is a volume discount of a task: ordering at least MinQuantity
+// units charges Price per unit instead of the regular price.
+type PriceTier struct {
+	MinQuantity int     // Smallest quantity the tier applies to
+	Price       float64 // Price per unit within the tier
+}
+
+// TieredUnitPrice returns the price per unit for the ordered quantity. The tier
+// with the largest minimum quantity not above the ordered quantity applies;
+// without such a tier the regular price is used.
+func TieredUnitPrice(pricePerSingle float64, tiers []PriceTier, quantity int) float64 {
+	price := pricePerSingle
+	bestMinQuantity := 0
+	for _, tier := range tiers {
+		if tier.MinQuantity <= quantity && tier.MinQuantity > bestMinQuantity {
+			price = tier.Price
+			bestMinQuantity = tier.MinQuantity
+		}
+	}
+	return price
+}
@@ -0,0 +1,31 @@
+// Package models provides data structures representing the core domain entities
+// of the PikaClean application, including workers, users, tasks, and orders.
+package models
+
+import "github.com/google/uuid"
+
+// TaxSettings describes how taxes are applied to stored task prices, which
+// are always kept without tax.
+type TaxSettings struct {
+	Rate      float64 // Tax rate as a fraction (e.g. 0.2 for 20%)
+	Inclusive bool    // Whether line items are presented with tax already included
+}
+
+// ReceiptLine represents a single task in an order receipt.
+type ReceiptLine struct {
+	TaskName  string  // Name of the ordered task
+	Quantity  int     // Number of ordered units
+	UnitPrice float64 // Price per unit as presented on the receipt
+	Total     float64 // Price of all units as presented on the receipt
+}
+
+// Receipt is the price breakdown of an order. Depending on the tax settings,
+// line items either include tax or tax is presented as a separate line.
+type Receipt struct {
+	OrderID     uuid.UUID     // Order the receipt belongs to
+	Lines       []ReceiptLine // Line items of the order
+	Subtotal    float64       // Sum of the line items
+	Tax         float64       // Tax amount, included in the line items when TaxIncluded is set
+	TaxIncluded bool          // Whether the line items already include tax
+	GrandTotal  float64       // Amount to be paid
+}
@@ -0,0 +1,10 @@
+// Package models provides data structures representing the core domain entities
+// of the PikaClean application, including workers, users, tasks, and orders.
+package models
+
+// RevenueSummary describes the orders billed within a period.
+type RevenueSummary struct {
+	Revenue           float64 // Total of the orders completed in the period
+	OrderCount        int     // Number of orders completed in the period
+	AverageOrderValue float64 // Mean total of those orders, 0 if there are none
+}
@@ -0,0 +1,55 @@
+// Package models provides data structures representing the core domain entities
+// of the PikaClean application, including workers, users, tasks, and orders.
+package models
+
+import "math"
+
+// RoundingMode determines how monetary amounts are rounded to whole cents.
+type RoundingMode int
+
+// RoundHalfUp rounds to the nearest cent, with halves rounded away from zero.
+// It is the default mode.
+const RoundHalfUp RoundingMode = 0
+
+// RoundHalfEven rounds to the nearest cent, with halves rounded to the even cent.
+const RoundHalfEven RoundingMode = 1
+
+// RoundUp always rounds up to the next cent.
+const RoundUp RoundingMode = 2
+
+// RoundDown always rounds down to the previous cent.
+const RoundDown RoundingMode = 3
+
+// RoundingModes maps configuration names to rounding modes.
+var RoundingModes = map[string]RoundingMode{
+	"half_up":   RoundHalfUp,
+	"half_even": RoundHalfEven,
+	"up":        RoundUp,
+	"down":      RoundDown,
+}
+
+// Round rounds an amount to two decimal places according to the mode.
+// Binary floating point noise is removed first, so that e.g. 1.005 is
+// treated as an exact half cent.
+//
+// Parameters:
+//   - amount: Amount to round
+//
+// Returns:
+//   - float64: Amount rounded to whole cents
+func (m RoundingMode) Round(amount float64) float64 {
+	cents := math.Round(amount*100*1e6) / 1e6
+
+	switch m {
+	case RoundHalfEven:
+		cents = math.RoundToEven(cents)
+	case RoundUp:
+		cents = math.Ceil(cents)
+	case RoundDown:
+		cents = math.Floor(cents)
+	default:
+		cents = math.Round(cents)
+	}
+
+	return cents / 100
+}
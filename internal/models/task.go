@@ -2,7 +2,10 @@
 // of the PikaClean application, including workers, users, tasks, and orders.
 package models
 
-import "github.com/google/uuid"
+import (
+	"github.com/google/uuid"
+	"time"
+)
 
 // Task represents a cleaning service that can be ordered by clients.
 // Each task has a unique identifier, name, price, and belongs to a specific category.
@@ -10,9 +13,48 @@ type Task struct {
 	ID             uuid.UUID // Unique identifier for the task
 	Name           string    // Name of the cleaning task
 	PricePerSingle float64   // Price per unit of the task
-	Category       int       // Category identifier (1-8 matching TaskCategories)
+	Category       int       // Identifier of the category in the categories table
+	Archived       bool      // Whether the task is retired and no longer offered
+	UpdatedAt      time.Time // When the task was created or last changed
 }
 
+// TaskPriced represents a task together with its price converted to another currency.
+type TaskPriced struct {
+	Task     Task    // Task whose price was converted
+	Currency string  // Code of the currency the price is expressed in
+	Price    float64 // Price per unit in the target currency
+}
+
+// TaskWithCategory represents a task together with the name of its category.
+type TaskWithCategory struct {
+	Task         Task   // Task being listed
+	CategoryName string // Name of the task's category, UnknownCategoryName if it does not exist
+}
+
+// TaskPopularity describes how often a task has been ordered.
+type TaskPopularity struct {
+	Task          Task // Task the report row belongs to
+	TotalQuantity int  // Sum of the quantities the task was ordered in
+	OrderCount    int  // Number of orders containing the task
+}
+
+// Fields the task catalog can be sorted by.
+const (
+	TaskSortByName     = "name"     // Sort by task name
+	TaskSortByPrice    = "price"    // Sort by price per unit
+	TaskSortByCategory = "category" // Sort by category identifier
+)
+
+// TaskSortFields lists the accepted sort fields of the task catalog.
+var TaskSortFields = map[string]bool{
+	TaskSortByName:     true,
+	TaskSortByPrice:    true,
+	TaskSortByCategory: true,
+}
+
+// UnknownCategoryName is displayed for tasks whose category cannot be resolved.
+const UnknownCategoryName = "Неизвестная категория"
+
 // TaskCategories defines the available cleaning service categories offered by PikaClean.
 // The index (plus 1) corresponds to the category identifier used in the Task struct.
 var TaskCategories = [8]string{
@@ -47,6 +89,6 @@ func GetCategoryName(category int) string {
 	case 8:
 		return TaskCategories[7]
 	default:
-		return "Неизвестная категория"
+		return UnknownCategoryName
 	}
 }
@@ -18,3 +18,27 @@ type User struct {
 	Email       string    // Email address used for account access and communication
 	Password    string    // Hashed password for authentication
 }
+
+// UserPublic is the view of a client that is safe to hand out to other parts
+// of the application. It has no password field, so it can never leak or be
+// saved back with an empty password.
+type UserPublic struct {
+	ID          uuid.UUID // Unique identifier for the user
+	Name        string    // First name of the user
+	Surname     string    // Last name of the user
+	Address     string    // Physical address where cleaning services might be performed
+	PhoneNumber string    // Contact phone number for notifications and communication
+	Email       string    // Email address used for account access and communication
+}
+
+// Public returns the password-free view of the user.
+func (u User) Public() UserPublic {
+	return UserPublic{
+		ID:          u.ID,
+		Name:        u.Name,
+		Surname:     u.Surname,
+		Address:     u.Address,
+		PhoneNumber: u.PhoneNumber,
+		Email:       u.Email,
+	}
+}
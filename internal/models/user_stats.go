@@ -0,0 +1,12 @@
+// Package models provides data structures representing the core domain entities
+// of the PikaClean application, including workers, users, tasks, and orders.
+package models
+
+// UserStats summarizes the order activity of a customer.
+type UserStats struct {
+	TotalOrders     int     // Number of orders placed by the customer
+	CompletedOrders int     // Number of completed orders
+	CancelledOrders int     // Number of cancelled orders
+	TotalSpent      float64 // Total of the completed orders
+	AverageRating   float64 // Mean rating given by the customer, 0 if no order was rated
+}
@@ -4,20 +4,25 @@
 // without any database implementation details.
 package models
 
-import "github.com/google/uuid"
+import (
+	"github.com/google/uuid"
+	"time"
+)
 
 // Worker represents a staff member of the cleaning service.
 // Workers can be either managers who oversee operations or
 // cleaning masters who perform the actual cleaning tasks.
 type Worker struct {
-	ID          uuid.UUID // Unique identifier for the worker
-	Name        string    // First name of the worker
-	Surname     string    // Last name of the worker
-	Address     string    // Physical address of the worker
-	PhoneNumber string    // Contact phone number
-	Email       string    // Email address used for communication and login
-	Role        int       // Role identifier (ManagerRole or MasterRole)
-	Password    string    // Hashed password for authentication
+	ID                  uuid.UUID  // Unique identifier for the worker
+	Name                string     // First name of the worker
+	Surname             string     // Last name of the worker
+	Address             string     // Physical address of the worker
+	PhoneNumber         string     // Contact phone number
+	Email               string     // Email address used for communication and login
+	Role                int        // Role identifier (ManagerRole or MasterRole)
+	Password            string     // Hashed password for authentication
+	LastLoginAt         *time.Time // When the worker last logged in successfully, nil if never
+	NotificationsOptOut bool       // Whether the worker has opted out of notifications
 }
 
 // ManagerRole is a constant indicating that a worker has manager privileges.
@@ -0,0 +1,13 @@
+// Package models provides data structures representing the core domain entities
+// of the PikaClean application, including workers, users, tasks, and orders.
+package models
+
+// WorkerComparison describes the performance of a master relative to the rest of the team.
+type WorkerComparison struct {
+	Worker               Worker  // Compared master, without the password
+	AverageRating        float64 // Average rating of the master's rated completed orders
+	CompletedOrders      int     // Number of orders completed by the master
+	TeamAverageRating    float64 // Average of the masters' average ratings
+	TeamAverageCompleted float64 // Average number of completed orders per master
+	RatingPercentile     float64 // Share of the team rated lower than the master (0-100)
+}
@@ -0,0 +1,12 @@
+// Package models provides data structures representing the core domain entities
+// of the PikaClean application, including workers, users, tasks, and orders.
+package models
+
+// WorkerPerformance summarizes the productivity of a master.
+type WorkerPerformance struct {
+	Worker          Worker  // Master the report row belongs to, without the password
+	CompletedOrders int     // Number of orders completed by the master
+	CancelledOrders int     // Number of cancelled orders that were assigned to the master
+	AverageRating   float64 // Average rating of the master's rated completed orders
+	Revenue         float64 // Sum of the billed totals of the master's completed orders
+}
@@ -0,0 +1,10 @@
+// Package models provides data structures representing the core domain entities
+// of the PikaClean application, including workers, users, tasks, and orders.
+package models
+
+// WorkerWithRating describes a master considered for an order assignment.
+type WorkerWithRating struct {
+	Worker        Worker  // Candidate master, without the password
+	AverageRating float64 // Average rating of the master's rated completed orders
+	ActiveOrders  int     // Number of new and in-progress orders assigned to the master
+}
@@ -4,13 +4,26 @@
 package registry
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
 	"os"
+	"teamdev/auth"
 	"teamdev/config"
+	"teamdev/exchange_rate"
+	"teamdev/http_server"
+	"teamdev/internal/models"
 	"teamdev/internal/repository/postgres"
+	"teamdev/internal/repository/repository_errors"
 	"teamdev/internal/repository/repository_interfaces"
 	services "teamdev/internal/services"
 	"teamdev/internal/services/service_interfaces"
+	"teamdev/notifier"
 	"teamdev/password_hash"
+	"teamdev/second_factor"
+	"time"
 
 	"github.com/charmbracelet/log"
 )
@@ -24,6 +37,10 @@ type Services struct {
 	TaskService     service_interfaces.ITaskService     // Handles cleaning task-related business logic
 	OrderService    service_interfaces.IOrderService    // Handles order processing business logic
 	CategoryService service_interfaces.ICategoryService // Handles category management business logic
+
+	Tokens auth.TokenIssuer // Issues and checks session tokens, nil if no secret is configured
+
+	HealthCheck func(ctx context.Context) error // Reports whether the storage behind the services is reachable
 }
 
 // Repositories encapsulates all data access objects used by the application.
@@ -43,6 +60,8 @@ type App struct {
 	Repositories *Repositories // Data access layer
 	Services     *Services     // Business logic layer
 	Logger       *log.Logger   // Application logging facility
+
+	postgres *postgres.PostgresConnection // Connection shared by the PostgreSQL repositories
 }
 
 // postgresRepositoriesInitialization creates and initializes all PostgreSQL-based repositories.
@@ -62,14 +81,26 @@ func (a *App) postgresRepositoriesInitialization(fields *postgres.PostgresConnec
 // servicesInitialization creates and initializes all business logic services.
 // It connects services with their required repositories and utilities.
 func (a *App) servicesInitialization(r *Repositories) *Services {
-	passwordHash := password_hash.NewPasswordHash()
+	passwordHash := password_hash.NewPasswordHashWithCost(a.Config.PasswordHashCost)
+	rateProvider := exchange_rate.NewIdentityRateProvider()
+	if len(a.Config.ExchangeRates) > 0 {
+		rateProvider = exchange_rate.NewStaticRateProvider(a.Config.ExchangeRates)
+	}
+
+	var tokens auth.TokenIssuer
+	if a.Config.TokenSecret != "" {
+		tokens = auth.NewTokenIssuer(a.Config.TokenSecret, time.Duration(a.Config.TokenExpiryMinutes)*time.Minute)
+	}
+
+	lockout := models.LockoutSettings{MaxFailures: a.Config.LoginMaxFailures, Window: time.Duration(a.Config.LoginLockoutMinutes) * time.Minute}
 
 	s := &Services{
-		UserService:     services.NewUserService(r.UserRepository, passwordHash, a.Logger),
-		WorkerService:   services.NewWorkerService(r.WorkerRepository, passwordHash, a.Logger),
-		OrderService:    services.NewOrderService(r.OrderRepository, r.WorkerRepository, r.TaskRepository, r.UserRepository, a.Logger),
-		TaskService:     services.NewTaskService(r.TaskRepository, a.Logger),
+		UserService:     services.NewUserService(r.UserRepository, passwordHash, a.Logger, lockout, tokens),
+		WorkerService:   services.NewWorkerService(r.WorkerRepository, passwordHash, a.Logger, a.Config.MaxActiveOrders, second_factor.NewUnconfiguredProvider(), a.Config.SecondFactorRequired, lockout, tokens),
+		OrderService:    services.NewOrderService(r.OrderRepository, r.WorkerRepository, r.TaskRepository, r.UserRepository, a.Logger, a.Config.MaxActiveOrders, models.TaxSettings{Rate: a.Config.TaxRate, Inclusive: a.Config.TaxInclusive}, a.Config.RoundingMode, models.DeadlineSettings{MinLeadTime: time.Duration(a.Config.DeadlineLeadHours) * time.Hour, MaxHorizon: time.Duration(a.Config.DeadlineHorizonDays) * 24 * time.Hour}, a.Config.ServiceArea, notifier.NewLogNotifier(a.Logger), notifier.NewLogOrderNotifier(a.Logger)),
+		TaskService:     services.NewTaskService(r.TaskRepository, r.CategoryRepository, rateProvider, a.Logger, a.Config.RoundTaskPrices, a.Config.RoundingMode),
 		CategoryService: services.NewCategoryService(r.CategoryRepository, r.TaskRepository, a.Logger),
+		Tokens:          tokens,
 	}
 	a.Logger.Info("Success initialization of services")
 
@@ -116,20 +147,74 @@ func (a *App) Init() error {
 			return err
 		}
 
+		a.postgres = fields
 		a.Repositories = a.postgresRepositoriesInitialization(fields)
 		a.Services = a.servicesInitialization(a.Repositories)
+		a.Services.HealthCheck = a.HealthCheck
 	}
 
 	return nil
 }
 
-// Run executes the application initialization sequence and prepares it for operation.
-// Returns an error if the application fails to initialize properly.
-func (a *App) Run() error {
-	err := a.Init()
+// HealthCheck pings the database behind the repositories. All PostgreSQL
+// repositories share one connection pool, so a single ping covers them all.
+//
+// Parameters:
+//   - ctx: Context bounding how long the check may take
+//
+// Returns:
+//   - error: repository_errors.ConnectionError if the database is not configured or unreachable
+func (a *App) HealthCheck(ctx context.Context) error {
+	if a.postgres == nil {
+		return repository_errors.ConnectionError
+	}
 
+	err := a.postgres.Ping(ctx)
 	if err != nil {
-		a.Logger.Error("Error init app", "err", err)
+		a.Logger.Error("Health check failed", "err", err)
+		return err
+	}
+
+	return nil
+}
+
+const (
+	// httpReadHeaderTimeout bounds how long the HTTP server waits for request headers.
+	httpReadHeaderTimeout = 10 * time.Second
+
+	// httpReadTimeout bounds how long the HTTP server waits for a whole request, body included.
+	httpReadTimeout = 30 * time.Second
+
+	// httpWriteTimeout bounds how long the HTTP server may take to write a response.
+	httpWriteTimeout = 30 * time.Second
+
+	// httpIdleTimeout bounds how long an idle keep-alive connection is kept open.
+	httpIdleTimeout = 2 * time.Minute
+)
+
+// Run serves the initialized application in the configured mode. In "http" mode
+// it serves the REST API on Config.Address and Config.Port until the server stops.
+// The "cmd" mode is driven by the caller, because the menus live in package cmd,
+// which depends on this package.
+// Returns an error if the mode cannot be served by the registry or the server fails.
+func (a *App) Run() error {
+	if a.Config.Mode != "http" {
+		return fmt.Errorf("mode %q is not served by the registry", a.Config.Mode)
+	}
+
+	server := &http.Server{
+		Addr:              net.JoinHostPort(a.Config.Address, a.Config.Port),
+		Handler:           http_server.NewServer(a.Services.UserService, a.Services.OrderService, a.Services.TaskService, a.Services.Tokens, a.Logger),
+		ReadHeaderTimeout: httpReadHeaderTimeout,
+		ReadTimeout:       httpReadTimeout,
+		WriteTimeout:      httpWriteTimeout,
+		IdleTimeout:       httpIdleTimeout,
+	}
+
+	a.Logger.Info("Starting HTTP server", "addr", server.Addr)
+	err := server.ListenAndServe()
+	if err != nil && !errors.Is(err, http.ErrServerClosed) {
+		a.Logger.Error("HTTP server failed", "err", err)
 		return err
 	}
 
@@ -4,7 +4,11 @@
 package postgres
 
 import (
+	"database/sql"
+	"errors"
+	"strings"
 	"teamdev/internal/models"
+	"teamdev/internal/repository/repository_errors"
 
 	"github.com/jmoiron/sqlx"
 )
@@ -65,11 +69,14 @@ func (c CategoryRepository) GetAll() ([]models.Category, error) {
 //
 // Returns:
 //   - *models.Category: Retrieved category entity
-//   - error: Database error if the operation fails or no category found
+//   - error: repository_errors.DoesNotExist if no category found,
+//     database error if the operation fails
 func (c CategoryRepository) GetByID(id int) (*models.Category, error) {
 	var category Category
 	err := c.db.Get(&category, "SELECT * FROM categories WHERE id = $1", id)
-	if err != nil {
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, repository_errors.DoesNotExist
+	} else if err != nil {
 		return nil, err
 	}
 	return &models.Category{
@@ -140,3 +147,133 @@ func (c CategoryRepository) Delete(id int) error {
 	}
 	return nil
 }
+
+// DeleteIfEmpty removes a category only if no task, archived ones included,
+// belongs to it. The check and the deletion happen in a single statement, so a
+// category with tasks is never removed.
+//
+// Parameters:
+//   - id: ID of the category to delete
+//
+// Returns:
+//   - int: Number of tasks in the category, 0 if it was deleted or does not exist
+//   - error: repository_errors.DeleteError if the operation fails
+func (c CategoryRepository) DeleteIfEmpty(id int) (int, error) {
+	query := `WITH deleted AS (
+		DELETE FROM categories
+		WHERE id = $1 AND NOT EXISTS (SELECT 1 FROM tasks WHERE category = $1)
+		RETURNING id
+	)
+	SELECT COUNT(*) FROM tasks WHERE category = $1;`
+
+	var tasks int
+	err := c.db.Get(&tasks, query, id)
+	if err != nil {
+		return 0, repository_errors.DeleteError
+	}
+
+	return tasks, nil
+}
+
+// DeleteMovingTasks moves all tasks of a category, archived ones included, to
+// another category and removes the category. Both steps run in one transaction,
+// so no task is left pointing at a deleted category.
+//
+// Parameters:
+//   - id: ID of the category to delete
+//   - moveTo: ID of the category that receives the tasks
+//
+// Returns:
+//   - int: Number of moved tasks
+//   - error: repository_errors.TransactionBeginError, repository_errors.TransactionRollbackError,
+//     repository_errors.UpdateError, repository_errors.DeleteError, repository_errors.TransactionCommitError,
+//     or repository_errors.DoesNotExist if there is no category with the ID, in which case nothing is changed
+func (c CategoryRepository) DeleteMovingTasks(id int, moveTo int) (int, error) {
+	tx, err := c.db.Begin()
+	if err != nil {
+		return 0, repository_errors.TransactionBeginError
+	}
+
+	result, err := tx.Exec(`UPDATE tasks SET category = $2, updated_at = now() WHERE category = $1;`, id, moveTo)
+	if err != nil {
+		err := tx.Rollback()
+		if err != nil {
+			return 0, repository_errors.TransactionRollbackError
+		}
+		return 0, repository_errors.UpdateError
+	}
+
+	moved, err := result.RowsAffected()
+	if err != nil {
+		err := tx.Rollback()
+		if err != nil {
+			return 0, repository_errors.TransactionRollbackError
+		}
+		return 0, repository_errors.UpdateError
+	}
+
+	result, err = tx.Exec(`DELETE FROM categories WHERE id = $1;`, id)
+	if err != nil {
+		err := tx.Rollback()
+		if err != nil {
+			return 0, repository_errors.TransactionRollbackError
+		}
+		return 0, repository_errors.DeleteError
+	}
+
+	deleted, err := result.RowsAffected()
+	if err != nil {
+		err := tx.Rollback()
+		if err != nil {
+			return 0, repository_errors.TransactionRollbackError
+		}
+		return 0, repository_errors.DeleteError
+	}
+
+	if deleted == 0 {
+		err := tx.Rollback()
+		if err != nil {
+			return 0, repository_errors.TransactionRollbackError
+		}
+		return 0, repository_errors.DoesNotExist
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return 0, repository_errors.TransactionCommitError
+	}
+
+	return int(moved), nil
+}
+
+// likePatternEscaper escapes characters that have a special meaning in LIKE patterns,
+// so user input is matched literally.
+var likePatternEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// SearchByName retrieves categories whose names contain the given substring,
+// ignoring case. The substring is passed as a bound parameter and matched literally.
+//
+// Parameters:
+//   - substring: Part of the category name to search for
+//
+// Returns:
+//   - []models.Category: Slice of matching categories ordered by ID
+//   - error: Database error if the operation fails
+func (c CategoryRepository) SearchByName(substring string) ([]models.Category, error) {
+	var categories []Category
+	err := c.db.Select(&categories, "SELECT * FROM categories WHERE name ILIKE '%' || $1 || '%' ORDER BY id", likePatternEscaper.Replace(substring))
+	if err != nil {
+		return nil, err
+	}
+
+	var categoryModels []models.Category
+	for i := range categories {
+		categoryModel := models.Category{
+			ID:   categories[i].ID,
+			Name: categories[i].Name,
+		}
+
+		categoryModels = append(categoryModels, categoryModel)
+	}
+	return categoryModels, nil
+}
@@ -7,6 +7,8 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"sort"
+	"strconv"
 	"strings"
 	"teamdev/internal/models"
 	"teamdev/internal/repository/repository_errors"
@@ -20,14 +22,20 @@ import (
 // OrderDB represents an order entity as stored in the PostgreSQL database.
 // It maps directly to the columns in the orders table.
 type OrderDB struct {
-	ID           uuid.UUID `db:"id"`            // Unique identifier for the order
-	WorkerID     uuid.UUID `db:"worker_id"`     // ID of the worker assigned to the order
-	UserID       uuid.UUID `db:"user_id"`       // ID of the user who created the order
-	Status       int       `db:"status"`        // Current status of the order (numeric code)
-	Address      string    `db:"address"`       // Location where the cleaning service should be performed
-	CreationDate time.Time `db:"creation_date"` // When the order was created
-	Deadline     time.Time `db:"deadline"`      // When the order should be completed
-	Rate         int       `db:"rate"`          // Customer satisfaction rating (0-5)
+	ID                 uuid.UUID  `db:"id"`                  // Unique identifier for the order
+	WorkerID           uuid.UUID  `db:"worker_id"`           // ID of the worker assigned to the order
+	UserID             uuid.UUID  `db:"user_id"`             // ID of the user who created the order
+	Status             int        `db:"status"`              // Current status of the order (numeric code)
+	Address            string     `db:"address"`             // Location where the cleaning service should be performed
+	CreationDate       time.Time  `db:"creation_date"`       // When the order was created
+	Deadline           time.Time  `db:"deadline"`            // When the order should be completed
+	Rate               int        `db:"rate"`                // Customer satisfaction rating (0-5)
+	QuotedTotal        float64    `db:"quoted_total"`        // Price snapshot stored when the order was created
+	AssignedAt         *time.Time `db:"assigned_at"`         // When a worker was assigned to the order
+	CompletedAt        *time.Time `db:"completed_at"`        // When the order was completed
+	CancelledAt        *time.Time `db:"cancelled_at"`        // When the order was cancelled
+	DeletedAt          *time.Time `db:"deleted_at"`          // When the order was deleted, nil if not deleted
+	CancellationReason string     `db:"cancellation_reason"` // Why the order was cancelled
 }
 
 // OrderRepository implements the IOrderRepository interface for PostgreSQL.
@@ -57,18 +65,26 @@ func NewOrderRepository(db *sqlx.DB) repository_interfaces.IOrderRepository {
 //   - *models.Order: Corresponding domain entity
 func copyOrderResultToModel(orderDB *OrderDB) *models.Order {
 	return &models.Order{
-		ID:           orderDB.ID,
-		WorkerID:     orderDB.WorkerID,
-		UserID:       orderDB.UserID,
-		Status:       orderDB.Status,
-		Address:      orderDB.Address,
-		CreationDate: orderDB.CreationDate,
-		Deadline:     orderDB.Deadline,
-		Rate:         orderDB.Rate,
+		ID:                 orderDB.ID,
+		WorkerID:           orderDB.WorkerID,
+		UserID:             orderDB.UserID,
+		Status:             orderDB.Status,
+		Address:            orderDB.Address,
+		CreationDate:       orderDB.CreationDate,
+		Deadline:           orderDB.Deadline,
+		Rate:               orderDB.Rate,
+		QuotedTotal:        orderDB.QuotedTotal,
+		AssignedAt:         orderDB.AssignedAt,
+		CompletedAt:        orderDB.CompletedAt,
+		CancelledAt:        orderDB.CancelledAt,
+		DeletedAt:          orderDB.DeletedAt,
+		CancellationReason: orderDB.CancellationReason,
 	}
 }
 
 // Create inserts a new order record into the database along with its associated tasks.
+// The order's QuotedTotal is stored as is, preserving the price agreed at creation time,
+// and the current price of every task is stored with its line.
 // The operation is performed within a transaction to ensure data consistency.
 //
 // Parameters:
@@ -85,9 +101,9 @@ func (o OrderRepository) Create(order *models.Order, orderedTasks []models.Order
 		return nil, repository_errors.TransactionBeginError
 	}
 
-	query := `INSERT INTO orders(user_id, status, address, deadline) VALUES ($1, $2, $3, $4) RETURNING id;`
+	query := `INSERT INTO orders(user_id, status, address, deadline, quoted_total) VALUES ($1, $2, $3, $4, $5) RETURNING id;`
 
-	err = transaction.QueryRow(query, order.UserID, order.Status, order.Address, order.Deadline).Scan(&order.ID)
+	err = transaction.QueryRow(query, order.UserID, order.Status, order.Address, order.Deadline, order.QuotedTotal).Scan(&order.ID)
 
 	if err != nil {
 		err = transaction.Rollback()
@@ -98,7 +114,8 @@ func (o OrderRepository) Create(order *models.Order, orderedTasks []models.Order
 	}
 
 	for _, task := range orderedTasks {
-		query = `INSERT INTO order_contains_tasks(order_id, task_id, quantity) VALUES ($1, $2, $3);`
+		query = `INSERT INTO order_contains_tasks(order_id, task_id, quantity, price_at_order)
+			VALUES ($1, $2, $3, ` + orderedUnitPrice + `);`
 		_, err = transaction.Exec(query, order.ID, task.Task.ID, task.Quantity)
 		if err != nil {
 			err = transaction.Rollback()
@@ -117,7 +134,118 @@ func (o OrderRepository) Create(order *models.Order, orderedTasks []models.Order
 	return order, nil
 }
 
-// Delete removes an order record and all associated task relationships from the database.
+// ImportOrders recreates orders together with their tasks in a single transaction.
+// Unlike Create, the status, rating, worker and timestamps of the orders are kept,
+// and so is the unit price of every task with a positive price.
+// The orders get new identifiers, which are set on the passed orders.
+//
+// Parameters:
+//   - orders: Orders to insert with their tasks, unit prices and quantities
+//
+// Returns:
+//   - error: repository_errors.TransactionBeginError, repository_errors.TransactionRollbackError,
+//     repository_errors.InsertError, or repository_errors.TransactionCommitError if the operation fails,
+//     in which case no order is inserted
+func (o OrderRepository) ImportOrders(orders []models.OrderWithTasks) error {
+	transaction, err := o.db.Begin()
+	if err != nil {
+		return repository_errors.TransactionBeginError
+	}
+
+	for i := range orders {
+		order := &orders[i].Order
+
+		var workerID interface{}
+		if order.WorkerID != uuid.Nil {
+			workerID = order.WorkerID
+		}
+
+		query := `INSERT INTO orders(worker_id, user_id, status, address, creation_date, deadline, rate, quoted_total, assigned_at, completed_at, cancelled_at, cancellation_reason)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12) RETURNING id;`
+		err = transaction.QueryRow(query, workerID, order.UserID, order.Status, order.Address, order.CreationDate, order.Deadline,
+			order.Rate, order.QuotedTotal, order.AssignedAt, order.CompletedAt, order.CancelledAt, order.CancellationReason).Scan(&order.ID)
+		if err != nil {
+			err = transaction.Rollback()
+			if err != nil {
+				return repository_errors.TransactionRollbackError
+			}
+			return repository_errors.InsertError
+		}
+
+		for _, task := range orders[i].Tasks {
+			query = `INSERT INTO order_contains_tasks(order_id, task_id, quantity, price_at_order)
+				VALUES ($1, $2, $3, COALESCE(NULLIF($4::float8, 0), ` + orderedUnitPrice + `));`
+			_, err = transaction.Exec(query, order.ID, task.Task.ID, task.Quantity, task.Task.PricePerSingle)
+			if err != nil {
+				err = transaction.Rollback()
+				if err != nil {
+					return repository_errors.TransactionRollbackError
+				}
+				return repository_errors.InsertError
+			}
+		}
+	}
+
+	err = transaction.Commit()
+	if err != nil {
+		return repository_errors.TransactionCommitError
+	}
+
+	return nil
+}
+
+// Delete marks an order as deleted. The order and its tasks are kept in the
+// database, so the order can be restored, but it no longer counts in queries
+// and revenue reports.
+//
+// Parameters:
+//   - id: UUID of the order to delete
+//
+// Returns:
+//   - error: repository_errors.DoesNotExist if no order was found or it is already deleted,
+//     repository_errors.DeleteError for other failures
+func (o OrderRepository) Delete(id uuid.UUID) error {
+	result, err := o.db.Exec(`UPDATE orders SET deleted_at = now() WHERE id = $1 AND deleted_at IS NULL;`, id)
+	if err != nil {
+		return repository_errors.DeleteError
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return repository_errors.DeleteError
+	} else if rowsAffected == 0 {
+		return repository_errors.DoesNotExist
+	}
+
+	return nil
+}
+
+// Restore clears the deletion mark of a deleted order.
+//
+// Parameters:
+//   - id: UUID of the order to restore
+//
+// Returns:
+//   - error: repository_errors.DoesNotExist if no deleted order was found,
+//     repository_errors.UpdateError for other failures
+func (o OrderRepository) Restore(id uuid.UUID) error {
+	result, err := o.db.Exec(`UPDATE orders SET deleted_at = NULL WHERE id = $1 AND deleted_at IS NOT NULL;`, id)
+	if err != nil {
+		return repository_errors.UpdateError
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return repository_errors.UpdateError
+	} else if rowsAffected == 0 {
+		return repository_errors.DoesNotExist
+	}
+
+	return nil
+}
+
+// HardDelete permanently removes an order record, including a deleted one, and all
+// associated task relationships from the database.
 // The operation is performed within a transaction to ensure data consistency.
 //
 // Parameters:
@@ -126,8 +254,9 @@ func (o OrderRepository) Create(order *models.Order, orderedTasks []models.Order
 // Returns:
 //   - error: repository_errors.TransactionBeginError, repository_errors.TransactionRollbackError,
 //     repository_errors.DeleteError, repository_errors.TransactionCommitError,
-//     or a custom error if no order was found to delete
-func (o OrderRepository) Delete(id uuid.UUID) error {
+//     or repository_errors.DoesNotExist if no order was found to delete, in which
+//     case nothing is removed
+func (o OrderRepository) HardDelete(id uuid.UUID) error {
 	// Start a new transaction
 	tx, err := o.db.Begin()
 	if err != nil {
@@ -165,7 +294,11 @@ func (o OrderRepository) Delete(id uuid.UUID) error {
 	}
 
 	if rowsAffected == 0 {
-		return errors.New("no order found to delete")
+		err := tx.Rollback()
+		if err != nil {
+			return repository_errors.TransactionRollbackError
+		}
+		return repository_errors.DoesNotExist
 	}
 
 	// Commit the transaction
@@ -187,7 +320,7 @@ func (o OrderRepository) Delete(id uuid.UUID) error {
 //   - *models.Order: Updated order after the operation
 //   - error: repository_errors.UpdateError if the operation fails
 func (o OrderRepository) Update(order *models.Order) (*models.Order, error) {
-	query := `UPDATE orders SET worker_id = $1, user_id = $2, status = $3, address = $4, creation_date = $5, deadline = $6, rate = $7 WHERE id = $8 RETURNING id, worker_id, user_id, status, address, creation_date, deadline, rate;`
+	query := `UPDATE orders SET worker_id = $1, user_id = $2, status = $3, address = $4, creation_date = $5, deadline = $6, rate = $7, assigned_at = $8, completed_at = $9, cancelled_at = $10, cancellation_reason = $11 WHERE id = $12 RETURNING id, worker_id, user_id, status, address, creation_date, deadline, rate, quoted_total, assigned_at, completed_at, cancelled_at, deleted_at, cancellation_reason;`
 
 	var workerID interface{}
 	if order.WorkerID != uuid.Nil {
@@ -195,7 +328,7 @@ func (o OrderRepository) Update(order *models.Order) (*models.Order, error) {
 	}
 
 	var updatedOrder models.Order
-	err := o.db.QueryRow(query, workerID, order.UserID, order.Status, order.Address, order.CreationDate, order.Deadline, order.Rate, order.ID).Scan(&updatedOrder.ID, &updatedOrder.WorkerID, &updatedOrder.UserID, &updatedOrder.Status, &updatedOrder.Address, &updatedOrder.CreationDate, &updatedOrder.Deadline, &updatedOrder.Rate)
+	err := o.db.QueryRow(query, workerID, order.UserID, order.Status, order.Address, order.CreationDate, order.Deadline, order.Rate, order.AssignedAt, order.CompletedAt, order.CancelledAt, order.CancellationReason, order.ID).Scan(&updatedOrder.ID, &updatedOrder.WorkerID, &updatedOrder.UserID, &updatedOrder.Status, &updatedOrder.Address, &updatedOrder.CreationDate, &updatedOrder.Deadline, &updatedOrder.Rate, &updatedOrder.QuotedTotal, &updatedOrder.AssignedAt, &updatedOrder.CompletedAt, &updatedOrder.CancelledAt, &updatedOrder.DeletedAt, &updatedOrder.CancellationReason)
 	if err != nil {
 		return nil, repository_errors.UpdateError
 	}
@@ -203,6 +336,7 @@ func (o OrderRepository) Update(order *models.Order) (*models.Order, error) {
 }
 
 // GetOrderByID retrieves an order by its unique identifier.
+// Deleted orders are not returned.
 //
 // Parameters:
 //   - id: UUID of the order to retrieve
@@ -212,7 +346,7 @@ func (o OrderRepository) Update(order *models.Order) (*models.Order, error) {
 //   - error: repository_errors.DoesNotExist if no order found,
 //     repository_errors.SelectError for other failures
 func (o OrderRepository) GetOrderByID(id uuid.UUID) (*models.Order, error) {
-	query := `SELECT * FROM orders WHERE id = $1;`
+	query := `SELECT * FROM orders WHERE id = $1 AND deleted_at IS NULL;`
 	orderDB := &OrderDB{}
 	err := o.db.Get(orderDB, query, id)
 
@@ -253,7 +387,46 @@ func (o OrderRepository) GetTasksInOrder(id uuid.UUID) ([]models.Task, error) {
 	return taskModels, nil
 }
 
-// GetCurrentOrderByUserID retrieves the most recent order for a specific user.
+// GetOrderedTasks retrieves the tasks of an order together with their
+// quantities in a single join query. The price of each task is the unit price
+// stored when it was added to the order, volume tier included.
+//
+// Parameters:
+//   - orderID: UUID of the order
+//
+// Returns:
+//   - []models.OrderedTask: Tasks of the order with their quantities, ordered by name
+//   - error: repository_errors.SelectError if the operation fails
+func (o OrderRepository) GetOrderedTasks(orderID uuid.UUID) ([]models.OrderedTask, error) {
+	query := `SELECT tasks.*, order_contains_tasks.quantity, ` + tieredUnitPrice + ` AS price_at_order FROM order_contains_tasks
+		JOIN tasks ON tasks.id = order_contains_tasks.task_id
+		WHERE order_contains_tasks.order_id = $1
+		ORDER BY tasks.name;`
+	var tasksDB []orderedTaskDB
+	err := o.db.Select(&tasksDB, query, orderID)
+	if err != nil {
+		return nil, repository_errors.SelectError
+	}
+
+	var orderedTasks []models.OrderedTask
+	for i := range tasksDB {
+		task := copyTaskResultToModel(&tasksDB[i].TaskDB)
+		if tasksDB[i].PriceAtOrder != nil {
+			task.PricePerSingle = *tasksDB[i].PriceAtOrder
+		}
+		orderedTasks = append(orderedTasks, models.OrderedTask{
+			Task:     task,
+			Quantity: tasksDB[i].Quantity,
+		})
+	}
+
+	return orderedTasks, nil
+}
+
+// GetCurrentOrderByUserID retrieves the current order of a specific user. The
+// most recent new or in-progress order is current, so a newer cancelled or
+// completed order does not hide an active one. Only if the user has no active
+// orders is the most recent order of any status returned.
 //
 // Parameters:
 //   - id: UUID of the user to retrieve the current order for
@@ -263,9 +436,10 @@ func (o OrderRepository) GetTasksInOrder(id uuid.UUID) ([]models.Task, error) {
 //   - error: repository_errors.DoesNotExist if no order found,
 //     repository_errors.SelectError for other failures
 func (o OrderRepository) GetCurrentOrderByUserID(id uuid.UUID) (*models.Order, error) {
-	query := `SELECT * FROM orders WHERE user_id = $1 ORDER BY creation_date DESC LIMIT 1;`
+	query := `SELECT * FROM orders WHERE user_id = $1 AND deleted_at IS NULL
+		ORDER BY status IN ($2, $3) DESC, creation_date DESC LIMIT 1;`
 	orderDB := &OrderDB{}
-	err := o.db.Get(orderDB, query, id)
+	err := o.db.Get(orderDB, query, id, models.NewOrderStatus, models.InProgressOrderStatus)
 
 	if errors.Is(err, sql.ErrNoRows) {
 		return nil, repository_errors.DoesNotExist
@@ -278,16 +452,43 @@ func (o OrderRepository) GetCurrentOrderByUserID(id uuid.UUID) (*models.Order, e
 	return orderModels, nil
 }
 
-// GetAllOrdersByUserID retrieves all orders for a specific user.
+// GetAllOrdersByUserID retrieves all orders for a specific user, except deleted ones.
 //
 // Parameters:
 //   - id: UUID of the user to retrieve orders for
 //
 // Returns:
-//   - []models.Order: Slice of order entities for the specified user
+//   - []models.Order: Slice of order entities for the specified user, newest first
 //   - error: repository_errors.SelectError if the operation fails
 func (o OrderRepository) GetAllOrdersByUserID(id uuid.UUID) ([]models.Order, error) {
-	query := `SELECT * FROM orders WHERE user_id = $1;`
+	query := `SELECT * FROM orders WHERE user_id = $1 AND deleted_at IS NULL ORDER BY creation_date DESC, id;`
+	var orderDB []OrderDB
+
+	err := o.db.Select(&orderDB, query, id)
+
+	if err != nil {
+		return nil, repository_errors.SelectError
+	}
+
+	var orderModels []models.Order
+	for i := range orderDB {
+		order := copyOrderResultToModel(&orderDB[i])
+		orderModels = append(orderModels, *order)
+	}
+
+	return orderModels, nil
+}
+
+// GetAllOrdersByWorkerID retrieves all orders assigned to a specific worker, except deleted ones.
+//
+// Parameters:
+//   - id: UUID of the worker to retrieve orders for
+//
+// Returns:
+//   - []models.Order: Slice of order entities assigned to the specified worker, newest first
+//   - error: repository_errors.SelectError if the operation fails
+func (o OrderRepository) GetAllOrdersByWorkerID(id uuid.UUID) ([]models.Order, error) {
+	query := `SELECT * FROM orders WHERE worker_id = $1 AND deleted_at IS NULL ORDER BY creation_date DESC, id;`
 	var orderDB []OrderDB
 
 	err := o.db.Select(&orderDB, query, id)
@@ -305,62 +506,148 @@ func (o OrderRepository) GetAllOrdersByUserID(id uuid.UUID) ([]models.Order, err
 	return orderModels, nil
 }
 
+// GetOrdersByUserIDPaged retrieves one page of a user's orders, newest first,
+// together with the total number of the user's orders. Deleted orders are skipped.
+//
+// Parameters:
+//   - userID: UUID of the user to retrieve orders for
+//   - limit: Maximum number of orders to return
+//   - offset: Number of orders to skip
+//
+// Returns:
+//   - []models.Order: Slice of order entities on the requested page
+//   - int: Total number of orders of the user
+//   - error: repository_errors.SelectError if the operation fails
+func (o OrderRepository) GetOrdersByUserIDPaged(userID uuid.UUID, limit int, offset int) ([]models.Order, int, error) {
+	var total int
+	err := o.db.Get(&total, `SELECT COUNT(*) FROM orders WHERE user_id = $1 AND deleted_at IS NULL;`, userID)
+	if err != nil {
+		return nil, 0, repository_errors.SelectError
+	}
+
+	query := `SELECT * FROM orders WHERE user_id = $1 AND deleted_at IS NULL ORDER BY creation_date DESC, id LIMIT $2 OFFSET $3;`
+	var orderDB []OrderDB
+
+	err = o.db.Select(&orderDB, query, userID, limit, offset)
+	if err != nil {
+		return nil, 0, repository_errors.SelectError
+	}
+
+	var orderModels []models.Order
+	for i := range orderDB {
+		order := copyOrderResultToModel(&orderDB[i])
+		orderModels = append(orderModels, *order)
+	}
+
+	return orderModels, total, nil
+}
+
+// filterableOrderColumns lists the orders columns that may be used as Filter keys.
+// Column names cannot be passed as query arguments, so they are checked against
+// this list before being written into the query.
+var filterableOrderColumns = map[string]bool{
+	"id":            true,
+	"worker_id":     true,
+	"user_id":       true,
+	"status":        true,
+	"address":       true,
+	"creation_date": true,
+	"deadline":      true,
+	"rate":          true,
+	"quoted_total":  true,
+	"assigned_at":   true,
+	"completed_at":  true,
+	"cancelled_at":  true,
+	"deleted_at":    true,
+}
+
 // Filter retrieves orders matching the specified criteria.
 // Supports flexible filtering by multiple fields and multiple values per field.
+// Filter values are always passed as query arguments and never interpolated into the query.
+// Orders are returned newest first, so consecutive pages do not overlap.
 //
 // Parameters:
 //   - params: Map of field names to filter values
 //     (values can be comma-separated for OR conditions)
+//   - limit: Maximum number of orders to return, 0 for no limit
+//   - offset: Number of matching orders to skip
+//   - includeDeleted: Whether deleted orders are returned as well
 //
 // Returns:
 //   - []models.Order: Slice of order entities matching the filter criteria
-//   - error: repository_errors.SelectError if the operation fails
-func (o OrderRepository) Filter(params map[string]string) ([]models.Order, error) {
+//   - error: repository_errors.SelectError if the operation fails or a field is unknown
+func (o OrderRepository) Filter(params map[string]string, limit int, offset int, includeDeleted bool) ([]models.Order, error) {
 	var query strings.Builder
 	query.WriteString("SELECT * FROM orders")
 
-	if len(params) > 0 {
-		query.WriteString(" WHERE ")
-		for field, value := range params {
-			// Разделяем значения по запятой
-			values := strings.Split(value, ",")
-			if len(values) > 1 {
-				// Если есть несколько значений, создаем условие SQL с OR
-				query.WriteString("(")
-				for _, v := range values {
-					if v == "null" {
-						query.WriteString(fmt.Sprintf("%s IS NULL OR ", field))
-					} else if field == "status" {
-						query.WriteString(fmt.Sprintf("%s = %s OR ", field, v))
-					} else {
-						query.WriteString(fmt.Sprintf("%s::text LIKE '%s' OR ", field, v))
-					}
-				}
-				// Удаляем последний " OR "
-				str := query.String()[:query.Len()-4]
-				query.Reset()
-				query.WriteString(str)
-				query.WriteString(") AND ")
-			} else {
-				if value == "null" {
-					query.WriteString(fmt.Sprintf("%s IS NULL AND ", field))
-				} else if value == "not null" {
-					query.WriteString(fmt.Sprintf("%s IS NOT NULL AND ", field))
-				} else if field == "status" {
-					query.WriteString(fmt.Sprintf("%s = %s AND ", field, value))
-				} else {
-					query.WriteString(fmt.Sprintf("%s::text LIKE '%s' AND ", field, value))
-				}
+	fields := make([]string, 0, len(params))
+	for field := range params {
+		if !filterableOrderColumns[field] {
+			return nil, repository_errors.SelectError
+		}
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+
+	var args []interface{}
+	condition := func(field string, value string, single bool) (string, error) {
+		switch {
+		case value == "null":
+			return fmt.Sprintf("%s IS NULL", field), nil
+		case value == "not null" && single:
+			return fmt.Sprintf("%s IS NOT NULL", field), nil
+		case field == "status":
+			status, err := strconv.Atoi(strings.TrimSpace(value))
+			if err != nil {
+				return "", err
+			}
+			args = append(args, status)
+			return fmt.Sprintf("%s = $%d", field, len(args)), nil
+		default:
+			args = append(args, value)
+			return fmt.Sprintf("%s::text LIKE $%d", field, len(args)), nil
+		}
+	}
+
+	conditions := make([]string, 0, len(fields))
+	for _, field := range fields {
+		// Разделяем значения по запятой
+		values := strings.Split(params[field], ",")
+		alternatives := make([]string, 0, len(values))
+		for _, v := range values {
+			cond, err := condition(field, v, len(values) == 1)
+			if err != nil {
+				return nil, repository_errors.SelectError
 			}
+			alternatives = append(alternatives, cond)
+		}
+		if len(alternatives) > 1 {
+			// Если есть несколько значений, создаем условие SQL с OR
+			conditions = append(conditions, "("+strings.Join(alternatives, " OR ")+")")
+		} else {
+			conditions = append(conditions, alternatives[0])
 		}
-		// Удаляем последний " AND "
-		str := query.String()[:query.Len()-5]
-		query.Reset()
-		query.WriteString(str)
+	}
+	if !includeDeleted {
+		conditions = append(conditions, "deleted_at IS NULL")
+	}
+	if len(conditions) > 0 {
+		query.WriteString(" WHERE ")
+		query.WriteString(strings.Join(conditions, " AND "))
+	}
+
+	query.WriteString(" ORDER BY creation_date DESC, id")
+	if limit > 0 {
+		args = append(args, limit)
+		query.WriteString(fmt.Sprintf(" LIMIT $%d", len(args)))
+	}
+	if offset > 0 {
+		args = append(args, offset)
+		query.WriteString(fmt.Sprintf(" OFFSET $%d", len(args)))
 	}
 
 	var orderDB []OrderDB
-	err := o.db.Select(&orderDB, query.String())
+	err := o.db.Select(&orderDB, query.String(), args...)
 
 	if err != nil {
 		return nil, repository_errors.SelectError
@@ -375,17 +662,21 @@ func (o OrderRepository) Filter(params map[string]string) ([]models.Order, error
 	return orderModels, nil
 }
 
-// AddTaskToOrder associates a task with an order.
+// AddTaskToOrder associates a task with an order. The current unit price of the
+// task, volume tier included, is stored with the line, so later price changes
+// do not affect the order.
 //
 // Parameters:
 //   - orderID: UUID of the order
 //   - taskID: UUID of the task to add
+//   - quantity: Initial quantity of the task in the order
 //
 // Returns:
 //   - error: repository_errors.InsertError if the operation fails
-func (o OrderRepository) AddTaskToOrder(orderID uuid.UUID, taskID uuid.UUID) error {
-	query := `INSERT INTO order_contains_tasks(order_id, task_id) VALUES ($1, $2);`
-	_, err := o.db.Exec(query, orderID, taskID)
+func (o OrderRepository) AddTaskToOrder(orderID uuid.UUID, taskID uuid.UUID, quantity int) error {
+	query := `INSERT INTO order_contains_tasks(order_id, task_id, quantity, price_at_order)
+		VALUES ($1, $2, $3, ` + orderedUnitPrice + `);`
+	_, err := o.db.Exec(query, orderID, taskID, quantity)
 
 	if err != nil {
 		return repository_errors.InsertError
@@ -394,6 +685,172 @@ func (o OrderRepository) AddTaskToOrder(orderID uuid.UUID, taskID uuid.UUID) err
 	return nil
 }
 
+// AddTasksToOrder associates several tasks with an order in one transaction.
+// If any of the tasks is already attached to the order, including a task listed
+// twice, the transaction is rolled back and nothing is inserted.
+//
+// Parameters:
+//   - orderID: UUID of the order
+//   - orderedTasks: Tasks to add together with their quantities
+//
+// Returns:
+//   - error: repository_errors.AlreadyExists if a task is already attached,
+//     repository_errors.TransactionBeginError, repository_errors.TransactionRollbackError,
+//     repository_errors.SelectError, repository_errors.InsertError, or
+//     repository_errors.TransactionCommitError if the operation fails
+func (o OrderRepository) AddTasksToOrder(orderID uuid.UUID, orderedTasks []models.OrderedTask) error {
+	transaction, err := o.db.Begin()
+	if err != nil {
+		return repository_errors.TransactionBeginError
+	}
+
+	for _, task := range orderedTasks {
+		var attached bool
+		err = transaction.QueryRow(`SELECT EXISTS(SELECT 1 FROM order_contains_tasks WHERE order_id = $1 AND task_id = $2);`,
+			orderID, task.Task.ID).Scan(&attached)
+		if err != nil {
+			err = transaction.Rollback()
+			if err != nil {
+				return repository_errors.TransactionRollbackError
+			}
+			return repository_errors.SelectError
+		}
+
+		if attached {
+			err = transaction.Rollback()
+			if err != nil {
+				return repository_errors.TransactionRollbackError
+			}
+			return repository_errors.AlreadyExists
+		}
+
+		_, err = transaction.Exec(`INSERT INTO order_contains_tasks(order_id, task_id, quantity, price_at_order)
+			VALUES ($1, $2, $3, `+orderedUnitPrice+`);`,
+			orderID, task.Task.ID, task.Quantity)
+		if err != nil {
+			err = transaction.Rollback()
+			if err != nil {
+				return repository_errors.TransactionRollbackError
+			}
+			return repository_errors.InsertError
+		}
+	}
+
+	err = transaction.Commit()
+	if err != nil {
+		return repository_errors.TransactionCommitError
+	}
+
+	return nil
+}
+
+// applicableTierPrice is the SQL expression for the price of the largest volume
+// tier that applies to the quantity of an order line, NULL when no tier applies.
+const applicableTierPrice = `(
+		SELECT task_price_tiers.price FROM task_price_tiers
+		WHERE task_price_tiers.task_id = tasks.id AND task_price_tiers.min_quantity <= order_contains_tasks.quantity
+		ORDER BY task_price_tiers.min_quantity DESC LIMIT 1
+	)`
+
+// orderedUnitPrice is the SQL expression for the unit price stored with a new
+// order line of task $2 with quantity $3: the price of the largest applicable
+// volume tier, or the task price.
+const orderedUnitPrice = `COALESCE(
+		(SELECT price FROM task_price_tiers WHERE task_id = $2 AND min_quantity <= $3 ORDER BY min_quantity DESC LIMIT 1),
+		(SELECT price_per_single FROM tasks WHERE id = $2)
+	)`
+
+// tieredUnitPrice is the SQL expression for the price per unit of an order line:
+// the unit price stored when the task was added to the order, so later changes
+// of task prices and tiers do not affect the order. Lines added before prices
+// were stored fall back to the current tier or task price.
+const tieredUnitPrice = `COALESCE(order_contains_tasks.price_at_order, ` + applicableTierPrice + `, tasks.price_per_single)`
+
+// currentTieredUnitPrice is like tieredUnitPrice, but ignores the stored unit
+// price and uses the current tier or task price.
+const currentTieredUnitPrice = `COALESCE(` + applicableTierPrice + `, tasks.price_per_single)`
+
+// GetOrderTotalPrice computes the total price of an order from the unit prices
+// stored when the tasks were added and their quantities in a single aggregate
+// query, so later changes of task prices and tiers do not affect the order.
+//
+// Parameters:
+//   - orderID: UUID of the order
+//
+// Returns:
+//   - float64: Total price of the order, 0 if it has no tasks
+//   - error: repository_errors.SelectError if the operation fails
+func (o OrderRepository) GetOrderTotalPrice(orderID uuid.UUID) (float64, error) {
+	query := `SELECT COALESCE(SUM(` + tieredUnitPrice + ` * order_contains_tasks.quantity), 0)
+		FROM order_contains_tasks JOIN tasks ON tasks.id = order_contains_tasks.task_id
+		WHERE order_contains_tasks.order_id = $1;`
+	var total float64
+
+	err := o.db.Get(&total, query, orderID)
+
+	if err != nil {
+		return 0, repository_errors.SelectError
+	}
+
+	return total, nil
+}
+
+// roundedAmount returns the SQL expression rounding a monetary amount to whole
+// cents with the given mode, like models.RoundingMode.Round does.
+//
+// Parameters:
+//   - amount: SQL expression of the amount, evaluated several times
+//   - rounding: Rounding mode to apply
+//
+// Returns:
+//   - string: SQL expression of the rounded amount
+func roundedAmount(amount string, rounding models.RoundingMode) string {
+	cents := `ROUND((` + amount + `)::numeric * 100, 6)`
+
+	var rounded string
+	switch rounding {
+	case models.RoundHalfEven:
+		rounded = `CASE WHEN ` + cents + ` - FLOOR(` + cents + `) = 0.5
+			THEN FLOOR(` + cents + `) + MOD(FLOOR(` + cents + `), 2)
+			ELSE ROUND(` + cents + `) END`
+	case models.RoundUp:
+		rounded = `CEIL(` + cents + `)`
+	case models.RoundDown:
+		rounded = `FLOOR(` + cents + `)`
+	default:
+		rounded = `ROUND(` + cents + `)`
+	}
+
+	return `((` + rounded + `) / 100)::float8`
+}
+
+// RequoteOrder sets the quoted total of an order to the sum of its tasks at the
+// unit prices stored with them, rounded with the given mode, in a single statement.
+// Deleted orders are left alone.
+//
+// Parameters:
+//   - orderID: UUID of the order
+//   - rounding: Rounding mode applied to the total
+//
+// Returns:
+//   - error: repository_errors.UpdateError if the operation fails
+func (o OrderRepository) RequoteOrder(orderID uuid.UUID, rounding models.RoundingMode) error {
+	query := `UPDATE orders SET quoted_total = ` + roundedAmount("order_lines.total", rounding) + `
+		FROM (
+			SELECT COALESCE(SUM(` + tieredUnitPrice + ` * order_contains_tasks.quantity), 0) AS total
+			FROM order_contains_tasks JOIN tasks ON tasks.id = order_contains_tasks.task_id
+			WHERE order_contains_tasks.order_id = $1
+		) AS order_lines
+		WHERE orders.id = $1 AND orders.deleted_at IS NULL;`
+	_, err := o.db.Exec(query, orderID)
+
+	if err != nil {
+		return repository_errors.UpdateError
+	}
+
+	return nil
+}
+
 // RemoveTaskFromOrder removes a task association from an order.
 //
 // Parameters:
@@ -433,26 +890,737 @@ func (o OrderRepository) UpdateTaskQuantity(orderID uuid.UUID, taskID uuid.UUID,
 	return nil
 }
 
-// GetTaskQuantity retrieves the quantity of a specific task in an order.
+// IncrementTaskQuantity increases the quantity of a task in an order by one
+// in a single statement, so concurrent increments are never lost.
 //
 // Parameters:
 //   - orderID: UUID of the order
 //   - taskID: UUID of the task
 //
 // Returns:
-//   - int: Quantity of the task in the order
+//   - int: Quantity of the task after the increment
 //   - error: repository_errors.DoesNotExist if the task is not in the order,
-//     repository_errors.SelectError for other failures
-func (o OrderRepository) GetTaskQuantity(orderID uuid.UUID, taskID uuid.UUID) (int, error) {
-	query := `SELECT quantity FROM order_contains_tasks WHERE order_id = $1 AND task_id = $2 LIMIT 1;`
+//     repository_errors.UpdateError for other failures
+func (o OrderRepository) IncrementTaskQuantity(orderID uuid.UUID, taskID uuid.UUID) (int, error) {
+	query := `UPDATE order_contains_tasks SET quantity = quantity + 1 WHERE order_id = $1 AND task_id = $2 RETURNING quantity;`
 	var quantity int
 
 	err := o.db.Get(&quantity, query, orderID, taskID)
 	if errors.Is(err, sql.ErrNoRows) {
 		return 0, repository_errors.DoesNotExist
 	} else if err != nil {
-		return 0, repository_errors.SelectError
+		return 0, repository_errors.UpdateError
+	}
+
+	return quantity, nil
+}
+
+// DecrementTaskQuantity decreases a positive quantity of a task in an order by
+// one in a single statement, so concurrent decrements are never lost and the
+// quantity never drops below 0.
+//
+// Parameters:
+//   - orderID: UUID of the order
+//   - taskID: UUID of the task
+//
+// Returns:
+//   - int: Quantity of the task after the decrement
+//   - error: repository_errors.DoesNotExist if the task is not in the order or its
+//     quantity is already 0, repository_errors.UpdateError for other failures
+func (o OrderRepository) DecrementTaskQuantity(orderID uuid.UUID, taskID uuid.UUID) (int, error) {
+	query := `UPDATE order_contains_tasks SET quantity = quantity - 1 WHERE order_id = $1 AND task_id = $2 AND quantity > 0 RETURNING quantity;`
+	var quantity int
+
+	err := o.db.Get(&quantity, query, orderID, taskID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, repository_errors.DoesNotExist
+	} else if err != nil {
+		return 0, repository_errors.UpdateError
 	}
 
 	return quantity, nil
 }
+
+// GetTaskQuantity retrieves the quantity of a specific task in an order.
+//
+// Parameters:
+//   - orderID: UUID of the order
+//   - taskID: UUID of the task
+//
+// Returns:
+//   - int: Quantity of the task in the order
+//   - error: repository_errors.DoesNotExist if the task is not in the order,
+//     repository_errors.SelectError for other failures
+func (o OrderRepository) GetTaskQuantity(orderID uuid.UUID, taskID uuid.UUID) (int, error) {
+	query := `SELECT quantity FROM order_contains_tasks WHERE order_id = $1 AND task_id = $2 LIMIT 1;`
+	var quantity int
+
+	err := o.db.Get(&quantity, query, orderID, taskID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, repository_errors.DoesNotExist
+	} else if err != nil {
+		return 0, repository_errors.SelectError
+	}
+
+	return quantity, nil
+}
+
+// GetOrdersByIDs retrieves all orders whose identifiers are in the given list
+// with a single query. Identifiers without a matching order, or of deleted orders,
+// are simply absent from the result.
+//
+// Parameters:
+//   - ids: Slice of order UUIDs to retrieve
+//
+// Returns:
+//   - []models.Order: Slice of found order entities
+//   - error: repository_errors.SelectError if the operation fails
+func (o OrderRepository) GetOrdersByIDs(ids []uuid.UUID) ([]models.Order, error) {
+	if len(ids) == 0 {
+		return []models.Order{}, nil
+	}
+
+	stringIDs := make([]string, len(ids))
+	for i, id := range ids {
+		stringIDs[i] = id.String()
+	}
+
+	query := `SELECT * FROM orders WHERE id = ANY($1::uuid[]) AND deleted_at IS NULL;`
+	var orderDB []OrderDB
+
+	err := o.db.Select(&orderDB, query, stringIDs)
+
+	if err != nil {
+		return nil, repository_errors.SelectError
+	}
+
+	var orderModels []models.Order
+	for i := range orderDB {
+		order := copyOrderResultToModel(&orderDB[i])
+		orderModels = append(orderModels, *order)
+	}
+
+	return orderModels, nil
+}
+
+// FilterByStatusAndDate retrieves orders of a user matching any of the given
+// statuses and created within the given period, newest first. Conditions are only
+// added for a non-nil user, a non-empty status slice and non-zero dates, and all
+// values are passed as query parameters. Deleted orders are skipped.
+//
+// Parameters:
+//   - userID: UUID of the customer, uuid.Nil for orders of every customer
+//   - statuses: Slice of status codes to match
+//   - from: Start of the creation period (inclusive)
+//   - to: End of the creation period (inclusive)
+//
+// Returns:
+//   - []models.Order: Slice of order entities matching the filter criteria
+//   - error: repository_errors.SelectError if the operation fails
+func (o OrderRepository) FilterByStatusAndDate(userID uuid.UUID, statuses []int, from time.Time, to time.Time) ([]models.Order, error) {
+	conditions := []string{"deleted_at IS NULL"}
+	var args []interface{}
+
+	if userID != uuid.Nil {
+		args = append(args, userID)
+		conditions = append(conditions, fmt.Sprintf("user_id = $%d", len(args)))
+	}
+
+	if len(statuses) > 0 {
+		placeholders := make([]string, len(statuses))
+		for i, status := range statuses {
+			args = append(args, status)
+			placeholders[i] = fmt.Sprintf("$%d", len(args))
+		}
+		conditions = append(conditions, fmt.Sprintf("status IN (%s)", strings.Join(placeholders, ", ")))
+	}
+	if !from.IsZero() {
+		args = append(args, from)
+		conditions = append(conditions, fmt.Sprintf("creation_date >= $%d", len(args)))
+	}
+	if !to.IsZero() {
+		args = append(args, to)
+		conditions = append(conditions, fmt.Sprintf("creation_date <= $%d", len(args)))
+	}
+
+	query := "SELECT * FROM orders WHERE " + strings.Join(conditions, " AND ")
+	query += " ORDER BY creation_date DESC, id;"
+
+	var orderDB []OrderDB
+	err := o.db.Select(&orderDB, query, args...)
+
+	if err != nil {
+		return nil, repository_errors.SelectError
+	}
+
+	var orderModels []models.Order
+	for i := range orderDB {
+		order := copyOrderResultToModel(&orderDB[i])
+		orderModels = append(orderModels, *order)
+	}
+
+	return orderModels, nil
+}
+
+// GetOverdueOrders retrieves new and in-progress orders whose deadline is before
+// the given moment, the most overdue first. Deleted orders are skipped.
+//
+// Parameters:
+//   - now: Moment the deadlines are compared with
+//
+// Returns:
+//   - []models.Order: Slice of overdue order entities
+//   - error: repository_errors.SelectError if the operation fails
+func (o OrderRepository) GetOverdueOrders(now time.Time) ([]models.Order, error) {
+	query := `SELECT * FROM orders
+	WHERE deleted_at IS NULL AND status IN ($1, $2) AND deadline < $3
+	ORDER BY deadline, id;`
+	var orderDB []OrderDB
+
+	err := o.db.Select(&orderDB, query, models.NewOrderStatus, models.InProgressOrderStatus, now)
+	if err != nil {
+		return nil, repository_errors.SelectError
+	}
+
+	var orderModels []models.Order
+	for i := range orderDB {
+		order := copyOrderResultToModel(&orderDB[i])
+		orderModels = append(orderModels, *order)
+	}
+
+	return orderModels, nil
+}
+
+// GetDigestBetween aggregates order activity within the given period in a single query.
+// Orders count as overdue when they are still new or in progress and their deadline
+// is before the end of the period. Completed orders are billed like in other revenue
+// reports. Deleted orders are skipped.
+//
+// Parameters:
+//   - from: Start of the period (inclusive)
+//   - to: End of the period (exclusive)
+//
+// Returns:
+//   - *models.DailyDigest: Aggregated counters for the period
+//   - error: repository_errors.SelectError if the operation fails
+func (o OrderRepository) GetDigestBetween(from time.Time, to time.Time) (*models.DailyDigest, error) {
+	query := `SELECT
+		COUNT(*) FILTER (WHERE creation_date >= $1 AND creation_date < $2) AS new_orders,
+		COUNT(*) FILTER (WHERE status = $3 AND completed_at >= $1 AND completed_at < $2) AS completed_orders,
+		COUNT(*) FILTER (WHERE status = $4 AND cancelled_at >= $1 AND cancelled_at < $2) AS cancelled_orders,
+		COALESCE(SUM(` + billedOrderTotal + `) FILTER (WHERE status = $3 AND completed_at >= $1 AND completed_at < $2), 0) AS revenue,
+		COUNT(*) FILTER (WHERE status IN ($5, $6) AND deadline < $2) AS overdue_orders
+	FROM orders
+	WHERE deleted_at IS NULL;`
+
+	var digestDB struct {
+		NewOrders       int     `db:"new_orders"`
+		CompletedOrders int     `db:"completed_orders"`
+		CancelledOrders int     `db:"cancelled_orders"`
+		Revenue         float64 `db:"revenue"`
+		OverdueOrders   int     `db:"overdue_orders"`
+	}
+
+	err := o.db.Get(&digestDB, query, from, to, models.CompletedOrderStatus, models.CancelledOrderStatus, models.NewOrderStatus, models.InProgressOrderStatus)
+
+	if err != nil {
+		return nil, repository_errors.SelectError
+	}
+
+	return &models.DailyDigest{
+		Day:             from,
+		NewOrders:       digestDB.NewOrders,
+		CompletedOrders: digestDB.CompletedOrders,
+		CancelledOrders: digestDB.CancelledOrders,
+		Revenue:         digestDB.Revenue,
+		OverdueOrders:   digestDB.OverdueOrders,
+	}, nil
+}
+
+// billedOrderTotal is the SQL expression for the billed total of an order, used
+// by every revenue report. An order is billed at its quoted total; orders created
+// before totals were quoted fall back to the prices of their tasks.
+const billedOrderTotal = `COALESCE(NULLIF(orders.quoted_total, 0), (
+		SELECT SUM(` + tieredUnitPrice + ` * order_contains_tasks.quantity)
+		FROM order_contains_tasks JOIN tasks ON tasks.id = order_contains_tasks.task_id
+		WHERE order_contains_tasks.order_id = orders.id
+	), 0)`
+
+// billedOrderTotals is the SQL subquery for the billed total of every order
+// completed between $2 and $3, with $1 the completed status. Deleted orders
+// are skipped.
+const billedOrderTotals = `SELECT ` + billedOrderTotal + ` AS total
+		FROM orders
+		WHERE orders.status = $1 AND orders.completed_at BETWEEN $2 AND $3 AND orders.deleted_at IS NULL`
+
+// GetAverageOrderValue computes the mean billed total of orders completed
+// within the given period in a single aggregate query. Orders are billed like
+// in GetRevenueSummary, so both reports agree on the average.
+//
+// Parameters:
+//   - from: Start of the period (inclusive)
+//   - to: End of the period (inclusive)
+//
+// Returns:
+//   - float64: Average order value, 0 if no orders were completed in the period
+//   - error: repository_errors.SelectError if the operation fails
+func (o OrderRepository) GetAverageOrderValue(from time.Time, to time.Time) (float64, error) {
+	query := `SELECT COALESCE(AVG(total), 0)::float8 FROM (` + billedOrderTotals + `) AS billed;`
+	var average float64
+
+	err := o.db.Get(&average, query, models.CompletedOrderStatus, from, to)
+
+	if err != nil {
+		return 0, repository_errors.SelectError
+	}
+
+	return average, nil
+}
+
+// GetRevenueSummary totals the orders completed within the given period in a
+// single aggregate query. An order is billed at its quoted total; orders created
+// before totals were quoted fall back to the prices of their tasks. Cancelled
+// and deleted orders are never counted, like in other revenue reports.
+//
+// Parameters:
+//   - from: Start of the period (inclusive)
+//   - to: End of the period (inclusive)
+//
+// Returns:
+//   - *models.RevenueSummary: Revenue, number and average value of the orders
+//   - error: repository_errors.SelectError if the operation fails
+func (o OrderRepository) GetRevenueSummary(from time.Time, to time.Time) (*models.RevenueSummary, error) {
+	query := `SELECT COALESCE(SUM(total), 0)::float8 AS revenue, COUNT(*) AS order_count, COALESCE(AVG(total), 0)::float8 AS average_order_value
+	FROM (` + billedOrderTotals + `) AS billed;`
+
+	var summaryDB struct {
+		Revenue           float64 `db:"revenue"`
+		OrderCount        int     `db:"order_count"`
+		AverageOrderValue float64 `db:"average_order_value"`
+	}
+
+	err := o.db.Get(&summaryDB, query, models.CompletedOrderStatus, from, to)
+
+	if err != nil {
+		return nil, repository_errors.SelectError
+	}
+
+	return &models.RevenueSummary{
+		Revenue:           summaryDB.Revenue,
+		OrderCount:        summaryDB.OrderCount,
+		AverageOrderValue: summaryDB.AverageOrderValue,
+	}, nil
+}
+
+// GetOrdersByRateBelow retrieves completed orders rated below the threshold.
+// Unrated orders, stored with a rating of 0, and deleted orders are skipped.
+//
+// Parameters:
+//   - threshold: Rating the orders must be below
+//
+// Returns:
+//   - []models.Order: Slice of matching order entities, lowest rated first
+//   - error: repository_errors.SelectError if the operation fails
+func (o OrderRepository) GetOrdersByRateBelow(threshold int) ([]models.Order, error) {
+	query := `SELECT * FROM orders WHERE status = $1 AND rate != 0 AND rate < $2 AND deleted_at IS NULL
+		ORDER BY rate, completed_at DESC;`
+	var orderDB []OrderDB
+
+	err := o.db.Select(&orderDB, query, models.CompletedOrderStatus, threshold)
+
+	if err != nil {
+		return nil, repository_errors.SelectError
+	}
+
+	var orderModels []models.Order
+	for i := range orderDB {
+		order := copyOrderResultToModel(&orderDB[i])
+		orderModels = append(orderModels, *order)
+	}
+
+	return orderModels, nil
+}
+
+// SearchByCustomerName retrieves orders placed by users whose name, surname or
+// full name contains the given substring, ignoring case. The substring is passed
+// as a bound parameter and matched literally. Deleted orders are skipped.
+//
+// Parameters:
+//   - substring: Part of the customer name to search for
+//
+// Returns:
+//   - []models.Order: Slice of matching order entities, newest first
+//   - error: repository_errors.SelectError if the operation fails
+func (o OrderRepository) SearchByCustomerName(substring string) ([]models.Order, error) {
+	query := `SELECT orders.* FROM orders JOIN users ON users.id = orders.user_id
+		WHERE orders.deleted_at IS NULL
+		  AND (users.name ILIKE '%' || $1 || '%'
+		   OR users.surname ILIKE '%' || $1 || '%'
+		   OR (users.name || ' ' || users.surname) ILIKE '%' || $1 || '%')
+		ORDER BY orders.creation_date DESC;`
+	var orderDB []OrderDB
+
+	err := o.db.Select(&orderDB, query, likePatternEscaper.Replace(substring))
+
+	if err != nil {
+		return nil, repository_errors.SelectError
+	}
+
+	var orderModels []models.Order
+	for i := range orderDB {
+		order := copyOrderResultToModel(&orderDB[i])
+		orderModels = append(orderModels, *order)
+	}
+
+	return orderModels, nil
+}
+
+// SearchByAddress retrieves orders whose address contains the given substring,
+// ignoring case. The substring is passed as a bound parameter and matched
+// literally. Deleted orders are skipped.
+//
+// Parameters:
+//   - substring: Part of the address to search for
+//
+// Returns:
+//   - []models.Order: Slice of matching order entities, newest first
+//   - error: repository_errors.SelectError if the operation fails
+func (o OrderRepository) SearchByAddress(substring string) ([]models.Order, error) {
+	query := `SELECT * FROM orders WHERE deleted_at IS NULL AND address ILIKE '%' || $1 || '%'
+		ORDER BY creation_date DESC;`
+	var orderDB []OrderDB
+
+	err := o.db.Select(&orderDB, query, likePatternEscaper.Replace(substring))
+
+	if err != nil {
+		return nil, repository_errors.SelectError
+	}
+
+	var orderModels []models.Order
+	for i := range orderDB {
+		order := copyOrderResultToModel(&orderDB[i])
+		orderModels = append(orderModels, *order)
+	}
+
+	return orderModels, nil
+}
+
+// orderedTaskDB represents a task joined with its quantity in an order or a draft.
+type orderedTaskDB struct {
+	TaskDB
+	Quantity     int      `db:"quantity"`       // Number of ordered units
+	PriceAtOrder *float64 `db:"price_at_order"` // Task price stored with the order line, nil for drafts
+}
+
+// SaveDraft stores the customer's draft order in a single transaction. The
+// previous draft of the customer and its tasks are replaced.
+//
+// Parameters:
+//   - draft: Draft order with the customer ID, address and selected tasks
+//
+// Returns:
+//   - error: repository_errors.InsertError if the operation fails, or a transaction error
+func (o OrderRepository) SaveDraft(draft *models.DraftOrder) error {
+	transaction, err := o.db.Begin()
+	if err != nil {
+		return repository_errors.TransactionBeginError
+	}
+
+	query := `INSERT INTO draft_orders(user_id, address, updated_at) VALUES ($1, $2, now())
+		ON CONFLICT (user_id) DO UPDATE SET address = EXCLUDED.address, updated_at = EXCLUDED.updated_at
+		RETURNING updated_at;`
+	err = transaction.QueryRow(query, draft.UserID, draft.Address).Scan(&draft.UpdatedAt)
+	if err != nil {
+		err = transaction.Rollback()
+		if err != nil {
+			return repository_errors.TransactionRollbackError
+		}
+		return repository_errors.InsertError
+	}
+
+	_, err = transaction.Exec(`DELETE FROM draft_order_contains_tasks WHERE user_id = $1;`, draft.UserID)
+	if err != nil {
+		err = transaction.Rollback()
+		if err != nil {
+			return repository_errors.TransactionRollbackError
+		}
+		return repository_errors.InsertError
+	}
+
+	for _, task := range draft.Tasks {
+		query = `INSERT INTO draft_order_contains_tasks(user_id, task_id, quantity) VALUES ($1, $2, $3);`
+		_, err = transaction.Exec(query, draft.UserID, task.Task.ID, task.Quantity)
+		if err != nil {
+			err = transaction.Rollback()
+			if err != nil {
+				return repository_errors.TransactionRollbackError
+			}
+			return repository_errors.InsertError
+		}
+	}
+
+	err = transaction.Commit()
+	if err != nil {
+		return repository_errors.TransactionCommitError
+	}
+
+	return nil
+}
+
+// GetDraft retrieves the draft order of a customer together with its tasks.
+//
+// Parameters:
+//   - userID: UUID of the customer
+//
+// Returns:
+//   - *models.DraftOrder: Stored draft order
+//   - error: repository_errors.DoesNotExist if there is no draft,
+//     repository_errors.SelectError if the operation fails
+func (o OrderRepository) GetDraft(userID uuid.UUID) (*models.DraftOrder, error) {
+	draft := &models.DraftOrder{UserID: userID}
+
+	query := `SELECT address, updated_at FROM draft_orders WHERE user_id = $1;`
+	err := o.db.QueryRow(query, userID).Scan(&draft.Address, &draft.UpdatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, repository_errors.DoesNotExist
+	} else if err != nil {
+		return nil, repository_errors.SelectError
+	}
+
+	query = `SELECT tasks.*, draft_order_contains_tasks.quantity FROM draft_order_contains_tasks
+		JOIN tasks ON tasks.id = draft_order_contains_tasks.task_id
+		WHERE draft_order_contains_tasks.user_id = $1
+		ORDER BY tasks.name;`
+	var tasksDB []orderedTaskDB
+	err = o.db.Select(&tasksDB, query, userID)
+	if err != nil {
+		return nil, repository_errors.SelectError
+	}
+
+	for i := range tasksDB {
+		draft.Tasks = append(draft.Tasks, models.OrderedTask{
+			Task:     copyTaskResultToModel(&tasksDB[i].TaskDB),
+			Quantity: tasksDB[i].Quantity,
+		})
+	}
+
+	return draft, nil
+}
+
+// AddTag attaches a tag to an order. Attaching a tag the order already has
+// leaves the stored tags unchanged.
+//
+// Parameters:
+//   - orderID: UUID of the order
+//   - tag: Normalized tag
+//
+// Returns:
+//   - error: repository_errors.InsertError if the operation fails
+func (o OrderRepository) AddTag(orderID uuid.UUID, tag string) error {
+	query := `INSERT INTO order_tags (order_id, tag) VALUES ($1, $2) ON CONFLICT DO NOTHING;`
+	_, err := o.db.Exec(query, orderID, tag)
+	if err != nil {
+		return repository_errors.InsertError
+	}
+
+	return nil
+}
+
+// RemoveTag detaches a tag from an order.
+//
+// Parameters:
+//   - orderID: UUID of the order
+//   - tag: Normalized tag
+//
+// Returns:
+//   - error: repository_errors.DoesNotExist if the order does not have the tag,
+//     repository_errors.DeleteError if the operation fails
+func (o OrderRepository) RemoveTag(orderID uuid.UUID, tag string) error {
+	query := `DELETE FROM order_tags WHERE order_id = $1 AND tag = $2;`
+	result, err := o.db.Exec(query, orderID, tag)
+	if err != nil {
+		return repository_errors.DeleteError
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return repository_errors.DeleteError
+	}
+	if rowsAffected == 0 {
+		return repository_errors.DoesNotExist
+	}
+
+	return nil
+}
+
+// GetTags retrieves the tags of an order.
+//
+// Parameters:
+//   - orderID: UUID of the order
+//
+// Returns:
+//   - []string: Tags of the order in alphabetical order
+//   - error: repository_errors.SelectError if the operation fails
+func (o OrderRepository) GetTags(orderID uuid.UUID) ([]string, error) {
+	query := `SELECT tag FROM order_tags WHERE order_id = $1 ORDER BY tag;`
+	tags := []string{}
+
+	err := o.db.Select(&tags, query, orderID)
+	if err != nil {
+		return nil, repository_errors.SelectError
+	}
+
+	return tags, nil
+}
+
+// GetOrdersByTag retrieves orders that have the given tag. Deleted orders are skipped.
+//
+// Parameters:
+//   - tag: Normalized tag
+//
+// Returns:
+//   - []models.Order: Slice of tagged orders, newest first
+//   - error: repository_errors.SelectError if the operation fails
+func (o OrderRepository) GetOrdersByTag(tag string) ([]models.Order, error) {
+	query := `SELECT orders.* FROM orders
+		JOIN order_tags ON order_tags.order_id = orders.id
+		WHERE order_tags.tag = $1 AND orders.deleted_at IS NULL
+		ORDER BY orders.creation_date DESC;`
+	var orderDB []OrderDB
+
+	err := o.db.Select(&orderDB, query, tag)
+	if err != nil {
+		return nil, repository_errors.SelectError
+	}
+
+	var orderModels []models.Order
+	for i := range orderDB {
+		order := copyOrderResultToModel(&orderDB[i])
+		orderModels = append(orderModels, *order)
+	}
+
+	return orderModels, nil
+}
+
+// DeleteDraft removes the draft order of a customer. Its tasks are removed by
+// the cascading foreign key.
+//
+// Parameters:
+//   - userID: UUID of the customer
+//
+// Returns:
+//   - error: repository_errors.DeleteError if the operation fails
+func (o OrderRepository) DeleteDraft(userID uuid.UUID) error {
+	query := `DELETE FROM draft_orders WHERE user_id = $1;`
+	_, err := o.db.Exec(query, userID)
+	if err != nil {
+		return repository_errors.DeleteError
+	}
+
+	return nil
+}
+
+// GetDeadlineDayOfWeekCounts counts orders that are not cancelled and whose
+// deadline falls within the given period, grouped by the weekday of the deadline.
+// Deleted orders are skipped.
+//
+// Parameters:
+//   - from: Start of the deadline period (inclusive)
+//   - to: End of the deadline period (inclusive)
+//
+// Returns:
+//   - map[time.Weekday]int: Number of orders per weekday, weekdays without orders are omitted
+//   - error: repository_errors.SelectError if the operation fails
+func (o OrderRepository) GetDeadlineDayOfWeekCounts(from time.Time, to time.Time) (map[time.Weekday]int, error) {
+	query := `SELECT EXTRACT(DOW FROM deadline)::int AS weekday, COUNT(*) AS orders FROM orders
+		WHERE status != $1 AND deadline >= $2 AND deadline <= $3 AND deleted_at IS NULL
+		GROUP BY weekday;`
+	var countsDB []struct {
+		Weekday int `db:"weekday"`
+		Orders  int `db:"orders"`
+	}
+
+	err := o.db.Select(&countsDB, query, models.CancelledOrderStatus, from, to)
+
+	if err != nil {
+		return nil, repository_errors.SelectError
+	}
+
+	counts := make(map[time.Weekday]int, len(countsDB))
+	for _, count := range countsDB {
+		counts[time.Weekday(count.Weekday)] = count.Orders
+	}
+
+	return counts, nil
+}
+
+// RecomputeNewOrderTotals sets the quoted total of every order with the New status
+// to the sum of its tasks at current prices, including volume tiers, rounded with
+// the given mode. The prices stored for the tasks of these orders are refreshed as
+// well. Deleted orders are left alone. All changes are made in a single transaction
+// of two statements.
+//
+// Parameters:
+//   - rounding: Rounding mode applied to the totals
+//
+// Returns:
+//   - int: Number of updated orders
+//   - error: repository_errors.TransactionBeginError, repository_errors.TransactionRollbackError,
+//     repository_errors.UpdateError, or repository_errors.TransactionCommitError if the operation fails
+func (o OrderRepository) RecomputeNewOrderTotals(rounding models.RoundingMode) (int, error) {
+	// Start a new transaction
+	tx, err := o.db.Begin()
+	if err != nil {
+		return 0, repository_errors.TransactionBeginError
+	}
+
+	// Refresh the prices stored with the tasks of new orders
+	_, err = tx.Exec(`UPDATE order_contains_tasks SET price_at_order = `+currentTieredUnitPrice+`
+		FROM tasks, orders
+		WHERE tasks.id = order_contains_tasks.task_id AND orders.id = order_contains_tasks.order_id
+			AND orders.status = $1 AND orders.deleted_at IS NULL;`,
+		models.NewOrderStatus)
+	if err != nil {
+		err := tx.Rollback()
+		if err != nil {
+			return 0, repository_errors.TransactionRollbackError
+		}
+		return 0, repository_errors.UpdateError
+	}
+
+	// Store the rounded sum of the tasks of every new order
+	result, err := tx.Exec(`UPDATE orders SET quoted_total = `+roundedAmount("new_orders.total", rounding)+`
+		FROM (
+			SELECT orders.id, COALESCE(SUM(`+currentTieredUnitPrice+` * order_contains_tasks.quantity), 0) AS total
+			FROM orders
+			LEFT JOIN order_contains_tasks ON order_contains_tasks.order_id = orders.id
+			LEFT JOIN tasks ON tasks.id = order_contains_tasks.task_id
+			WHERE orders.status = $1 AND orders.deleted_at IS NULL
+			GROUP BY orders.id
+		) AS new_orders
+		WHERE orders.id = new_orders.id;`, models.NewOrderStatus)
+	if err != nil {
+		err := tx.Rollback()
+		if err != nil {
+			return 0, repository_errors.TransactionRollbackError
+		}
+		return 0, repository_errors.UpdateError
+	}
+
+	updated, err := result.RowsAffected()
+	if err != nil {
+		err := tx.Rollback()
+		if err != nil {
+			return 0, repository_errors.TransactionRollbackError
+		}
+		return 0, repository_errors.UpdateError
+	}
+
+	// Commit the transaction
+	err = tx.Commit()
+	if err != nil {
+		return 0, repository_errors.TransactionCommitError
+	}
+
+	return int(updated), nil
+}
@@ -4,12 +4,17 @@
 package postgres
 
 import (
+	"context"
 	"database/sql"
+	"errors"
+	"fmt"
 	"teamdev/config"
 	"teamdev/internal/repository/repository_errors"
 	"teamdev/internal/repository/repository_interfaces"
+	"time"
 
 	"github.com/charmbracelet/log"
+	"github.com/jackc/pgconn"
 	"github.com/jmoiron/sqlx"
 )
 
@@ -21,6 +26,23 @@ type PostgresConnection struct {
 	Config config.Config // Application configuration parameters
 }
 
+// uniqueViolationCode is the SQLSTATE PostgreSQL reports for a broken unique constraint.
+const uniqueViolationCode = "23505"
+
+// isUniqueViolation reports whether a query failed because it would break a
+// unique constraint, for example when another row with the same email was
+// inserted concurrently.
+//
+// Parameters:
+//   - err: Error returned by the database driver
+//
+// Returns:
+//   - bool: True if PostgreSQL rejected the query with SQLSTATE 23505
+func isUniqueViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == uniqueViolationCode
+}
+
 // NewPostgresConnection creates a new PostgreSQL connection using the provided configuration.
 // It establishes a connection to the database and validates that the connection works.
 //
@@ -43,11 +65,46 @@ func NewPostgresConnection(Postgres config.DbConnectionFlags, logger *log.Logger
 		return nil, repository_errors.ConnectionError
 	}
 
+	fields.ConfigurePool()
+
 	logger.Info("POSTGRES! Successfully create postgres repository fields")
 
 	return fields, nil
 }
 
+// ConfigurePool applies the pool limits from the database configuration to the
+// underlying database handle. Recycling connections after their lifetime lets the
+// pool drop sockets left broken by a database restart instead of handing them out forever.
+func (p *PostgresConnection) ConfigurePool() {
+	flags := p.Config.DBFlags
+
+	p.DB.SetMaxOpenConns(flags.MaxOpenConns)
+	p.DB.SetMaxIdleConns(flags.MaxIdleConns)
+	p.DB.SetConnMaxLifetime(time.Duration(flags.ConnMaxLifetimeMinutes) * time.Minute)
+}
+
+// Ping verifies that the database is still reachable. The pool discards
+// connections that fail, so a successful ping after a database restart means
+// subsequent queries will run on fresh connections.
+//
+// Parameters:
+//   - ctx: Context bounding how long the check may take
+//
+// Returns:
+//   - error: repository_errors.ConnectionError wrapping the driver error if the database cannot be reached
+func (p *PostgresConnection) Ping(ctx context.Context) error {
+	if p.DB == nil {
+		return repository_errors.ConnectionError
+	}
+
+	err := p.DB.PingContext(ctx)
+	if err != nil {
+		return fmt.Errorf("%w: %v", repository_errors.ConnectionError, err)
+	}
+
+	return nil
+}
+
 // CreateUserRepository constructs a new UserRepository with the connection.
 // This factory method provides a properly initialized repository implementation
 // that satisfies the IUserRepository interface.
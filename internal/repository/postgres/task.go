@@ -6,9 +6,13 @@ package postgres
 import (
 	"database/sql"
 	"errors"
+	"fmt"
+	"math"
+	"strings"
 	"teamdev/internal/models"
 	"teamdev/internal/repository/repository_errors"
 	"teamdev/internal/repository/repository_interfaces"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/jmoiron/sqlx"
@@ -21,6 +25,8 @@ type TaskDB struct {
 	Name           string    `db:"name"`             // Descriptive name of the cleaning task
 	PricePerSingle float64   `db:"price_per_single"` // Cost per unit of the task
 	Category       int       `db:"category"`         // Category ID the task belongs to
+	Archived       bool      `db:"archived"`         // Whether the task is retired from the catalog
+	UpdatedAt      time.Time `db:"updated_at"`       // When the task was created or last changed
 }
 
 // TaskRepository implements the ITaskRepository interface for PostgreSQL.
@@ -54,6 +60,8 @@ func copyTaskResultToModel(taskDB *TaskDB) *models.Task {
 		Name:           taskDB.Name,
 		PricePerSingle: taskDB.PricePerSingle,
 		Category:       taskDB.Category,
+		Archived:       taskDB.Archived,
+		UpdatedAt:      taskDB.UpdatedAt,
 	}
 }
 
@@ -66,10 +74,11 @@ func copyTaskResultToModel(taskDB *TaskDB) *models.Task {
 //   - *models.Task: Created task with assigned ID
 //   - error: repository_errors.InsertError if the operation fails
 func (t TaskRepository) Create(task *models.Task) (*models.Task, error) {
-	query := `INSERT INTO tasks(name, price_per_single, category) VALUES ($1, $2, $3) RETURNING id;`
+	query := `INSERT INTO tasks(name, price_per_single, category) VALUES ($1, $2, $3) RETURNING id, updated_at;`
 
 	var taskID uuid.UUID
-	err := t.db.QueryRow(query, task.Name, task.PricePerSingle, task.Category).Scan(&taskID)
+	var updatedAt time.Time
+	err := t.db.QueryRow(query, task.Name, task.PricePerSingle, task.Category).Scan(&taskID, &updatedAt)
 
 	if err != nil {
 		return nil, repository_errors.InsertError
@@ -80,6 +89,7 @@ func (t TaskRepository) Create(task *models.Task) (*models.Task, error) {
 		Name:           task.Name,
 		PricePerSingle: task.PricePerSingle,
 		Category:       task.Category,
+		UpdatedAt:      updatedAt,
 	}, nil
 }
 
@@ -111,7 +121,8 @@ func (t TaskRepository) Delete(id uuid.UUID) error {
 	return nil
 }
 
-// Update modifies an existing task record in the database.
+// Update modifies an existing task record in the database and records
+// the time of the change.
 //
 // Parameters:
 //   - task: Task entity with updated values
@@ -120,10 +131,11 @@ func (t TaskRepository) Delete(id uuid.UUID) error {
 //   - *models.Task: Updated task after the operation
 //   - error: repository_errors.UpdateError if the operation fails
 func (t TaskRepository) Update(task *models.Task) (*models.Task, error) {
-	query := `UPDATE tasks SET name = $1, price_per_single = $2, category = $3 WHERE tasks.id = $4 RETURNING id, name, price_per_single, category;`
+	query := `UPDATE tasks SET name = $1, price_per_single = $2, category = $3, updated_at = now() WHERE tasks.id = $4
+		RETURNING id, name, price_per_single, category, archived, updated_at;`
 
 	var updatedTask models.Task
-	err := t.db.QueryRow(query, task.Name, task.PricePerSingle, task.Category, task.ID).Scan(&updatedTask.ID, &updatedTask.Name, &updatedTask.PricePerSingle, &updatedTask.Category)
+	err := t.db.QueryRow(query, task.Name, task.PricePerSingle, task.Category, task.ID).Scan(&updatedTask.ID, &updatedTask.Name, &updatedTask.PricePerSingle, &updatedTask.Category, &updatedTask.Archived, &updatedTask.UpdatedAt)
 	if err != nil {
 		return nil, repository_errors.UpdateError
 	}
@@ -155,6 +167,42 @@ func (t TaskRepository) GetTaskByID(id uuid.UUID) (*models.Task, error) {
 	return taskModels, nil
 }
 
+// GetTasksByIDs retrieves several tasks by their identifiers in one query.
+// Identifiers without a matching task are absent from the result.
+//
+// Parameters:
+//   - ids: Slice of task UUIDs to retrieve
+//
+// Returns:
+//   - map[uuid.UUID]models.Task: Found tasks keyed by their ID
+//   - error: repository_errors.SelectError if the operation fails
+func (t TaskRepository) GetTasksByIDs(ids []uuid.UUID) (map[uuid.UUID]models.Task, error) {
+	tasks := make(map[uuid.UUID]models.Task, len(ids))
+	if len(ids) == 0 {
+		return tasks, nil
+	}
+
+	stringIDs := make([]string, len(ids))
+	for i, id := range ids {
+		stringIDs[i] = id.String()
+	}
+
+	query := `SELECT * FROM tasks WHERE id = ANY($1::uuid[]);`
+	var tasksDB []TaskDB
+
+	err := t.db.Select(&tasksDB, query, stringIDs)
+	if err != nil {
+		return nil, repository_errors.SelectError
+	}
+
+	for i := range tasksDB {
+		task := copyTaskResultToModel(&tasksDB[i])
+		tasks[task.ID] = *task
+	}
+
+	return tasks, nil
+}
+
 // GetTaskByName retrieves a task by its name.
 //
 // Parameters:
@@ -178,13 +226,71 @@ func (t TaskRepository) GetTaskByName(name string) (*models.Task, error) {
 	return copyTaskResultToModel(taskDB), nil
 }
 
-// GetAllTasks retrieves all tasks from the database.
+// SearchTasksByName retrieves tasks that are not archived and whose names
+// contain the given substring, ignoring case. The substring is passed as a
+// bound parameter and matched literally.
+//
+// Parameters:
+//   - query: Part of the task name to search for, empty to match every task
+//
+// Returns:
+//   - []models.Task: Slice of matching task entities ordered by name
+//   - error: repository_errors.SelectError if the operation fails
+func (t TaskRepository) SearchTasksByName(query string) ([]models.Task, error) {
+	var taskDB []TaskDB
+
+	err := t.db.Select(&taskDB, `SELECT * FROM tasks WHERE archived = false AND name ILIKE '%' || $1 || '%' ORDER BY name;`,
+		likePatternEscaper.Replace(query))
+
+	if err != nil {
+		return nil, repository_errors.SelectError
+	}
+
+	var taskModels []models.Task
+	for i := range taskDB {
+		task := copyTaskResultToModel(&taskDB[i])
+		taskModels = append(taskModels, *task)
+	}
+
+	return taskModels, nil
+}
+
+// GetTasksModifiedSince retrieves tasks created or changed at or after the given
+// time, including archived ones, so that synchronized copies of the catalog can
+// also drop retired tasks.
+//
+// Parameters:
+//   - since: Earliest modification time to include
+//
+// Returns:
+//   - []models.Task: Slice of modified task entities, least recently modified first
+//   - error: repository_errors.SelectError if the operation fails
+func (t TaskRepository) GetTasksModifiedSince(since time.Time) ([]models.Task, error) {
+	query := `SELECT * FROM tasks WHERE updated_at >= $1 ORDER BY updated_at, id;`
+	var taskDB []TaskDB
+
+	err := t.db.Select(&taskDB, query, since)
+
+	if err != nil {
+		return nil, repository_errors.SelectError
+	}
+
+	var taskModels []models.Task
+	for i := range taskDB {
+		task := copyTaskResultToModel(&taskDB[i])
+		taskModels = append(taskModels, *task)
+	}
+
+	return taskModels, nil
+}
+
+// GetAllTasks retrieves all tasks from the database that are not archived.
 //
 // Returns:
 //   - []models.Task: Slice of all task entities
 //   - error: repository_errors.SelectError if the operation fails
 func (t TaskRepository) GetAllTasks() ([]models.Task, error) {
-	query := `SELECT id, name, price_per_single, category FROM tasks;`
+	query := `SELECT id, name, price_per_single, category, archived FROM tasks WHERE archived = false;`
 	var taskDB []TaskDB
 
 	err := t.db.Select(&taskDB, query)
@@ -202,7 +308,103 @@ func (t TaskRepository) GetAllTasks() ([]models.Task, error) {
 	return taskModels, nil
 }
 
-// GetTasksInCategory retrieves all tasks belonging to a specific category.
+// sortableTaskColumns maps the sort fields of the task catalog to tasks columns.
+// Column names cannot be passed as query arguments, so only columns from this
+// map are ever written into the query.
+var sortableTaskColumns = map[string]string{
+	models.TaskSortByName:     "name",
+	models.TaskSortByPrice:    "price_per_single",
+	models.TaskSortByCategory: "category",
+}
+
+// GetAllTasksPaged retrieves one page of the tasks that are not archived, sorted
+// by the given field, together with the total number of such tasks. Ties are
+// broken by name and ID, so consecutive pages do not overlap.
+//
+// Parameters:
+//   - limit: Maximum number of tasks to return
+//   - offset: Number of tasks to skip
+//   - sortBy: One of the fields in models.TaskSortFields
+//   - asc: Whether tasks are sorted in ascending order
+//
+// Returns:
+//   - []models.Task: Tasks on the requested page
+//   - int: Total number of tasks that are not archived
+//   - error: repository_errors.SelectError if the sort field is unknown or the operation fails
+func (t TaskRepository) GetAllTasksPaged(limit, offset int, sortBy string, asc bool) ([]models.Task, int, error) {
+	column, ok := sortableTaskColumns[sortBy]
+	if !ok {
+		return nil, 0, repository_errors.SelectError
+	}
+
+	direction := "DESC"
+	if asc {
+		direction = "ASC"
+	}
+
+	var total int
+	err := t.db.Get(&total, `SELECT COUNT(*) FROM tasks WHERE archived = false;`)
+	if err != nil {
+		return nil, 0, repository_errors.SelectError
+	}
+
+	query := fmt.Sprintf(`SELECT id, name, price_per_single, category, archived FROM tasks WHERE archived = false
+	ORDER BY %s %s, name, id LIMIT $1 OFFSET $2;`, column, direction)
+	var taskDB []TaskDB
+
+	err = t.db.Select(&taskDB, query, limit, offset)
+	if err != nil {
+		return nil, 0, repository_errors.SelectError
+	}
+
+	var taskModels []models.Task
+	for i := range taskDB {
+		task := copyTaskResultToModel(&taskDB[i])
+		taskModels = append(taskModels, *task)
+	}
+
+	return taskModels, total, nil
+}
+
+// TaskWithCategoryDB represents a task row joined with the name of its category.
+type TaskWithCategoryDB struct {
+	TaskDB
+	CategoryName string `db:"category_name"` // Name of the task's category
+}
+
+// GetAllTasksWithCategoryNames retrieves all tasks that are not archived together
+// with the names of their categories. Tasks referencing a missing category get
+// models.UnknownCategoryName.
+//
+// Returns:
+//   - []models.TaskWithCategory: Tasks ordered by category and name
+//   - error: repository_errors.SelectError if the operation fails
+func (t TaskRepository) GetAllTasksWithCategoryNames() ([]models.TaskWithCategory, error) {
+	query := `SELECT tasks.id, tasks.name, tasks.price_per_single, tasks.category, tasks.archived,
+			COALESCE(categories.name, $1) AS category_name
+		FROM tasks LEFT JOIN categories ON categories.id = tasks.category
+		WHERE tasks.archived = false
+		ORDER BY tasks.category, tasks.name;`
+	var tasksDB []TaskWithCategoryDB
+
+	err := t.db.Select(&tasksDB, query, models.UnknownCategoryName)
+
+	if err != nil {
+		return nil, repository_errors.SelectError
+	}
+
+	var taskModels []models.TaskWithCategory
+	for i := range tasksDB {
+		taskModels = append(taskModels, models.TaskWithCategory{
+			Task:         *copyTaskResultToModel(&tasksDB[i].TaskDB),
+			CategoryName: tasksDB[i].CategoryName,
+		})
+	}
+
+	return taskModels, nil
+}
+
+// GetTasksInCategory retrieves all tasks belonging to a specific category that are not archived.
 //
 // Parameters:
 //   - category: Category ID to filter by
@@ -211,7 +413,7 @@ func (t TaskRepository) GetAllTasks() ([]models.Task, error) {
 //   - []models.Task: Slice of task entities in the specified category
 //   - error: repository_errors.SelectError if the operation fails
 func (t TaskRepository) GetTasksInCategory(category int) ([]models.Task, error) {
-	query := `SELECT * FROM tasks WHERE category = $1;`
+	query := `SELECT * FROM tasks WHERE category = $1 AND archived = false;`
 	var taskDB []TaskDB
 
 	err := t.db.Select(&taskDB, query, category)
@@ -228,3 +430,490 @@ func (t TaskRepository) GetTasksInCategory(category int) ([]models.Task, error)
 
 	return taskModels, nil
 }
+
+// GetUnorderedTasks retrieves all tasks that are not referenced by any
+// order_contains_tasks row, i.e. tasks that have never been ordered.
+//
+// Returns:
+//   - []models.Task: Slice of task entities that were never ordered
+//   - error: repository_errors.SelectError if the operation fails
+func (t TaskRepository) GetUnorderedTasks() ([]models.Task, error) {
+	query := `SELECT * FROM tasks WHERE NOT EXISTS (SELECT 1 FROM order_contains_tasks WHERE order_contains_tasks.task_id = tasks.id);`
+	var taskDB []TaskDB
+
+	err := t.db.Select(&taskDB, query)
+
+	if err != nil {
+		return nil, repository_errors.SelectError
+	}
+
+	var taskModels []models.Task
+	for i := range taskDB {
+		task := copyTaskResultToModel(&taskDB[i])
+		taskModels = append(taskModels, *task)
+	}
+
+	return taskModels, nil
+}
+
+// GetMostOrderedTasks ranks tasks by the total quantity ordered in a single
+// grouped query. Cancelled orders are not counted, while deleted ones are, like
+// in other reports. Ties are broken by the number of orders and then by name.
+// Date conditions are only added for non-zero dates.
+//
+// Parameters:
+//   - limit: Maximum number of tasks to return
+//   - from: Start of the creation period (inclusive)
+//   - to: End of the creation period (inclusive)
+//
+// Returns:
+//   - []models.TaskPopularity: Tasks with their total quantity and number of orders,
+//     most ordered first
+//   - error: repository_errors.SelectError if the operation fails
+func (t TaskRepository) GetMostOrderedTasks(limit int, from time.Time, to time.Time) ([]models.TaskPopularity, error) {
+	conditions := []string{"orders.status != $1"}
+	args := []interface{}{models.CancelledOrderStatus}
+
+	if !from.IsZero() {
+		args = append(args, from)
+		conditions = append(conditions, fmt.Sprintf("orders.creation_date >= $%d", len(args)))
+	}
+	if !to.IsZero() {
+		args = append(args, to)
+		conditions = append(conditions, fmt.Sprintf("orders.creation_date <= $%d", len(args)))
+	}
+	args = append(args, limit)
+
+	query := `SELECT tasks.*, SUM(order_contains_tasks.quantity) AS total_quantity, COUNT(DISTINCT orders.id) AS order_count
+	FROM tasks
+		JOIN order_contains_tasks ON order_contains_tasks.task_id = tasks.id
+		JOIN orders ON orders.id = order_contains_tasks.order_id
+	WHERE ` + strings.Join(conditions, " AND ") + `
+	GROUP BY tasks.id
+	ORDER BY total_quantity DESC, order_count DESC, tasks.name
+	LIMIT ` + fmt.Sprintf("$%d", len(args)) + `;`
+
+	var popularityDB []struct {
+		TaskDB            // Columns of the task itself
+		TotalQuantity int `db:"total_quantity"` // Units of the task in the non-cancelled orders of the period
+		OrderCount    int `db:"order_count"`    // Number of distinct orders containing the task
+	}
+
+	err := t.db.Select(&popularityDB, query, args...)
+
+	if err != nil {
+		return nil, repository_errors.SelectError
+	}
+
+	popularity := make([]models.TaskPopularity, 0, len(popularityDB))
+	for i := range popularityDB {
+		popularity = append(popularity, models.TaskPopularity{
+			Task:          *copyTaskResultToModel(&popularityDB[i].TaskDB),
+			TotalQuantity: popularityDB[i].TotalQuantity,
+			OrderCount:    popularityDB[i].OrderCount,
+		})
+	}
+
+	return popularity, nil
+}
+
+// ArchiveCategory marks all tasks of a category as archived in one transaction.
+// Unless forced, the transaction is rolled back when any of the tasks belongs
+// to a new or in-progress order.
+//
+// Parameters:
+//   - category: Category ID whose tasks are archived
+//   - force: Whether to archive tasks referenced by open orders
+//
+// Returns:
+//   - int: Number of archived tasks
+//   - error: repository_errors.ReferencedByOpenOrders if open orders refer to the tasks
+//     and force is not set, repository_errors.TransactionBeginError,
+//     repository_errors.TransactionRollbackError, repository_errors.SelectError,
+//     repository_errors.UpdateError, or repository_errors.TransactionCommitError if the operation fails
+func (t TaskRepository) ArchiveCategory(category int, force bool) (int, error) {
+	// Start a new transaction
+	tx, err := t.db.Begin()
+	if err != nil {
+		return 0, repository_errors.TransactionBeginError
+	}
+
+	if !force {
+		// Check whether open orders refer to any task of the category
+		var openOrders int
+		err = tx.QueryRow(`SELECT COUNT(DISTINCT orders.id) FROM orders
+			JOIN order_contains_tasks ON order_contains_tasks.order_id = orders.id
+			JOIN tasks ON tasks.id = order_contains_tasks.task_id
+			WHERE tasks.category = $1 AND tasks.archived = false AND orders.status IN ($2, $3);`,
+			category, models.NewOrderStatus, models.InProgressOrderStatus).Scan(&openOrders)
+		if err != nil {
+			err := tx.Rollback()
+			if err != nil {
+				return 0, repository_errors.TransactionRollbackError
+			}
+			return 0, repository_errors.SelectError
+		}
+
+		if openOrders > 0 {
+			err := tx.Rollback()
+			if err != nil {
+				return 0, repository_errors.TransactionRollbackError
+			}
+			return 0, repository_errors.ReferencedByOpenOrders
+		}
+	}
+
+	// Archive the tasks of the category
+	result, err := tx.Exec(`UPDATE tasks SET archived = true, updated_at = now() WHERE category = $1 AND archived = false;`, category)
+	if err != nil {
+		err := tx.Rollback()
+		if err != nil {
+			return 0, repository_errors.TransactionRollbackError
+		}
+		return 0, repository_errors.UpdateError
+	}
+
+	archived, err := result.RowsAffected()
+	if err != nil {
+		err := tx.Rollback()
+		if err != nil {
+			return 0, repository_errors.TransactionRollbackError
+		}
+		return 0, repository_errors.UpdateError
+	}
+
+	// Commit the transaction
+	err = tx.Commit()
+	if err != nil {
+		return 0, repository_errors.TransactionCommitError
+	}
+
+	return int(archived), nil
+}
+
+// minAdjustedPrice is the lowest price a price adjustment may produce.
+const minAdjustedPrice = 0.01
+
+// AdjustCategoryPrices multiplies the price of every task of a category that is
+// not archived by the multiplier. The tasks are locked and updated in a single
+// transaction, so either all prices change or none do. Prices are rounded to whole
+// cents with the given mode and never drop below one cent.
+//
+// Parameters:
+//   - category: Category ID whose prices are adjusted
+//   - multiplier: Factor applied to the prices
+//   - rounding: Rounding mode applied to the new prices
+//
+// Returns:
+//   - int: Number of tasks whose price was adjusted
+//   - error: repository_errors.TransactionBeginError, repository_errors.TransactionRollbackError,
+//     repository_errors.SelectError, repository_errors.UpdateError, or
+//     repository_errors.TransactionCommitError if the operation fails
+func (t TaskRepository) AdjustCategoryPrices(category int, multiplier float64, rounding models.RoundingMode) (int, error) {
+	// Start a new transaction
+	tx, err := t.db.Begin()
+	if err != nil {
+		return 0, repository_errors.TransactionBeginError
+	}
+
+	// Lock the tasks of the category and read their current prices
+	rows, err := tx.Query(`SELECT id, price_per_single FROM tasks WHERE category = $1 AND archived = false FOR UPDATE;`, category)
+	if err != nil {
+		err := tx.Rollback()
+		if err != nil {
+			return 0, repository_errors.TransactionRollbackError
+		}
+		return 0, repository_errors.SelectError
+	}
+
+	prices := make(map[uuid.UUID]float64)
+	for rows.Next() {
+		var id uuid.UUID
+		var price float64
+		err = rows.Scan(&id, &price)
+		if err != nil {
+			break
+		}
+		prices[id] = math.Max(rounding.Round(price*multiplier), minAdjustedPrice)
+	}
+	if err == nil {
+		err = rows.Err()
+	}
+	rows.Close()
+	if err != nil {
+		err := tx.Rollback()
+		if err != nil {
+			return 0, repository_errors.TransactionRollbackError
+		}
+		return 0, repository_errors.SelectError
+	}
+
+	// Store the rounded prices
+	for id, price := range prices {
+		_, err = tx.Exec(`UPDATE tasks SET price_per_single = $1, updated_at = now() WHERE id = $2;`, price, id)
+		if err != nil {
+			err := tx.Rollback()
+			if err != nil {
+				return 0, repository_errors.TransactionRollbackError
+			}
+			return 0, repository_errors.UpdateError
+		}
+	}
+
+	// Commit the transaction
+	err = tx.Commit()
+	if err != nil {
+		return 0, repository_errors.TransactionCommitError
+	}
+
+	return len(prices), nil
+}
+
+// UpsertByName inserts new tasks and updates the price and category of existing
+// ones in one transaction. Tasks are matched by name case-insensitively.
+//
+// Parameters:
+//   - tasks: Tasks to insert or update
+//
+// Returns:
+//   - created: Number of inserted tasks
+//   - updated: Number of updated tasks
+//   - err: repository_errors.TransactionBeginError, repository_errors.TransactionRollbackError,
+//     repository_errors.UpdateError, repository_errors.InsertError, or
+//     repository_errors.TransactionCommitError if the operation fails
+func (t TaskRepository) UpsertByName(tasks []models.Task) (created int, updated int, err error) {
+	// Start a new transaction
+	tx, err := t.db.Begin()
+	if err != nil {
+		return 0, 0, repository_errors.TransactionBeginError
+	}
+
+	for _, task := range tasks {
+		// Update the existing task with the same name, if any
+		result, err := tx.Exec(`UPDATE tasks SET price_per_single = $1, category = $2, updated_at = now() WHERE LOWER(name) = LOWER($3);`,
+			task.PricePerSingle, task.Category, task.Name)
+		if err != nil {
+			err := tx.Rollback()
+			if err != nil {
+				return 0, 0, repository_errors.TransactionRollbackError
+			}
+			return 0, 0, repository_errors.UpdateError
+		}
+
+		rowsAffected, err := result.RowsAffected()
+		if err != nil {
+			err := tx.Rollback()
+			if err != nil {
+				return 0, 0, repository_errors.TransactionRollbackError
+			}
+			return 0, 0, repository_errors.UpdateError
+		}
+
+		if rowsAffected > 0 {
+			updated++
+			continue
+		}
+
+		// Insert the task if none matched
+		_, err = tx.Exec(`INSERT INTO tasks(name, price_per_single, category) VALUES ($1, $2, $3);`,
+			task.Name, task.PricePerSingle, task.Category)
+		if err != nil {
+			err := tx.Rollback()
+			if err != nil {
+				return 0, 0, repository_errors.TransactionRollbackError
+			}
+			return 0, 0, repository_errors.InsertError
+		}
+		created++
+	}
+
+	// Commit the transaction
+	err = tx.Commit()
+	if err != nil {
+		return 0, 0, repository_errors.TransactionCommitError
+	}
+
+	return created, updated, nil
+}
+
+// InsertMissingByName inserts the tasks whose name is not in the catalog yet
+// in one transaction and leaves existing ones untouched. Tasks are matched by
+// name case-insensitively.
+//
+// Parameters:
+//   - tasks: Tasks to insert
+//
+// Returns:
+//   - created: Number of inserted tasks
+//   - skipped: Number of tasks that already existed
+//   - err: repository_errors.TransactionBeginError, repository_errors.TransactionRollbackError,
+//     repository_errors.InsertError, or repository_errors.TransactionCommitError if the operation fails
+func (t TaskRepository) InsertMissingByName(tasks []models.Task) (created int, skipped int, err error) {
+	// Start a new transaction
+	tx, err := t.db.Begin()
+	if err != nil {
+		return 0, 0, repository_errors.TransactionBeginError
+	}
+
+	for _, task := range tasks {
+		// Insert the task unless one with the same name exists
+		result, err := tx.Exec(`INSERT INTO tasks(name, price_per_single, category)
+			SELECT $1, $2, $3 WHERE NOT EXISTS (SELECT 1 FROM tasks WHERE LOWER(name) = LOWER($1));`,
+			task.Name, task.PricePerSingle, task.Category)
+		if err != nil {
+			err := tx.Rollback()
+			if err != nil {
+				return 0, 0, repository_errors.TransactionRollbackError
+			}
+			return 0, 0, repository_errors.InsertError
+		}
+
+		rowsAffected, err := result.RowsAffected()
+		if err != nil {
+			err := tx.Rollback()
+			if err != nil {
+				return 0, 0, repository_errors.TransactionRollbackError
+			}
+			return 0, 0, repository_errors.InsertError
+		}
+
+		if rowsAffected > 0 {
+			created++
+		} else {
+			skipped++
+		}
+	}
+
+	// Commit the transaction
+	err = tx.Commit()
+	if err != nil {
+		return 0, 0, repository_errors.TransactionCommitError
+	}
+
+	return created, skipped, nil
+}
+
+// PriceTierDB represents a price tier as stored in the task_price_tiers table.
+type PriceTierDB struct {
+	MinQuantity int     `db:"min_quantity"` // Smallest quantity the tier applies to
+	Price       float64 `db:"price"`        // Price per unit within the tier
+}
+
+// GetPriceTiers retrieves the volume discounts of a task.
+//
+// Parameters:
+//   - taskID: UUID of the task
+//
+// Returns:
+//   - []models.PriceTier: Price tiers of the task, smallest minimum quantity first
+//   - error: repository_errors.SelectError if the operation fails
+func (t TaskRepository) GetPriceTiers(taskID uuid.UUID) ([]models.PriceTier, error) {
+	query := `SELECT min_quantity, price FROM task_price_tiers WHERE task_id = $1 ORDER BY min_quantity;`
+	var tiersDB []PriceTierDB
+
+	err := t.db.Select(&tiersDB, query, taskID)
+
+	if err != nil {
+		return nil, repository_errors.SelectError
+	}
+
+	var tiers []models.PriceTier
+	for _, tier := range tiersDB {
+		tiers = append(tiers, models.PriceTier{MinQuantity: tier.MinQuantity, Price: tier.Price})
+	}
+
+	return tiers, nil
+}
+
+// GetPriceTiersByTaskIDs retrieves the volume discounts of several tasks in one query.
+// Tasks without tiers are absent from the result.
+//
+// Parameters:
+//   - taskIDs: Slice of task UUIDs
+//
+// Returns:
+//   - map[uuid.UUID][]models.PriceTier: Price tiers keyed by task ID, smallest minimum quantity first
+//   - error: repository_errors.SelectError if the operation fails
+func (t TaskRepository) GetPriceTiersByTaskIDs(taskIDs []uuid.UUID) (map[uuid.UUID][]models.PriceTier, error) {
+	tiers := make(map[uuid.UUID][]models.PriceTier)
+	if len(taskIDs) == 0 {
+		return tiers, nil
+	}
+
+	stringIDs := make([]string, len(taskIDs))
+	for i, id := range taskIDs {
+		stringIDs[i] = id.String()
+	}
+
+	query := `SELECT task_id, min_quantity, price FROM task_price_tiers WHERE task_id = ANY($1::uuid[]) ORDER BY task_id, min_quantity;`
+	var tiersDB []struct {
+		TaskID uuid.UUID `db:"task_id"` // Task the tier belongs to
+		PriceTierDB
+	}
+
+	err := t.db.Select(&tiersDB, query, stringIDs)
+	if err != nil {
+		return nil, repository_errors.SelectError
+	}
+
+	for _, tier := range tiersDB {
+		tiers[tier.TaskID] = append(tiers[tier.TaskID], models.PriceTier{MinQuantity: tier.MinQuantity, Price: tier.Price})
+	}
+
+	return tiers, nil
+}
+
+// SetPriceTiers replaces the volume discounts of a task in one transaction.
+// The task's updated_at is bumped, so price changes show up in catalog syncs.
+//
+// Parameters:
+//   - taskID: UUID of the task
+//   - tiers: New price tiers, empty to remove all discounts
+//
+// Returns:
+//   - error: repository_errors.TransactionBeginError, repository_errors.TransactionRollbackError,
+//     repository_errors.DeleteError, repository_errors.InsertError, repository_errors.UpdateError,
+//     or repository_errors.TransactionCommitError if the operation fails
+func (t TaskRepository) SetPriceTiers(taskID uuid.UUID, tiers []models.PriceTier) error {
+	tx, err := t.db.Begin()
+	if err != nil {
+		return repository_errors.TransactionBeginError
+	}
+
+	_, err = tx.Exec(`DELETE FROM task_price_tiers WHERE task_id = $1;`, taskID)
+	if err != nil {
+		err := tx.Rollback()
+		if err != nil {
+			return repository_errors.TransactionRollbackError
+		}
+		return repository_errors.DeleteError
+	}
+
+	for _, tier := range tiers {
+		_, err = tx.Exec(`INSERT INTO task_price_tiers(task_id, min_quantity, price) VALUES ($1, $2, $3);`,
+			taskID, tier.MinQuantity, tier.Price)
+		if err != nil {
+			err := tx.Rollback()
+			if err != nil {
+				return repository_errors.TransactionRollbackError
+			}
+			return repository_errors.InsertError
+		}
+	}
+
+	_, err = tx.Exec(`UPDATE tasks SET updated_at = now() WHERE id = $1;`, taskID)
+	if err != nil {
+		err := tx.Rollback()
+		if err != nil {
+			return repository_errors.TransactionRollbackError
+		}
+		return repository_errors.UpdateError
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return repository_errors.TransactionCommitError
+	}
+
+	return nil
+}
@@ -70,14 +70,17 @@ func copyUserResultToModel(userDB *UserDB) *models.User {
 //
 // Returns:
 //   - *models.User: Created user with assigned ID
-//   - error: repository_errors.InsertError if the operation fails
+//   - error: repository_errors.AlreadyExists if the email is already taken,
+//     repository_errors.InsertError if the operation fails
 func (u UserRepository) Create(user *models.User) (*models.User, error) {
 	query := `INSERT INTO users(name, surname, address, phone_number, email, password) VALUES ($1, $2, $3, $4, $5, $6) RETURNING id;`
 
 	var userID uuid.UUID
 	err := u.db.QueryRow(query, user.Name, user.Surname, user.Address, user.PhoneNumber, user.Email, user.Password).Scan(&userID)
 
-	if err != nil {
+	if isUniqueViolation(err) {
+		return nil, repository_errors.AlreadyExists
+	} else if err != nil {
 		return nil, repository_errors.InsertError
 	}
 
@@ -206,14 +209,14 @@ func (u UserRepository) GetUserByEmail(email string) (*models.User, error) {
 	return userModels, nil
 }
 
-// GetAllUsers retrieves all users from the database.
+// GetAllUsers retrieves all users from the database ordered by surname and name.
 // For security reasons, this method does not return user passwords.
 //
 // Returns:
 //   - []models.User: Slice of all user entities
 //   - error: repository_errors.SelectError if the operation fails
 func (u UserRepository) GetAllUsers() ([]models.User, error) {
-	query := `SELECT name, surname, address, phone_number, email FROM users;`
+	query := `SELECT id, name, surname, address, phone_number, email FROM users ORDER BY surname, name;`
 	var userDB []UserDB
 
 	err := u.db.Select(&userDB, query)
@@ -230,3 +233,93 @@ func (u UserRepository) GetAllUsers() ([]models.User, error) {
 
 	return userModels, nil
 }
+
+// GetCustomersWithCompletedOrders retrieves users who have at least one completed order.
+// For security reasons, this method does not return user passwords.
+//
+// Returns:
+//   - []models.User: Slice of users with completed orders
+//   - error: repository_errors.SelectError if the operation fails
+func (u UserRepository) GetCustomersWithCompletedOrders() ([]models.User, error) {
+	query := `SELECT id, name, surname, address, phone_number, email FROM users WHERE EXISTS (SELECT 1 FROM orders WHERE orders.user_id = users.id AND orders.status = $1);`
+	var userDB []UserDB
+
+	err := u.db.Select(&userDB, query, models.CompletedOrderStatus)
+
+	if err != nil {
+		return nil, repository_errors.SelectError
+	}
+
+	var userModels []models.User
+	for i := range userDB {
+		user := copyUserResultToModel(&userDB[i])
+		userModels = append(userModels, *user)
+	}
+
+	return userModels, nil
+}
+
+// GetOrderStatistics summarizes the orders of a user in a single aggregate query.
+// Completed orders are counted at their quoted total, falling back to the prices
+// of their tasks like in revenue reports. Only rated orders make up the average
+// rating. Deleted orders are not counted.
+//
+// Parameters:
+//   - userID: UUID of the user
+//
+// Returns:
+//   - *models.UserStats: Order counts, total spent and average rating, zero for a user without orders
+//   - error: repository_errors.SelectError if the operation fails
+func (u UserRepository) GetOrderStatistics(userID uuid.UUID) (*models.UserStats, error) {
+	query := `SELECT COUNT(*) AS total_orders,
+		COUNT(*) FILTER (WHERE status = $2) AS completed_orders,
+		COUNT(*) FILTER (WHERE status = $3) AS cancelled_orders,
+		COALESCE(SUM(total) FILTER (WHERE status = $2), 0)::float8 AS total_spent,
+		COALESCE(AVG(rate) FILTER (WHERE rate > 0), 0)::float8 AS average_rating
+	FROM (
+		SELECT orders.status, orders.rate, ` + billedOrderTotal + ` AS total
+		FROM orders
+		WHERE orders.user_id = $1 AND orders.deleted_at IS NULL
+	) AS user_orders;`
+
+	var statsDB struct {
+		TotalOrders     int     `db:"total_orders"`     // Number of orders the user has placed, deleted ones excluded
+		CompletedOrders int     `db:"completed_orders"` // Number of the user's completed orders
+		CancelledOrders int     `db:"cancelled_orders"` // Number of the user's cancelled orders
+		TotalSpent      float64 `db:"total_spent"`      // Amount billed for completed orders
+		AverageRating   float64 `db:"average_rating"`   // Average rating of the rated orders, 0 if none
+	}
+	err := u.db.Get(&statsDB, query, userID, models.CompletedOrderStatus, models.CancelledOrderStatus)
+
+	if err != nil {
+		return nil, repository_errors.SelectError
+	}
+
+	return &models.UserStats{
+		TotalOrders:     statsDB.TotalOrders,
+		CompletedOrders: statsDB.CompletedOrders,
+		CancelledOrders: statsDB.CancelledOrders,
+		TotalSpent:      statsDB.TotalSpent,
+		AverageRating:   statsDB.AverageRating,
+	}, nil
+}
+
+// UpdatePassword replaces the stored password hash of a user without
+// touching the rest of the profile.
+//
+// Parameters:
+//   - id: UUID of the user
+//   - password: New password hash
+//
+// Returns:
+//   - error: repository_errors.UpdateError if the operation fails
+func (u UserRepository) UpdatePassword(id uuid.UUID, password string) error {
+	query := `UPDATE users SET password = $1 WHERE id = $2;`
+
+	_, err := u.db.Exec(query, password, id)
+	if err != nil {
+		return repository_errors.UpdateError
+	}
+
+	return nil
+}
@@ -9,6 +9,7 @@ import (
 	"teamdev/internal/models"
 	"teamdev/internal/repository/repository_errors"
 	"teamdev/internal/repository/repository_interfaces"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/jmoiron/sqlx"
@@ -17,14 +18,16 @@ import (
 // WorkerDB represents a worker entity as stored in the PostgreSQL database.
 // It maps directly to the columns in the workers table.
 type WorkerDB struct {
-	ID          uuid.UUID `db:"id"`           // Unique identifier for the worker
-	Name        string    `db:"name"`         // First name of the worker
-	Surname     string    `db:"surname"`      // Last name of the worker
-	Address     string    `db:"address"`      // Physical address of the worker
-	PhoneNumber string    `db:"phone_number"` // Contact phone number
-	Email       string    `db:"email"`        // Email address, used as username for login
-	Role        int       `db:"role"`         // Role identifier (determines permissions)
-	Password    string    `db:"password"`     // Hashed password for authentication
+	ID                  uuid.UUID  `db:"id"`                    // Unique identifier for the worker
+	Name                string     `db:"name"`                  // First name of the worker
+	Surname             string     `db:"surname"`               // Last name of the worker
+	Address             string     `db:"address"`               // Physical address of the worker
+	PhoneNumber         string     `db:"phone_number"`          // Contact phone number
+	Email               string     `db:"email"`                 // Email address, used as username for login
+	Role                int        `db:"role"`                  // Role identifier (determines permissions)
+	Password            string     `db:"password"`              // Hashed password for authentication
+	LastLoginAt         *time.Time `db:"last_login_at"`         // When the worker last logged in successfully
+	NotificationsOptOut bool       `db:"notifications_opt_out"` // Whether the worker has opted out of notifications
 }
 
 // WorkerRepository implements the IWorkerRepository interface for PostgreSQL.
@@ -54,14 +57,16 @@ func NewWorkerRepository(db *sqlx.DB) repository_interfaces.IWorkerRepository {
 //   - *models.Worker: Corresponding domain entity
 func copyWorkerResultToModel(workerDB *WorkerDB) *models.Worker {
 	return &models.Worker{
-		ID:          workerDB.ID,
-		Name:        workerDB.Name,
-		Surname:     workerDB.Surname,
-		Address:     workerDB.Address,
-		PhoneNumber: workerDB.PhoneNumber,
-		Email:       workerDB.Email,
-		Role:        workerDB.Role,
-		Password:    workerDB.Password,
+		ID:                  workerDB.ID,
+		Name:                workerDB.Name,
+		Surname:             workerDB.Surname,
+		Address:             workerDB.Address,
+		PhoneNumber:         workerDB.PhoneNumber,
+		Email:               workerDB.Email,
+		Role:                workerDB.Role,
+		Password:            workerDB.Password,
+		LastLoginAt:         workerDB.LastLoginAt,
+		NotificationsOptOut: workerDB.NotificationsOptOut,
 	}
 }
 
@@ -72,26 +77,30 @@ func copyWorkerResultToModel(workerDB *WorkerDB) *models.Worker {
 //
 // Returns:
 //   - *models.Worker: Created worker with assigned ID
-//   - error: repository_errors.InsertError if the operation fails
+//   - error: repository_errors.AlreadyExists if the email is already taken,
+//     repository_errors.InsertError if the operation fails
 func (w WorkerRepository) Create(worker *models.Worker) (*models.Worker, error) {
-	query := `INSERT INTO workers(name, surname, address, phone_number, email, role, password) VALUES ($1, $2, $3, $4, $5, $6, $7) RETURNING id;`
+	query := `INSERT INTO workers(name, surname, address, phone_number, email, role, password, notifications_opt_out) VALUES ($1, $2, $3, $4, $5, $6, $7, $8) RETURNING id;`
 
 	var workerID uuid.UUID
-	err := w.db.QueryRow(query, worker.Name, worker.Surname, worker.Address, worker.PhoneNumber, worker.Email, worker.Role, worker.Password).Scan(&workerID)
+	err := w.db.QueryRow(query, worker.Name, worker.Surname, worker.Address, worker.PhoneNumber, worker.Email, worker.Role, worker.Password, worker.NotificationsOptOut).Scan(&workerID)
 
-	if err != nil {
+	if isUniqueViolation(err) {
+		return nil, repository_errors.AlreadyExists
+	} else if err != nil {
 		return nil, repository_errors.InsertError
 	}
 
 	return &models.Worker{
-		ID:          workerID,
-		Name:        worker.Name,
-		Surname:     worker.Surname,
-		Address:     worker.Address,
-		PhoneNumber: worker.PhoneNumber,
-		Email:       worker.Email,
-		Role:        worker.Role,
-		Password:    worker.Password,
+		ID:                  workerID,
+		Name:                worker.Name,
+		Surname:             worker.Surname,
+		Address:             worker.Address,
+		PhoneNumber:         worker.PhoneNumber,
+		Email:               worker.Email,
+		Role:                worker.Role,
+		Password:            worker.Password,
+		NotificationsOptOut: worker.NotificationsOptOut,
 	}, nil
 }
 
@@ -104,10 +113,10 @@ func (w WorkerRepository) Create(worker *models.Worker) (*models.Worker, error)
 //   - *models.Worker: Updated worker after the operation
 //   - error: repository_errors.UpdateError if the operation fails
 func (w WorkerRepository) Update(worker *models.Worker) (*models.Worker, error) {
-	query := `UPDATE workers SET name = $1, surname = $2, address = $3, phone_number = $4, email = $5, role = $6, password = $7 WHERE workers.id = $8 RETURNING id, name, surname, address, phone_number, email, role, password;`
+	query := `UPDATE workers SET name = $1, surname = $2, address = $3, phone_number = $4, email = $5, role = $6, password = $7, notifications_opt_out = $8 WHERE workers.id = $9 RETURNING id, name, surname, address, phone_number, email, role, password, last_login_at, notifications_opt_out;`
 
 	var updatedWorker models.Worker
-	err := w.db.QueryRow(query, worker.Name, worker.Surname, worker.Address, worker.PhoneNumber, worker.Email, worker.Role, worker.Password, worker.ID).Scan(&updatedWorker.ID, &updatedWorker.Name, &updatedWorker.Surname, &updatedWorker.Address, &updatedWorker.PhoneNumber, &updatedWorker.Email, &updatedWorker.Role, &updatedWorker.Password)
+	err := w.db.QueryRow(query, worker.Name, worker.Surname, worker.Address, worker.PhoneNumber, worker.Email, worker.Role, worker.Password, worker.NotificationsOptOut, worker.ID).Scan(&updatedWorker.ID, &updatedWorker.Name, &updatedWorker.Surname, &updatedWorker.Address, &updatedWorker.PhoneNumber, &updatedWorker.Email, &updatedWorker.Role, &updatedWorker.Password, &updatedWorker.LastLoginAt, &updatedWorker.NotificationsOptOut)
 	if err != nil {
 		return nil, repository_errors.UpdateError
 	}
@@ -115,20 +124,50 @@ func (w WorkerRepository) Update(worker *models.Worker) (*models.Worker, error)
 }
 
 // Delete removes a worker record from the database by ID.
+// Active orders of the worker are returned to the new status and unassigned
+// within the same transaction, so a failed deletion leaves them untouched.
 //
 // Parameters:
 //   - id: UUID of the worker to delete
 //
 // Returns:
-//   - error: repository_errors.DeleteError if the operation fails
+//   - error: repository_errors.TransactionBeginError, repository_errors.TransactionRollbackError,
+//     repository_errors.UpdateError, repository_errors.DeleteError,
+//     or repository_errors.TransactionCommitError if the operation fails
 func (w WorkerRepository) Delete(id uuid.UUID) error {
-	query := `DELETE FROM workers WHERE id = $1;`
-	_, err := w.db.Exec(query, id)
+	// Start a new transaction
+	tx, err := w.db.Begin()
+	if err != nil {
+		return repository_errors.TransactionBeginError
+	}
 
+	// Unassign the active orders of the worker
+	_, err = tx.Exec(`UPDATE orders SET worker_id = NULL, status = $1, assigned_at = NULL WHERE worker_id = $2 AND status IN ($1, $3);`,
+		models.NewOrderStatus, id, models.InProgressOrderStatus)
 	if err != nil {
+		err := tx.Rollback()
+		if err != nil {
+			return repository_errors.TransactionRollbackError
+		}
+		return repository_errors.UpdateError
+	}
+
+	// Delete the worker
+	_, err = tx.Exec(`DELETE FROM workers WHERE id = $1;`, id)
+	if err != nil {
+		err := tx.Rollback()
+		if err != nil {
+			return repository_errors.TransactionRollbackError
+		}
 		return repository_errors.DeleteError
 	}
 
+	// Commit the transaction
+	err = tx.Commit()
+	if err != nil {
+		return repository_errors.TransactionCommitError
+	}
+
 	return nil
 }
 
@@ -163,7 +202,7 @@ func (w WorkerRepository) GetWorkerByID(id uuid.UUID) (*models.Worker, error) {
 //   - []models.Worker: Slice of all worker entities
 //   - error: repository_errors.SelectError if the operation fails
 func (w WorkerRepository) GetAllWorkers() ([]models.Worker, error) {
-	query := `SELECT id, name, surname, address, phone_number, email, role FROM workers;`
+	query := `SELECT id, name, surname, address, phone_number, email, role, last_login_at, notifications_opt_out FROM workers;`
 	var workerDB []WorkerDB
 
 	err := w.db.Select(&workerDB, query)
@@ -210,15 +249,16 @@ func (w WorkerRepository) GetWorkerByEmail(email string) (*models.Worker, error)
 //
 // Parameters:
 //   - role: Role identifier to filter by
+//   - excludeID: UUID of a worker to leave out of the result (uuid.Nil to keep everyone)
 //
 // Returns:
 //   - []models.Worker: Slice of worker entities with the specified role
 //   - error: repository_errors.SelectError if the operation fails
-func (w WorkerRepository) GetWorkersByRole(role int) ([]models.Worker, error) {
-	query := `SELECT * FROM workers WHERE role = $1;`
+func (w WorkerRepository) GetWorkersByRole(role int, excludeID uuid.UUID) ([]models.Worker, error) {
+	query := `SELECT * FROM workers WHERE role = $1 AND id != $2;`
 	var workerDB []WorkerDB
 
-	err := w.db.Select(&workerDB, query, role)
+	err := w.db.Select(&workerDB, query, role, excludeID)
 
 	if err != nil {
 		return nil, repository_errors.SelectError
@@ -234,7 +274,8 @@ func (w WorkerRepository) GetWorkersByRole(role int) ([]models.Worker, error) {
 }
 
 // GetAverageOrderRate calculates the average rating for completed orders
-// assigned to a specific worker.
+// assigned to a specific worker. Deleted orders are skipped, and workers
+// without rated orders get 0.
 //
 // Parameters:
 //   - worker: Worker entity to calculate average rating for
@@ -243,7 +284,7 @@ func (w WorkerRepository) GetWorkersByRole(role int) ([]models.Worker, error) {
 //   - float64: Average rating value (0.0-5.0)
 //   - error: repository_errors.SelectError if the operation fails
 func (w WorkerRepository) GetAverageOrderRate(worker *models.Worker) (float64, error) {
-	query := `SELECT AVG(rate) FROM orders WHERE worker_id = $1 AND status = 3 AND rate != 0;`
+	query := `SELECT COALESCE(AVG(rate), 0) FROM orders WHERE worker_id = $1 AND status = 3 AND rate != 0 AND deleted_at IS NULL;`
 	var averageRate float64
 
 	err := w.db.Get(&averageRate, query, worker.ID)
@@ -254,3 +295,398 @@ func (w WorkerRepository) GetAverageOrderRate(worker *models.Worker) (float64, e
 
 	return averageRate, nil
 }
+
+// GetActiveOrdersCount counts new and in-progress orders assigned to a worker.
+//
+// Parameters:
+//   - workerID: UUID of the worker
+//
+// Returns:
+//   - int: Number of active orders assigned to the worker
+//   - error: repository_errors.SelectError if the operation fails
+func (w WorkerRepository) GetActiveOrdersCount(workerID uuid.UUID) (int, error) {
+	query := `SELECT COUNT(*) FROM orders WHERE worker_id = $1 AND status IN ($2, $3) AND deleted_at IS NULL;`
+	var count int
+
+	err := w.db.Get(&count, query, workerID, models.NewOrderStatus, models.InProgressOrderStatus)
+
+	if err != nil {
+		return 0, repository_errors.SelectError
+	}
+
+	return count, nil
+}
+
+// GetRevenueBetween sums the billed totals of completed orders assigned to a worker
+// and completed within the given period. Deleted orders are skipped.
+//
+// Parameters:
+//   - workerID: UUID of the worker
+//   - from: Start of the period (inclusive)
+//   - to: End of the period (inclusive)
+//
+// Returns:
+//   - float64: Total revenue of the worker in the period, 0 if there are no such orders
+//   - error: repository_errors.SelectError if the operation fails
+func (w WorkerRepository) GetRevenueBetween(workerID uuid.UUID, from time.Time, to time.Time) (float64, error) {
+	query := `SELECT COALESCE(SUM(` + billedOrderTotal + `), 0) FROM orders
+		WHERE orders.worker_id = $1 AND orders.status = $2 AND orders.completed_at BETWEEN $3 AND $4 AND orders.deleted_at IS NULL;`
+	var revenue float64
+
+	err := w.db.Get(&revenue, query, workerID, models.CompletedOrderStatus, from, to)
+
+	if err != nil {
+		return 0, repository_errors.SelectError
+	}
+
+	return revenue, nil
+}
+
+// GetCompletedOrdersInYear retrieves the completed orders assigned to a worker
+// that were completed in the given calendar year. Deleted orders are skipped.
+//
+// Parameters:
+//   - workerID: UUID of the worker
+//   - year: Calendar year of completion
+//
+// Returns:
+//   - []models.Order: Completed orders, oldest completion first
+//   - error: repository_errors.SelectError if the operation fails
+func (w WorkerRepository) GetCompletedOrdersInYear(workerID uuid.UUID, year int) ([]models.Order, error) {
+	query := `SELECT * FROM orders WHERE worker_id = $1 AND status = $2 AND EXTRACT(YEAR FROM completed_at) = $3 AND deleted_at IS NULL
+		ORDER BY completed_at, id;`
+	var orders []OrderDB
+
+	err := w.db.Select(&orders, query, workerID, models.CompletedOrderStatus, year)
+
+	if err != nil {
+		return nil, repository_errors.SelectError
+	}
+
+	var orderModels []models.Order
+	for i := range orders {
+		orderModels = append(orderModels, *copyOrderResultToModel(&orders[i]))
+	}
+
+	return orderModels, nil
+}
+
+// UpdateLastLogin records the time of the latest successful login of a worker.
+//
+// Parameters:
+//   - id: UUID of the worker
+//   - loggedInAt: Time of the login
+//
+// Returns:
+//   - error: repository_errors.UpdateError if the operation fails
+func (w WorkerRepository) UpdateLastLogin(id uuid.UUID, loggedInAt time.Time) error {
+	query := `UPDATE workers SET last_login_at = $1 WHERE id = $2;`
+
+	_, err := w.db.Exec(query, loggedInAt, id)
+	if err != nil {
+		return repository_errors.UpdateError
+	}
+
+	return nil
+}
+
+// UpdatePassword replaces the stored password hash of a worker without
+// touching the rest of the profile.
+//
+// Parameters:
+//   - id: UUID of the worker
+//   - password: New password hash
+//
+// Returns:
+//   - error: repository_errors.UpdateError if the operation fails
+func (w WorkerRepository) UpdatePassword(id uuid.UUID, password string) error {
+	query := `UPDATE workers SET password = $1 WHERE id = $2;`
+
+	_, err := w.db.Exec(query, password, id)
+	if err != nil {
+		return repository_errors.UpdateError
+	}
+
+	return nil
+}
+
+// WorkerComparisonDB represents a row of the team comparison query.
+type WorkerComparisonDB struct {
+	ID                   uuid.UUID `db:"id"`                     // Unique identifier for the worker
+	Name                 string    `db:"name"`                   // First name of the worker
+	Surname              string    `db:"surname"`                // Last name of the worker
+	Address              string    `db:"address"`                // Physical address of the worker
+	PhoneNumber          string    `db:"phone_number"`           // Contact phone number
+	Email                string    `db:"email"`                  // Email address, used as username for login
+	Role                 int       `db:"role"`                   // Role identifier (determines permissions)
+	AverageRating        float64   `db:"average_rating"`         // Average rating of the master's completed orders
+	CompletedOrders      int       `db:"completed_orders"`       // Number of completed orders of the master
+	TeamAverageRating    float64   `db:"team_average_rating"`    // Average rating across all masters
+	TeamAverageCompleted float64   `db:"team_average_completed"` // Average number of completed orders per master
+	RatingPercentile     float64   `db:"rating_percentile"`      // Share of the team rated lower than the master (0-100)
+}
+
+// GetTeamComparison computes the rating and the number of completed orders of
+// every master together with the team averages. The rating percentile is the
+// percent rank of the master's average rating within the team. Deleted orders are skipped.
+//
+// Returns:
+//   - []models.WorkerComparison: Comparison of every master, best rated first
+//   - error: repository_errors.SelectError if the operation fails
+func (w WorkerRepository) GetTeamComparison() ([]models.WorkerComparison, error) {
+	query := `WITH stats AS (
+		SELECT workers.id, workers.name, workers.surname, workers.address, workers.phone_number, workers.email, workers.role,
+			COALESCE(AVG(orders.rate) FILTER (WHERE orders.status = $1 AND orders.rate != 0), 0)::float8 AS average_rating,
+			COUNT(orders.id) FILTER (WHERE orders.status = $1) AS completed_orders
+		FROM workers LEFT JOIN orders ON orders.worker_id = workers.id AND orders.deleted_at IS NULL
+		WHERE workers.role = $2
+		GROUP BY workers.id
+	)
+	SELECT stats.*,
+		AVG(average_rating) OVER ()::float8 AS team_average_rating,
+		AVG(completed_orders) OVER ()::float8 AS team_average_completed,
+		(PERCENT_RANK() OVER (ORDER BY average_rating) * 100)::float8 AS rating_percentile
+	FROM stats
+	ORDER BY average_rating DESC, completed_orders DESC;`
+	var comparisonDB []WorkerComparisonDB
+
+	err := w.db.Select(&comparisonDB, query, models.CompletedOrderStatus, models.MasterRole)
+
+	if err != nil {
+		return nil, repository_errors.SelectError
+	}
+
+	comparisons := make([]models.WorkerComparison, len(comparisonDB))
+	for i, row := range comparisonDB {
+		comparisons[i] = models.WorkerComparison{
+			Worker: models.Worker{
+				ID:          row.ID,
+				Name:        row.Name,
+				Surname:     row.Surname,
+				Address:     row.Address,
+				PhoneNumber: row.PhoneNumber,
+				Email:       row.Email,
+				Role:        row.Role,
+			},
+			AverageRating:        row.AverageRating,
+			CompletedOrders:      row.CompletedOrders,
+			TeamAverageRating:    row.TeamAverageRating,
+			TeamAverageCompleted: row.TeamAverageCompleted,
+			RatingPercentile:     row.RatingPercentile,
+		}
+	}
+
+	return comparisons, nil
+}
+
+// AssignmentCandidateDB represents a row of the assignment candidates query.
+type AssignmentCandidateDB struct {
+	ID            uuid.UUID `db:"id"`             // Unique identifier for the worker
+	Name          string    `db:"name"`           // First name of the worker
+	Surname       string    `db:"surname"`        // Last name of the worker
+	Address       string    `db:"address"`        // Physical address of the worker
+	PhoneNumber   string    `db:"phone_number"`   // Contact phone number
+	Email         string    `db:"email"`          // Email address, used as username for login
+	Role          int       `db:"role"`           // Role identifier (determines permissions)
+	AverageRating float64   `db:"average_rating"` // Average rating of the master's completed orders, 0 if unrated
+	ActiveOrders  int       `db:"active_orders"`  // Number of new and in-progress orders assigned to the master
+}
+
+// GetAssignmentCandidates selects the masters rated at or above the threshold
+// who can take another order, in a single query. Masters without rated orders
+// have a rating of 0. Deleted orders are skipped.
+//
+// Parameters:
+//   - minRating: Minimum average rating of a candidate
+//   - maxActiveOrders: Maximum number of active orders per master, 0 means unlimited
+//
+// Returns:
+//   - []models.WorkerWithRating: Candidates, best rated first, then least busy first
+//   - error: repository_errors.SelectError if the operation fails
+func (w WorkerRepository) GetAssignmentCandidates(minRating float64, maxActiveOrders int) ([]models.WorkerWithRating, error) {
+	query := `WITH stats AS (
+		SELECT workers.id, workers.name, workers.surname, workers.address, workers.phone_number, workers.email, workers.role,
+			COALESCE(AVG(orders.rate) FILTER (WHERE orders.status = $1 AND orders.rate != 0), 0)::float8 AS average_rating,
+			COUNT(orders.id) FILTER (WHERE orders.status IN ($2, $3)) AS active_orders
+		FROM workers LEFT JOIN orders ON orders.worker_id = workers.id AND orders.deleted_at IS NULL
+		WHERE workers.role = $4
+		GROUP BY workers.id
+	)
+	SELECT * FROM stats
+	WHERE average_rating >= $5 AND ($6::int <= 0 OR active_orders < $6::int)
+	ORDER BY average_rating DESC, active_orders, id;`
+	var candidatesDB []AssignmentCandidateDB
+
+	err := w.db.Select(&candidatesDB, query, models.CompletedOrderStatus, models.NewOrderStatus, models.InProgressOrderStatus,
+		models.MasterRole, minRating, maxActiveOrders)
+
+	if err != nil {
+		return nil, repository_errors.SelectError
+	}
+
+	candidates := make([]models.WorkerWithRating, len(candidatesDB))
+	for i, row := range candidatesDB {
+		candidates[i] = models.WorkerWithRating{
+			Worker: models.Worker{
+				ID:          row.ID,
+				Name:        row.Name,
+				Surname:     row.Surname,
+				Address:     row.Address,
+				PhoneNumber: row.PhoneNumber,
+				Email:       row.Email,
+				Role:        row.Role,
+			},
+			AverageRating: row.AverageRating,
+			ActiveOrders:  row.ActiveOrders,
+		}
+	}
+
+	return candidates, nil
+}
+
+// WorkerPerformanceDB represents a row of the performance report query.
+type WorkerPerformanceDB struct {
+	ID              uuid.UUID `db:"id"`               // Unique identifier for the worker
+	Name            string    `db:"name"`             // First name of the worker
+	Surname         string    `db:"surname"`          // Last name of the worker
+	Address         string    `db:"address"`          // Physical address of the worker
+	PhoneNumber     string    `db:"phone_number"`     // Contact phone number
+	Email           string    `db:"email"`            // Email address, used as username for login
+	Role            int       `db:"role"`             // Role identifier (determines permissions)
+	CompletedOrders int       `db:"completed_orders"` // Number of completed orders
+	CancelledOrders int       `db:"cancelled_orders"` // Number of cancelled orders
+	AverageRating   float64   `db:"average_rating"`   // Average rating of completed orders, 0 if none are rated
+	Revenue         float64   `db:"revenue"`          // Sum of the billed totals of completed orders
+}
+
+// GetPerformanceReport counts the completed and cancelled orders of every master
+// and computes their average rating and revenue in a single grouped query.
+// Masters without rated orders have a rating of 0. Deleted orders are skipped.
+//
+// Returns:
+//   - []models.WorkerPerformance: Report row of every master, best rated first,
+//     then by the number of completed orders
+//   - error: repository_errors.SelectError if the operation fails
+func (w WorkerRepository) GetPerformanceReport() ([]models.WorkerPerformance, error) {
+	query := `SELECT workers.id, workers.name, workers.surname, workers.address, workers.phone_number, workers.email, workers.role,
+		COUNT(orders.id) FILTER (WHERE orders.status = $1) AS completed_orders,
+		COUNT(orders.id) FILTER (WHERE orders.status = $2) AS cancelled_orders,
+		COALESCE(AVG(orders.rate) FILTER (WHERE orders.status = $1 AND orders.rate != 0), 0)::float8 AS average_rating,
+		COALESCE(SUM(` + billedOrderTotal + `) FILTER (WHERE orders.status = $1), 0)::float8 AS revenue
+	FROM workers LEFT JOIN orders ON orders.worker_id = workers.id AND orders.deleted_at IS NULL
+	WHERE workers.role = $3
+	GROUP BY workers.id
+	ORDER BY average_rating DESC, completed_orders DESC, workers.id;`
+	var rowsDB []WorkerPerformanceDB
+
+	err := w.db.Select(&rowsDB, query, models.CompletedOrderStatus, models.CancelledOrderStatus, models.MasterRole)
+
+	if err != nil {
+		return nil, repository_errors.SelectError
+	}
+
+	report := make([]models.WorkerPerformance, len(rowsDB))
+	for i, row := range rowsDB {
+		report[i] = models.WorkerPerformance{
+			Worker: models.Worker{
+				ID:          row.ID,
+				Name:        row.Name,
+				Surname:     row.Surname,
+				Address:     row.Address,
+				PhoneNumber: row.PhoneNumber,
+				Email:       row.Email,
+				Role:        row.Role,
+			},
+			CompletedOrders: row.CompletedOrders,
+			CancelledOrders: row.CancelledOrders,
+			AverageRating:   row.AverageRating,
+			Revenue:         row.Revenue,
+		}
+	}
+
+	return report, nil
+}
+
+// OrderRatingDB represents the rating of a completed order as selected from the orders table.
+type OrderRatingDB struct {
+	Rate        int       `db:"rate"`         // Customer satisfaction rating
+	CompletedAt time.Time `db:"completed_at"` // When the order was completed
+}
+
+// GetCompletedOrderRatings retrieves the ratings of rated completed orders assigned
+// to a worker, except deleted ones. Orders completed before completion times were
+// recorded fall back to their creation date.
+//
+// Parameters:
+//   - workerID: UUID of the worker
+//
+// Returns:
+//   - []models.OrderRating: Ratings of the worker's completed orders, newest first
+//   - error: repository_errors.SelectError if the operation fails
+func (w WorkerRepository) GetCompletedOrderRatings(workerID uuid.UUID) ([]models.OrderRating, error) {
+	query := `SELECT rate, COALESCE(completed_at, creation_date) AS completed_at FROM orders
+		WHERE worker_id = $1 AND status = $2 AND rate != 0 AND deleted_at IS NULL
+		ORDER BY completed_at DESC;`
+	var ratings []OrderRatingDB
+
+	err := w.db.Select(&ratings, query, workerID, models.CompletedOrderStatus)
+
+	if err != nil {
+		return nil, repository_errors.SelectError
+	}
+
+	var ratingModels []models.OrderRating
+	for _, rating := range ratings {
+		ratingModels = append(ratingModels, models.OrderRating{Rate: rating.Rate, CompletedAt: rating.CompletedAt})
+	}
+
+	return ratingModels, nil
+}
+
+// SetAvailability records whether a worker is available during a period.
+// A later record overrides earlier ones where their periods overlap.
+//
+// Parameters:
+//   - workerID: UUID of the worker
+//   - from: Start of the period (inclusive)
+//   - to: End of the period (exclusive)
+//   - available: Whether the worker can take orders during the period
+//
+// Returns:
+//   - error: repository_errors.InsertError if the operation fails
+func (w WorkerRepository) SetAvailability(workerID uuid.UUID, from time.Time, to time.Time, available bool) error {
+	query := `INSERT INTO worker_availability(worker_id, from_time, to_time, available) VALUES ($1, $2, $3, $4);`
+
+	_, err := w.db.Exec(query, workerID, from, to, available)
+	if err != nil {
+		return repository_errors.InsertError
+	}
+
+	return nil
+}
+
+// IsAvailable reports whether a worker is available at the given moment. The
+// latest record covering the moment decides; a worker without such a record
+// is available.
+//
+// Parameters:
+//   - workerID: UUID of the worker
+//   - at: Moment to check
+//
+// Returns:
+//   - bool: Whether the worker is available
+//   - error: repository_errors.SelectError if the operation fails
+func (w WorkerRepository) IsAvailable(workerID uuid.UUID, at time.Time) (bool, error) {
+	query := `SELECT COALESCE((SELECT available FROM worker_availability
+		WHERE worker_id = $1 AND from_time <= $2 AND $2 < to_time
+		ORDER BY id DESC LIMIT 1), true);`
+	var available bool
+
+	err := w.db.Get(&available, query, workerID, at)
+
+	if err != nil {
+		return false, repository_errors.SelectError
+	}
+
+	return available, nil
+}
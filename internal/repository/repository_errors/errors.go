@@ -41,4 +41,12 @@ var (
 	// ConnectionError is returned when establishing a database connection fails.
 	// This may be due to incorrect credentials, network issues, or database server problems.
 	ConnectionError = errors.New("DB ERROR: Connection error")
+
+	// ReferencedByOpenOrders is returned when rows cannot be changed because
+	// orders that are still open refer to them.
+	ReferencedByOpenOrders = errors.New("DB ERROR: Rows are referenced by open orders")
+
+	// AlreadyExists is returned when a row cannot be inserted because an
+	// equivalent row is already stored.
+	AlreadyExists = errors.New("DB ERROR: Such row already exists")
 )
@@ -23,7 +23,8 @@ type ICategoryRepository interface {
 	//
 	// Returns:
 	//   - *models.Category: Retrieved category entity
-	//   - error: Error if retrieval fails or category not found
+	//   - error: repository_errors.DoesNotExist if category not found,
+	//     other error if retrieval fails
 	GetByID(id int) (*models.Category, error)
 
 	// Create adds a new category record to the data store.
@@ -54,4 +55,38 @@ type ICategoryRepository interface {
 	// Returns:
 	//   - error: Error if deletion fails
 	Delete(id int) error
+
+	// DeleteIfEmpty removes a category only if no task, archived ones included,
+	// belongs to it. The check and the deletion happen in a single statement.
+	//
+	// Parameters:
+	//   - id: Numeric ID of the category to delete
+	//
+	// Returns:
+	//   - int: Number of tasks in the category, 0 if it was deleted or does not exist
+	//   - error: Error if the operation fails
+	DeleteIfEmpty(id int) (int, error)
+
+	// DeleteMovingTasks moves all tasks of a category, archived ones included, to
+	// another category and removes the category in one transaction.
+	//
+	// Parameters:
+	//   - id: Numeric ID of the category to delete
+	//   - moveTo: Numeric ID of the category that receives the tasks
+	//
+	// Returns:
+	//   - int: Number of moved tasks
+	//   - error: Error if the category does not exist or the operation fails
+	DeleteMovingTasks(id int, moveTo int) (int, error)
+
+	// SearchByName retrieves categories whose names contain the given substring,
+	// ignoring case.
+	//
+	// Parameters:
+	//   - substring: Part of the category name to search for
+	//
+	// Returns:
+	//   - []models.Category: Slice of matching categories
+	//   - error: Error if retrieval fails
+	SearchByName(substring string) ([]models.Category, error)
 }
@@ -6,6 +6,7 @@ package repository_interfaces
 import (
 	"github.com/google/uuid"
 	"teamdev/internal/models"
+	"time"
 )
 
 // IOrderRepository defines the contract for order data persistence operations.
@@ -23,15 +24,36 @@ type IOrderRepository interface {
 	//   - error: Error if creation fails
 	Create(order *models.Order, orderedTasks []models.OrderedTask) (*models.Order, error)
 
-	// Delete removes an order record and all associated task relationships from the data store.
+	// Delete marks an order as deleted. The order and its tasks are kept, so the
+	// order can be restored, but it is hidden from regular queries and revenue reports.
 	//
 	// Parameters:
 	//   - id: UUID of the order to delete
 	//
 	// Returns:
-	//   - error: Error if deletion fails
+	//   - error: Error if deletion fails or the order is not found
 	Delete(id uuid.UUID) error
 
+	// HardDelete permanently removes an order record and all associated task
+	// relationships from the data store. It is meant for administrative cleanup only.
+	//
+	// Parameters:
+	//   - id: UUID of the order to remove
+	//
+	// Returns:
+	//   - error: repository_errors.DoesNotExist if no order was found,
+	//     other error if deletion fails
+	HardDelete(id uuid.UUID) error
+
+	// Restore clears the deletion mark of a deleted order.
+	//
+	// Parameters:
+	//   - id: UUID of the order to restore
+	//
+	// Returns:
+	//   - error: Error if the update fails or no deleted order is found
+	Restore(id uuid.UUID) error
+
 	// Update modifies an existing order record in the data store.
 	//
 	// Parameters:
@@ -42,7 +64,7 @@ type IOrderRepository interface {
 	//   - error: Error if update fails
 	Update(order *models.Order) (*models.Order, error)
 
-	// GetOrderByID retrieves an order by unique identifier.
+	// GetOrderByID retrieves an order by unique identifier. Deleted orders are not returned.
 	//
 	// Parameters:
 	//   - id: UUID of the order to retrieve
@@ -62,7 +84,9 @@ type IOrderRepository interface {
 	//   - error: Error if retrieval fails
 	GetTasksInOrder(id uuid.UUID) ([]models.Task, error)
 
-	// GetCurrentOrderByUserID retrieves the most recent order for a specific user.
+	// GetCurrentOrderByUserID retrieves the current order of a specific user, not
+	// counting deleted orders. The most recent new or in-progress order is current;
+	// if the user has none, the most recent order of any status is returned.
 	//
 	// Parameters:
 	//   - id: UUID of the user to retrieve the current order for
@@ -72,25 +96,97 @@ type IOrderRepository interface {
 	//   - error: Error if retrieval fails or no orders found
 	GetCurrentOrderByUserID(id uuid.UUID) (*models.Order, error)
 
-	// GetAllOrdersByUserID retrieves all orders for a specific user.
+	// GetAllOrdersByUserID retrieves all orders for a specific user, except deleted ones.
 	//
 	// Parameters:
 	//   - id: UUID of the user to retrieve orders for
 	//
 	// Returns:
-	//   - []models.Order: Slice of order entities for the specified user
+	//   - []models.Order: Slice of order entities for the specified user, newest first
 	//   - error: Error if retrieval fails
 	GetAllOrdersByUserID(id uuid.UUID) ([]models.Order, error)
 
-	// AddTaskToOrder associates a task with an order.
+	// ImportOrders recreates orders together with their tasks in one transaction,
+	// keeping their status, rating, worker, timestamps and the positive unit
+	// prices of their tasks. The new identifiers are set on the passed orders.
+	//
+	// Parameters:
+	//   - orders: Orders to insert with their tasks and quantities
+	//
+	// Returns:
+	//   - error: Error if the operation fails, in which case no order is inserted
+	ImportOrders(orders []models.OrderWithTasks) error
+
+	// GetAllOrdersByWorkerID retrieves all orders assigned to a specific worker, except deleted ones.
+	//
+	// Parameters:
+	//   - id: UUID of the worker to retrieve orders for
+	//
+	// Returns:
+	//   - []models.Order: Slice of order entities assigned to the specified worker, newest first
+	//   - error: Error if retrieval fails
+	GetAllOrdersByWorkerID(id uuid.UUID) ([]models.Order, error)
+
+	// GetOrdersByUserIDPaged retrieves one page of a user's orders, newest first,
+	// together with the total number of the user's orders.
+	//
+	// Parameters:
+	//   - userID: UUID of the user to retrieve orders for
+	//   - limit: Maximum number of orders to return
+	//   - offset: Number of orders to skip
+	//
+	// Returns:
+	//   - []models.Order: Slice of order entities on the requested page
+	//   - int: Total number of orders of the user
+	//   - error: Error if retrieval fails
+	GetOrdersByUserIDPaged(userID uuid.UUID, limit int, offset int) ([]models.Order, int, error)
+
+	// AddTaskToOrder associates a task with an order, storing the current unit
+	// price of the task with its volume tier applied.
 	//
 	// Parameters:
 	//   - orderID: UUID of the order
 	//   - taskID: UUID of the task to add
+	//   - quantity: Initial quantity of the task in the order
 	//
 	// Returns:
 	//   - error: Error if association fails
-	AddTaskToOrder(orderID uuid.UUID, taskID uuid.UUID) error
+	AddTaskToOrder(orderID uuid.UUID, taskID uuid.UUID, quantity int) error
+
+	// AddTasksToOrder associates several tasks with an order in one transaction.
+	// Nothing is inserted if any of the tasks is already attached to the order.
+	//
+	// Parameters:
+	//   - orderID: UUID of the order
+	//   - orderedTasks: Tasks to add together with their quantities
+	//
+	// Returns:
+	//   - error: repository_errors.AlreadyExists if a task is already attached,
+	//     other error if the operation fails
+	AddTasksToOrder(orderID uuid.UUID, orderedTasks []models.OrderedTask) error
+
+	// GetOrderTotalPrice computes the total price of an order from the unit prices
+	// stored when the tasks were added and their quantities.
+	//
+	// Parameters:
+	//   - orderID: UUID of the order
+	//
+	// Returns:
+	//   - float64: Total price of the order, 0 if it has no tasks
+	//   - error: Error if retrieval fails
+	GetOrderTotalPrice(orderID uuid.UUID) (float64, error)
+
+	// RequoteOrder sets the quoted total of an order to the sum of its tasks at
+	// the unit prices stored with them, rounded with the given mode, so the quote
+	// follows changes of the order's tasks.
+	//
+	// Parameters:
+	//   - orderID: UUID of the order
+	//   - rounding: Rounding mode applied to the total
+	//
+	// Returns:
+	//   - error: Error if update fails
+	RequoteOrder(orderID uuid.UUID, rounding models.RoundingMode) error
 
 	// RemoveTaskFromOrder removes a task association from an order.
 	//
@@ -113,6 +209,29 @@ type IOrderRepository interface {
 	//   - error: Error if update fails
 	UpdateTaskQuantity(orderID uuid.UUID, taskID uuid.UUID, quantity int) error
 
+	// IncrementTaskQuantity atomically increases the quantity of a task in an order by one.
+	//
+	// Parameters:
+	//   - orderID: UUID of the order
+	//   - taskID: UUID of the task
+	//
+	// Returns:
+	//   - int: Quantity of the task after the increment
+	//   - error: Error if the task is not in the order or the update fails
+	IncrementTaskQuantity(orderID uuid.UUID, taskID uuid.UUID) (int, error)
+
+	// DecrementTaskQuantity atomically decreases a positive quantity of a task in
+	// an order by one. Nothing is changed if the quantity is already 0.
+	//
+	// Parameters:
+	//   - orderID: UUID of the order
+	//   - taskID: UUID of the task
+	//
+	// Returns:
+	//   - int: Quantity of the task after the decrement
+	//   - error: Error if no row was updated or the update fails
+	DecrementTaskQuantity(orderID uuid.UUID, taskID uuid.UUID) (int, error)
+
 	// GetTaskQuantity retrieves the quantity of a specific task in an order.
 	//
 	// Parameters:
@@ -124,13 +243,229 @@ type IOrderRepository interface {
 	//   - error: Error if retrieval fails
 	GetTaskQuantity(orderID uuid.UUID, taskID uuid.UUID) (int, error)
 
-	// Filter retrieves orders matching the specified criteria.
+	// GetOrderedTasks retrieves the tasks of an order together with their quantities.
+	// The price of each task is the unit price stored when it was added to the
+	// order, volume tier included.
+	//
+	// Parameters:
+	//   - orderID: UUID of the order
+	//
+	// Returns:
+	//   - []models.OrderedTask: Tasks of the order with their quantities, ordered by name
+	//   - error: Error if retrieval fails
+	GetOrderedTasks(orderID uuid.UUID) ([]models.OrderedTask, error)
+
+	// Filter retrieves orders matching the specified criteria, newest first.
 	//
 	// Parameters:
 	//   - params: Map of field names to filter values
+	//   - limit: Maximum number of orders to return, 0 for no limit
+	//   - offset: Number of matching orders to skip
+	//   - includeDeleted: Whether deleted orders are returned as well
+	//
+	// Returns:
+	//   - []models.Order: Slice of order entities matching the filter criteria
+	//   - error: Error if filtering fails
+	Filter(params map[string]string, limit int, offset int, includeDeleted bool) ([]models.Order, error)
+
+	// GetOrdersByIDs retrieves all orders whose identifiers are in the given list.
+	// Identifiers without a matching order are simply absent from the result.
+	//
+	// Parameters:
+	//   - ids: Slice of order UUIDs to retrieve
+	//
+	// Returns:
+	//   - []models.Order: Slice of found order entities
+	//   - error: Error if retrieval fails
+	GetOrdersByIDs(ids []uuid.UUID) ([]models.Order, error)
+
+	// FilterByStatusAndDate retrieves orders of a user matching any of the given
+	// statuses and created within the given period, newest first. A nil user, an
+	// empty status slice and zero-value dates disable the corresponding condition.
+	//
+	// Parameters:
+	//   - userID: UUID of the customer, uuid.Nil for orders of every customer
+	//   - statuses: Slice of status codes to match
+	//   - from: Start of the creation period (inclusive)
+	//   - to: End of the creation period (inclusive)
 	//
 	// Returns:
 	//   - []models.Order: Slice of order entities matching the filter criteria
 	//   - error: Error if filtering fails
-	Filter(params map[string]string) ([]models.Order, error)
+	FilterByStatusAndDate(userID uuid.UUID, statuses []int, from time.Time, to time.Time) ([]models.Order, error)
+
+	// GetOverdueOrders retrieves new and in-progress orders whose deadline is
+	// before the given moment, the most overdue first.
+	//
+	// Parameters:
+	//   - now: Moment the deadlines are compared with
+	//
+	// Returns:
+	//   - []models.Order: Slice of overdue order entities
+	//   - error: Error if retrieval fails
+	GetOverdueOrders(now time.Time) ([]models.Order, error)
+
+	// GetDigestBetween aggregates order activity within the given period: created,
+	// completed and cancelled orders, revenue of completed orders, and active
+	// orders whose deadline is before the end of the period.
+	//
+	// Parameters:
+	//   - from: Start of the period (inclusive)
+	//   - to: End of the period (exclusive)
+	//
+	// Returns:
+	//   - *models.DailyDigest: Aggregated counters for the period
+	//   - error: Error if retrieval fails
+	GetDigestBetween(from time.Time, to time.Time) (*models.DailyDigest, error)
+
+	// GetAverageOrderValue computes the mean billed total of orders completed
+	// within the given period, like the average of GetRevenueSummary.
+	//
+	// Parameters:
+	//   - from: Start of the period (inclusive)
+	//   - to: End of the period (inclusive)
+	//
+	// Returns:
+	//   - float64: Average order value, 0 if no orders were completed in the period
+	//   - error: Error if retrieval fails
+	GetAverageOrderValue(from time.Time, to time.Time) (float64, error)
+
+	// GetRevenueSummary totals the orders completed within the given period.
+	//
+	// Parameters:
+	//   - from: Start of the period (inclusive)
+	//   - to: End of the period (inclusive)
+	//
+	// Returns:
+	//   - *models.RevenueSummary: Revenue, number and average value of the orders
+	//   - error: Error if retrieval fails
+	GetRevenueSummary(from time.Time, to time.Time) (*models.RevenueSummary, error)
+
+	// GetOrdersByRateBelow retrieves completed orders rated below the threshold.
+	// Unrated orders are skipped.
+	//
+	// Parameters:
+	//   - threshold: Rating the orders must be below
+	//
+	// Returns:
+	//   - []models.Order: Slice of matching order entities, lowest rated first
+	//   - error: Error if retrieval fails
+	GetOrdersByRateBelow(threshold int) ([]models.Order, error)
+
+	// SearchByCustomerName retrieves orders placed by users whose name, surname or
+	// full name contains the given substring, ignoring case.
+	//
+	// Parameters:
+	//   - substring: Part of the customer name to search for
+	//
+	// Returns:
+	//   - []models.Order: Slice of matching order entities
+	//   - error: Error if retrieval fails
+	SearchByCustomerName(substring string) ([]models.Order, error)
+
+	// SearchByAddress retrieves orders whose address contains the given substring,
+	// ignoring case.
+	//
+	// Parameters:
+	//   - substring: Part of the address to search for
+	//
+	// Returns:
+	//   - []models.Order: Slice of matching order entities, newest first
+	//   - error: Error if retrieval fails
+	SearchByAddress(substring string) ([]models.Order, error)
+
+	// GetDeadlineDayOfWeekCounts counts orders that are not cancelled and whose
+	// deadline falls within the given period, grouped by the weekday of the deadline.
+	//
+	// Parameters:
+	//   - from: Start of the deadline period (inclusive)
+	//   - to: End of the deadline period (inclusive)
+	//
+	// Returns:
+	//   - map[time.Weekday]int: Number of orders per weekday
+	//   - error: Error if retrieval fails
+	GetDeadlineDayOfWeekCounts(from time.Time, to time.Time) (map[time.Weekday]int, error)
+
+	// RecomputeNewOrderTotals sets the quoted total of every order with the New
+	// status to the sum of its tasks at current prices, rounded with the given
+	// mode. The task prices stored with these orders are refreshed as well.
+	// Deleted orders are left alone.
+	//
+	// Parameters:
+	//   - rounding: Rounding mode applied to the totals
+	//
+	// Returns:
+	//   - int: Number of updated orders
+	//   - error: Error if update fails
+	RecomputeNewOrderTotals(rounding models.RoundingMode) (int, error)
+
+	// AddTag attaches a tag to an order. Attaching a tag the order already has
+	// is not an error.
+	//
+	// Parameters:
+	//   - orderID: UUID of the order
+	//   - tag: Normalized tag
+	//
+	// Returns:
+	//   - error: Error if the order does not exist or insertion fails
+	AddTag(orderID uuid.UUID, tag string) error
+
+	// RemoveTag detaches a tag from an order.
+	//
+	// Parameters:
+	//   - orderID: UUID of the order
+	//   - tag: Normalized tag
+	//
+	// Returns:
+	//   - error: Error if the order does not have the tag or deletion fails
+	RemoveTag(orderID uuid.UUID, tag string) error
+
+	// GetTags retrieves the tags of an order.
+	//
+	// Parameters:
+	//   - orderID: UUID of the order
+	//
+	// Returns:
+	//   - []string: Tags of the order in alphabetical order
+	//   - error: Error if retrieval fails
+	GetTags(orderID uuid.UUID) ([]string, error)
+
+	// GetOrdersByTag retrieves orders that have the given tag.
+	//
+	// Parameters:
+	//   - tag: Normalized tag
+	//
+	// Returns:
+	//   - []models.Order: Slice of tagged orders
+	//   - error: Error if retrieval fails
+	GetOrdersByTag(tag string) ([]models.Order, error)
+
+	// SaveDraft stores the customer's draft order, replacing any previous draft.
+	//
+	// Parameters:
+	//   - draft: Draft order with the customer ID, address and selected tasks
+	//
+	// Returns:
+	//   - error: Error if saving fails
+	SaveDraft(draft *models.DraftOrder) error
+
+	// GetDraft retrieves the draft order of a customer together with its tasks.
+	//
+	// Parameters:
+	//   - userID: UUID of the customer
+	//
+	// Returns:
+	//   - *models.DraftOrder: Stored draft order
+	//   - error: Error if the customer has no draft or retrieval fails
+	GetDraft(userID uuid.UUID) (*models.DraftOrder, error)
+
+	// DeleteDraft removes the draft order of a customer. Removing a missing draft
+	// is not an error.
+	//
+	// Parameters:
+	//   - userID: UUID of the customer
+	//
+	// Returns:
+	//   - error: Error if deletion fails
+	DeleteDraft(userID uuid.UUID) error
 }
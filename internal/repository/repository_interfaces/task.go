@@ -6,6 +6,7 @@ package repository_interfaces
 import (
 	"github.com/google/uuid"
 	"teamdev/internal/models"
+	"time"
 )
 
 // ITaskRepository defines the contract for task data persistence operations.
@@ -51,14 +52,48 @@ type ITaskRepository interface {
 	//   - error: Error if retrieval fails or task not found
 	GetTaskByID(id uuid.UUID) (*models.Task, error)
 
-	// GetAllTasks retrieves all tasks from the data store.
+	// GetTasksByIDs retrieves several tasks by their identifiers in one query.
+	// Identifiers without a matching task are absent from the result.
+	//
+	// Parameters:
+	//   - ids: UUIDs of the tasks to retrieve
+	//
+	// Returns:
+	//   - map[uuid.UUID]models.Task: Found tasks keyed by their ID
+	//   - error: Error if retrieval fails
+	GetTasksByIDs(ids []uuid.UUID) (map[uuid.UUID]models.Task, error)
+
+	// GetAllTasks retrieves all tasks from the data store that are not archived.
 	//
 	// Returns:
 	//   - []models.Task: Slice of all task entities
 	//   - error: Error if retrieval fails
 	GetAllTasks() ([]models.Task, error)
 
-	// GetTasksInCategory retrieves all tasks belonging to a specific category.
+	// GetAllTasksPaged retrieves one page of the tasks that are not archived,
+	// sorted by the given field, together with the total number of such tasks.
+	//
+	// Parameters:
+	//   - limit: Maximum number of tasks to return
+	//   - offset: Number of tasks to skip
+	//   - sortBy: One of the fields in models.TaskSortFields
+	//   - asc: Whether tasks are sorted in ascending order
+	//
+	// Returns:
+	//   - []models.Task: Tasks on the requested page
+	//   - int: Total number of tasks that are not archived
+	//   - error: Error if the sort field is unknown or retrieval fails
+	GetAllTasksPaged(limit, offset int, sortBy string, asc bool) ([]models.Task, int, error)
+
+	// GetAllTasksWithCategoryNames retrieves all tasks that are not archived together
+	// with the names of their categories.
+	//
+	// Returns:
+	//   - []models.TaskWithCategory: Tasks with resolved category names
+	//   - error: Error if retrieval fails
+	GetAllTasksWithCategoryNames() ([]models.TaskWithCategory, error)
+
+	// GetTasksInCategory retrieves all tasks belonging to a specific category that are not archived.
 	//
 	// Parameters:
 	//   - category: Category ID to filter by
@@ -77,4 +112,131 @@ type ITaskRepository interface {
 	//   - *models.Task: Retrieved task entity
 	//   - error: Error if retrieval fails or task not found
 	GetTaskByName(name string) (*models.Task, error)
+
+	// SearchTasksByName retrieves tasks that are not archived and whose names
+	// contain the given substring, ignoring case.
+	//
+	// Parameters:
+	//   - query: Part of the task name to search for, empty to match every task
+	//
+	// Returns:
+	//   - []models.Task: Slice of matching task entities ordered by name
+	//   - error: Error if retrieval fails
+	SearchTasksByName(query string) ([]models.Task, error)
+
+	// GetTasksModifiedSince retrieves tasks created or changed at or after the given
+	// time, including archived ones.
+	//
+	// Parameters:
+	//   - since: Earliest modification time to include
+	//
+	// Returns:
+	//   - []models.Task: Slice of modified task entities, least recently modified first
+	//   - error: Error if retrieval fails
+	GetTasksModifiedSince(since time.Time) ([]models.Task, error)
+
+	// GetUnorderedTasks retrieves all tasks that have never been included in any order.
+	//
+	// Returns:
+	//   - []models.Task: Slice of tasks not referenced by any order
+	//   - error: Error if retrieval fails
+	GetUnorderedTasks() ([]models.Task, error)
+
+	// GetMostOrderedTasks ranks tasks by the total quantity ordered, most ordered first.
+	// Cancelled orders are not counted. Zero-value dates disable the corresponding
+	// condition on the order creation date.
+	//
+	// Parameters:
+	//   - limit: Maximum number of tasks to return
+	//   - from: Start of the creation period (inclusive)
+	//   - to: End of the creation period (inclusive)
+	//
+	// Returns:
+	//   - []models.TaskPopularity: Tasks with their total quantity and number of orders
+	//   - error: Error if retrieval fails
+	GetMostOrderedTasks(limit int, from time.Time, to time.Time) ([]models.TaskPopularity, error)
+
+	// ArchiveCategory marks all tasks of a category as archived in one transaction.
+	// Unless forced, nothing is archived when any of the tasks belongs to an open order.
+	//
+	// Parameters:
+	//   - category: Category ID whose tasks are archived
+	//   - force: Whether to archive tasks referenced by open orders
+	//
+	// Returns:
+	//   - int: Number of archived tasks
+	//   - error: repository_errors.ReferencedByOpenOrders if open orders refer to the tasks
+	//     and force is not set, other error if the operation fails
+	ArchiveCategory(category int, force bool) (int, error)
+
+	// AdjustCategoryPrices multiplies the price of every task of a category that is
+	// not archived by the multiplier in a single transaction, rounding to whole
+	// cents with the given mode.
+	//
+	// Parameters:
+	//   - category: Category ID whose prices are adjusted
+	//   - multiplier: Factor applied to the prices
+	//   - rounding: Rounding mode applied to the new prices
+	//
+	// Returns:
+	//   - int: Number of tasks whose price was adjusted
+	//   - error: Error if the update fails, in which case no price is changed
+	AdjustCategoryPrices(category int, multiplier float64, rounding models.RoundingMode) (int, error)
+
+	// UpsertByName inserts new tasks and updates the price and category of existing
+	// ones in one transaction. Tasks are matched by name case-insensitively.
+	//
+	// Parameters:
+	//   - tasks: Tasks to insert or update
+	//
+	// Returns:
+	//   - created: Number of inserted tasks
+	//   - updated: Number of updated tasks
+	//   - err: Error if the operation fails, in which case nothing is changed
+	UpsertByName(tasks []models.Task) (created int, updated int, err error)
+
+	// InsertMissingByName inserts the tasks whose name is not in the catalog yet
+	// in one transaction and leaves existing ones untouched. Tasks are matched by
+	// name case-insensitively.
+	//
+	// Parameters:
+	//   - tasks: Tasks to insert
+	//
+	// Returns:
+	//   - created: Number of inserted tasks
+	//   - skipped: Number of tasks that already existed
+	//   - err: Error if the operation fails, in which case nothing is changed
+	InsertMissingByName(tasks []models.Task) (created int, skipped int, err error)
+
+	// GetPriceTiers retrieves the volume discounts of a task.
+	//
+	// Parameters:
+	//   - taskID: UUID of the task
+	//
+	// Returns:
+	//   - []models.PriceTier: Price tiers of the task, smallest minimum quantity first
+	//   - error: Error if retrieval fails
+	GetPriceTiers(taskID uuid.UUID) ([]models.PriceTier, error)
+
+	// GetPriceTiersByTaskIDs retrieves the volume discounts of several tasks in one query.
+	// Tasks without tiers are absent from the result.
+	//
+	// Parameters:
+	//   - taskIDs: Slice of task UUIDs
+	//
+	// Returns:
+	//   - map[uuid.UUID][]models.PriceTier: Price tiers keyed by task ID, smallest minimum quantity first
+	//   - error: Error if retrieval fails
+	GetPriceTiersByTaskIDs(taskIDs []uuid.UUID) (map[uuid.UUID][]models.PriceTier, error)
+
+	// SetPriceTiers replaces the volume discounts of a task in one transaction
+	// and bumps the task's last change time.
+	//
+	// Parameters:
+	//   - taskID: UUID of the task
+	//   - tiers: New price tiers, empty to remove all discounts
+	//
+	// Returns:
+	//   - error: Error if the operation fails, in which case nothing is changed
+	SetPriceTiers(taskID uuid.UUID, tiers []models.PriceTier) error
 }
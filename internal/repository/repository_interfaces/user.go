@@ -19,7 +19,8 @@ type IUserRepository interface {
 	//
 	// Returns:
 	//   - *models.User: Created user with assigned ID
-	//   - error: Error if creation fails
+	//   - error: repository_errors.AlreadyExists if the email is already taken,
+	//     other errors if creation fails
 	Create(user *models.User) (*models.User, error)
 
 	// Update modifies an existing user record in the data store.
@@ -70,4 +71,33 @@ type IUserRepository interface {
 	//   - *models.User: Retrieved user entity
 	//   - error: Error if retrieval fails or user not found
 	GetUserByEmail(email string) (*models.User, error)
+
+	// GetCustomersWithCompletedOrders retrieves users who have at least one completed order.
+	// For security reasons, this method does not return password data.
+	//
+	// Returns:
+	//   - []models.User: Slice of users with completed orders
+	//   - error: Error if retrieval fails
+	GetCustomersWithCompletedOrders() ([]models.User, error)
+
+	// GetOrderStatistics summarizes the orders of a user in a single aggregate query.
+	// Deleted orders are not counted.
+	//
+	// Parameters:
+	//   - userID: UUID of the user
+	//
+	// Returns:
+	//   - *models.UserStats: Order counts, total spent and average rating, zero for a user without orders
+	//   - error: Error if retrieval fails
+	GetOrderStatistics(userID uuid.UUID) (*models.UserStats, error)
+
+	// UpdatePassword replaces the stored password hash of a user.
+	//
+	// Parameters:
+	//   - id: UUID of the user
+	//   - password: New password hash
+	//
+	// Returns:
+	//   - error: Error if update fails
+	UpdatePassword(id uuid.UUID, password string) error
 }
@@ -6,6 +6,7 @@ package repository_interfaces
 import (
 	"github.com/google/uuid"
 	"teamdev/internal/models"
+	"time"
 )
 
 // IWorkerRepository defines the contract for worker data persistence operations.
@@ -19,7 +20,8 @@ type IWorkerRepository interface {
 	//
 	// Returns:
 	//   - *models.Worker: Created worker with assigned ID
-	//   - error: Error if creation fails
+	//   - error: repository_errors.AlreadyExists if the email is already taken,
+	//     other errors if creation fails
 	Create(worker *models.Worker) (*models.Worker, error)
 
 	// Update modifies an existing worker record in the data store.
@@ -32,7 +34,8 @@ type IWorkerRepository interface {
 	//   - error: Error if update fails
 	Update(worker *models.Worker) (*models.Worker, error)
 
-	// Delete removes a worker record from the data store by ID.
+	// Delete removes a worker record from the data store by ID. Active orders of
+	// the worker are unassigned atomically with the deletion.
 	//
 	// Parameters:
 	//   - id: UUID of the worker to delete
@@ -72,11 +75,12 @@ type IWorkerRepository interface {
 	//
 	// Parameters:
 	//   - role: Role ID to filter by
+	//   - excludeID: UUID of a worker to leave out of the result (uuid.Nil to keep everyone)
 	//
 	// Returns:
 	//   - []models.Worker: Slice of workers with the specified role
 	//   - error: Error if retrieval fails
-	GetWorkersByRole(role int) ([]models.Worker, error)
+	GetWorkersByRole(role int, excludeID uuid.UUID) ([]models.Worker, error)
 
 	// GetAverageOrderRate calculates the average rating for completed orders
 	// assigned to a specific worker.
@@ -88,4 +92,123 @@ type IWorkerRepository interface {
 	//   - float64: Average rating value (0.0-5.0)
 	//   - error: Error if calculation fails
 	GetAverageOrderRate(worker *models.Worker) (float64, error)
+
+	// GetActiveOrdersCount counts new and in-progress orders assigned to a worker.
+	//
+	// Parameters:
+	//   - workerID: UUID of the worker
+	//
+	// Returns:
+	//   - int: Number of active orders assigned to the worker
+	//   - error: Error if retrieval fails
+	GetActiveOrdersCount(workerID uuid.UUID) (int, error)
+
+	// GetRevenueBetween sums the billed totals of completed orders assigned to a worker
+	// and completed within the given period. Deleted orders are skipped.
+	//
+	// Parameters:
+	//   - workerID: UUID of the worker
+	//   - from: Start of the period (inclusive)
+	//   - to: End of the period (inclusive)
+	//
+	// Returns:
+	//   - float64: Total revenue of the worker in the period
+	//   - error: Error if retrieval fails
+	GetRevenueBetween(workerID uuid.UUID, from time.Time, to time.Time) (float64, error)
+
+	// GetCompletedOrdersInYear retrieves the completed orders assigned to a worker
+	// that were completed in the given calendar year. Deleted orders are skipped.
+	//
+	// Parameters:
+	//   - workerID: UUID of the worker
+	//   - year: Calendar year of completion
+	//
+	// Returns:
+	//   - []models.Order: Completed orders, oldest completion first
+	//   - error: Error if retrieval fails
+	GetCompletedOrdersInYear(workerID uuid.UUID, year int) ([]models.Order, error)
+
+	// UpdateLastLogin records the time of the latest successful login of a worker.
+	//
+	// Parameters:
+	//   - id: UUID of the worker
+	//   - loggedInAt: Time of the login
+	//
+	// Returns:
+	//   - error: Error if update fails
+	UpdateLastLogin(id uuid.UUID, loggedInAt time.Time) error
+
+	// UpdatePassword replaces the stored password hash of a worker.
+	//
+	// Parameters:
+	//   - id: UUID of the worker
+	//   - password: New password hash
+	//
+	// Returns:
+	//   - error: Error if update fails
+	UpdatePassword(id uuid.UUID, password string) error
+
+	// GetTeamComparison computes the rating and the number of completed orders of
+	// every master together with the team averages and the rating percentile.
+	//
+	// Returns:
+	//   - []models.WorkerComparison: Comparison of every master, best rated first
+	//   - error: Error if retrieval fails
+	GetTeamComparison() ([]models.WorkerComparison, error)
+
+	// GetAssignmentCandidates selects the masters rated at or above the threshold
+	// who can take another order.
+	//
+	// Parameters:
+	//   - minRating: Minimum average rating of a candidate
+	//   - maxActiveOrders: Maximum number of active orders per master, 0 means unlimited
+	//
+	// Returns:
+	//   - []models.WorkerWithRating: Candidates, best rated first, then least busy first
+	//   - error: Error if retrieval fails
+	GetAssignmentCandidates(minRating float64, maxActiveOrders int) ([]models.WorkerWithRating, error)
+
+	// GetPerformanceReport counts the completed and cancelled orders of every
+	// master and computes their average rating and revenue.
+	//
+	// Returns:
+	//   - []models.WorkerPerformance: Report row of every master, best rated first
+	//   - error: Error if retrieval fails
+	GetPerformanceReport() ([]models.WorkerPerformance, error)
+
+	// GetCompletedOrderRatings retrieves the ratings of rated completed orders
+	// assigned to a worker together with their completion time.
+	//
+	// Parameters:
+	//   - workerID: UUID of the worker
+	//
+	// Returns:
+	//   - []models.OrderRating: Ratings of the worker's completed orders
+	//   - error: Error if retrieval fails
+	GetCompletedOrderRatings(workerID uuid.UUID) ([]models.OrderRating, error)
+
+	// SetAvailability records whether a worker is available during a period.
+	// A later record overrides earlier ones where their periods overlap.
+	//
+	// Parameters:
+	//   - workerID: UUID of the worker
+	//   - from: Start of the period (inclusive)
+	//   - to: End of the period (exclusive)
+	//   - available: Whether the worker can take orders during the period
+	//
+	// Returns:
+	//   - error: Error if the record cannot be saved
+	SetAvailability(workerID uuid.UUID, from time.Time, to time.Time, available bool) error
+
+	// IsAvailable reports whether a worker is available at the given moment.
+	// A worker without a record covering the moment is available.
+	//
+	// Parameters:
+	//   - workerID: UUID of the worker
+	//   - at: Moment to check
+	//
+	// Returns:
+	//   - bool: Whether the worker is available
+	//   - error: Error if retrieval fails
+	IsAvailable(workerID uuid.UUID, at time.Time) (bool, error)
 }
@@ -3,8 +3,11 @@
 package interfaces
 
 import (
+	"fmt"
+	"strings"
 	"teamdev/internal/models"
 	"teamdev/internal/repository/repository_interfaces"
+	"teamdev/internal/services/service_errors"
 
 	"github.com/charmbracelet/log"
 )
@@ -75,19 +78,79 @@ func (c *CategoryService) Update(category *models.Category) (*models.Category, e
 	return category, nil
 }
 
-// Delete removes a category from the system by ID.
+// Delete removes a category from the system by ID. A category that still has
+// tasks, archived ones included, is only deleted when forced; its tasks are then
+// moved to the uncategorized category, which is created on first use.
 //
 // Parameters:
 //   - id: ID of the category to delete
+//   - force: Whether to move the tasks of a non-empty category and delete it anyway
 //
 // Returns:
-//   - error: Error if deletion fails
-func (c *CategoryService) Delete(id int) error {
-	err := c.CategoryRepository.Delete(id)
+//   - error: service_errors.CategoryHasTasks if the category has tasks and force is not set,
+//     service_errors.InvalidCategory when forcing the deletion of the uncategorized category,
+//     or a repository error if deletion fails
+func (c *CategoryService) Delete(id int, force bool) error {
+	tasks, err := c.CategoryRepository.DeleteIfEmpty(id)
 	if err != nil {
-		c.logger.Error("Error deleting category")
+		c.logger.Error("Error deleting category", "id", id, "error", err)
+		return err
 	}
-	return err
+
+	if tasks == 0 {
+		return nil
+	}
+
+	if !force {
+		c.logger.Error("Category still has tasks", "id", id, "tasks", tasks)
+		return fmt.Errorf("%w: %d task(s) in category %d", service_errors.CategoryHasTasks, tasks, id)
+	}
+
+	uncategorized, err := c.uncategorized()
+	if err != nil {
+		return err
+	}
+	if uncategorized.ID == id {
+		c.logger.Error("Cannot move tasks of the uncategorized category", "id", id)
+		return service_errors.InvalidCategory
+	}
+
+	moved, err := c.CategoryRepository.DeleteMovingTasks(id, uncategorized.ID)
+	if err != nil {
+		c.logger.Error("Error deleting category", "id", id, "error", err)
+		return err
+	}
+
+	c.logger.Info("Deleted category and moved its tasks", "id", id, "moved", moved, "to", uncategorized.ID)
+	return nil
+}
+
+// uncategorized returns the category that receives the tasks of force-deleted
+// categories, creating it if it does not exist yet.
+//
+// Returns:
+//   - *models.Category: The uncategorized category
+//   - error: Error if the category cannot be found or created
+func (c *CategoryService) uncategorized() (*models.Category, error) {
+	categories, err := c.CategoryRepository.SearchByName(models.UncategorizedCategoryName)
+	if err != nil {
+		c.logger.Error("Error searching for the uncategorized category")
+		return nil, err
+	}
+
+	for i := range categories {
+		if strings.EqualFold(categories[i].Name, models.UncategorizedCategoryName) {
+			return &categories[i], nil
+		}
+	}
+
+	category, err := c.CategoryRepository.Create(&models.Category{Name: models.UncategorizedCategoryName})
+	if err != nil {
+		c.logger.Error("Error creating the uncategorized category")
+		return nil, err
+	}
+
+	return category, nil
 }
 
 // GetAll retrieves all categories from the system.
@@ -140,3 +203,27 @@ func (c *CategoryService) GetTasksInCategory(id int) ([]models.Task, error) {
 
 	return tasks, nil
 }
+
+// SearchByName retrieves categories whose names contain the given substring,
+// ignoring case.
+//
+// Parameters:
+//   - substring: Part of the category name to search for
+//
+// Returns:
+//   - []models.Category: Slice of matching categories
+//   - error: service_errors.InvalidName if the substring is empty, or retrieval error
+func (c *CategoryService) SearchByName(substring string) ([]models.Category, error) {
+	if strings.TrimSpace(substring) == "" {
+		c.logger.Error("Empty category search query")
+		return nil, service_errors.InvalidName
+	}
+
+	categories, err := c.CategoryRepository.SearchByName(substring)
+	if err != nil {
+		c.logger.Error("Error searching categories by name")
+		return nil, err
+	}
+
+	return categories, nil
+}
@@ -0,0 +1,153 @@
+// Package interfaces defines the core service interfaces and implementations
+// of the PikaClean application. This file keeps track of failed logins so that
+// user and worker services can lock out an email after too many of them.
+package interfaces
+
+import (
+	"strings"
+	"sync"
+	"teamdev/internal/models"
+	"time"
+)
+
+// loginAttempt holds the failed login attempts made with one email.
+type loginAttempt struct {
+	failures     int       // Number of failures since firstFailure
+	firstFailure time.Time // Moment of the first failure in the current window
+	lockedUntil  time.Time // Moment the lock is lifted (zero if not locked)
+}
+
+// loginAttempts counts failed logins per email and locks an email out after
+// too many failures within the configured window. It is safe for concurrent use.
+type loginAttempts struct {
+	settings  models.LockoutSettings   // Threshold and window of the lockout
+	mu        sync.Mutex               // Guards attempts and lastPrune
+	attempts  map[string]*loginAttempt // Failed attempts by normalized email
+	lastPrune time.Time                // Moment expired attempts were last dropped
+}
+
+// newLoginAttempts creates an empty failed login counter.
+//
+// Parameters:
+//   - settings: Threshold and window of the lockout
+//
+// Returns:
+//   - *loginAttempts: Counter with no recorded failures
+func newLoginAttempts(settings models.LockoutSettings) *loginAttempts {
+	return &loginAttempts{
+		settings: settings,
+		attempts: make(map[string]*loginAttempt),
+	}
+}
+
+// attemptKey normalizes an email so that case and surrounding spaces
+// do not produce separate counters.
+func attemptKey(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
+
+// enabled reports whether failed logins are counted at all.
+func (l *loginAttempts) enabled() bool {
+	return l.settings.MaxFailures > 0 && l.settings.Window > 0
+}
+
+// isLocked reports whether logging in with the email is currently blocked.
+//
+// Parameters:
+//   - email: Email used for the login
+//
+// Returns:
+//   - bool: true if the email is locked out
+func (l *loginAttempts) isLocked(email string) bool {
+	if !l.enabled() {
+		return false
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	key := attemptKey(email)
+	attempt, ok := l.attempts[key]
+	if ok && l.expired(attempt, now) {
+		delete(l.attempts, key)
+		return false
+	}
+
+	return ok && now.Before(attempt.lockedUntil)
+}
+
+// expired reports whether an attempt no longer counts: its window has passed
+// and it does not hold a lock. The caller must hold mu.
+func (l *loginAttempts) expired(attempt *loginAttempt, now time.Time) bool {
+	return now.Sub(attempt.firstFailure) >= l.settings.Window && !now.Before(attempt.lockedUntil)
+}
+
+// prune drops expired attempts so that emails which are never used again do
+// not stay in memory. It scans the map at most once per window. The caller
+// must hold mu.
+//
+// Parameters:
+//   - now: Current time
+func (l *loginAttempts) prune(now time.Time) {
+	if now.Sub(l.lastPrune) < l.settings.Window {
+		return
+	}
+	l.lastPrune = now
+
+	for key, attempt := range l.attempts {
+		if l.expired(attempt, now) {
+			delete(l.attempts, key)
+		}
+	}
+}
+
+// fail records a failed login and locks the email once the threshold is
+// reached within the window.
+//
+// Parameters:
+//   - email: Email used for the login
+//
+// Returns:
+//   - bool: true if this failure locked the email
+func (l *loginAttempts) fail(email string) bool {
+	if !l.enabled() {
+		return false
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	l.prune(now)
+
+	key := attemptKey(email)
+	attempt, ok := l.attempts[key]
+	if !ok || now.Sub(attempt.firstFailure) >= l.settings.Window {
+		attempt = &loginAttempt{firstFailure: now}
+		l.attempts[key] = attempt
+	}
+
+	attempt.failures++
+	if attempt.failures >= l.settings.MaxFailures {
+		attempt.lockedUntil = now.Add(l.settings.Window)
+		return true
+	}
+
+	return false
+}
+
+// reset forgets the failed logins of the email after a successful login.
+//
+// Parameters:
+//   - email: Email used for the login
+func (l *loginAttempts) reset(email string) {
+	if !l.enabled() {
+		return
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	delete(l.attempts, attemptKey(email))
+}
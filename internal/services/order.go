@@ -4,14 +4,21 @@
 package interfaces
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"github.com/charmbracelet/log"
 	"github.com/google/uuid"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
 	"teamdev/internal/models"
 	"teamdev/internal/repository/repository_errors"
 	"teamdev/internal/repository/repository_interfaces"
+	"teamdev/internal/services/service_errors"
 	"teamdev/internal/services/service_interfaces"
+	"teamdev/notifier"
 	"time"
 )
 
@@ -24,6 +31,13 @@ type OrderService struct {
 	WorkerRepository repository_interfaces.IWorkerRepository // Data access for workers
 	UserRepository   repository_interfaces.IUserRepository   // Data access for users
 	logger           *log.Logger                             // Logger for service operations
+	maxActiveOrders  int                                     // Maximum number of active orders per master (0 means unlimited)
+	tax              models.TaxSettings                      // How taxes are presented on receipts
+	rounding         models.RoundingMode                     // How prices are rounded to whole cents
+	deadlines        models.DeadlineSettings                 // Bounds for the deadline of new orders
+	serviceArea      []string                                // Lowercased city names or postal code prefixes that are served (empty means everywhere)
+	notifier         notifier.Notifier                       // Delivers alerts to workers (nil disables them)
+	orderNotifiers   []notifier.OrderNotifier                // Hooks invoked after status changes and assignments
 }
 
 // NewOrderService creates a new OrderService with the required repository dependencies.
@@ -34,19 +48,80 @@ type OrderService struct {
 //   - taskRepository: Repository for task data access
 //   - userRepository: Repository for user data access
 //   - logger: Logger for recording service operations
+//   - maxActiveOrders: Maximum number of active orders a master may hold (0 means unlimited)
+//   - tax: Tax rate and presentation mode used for receipts
+//   - rounding: Rounding applied to totals and receipt amounts
+//   - deadlines: Minimum lead time and maximum horizon of order deadlines
+//   - serviceArea: City names or postal code prefixes of the served region, empty means everywhere
+//   - notifier: Delivers alerts to workers, nil disables notifications
+//   - orderNotifiers: Optional hooks invoked after status changes and assignments, nil entries are skipped
 //
 // Returns:
 //   - service_interfaces.IOrderService: Fully initialized order service
-func NewOrderService(orderRepository repository_interfaces.IOrderRepository, workerRepository repository_interfaces.IWorkerRepository, taskRepository repository_interfaces.ITaskRepository, userRepository repository_interfaces.IUserRepository, logger *log.Logger) service_interfaces.IOrderService {
+func NewOrderService(orderRepository repository_interfaces.IOrderRepository, workerRepository repository_interfaces.IWorkerRepository, taskRepository repository_interfaces.ITaskRepository, userRepository repository_interfaces.IUserRepository, logger *log.Logger, maxActiveOrders int, tax models.TaxSettings, rounding models.RoundingMode, deadlines models.DeadlineSettings, serviceArea []string, notifier notifier.Notifier, orderNotifiers ...notifier.OrderNotifier) service_interfaces.IOrderService {
 	return &OrderService{
 		OrderRepository:  orderRepository,
 		TaskRepository:   taskRepository,
 		WorkerRepository: workerRepository,
 		UserRepository:   userRepository,
 		logger:           logger,
+		maxActiveOrders:  maxActiveOrders,
+		tax:              tax,
+		rounding:         rounding,
+		deadlines:        deadlines,
+		serviceArea:      normalizeServiceArea(serviceArea),
+		notifier:         notifier,
+		orderNotifiers:   orderNotifiers,
 	}
 }
 
+// normalizeServiceArea trims and lowercases the served prefixes and drops empty ones.
+//
+// Parameters:
+//   - serviceArea: City names or postal code prefixes as configured
+//
+// Returns:
+//   - []string: Prefixes ready to be matched against address parts
+func normalizeServiceArea(serviceArea []string) []string {
+	var prefixes []string
+	for _, prefix := range serviceArea {
+		prefix = strings.ToLower(strings.TrimSpace(prefix))
+		if prefix != "" {
+			prefixes = append(prefixes, prefix)
+		}
+	}
+
+	return prefixes
+}
+
+// validServiceArea checks whether an address lies in the served region. The
+// address is split into comma-separated parts, and it is served if any part,
+// ignoring case and a leading "г." city marker, starts with a configured city
+// name or postal code prefix. Every address is served when no area is configured.
+//
+// Parameters:
+//   - address: The order address to check
+//
+// Returns:
+//   - bool: True if the address is served, false otherwise
+func (o OrderService) validServiceArea(address string) bool {
+	if len(o.serviceArea) == 0 {
+		return true
+	}
+
+	for _, part := range strings.Split(strings.ToLower(address), ",") {
+		part = strings.TrimSpace(part)
+		part = strings.TrimSpace(strings.TrimPrefix(part, "г."))
+		for _, prefix := range o.serviceArea {
+			if strings.HasPrefix(part, prefix) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
 // orderIsCompleted determines if an order's status indicates it is no longer active.
 //
 // Parameters:
@@ -58,36 +133,154 @@ func orderIsCompleted(orderStatus int) bool {
 	return orderStatus == models.CompletedOrderStatus || orderStatus == models.CancelledOrderStatus
 }
 
+// stampTransitions sets the assignment, completion and cancellation timestamps
+// of an order when the corresponding transition happens.
+//
+// Parameters:
+//   - order: Order with already applied new status and worker
+//   - previousStatus: Status of the order before the update
+//   - previousWorkerID: Worker assigned to the order before the update
+//   - now: Moment of the transition
+func stampTransitions(order *models.Order, previousStatus int, previousWorkerID uuid.UUID, now time.Time) {
+	if order.WorkerID != uuid.Nil && order.WorkerID != previousWorkerID {
+		order.AssignedAt = &now
+	}
+
+	if order.Status != previousStatus {
+		switch order.Status {
+		case models.CompletedOrderStatus:
+			order.CompletedAt = &now
+		case models.CancelledOrderStatus:
+			order.CancelledAt = &now
+		}
+	}
+}
+
+// tasksWithoutQuantity lists the names of the tasks attached to an order
+// whose quantity is less than one.
+//
+// Parameters:
+//   - orderID: UUID of the order to check
+//
+// Returns:
+//   - []string: Names of the offending tasks, empty if all quantities are positive
+//   - error: Any retrieval errors
+func (o OrderService) tasksWithoutQuantity(orderID uuid.UUID) ([]string, error) {
+	tasks, err := o.OrderRepository.GetTasksInOrder(orderID)
+	if err != nil {
+		return nil, err
+	}
+
+	var emptyTasks []string
+	for _, task := range tasks {
+		quantity, err := o.OrderRepository.GetTaskQuantity(orderID, task.ID)
+		if err != nil {
+			return nil, err
+		}
+		if quantity < 1 {
+			emptyTasks = append(emptyTasks, task.Name)
+		}
+	}
+
+	return emptyTasks, nil
+}
+
 // checkTasksExistence verifies that all tasks in a list exist in the system
-// and have valid quantities.
+// and have valid quantities. The tasks are fetched with a single query.
 //
 // Parameters:
 //   - tasks: Slice of ordered tasks to validate
 //
 // Returns:
-//   - bool: true if all tasks exist and have valid quantities
-//   - error: Error describing any validation failures
-func (o OrderService) checkTasksExistence(tasks []models.OrderedTask) (bool, error) {
+//   - map[uuid.UUID]models.Task: Stored tasks keyed by ID, used to price the order
+//   - error: Error describing any validation failures, naming the missing task if one does not exist
+func (o OrderService) checkTasksExistence(tasks []models.OrderedTask) (map[uuid.UUID]models.Task, error) {
+	ids := make([]uuid.UUID, 0, len(tasks))
 	for _, task := range tasks {
 		if task.Quantity <= 0 {
 			o.logger.Error("SERVICE: Quantity is negative", "task", task)
-			return false, fmt.Errorf("SERVICE: Quantity is negative")
+			return nil, service_errors.NegativeQuantity
 		}
+		ids = append(ids, task.Task.ID)
+	}
+	if len(ids) == 0 {
+		return map[uuid.UUID]models.Task{}, nil
+	}
 
-		_, err := o.TaskRepository.GetTaskByID(task.Task.ID)
-		if errors.Is(err, repository_errors.DoesNotExist) {
-			o.logger.Error("SERVICE: Task does not exist", "id", task.Task.ID)
-			return false, fmt.Errorf("SERVICE: Task does not exist")
-		} else if err != nil {
-			o.logger.Error("SERVICE: GetTaskByID method failed", "id", task.Task.ID, "error", err)
-			return false, err
+	existing, err := o.TaskRepository.GetTasksByIDs(ids)
+	if err != nil {
+		o.logger.Error("SERVICE: GetTasksByIDs method failed", "ids", ids, "error", err)
+		return nil, err
+	}
+
+	for _, id := range ids {
+		if _, ok := existing[id]; !ok {
+			o.logger.Error("SERVICE: Task does not exist", "id", id)
+			return nil, fmt.Errorf("%w: task %s does not exist", service_errors.InvalidReference, id)
 		}
 	}
 
-	return true, nil
+	return existing, nil
+}
+
+// priceTiers loads the volume tiers of several tasks in one query.
+//
+// Parameters:
+//   - taskIDs: Slice of task UUIDs
+//
+// Returns:
+//   - map[uuid.UUID][]models.PriceTier: Price tiers keyed by task ID
+//   - error: Any retrieval errors
+func (o OrderService) priceTiers(taskIDs []uuid.UUID) (map[uuid.UUID][]models.PriceTier, error) {
+	tiers, err := o.TaskRepository.GetPriceTiersByTaskIDs(taskIDs)
+	if err != nil {
+		o.logger.Error("SERVICE: GetPriceTiersByTaskIDs method failed", "task_ids", taskIDs, "error", err)
+		return nil, err
+	}
+	return tiers, nil
+}
+
+// pricedOrderedTasks prices a list of ordered tasks using the stored task prices
+// and the volume tiers of the tasks. Task details and prices sent along with the
+// ordered tasks are ignored, so callers cannot choose what they pay.
+//
+// Parameters:
+//   - tasks: Slice of ordered tasks with their quantities
+//   - stored: Stored tasks keyed by ID, as returned by checkTasksExistence
+//
+// Returns:
+//   - []models.OrderedTask: Stored tasks with their quantities, priced at the unit
+//     price stored with the order lines
+//   - float64: Sum of the unit price multiplied by quantity for every task
+//   - error: Any retrieval errors
+func (o OrderService) pricedOrderedTasks(tasks []models.OrderedTask, stored map[uuid.UUID]models.Task) ([]models.OrderedTask, float64, error) {
+	ids := make([]uuid.UUID, 0, len(tasks))
+	for _, task := range tasks {
+		ids = append(ids, task.Task.ID)
+	}
+
+	tiers, err := o.priceTiers(ids)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	priced := make([]models.OrderedTask, 0, len(tasks))
+	var sum float64
+	for _, task := range tasks {
+		storedTask := stored[task.Task.ID]
+		storedTask.PricePerSingle = models.TieredUnitPrice(storedTask.PricePerSingle, tiers[task.Task.ID], task.Quantity)
+		priced = append(priced, models.OrderedTask{Task: &storedTask, Quantity: task.Quantity})
+		sum += storedTask.PricePerSingle * float64(task.Quantity)
+	}
+	return priced, sum, nil
 }
 
 // CreateOrder creates a new cleaning service order with the specified tasks and details.
+// The total price of the ordered tasks is stored on the order as a quote, so the agreed
+// price does not change if task prices are updated later. The customer's draft
+// order, if any, is cleared once the order is placed. The created order is returned
+// together with the persisted tasks, as stored in the catalog and priced at the
+// unit prices of the order, so callers do not need to fetch it again.
 //
 // Parameters:
 //   - userID: UUID of the customer creating the order
@@ -96,78 +289,333 @@ func (o OrderService) checkTasksExistence(tasks []models.OrderedTask) (bool, err
 //   - orderedTasks: Slice of tasks and their quantities to include in the order
 //
 // Returns:
+//   - *models.OrderWithTasks: Created order with assigned ID and its ordered tasks
+//   - error: service_errors.InvalidAddressOrder if the address is empty or outside the
+//     service area, service_errors.EmptyTasksOrder if there are no tasks,
+//     service_errors.InvalidDeadlineOrder for a deadline out of bounds,
+//     service_errors.NegativeQuantity if a task quantity is not positive,
+//     service_errors.InvalidReference if a task or the user does not exist,
+//     any other persistence errors
+func (o OrderService) CreateOrder(userID uuid.UUID, address string, deadline time.Time, orderedTasks []models.OrderedTask) (*models.OrderWithTasks, error) {
+	order, tasks, err := o.placeOrder(userID, address, deadline, orderedTasks)
+	if err != nil {
+		return nil, err
+	}
+
+	err = o.OrderRepository.DeleteDraft(userID)
+	if err != nil {
+		o.logger.Error("SERVICE: DeleteDraft method failed", "user_id", userID, "error", err)
+	}
+
+	return &models.OrderWithTasks{
+		Order: *order,
+		Tasks: tasks,
+	}, nil
+}
+
+// placeOrder validates and stores a new order with the New status, quoting the
+// total of the ordered tasks, and notifies managers about it.
+//
+// Parameters:
+//   - userID: UUID of the customer placing the order
+//   - address: Location where the cleaning service should be performed
+//   - deadline: When the order should be completed
+//   - orderedTasks: Slice of tasks and their quantities to include in the order
+//
+// Returns:
 //   - *models.Order: Created order with assigned ID
+//   - []models.OrderedTask: Stored tasks of the order with their quantities and unit prices
 //   - error: Any validation or persistence errors
-func (o OrderService) CreateOrder(userID uuid.UUID, address string, deadline time.Time, orderedTasks []models.OrderedTask) (*models.Order, error) {
+func (o OrderService) placeOrder(userID uuid.UUID, address string, deadline time.Time, orderedTasks []models.OrderedTask) (*models.Order, []models.OrderedTask, error) {
 	// checking if order is valid
-	if !validAddress(address) || !validDeadline(deadline) || !validTasksNumber(orderedTasks) {
-		o.logger.Error("SERVICE: Invalid input")
-		return nil, fmt.Errorf("SERVICE: Invalid input")
+	if !validAddress(address) {
+		o.logger.Error("SERVICE: Invalid address", "address", address)
+		return nil, nil, service_errors.InvalidAddressOrder
+	} else if !validTasksNumber(orderedTasks) {
+		o.logger.Error("SERVICE: Order has no tasks")
+		return nil, nil, service_errors.EmptyTasksOrder
+	}
+
+	if err := validDeadline(deadline, time.Now(), o.deadlines); err != nil {
+		o.logger.Error("SERVICE: Invalid deadline", "deadline", deadline, "error", err)
+		return nil, nil, err
+	}
+
+	if !o.validServiceArea(address) {
+		o.logger.Error("SERVICE: Address is outside the service area", "address", address)
+		return nil, nil, service_errors.InvalidAddressOrder
 	}
 
-	if _, err := o.checkTasksExistence(orderedTasks); err != nil {
+	storedTasks, err := o.checkTasksExistence(orderedTasks)
+	if err != nil {
 		o.logger.Error("SERVICE: CheckTasksExistence method failed", "orderedTasks", orderedTasks, "error", err)
-		return nil, err
+		return nil, nil, err
 	}
 
 	// checking if user exists
-	_, err := o.UserRepository.GetUserByID(userID)
+	_, err = o.UserRepository.GetUserByID(userID)
 	if errors.Is(err, repository_errors.DoesNotExist) {
 		o.logger.Error("SERVICE: User does not exist", "id", userID)
-		return nil, fmt.Errorf("SERVICE: User does not exist")
+		return nil, nil, fmt.Errorf("%w: user %s does not exist", service_errors.InvalidReference, userID)
 	} else if err != nil {
 		o.logger.Error("SERVICE: GetWorkerByID method failed", "id", userID, "error", err)
-		return nil, err
+		return nil, nil, err
+	}
+
+	pricedTasks, quotedTotal, err := o.pricedOrderedTasks(orderedTasks, storedTasks)
+	if err != nil {
+		return nil, nil, err
 	}
 
 	// creating order
 	var order = &models.Order{
-		UserID:   userID,
-		Status:   models.NewOrderStatus,
-		Address:  address,
-		Deadline: deadline,
+		UserID:      userID,
+		Status:      models.NewOrderStatus,
+		Address:     address,
+		Deadline:    deadline,
+		QuotedTotal: o.rounding.Round(quotedTotal),
 	}
 
 	order, err = o.OrderRepository.Create(order, orderedTasks)
 	if err != nil {
 		o.logger.Error("SERVICE: Create method failed", "order", order, "error", err)
-		return nil, err
+		return nil, nil, err
 	}
 
+	o.notifyManagersAboutOrder(order)
+
 	o.logger.Info("SERVICE: Successfully created order", "order", order)
-	return order, nil
+	return order, pricedTasks, nil
 }
 
-// DeleteOrder removes an order and all associated tasks from the system.
+// CloneOrder places a new order for the customer of an existing order with the
+// same address and the same tasks and quantities. The status of the source order
+// does not matter. The new order starts with the New status and is quoted at
+// current task prices.
 //
 // Parameters:
-//   - id: UUID of the order to delete
+//   - sourceOrderID: UUID of the order to repeat
+//   - newDeadline: Deadline of the new order
 //
 // Returns:
-//   - error: Any validation or persistence errors
-func (o OrderService) DeleteOrder(id uuid.UUID) error {
-	order, err := o.OrderRepository.GetOrderByID(id)
+//   - *models.Order: Created order with a new ID
+//   - error: Validation error if the deadline is out of bounds or a task is no longer
+//     offered, retrieval or persistence error otherwise
+func (o OrderService) CloneOrder(sourceOrderID uuid.UUID, newDeadline time.Time) (*models.Order, error) {
+	source, err := o.OrderRepository.GetOrderByID(sourceOrderID)
 	if err != nil {
-		o.logger.Error("SERVICE: GetUserByID method failed", "id", id, "error", err)
-		return err
+		o.logger.Error("SERVICE: GetOrderByID method failed", "id", sourceOrderID, "error", err)
+		return nil, err
 	}
 
-	tasksFromOrder, err := o.OrderRepository.GetTasksInOrder(order.ID)
+	sourceTasks, err := o.OrderRepository.GetOrderedTasks(sourceOrderID)
 	if err != nil {
-		o.logger.Error("SERVICE: GetTasksInOrder method failed", "id", order.ID, "error", err)
-		return err
+		o.logger.Error("SERVICE: GetOrderedTasks method failed", "order_id", sourceOrderID, "error", err)
+		return nil, err
+	}
+
+	ids := make([]uuid.UUID, 0, len(sourceTasks))
+	for _, task := range sourceTasks {
+		ids = append(ids, task.Task.ID)
+	}
+
+	// ordered tasks carry the prices of the source order, the clone is quoted at current ones
+	current, err := o.TaskRepository.GetTasksByIDs(ids)
+	if err != nil {
+		o.logger.Error("SERVICE: GetTasksByIDs method failed", "ids", ids, "error", err)
+		return nil, err
+	}
+
+	orderedTasks := make([]models.OrderedTask, 0, len(sourceTasks))
+	for _, sourceTask := range sourceTasks {
+		task, ok := current[sourceTask.Task.ID]
+		if !ok || task.Archived {
+			o.logger.Error("SERVICE: Task is no longer offered", "id", sourceTask.Task.ID)
+			return nil, fmt.Errorf("SERVICE: Task %s is no longer offered", sourceTask.Task.ID)
+		}
+		orderedTasks = append(orderedTasks, models.OrderedTask{Task: &task, Quantity: sourceTask.Quantity})
+	}
+
+	order, _, err := o.placeOrder(source.UserID, source.Address, newDeadline, orderedTasks)
+	if err != nil {
+		return nil, err
+	}
+
+	o.logger.Info("SERVICE: Successfully cloned order", "source_id", sourceOrderID, "id", order.ID)
+	return order, nil
+}
+
+// notifyManagersAboutOrder alerts every manager who has not opted out of
+// notifications that a new order is waiting for assignment. Managers are looked
+// up and messages are delivered in the background, so neither a slow or failed
+// manager lookup nor a failed delivery affects the caller.
+//
+// Parameters:
+//   - order: Newly created order
+func (o OrderService) notifyManagersAboutOrder(order *models.Order) {
+	if o.notifier == nil || order.WorkerID != uuid.Nil {
+		return
+	}
+
+	go func() {
+		managers, err := o.WorkerRepository.GetWorkersByRole(models.ManagerRole, uuid.Nil)
+		if err != nil {
+			o.logger.Error("SERVICE: GetWorkersByRole method failed", "role", models.ManagerRole, "error", err)
+			return
+		}
+
+		message := fmt.Sprintf("Новый заказ %s по адресу %s ожидает назначения мастера", order.ID, order.Address)
+		for _, manager := range managers {
+			if manager.NotificationsOptOut {
+				continue
+			}
+			if err := o.notifier.NotifyWorker(manager, message); err != nil {
+				o.logger.Error("SERVICE: NotifyWorker method failed", "id", manager.ID, "order", order.ID, "error", err)
+			}
+		}
+	}()
+}
+
+// buildDispatchInfo collects the details a master needs to carry out the order.
+//
+// Parameters:
+//   - order: Order to describe
+//
+// Returns:
+//   - *models.DispatchInfo: Address, deadline and tasks of the order
+//   - error: Any retrieval errors
+func (o OrderService) buildDispatchInfo(order *models.Order) (*models.DispatchInfo, error) {
+	tasks, err := o.OrderRepository.GetTasksInOrder(order.ID)
+	if err != nil {
+		o.logger.Error("SERVICE: GetTasksInOrder method failed", "order_id", order.ID, "error", err)
+		return nil, err
 	}
 
-	for _, task := range tasksFromOrder {
-		err = o.OrderRepository.RemoveTaskFromOrder(order.ID, task.ID)
+	info := &models.DispatchInfo{
+		OrderID:  order.ID,
+		Address:  order.Address,
+		Deadline: order.Deadline,
+		Tasks:    make([]models.OrderedTask, 0, len(tasks)),
+	}
+	for i := range tasks {
+		quantity, err := o.OrderRepository.GetTaskQuantity(order.ID, tasks[i].ID)
 		if err != nil {
-			o.logger.Error("SERVICE: Delete method failed", "id", task.ID, "error", err)
-			return err
+			o.logger.Error("SERVICE: GetTaskQuantity method failed", "order_id", order.ID, "task_id", tasks[i].ID, "error", err)
+			return nil, err
+		}
+		info.Tasks = append(info.Tasks, models.OrderedTask{Task: &tasks[i], Quantity: quantity})
+	}
+
+	return info, nil
+}
+
+// notifyWorkerAboutAssignment sends the assigned master the details of the order,
+// unless the master has opted out of notifications. The details are collected
+// before returning, while the delivery happens in the background, so failures
+// are only logged and do not affect the caller.
+//
+// Parameters:
+//   - order: Order that has just been assigned
+//   - worker: Assigned worker
+func (o OrderService) notifyWorkerAboutAssignment(order *models.Order, worker models.Worker) {
+	if o.notifier == nil {
+		return
+	}
+
+	if worker.NotificationsOptOut {
+		o.logger.Info("SERVICE: Worker opted out of notifications", "id", worker.ID, "order", order.ID)
+		return
+	}
+
+	info, err := o.buildDispatchInfo(order)
+	if err != nil {
+		return
+	}
+
+	go func() {
+		if err := o.notifier.NotifyAssignment(worker, *info); err != nil {
+			o.logger.Error("SERVICE: NotifyAssignment method failed", "id", worker.ID, "order", order.ID, "error", err)
+		}
+	}()
+}
+
+// notifyOrderCompleted publishes the order.completed event with the grand total
+// of the order, so external accounting systems learn the final amount. The
+// receipt is built before returning, while the delivery happens in the
+// background, so failures are only logged and do not affect the caller. If the
+// receipt cannot be built, the event carries the quoted total of the order instead.
+//
+// Parameters:
+//   - order: Order that has just been completed
+func (o OrderService) notifyOrderCompleted(order *models.Order) {
+	if o.notifier == nil {
+		return
+	}
+
+	event := models.OrderEvent{
+		Type:       models.OrderCompletedEvent,
+		OrderID:    order.ID,
+		GrandTotal: order.QuotedTotal,
+		OccurredAt: time.Now(),
+	}
+
+	receipt, err := o.BuildReceipt(order.ID)
+	if err != nil {
+		o.logger.Error("SERVICE: BuildReceipt method failed, publishing the quoted total", "order", order.ID, "error", err)
+	} else {
+		event.GrandTotal = receipt.GrandTotal
+	}
+	if order.CompletedAt != nil {
+		event.OccurredAt = *order.CompletedAt
+	}
+
+	go func() {
+		if err := o.notifier.NotifyOrderEvent(event); err != nil {
+			o.logger.Error("SERVICE: NotifyOrderEvent method failed", "order", order.ID, "type", event.Type, "error", err)
+		}
+	}()
+}
+
+// notifyStatusChanged invokes the order notifiers after a stored status change.
+//
+// Parameters:
+//   - order: Order whose status has changed
+//   - oldStatus: Status of the order before the change
+//   - newStatus: Status of the order after the change
+func (o OrderService) notifyStatusChanged(order *models.Order, oldStatus int, newStatus int) {
+	for _, orderNotifier := range o.orderNotifiers {
+		if orderNotifier != nil {
+			orderNotifier.OrderStatusChanged(order, oldStatus, newStatus)
+		}
+	}
+}
+
+// notifyAssigned invokes the order notifiers after a worker was assigned to an order.
+//
+// Parameters:
+//   - order: Order that has just been assigned
+//   - worker: Assigned worker
+func (o OrderService) notifyAssigned(order *models.Order, worker *models.Worker) {
+	for _, orderNotifier := range o.orderNotifiers {
+		if orderNotifier != nil {
+			orderNotifier.OrderAssigned(order, worker)
 		}
 	}
-	o.logger.Info("SERVICE: Successfully deleted tasks from order", "order_id", order.ID)
+}
 
-	err = o.OrderRepository.Delete(id)
+// DeleteOrder removes an order from the system. The order is archived rather
+// than erased, so its tasks are kept and it can be restored with RestoreOrder.
+// The repository marks the order in a single statement, so the existence check
+// and the deletion cannot be separated by a concurrent change.
+//
+// Parameters:
+//   - id: UUID of the order to delete
+//
+// Returns:
+//   - error: repository_errors.DoesNotExist if the order does not exist or is already
+//     deleted, any other persistence errors
+func (o OrderService) DeleteOrder(id uuid.UUID) error {
+	err := o.OrderRepository.Delete(id)
 	if err != nil {
 		o.logger.Error("SERVICE: Delete method failed", "id", id, "error", err)
 		return err
@@ -177,6 +625,24 @@ func (o OrderService) DeleteOrder(id uuid.UUID) error {
 	return nil
 }
 
+// RestoreOrder brings back a deleted order together with its tasks.
+//
+// Parameters:
+//   - id: UUID of the deleted order
+//
+// Returns:
+//   - error: Repository error if no deleted order is found or the update fails
+func (o OrderService) RestoreOrder(id uuid.UUID) error {
+	err := o.OrderRepository.Restore(id)
+	if err != nil {
+		o.logger.Error("SERVICE: Restore method failed", "id", id, "error", err)
+		return err
+	}
+
+	o.logger.Info("SERVICE: Successfully restored order", "id", id)
+	return nil
+}
+
 // GetTasksInOrder retrieves all tasks associated with a specific order.
 //
 // Parameters:
@@ -202,7 +668,9 @@ func (o OrderService) GetTasksInOrder(orderID uuid.UUID) ([]models.Task, error)
 	return tasks, nil
 }
 
-// GetCurrentOrderByUserID retrieves the most recent order for a specific user.
+// GetCurrentOrderByUserID retrieves the current order of a specific user. The
+// most recent new or in-progress order is preferred over newer closed orders;
+// if none are active, the most recent order of any status is returned.
 //
 // Parameters:
 //   - userID: UUID of the user to retrieve the current order for
@@ -252,60 +720,348 @@ func (o OrderService) GetAllOrdersByUserID(userID uuid.UUID) ([]models.Order, er
 	return orders, nil
 }
 
-// Filter retrieves orders matching the specified criteria.
+// userOrdersExport is the JSON document produced by ExportUserOrders.
+type userOrdersExport struct {
+	UserID uuid.UUID     `json:"user_id"` // User the orders belonged to
+	Orders []orderExport `json:"orders"`  // Exported orders
+}
+
+// orderExport is an exported order with its tasks.
+type orderExport struct {
+	ID                 uuid.UUID         `json:"id"`
+	WorkerID           uuid.UUID         `json:"worker_id"`
+	Status             int               `json:"status"`
+	Address            string            `json:"address"`
+	CreationDate       time.Time         `json:"creation_date"`
+	Deadline           time.Time         `json:"deadline"`
+	Rate               int               `json:"rate"`
+	QuotedTotal        float64           `json:"quoted_total"`
+	AssignedAt         *time.Time        `json:"assigned_at,omitempty"`
+	CompletedAt        *time.Time        `json:"completed_at,omitempty"`
+	CancelledAt        *time.Time        `json:"cancelled_at,omitempty"`
+	Tasks              []orderTaskExport `json:"tasks"`
+	CancellationReason string            `json:"cancellation_reason,omitempty"`
+}
+
+// orderTaskExport is an exported task of an order with its quantity.
+type orderTaskExport struct {
+	TaskID   uuid.UUID `json:"task_id"`
+	Name     string    `json:"name"`
+	Quantity int       `json:"quantity"`
+	Price    float64   `json:"price,omitempty"`
+}
+
+// ExportUserOrders produces a JSON snapshot of all orders of a user, except
+// deleted ones, with their statuses, ratings, workers, timestamps, tasks, unit
+// prices and quantities.
 //
 // Parameters:
-//   - params: Map of field names to filter values
+//   - userID: UUID of the user whose orders are exported
 //
 // Returns:
-//   - []models.Order: Slice of order entities matching the filter criteria
-//   - error: Any filtering or retrieval errors
-func (o OrderService) Filter(params map[string]string) ([]models.Order, error) {
-	orders, err := o.OrderRepository.Filter(params)
+//   - []byte: JSON document with the user's orders
+//   - error: Any retrieval errors
+func (o OrderService) ExportUserOrders(userID uuid.UUID) ([]byte, error) {
+	_, err := o.UserRepository.GetUserByID(userID)
 	if err != nil {
-		o.logger.Error("SERVICE: Filter method failed", "params", params, "error", err)
+		o.logger.Error("SERVICE: GetUserByID method failed", "id", userID, "error", err)
 		return nil, err
 	}
 
-	o.logger.Info("SERVICE: Successfully filtered orders", "params", params)
-	return orders, nil
-}
-
-// Update modifies an existing order record with updated status, rating and worker assignment.
-//
-// Parameters:
-//   - orderID: UUID of the order to update
-//   - status: New status code for the order
-//   - rate: Customer satisfaction rating (0-5)
-//   - workerID: UUID of the worker to assign to the order (uuid.Nil to unassign)
-//
-// Returns:
-//   - *models.Order: Updated order after the operation
-//   - error: Any validation or persistence errors
-func (o OrderService) Update(orderID uuid.UUID, status int, rate int, workerID uuid.UUID) (*models.Order, error) {
-	order, err := o.OrderRepository.GetOrderByID(orderID)
+	orders, err := o.OrderRepository.GetAllOrdersByUserID(userID)
 	if err != nil {
-		o.logger.Error("SERVICE: GetOrderByID method failed", "id", orderID, "error", err)
+		o.logger.Error("SERVICE: GetAllOrdersByUserID method failed", "id", userID, "error", err)
 		return nil, err
 	}
 
-	if workerID != uuid.Nil {
-		_, err = o.WorkerRepository.GetWorkerByID(workerID)
+	export := userOrdersExport{UserID: userID, Orders: make([]orderExport, 0, len(orders))}
+	for _, order := range orders {
+		orderedTasks, err := o.OrderRepository.GetOrderedTasks(order.ID)
 		if err != nil {
-			o.logger.Error("SERVICE: GetWorkerByID method failed", "id", workerID, "error", err)
+			o.logger.Error("SERVICE: GetOrderedTasks method failed", "order_id", order.ID, "error", err)
 			return nil, err
 		}
 
-		order.WorkerID = workerID
-	} else {
-		order.WorkerID = uuid.Nil
+		exported := orderExport{
+			ID:                 order.ID,
+			WorkerID:           order.WorkerID,
+			Status:             order.Status,
+			Address:            order.Address,
+			CreationDate:       order.CreationDate,
+			Deadline:           order.Deadline,
+			Rate:               order.Rate,
+			QuotedTotal:        order.QuotedTotal,
+			AssignedAt:         order.AssignedAt,
+			CompletedAt:        order.CompletedAt,
+			CancelledAt:        order.CancelledAt,
+			Tasks:              make([]orderTaskExport, 0, len(orderedTasks)),
+			CancellationReason: order.CancellationReason,
+		}
+		for _, orderedTask := range orderedTasks {
+			exported.Tasks = append(exported.Tasks, orderTaskExport{
+				TaskID:   orderedTask.Task.ID,
+				Name:     orderedTask.Task.Name,
+				Quantity: orderedTask.Quantity,
+				Price:    orderedTask.Task.PricePerSingle,
+			})
+		}
+		export.Orders = append(export.Orders, exported)
 	}
 
-	if !validStatus(status) {
-		o.logger.Error("SERVICE: Invalid status", "status", status)
-		return nil, fmt.Errorf("SERVICE: Invalid status")
-	} else {
-		order.Status = status
+	data, err := json.Marshal(export)
+	if err != nil {
+		o.logger.Error("SERVICE: Encoding orders failed", "user_id", userID, "error", err)
+		return nil, err
+	}
+
+	o.logger.Info("SERVICE: Successfully exported user orders", "user_id", userID, "orders", len(export.Orders))
+	return data, nil
+}
+
+// ImportUserOrders recreates the orders of a snapshot produced by ExportUserOrders
+// for a user. Every order is validated and its tasks and worker must exist before
+// anything is written; the orders are then inserted in one transaction with new
+// identifiers, keeping their statuses, ratings, quotes, timestamps and the unit
+// prices of their tasks. Tasks exported without a price are priced at current prices.
+//
+// Parameters:
+//   - userID: UUID of the user the orders are recreated for
+//   - data: JSON document produced by ExportUserOrders
+//
+// Returns:
+//   - int: Number of recreated orders
+//   - error: Validation or persistence errors
+func (o OrderService) ImportUserOrders(userID uuid.UUID, data []byte) (int, error) {
+	_, err := o.UserRepository.GetUserByID(userID)
+	if err != nil {
+		o.logger.Error("SERVICE: GetUserByID method failed", "id", userID, "error", err)
+		return 0, err
+	}
+
+	var export userOrdersExport
+	if err = json.Unmarshal(data, &export); err != nil {
+		o.logger.Error("SERVICE: Decoding orders failed", "user_id", userID, "error", err)
+		return 0, fmt.Errorf("SERVICE: Invalid input")
+	}
+
+	orders := make([]models.OrderWithTasks, 0, len(export.Orders))
+	for _, exported := range export.Orders {
+		orderedTasks := make([]models.OrderedTask, 0, len(exported.Tasks))
+		for _, task := range exported.Tasks {
+			orderedTasks = append(orderedTasks, models.OrderedTask{Task: &models.Task{ID: task.TaskID, Name: task.Name, PricePerSingle: task.Price}, Quantity: task.Quantity})
+		}
+
+		if !validAddress(exported.Address) || !validStatus(exported.Status) || !validRate(exported.Rate) ||
+			!validTasksNumber(orderedTasks) || (exported.Rate != 0 && exported.Status != models.CompletedOrderStatus) {
+			o.logger.Error("SERVICE: Invalid input", "order", exported.ID)
+			return 0, fmt.Errorf("SERVICE: Invalid input")
+		}
+
+		if _, err = o.checkTasksExistence(orderedTasks); err != nil {
+			return 0, err
+		}
+
+		if exported.WorkerID != uuid.Nil {
+			_, err = o.WorkerRepository.GetWorkerByID(exported.WorkerID)
+			if err != nil {
+				o.logger.Error("SERVICE: GetWorkerByID method failed", "id", exported.WorkerID, "error", err)
+				return 0, err
+			}
+		}
+
+		orders = append(orders, models.OrderWithTasks{
+			Order: models.Order{
+				WorkerID:           exported.WorkerID,
+				UserID:             userID,
+				Status:             exported.Status,
+				Address:            exported.Address,
+				CreationDate:       exported.CreationDate,
+				Deadline:           exported.Deadline,
+				Rate:               exported.Rate,
+				QuotedTotal:        exported.QuotedTotal,
+				AssignedAt:         exported.AssignedAt,
+				CompletedAt:        exported.CompletedAt,
+				CancelledAt:        exported.CancelledAt,
+				CancellationReason: exported.CancellationReason,
+			},
+			Tasks: orderedTasks,
+		})
+	}
+
+	err = o.OrderRepository.ImportOrders(orders)
+	if err != nil {
+		o.logger.Error("SERVICE: ImportOrders method failed", "user_id", userID, "error", err)
+		return 0, err
+	}
+
+	o.logger.Info("SERVICE: Successfully imported user orders", "user_id", userID, "orders", len(orders))
+	return len(orders), nil
+}
+
+// GetAllOrdersByWorkerID retrieves all orders assigned to a specific worker.
+//
+// Parameters:
+//   - workerID: UUID of the worker to retrieve orders for
+//
+// Returns:
+//   - []models.Order: Slice of order entities assigned to the specified worker
+//   - error: Any validation or retrieval errors
+func (o OrderService) GetAllOrdersByWorkerID(workerID uuid.UUID) ([]models.Order, error) {
+	_, err := o.WorkerRepository.GetWorkerByID(workerID)
+	if err != nil {
+		o.logger.Error("SERVICE: GetWorkerByID method failed", "id", workerID, "error", err)
+		return nil, err
+	}
+
+	orders, err := o.OrderRepository.GetAllOrdersByWorkerID(workerID)
+	if err != nil {
+		o.logger.Error("SERVICE: GetAllOrdersByWorkerID method failed", "id", workerID, "error", err)
+		return nil, err
+	}
+
+	o.logger.Info("SERVICE: Successfully got all orders by worker id", "worker_id", workerID)
+	return orders, nil
+}
+
+// GetOrdersByUserIDPaged retrieves one page of a user's orders, newest first,
+// together with the total number of the user's orders.
+//
+// Parameters:
+//   - userID: UUID of the user to retrieve orders for
+//   - limit: Maximum number of orders on the page, must be positive
+//   - offset: Number of orders to skip, must not be negative
+//
+// Returns:
+//   - []models.Order: Slice of order entities on the requested page
+//   - int: Total number of orders of the user
+//   - error: Any validation or retrieval errors
+func (o OrderService) GetOrdersByUserIDPaged(userID uuid.UUID, limit int, offset int) ([]models.Order, int, error) {
+	if limit <= 0 || offset < 0 {
+		o.logger.Error("SERVICE: Invalid input", "limit", limit, "offset", offset)
+		return nil, 0, fmt.Errorf("SERVICE: Invalid input")
+	}
+
+	orders, total, err := o.OrderRepository.GetOrdersByUserIDPaged(userID, limit, offset)
+	if err != nil {
+		o.logger.Error("SERVICE: GetOrdersByUserIDPaged method failed", "id", userID, "error", err)
+		return nil, 0, err
+	}
+
+	o.logger.Info("SERVICE: Successfully got page of orders by user id", "user_id", userID, "limit", limit, "offset", offset)
+	return orders, total, nil
+}
+
+// Filter retrieves orders matching the specified criteria.
+//
+// Parameters:
+//   - params: Map of field names to filter values
+//
+// Returns:
+//   - []models.Order: Slice of order entities matching the filter criteria
+//   - error: Any filtering or retrieval errors
+func (o OrderService) Filter(params map[string]string) ([]models.Order, error) {
+	orders, err := o.OrderRepository.Filter(params, 0, 0, false)
+	if err != nil {
+		o.logger.Error("SERVICE: Filter method failed", "params", params, "error", err)
+		return nil, err
+	}
+
+	o.logger.Info("SERVICE: Successfully filtered orders", "params", params)
+	return orders, nil
+}
+
+// Update modifies an existing order record with updated status, rating and worker assignment.
+// Assignment, completion and cancellation timestamps are set on the respective transitions.
+// Only a manager can change the assigned worker. A newly assigned worker must be
+// available at the order deadline, a newly assigned master must not exceed the
+// configured number of active orders, and an order can only be completed when every attached task has a positive quantity
+// and its total is positive. An order can only be in progress while a worker is assigned to it.
+// The status may only change along the allowed transitions: a new order may be
+// taken into work or cancelled, an order in progress may be completed, cancelled
+// or returned to new. Completed and cancelled orders can only be reopened with ReopenOrder.
+// When status, rate and worker all match the stored order, nothing is written and
+// the stored order is returned as is.
+//
+// Parameters:
+//   - editor: Worker performing the operation, nil for a customer
+//   - orderID: UUID of the order to update
+//   - status: New status code for the order
+//   - rate: Customer satisfaction rating (0-5)
+//   - workerID: UUID of the worker to assign to the order (uuid.Nil to unassign)
+//
+// Returns:
+//   - *models.Order: Updated order after the operation
+//   - error: service_errors.InvalidRole if a non-manager changes the worker,
+//     service_errors.WorkerUnavailable if the new worker is off at the deadline,
+//     any other validation or persistence errors
+func (o OrderService) Update(editor *models.Worker, orderID uuid.UUID, status int, rate int, workerID uuid.UUID) (*models.Order, error) {
+	order, err := o.OrderRepository.GetOrderByID(orderID)
+	if err != nil {
+		o.logger.Error("SERVICE: GetOrderByID method failed", "id", orderID, "error", err)
+		return nil, err
+	}
+
+	if order.Status == status && order.Rate == rate && order.WorkerID == workerID {
+		o.logger.Info("SERVICE: Order is unchanged, skipping update", "order_id", orderID)
+		return order, nil
+	}
+
+	previousStatus := order.Status
+	previousWorkerID := order.WorkerID
+
+	if workerID != previousWorkerID && !isManager(editor) {
+		o.logger.Error("SERVICE: Only a manager can change the assigned worker", "order_id", orderID)
+		return nil, service_errors.InvalidRole
+	}
+
+	var worker *models.Worker
+	if workerID != uuid.Nil {
+		worker, err = o.WorkerRepository.GetWorkerByID(workerID)
+		if err != nil {
+			o.logger.Error("SERVICE: GetWorkerByID method failed", "id", workerID, "error", err)
+			return nil, err
+		}
+
+		if workerID != previousWorkerID {
+			available, err := o.WorkerRepository.IsAvailable(workerID, order.Deadline)
+			if err != nil {
+				o.logger.Error("SERVICE: IsAvailable method failed", "id", workerID, "error", err)
+				return nil, err
+			} else if !available {
+				o.logger.Error("SERVICE: Worker is unavailable at the order deadline", "worker_id", workerID, "deadline", order.Deadline)
+				return nil, service_errors.WorkerUnavailable
+			}
+		}
+
+		if workerID != previousWorkerID && worker.Role == models.MasterRole {
+			hasCapacity, capacityErr := workerHasCapacity(o.WorkerRepository, workerID, o.maxActiveOrders)
+			if capacityErr != nil {
+				o.logger.Error("SERVICE: GetActiveOrdersCount method failed", "id", workerID, "error", capacityErr)
+				return nil, capacityErr
+			} else if !hasCapacity {
+				o.logger.Error("SERVICE: Worker has reached the maximum number of active orders", "id", workerID)
+				return nil, service_errors.WorkerAtCapacity
+			}
+		}
+
+		order.WorkerID = workerID
+	} else {
+		order.WorkerID = uuid.Nil
+	}
+
+	if !validStatus(status) {
+		o.logger.Error("SERVICE: Invalid status", "status", status)
+		return nil, fmt.Errorf("SERVICE: Invalid status")
+	} else if !validStatusTransition(previousStatus, status) {
+		o.logger.Error("SERVICE: Status transition is not allowed", "order_id", orderID, "from", previousStatus, "to", status)
+		return nil, service_errors.InvalidOrderStatus
+	} else {
+		order.Status = status
+	}
+
+	if status == models.InProgressOrderStatus && order.WorkerID == uuid.Nil {
+		o.logger.Error("SERVICE: Order cannot be in progress without a worker", "order_id", orderID)
+		return nil, service_errors.InProgressWithoutWorker
 	}
 
 	if !orderIsCompleted(status) && rate != 0 {
@@ -320,294 +1076,1485 @@ func (o OrderService) Update(orderID uuid.UUID, status int, rate int, workerID u
 		order.Rate = rate
 	}
 
-	order, err = o.OrderRepository.Update(order)
-	if err != nil {
-		o.logger.Error("SERVICE: Update method failed", "order", order, "error", err)
-		return nil, err
+	if status == models.CompletedOrderStatus && previousStatus != models.CompletedOrderStatus {
+		emptyTasks, err := o.tasksWithoutQuantity(orderID)
+		if err != nil {
+			o.logger.Error("SERVICE: Checking task quantities failed", "order_id", orderID, "error", err)
+			return nil, err
+		} else if len(emptyTasks) > 0 {
+			o.logger.Error("SERVICE: Order has tasks without quantity", "order_id", orderID, "tasks", emptyTasks)
+			return nil, fmt.Errorf("%w: %s", service_errors.TasksWithoutQuantity, strings.Join(emptyTasks, ", "))
+		}
+
+		total, err := o.GetTotalPrice(orderID)
+		if err != nil {
+			return nil, err
+		} else if total <= 0 {
+			o.logger.Error("SERVICE: Order total is not positive", "order_id", orderID, "total", total)
+			return nil, fmt.Errorf("%w: total is %.2f", service_errors.NonPositiveTotal, total)
+		}
+	}
+
+	stampTransitions(order, previousStatus, previousWorkerID, time.Now())
+
+	order, err = o.OrderRepository.Update(order)
+	if err != nil {
+		o.logger.Error("SERVICE: Update method failed", "order", order, "error", err)
+		return nil, err
+	}
+
+	if status == models.CompletedOrderStatus && previousStatus != models.CompletedOrderStatus {
+		o.notifyOrderCompleted(order)
+	}
+
+	if status != previousStatus {
+		o.notifyStatusChanged(order, previousStatus, status)
+	}
+
+	if worker != nil && workerID != previousWorkerID {
+		o.notifyAssigned(order, worker)
+	}
+
+	o.logger.Info("SERVICE: Successfully changed order status", "order_id", orderID, "status", status)
+	return order, nil
+}
+
+// ReopenOrder returns a completed or cancelled order to work. The order becomes
+// in progress if a worker is still assigned and new otherwise; its rating and
+// completion and cancellation timestamps and the cancellation reason are cleared.
+// Only a manager can reopen orders.
+//
+// Parameters:
+//   - editor: Worker performing the operation
+//   - orderID: UUID of the order to reopen
+//
+// Returns:
+//   - *models.Order: Reopened order
+//   - error: service_errors.InvalidRole if the editor is not a manager,
+//     service_errors.InvalidOrderStatus if the order is neither completed nor cancelled,
+//     any other retrieval or persistence errors
+func (o OrderService) ReopenOrder(editor *models.Worker, orderID uuid.UUID) (*models.Order, error) {
+	if !isManager(editor) {
+		o.logger.Error("SERVICE: Only a manager can reopen orders", "order_id", orderID)
+		return nil, service_errors.InvalidRole
+	}
+
+	order, err := o.OrderRepository.GetOrderByID(orderID)
+	if err != nil {
+		o.logger.Error("SERVICE: GetOrderByID method failed", "id", orderID, "error", err)
+		return nil, err
+	}
+
+	if order.Status != models.CompletedOrderStatus && order.Status != models.CancelledOrderStatus {
+		o.logger.Error("SERVICE: Only completed or cancelled orders can be reopened", "order_id", orderID, "status", order.Status)
+		return nil, service_errors.InvalidOrderStatus
+	}
+
+	previousStatus := order.Status
+	if order.WorkerID != uuid.Nil {
+		order.Status = models.InProgressOrderStatus
+	} else {
+		order.Status = models.NewOrderStatus
+	}
+	order.Rate = 0
+	order.CompletedAt = nil
+	order.CancelledAt = nil
+	order.CancellationReason = ""
+
+	order, err = o.OrderRepository.Update(order)
+	if err != nil {
+		o.logger.Error("SERVICE: Update method failed", "order_id", orderID, "error", err)
+		return nil, err
+	}
+
+	o.notifyStatusChanged(order, previousStatus, order.Status)
+
+	o.logger.Info("SERVICE: Successfully reopened order", "order_id", orderID, "status", order.Status)
+	return order, nil
+}
+
+// Reschedule moves an open order to a new deadline and address.
+//
+// Parameters:
+//   - orderID: UUID of the order to reschedule
+//   - newDeadline: New deadline of the order
+//   - newAddress: New address of the order
+//
+// Returns:
+//   - error: service_errors.InvalidDeadlineOrder if the deadline is out of bounds,
+//     service_errors.InvalidAddressOrder if the address is outside the service area,
+//     service_errors.OrderIsAlreadyCompleted or service_errors.OrderIsCancelled
+//     for closed orders, any other validation, retrieval or persistence errors
+func (o OrderService) Reschedule(orderID uuid.UUID, newDeadline time.Time, newAddress string) error {
+	if !validAddress(newAddress) {
+		o.logger.Error("SERVICE: Invalid input", "address", newAddress)
+		return fmt.Errorf("SERVICE: Invalid input")
+	}
+
+	if err := validDeadline(newDeadline, time.Now(), o.deadlines); err != nil {
+		o.logger.Error("SERVICE: Invalid deadline", "deadline", newDeadline, "error", err)
+		return err
+	}
+
+	if !o.validServiceArea(newAddress) {
+		o.logger.Error("SERVICE: Address is outside the service area", "address", newAddress)
+		return service_errors.InvalidAddressOrder
+	}
+
+	order, err := o.OrderRepository.GetOrderByID(orderID)
+	if err != nil {
+		o.logger.Error("SERVICE: GetOrderByID method failed", "id", orderID, "error", err)
+		return err
+	}
+
+	if err = o.checkOrderIsOpen(order); err != nil {
+		return err
+	}
+
+	order.Deadline = newDeadline
+	order.Address = newAddress
+
+	_, err = o.OrderRepository.Update(order)
+	if err != nil {
+		o.logger.Error("SERVICE: Update method failed", "order_id", orderID, "error", err)
+		return err
+	}
+
+	o.logger.Info("SERVICE: Successfully rescheduled order", "order_id", orderID, "deadline", newDeadline)
+	return nil
+}
+
+// CancelOrder cancels an open order, storing the reason and the moment of cancellation.
+//
+// Parameters:
+//   - orderID: UUID of the order to cancel
+//   - reason: Why the order is cancelled, must not be blank
+//
+// Returns:
+//   - error: service_errors.OrderIsAlreadyCompleted or service_errors.OrderIsCancelled
+//     for closed orders, any other validation, retrieval or persistence errors
+func (o OrderService) CancelOrder(orderID uuid.UUID, reason string) error {
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		o.logger.Error("SERVICE: Invalid input", "reason", reason)
+		return fmt.Errorf("SERVICE: Invalid input")
+	}
+
+	order, err := o.OrderRepository.GetOrderByID(orderID)
+	if err != nil {
+		o.logger.Error("SERVICE: GetOrderByID method failed", "id", orderID, "error", err)
+		return err
+	}
+
+	if err = o.checkOrderIsOpen(order); err != nil {
+		return err
+	}
+
+	now := time.Now()
+	previousStatus := order.Status
+	order.Status = models.CancelledOrderStatus
+	order.CancelledAt = &now
+	order.CancellationReason = reason
+
+	_, err = o.OrderRepository.Update(order)
+	if err != nil {
+		o.logger.Error("SERVICE: Update method failed", "order_id", orderID, "error", err)
+		return err
+	}
+
+	o.notifyStatusChanged(order, previousStatus, order.Status)
+
+	o.logger.Info("SERVICE: Successfully cancelled order", "order_id", orderID, "reason", reason)
+	return nil
+}
+
+// requote refreshes the quoted total of an order after its tasks have changed,
+// so the quote, the receipt and revenue reports agree on the price of the order.
+//
+// Parameters:
+//   - orderID: UUID of the order
+//
+// Returns:
+//   - error: Any persistence errors
+func (o OrderService) requote(orderID uuid.UUID) error {
+	err := o.OrderRepository.RequoteOrder(orderID, o.rounding)
+	if err != nil {
+		o.logger.Error("SERVICE: RequoteOrder method failed", "order_id", orderID, "error", err)
+		return err
+	}
+	return nil
+}
+
+// AddTask associates a task with an order with a quantity of one. The quoted
+// total of the order is updated to include the task.
+//
+// Parameters:
+//   - orderID: UUID of the order
+//   - taskID: UUID of the task to add
+//
+// Returns:
+//   - error: service_errors.TaskIsAlreadyAttachedToOrder if the task is already
+//     attached, any other validation or persistence errors
+func (o OrderService) AddTask(orderID uuid.UUID, taskID uuid.UUID) error {
+	order, err := o.OrderRepository.GetOrderByID(orderID)
+	if err != nil {
+		o.logger.Error("SERVICE: GetOrderByID method failed", "id", orderID, "error", err)
+		return err
+	}
+
+	attachedTasks, err := o.OrderRepository.GetTasksInOrder(order.ID)
+	if err != nil {
+		o.logger.Error("SERVICE: GetTasksInOrder method failed", "id", order.ID, "error", err)
+		return err
+	}
+
+	_, err = o.TaskRepository.GetTaskByID(taskID)
+	if err != nil {
+		o.logger.Error("SERVICE: GetTaskByID method failed", "id", taskID, "error", err)
+		return err
+	}
+
+	if taskIsAttachedToOrder(taskID, attachedTasks) {
+		o.logger.Error("SERVICE: Task is already attached to order", "order_id", orderID, "task_id", taskID)
+		return service_errors.TaskIsAlreadyAttachedToOrder
+	}
+
+	err = o.OrderRepository.AddTaskToOrder(order.ID, taskID, 1)
+	if err != nil {
+		o.logger.Error("SERVICE: AddTaskToOrder method failed", "order_id", order.ID, "task_id", taskID, "error", err)
+		return err
+	}
+
+	err = o.requote(order.ID)
+	if err != nil {
+		return err
+	}
+
+	o.logger.Info("SERVICE: Successfully added tasks to order", "order_id", orderID)
+	return nil
+}
+
+// AddTasks associates several tasks with an order in a single transaction.
+// All tasks must exist and have a positive quantity, and none of them may already
+// be attached to the order or be listed twice; otherwise nothing is added. The
+// quoted total of the order is updated to include the tasks.
+//
+// Parameters:
+//   - orderID: UUID of the order
+//   - orderedTasks: Tasks to add together with their quantities
+//
+// Returns:
+//   - error: service_errors.EmptyTasksOrder for an empty list,
+//     service_errors.TaskIsAlreadyAttachedToOrder if a task is already attached,
+//     any other validation or persistence errors
+func (o OrderService) AddTasks(orderID uuid.UUID, orderedTasks []models.OrderedTask) error {
+	if len(orderedTasks) == 0 {
+		o.logger.Error("SERVICE: No tasks to add", "order_id", orderID)
+		return service_errors.EmptyTasksOrder
+	}
+
+	order, err := o.OrderRepository.GetOrderByID(orderID)
+	if err != nil {
+		o.logger.Error("SERVICE: GetOrderByID method failed", "id", orderID, "error", err)
+		return err
+	}
+
+	_, err = o.checkTasksExistence(orderedTasks)
+	if err != nil {
+		return err
+	}
+
+	attachedTasks, err := o.OrderRepository.GetTasksInOrder(order.ID)
+	if err != nil {
+		o.logger.Error("SERVICE: GetTasksInOrder method failed", "id", order.ID, "error", err)
+		return err
+	}
+
+	added := make([]models.Task, 0, len(orderedTasks))
+	for _, task := range orderedTasks {
+		if taskIsAttachedToOrder(task.Task.ID, attachedTasks) || taskIsAttachedToOrder(task.Task.ID, added) {
+			o.logger.Error("SERVICE: Task is already attached to order", "order_id", orderID, "task_id", task.Task.ID)
+			return service_errors.TaskIsAlreadyAttachedToOrder
+		}
+		added = append(added, *task.Task)
+	}
+
+	err = o.OrderRepository.AddTasksToOrder(order.ID, orderedTasks)
+	if errors.Is(err, repository_errors.AlreadyExists) {
+		o.logger.Error("SERVICE: Task is already attached to order", "order_id", orderID)
+		return service_errors.TaskIsAlreadyAttachedToOrder
+	} else if err != nil {
+		o.logger.Error("SERVICE: AddTasksToOrder method failed", "order_id", order.ID, "error", err)
+		return err
+	}
+
+	err = o.requote(order.ID)
+	if err != nil {
+		return err
+	}
+
+	o.logger.Info("SERVICE: Successfully added tasks to order", "order_id", orderID, "count", len(orderedTasks))
+	return nil
+}
+
+// RemoveTask removes a task association from an order and updates the quoted
+// total of the order.
+//
+// Parameters:
+//   - orderID: UUID of the order
+//   - taskID: UUID of the task to remove
+//
+// Returns:
+//   - error: Any validation or persistence errors
+func (o OrderService) RemoveTask(orderID uuid.UUID, taskID uuid.UUID) error {
+	order, err := o.OrderRepository.GetOrderByID(orderID)
+	if err != nil {
+		o.logger.Error("SERVICE: GetOrderByID method failed", "id", orderID, "error", err)
+		return err
+	}
+
+	_, err = o.TaskRepository.GetTaskByID(taskID)
+	if err != nil {
+		o.logger.Error("SERVICE: GetTaskByID method failed", "id", taskID, "error", err)
+		return err
+	}
+
+	attachedTasks, err := o.OrderRepository.GetTasksInOrder(order.ID)
+	if err != nil {
+		o.logger.Error("SERVICE: GetTasksInOrder method failed", "order_id", order.ID, "error", err)
+		return err
+	}
+
+	if !taskIsAttachedToOrder(taskID, attachedTasks) {
+		o.logger.Error("SERVICE: Task is not attached to order", "order_id", orderID, "task_id", taskID)
+		return fmt.Errorf("SERVICE: Task is not attached to order")
+	}
+
+	// remove task from order
+	err = o.OrderRepository.RemoveTaskFromOrder(order.ID, taskID)
+	if err != nil {
+		o.logger.Error("SERVICE: RemoveTaskFromOrder method failed", "order_id", order.ID, "task_id", taskID, "error", err)
+		return err
+	}
+
+	err = o.requote(order.ID)
+	if err != nil {
+		return err
+	}
+
+	o.logger.Info("SERVICE: Successfully removed task from order", "order_id", orderID, "task_id", taskID)
+	return nil
+}
+
+// GetOrderByID retrieves an order by its unique identifier.
+//
+// Parameters:
+//   - id: UUID of the order to retrieve
+//
+// Returns:
+//   - *models.Order: Retrieved order entity
+//   - error: Any validation or retrieval errors
+func (o OrderService) GetOrderByID(id uuid.UUID) (*models.Order, error) {
+	order, err := o.OrderRepository.GetOrderByID(id)
+	if err != nil {
+		o.logger.Error("SERVICE: GetOrderByID method failed", "id", id, "error", err)
+		return nil, err
+	}
+
+	o.logger.Info("SERVICE: Successfully got order by id", "id", id)
+	return order, nil
+}
+
+// IncrementTaskQuantity increases the quantity of a specific task in an order by one.
+// The quantity is updated atomically, so concurrent increments are never lost, and
+// the quoted total of the order follows the new quantity.
+//
+// Parameters:
+//   - id: UUID of the order
+//   - taskID: UUID of the task
+//
+// Returns:
+//   - int: Updated quantity value after increment
+//   - error: Any validation or persistence errors
+func (o OrderService) IncrementTaskQuantity(id uuid.UUID, taskID uuid.UUID) (int, error) {
+	_, err := o.OrderRepository.GetOrderByID(id)
+	if err != nil {
+		o.logger.Error("SERVICE: GetOrderByID method failed", "id", id, "error", err)
+		return 0, err
+	}
+
+	_, err = o.TaskRepository.GetTaskByID(taskID)
+	if err != nil {
+		o.logger.Error("SERVICE: GetTaskByID method failed", "id", taskID, "error", err)
+		return 0, err
+	}
+
+	quantity, err := o.OrderRepository.IncrementTaskQuantity(id, taskID)
+	if err != nil {
+		o.logger.Error("SERVICE: IncrementTaskQuantity method failed", "order_id", id, "task_id", taskID, "error", err)
+		return 0, err
+	}
+
+	err = o.requote(id)
+	if err != nil {
+		return 0, err
+	}
+
+	o.logger.Info("SERVICE: Successfully incremented task quantity", "order_id", id, "task_id", taskID, "quantity", quantity)
+	return quantity, nil
+}
+
+// DecrementTaskQuantity decreases the quantity of a specific task in an order by one.
+// The quantity is updated atomically and never drops below 0, and the quoted total
+// of the order follows the new quantity.
+//
+// Parameters:
+//   - id: UUID of the order
+//   - taskID: UUID of the task
+//
+// Returns:
+//   - int: Updated quantity value after decrement
+//   - error: Error if the quantity is already 0, any other validation or persistence errors
+func (o OrderService) DecrementTaskQuantity(id uuid.UUID, taskID uuid.UUID) (int, error) {
+	_, err := o.OrderRepository.GetOrderByID(id)
+	if err != nil {
+		o.logger.Error("SERVICE: GetOrderByID method failed", "id", id, "error", err)
+		return 0, err
+	}
+
+	_, err = o.TaskRepository.GetTaskByID(taskID)
+	if err != nil {
+		o.logger.Error("SERVICE: GetTaskByID method failed", "id", taskID, "error", err)
+		return 0, err
+	}
+
+	quantity, err := o.OrderRepository.DecrementTaskQuantity(id, taskID)
+	if errors.Is(err, repository_errors.DoesNotExist) {
+		// no row was updated, tell a task missing from the order from a quantity of 0
+		_, err = o.OrderRepository.GetTaskQuantity(id, taskID)
+		if err != nil {
+			o.logger.Error("SERVICE: GetTaskQuantity method failed", "order_id", id, "task_id", taskID, "error", err)
+			return 0, err
+		}
+
+		o.logger.Error("SERVICE: Quantity is already 0", "order_id", id, "task_id", taskID)
+		return 0, fmt.Errorf("SERVICE: Quantity is already 0")
+	} else if err != nil {
+		o.logger.Error("SERVICE: DecrementTaskQuantity method failed", "order_id", id, "task_id", taskID, "error", err)
+		return 0, err
+	}
+
+	err = o.requote(id)
+	if err != nil {
+		return 0, err
+	}
+
+	o.logger.Info("SERVICE: Successfully decremented task quantity", "order_id", id, "task_id", taskID, "quantity", quantity)
+	return quantity, nil
+}
+
+// SetTaskQuantity updates the quantity of a specific task in an order and the
+// quoted total of the order.
+//
+// Parameters:
+//   - id: UUID of the order
+//   - taskID: UUID of the task
+//   - quantity: New quantity value
+//
+// Returns:
+//   - error: Any validation or persistence errors
+func (o OrderService) SetTaskQuantity(id uuid.UUID, taskID uuid.UUID, quantity int) error {
+	if quantity < 0 {
+		o.logger.Error("SERVICE: Quantity is negative", "order_id", id, "task_id", taskID, "quantity", quantity)
+		return service_errors.NegativeQuantity
+	}
+
+	_, err := o.OrderRepository.GetOrderByID(id)
+	if err != nil {
+		o.logger.Error("SERVICE: GetOrderByID method failed", "id", id, "error", err)
+		return err
+	}
+
+	_, err = o.TaskRepository.GetTaskByID(taskID)
+	if err != nil {
+		o.logger.Error("SERVICE: GetTaskByID method failed", "id", taskID, "error", err)
+		return err
+	}
+
+	err = o.OrderRepository.UpdateTaskQuantity(id, taskID, quantity)
+	if err != nil {
+		o.logger.Error("SERVICE: UpdateTaskQuantity method failed", "order_id", id, "task_id", taskID, "error", err)
+		return err
+	}
+
+	err = o.requote(id)
+	if err != nil {
+		return err
+	}
+
+	o.logger.Info("SERVICE: Successfully set task quantity", "order_id", id, "task_id", taskID, "quantity", quantity)
+	return nil
+}
+
+// GetTaskQuantity retrieves the quantity of a specific task in an order.
+//
+// Parameters:
+//   - orderID: UUID of the order
+//   - taskID: UUID of the task
+//
+// Returns:
+//   - int: Quantity of the task in the order
+//   - error: Any validation or retrieval errors
+func (o OrderService) GetTaskQuantity(orderID uuid.UUID, taskID uuid.UUID) (int, error) {
+	_, err := o.OrderRepository.GetOrderByID(orderID)
+	if err != nil {
+		o.logger.Error("SERVICE: GetOrderByID method failed", "id", orderID, "error", err)
+		return 0, err
+	}
+
+	_, err = o.TaskRepository.GetTaskByID(taskID)
+	if err != nil {
+		o.logger.Error("SERVICE: GetTaskByID method failed", "id", taskID, "error", err)
+		return 0, err
+	}
+
+	quantity, err := o.OrderRepository.GetTaskQuantity(orderID, taskID)
+	if err != nil {
+		o.logger.Error("SERVICE: GetTaskQuantity method failed", "order_id", orderID, "task_id", taskID, "error", err)
+		return 0, err
+	}
+
+	o.logger.Info("SERVICE: Successfully got task quantity", "order_id", orderID, "task_id", taskID, "quantity", quantity)
+	return quantity, nil
+}
+
+// GetDispatchInfo retrieves the details a master needs to carry out an order:
+// its address, deadline and tasks with their quantities.
+//
+// Parameters:
+//   - orderID: UUID of the order
+//
+// Returns:
+//   - *models.DispatchInfo: Details of the order
+//   - error: Any retrieval errors
+func (o OrderService) GetDispatchInfo(orderID uuid.UUID) (*models.DispatchInfo, error) {
+	order, err := o.OrderRepository.GetOrderByID(orderID)
+	if err != nil {
+		o.logger.Error("SERVICE: GetOrderByID method failed", "id", orderID, "error", err)
+		return nil, err
+	}
+
+	info, err := o.buildDispatchInfo(order)
+	if err != nil {
+		return nil, err
+	}
+
+	o.logger.Info("SERVICE: Successfully got dispatch info", "order_id", orderID)
+	return info, nil
+}
+
+// checkOrderIsOpen makes sure the assignment of an order may still be changed.
+//
+// Parameters:
+//   - order: Order to check
+//
+// Returns:
+//   - error: service_errors.OrderIsAlreadyCompleted or service_errors.OrderIsCancelled
+//     for closed orders, nil otherwise
+func (o OrderService) checkOrderIsOpen(order *models.Order) error {
+	switch order.Status {
+	case models.CompletedOrderStatus:
+		o.logger.Error("SERVICE: Order is already completed", "order_id", order.ID)
+		return service_errors.OrderIsAlreadyCompleted
+	case models.CancelledOrderStatus:
+		o.logger.Error("SERVICE: Order is cancelled", "order_id", order.ID)
+		return service_errors.OrderIsCancelled
+	}
+
+	return nil
+}
+
+// AssignWorker assigns a master to an order. A new order is moved to in progress,
+// while the rating is kept. The assignment follows the same capacity and
+// availability rules as Update. When the
+// worker changes, the new worker is sent the details of the order.
+// Only a manager can assign workers.
+//
+// Parameters:
+//   - editor: Worker performing the operation
+//   - orderID: UUID of the order
+//   - workerID: UUID of the master to assign
+//
+// Returns:
+//   - error: service_errors.InvalidRole if the editor is not a manager or the worker
+//     is not a master, service_errors.WorkerUnavailable if the worker is off at the
+//     deadline, service_errors.OrderIsAlreadyCompleted or
+//     service_errors.OrderIsCancelled for closed orders, any other validation or
+//     persistence errors
+func (o OrderService) AssignWorker(editor *models.Worker, orderID uuid.UUID, workerID uuid.UUID) error {
+	if !isManager(editor) {
+		o.logger.Error("SERVICE: Only a manager can assign workers", "editor_id", editorID(editor), "order_id", orderID)
+		return service_errors.InvalidRole
+	}
+
+	if workerID == uuid.Nil {
+		o.logger.Error("SERVICE: Invalid input")
+		return fmt.Errorf("SERVICE: Invalid input")
+	}
+
+	order, err := o.OrderRepository.GetOrderByID(orderID)
+	if err != nil {
+		o.logger.Error("SERVICE: GetOrderByID method failed", "id", orderID, "error", err)
+		return err
+	}
+
+	err = o.checkOrderIsOpen(order)
+	if err != nil {
+		return err
+	}
+
+	worker, err := o.WorkerRepository.GetWorkerByID(workerID)
+	if err != nil {
+		o.logger.Error("SERVICE: GetWorkerByID method failed", "id", workerID, "error", err)
+		return err
+	}
+
+	if worker.Role != models.MasterRole {
+		o.logger.Error("SERVICE: Only masters can be assigned to orders", "worker_id", workerID, "role", worker.Role)
+		return service_errors.InvalidRole
+	}
+
+	previousWorkerID := order.WorkerID
+	status := order.Status
+	if status == models.NewOrderStatus {
+		status = models.InProgressOrderStatus
+	}
+
+	updatedOrder, err := o.Update(editor, orderID, status, order.Rate, workerID)
+	if err != nil {
+		return err
+	}
+
+	if previousWorkerID != workerID {
+		o.notifyWorkerAboutAssignment(updatedOrder, *worker)
+	}
+
+	o.logger.Info("SERVICE: Successfully assigned worker", "order_id", orderID, "worker_id", workerID)
+	return nil
+}
+
+// UnassignWorker removes the assigned master from an order. An order in progress
+// is moved back to new, so it can be assigned again. Only a manager can unassign workers.
+//
+// Parameters:
+//   - editor: Worker performing the operation
+//   - orderID: UUID of the order
+//
+// Returns:
+//   - error: service_errors.InvalidRole if the editor is not a manager,
+//     service_errors.OrderIsAlreadyCompleted or service_errors.OrderIsCancelled
+//     for closed orders, any other validation or persistence errors
+func (o OrderService) UnassignWorker(editor *models.Worker, orderID uuid.UUID) error {
+	if !isManager(editor) {
+		o.logger.Error("SERVICE: Only a manager can unassign workers", "editor_id", editorID(editor), "order_id", orderID)
+		return service_errors.InvalidRole
+	}
+
+	order, err := o.OrderRepository.GetOrderByID(orderID)
+	if err != nil {
+		o.logger.Error("SERVICE: GetOrderByID method failed", "id", orderID, "error", err)
+		return err
+	}
+
+	err = o.checkOrderIsOpen(order)
+	if err != nil {
+		return err
+	}
+
+	status := order.Status
+	if status == models.InProgressOrderStatus {
+		status = models.NewOrderStatus
+	}
+
+	_, err = o.Update(editor, orderID, status, order.Rate, uuid.Nil)
+	if err != nil {
+		return err
+	}
+
+	o.logger.Info("SERVICE: Successfully unassigned worker", "order_id", orderID)
+	return nil
+}
+
+// GetTotalPrice calculates the total price for an order based on task prices and quantities.
+// The total is rounded according to the configured rounding mode.
+//
+// Parameters:
+//   - orderID: UUID of the order to calculate price for
+//
+// Returns:
+//   - float64: Total price for the order
+//   - error: Any calculation or retrieval errors
+func (o OrderService) GetTotalPrice(orderID uuid.UUID) (float64, error) {
+	sum, err := o.OrderRepository.GetOrderTotalPrice(orderID)
+	if err != nil {
+		o.logger.Error("SERVICE: GetOrderTotalPrice method failed", "order_id", orderID, "error", err)
+		return 0, err
+	}
+	sum = o.rounding.Round(sum)
+
+	o.logger.Info("SERVICE: Successfully got total price", "order_id", orderID, "total_price", sum)
+	return sum, nil
+}
+
+// GetOrderDetails retrieves an order together with its tasks and their
+// quantities, the assigned worker and the total price. Tasks and quantities are
+// loaded at once, and the total is computed from the unit prices stored with the
+// order and rounded according to the configured rounding mode.
+//
+// Parameters:
+//   - orderID: UUID of the order
+//
+// Returns:
+//   - *models.OrderDetails: Order with its tasks, worker and total price
+//   - error: Any retrieval errors
+func (o OrderService) GetOrderDetails(orderID uuid.UUID) (*models.OrderDetails, error) {
+	order, err := o.OrderRepository.GetOrderByID(orderID)
+	if err != nil {
+		o.logger.Error("SERVICE: GetOrderByID method failed", "id", orderID, "error", err)
+		return nil, err
+	}
+
+	orderedTasks, err := o.OrderRepository.GetOrderedTasks(orderID)
+	if err != nil {
+		o.logger.Error("SERVICE: GetOrderedTasks method failed", "order_id", orderID, "error", err)
+		return nil, err
+	}
+
+	// ordered tasks carry the unit prices stored with the order, tiers included
+	var total float64
+	for _, task := range orderedTasks {
+		total += task.Task.PricePerSingle * float64(task.Quantity)
+	}
+
+	details := &models.OrderDetails{Order: *order, Tasks: orderedTasks, TotalPrice: o.rounding.Round(total)}
+
+	if order.WorkerID != uuid.Nil {
+		worker, err := o.WorkerRepository.GetWorkerByID(order.WorkerID)
+		if err != nil {
+			o.logger.Error("SERVICE: GetWorkerByID method failed", "id", order.WorkerID, "error", err)
+			return nil, err
+		}
+		worker.Password = ""
+		details.Worker = worker
+	}
+
+	o.logger.Info("SERVICE: Successfully got order details", "order_id", orderID, "total_price", details.TotalPrice)
+	return details, nil
+}
+
+// GetOrdersByIDs retrieves all orders whose identifiers are in the given list.
+// Identifiers without a matching order are simply absent from the result.
+//
+// Parameters:
+//   - ids: Slice of order UUIDs to retrieve
+//
+// Returns:
+//   - []models.Order: Slice of found order entities
+//   - error: Any retrieval errors
+func (o OrderService) GetOrdersByIDs(ids []uuid.UUID) ([]models.Order, error) {
+	if len(ids) == 0 {
+		o.logger.Info("SERVICE: No order ids provided")
+		return []models.Order{}, nil
+	}
+
+	orders, err := o.OrderRepository.GetOrdersByIDs(ids)
+	if err != nil {
+		o.logger.Error("SERVICE: GetOrdersByIDs method failed", "ids", ids, "error", err)
+		return nil, err
+	}
+
+	o.logger.Info("SERVICE: Successfully got orders by ids", "requested", len(ids), "found", len(orders))
+	return orders, nil
+}
+
+// FilterOrders retrieves orders matching any of the given statuses and created
+// within the given period, newest first. An empty status slice and zero-value
+// dates mean that the corresponding condition is ignored.
+//
+// Parameters:
+//   - statuses: Slice of status codes to match
+//   - from: Start of the creation period (inclusive)
+//   - to: End of the creation period (inclusive)
+//
+// Returns:
+//   - []models.Order: Slice of order entities matching the filter criteria
+//   - error: Validation error if a status or the period is invalid, retrieval error otherwise
+func (o OrderService) FilterOrders(statuses []int, from time.Time, to time.Time) ([]models.Order, error) {
+	return o.filterOrders(uuid.Nil, statuses, from, to)
+}
+
+// filterOrders validates the filter and retrieves the matching orders of a user,
+// or of every user for uuid.Nil.
+//
+// Parameters:
+//   - userID: UUID of the customer, uuid.Nil for orders of every customer
+//   - statuses: Slice of status codes to match
+//   - from: Start of the creation period (inclusive)
+//   - to: End of the creation period (inclusive)
+//
+// Returns:
+//   - []models.Order: Slice of order entities matching the filter criteria
+//   - error: Validation error if a status or the period is invalid, retrieval error otherwise
+func (o OrderService) filterOrders(userID uuid.UUID, statuses []int, from time.Time, to time.Time) ([]models.Order, error) {
+	for _, status := range statuses {
+		if _, ok := models.OrderStatuses[status]; !ok || status == models.NoStatus {
+			o.logger.Error("SERVICE: Invalid input", "status", status)
+			return nil, fmt.Errorf("SERVICE: Invalid input")
+		}
+	}
+	if !from.IsZero() && !to.IsZero() && to.Before(from) {
+		o.logger.Error("SERVICE: Invalid input", "from", from, "to", to)
+		return nil, fmt.Errorf("SERVICE: Invalid input")
+	}
+
+	orders, err := o.OrderRepository.FilterByStatusAndDate(userID, statuses, from, to)
+	if err != nil {
+		o.logger.Error("SERVICE: FilterByStatusAndDate method failed", "user_id", userID, "statuses", statuses, "from", from, "to", to, "error", err)
+		return nil, err
+	}
+
+	o.logger.Info("SERVICE: Successfully filtered orders", "user_id", userID, "statuses", statuses, "from", from, "to", to, "found", len(orders))
+	return orders, nil
+}
+
+// GetOrdersByStatus retrieves all orders that are in any of the given statuses,
+// newest first. The statuses are passed to the query as parameters, like in FilterOrders.
+//
+// Parameters:
+//   - statuses: Statuses to match, at least one is required
+//
+// Returns:
+//   - []models.Order: Slice of order entities in the given statuses
+//   - error: Validation error if no status is given or a status is invalid, retrieval error otherwise
+func (o OrderService) GetOrdersByStatus(statuses ...models.OrderStatus) ([]models.Order, error) {
+	return o.GetUserOrdersByStatus(uuid.Nil, statuses...)
+}
+
+// GetUserOrdersByStatus retrieves the orders of a customer that are in any of
+// the given statuses, newest first.
+//
+// Parameters:
+//   - userID: UUID of the customer, uuid.Nil for orders of every customer
+//   - statuses: Statuses to match, at least one is required
+//
+// Returns:
+//   - []models.Order: Slice of order entities in the given statuses
+//   - error: Validation error if no status is given or a status is invalid, retrieval error otherwise
+func (o OrderService) GetUserOrdersByStatus(userID uuid.UUID, statuses ...models.OrderStatus) ([]models.Order, error) {
+	if len(statuses) == 0 {
+		o.logger.Error("SERVICE: Invalid input", "statuses", statuses)
+		return nil, fmt.Errorf("SERVICE: Invalid input")
+	}
+
+	codes := make([]int, len(statuses))
+	for i, status := range statuses {
+		codes[i] = int(status)
+	}
+
+	return o.filterOrders(userID, codes, time.Time{}, time.Time{})
+}
+
+// GetOverdueOrders retrieves new and in-progress orders whose deadline has
+// already passed, the most overdue first.
+//
+// Returns:
+//   - []models.Order: Slice of overdue order entities
+//   - error: Any retrieval errors
+func (o OrderService) GetOverdueOrders() ([]models.Order, error) {
+	orders, err := o.OrderRepository.GetOverdueOrders(time.Now())
+	if err != nil {
+		o.logger.Error("SERVICE: GetOverdueOrders method failed", "error", err)
+		return nil, err
+	}
+
+	o.logger.Info("SERVICE: Successfully got overdue orders", "count", len(orders))
+	return orders, nil
+}
+
+// AutoAssignUnassigned assigns every new order without a worker to the least
+// loaded master who still has capacity and is available at the order deadline.
+// Orders with the earliest deadline are assigned first, and orders for which no
+// master is available are left unassigned.
+// Every order is assigned with AssignWorker, so it is moved to in progress, the
+// assigned master is sent its details, and an order that changed in the meantime
+// is left unassigned. Only a manager can run the assignment.
+//
+// Parameters:
+//   - editor: Worker performing the operation
+//
+// Returns:
+//   - map[uuid.UUID]uuid.UUID: Mapping of assigned order IDs to worker IDs
+//   - error: service_errors.InvalidRole if the editor is not a manager,
+//     any retrieval or persistence errors
+func (o OrderService) AutoAssignUnassigned(editor *models.Worker) (map[uuid.UUID]uuid.UUID, error) {
+	if !isManager(editor) {
+		o.logger.Error("SERVICE: Only a manager can assign workers", "editor_id", editorID(editor))
+		return nil, service_errors.InvalidRole
+	}
+
+	orders, err := o.OrderRepository.Filter(map[string]string{
+		"worker_id": "null",
+		"status":    strconv.Itoa(models.NewOrderStatus),
+	}, 0, 0, false)
+	if err != nil {
+		o.logger.Error("SERVICE: Filter method failed", "error", err)
+		return nil, err
+	}
+
+	masters, err := o.WorkerRepository.GetWorkersByRole(models.MasterRole, uuid.Nil)
+	if err != nil {
+		o.logger.Error("SERVICE: GetWorkersByRole method failed", "error", err)
+		return nil, err
+	}
+
+	load := make(map[uuid.UUID]int, len(masters))
+	for _, master := range masters {
+		activeOrders, err := o.WorkerRepository.GetActiveOrdersCount(master.ID)
+		if err != nil {
+			o.logger.Error("SERVICE: GetActiveOrdersCount method failed", "id", master.ID, "error", err)
+			return nil, err
+		}
+		load[master.ID] = activeOrders
+	}
+
+	sort.SliceStable(orders, func(i, j int) bool {
+		return orders[i].Deadline.Before(orders[j].Deadline)
+	})
+
+	assignments := make(map[uuid.UUID]uuid.UUID)
+	for i := range orders {
+		order := &orders[i]
+
+		workerID := uuid.Nil
+		for _, master := range masters {
+			if o.maxActiveOrders > 0 && load[master.ID] >= o.maxActiveOrders {
+				continue
+			}
+			if workerID != uuid.Nil && load[master.ID] >= load[workerID] {
+				continue
+			}
+
+			available, err := o.WorkerRepository.IsAvailable(master.ID, order.Deadline)
+			if err != nil {
+				o.logger.Error("SERVICE: IsAvailable method failed", "id", master.ID, "error", err)
+				return nil, err
+			}
+			if available {
+				workerID = master.ID
+			}
+		}
+
+		if workerID == uuid.Nil {
+			o.logger.Info("SERVICE: No available worker for order", "order_id", order.ID)
+			continue
+		}
+
+		err = o.AssignWorker(editor, order.ID, workerID)
+		if errors.Is(err, service_errors.WorkerUnavailable) || errors.Is(err, service_errors.WorkerAtCapacity) ||
+			errors.Is(err, service_errors.OrderIsAlreadyCompleted) || errors.Is(err, service_errors.OrderIsCancelled) {
+			// the order or the master changed since they were loaded
+			o.logger.Info("SERVICE: Order cannot be assigned anymore", "order_id", order.ID, "worker_id", workerID, "error", err)
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+
+		load[workerID]++
+		assignments[order.ID] = workerID
 	}
 
-	o.logger.Info("SERVICE: Successfully changed order status", "order_id", orderID, "status", status)
-	return order, nil
+	o.logger.Info("SERVICE: Successfully auto-assigned orders", "assigned", len(assignments), "unassigned", len(orders)-len(assignments))
+	return assignments, nil
 }
 
-// AddTask associates a task with an order.
+// BuildDailyDigest aggregates order activity of the given day into a summary
+// suitable for the daily manager email. The day is taken in the location of
+// the given time.
 //
 // Parameters:
-//   - orderID: UUID of the order
-//   - taskID: UUID of the task to add
+//   - day: Any moment of the day to summarize
 //
 // Returns:
-//   - error: Any validation or persistence errors
-func (o OrderService) AddTask(orderID uuid.UUID, taskID uuid.UUID) error {
-	order, err := o.OrderRepository.GetOrderByID(orderID)
-	if err != nil {
-		o.logger.Error("SERVICE: GetOrderByID method failed", "id", orderID, "error", err)
-		return err
+//   - models.DailyDigest: Aggregated counters for the day
+//   - error: Validation error if the day is not set, retrieval error otherwise
+func (o OrderService) BuildDailyDigest(day time.Time) (models.DailyDigest, error) {
+	if day.IsZero() {
+		o.logger.Error("SERVICE: Invalid input", "day", day)
+		return models.DailyDigest{}, fmt.Errorf("SERVICE: Invalid input")
 	}
 
-	attachedTasks, err := o.OrderRepository.GetTasksInOrder(order.ID)
+	from := time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, day.Location())
+	to := from.AddDate(0, 0, 1)
 
-	_, err = o.TaskRepository.GetTaskByID(taskID)
+	digest, err := o.OrderRepository.GetDigestBetween(from, to)
 	if err != nil {
-		o.logger.Error("SERVICE: GetTaskByID method failed", "id", taskID, "error", err)
-		return err
+		o.logger.Error("SERVICE: GetDigestBetween method failed", "from", from, "to", to, "error", err)
+		return models.DailyDigest{}, err
 	}
 
-	if taskIsAttachedToOrder(taskID, attachedTasks) {
-		o.logger.Error("SERVICE: Task is already attached to order", "order_id", orderID, "task_id", taskID)
-		return fmt.Errorf("SERVICE: Task is already attached to order")
+	o.logger.Info("SERVICE: Successfully built daily digest", "day", from, "digest", digest)
+	return *digest, nil
+}
+
+// GetAverageOrderValue computes the mean total of orders completed within
+// the given period, rounded according to the configured rounding mode.
+//
+// Parameters:
+//   - from: Start of the period (inclusive)
+//   - to: End of the period (inclusive)
+//
+// Returns:
+//   - float64: Average order value, 0 if no orders were completed in the period
+//   - error: Validation error if the period is invalid, retrieval error otherwise
+func (o OrderService) GetAverageOrderValue(from time.Time, to time.Time) (float64, error) {
+	if from.IsZero() || to.IsZero() || to.Before(from) {
+		o.logger.Error("SERVICE: Invalid input", "from", from, "to", to)
+		return 0, fmt.Errorf("SERVICE: Invalid input")
 	}
 
-	err = o.OrderRepository.AddTaskToOrder(order.ID, taskID)
+	average, err := o.OrderRepository.GetAverageOrderValue(from, to)
 	if err != nil {
-		o.logger.Error("SERVICE: AddTaskToOrder method failed", "order_id", order.ID, "task_id", taskID, "error", err)
-		return err
+		o.logger.Error("SERVICE: GetAverageOrderValue method failed", "from", from, "to", to, "error", err)
+		return 0, err
 	}
 
-	o.logger.Info("SERVICE: Successfully added tasks to order", "order_id", orderID)
-	return nil
+	average = o.rounding.Round(average)
+	o.logger.Info("SERVICE: Successfully computed average order value", "from", from, "to", to, "average", average)
+	return average, nil
 }
 
-// RemoveTask removes a task association from an order.
+// GetRevenueSummary reports the revenue, number and average value of the orders
+// completed within the given period. Cancelled orders are excluded and the
+// amounts are rounded according to the configured rounding mode.
 //
 // Parameters:
-//   - orderID: UUID of the order
-//   - taskID: UUID of the task to remove
+//   - from: Start of the period (inclusive)
+//   - to: End of the period (inclusive)
 //
 // Returns:
-//   - error: Any validation or persistence errors
-func (o OrderService) RemoveTask(orderID uuid.UUID, taskID uuid.UUID) error {
-	order, err := o.OrderRepository.GetOrderByID(orderID)
-	if err != nil {
-		o.logger.Error("SERVICE: GetOrderByID method failed", "id", orderID, "error", err)
-		return err
+//   - *models.RevenueSummary: Revenue, number and average value of the orders
+//   - error: Validation error if the period is invalid, retrieval error otherwise
+func (o OrderService) GetRevenueSummary(from time.Time, to time.Time) (*models.RevenueSummary, error) {
+	if from.IsZero() || to.IsZero() || to.Before(from) {
+		o.logger.Error("SERVICE: Invalid input", "from", from, "to", to)
+		return nil, fmt.Errorf("SERVICE: Invalid input")
 	}
 
-	_, err = o.TaskRepository.GetTaskByID(taskID)
+	summary, err := o.OrderRepository.GetRevenueSummary(from, to)
 	if err != nil {
-		o.logger.Error("SERVICE: GetTaskByID method failed", "id", taskID, "error", err)
-		return err
+		o.logger.Error("SERVICE: GetRevenueSummary method failed", "from", from, "to", to, "error", err)
+		return nil, err
 	}
 
-	attachedTasks, err := o.OrderRepository.GetTasksInOrder(order.ID)
-	if err != nil {
-		o.logger.Error("SERVICE: GetTasksInOrder method failed", "order_id", order.ID, "error", err)
-		return err
-	}
+	summary.Revenue = o.rounding.Round(summary.Revenue)
+	summary.AverageOrderValue = o.rounding.Round(summary.AverageOrderValue)
+	o.logger.Info("SERVICE: Successfully computed revenue summary", "from", from, "to", to, "summary", summary)
+	return summary, nil
+}
 
-	if !taskIsAttachedToOrder(taskID, attachedTasks) {
-		o.logger.Error("SERVICE: Task is not attached to order", "order_id", orderID, "task_id", taskID)
-		return fmt.Errorf("SERVICE: Task is not attached to order")
+// GetOrdersByRateBelow retrieves rated completed orders whose rating is below
+// the threshold. Unrated orders are never returned.
+//
+// Parameters:
+//   - threshold: Rating the orders must be below (1-5)
+//
+// Returns:
+//   - []models.Order: Slice of matching orders, lowest rated first
+//   - error: service_errors.RatingOutOfRange for an invalid threshold, retrieval error otherwise
+func (o OrderService) GetOrdersByRateBelow(threshold int) ([]models.Order, error) {
+	if threshold == 0 || !validRate(threshold) {
+		o.logger.Error("SERVICE: Rating threshold is out of range", "threshold", threshold)
+		return nil, service_errors.RatingOutOfRange
 	}
 
-	// remove task from order
-	err = o.OrderRepository.RemoveTaskFromOrder(order.ID, taskID)
+	orders, err := o.OrderRepository.GetOrdersByRateBelow(threshold)
 	if err != nil {
-		o.logger.Error("SERVICE: RemoveTaskFromOrder method failed", "order_id", order.ID, "task_id", taskID, "error", err)
-		return err
+		o.logger.Error("SERVICE: GetOrdersByRateBelow method failed", "threshold", threshold, "error", err)
+		return nil, err
 	}
 
-	o.logger.Info("SERVICE: Successfully removed task from order", "order_id", orderID, "task_id", taskID)
-	return nil
+	o.logger.Info("SERVICE: Successfully got orders rated below threshold", "threshold", threshold, "found", len(orders))
+	return orders, nil
 }
 
-// GetOrderByID retrieves an order by its unique identifier.
+// SearchOrdersByCustomerName retrieves orders placed by customers whose name,
+// surname or full name contains the given substring, ignoring case.
 //
 // Parameters:
-//   - id: UUID of the order to retrieve
+//   - substring: Part of the customer name to search for
 //
 // Returns:
-//   - *models.Order: Retrieved order entity
-//   - error: Any validation or retrieval errors
-func (o OrderService) GetOrderByID(id uuid.UUID) (*models.Order, error) {
-	order, err := o.OrderRepository.GetOrderByID(id)
+//   - []models.Order: Slice of matching order entities
+//   - error: service_errors.InvalidName for empty input, retrieval error otherwise
+func (o OrderService) SearchOrdersByCustomerName(substring string) ([]models.Order, error) {
+	substring = strings.TrimSpace(substring)
+	if substring == "" {
+		o.logger.Error("SERVICE: Empty customer name search query")
+		return nil, service_errors.InvalidName
+	}
+
+	orders, err := o.OrderRepository.SearchByCustomerName(substring)
 	if err != nil {
-		o.logger.Error("SERVICE: GetOrderByID method failed", "id", id, "error", err)
+		o.logger.Error("SERVICE: SearchByCustomerName method failed", "substring", substring, "error", err)
 		return nil, err
 	}
 
-	o.logger.Info("SERVICE: Successfully got order by id", "id", id)
-	return order, nil
+	o.logger.Info("SERVICE: Successfully searched orders by customer name", "substring", substring, "found", len(orders))
+	return orders, nil
 }
 
-// IncrementTaskQuantity increases the quantity of a specific task in an order by one.
+// orderReferencePattern matches an order reference: the beginning of an order ID,
+// at least its first eight hex digits, optionally prefixed with '#'.
+var orderReferencePattern = regexp.MustCompile(`^#?([0-9a-f]{8}[0-9a-f-]*)$`)
+
+// Search looks up orders by a single free-form query. A full order ID finds
+// exactly that order. Otherwise orders whose ID starts with an order reference
+// come first, followed by orders of customers with a matching name and then
+// orders with a matching address. Within each group the newest orders come
+// first, and every order is returned once.
 //
 // Parameters:
-//   - id: UUID of the order
-//   - taskID: UUID of the task
+//   - query: Order ID, order reference, customer name or part of an address
 //
 // Returns:
-//   - int: Updated quantity value after increment
-//   - error: Any validation or persistence errors
-func (o OrderService) IncrementTaskQuantity(id uuid.UUID, taskID uuid.UUID) (int, error) {
-	_, err := o.OrderRepository.GetOrderByID(id)
-	if err != nil {
-		o.logger.Error("SERVICE: GetOrderByID method failed", "id", id, "error", err)
-		return 0, err
+//   - []models.Order: Matching orders, most relevant first
+//   - error: Validation error for an empty query, retrieval error otherwise
+func (o OrderService) Search(query string) ([]models.Order, error) {
+	query = strings.TrimSpace(query)
+	if query == "" {
+		o.logger.Error("SERVICE: Empty order search query")
+		return nil, fmt.Errorf("SERVICE: Invalid input")
 	}
 
-	_, err = o.TaskRepository.GetTaskByID(taskID)
-	if err != nil {
-		o.logger.Error("SERVICE: GetTaskByID method failed", "id", taskID, "error", err)
-		return 0, err
+	if id, err := uuid.Parse(query); err == nil {
+		order, err := o.OrderRepository.GetOrderByID(id)
+		if errors.Is(err, repository_errors.DoesNotExist) {
+			return []models.Order{}, nil
+		} else if err != nil {
+			o.logger.Error("SERVICE: GetOrderByID method failed", "id", id, "error", err)
+			return nil, err
+		}
+
+		o.logger.Info("SERVICE: Successfully searched orders", "query", query, "found", 1)
+		return []models.Order{*order}, nil
+	}
+
+	found := make([]models.Order, 0)
+	seen := make(map[uuid.UUID]bool)
+	add := func(orders []models.Order) {
+		for _, order := range orders {
+			if !seen[order.ID] {
+				seen[order.ID] = true
+				found = append(found, order)
+			}
+		}
+	}
+
+	if match := orderReferencePattern.FindStringSubmatch(strings.ToLower(query)); match != nil {
+		byReference, err := o.OrderRepository.Filter(map[string]string{"id": match[1] + "%"}, 0, 0, false)
+		if err != nil {
+			o.logger.Error("SERVICE: Filter method failed", "reference", match[1], "error", err)
+			return nil, err
+		}
+		add(byReference)
 	}
 
-	quantity, err := o.OrderRepository.GetTaskQuantity(id, taskID)
+	byCustomer, err := o.OrderRepository.SearchByCustomerName(query)
 	if err != nil {
-		o.logger.Error("SERVICE: GetTaskQuantity method failed", "order_id", id, "task_id", taskID, "error", err)
-		return 0, err
+		o.logger.Error("SERVICE: SearchByCustomerName method failed", "substring", query, "error", err)
+		return nil, err
 	}
+	add(byCustomer)
 
-	quantity++
-	err = o.OrderRepository.UpdateTaskQuantity(id, taskID, quantity)
+	byAddress, err := o.OrderRepository.SearchByAddress(query)
 	if err != nil {
-		o.logger.Error("SERVICE: UpdateTaskQuantity method failed", "order_id", id, "task_id", taskID, "error", err)
-		return 0, err
+		o.logger.Error("SERVICE: SearchByAddress method failed", "substring", query, "error", err)
+		return nil, err
 	}
+	add(byAddress)
 
-	o.logger.Info("SERVICE: Successfully incremented task quantity", "order_id", id, "task_id", taskID, "quantity", quantity)
-	return quantity, nil
+	o.logger.Info("SERVICE: Successfully searched orders", "query", query, "found", len(found))
+	return found, nil
 }
 
-// DecrementTaskQuantity decreases the quantity of a specific task in an order by one.
+// BuildReceipt builds the price breakdown of an order from the unit prices stored
+// when its tasks were added, so later changes of task prices and volume tiers do
+// not affect it. Stored prices are never changed: in tax-inclusive mode each line
+// item is shown with tax added, otherwise tax is shown as a separate amount on top
+// of the subtotal.
+// The grand total is the same in both modes. Unit prices, line totals and the tax
+// are rounded according to the configured rounding mode, and the totals are sums
+// of the rounded amounts.
 //
 // Parameters:
-//   - id: UUID of the order
-//   - taskID: UUID of the task
+//   - orderID: UUID of the order
 //
 // Returns:
-//   - int: Updated quantity value after decrement
-//   - error: Any validation or persistence errors
-func (o OrderService) DecrementTaskQuantity(id uuid.UUID, taskID uuid.UUID) (int, error) {
-	_, err := o.OrderRepository.GetOrderByID(id)
+//   - *models.Receipt: Price breakdown of the order
+//   - error: Any retrieval errors
+func (o OrderService) BuildReceipt(orderID uuid.UUID) (*models.Receipt, error) {
+	tasks, err := o.OrderRepository.GetOrderedTasks(orderID)
 	if err != nil {
-		o.logger.Error("SERVICE: GetOrderByID method failed", "id", id, "error", err)
-		return 0, err
+		o.logger.Error("SERVICE: GetOrderedTasks method failed", "order_id", orderID, "error", err)
+		return nil, err
 	}
 
-	_, err = o.TaskRepository.GetTaskByID(taskID)
-	if err != nil {
-		o.logger.Error("SERVICE: GetTaskByID method failed", "id", taskID, "error", err)
-		return 0, err
+	lineMultiplier := 1.0
+	if o.tax.Inclusive {
+		lineMultiplier += o.tax.Rate
 	}
 
-	quantity, err := o.OrderRepository.GetTaskQuantity(id, taskID)
-	if err != nil {
-		o.logger.Error("SERVICE: GetTaskQuantity method failed", "order_id", id, "task_id", taskID, "error", err)
-		return 0, err
+	receipt := &models.Receipt{
+		OrderID:     orderID,
+		Lines:       make([]models.ReceiptLine, 0, len(tasks)),
+		TaxIncluded: o.tax.Inclusive,
 	}
 
-	if quantity == 0 {
-		o.logger.Error("SERVICE: Quantity is already 0", "order_id", id, "task_id", taskID)
-		return 0, fmt.Errorf("SERVICE: Quantity is already 0")
+	var net float64
+	for _, task := range tasks {
+		quantity := task.Quantity
+		price := task.Task.PricePerSingle
+		unitPrice := o.rounding.Round(price * lineMultiplier)
+		lineTotal := o.rounding.Round(unitPrice * float64(quantity))
+		receipt.Lines = append(receipt.Lines, models.ReceiptLine{
+			TaskName:  task.Task.Name,
+			Quantity:  quantity,
+			UnitPrice: unitPrice,
+			Total:     lineTotal,
+		})
+		receipt.Subtotal = o.rounding.Round(receipt.Subtotal + lineTotal)
+		net += price * float64(quantity)
 	}
 
-	quantity--
-	err = o.OrderRepository.UpdateTaskQuantity(id, taskID, quantity)
-	if err != nil {
-		o.logger.Error("SERVICE: UpdateTaskQuantity method failed", "order_id", id, "task_id", taskID, "error", err)
-		return 0, err
+	receipt.Tax = o.rounding.Round(net * o.tax.Rate)
+	if o.tax.Inclusive {
+		receipt.GrandTotal = receipt.Subtotal
+	} else {
+		receipt.GrandTotal = o.rounding.Round(receipt.Subtotal + receipt.Tax)
 	}
 
-	o.logger.Info("SERVICE: Successfully decremented task quantity", "order_id", id, "task_id", taskID, "quantity", quantity)
-	return quantity, nil
+	o.logger.Info("SERVICE: Successfully built receipt", "order_id", orderID, "grand_total", receipt.GrandTotal)
+	return receipt, nil
 }
 
-// SetTaskQuantity updates the quantity of a specific task in an order.
+// AddTag flags an order with a tag for internal triage, e.g. "urgent" or "vip".
+// The tag is trimmed and lowercased, so adding a tag the order already has,
+// in any case, changes nothing.
 //
 // Parameters:
-//   - id: UUID of the order
-//   - taskID: UUID of the task
-//   - quantity: New quantity value
+//   - orderID: UUID of the order
+//   - tag: Tag to attach, at most maxTagLength characters
 //
 // Returns:
-//   - error: Any validation or persistence errors
-func (o OrderService) SetTaskQuantity(id uuid.UUID, taskID uuid.UUID, quantity int) error {
-	if quantity < 0 {
-		o.logger.Error("SERVICE: Quantity is negative", "order_id", id, "task_id", taskID, "quantity", quantity)
-		return fmt.Errorf("SERVICE: Quantity is negative")
+//   - error: service_errors.InvalidTag for an empty or too long tag,
+//     retrieval or persistence error otherwise
+func (o OrderService) AddTag(orderID uuid.UUID, tag string) error {
+	normalized, ok := normalizeTag(tag)
+	if !ok {
+		o.logger.Error("SERVICE: Invalid tag", "order_id", orderID, "tag", tag)
+		return service_errors.InvalidTag
 	}
 
-	_, err := o.OrderRepository.GetOrderByID(id)
+	_, err := o.OrderRepository.GetOrderByID(orderID)
 	if err != nil {
-		o.logger.Error("SERVICE: GetOrderByID method failed", "id", id, "error", err)
+		o.logger.Error("SERVICE: GetOrderByID method failed", "id", orderID, "error", err)
 		return err
 	}
 
-	_, err = o.TaskRepository.GetTaskByID(taskID)
+	err = o.OrderRepository.AddTag(orderID, normalized)
 	if err != nil {
-		o.logger.Error("SERVICE: GetTaskByID method failed", "id", taskID, "error", err)
+		o.logger.Error("SERVICE: AddTag method failed", "order_id", orderID, "tag", normalized, "error", err)
 		return err
 	}
 
-	err = o.OrderRepository.UpdateTaskQuantity(id, taskID, quantity)
+	o.logger.Info("SERVICE: Successfully tagged order", "order_id", orderID, "tag", normalized)
+	return nil
+}
+
+// RemoveTag removes a tag from an order. The tag is normalized the same way as in AddTag.
+//
+// Parameters:
+//   - orderID: UUID of the order
+//   - tag: Tag to detach
+//
+// Returns:
+//   - error: service_errors.InvalidTag for an empty or too long tag,
+//     repository_errors.DoesNotExist if the order does not have the tag, or a persistence error
+func (o OrderService) RemoveTag(orderID uuid.UUID, tag string) error {
+	normalized, ok := normalizeTag(tag)
+	if !ok {
+		o.logger.Error("SERVICE: Invalid tag", "order_id", orderID, "tag", tag)
+		return service_errors.InvalidTag
+	}
+
+	err := o.OrderRepository.RemoveTag(orderID, normalized)
 	if err != nil {
-		o.logger.Error("SERVICE: UpdateTaskQuantity method failed", "order_id", id, "task_id", taskID, "error", err)
+		o.logger.Error("SERVICE: RemoveTag method failed", "order_id", orderID, "tag", normalized, "error", err)
 		return err
 	}
 
-	o.logger.Info("SERVICE: Successfully set task quantity", "order_id", id, "task_id", taskID, "quantity", quantity)
+	o.logger.Info("SERVICE: Successfully removed tag from order", "order_id", orderID, "tag", normalized)
 	return nil
 }
 
-// GetTaskQuantity retrieves the quantity of a specific task in an order.
+// GetTags retrieves the tags of an order.
 //
 // Parameters:
 //   - orderID: UUID of the order
-//   - taskID: UUID of the task
 //
 // Returns:
-//   - int: Quantity of the task in the order
-//   - error: Any validation or retrieval errors
-func (o OrderService) GetTaskQuantity(orderID uuid.UUID, taskID uuid.UUID) (int, error) {
+//   - []string: Tags of the order in alphabetical order
+//   - error: Retrieval error if the order does not exist or the tags cannot be read
+func (o OrderService) GetTags(orderID uuid.UUID) ([]string, error) {
 	_, err := o.OrderRepository.GetOrderByID(orderID)
 	if err != nil {
 		o.logger.Error("SERVICE: GetOrderByID method failed", "id", orderID, "error", err)
-		return 0, err
+		return nil, err
 	}
 
-	_, err = o.TaskRepository.GetTaskByID(taskID)
+	tags, err := o.OrderRepository.GetTags(orderID)
 	if err != nil {
-		o.logger.Error("SERVICE: GetTaskByID method failed", "id", taskID, "error", err)
-		return 0, err
+		o.logger.Error("SERVICE: GetTags method failed", "order_id", orderID, "error", err)
+		return nil, err
 	}
 
-	quantity, err := o.OrderRepository.GetTaskQuantity(orderID, taskID)
+	o.logger.Info("SERVICE: Successfully got tags of order", "order_id", orderID, "tags", tags)
+	return tags, nil
+}
+
+// GetOrdersByTag retrieves orders flagged with a tag. The tag is normalized the
+// same way as in AddTag.
+//
+// Parameters:
+//   - tag: Tag to filter by
+//
+// Returns:
+//   - []models.Order: Slice of tagged orders, newest first
+//   - error: service_errors.InvalidTag for an empty or too long tag, retrieval error otherwise
+func (o OrderService) GetOrdersByTag(tag string) ([]models.Order, error) {
+	normalized, ok := normalizeTag(tag)
+	if !ok {
+		o.logger.Error("SERVICE: Invalid tag", "tag", tag)
+		return nil, service_errors.InvalidTag
+	}
+
+	orders, err := o.OrderRepository.GetOrdersByTag(normalized)
 	if err != nil {
-		o.logger.Error("SERVICE: GetTaskQuantity method failed", "order_id", orderID, "task_id", taskID, "error", err)
-		return 0, err
+		o.logger.Error("SERVICE: GetOrdersByTag method failed", "tag", normalized, "error", err)
+		return nil, err
 	}
 
-	o.logger.Info("SERVICE: Successfully got task quantity", "order_id", orderID, "task_id", taskID, "quantity", quantity)
-	return quantity, nil
+	o.logger.Info("SERVICE: Successfully got orders by tag", "tag", normalized, "found", len(orders))
+	return orders, nil
 }
 
-// GetTotalPrice calculates the total price for an order based on task prices and quantities.
+// SaveDraft stores the order a customer is composing so it can be resumed in a
+// later session. The previous draft of the customer is replaced.
 //
 // Parameters:
-//   - orderID: UUID of the order to calculate price for
+//   - userID: UUID of the customer
+//   - address: Address entered so far, may be empty
+//   - tasks: Selected tasks with their quantities
 //
 // Returns:
-//   - float64: Total price for the order
-//   - error: Any calculation or retrieval errors
-func (o OrderService) GetTotalPrice(orderID uuid.UUID) (float64, error) {
-	orders, err := o.OrderRepository.GetTasksInOrder(orderID)
+//   - error: Any validation or persistence errors
+func (o OrderService) SaveDraft(userID uuid.UUID, address string, tasks []models.OrderedTask) error {
+	if _, err := o.checkTasksExistence(tasks); err != nil {
+		o.logger.Error("SERVICE: CheckTasksExistence method failed", "tasks", tasks, "error", err)
+		return err
+	}
+
+	_, err := o.UserRepository.GetUserByID(userID)
+	if errors.Is(err, repository_errors.DoesNotExist) {
+		o.logger.Error("SERVICE: User does not exist", "id", userID)
+		return fmt.Errorf("SERVICE: User does not exist")
+	} else if err != nil {
+		o.logger.Error("SERVICE: GetUserByID method failed", "id", userID, "error", err)
+		return err
+	}
+
+	err = o.OrderRepository.SaveDraft(&models.DraftOrder{UserID: userID, Address: address, Tasks: tasks})
 	if err != nil {
-		o.logger.Error("SERVICE: GetTasksInOrder method failed", "order_id", orderID, "error", err)
-		return 0, err
+		o.logger.Error("SERVICE: SaveDraft method failed", "user_id", userID, "error", err)
+		return err
 	}
 
-	var sum float64 = 0
-	for _, task := range orders {
-		var quantity int
-		quantity, err = o.OrderRepository.GetTaskQuantity(orderID, task.ID)
-		if err != nil {
-			o.logger.Error("SERVICE: GetTaskQuantity method failed", "order_id", orderID, "task_id", task.ID, "error", err)
-			return 0, err
-		}
-		sum += task.PricePerSingle * float64(quantity)
+	o.logger.Info("SERVICE: Successfully saved draft order", "user_id", userID)
+	return nil
+}
+
+// GetDraft retrieves the draft order a customer left unfinished.
+//
+// Parameters:
+//   - userID: UUID of the customer
+//
+// Returns:
+//   - *models.DraftOrder: Stored draft order
+//   - error: repository_errors.DoesNotExist if there is no draft, or a persistence error
+func (o OrderService) GetDraft(userID uuid.UUID) (*models.DraftOrder, error) {
+	draft, err := o.OrderRepository.GetDraft(userID)
+	if err != nil {
+		o.logger.Error("SERVICE: GetDraft method failed", "user_id", userID, "error", err)
+		return nil, err
 	}
 
-	o.logger.Info("SERVICE: Successfully got total price", "order_id", orderID, "total_price", sum)
-	return sum, nil
+	o.logger.Info("SERVICE: Successfully got draft order", "user_id", userID)
+	return draft, nil
+}
+
+// GetDeadlineDayOfWeekCounts builds a histogram of demand by weekday: orders that
+// are not cancelled and whose deadline falls within the given period are grouped
+// by the weekday of their deadline.
+//
+// Parameters:
+//   - from: Start of the deadline period (inclusive)
+//   - to: End of the deadline period (inclusive)
+//
+// Returns:
+//   - map[time.Weekday]int: Number of orders per weekday, weekdays without orders are omitted
+//   - error: Validation error if the period is invalid, repository error otherwise
+func (o OrderService) GetDeadlineDayOfWeekCounts(from time.Time, to time.Time) (map[time.Weekday]int, error) {
+	if to.Before(from) {
+		o.logger.Error("SERVICE: Invalid input", "from", from, "to", to)
+		return nil, fmt.Errorf("SERVICE: Invalid input")
+	}
+
+	counts, err := o.OrderRepository.GetDeadlineDayOfWeekCounts(from, to)
+	if err != nil {
+		o.logger.Error("SERVICE: GetDeadlineDayOfWeekCounts method failed", "from", from, "to", to, "error", err)
+		return nil, err
+	}
+
+	o.logger.Info("SERVICE: Successfully got deadline weekday counts", "from", from, "to", to, "counts", counts)
+	return counts, nil
+}
+
+// RecomputeOpenOrderTotals refreshes the quoted totals of all new orders using
+// current task prices, for example after a broad price change. The totals are
+// rounded with the configured rounding mode, like quotes of new orders. Orders
+// that are in progress, completed or cancelled keep the totals quoted to the
+// customer, and deleted orders are left alone.
+//
+// Returns:
+//   - int: Number of updated orders
+//   - error: Any persistence errors
+func (o OrderService) RecomputeOpenOrderTotals() (int, error) {
+	updated, err := o.OrderRepository.RecomputeNewOrderTotals(o.rounding)
+	if err != nil {
+		o.logger.Error("SERVICE: RecomputeNewOrderTotals method failed", "error", err)
+		return 0, err
+	}
+
+	o.logger.Info("SERVICE: Successfully recomputed quoted totals of new orders", "updated", updated)
+	return updated, nil
 }
@@ -42,10 +42,25 @@ var (
 	// (e.g., empty, unreachable, or outside of service area).
 	InvalidAddressOrder = errors.New("invalid address of the order")
 
+	// InvalidTag indicates that an order tag is empty or longer than allowed
+	// after trimming spaces.
+	InvalidTag = errors.New("invalid tag")
+
 	// InvalidDeadlineOrder indicates that an order's deadline is not acceptable
 	// (e.g., in the past, too soon to be fulfilled, or too far in the future).
 	InvalidDeadlineOrder = errors.New("invalid deadline of the order")
 
+	// DeadlineInPast is wrapped by InvalidDeadlineOrder when the deadline has already passed.
+	DeadlineInPast = errors.New("deadline is in the past")
+
+	// DeadlineTooSoon is wrapped by InvalidDeadlineOrder when the deadline leaves
+	// less than the minimum lead time to fulfil the order.
+	DeadlineTooSoon = errors.New("deadline is too soon")
+
+	// DeadlineTooFar is wrapped by InvalidDeadlineOrder when the deadline is
+	// beyond the maximum horizon.
+	DeadlineTooFar = errors.New("deadline is too far in the future")
+
 	// EmptyTasksOrder indicates an attempt to create or process an order with no tasks.
 	EmptyTasksOrder = errors.New("order has no tasks")
 
@@ -69,6 +84,10 @@ var (
 	// already been marked as completed.
 	OrderIsAlreadyCompleted = errors.New("order is already completed")
 
+	// OrderIsCancelled indicates an attempt to modify an order that has
+	// already been cancelled.
+	OrderIsCancelled = errors.New("order is cancelled")
+
 	// RatingOutOfRange indicates that a rating value is outside the acceptable range
 	// (e.g., not between 0-5 stars).
 	RatingOutOfRange = errors.New("rating is out of range")
@@ -88,4 +107,66 @@ var (
 	// NegativeQuantity indicates an attempt to set a negative quantity value
 	// for a task in an order or other quantity field.
 	NegativeQuantity = errors.New("quantity is negative")
+
+	// WorkerAtCapacity indicates an attempt to assign an order to a master who
+	// already holds the maximum allowed number of active orders.
+	WorkerAtCapacity = errors.New("worker has reached the maximum number of active orders")
+
+	// WorkerHasActiveOrders indicates an attempt to delete a worker who still has
+	// new or in-progress orders assigned without forcing the deletion.
+	WorkerHasActiveOrders = errors.New("worker has active orders")
+
+	// CategoryHasTasks indicates an attempt to delete a category that still has
+	// tasks without forcing the deletion.
+	CategoryHasTasks = errors.New("category still has tasks")
+
+	// TasksInOpenOrders indicates an attempt to archive tasks that are still part
+	// of new or in-progress orders without forcing the operation.
+	TasksInOpenOrders = errors.New("tasks are referenced by open orders")
+
+	// TasksWithoutQuantity indicates an attempt to complete an order that has
+	// attached tasks with a quantity below one.
+	TasksWithoutQuantity = errors.New("order has tasks without quantity")
+
+	// InvalidSecondFactorCode indicates that the second factor code entered
+	// during login is empty or was rejected.
+	InvalidSecondFactorCode = errors.New("invalid second factor code")
+
+	// InProgressWithoutWorker indicates an attempt to move an order to the
+	// in-progress status, or keep it there, without an assigned worker.
+	InProgressWithoutWorker = errors.New("order cannot be in progress without an assigned worker")
+
+	// PermissionDenied indicates an attempt to view or change data the acting
+	// worker is not allowed to access.
+	PermissionDenied = errors.New("permission denied")
+
+	// LastManager indicates an attempt to change the role of the only remaining
+	// manager, which would leave nobody able to perform administrative tasks.
+	LastManager = errors.New("cannot change the role of the last remaining manager")
+
+	// NonPositiveTotal indicates an attempt to complete an order whose computed
+	// total is zero or negative, so there is nothing to be paid for.
+	NonPositiveTotal = errors.New("order total must be positive")
+
+	// NoMasters indicates that an order cannot be assigned automatically because
+	// there are no workers with the master role.
+	NoMasters = errors.New("there are no masters")
+
+	// LoginLocked indicates that logging in with the given email is temporarily
+	// blocked after too many failed attempts.
+	LoginLocked = errors.New("too many failed login attempts, try again later")
+
+	// InvalidSortField indicates a request to sort by a field that is not on
+	// the list of sortable fields.
+	InvalidSortField = errors.New("invalid sort field")
+
+	// InvalidMultiplier indicates a price multiplier that is not a positive number.
+	InvalidMultiplier = errors.New("invalid price multiplier")
+
+	// WorkerUnavailable indicates that a worker is marked as unavailable at the
+	// time an order is due.
+	WorkerUnavailable = errors.New("worker is unavailable at the order deadline")
+
+	// InvalidPeriod indicates a time period whose start is not before its end.
+	InvalidPeriod = errors.New("period must start before it ends")
 )
@@ -56,13 +56,25 @@ type ICategoryService interface {
 	//   - error: Error if update fails or validation fails
 	Update(category *models.Category) (*models.Category, error)
 
-	// Delete removes a category by its ID.
-	// Note: This may fail if there are tasks still associated with the category.
+	// Delete removes a category by its ID. A category that still has tasks is
+	// only deleted when forced, and its tasks are moved to the uncategorized category.
 	//
 	// Parameters:
 	//   - id: Numeric ID of the category to delete
+	//   - force: Whether to move the tasks of a non-empty category and delete it anyway
 	//
 	// Returns:
-	//   - error: Error if deletion fails
-	Delete(id int) error
+	//   - error: Error if the category has tasks and force is not set, or deletion fails
+	Delete(id int, force bool) error
+
+	// SearchByName retrieves categories whose names contain the given substring,
+	// ignoring case. Empty input is rejected.
+	//
+	// Parameters:
+	//   - substring: Part of the category name to search for
+	//
+	// Returns:
+	//   - []models.Category: Slice of matching categories
+	//   - error: Error if the substring is empty or retrieval fails
+	SearchByName(substring string) ([]models.Category, error)
 }
@@ -14,6 +14,8 @@ import (
 // including task assignment, pricing calculations, and order status management.
 type IOrderService interface {
 	// CreateOrder creates a new cleaning service order for a customer.
+	// Managers are notified in the background that the order awaits assignment,
+	// and the customer's draft order is cleared.
 	//
 	// Parameters:
 	//   - userID: UUID of the customer placing the order
@@ -22,19 +24,31 @@ type IOrderService interface {
 	//   - orderedTasks: Slice of tasks with their quantities to be included in the order
 	//
 	// Returns:
-	//   - *models.Order: Created order with assigned ID and initial status
+	//   - *models.OrderWithTasks: Created order with assigned ID and initial status,
+	//     together with the ordered tasks and their quantities
 	//   - error: Error if creation fails or validation fails
-	CreateOrder(userID uuid.UUID, address string, deadline time.Time, orderedTasks []models.OrderedTask) (*models.Order, error)
+	CreateOrder(userID uuid.UUID, address string, deadline time.Time, orderedTasks []models.OrderedTask) (*models.OrderWithTasks, error)
 
-	// DeleteOrder removes an order and its associated task relationships.
+	// DeleteOrder removes an order. The order is archived with its tasks
+	// and can be brought back with RestoreOrder.
 	//
 	// Parameters:
 	//   - id: UUID of the order to delete
 	//
 	// Returns:
-	//   - error: Error if deletion fails
+	//   - error: repository_errors.DoesNotExist if the order does not exist or is
+	//     already deleted, other error if deletion fails
 	DeleteOrder(id uuid.UUID) error
 
+	// RestoreOrder brings back a deleted order together with its tasks.
+	//
+	// Parameters:
+	//   - id: UUID of the deleted order
+	//
+	// Returns:
+	//   - error: Error if no deleted order is found or the update fails
+	RestoreOrder(id uuid.UUID) error
+
 	// GetTasksInOrder retrieves all cleaning tasks associated with a specific order.
 	//
 	// Parameters:
@@ -45,6 +59,44 @@ type IOrderService interface {
 	//   - error: Error if retrieval fails
 	GetTasksInOrder(orderID uuid.UUID) ([]models.Task, error)
 
+	// GetDispatchInfo retrieves the details a master needs to carry out an order:
+	// its address, deadline and tasks with their quantities.
+	//
+	// Parameters:
+	//   - orderID: UUID of the order
+	//
+	// Returns:
+	//   - *models.DispatchInfo: Details of the order
+	//   - error: Error if retrieval fails
+	GetDispatchInfo(orderID uuid.UUID) (*models.DispatchInfo, error)
+
+	// AssignWorker assigns a master to an order and moves a new order to in progress.
+	// When the worker changes, the new worker is sent the details of the order.
+	// Only a manager can assign workers.
+	//
+	// Parameters:
+	//   - editor: Worker performing the operation
+	//   - orderID: UUID of the order
+	//   - workerID: UUID of the master to assign
+	//
+	// Returns:
+	//   - error: service_errors.InvalidRole if the editor is not a manager or the worker
+	//     is not a master, error if the order is completed or cancelled, validation
+	//     or persistence fails
+	AssignWorker(editor *models.Worker, orderID uuid.UUID, workerID uuid.UUID) error
+
+	// UnassignWorker removes the assigned master from an order and moves an order
+	// in progress back to new. Only a manager can unassign workers.
+	//
+	// Parameters:
+	//   - editor: Worker performing the operation
+	//   - orderID: UUID of the order
+	//
+	// Returns:
+	//   - error: service_errors.InvalidRole if the editor is not a manager, error if
+	//     the order is completed or cancelled, validation or persistence fails
+	UnassignWorker(editor *models.Worker, orderID uuid.UUID) error
+
 	// GetOrderByID retrieves an order by its unique identifier.
 	//
 	// Parameters:
@@ -55,7 +107,9 @@ type IOrderService interface {
 	//   - error: Error if retrieval fails or order not found
 	GetOrderByID(id uuid.UUID) (*models.Order, error)
 
-	// GetCurrentOrderByUserID retrieves the most recent order for a specific user.
+	// GetCurrentOrderByUserID retrieves the current order of a specific user: the
+	// most recent new or in-progress order, or the most recent order of any status
+	// if none are active.
 	//
 	// Parameters:
 	//   - userID: UUID of the user to retrieve the current order for
@@ -75,9 +129,62 @@ type IOrderService interface {
 	//   - error: Error if retrieval fails
 	GetAllOrdersByUserID(userID uuid.UUID) ([]models.Order, error)
 
+	// ExportUserOrders produces a JSON snapshot of all orders of a user with
+	// their statuses, tasks and quantities.
+	//
+	// Parameters:
+	//   - userID: UUID of the user whose orders are exported
+	//
+	// Returns:
+	//   - []byte: JSON document with the user's orders
+	//   - error: Error if the user does not exist or retrieval fails
+	ExportUserOrders(userID uuid.UUID) ([]byte, error)
+
+	// ImportUserOrders recreates the orders of a snapshot produced by
+	// ExportUserOrders for a user, all at once.
+	//
+	// Parameters:
+	//   - userID: UUID of the user the orders are recreated for
+	//   - data: JSON document produced by ExportUserOrders
+	//
+	// Returns:
+	//   - int: Number of recreated orders
+	//   - error: Error if the document is invalid, refers to missing tasks or workers,
+	//     or saving fails, in which case no order is recreated
+	ImportUserOrders(userID uuid.UUID, data []byte) (int, error)
+
+	// GetAllOrdersByWorkerID retrieves all orders assigned to a specific worker.
+	//
+	// Parameters:
+	//   - workerID: UUID of the worker to retrieve orders for
+	//
+	// Returns:
+	//   - []models.Order: Slice of order entities assigned to the specified worker
+	//   - error: Error if the worker does not exist or retrieval fails
+	GetAllOrdersByWorkerID(workerID uuid.UUID) ([]models.Order, error)
+
+	// GetOrdersByUserIDPaged retrieves one page of a user's orders, newest first,
+	// together with the total number of the user's orders.
+	//
+	// Parameters:
+	//   - userID: UUID of the user to retrieve orders for
+	//   - limit: Maximum number of orders on the page, must be positive
+	//   - offset: Number of orders to skip, must not be negative
+	//
+	// Returns:
+	//   - []models.Order: Slice of order entities on the requested page
+	//   - int: Total number of orders of the user
+	//   - error: Error if validation or retrieval fails
+	GetOrdersByUserIDPaged(userID uuid.UUID, limit int, offset int) ([]models.Order, int, error)
+
 	// Update modifies an existing order's status, rating, or worker assignment.
+	// An update that changes nothing is a no-op returning the stored order.
+	// Completed and cancelled orders are terminal and can only be reopened with ReopenOrder.
+	// Only a manager can change the assigned worker, and a new worker must be
+	// available at the order deadline.
 	//
 	// Parameters:
+	//   - editor: Worker performing the operation, nil for a customer
 	//   - orderID: UUID of the order to update
 	//   - status: New status code for the order
 	//   - rate: Customer satisfaction rating (0-5)
@@ -85,10 +192,69 @@ type IOrderService interface {
 	//
 	// Returns:
 	//   - *models.Order: Updated order data
-	//   - error: Error if update fails or validation fails
-	Update(orderID uuid.UUID, status int, rate int, workerID uuid.UUID) (*models.Order, error)
+	//   - error: Error if update fails, validation fails, the order is being
+	//     completed while some of its tasks have no positive quantity or its
+	//     total is not positive (service_errors.NonPositiveTotal), or the
+	//     order would be in progress without an assigned worker,
+	//     service_errors.InvalidOrderStatus if the status transition is not allowed,
+	//     service_errors.InvalidRole if a non-manager changes the worker,
+	//     service_errors.WorkerUnavailable if the new worker is off at the deadline
+	Update(editor *models.Worker, orderID uuid.UUID, status int, rate int, workerID uuid.UUID) (*models.Order, error)
 
-	// AddTask associates a new task with an existing order.
+	// CloneOrder places a new order for the same customer with the address, tasks
+	// and quantities of an existing order, whatever its status.
+	//
+	// Parameters:
+	//   - sourceOrderID: UUID of the order to repeat
+	//   - newDeadline: Deadline of the new order
+	//
+	// Returns:
+	//   - *models.Order: Created order with a new ID and the New status
+	//   - error: Error if validation, retrieval or creation fails
+	CloneOrder(sourceOrderID uuid.UUID, newDeadline time.Time) (*models.Order, error)
+
+	// ReopenOrder returns a completed or cancelled order to work. The order becomes
+	// in progress if a worker is still assigned and new otherwise. Only a manager
+	// can reopen orders.
+	//
+	// Parameters:
+	//   - editor: Worker performing the operation
+	//   - orderID: UUID of the order to reopen
+	//
+	// Returns:
+	//   - *models.Order: Reopened order
+	//   - error: service_errors.InvalidRole if the editor is not a manager,
+	//     service_errors.InvalidOrderStatus if the order is neither completed
+	//     nor cancelled, or an error if retrieval or persistence fails
+	ReopenOrder(editor *models.Worker, orderID uuid.UUID) (*models.Order, error)
+
+	// Reschedule moves an open order to a new deadline and address. The deadline
+	// is checked against the same bounds as on creation.
+	//
+	// Parameters:
+	//   - orderID: UUID of the order to reschedule
+	//   - newDeadline: New deadline of the order
+	//   - newAddress: New address of the order
+	//
+	// Returns:
+	//   - error: service_errors.InvalidDeadlineOrder if the deadline is out of bounds,
+	//     service_errors.OrderIsAlreadyCompleted or service_errors.OrderIsCancelled
+	//     for closed orders, any other validation, retrieval or persistence errors
+	Reschedule(orderID uuid.UUID, newDeadline time.Time, newAddress string) error
+
+	// CancelOrder cancels a new or in progress order. The reason and the moment
+	// of cancellation are stored with the order.
+	//
+	// Parameters:
+	//   - orderID: UUID of the order to cancel
+	//   - reason: Why the order is cancelled, must not be blank
+	//
+	// Returns:
+	//   - error: service_errors.OrderIsAlreadyCompleted or service_errors.OrderIsCancelled
+	//     for closed orders, any other validation, retrieval or persistence errors
+	CancelOrder(orderID uuid.UUID, reason string) error
+
+	// AddTask associates a new task with an existing order with a quantity of one.
 	//
 	// Parameters:
 	//   - orderID: UUID of the order
@@ -98,6 +264,18 @@ type IOrderService interface {
 	//   - error: Error if addition fails or task is already in the order
 	AddTask(orderID uuid.UUID, tasksID uuid.UUID) error
 
+	// AddTasks associates several tasks with an existing order at once.
+	// Either all of the tasks are added or none of them.
+	//
+	// Parameters:
+	//   - orderID: UUID of the order
+	//   - orderedTasks: Tasks to add together with their quantities
+	//
+	// Returns:
+	//   - error: service_errors.TaskIsAlreadyAttachedToOrder if any of the tasks is
+	//     already in the order, or an error if validation or addition fails
+	AddTasks(orderID uuid.UUID, orderedTasks []models.OrderedTask) error
+
 	// RemoveTask removes a task association from an order.
 	//
 	// Parameters:
@@ -171,4 +349,250 @@ type IOrderService interface {
 	//   - float64: Total price of the order
 	//   - error: Error if calculation fails
 	GetTotalPrice(orderID uuid.UUID) (float64, error)
+
+	// GetOrderDetails retrieves an order together with its tasks and their
+	// quantities, the assigned worker and the total price.
+	//
+	// Parameters:
+	//   - orderID: UUID of the order
+	//
+	// Returns:
+	//   - *models.OrderDetails: Order with its tasks, worker and total price
+	//   - error: Error if the order is not found or retrieval fails
+	GetOrderDetails(orderID uuid.UUID) (*models.OrderDetails, error)
+
+	// GetOrdersByIDs retrieves all orders whose identifiers are in the given list.
+	// Identifiers without a matching order are simply absent from the result.
+	//
+	// Parameters:
+	//   - ids: Slice of order UUIDs to retrieve
+	//
+	// Returns:
+	//   - []models.Order: Slice of found order entities
+	//   - error: Error if retrieval fails
+	GetOrdersByIDs(ids []uuid.UUID) ([]models.Order, error)
+
+	// FilterOrders retrieves orders matching any of the given statuses and created
+	// within the given period, newest first. An empty status slice and zero-value
+	// dates mean that the corresponding condition is ignored.
+	//
+	// Parameters:
+	//   - statuses: Slice of status codes to match
+	//   - from: Start of the creation period (inclusive)
+	//   - to: End of the creation period (inclusive)
+	//
+	// Returns:
+	//   - []models.Order: Slice of order entities matching the filter criteria
+	//   - error: Validation error if a status or the period is invalid, retrieval error otherwise
+	FilterOrders(statuses []int, from time.Time, to time.Time) ([]models.Order, error)
+
+	// GetOrdersByStatus retrieves all orders that are in any of the given statuses,
+	// newest first.
+	//
+	// Parameters:
+	//   - statuses: Statuses to match, at least one is required
+	//
+	// Returns:
+	//   - []models.Order: Slice of order entities in the given statuses
+	//   - error: Validation error if no status is given or a status is invalid,
+	//     retrieval error otherwise
+	GetOrdersByStatus(statuses ...models.OrderStatus) ([]models.Order, error)
+
+	// GetUserOrdersByStatus retrieves the orders of a customer that are in any of
+	// the given statuses, newest first.
+	//
+	// Parameters:
+	//   - userID: UUID of the customer
+	//   - statuses: Statuses to match, at least one is required
+	//
+	// Returns:
+	//   - []models.Order: Slice of order entities in the given statuses
+	//   - error: Validation error if no status is given or a status is invalid,
+	//     retrieval error otherwise
+	GetUserOrdersByStatus(userID uuid.UUID, statuses ...models.OrderStatus) ([]models.Order, error)
+
+	// GetOverdueOrders retrieves new and in-progress orders whose deadline has
+	// already passed, the most overdue first.
+	//
+	// Returns:
+	//   - []models.Order: Slice of overdue order entities
+	//   - error: Error if retrieval fails
+	GetOverdueOrders() ([]models.Order, error)
+
+	// AutoAssignUnassigned assigns every new order without a worker to the least
+	// loaded master who still has capacity. Orders for which no master is available
+	// are left unassigned. Only a manager can run the assignment.
+	//
+	// Parameters:
+	//   - editor: Worker performing the operation
+	//
+	// Returns:
+	//   - map[uuid.UUID]uuid.UUID: Mapping of assigned order IDs to worker IDs
+	//   - error: service_errors.InvalidRole if the editor is not a manager,
+	//     error if retrieval or assignment fails
+	AutoAssignUnassigned(editor *models.Worker) (map[uuid.UUID]uuid.UUID, error)
+
+	// BuildDailyDigest aggregates order activity of the given day into a summary
+	// suitable for the daily manager email.
+	//
+	// Parameters:
+	//   - day: Any moment of the day to summarize
+	//
+	// Returns:
+	//   - models.DailyDigest: Aggregated counters for the day
+	//   - error: Error if the day is not set or retrieval fails
+	BuildDailyDigest(day time.Time) (models.DailyDigest, error)
+
+	// GetAverageOrderValue computes the mean total of orders completed within
+	// the given period, rounded according to the configured rounding mode.
+	//
+	// Parameters:
+	//   - from: Start of the period (inclusive)
+	//   - to: End of the period (inclusive)
+	//
+	// Returns:
+	//   - float64: Average order value, 0 if no orders were completed in the period
+	//   - error: Error if the period is invalid or retrieval fails
+	GetAverageOrderValue(from time.Time, to time.Time) (float64, error)
+
+	// GetRevenueSummary reports how much was billed for the orders completed
+	// within the given period. Cancelled orders are excluded.
+	//
+	// Parameters:
+	//   - from: Start of the period (inclusive)
+	//   - to: End of the period (inclusive)
+	//
+	// Returns:
+	//   - *models.RevenueSummary: Revenue, number and average value of the orders
+	//   - error: Error if the period is invalid or retrieval fails
+	GetRevenueSummary(from time.Time, to time.Time) (*models.RevenueSummary, error)
+
+	// GetOrdersByRateBelow retrieves rated completed orders whose rating is below
+	// the threshold, to help managers review the quality of the work.
+	//
+	// Parameters:
+	//   - threshold: Rating the orders must be below (1-5)
+	//
+	// Returns:
+	//   - []models.Order: Slice of matching orders, lowest rated first
+	//   - error: Error if the threshold is out of range or retrieval fails
+	GetOrdersByRateBelow(threshold int) ([]models.Order, error)
+
+	// SearchOrdersByCustomerName retrieves orders placed by customers whose name
+	// contains the given substring, ignoring case.
+	//
+	// Parameters:
+	//   - substring: Part of the customer name to search for
+	//
+	// Returns:
+	//   - []models.Order: Slice of matching order entities
+	//   - error: service_errors.InvalidName for empty input, retrieval error otherwise
+	SearchOrdersByCustomerName(substring string) ([]models.Order, error)
+
+	// Search looks up orders by a single free-form query: a full order ID, an
+	// order reference (the first eight or more hex digits of an order ID),
+	// a customer name or part of an address. Reference matches come first,
+	// then customer name matches, then address matches, newest first in each group.
+	//
+	// Parameters:
+	//   - query: Search query
+	//
+	// Returns:
+	//   - []models.Order: Matching orders, most relevant first
+	//   - error: Error if the query is empty or retrieval fails
+	Search(query string) ([]models.Order, error)
+
+	// BuildReceipt builds the price breakdown of an order. Line items include tax
+	// or tax is shown separately depending on the configured tax settings.
+	//
+	// Parameters:
+	//   - orderID: UUID of the order
+	//
+	// Returns:
+	//   - *models.Receipt: Price breakdown of the order
+	//   - error: Error if retrieval fails
+	BuildReceipt(orderID uuid.UUID) (*models.Receipt, error)
+
+	// AddTag flags an order with a tag for internal triage, e.g. "urgent" or "vip".
+	// The tag is trimmed and lowercased; adding a tag the order already has changes nothing.
+	//
+	// Parameters:
+	//   - orderID: UUID of the order
+	//   - tag: Tag to attach
+	//
+	// Returns:
+	//   - error: Error if the tag is invalid, the order does not exist or saving fails
+	AddTag(orderID uuid.UUID, tag string) error
+
+	// RemoveTag removes a tag from an order.
+	//
+	// Parameters:
+	//   - orderID: UUID of the order
+	//   - tag: Tag to detach
+	//
+	// Returns:
+	//   - error: Error if the tag is invalid, the order does not have it or deletion fails
+	RemoveTag(orderID uuid.UUID, tag string) error
+
+	// GetTags retrieves the tags of an order.
+	//
+	// Parameters:
+	//   - orderID: UUID of the order
+	//
+	// Returns:
+	//   - []string: Tags of the order in alphabetical order
+	//   - error: Error if the order does not exist or retrieval fails
+	GetTags(orderID uuid.UUID) ([]string, error)
+
+	// GetOrdersByTag retrieves orders flagged with a tag.
+	//
+	// Parameters:
+	//   - tag: Tag to filter by
+	//
+	// Returns:
+	//   - []models.Order: Slice of tagged orders
+	//   - error: Error if the tag is invalid or retrieval fails
+	GetOrdersByTag(tag string) ([]models.Order, error)
+
+	// SaveDraft stores the order a customer is composing so it can be resumed later.
+	// The previous draft of the customer is replaced.
+	//
+	// Parameters:
+	//   - userID: UUID of the customer
+	//   - address: Address entered so far, may be empty
+	//   - tasks: Selected tasks with their quantities
+	//
+	// Returns:
+	//   - error: Error if validation or saving fails
+	SaveDraft(userID uuid.UUID, address string, tasks []models.OrderedTask) error
+
+	// GetDraft retrieves the draft order a customer left unfinished.
+	//
+	// Parameters:
+	//   - userID: UUID of the customer
+	//
+	// Returns:
+	//   - *models.DraftOrder: Stored draft order
+	//   - error: Error if there is no draft or retrieval fails
+	GetDraft(userID uuid.UUID) (*models.DraftOrder, error)
+
+	// GetDeadlineDayOfWeekCounts counts orders that are not cancelled and whose
+	// deadline falls within the given period, grouped by the weekday of the deadline.
+	//
+	// Parameters:
+	//   - from: Start of the deadline period (inclusive)
+	//   - to: End of the deadline period (inclusive)
+	//
+	// Returns:
+	//   - map[time.Weekday]int: Number of orders per weekday
+	//   - error: Error if the period is invalid or retrieval fails
+	GetDeadlineDayOfWeekCounts(from time.Time, to time.Time) (map[time.Weekday]int, error)
+
+	// RecomputeOpenOrderTotals refreshes the quoted totals of all new orders using
+	// current task prices. Orders in progress, completed or cancelled keep their totals.
+	//
+	// Returns:
+	//   - int: Number of updated orders
+	//   - error: Error if update fails
+	RecomputeOpenOrderTotals() (int, error)
 }
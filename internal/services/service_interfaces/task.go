@@ -5,14 +5,17 @@ package service_interfaces
 
 import (
 	"github.com/google/uuid"
+	"io"
 	"teamdev/internal/models"
+	"time"
 )
 
 // ITaskService defines the contract for cleaning task management operations.
 // It provides methods for creating, retrieving, updating, and deleting tasks,
 // as well as organizing them by categories.
 type ITaskService interface {
-	// Create adds a new cleaning task to the system.
+	// Create adds a new cleaning task to the system. The price must have at most
+	// two decimal places unless the service is configured to round it.
 	//
 	// Parameters:
 	//   - name: Descriptive name of the cleaning task
@@ -24,7 +27,8 @@ type ITaskService interface {
 	//   - error: Error if creation fails or validation fails
 	Create(name string, price float64, category int) (*models.Task, error)
 
-	// Update modifies an existing task's properties.
+	// Update modifies an existing task's properties. The price must have at most
+	// two decimal places unless the service is configured to round it.
 	//
 	// Parameters:
 	//   - taskID: UUID of the task to update
@@ -53,6 +57,29 @@ type ITaskService interface {
 	//   - error: Error if retrieval fails
 	GetAllTasks() ([]models.Task, error)
 
+	// GetAllTasksPaged retrieves one page of available cleaning tasks sorted by
+	// name, price or category, together with the total number of available tasks.
+	//
+	// Parameters:
+	//   - limit: Maximum number of tasks on the page, must be positive
+	//   - offset: Number of tasks to skip, must not be negative
+	//   - sortBy: One of the fields in models.TaskSortFields
+	//   - asc: Whether tasks are sorted in ascending order
+	//
+	// Returns:
+	//   - []models.Task: Tasks on the requested page
+	//   - int: Total number of available tasks
+	//   - error: service_errors.InvalidSortField for an unknown sort field, or retrieval errors
+	GetAllTasksPaged(limit, offset int, sortBy string, asc bool) ([]models.Task, int, error)
+
+	// GetAllTasksWithCategoryNames retrieves all available cleaning tasks together
+	// with the names of their categories.
+	//
+	// Returns:
+	//   - []models.TaskWithCategory: Tasks with resolved category names
+	//   - error: Error if retrieval fails
+	GetAllTasksWithCategoryNames() ([]models.TaskWithCategory, error)
+
 	// GetTaskByID retrieves a task by its unique identifier.
 	//
 	// Parameters:
@@ -82,4 +109,148 @@ type ITaskService interface {
 	//   - *models.Task: Retrieved task entity
 	//   - error: Error if retrieval fails or task not found
 	GetTaskByName(name string) (*models.Task, error)
+
+	// SearchTasksByName retrieves catalog tasks whose names contain the given
+	// text, ignoring case.
+	//
+	// Parameters:
+	//   - query: Part of the task name to search for, empty to list every task
+	//
+	// Returns:
+	//   - []models.Task: Slice of matching tasks ordered by name
+	//   - error: Error if retrieval fails
+	SearchTasksByName(query string) ([]models.Task, error)
+
+	// GetTasksModifiedSince retrieves tasks created or changed at or after the
+	// given time, so that integrators can synchronize the catalog incrementally.
+	//
+	// Parameters:
+	//   - since: Earliest modification time to include
+	//
+	// Returns:
+	//   - []models.Task: Slice of modified tasks, least recently modified first,
+	//     archived tasks included
+	//   - error: Error if retrieval fails
+	GetTasksModifiedSince(since time.Time) ([]models.Task, error)
+
+	// GetUnorderedTasks retrieves all tasks that have never been included in any order.
+	//
+	// Returns:
+	//   - []models.Task: Slice of tasks not referenced by any order
+	//   - error: Error if retrieval fails
+	GetUnorderedTasks() ([]models.Task, error)
+
+	// GetMostOrderedTasks ranks tasks by the total quantity ordered across all
+	// orders that were not cancelled, most ordered first.
+	//
+	// Parameters:
+	//   - limit: Maximum number of tasks to return, must be positive
+	//
+	// Returns:
+	//   - []models.TaskPopularity: Tasks with their total quantity and number of orders
+	//   - error: Error if validation or retrieval fails
+	GetMostOrderedTasks(limit int) ([]models.TaskPopularity, error)
+
+	// GetMostOrderedTasksBetween ranks tasks by the total quantity ordered in the
+	// orders created within the given period, most ordered first.
+	//
+	// Parameters:
+	//   - from: Start of the period (inclusive)
+	//   - to: End of the period (inclusive)
+	//   - limit: Maximum number of tasks to return, must be positive
+	//
+	// Returns:
+	//   - []models.TaskPopularity: Tasks with their total quantity and number of orders
+	//   - error: Error if validation or retrieval fails
+	GetMostOrderedTasksBetween(from time.Time, to time.Time, limit int) ([]models.TaskPopularity, error)
+
+	// GetTasksInCurrency retrieves all tasks with their prices converted
+	// to the given currency.
+	//
+	// Parameters:
+	//   - code: Code of the target currency (e.g. "USD")
+	//
+	// Returns:
+	//   - []models.TaskPriced: Slice of tasks with converted prices
+	//   - error: Error if the currency is unknown or retrieval fails
+	GetTasksInCurrency(code string) ([]models.TaskPriced, error)
+
+	// ArchiveCategory archives all tasks of a category at once.
+	//
+	// Parameters:
+	//   - category: Category ID whose tasks are archived
+	//   - force: Whether to archive tasks that are part of open orders
+	//
+	// Returns:
+	//   - int: Number of archived tasks
+	//   - error: Error if the category is invalid, open orders refer to the tasks
+	//     and force is not set, or archiving fails
+	ArchiveCategory(category int, force bool) (int, error)
+
+	// AdjustCategoryPrices multiplies the prices of all tasks of a category by
+	// the multiplier, for example 1.1 for a ten percent surcharge.
+	//
+	// Parameters:
+	//   - category: Category ID whose prices are adjusted
+	//   - multiplier: Positive factor applied to the prices
+	//
+	// Returns:
+	//   - int: Number of tasks whose price was adjusted
+	//   - error: Error if the multiplier or category is invalid or the update fails
+	AdjustCategoryPrices(category int, multiplier float64) (int, error)
+
+	// UpsertByName imports a list of tasks: new tasks are created and the price
+	// and category of tasks with the same name (ignoring case) are updated.
+	//
+	// Parameters:
+	//   - tasks: Tasks to import
+	//
+	// Returns:
+	//   - created: Number of created tasks
+	//   - updated: Number of updated tasks
+	//   - err: Error if any of the tasks is invalid or the import fails
+	UpsertByName(tasks []models.Task) (created int, updated int, err error)
+
+	// GetPriceTiers retrieves the volume discounts of a task.
+	//
+	// Parameters:
+	//   - taskID: UUID of the task
+	//
+	// Returns:
+	//   - []models.PriceTier: Price tiers of the task, smallest minimum quantity first
+	//   - error: Error if the task does not exist or retrieval fails
+	GetPriceTiers(taskID uuid.UUID) ([]models.PriceTier, error)
+
+	// SetPriceTiers replaces the volume discounts of a task.
+	//
+	// Parameters:
+	//   - taskID: UUID of the task
+	//   - tiers: New price tiers, empty to remove all discounts
+	//
+	// Returns:
+	//   - error: Error if the task does not exist, a tier is invalid or saving fails
+	SetPriceTiers(taskID uuid.UUID, tiers []models.PriceTier) error
+
+	// ExportCatalog writes all tasks of the catalog as a JSON array of their
+	// names, prices and categories.
+	//
+	// Parameters:
+	//   - w: Destination of the JSON document
+	//
+	// Returns:
+	//   - error: Error if retrieval or writing fails
+	ExportCatalog(w io.Writer) error
+
+	// ImportCatalog reads a catalog produced by ExportCatalog in one transaction.
+	// Tasks with a name that is not in the catalog yet are created, existing ones
+	// are updated when overwrite is set and skipped otherwise.
+	//
+	// Parameters:
+	//   - r: Source of the JSON document
+	//   - overwrite: Whether existing tasks with the same name are updated
+	//
+	// Returns:
+	//   - models.CatalogImportSummary: Numbers of created, updated and skipped tasks
+	//   - error: Error if the document or any of its entries is invalid or the import fails
+	ImportCatalog(r io.Reader, overwrite bool) (models.CatalogImportSummary, error)
 }
@@ -43,7 +43,20 @@ type IUserService interface {
 	//   - error: Error if authentication fails or credentials are invalid
 	Login(email, password string) (*models.User, error)
 
+	// LoginWithToken authenticates a user like Login and issues a session token for them.
+	//
+	// Parameters:
+	//   - email: User's email address
+	//   - password: Plain text password to validate
+	//
+	// Returns:
+	//   - *models.User: Authenticated user data
+	//   - string: Session token, empty if the service issues no tokens
+	//   - error: Error if authentication or token generation fails
+	LoginWithToken(email, password string) (*models.User, string, error)
+
 	// Update modifies an existing user's profile information.
+	// The stored password is left unchanged, use ChangePassword to replace it.
 	//
 	// Parameters:
 	//   - id: UUID of the user to update
@@ -52,12 +65,23 @@ type IUserService interface {
 	//   - email: New email address
 	//   - address: New physical address
 	//   - phoneNumber: New contact phone number
-	//   - password: New password (will be hashed before storage)
 	//
 	// Returns:
 	//   - *models.User: Updated user data
 	//   - error: Error if update fails or validation fails
-	Update(id uuid.UUID, name string, surname string, email string, address string, phoneNumber string, password string) (*models.User, error)
+	Update(id uuid.UUID, name string, surname string, email string, address string, phoneNumber string) (*models.User, error)
+
+	// ChangePassword replaces a user's password after verifying the current one.
+	//
+	// Parameters:
+	//   - id: UUID of the user
+	//   - oldPassword: Current plain text password
+	//   - newPassword: New plain text password (will be hashed before storage)
+	//
+	// Returns:
+	//   - error: service_errors.MismatchedPassword if the current password is wrong,
+	//     error if the user is not found, the new password is invalid or update fails
+	ChangePassword(id uuid.UUID, oldPassword string, newPassword string) error
 
 	// GetUserByEmail retrieves a user by their email address.
 	//
@@ -68,4 +92,31 @@ type IUserService interface {
 	//   - *models.User: Retrieved user entity
 	//   - error: Error if retrieval fails or user not found
 	GetUserByEmail(email string) (*models.User, error)
+
+	// GetCustomersWithCompletedOrders retrieves users who have at least one completed order.
+	// Passwords are never included in the result.
+	//
+	// Returns:
+	//   - []models.User: Slice of users with completed orders
+	//   - error: Error if retrieval fails
+	GetCustomersWithCompletedOrders() ([]models.User, error)
+
+	// GetAllUsers retrieves all clients as password-free views.
+	//
+	// Returns:
+	//   - []models.UserPublic: Slice of all clients ordered by surname and name
+	//   - error: Error if retrieval fails
+	GetAllUsers() ([]models.UserPublic, error)
+
+	// GetOrderStatistics summarizes the orders of a user for their profile:
+	// the number of orders, completed and cancelled ones, the total spent on
+	// completed orders and the average rating the user has given.
+	//
+	// Parameters:
+	//   - userID: UUID of the user
+	//
+	// Returns:
+	//   - *models.UserStats: Order statistics, zero for a user without orders
+	//   - error: Error if the user is not found or retrieval fails
+	GetOrderStatistics(userID uuid.UUID) (*models.UserStats, error)
 }
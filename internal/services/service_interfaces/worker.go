@@ -6,12 +6,15 @@ package service_interfaces
 import (
 	"github.com/google/uuid"
 	"teamdev/internal/models"
+	"time"
 )
 
 // IWorkerService defines the contract for worker management operations.
 // It provides methods for worker authentication, profile management, and performance metrics.
 type IWorkerService interface {
 	// Login authenticates a worker with email and password credentials.
+	// A successful login updates the worker's last login time. When a second factor
+	// is required, the login is completed later by VerifySecondFactor.
 	//
 	// Parameters:
 	//   - email: Worker's email address
@@ -19,38 +22,99 @@ type IWorkerService interface {
 	//
 	// Returns:
 	//   - *models.Worker: Authenticated worker data
+	//   - bool: true if a second factor must be verified to finish the login
 	//   - error: Error if authentication fails or credentials are invalid
-	Login(email, password string) (*models.Worker, error)
+	Login(email, password string) (*models.Worker, bool, error)
+
+	// VerifySecondFactor checks a second factor code after a successful password
+	// check and completes the worker's login.
+	//
+	// Parameters:
+	//   - workerID: UUID of the worker finishing the login
+	//   - code: Second factor code entered by the worker
+	//
+	// Returns:
+	//   - error: Error if the code is invalid or verification fails
+	VerifySecondFactor(workerID uuid.UUID, code string) error
+
+	// LoginWithToken authenticates a worker like Login and issues a session token
+	// once the login is complete. When a second factor is required no token is
+	// issued yet, it is returned by VerifySecondFactorWithToken instead.
+	//
+	// Parameters:
+	//   - email: Worker's email address
+	//   - password: Plain text password to validate
+	//
+	// Returns:
+	//   - *models.Worker: Authenticated worker data
+	//   - bool: true if a second factor must be verified to finish the login
+	//   - string: Session token, empty if the login is not complete or the service issues no tokens
+	//   - error: Error if authentication or token generation fails
+	LoginWithToken(email, password string) (*models.Worker, bool, string, error)
+
+	// VerifySecondFactorWithToken completes the login like VerifySecondFactor and
+	// issues a session token for the worker.
+	//
+	// Parameters:
+	//   - workerID: UUID of the worker finishing the login
+	//   - code: Second factor code entered by the worker
+	//
+	// Returns:
+	//   - string: Session token, empty if the service issues no tokens
+	//   - error: Error if the code is invalid, verification or token generation fails
+	VerifySecondFactorWithToken(workerID uuid.UUID, code string) (string, error)
 
 	// Create registers a new worker account in the system with the specified credentials.
+	// Only a manager can create workers; the first manager is created without an editor.
 	//
 	// Parameters:
+	//   - editor: Worker performing the operation, nil only when bootstrapping the first manager
 	//   - worker: Worker information including name, contact details, role, etc.
 	//   - password: Plain text password that will be hashed before storage
 	//
 	// Returns:
 	//   - *models.Worker: Created worker with assigned ID
-	//   - error: Error if registration fails or validation fails
-	Create(worker *models.Worker, password string) (*models.Worker, error)
+	//   - error: service_errors.InvalidRole if the editor is not allowed to create workers,
+	//     error if registration fails or validation fails
+	Create(editor *models.Worker, worker *models.Worker, password string) (*models.Worker, error)
 
-	// Delete removes a worker account from the system.
+	// Delete removes a worker account from the system. A worker with active orders
+	// is only deleted when forced, in which case the orders are unassigned.
+	// Only a manager can delete workers.
 	//
 	// Parameters:
+	//   - editor: Worker performing the operation
 	//   - id: UUID of the worker to delete
+	//   - force: Whether to unassign active orders instead of refusing the deletion
 	//
 	// Returns:
-	//   - error: Error if deletion fails
-	Delete(id uuid.UUID) error
+	//   - error: service_errors.InvalidRole if the editor is not a manager,
+	//     error if deletion fails or the worker has active orders and force is not set
+	Delete(editor *models.Worker, id uuid.UUID, force bool) error
 
-	// GetWorkerByID retrieves a worker by their unique identifier.
+	// GetWorkerByID retrieves a worker by their unique identifier. Only managers
+	// may retrieve profiles of other workers.
 	//
 	// Parameters:
+	//   - editor: Worker performing the operation
 	//   - id: UUID of the worker to retrieve
 	//
 	// Returns:
 	//   - *models.Worker: Retrieved worker entity
-	//   - error: Error if retrieval fails or worker not found
-	GetWorkerByID(id uuid.UUID) (*models.Worker, error)
+	//   - error: Error if access is denied, retrieval fails or worker not found
+	GetWorkerByID(editor *models.Worker, id uuid.UUID) (*models.Worker, error)
+
+	// GetWorkerByEmail retrieves a worker by their email address. Only managers
+	// may retrieve profiles of other workers. The password hash is not returned.
+	//
+	// Parameters:
+	//   - editor: Worker performing the operation
+	//   - email: Email address to search for
+	//
+	// Returns:
+	//   - *models.Worker: Retrieved worker entity without the password
+	//   - error: Error if access is denied, retrieval fails or worker not found
+	GetWorkerByEmail(editor *models.Worker, email string) (*models.Worker, error)
 
 	// GetAllWorkers retrieves all workers registered in the system.
 	//
@@ -59,9 +123,21 @@ type IWorkerService interface {
 	//   - error: Error if retrieval fails
 	GetAllWorkers() ([]models.Worker, error)
 
-	// Update modifies an existing worker's profile information.
+	// GetRoster retrieves the team split into managers and masters.
+	// Password hashes are never included.
+	//
+	// Returns:
+	//   - managers: Workers with the manager role
+	//   - masters: Workers with the master role
+	//   - err: Error if retrieval fails
+	GetRoster() (managers, masters []models.Worker, err error)
+
+	// Update modifies an existing worker's profile information. Only managers
+	// may update profiles of other workers or change roles. The stored password
+	// is left unchanged, use ChangePassword to replace it.
 	//
 	// Parameters:
+	//   - editor: Worker performing the operation
 	//   - id: UUID of the worker to update
 	//   - name: New first name
 	//   - surname: New last name
@@ -69,22 +145,45 @@ type IWorkerService interface {
 	//   - address: New physical address
 	//   - phoneNumber: New contact phone number
 	//   - role: New worker role (determines permissions)
-	//   - password: New password (will be hashed before storage)
 	//
 	// Returns:
 	//   - *models.Worker: Updated worker data
-	//   - error: Error if update fails or validation fails
-	Update(id uuid.UUID, name string, surname string, email string, address string, phoneNumber string, role int, password string) (*models.Worker, error)
+	//   - error: Error if access is denied, update fails or validation fails
+	Update(editor *models.Worker, id uuid.UUID, name string, surname string, email string, address string, phoneNumber string, role int) (*models.Worker, error)
+
+	// ChangePassword replaces a worker's password after verifying the current one.
+	//
+	// Parameters:
+	//   - id: UUID of the worker
+	//   - oldPassword: Current plain text password
+	//   - newPassword: New plain text password (will be hashed before storage)
+	//
+	// Returns:
+	//   - error: service_errors.MismatchedPassword if the current password is wrong,
+	//     error if the worker is not found, the new password is invalid or update fails
+	ChangePassword(id uuid.UUID, oldPassword string, newPassword string) error
+
+	// SetNotificationsOptOut records whether a worker wants to receive notifications,
+	// such as details of orders assigned to them.
+	//
+	// Parameters:
+	//   - id: UUID of the worker
+	//   - optOut: true to stop notifications, false to receive them again
+	//
+	// Returns:
+	//   - error: Error if the worker is not found or update fails
+	SetNotificationsOptOut(id uuid.UUID, optOut bool) error
 
 	// GetWorkersByRole retrieves all workers with a specific role.
 	//
 	// Parameters:
 	//   - role: Role identifier to filter by
+	//   - excludeID: UUID of a worker to leave out of the result (uuid.Nil to keep everyone)
 	//
 	// Returns:
 	//   - []models.Worker: Slice of worker entities with the specified role
 	//   - error: Error if retrieval fails
-	GetWorkersByRole(role int) ([]models.Worker, error)
+	GetWorkersByRole(role int, excludeID uuid.UUID) ([]models.Worker, error)
 
 	// GetAverageOrderRate calculates the average customer satisfaction rating
 	// for completed orders assigned to a specific worker.
@@ -96,4 +195,132 @@ type IWorkerService interface {
 	//   - float64: Average rating value (0.0-5.0)
 	//   - error: Error if calculation fails
 	GetAverageOrderRate(worker *models.Worker) (float64, error)
+
+	// ExportCSV builds a CSV roster of all workers with their contact details,
+	// role label and average rating. Passwords are never included.
+	//
+	// Returns:
+	//   - []byte: CSV document including a header row
+	//   - error: Error if retrieval or encoding fails
+	ExportCSV() ([]byte, error)
+
+	// ExportEarningsCSV builds a CSV statement of the orders a worker completed
+	// in a calendar year, with completion dates, totals and a closing year total row.
+	//
+	// Parameters:
+	//   - workerID: UUID of the worker
+	//   - year: Calendar year of completion
+	//
+	// Returns:
+	//   - []byte: CSV document including a header row and a total row
+	//   - error: Error if the worker does not exist, retrieval or encoding fails
+	ExportEarningsCSV(workerID uuid.UUID, year int) ([]byte, error)
+
+	// HasCapacity checks whether a worker may be assigned one more active order
+	// without exceeding the configured limit.
+	//
+	// Parameters:
+	//   - workerID: UUID of the worker to check
+	//
+	// Returns:
+	//   - bool: true if the worker can take another order
+	//   - error: Error if the worker does not exist or the check fails
+	HasCapacity(workerID uuid.UUID) (bool, error)
+
+	// GetWorkerWorkload counts the new and in-progress orders assigned to a worker.
+	//
+	// Parameters:
+	//   - workerID: UUID of the worker
+	//
+	// Returns:
+	//   - int: Number of active orders of the worker
+	//   - error: Error if the worker does not exist or counting fails
+	GetWorkerWorkload(workerID uuid.UUID) (int, error)
+
+	// GetLeastBusyWorker finds the master with the fewest active orders.
+	// Ties are resolved by the lowest worker ID.
+	//
+	// Returns:
+	//   - *models.Worker: Least busy master
+	//   - error: service_errors.NoMasters if there are no masters, or an error if retrieval fails
+	GetLeastBusyWorker() (*models.Worker, error)
+
+	// GetRevenueBetween sums the totals of completed orders assigned to a worker
+	// and completed within the given period.
+	//
+	// Parameters:
+	//   - workerID: UUID of the worker
+	//   - from: Start of the period (inclusive)
+	//   - to: End of the period (inclusive)
+	//
+	// Returns:
+	//   - float64: Total revenue of the worker in the period
+	//   - error: Error if the worker does not exist, the period is invalid or retrieval fails
+	GetRevenueBetween(workerID uuid.UUID, from time.Time, to time.Time) (float64, error)
+
+	// GetTeamComparison ranks every master relative to the team averages.
+	//
+	// Returns:
+	//   - []models.WorkerComparison: Comparison of every master, best rated first
+	//   - error: Error if retrieval fails
+	GetTeamComparison() ([]models.WorkerComparison, error)
+
+	// GetAssignmentCandidates lists the masters rated at or above the threshold
+	// who have capacity for another order.
+	//
+	// Parameters:
+	//   - minRating: Minimum average rating of a candidate (0-5)
+	//
+	// Returns:
+	//   - []models.WorkerWithRating: Candidates, best rated first, then least busy first
+	//   - error: Error if the threshold is out of range or retrieval fails
+	GetAssignmentCandidates(minRating float64) ([]models.WorkerWithRating, error)
+
+	// GetPerformanceReport summarizes the completed and cancelled orders, average
+	// rating and revenue of every master.
+	//
+	// Returns:
+	//   - []models.WorkerPerformance: Report row of every master, best rated first
+	//   - error: Error if retrieval fails
+	GetPerformanceReport() ([]models.WorkerPerformance, error)
+
+	// GetRecencyWeightedRating computes the average rating of a worker's completed
+	// orders with recent ratings weighted more than older ones.
+	//
+	// Parameters:
+	//   - workerID: UUID of the worker
+	//   - halfLife: Age at which a rating loses half of its weight
+	//
+	// Returns:
+	//   - float64: Weighted average rating, 0 if the worker has no rated orders
+	//   - error: Error if the half-life is not positive, the worker is not found or retrieval fails
+	GetRecencyWeightedRating(workerID uuid.UUID, halfLife time.Duration) (float64, error)
+
+	// SetAvailability marks a worker as available or unavailable during a period.
+	// The latest call wins where periods overlap. Only a manager can change the
+	// availability.
+	//
+	// Parameters:
+	//   - editor: Worker performing the operation
+	//   - workerID: UUID of the worker
+	//   - from: Start of the period (inclusive)
+	//   - to: End of the period (exclusive)
+	//   - available: Whether the worker can take orders during the period
+	//
+	// Returns:
+	//   - error: Error if the editor is not a manager, the period is empty,
+	//     the worker is not found or saving fails
+	SetAvailability(editor *models.Worker, workerID uuid.UUID, from time.Time, to time.Time, available bool) error
+
+	// IsAvailable reports whether a worker is available at the given moment.
+	// Workers are available unless marked otherwise.
+	//
+	// Parameters:
+	//   - workerID: UUID of the worker
+	//   - at: Moment to check
+	//
+	// Returns:
+	//   - bool: Whether the worker is available
+	//   - error: Error if the worker is not found or retrieval fails
+	IsAvailable(workerID uuid.UUID, at time.Time) (bool, error)
 }
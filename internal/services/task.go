@@ -6,38 +6,109 @@
 package interfaces
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
 	"github.com/charmbracelet/log"
 	"github.com/google/uuid"
+	"io"
+	"math"
+	"strings"
+	"teamdev/exchange_rate"
 	"teamdev/internal/models"
+	"teamdev/internal/repository/repository_errors"
 	"teamdev/internal/repository/repository_interfaces"
+	"teamdev/internal/services/service_errors"
 	"teamdev/internal/services/service_interfaces"
+	"time"
 )
 
 // TaskService implements the ITaskService interface and provides
 // business logic for managing cleaning tasks in the application.
 // It handles task creation, updates, deletion, and various retrieval methods.
 type TaskService struct {
-	TaskRepository repository_interfaces.ITaskRepository // Repository for persistent task operations
-	logger         *log.Logger                           // Logger for recording service activity
+	TaskRepository     repository_interfaces.ITaskRepository     // Repository for persistent task operations
+	CategoryRepository repository_interfaces.ICategoryRepository // Repository used to check that categories exist
+	rateProvider       exchange_rate.RateProvider                // Provider of exchange rates for price conversion
+	roundPrices        bool                                      // Whether over-precise prices are rounded instead of rejected
+	rounding           models.RoundingMode                       // Mode used to round over-precise prices
+	logger             *log.Logger                               // Logger for recording service activity
 }
 
 // NewTaskService creates a new TaskService instance with the provided dependencies.
 //
 // Parameters:
 //   - TaskRepository: Repository for task data access operations
+//   - CategoryRepository: Repository for category data access operations
+//   - rateProvider: Provider of exchange rates, nil leaves prices unchanged
 //   - logger: Logger for recording service activity and errors
+//   - roundPrices: Whether prices with more than two decimal places are rounded instead of rejected
+//   - rounding: Mode used to round such prices
 //
 // Returns:
 //   - service_interfaces.ITaskService: A fully initialized task service
-func NewTaskService(TaskRepository repository_interfaces.ITaskRepository, logger *log.Logger) service_interfaces.ITaskService {
+func NewTaskService(TaskRepository repository_interfaces.ITaskRepository, CategoryRepository repository_interfaces.ICategoryRepository, rateProvider exchange_rate.RateProvider, logger *log.Logger, roundPrices bool, rounding models.RoundingMode) service_interfaces.ITaskService {
+	if rateProvider == nil {
+		rateProvider = exchange_rate.NewIdentityRateProvider()
+	}
+
 	return &TaskService{
-		TaskRepository: TaskRepository,
-		logger:         logger,
+		TaskRepository:     TaskRepository,
+		CategoryRepository: CategoryRepository,
+		rateProvider:       rateProvider,
+		roundPrices:        roundPrices,
+		rounding:           rounding,
+		logger:             logger,
+	}
+}
+
+// checkCategory verifies that a category with the given ID exists in the
+// categories table.
+//
+// Parameters:
+//   - category: The category ID to check
+//
+// Returns:
+//   - error: service_errors.InvalidCategory if there is no such category,
+//     repository error if the lookup fails
+func (t TaskService) checkCategory(category int) error {
+	_, err := t.CategoryRepository.GetByID(category)
+	if errors.Is(err, repository_errors.DoesNotExist) {
+		t.logger.Error("SERVICE: Category does not exist", "category", category)
+		return service_errors.InvalidCategory
+	} else if err != nil {
+		t.logger.Error("SERVICE: GetByID method failed", "category", category, "error", err)
+		return err
+	}
+
+	return nil
+}
+
+// normalizePrice makes sure a price is a whole number of cents. Over-precise
+// prices are rounded when rounding is enabled and rejected otherwise.
+//
+// Parameters:
+//   - price: Price per unit entered for a task
+//
+// Returns:
+//   - float64: Price with at most two decimal places
+//   - error: service_errors.InvalidPrice if the price has more than two decimal
+//     places and rounding is disabled
+func (t TaskService) normalizePrice(price float64) (float64, error) {
+	if validPricePrecision(price) {
+		return price, nil
 	}
+
+	if !t.roundPrices {
+		t.logger.Error("SERVICE: Price has more than two decimal places", "price", price)
+		return 0, service_errors.InvalidPrice
+	}
+
+	return t.rounding.Round(price), nil
 }
 
 // Create adds a new cleaning task to the system with the provided details.
+// The price must have at most two decimal places unless rounding is enabled.
 //
 // Parameters:
 //   - name: Descriptive name of the cleaning task
@@ -46,11 +117,25 @@ func NewTaskService(TaskRepository repository_interfaces.ITaskRepository, logger
 //
 // Returns:
 //   - *models.Task: Created task with assigned ID if successful
-//   - error: Validation or persistence errors if they occur
+//   - error: service_errors.InvalidName or service_errors.InvalidPrice for invalid input,
+//     validation or persistence errors if they occur
 func (t TaskService) Create(name string, price float64, category int) (*models.Task, error) {
-	if !validName(name) || !validPrice(price) || !validCategory(category) {
-		t.logger.Error("SERVICE: Invalid input")
-		return nil, fmt.Errorf("SERVICE: Invalid input")
+	price, err := t.normalizePrice(price)
+	if err != nil {
+		return nil, err
+	}
+
+	if !validName(name) {
+		t.logger.Error("SERVICE: Invalid name", "name", name)
+		return nil, service_errors.InvalidName
+	} else if !validPrice(price) {
+		t.logger.Error("SERVICE: Invalid price", "price", price)
+		return nil, service_errors.InvalidPrice
+	}
+
+	err = t.checkCategory(category)
+	if err != nil {
+		return nil, err
 	}
 
 	task := &models.Task{
@@ -59,7 +144,7 @@ func (t TaskService) Create(name string, price float64, category int) (*models.T
 		Category:       category,
 	}
 
-	task, err := t.TaskRepository.Create(task)
+	task, err = t.TaskRepository.Create(task)
 	if err != nil {
 		t.logger.Error("SERVICE: CreateNewTask method failed", "error", err)
 		return nil, err
@@ -70,6 +155,7 @@ func (t TaskService) Create(name string, price float64, category int) (*models.T
 }
 
 // Update modifies an existing task with new information.
+// The price must have at most two decimal places unless rounding is enabled.
 //
 // Parameters:
 //   - taskID: UUID of the task to update
@@ -79,7 +165,8 @@ func (t TaskService) Create(name string, price float64, category int) (*models.T
 //
 // Returns:
 //   - *models.Task: Updated task after changes
-//   - error: Validation or persistence errors if they occur
+//   - error: service_errors.InvalidName or service_errors.InvalidPrice for invalid input,
+//     validation or persistence errors if they occur
 func (t TaskService) Update(taskID uuid.UUID, category int, name string, price float64) (*models.Task, error) {
 	task, err := t.GetTaskByID(taskID)
 	if err != nil {
@@ -87,15 +174,28 @@ func (t TaskService) Update(taskID uuid.UUID, category int, name string, price f
 		return nil, err
 	}
 
-	if !validCategory(category) || !validName(name) || !validPrice(price) {
-		t.logger.Error("SERVICE: Invalid input")
-		return nil, fmt.Errorf("SERVICE: Invalid input")
-	} else {
-		task.Category = category
-		task.Name = name
-		task.PricePerSingle = price
+	price, err = t.normalizePrice(price)
+	if err != nil {
+		return nil, err
+	}
+
+	if !validName(name) {
+		t.logger.Error("SERVICE: Invalid name", "name", name)
+		return nil, service_errors.InvalidName
+	} else if !validPrice(price) {
+		t.logger.Error("SERVICE: Invalid price", "price", price)
+		return nil, service_errors.InvalidPrice
+	}
+
+	err = t.checkCategory(category)
+	if err != nil {
+		return nil, err
 	}
 
+	task.Category = category
+	task.Name = name
+	task.PricePerSingle = price
+
 	updatedTask, err := t.TaskRepository.Update(task)
 	if err != nil {
 		t.logger.Error("SERVICE: UpdateTask method failed", "error", err)
@@ -146,6 +246,59 @@ func (t TaskService) GetAllTasks() ([]models.Task, error) {
 	return tasks, nil
 }
 
+// GetAllTasksPaged retrieves one page of the cleaning tasks sorted by name,
+// price or category, together with the total number of tasks. The sort field is
+// checked against models.TaskSortFields before it reaches the repository.
+//
+// Parameters:
+//   - limit: Maximum number of tasks on the page, must be positive
+//   - offset: Number of tasks to skip, must not be negative
+//   - sortBy: One of the fields in models.TaskSortFields
+//   - asc: Whether tasks are sorted in ascending order
+//
+// Returns:
+//   - []models.Task: Tasks on the requested page
+//   - int: Total number of tasks
+//   - error: service_errors.InvalidSortField for an unknown sort field, any validation or retrieval errors
+func (t TaskService) GetAllTasksPaged(limit, offset int, sortBy string, asc bool) ([]models.Task, int, error) {
+	if limit <= 0 || offset < 0 {
+		t.logger.Error("SERVICE: Invalid input", "limit", limit, "offset", offset)
+		return nil, 0, fmt.Errorf("SERVICE: Invalid input")
+	}
+
+	if !models.TaskSortFields[sortBy] {
+		t.logger.Error("SERVICE: Invalid sort field", "sort_by", sortBy)
+		return nil, 0, fmt.Errorf("%w: %q", service_errors.InvalidSortField, sortBy)
+	}
+
+	tasks, total, err := t.TaskRepository.GetAllTasksPaged(limit, offset, sortBy, asc)
+	if err != nil {
+		t.logger.Error("SERVICE: GetAllTasksPaged method failed", "error", err)
+		return nil, 0, err
+	}
+
+	t.logger.Info("SERVICE: Successfully got page of tasks", "limit", limit, "offset", offset, "sort_by", sortBy, "asc", asc)
+	return tasks, total, nil
+}
+
+// GetAllTasksWithCategoryNames retrieves all cleaning tasks together with the
+// names of their categories. Tasks whose category no longer exists carry
+// models.UnknownCategoryName.
+//
+// Returns:
+//   - []models.TaskWithCategory: Tasks with resolved category names
+//   - error: Any retrieval errors
+func (t TaskService) GetAllTasksWithCategoryNames() ([]models.TaskWithCategory, error) {
+	tasks, err := t.TaskRepository.GetAllTasksWithCategoryNames()
+	if err != nil {
+		t.logger.Error("SERVICE: GetAllTasksWithCategoryNames method failed", "error", err)
+		return nil, err
+	}
+
+	t.logger.Info("SERVICE: Successfully got all tasks with category names", "count", len(tasks))
+	return tasks, nil
+}
+
 // GetTaskByID retrieves a specific task by its unique identifier.
 //
 // Parameters:
@@ -176,9 +329,9 @@ func (t TaskService) GetTaskByID(id uuid.UUID) (*models.Task, error) {
 //   - error: Validation or retrieval errors
 func (t TaskService) GetTasksInCategory(category int) ([]models.Task, error) {
 	print(category)
-	if !validCategory(category) {
-		t.logger.Error("SERVICE: Invalid category", "category", category)
-		return nil, fmt.Errorf("SERVICE: Invalid category")
+	err := t.checkCategory(category)
+	if err != nil {
+		return nil, err
 	}
 
 	tasks, err := t.TaskRepository.GetTasksInCategory(category)
@@ -210,3 +363,447 @@ func (t TaskService) GetTaskByName(name string) (*models.Task, error) {
 	t.logger.Info("SERVICE: Successfully got task with GetTaskByName", "name", name)
 	return task, nil
 }
+
+// SearchTasksByName retrieves catalog tasks whose names contain the given text,
+// ignoring case, so that customers can find a task without knowing its exact name.
+// Surrounding whitespace is ignored and an empty query lists every task.
+//
+// Parameters:
+//   - query: Part of the task name to search for
+//
+// Returns:
+//   - []models.Task: Slice of matching tasks ordered by name
+//   - error: Any retrieval errors
+func (t TaskService) SearchTasksByName(query string) ([]models.Task, error) {
+	query = strings.TrimSpace(query)
+
+	tasks, err := t.TaskRepository.SearchTasksByName(query)
+	if err != nil {
+		t.logger.Error("SERVICE: SearchTasksByName method failed", "query", query, "error", err)
+		return nil, err
+	}
+
+	t.logger.Info("SERVICE: Successfully searched tasks by name", "query", query, "found", len(tasks))
+	return tasks, nil
+}
+
+// GetTasksModifiedSince retrieves tasks created or changed at or after the given
+// time, so that integrators can synchronize the catalog incrementally. Archived
+// tasks are included, so that they can be removed from synchronized copies.
+//
+// Parameters:
+//   - since: Earliest modification time to include, the zero time for the whole catalog
+//
+// Returns:
+//   - []models.Task: Slice of modified tasks, least recently modified first
+//   - error: Any retrieval errors
+func (t TaskService) GetTasksModifiedSince(since time.Time) ([]models.Task, error) {
+	tasks, err := t.TaskRepository.GetTasksModifiedSince(since)
+	if err != nil {
+		t.logger.Error("SERVICE: GetTasksModifiedSince method failed", "since", since, "error", err)
+		return nil, err
+	}
+
+	t.logger.Info("SERVICE: Successfully got modified tasks", "since", since, "count", len(tasks))
+	return tasks, nil
+}
+
+// GetUnorderedTasks retrieves all tasks that have never been included in any order.
+// Used by managers to find unused services in the catalog.
+//
+// Returns:
+//   - []models.Task: Slice of tasks not referenced by any order
+//   - error: Any retrieval errors
+func (t TaskService) GetUnorderedTasks() ([]models.Task, error) {
+	tasks, err := t.TaskRepository.GetUnorderedTasks()
+	if err != nil {
+		t.logger.Error("SERVICE: GetUnorderedTasks method failed", "error", err)
+		return nil, err
+	}
+
+	t.logger.Info("SERVICE: Successfully got unordered tasks", "count", len(tasks))
+	return tasks, nil
+}
+
+// GetMostOrderedTasks ranks tasks by the total quantity ordered across all
+// orders that were not cancelled. Used by managers to plan staffing.
+//
+// Parameters:
+//   - limit: Maximum number of tasks to return, must be positive
+//
+// Returns:
+//   - []models.TaskPopularity: Tasks with their total quantity and number of orders,
+//     most ordered first
+//   - error: Validation error if the limit is not positive, retrieval error otherwise
+func (t TaskService) GetMostOrderedTasks(limit int) ([]models.TaskPopularity, error) {
+	return t.mostOrderedTasks(time.Time{}, time.Time{}, limit)
+}
+
+// GetMostOrderedTasksBetween ranks tasks by the total quantity ordered in the
+// orders created within the given period. Cancelled orders are not counted.
+//
+// Parameters:
+//   - from: Start of the period (inclusive)
+//   - to: End of the period (inclusive)
+//   - limit: Maximum number of tasks to return, must be positive
+//
+// Returns:
+//   - []models.TaskPopularity: Tasks with their total quantity and number of orders,
+//     most ordered first
+//   - error: Validation error if the period or the limit is invalid, retrieval error otherwise
+func (t TaskService) GetMostOrderedTasksBetween(from time.Time, to time.Time, limit int) ([]models.TaskPopularity, error) {
+	if from.IsZero() || to.IsZero() || to.Before(from) {
+		t.logger.Error("SERVICE: Invalid input", "from", from, "to", to)
+		return nil, fmt.Errorf("SERVICE: Invalid input")
+	}
+
+	return t.mostOrderedTasks(from, to, limit)
+}
+
+// mostOrderedTasks validates the limit and retrieves the task ranking,
+// zero-value dates leaving the period unbounded.
+func (t TaskService) mostOrderedTasks(from time.Time, to time.Time, limit int) ([]models.TaskPopularity, error) {
+	if limit <= 0 {
+		t.logger.Error("SERVICE: Invalid input", "limit", limit)
+		return nil, fmt.Errorf("SERVICE: Invalid input")
+	}
+
+	popularity, err := t.TaskRepository.GetMostOrderedTasks(limit, from, to)
+	if err != nil {
+		t.logger.Error("SERVICE: GetMostOrderedTasks method failed", "from", from, "to", to, "limit", limit, "error", err)
+		return nil, err
+	}
+
+	t.logger.Info("SERVICE: Successfully got most ordered tasks", "from", from, "to", to, "count", len(popularity))
+	return popularity, nil
+}
+
+// GetTasksInCurrency retrieves all tasks with their prices converted to the
+// given currency using the configured exchange rate provider.
+//
+// Parameters:
+//   - code: Code of the target currency (e.g. "USD")
+//
+// Returns:
+//   - []models.TaskPriced: Slice of tasks with converted prices
+//   - error: Validation error for an empty code, exchange_rate.UnknownCurrency
+//     if no rate is known, or any retrieval errors
+func (t TaskService) GetTasksInCurrency(code string) ([]models.TaskPriced, error) {
+	code = strings.ToUpper(strings.TrimSpace(code))
+	if code == "" {
+		t.logger.Error("SERVICE: Invalid input", "code", code)
+		return nil, fmt.Errorf("SERVICE: Invalid input")
+	}
+
+	rate, err := t.rateProvider.GetRate(code)
+	if err != nil {
+		t.logger.Error("SERVICE: GetRate method failed", "code", code, "error", err)
+		return nil, err
+	}
+
+	tasks, err := t.TaskRepository.GetAllTasks()
+	if err != nil {
+		t.logger.Error("SERVICE: GetAllTasks method failed", "error", err)
+		return nil, err
+	}
+
+	pricedTasks := make([]models.TaskPriced, len(tasks))
+	for i, task := range tasks {
+		pricedTasks[i] = models.TaskPriced{
+			Task:     task,
+			Currency: code,
+			Price:    task.PricePerSingle * rate,
+		}
+	}
+
+	t.logger.Info("SERVICE: Successfully got tasks in currency", "code", code, "rate", rate)
+	return pricedTasks, nil
+}
+
+// ArchiveCategory archives all tasks of a category in one transaction.
+// Archived tasks are no longer listed in the catalog. Unless forced, nothing is
+// archived when any of the tasks is part of a new or in-progress order.
+//
+// Parameters:
+//   - category: Category ID whose tasks are archived
+//   - force: Whether to archive tasks that are part of open orders
+//
+// Returns:
+//   - int: Number of archived tasks
+//   - error: service_errors.InvalidCategory for an unknown category,
+//     service_errors.TasksInOpenOrders if open orders refer to the tasks and
+//     force is not set, or any persistence errors
+func (t TaskService) ArchiveCategory(category int, force bool) (int, error) {
+	err := t.checkCategory(category)
+	if err != nil {
+		return 0, err
+	}
+
+	archived, err := t.TaskRepository.ArchiveCategory(category, force)
+	if errors.Is(err, repository_errors.ReferencedByOpenOrders) {
+		t.logger.Error("SERVICE: Tasks of the category are part of open orders", "category", category)
+		return 0, service_errors.TasksInOpenOrders
+	} else if err != nil {
+		t.logger.Error("SERVICE: ArchiveCategory method failed", "category", category, "error", err)
+		return 0, err
+	}
+
+	t.logger.Info("SERVICE: Successfully archived category", "category", category, "archived", archived, "force", force)
+	return archived, nil
+}
+
+// AdjustCategoryPrices multiplies the prices of all tasks of a category that are
+// not archived by the multiplier in one transaction, for example 1.1 for a ten
+// percent surcharge. New prices are rounded to whole cents with the configured
+// rounding mode; they are rounded even if entered prices are not, since a
+// percentage change rarely lands on whole cents.
+//
+// Parameters:
+//   - category: Category ID whose prices are adjusted
+//   - multiplier: Positive factor applied to the prices
+//
+// Returns:
+//   - int: Number of tasks whose price was adjusted
+//   - error: service_errors.InvalidMultiplier if the multiplier is not a positive number,
+//     service_errors.InvalidCategory for an unknown category, or any persistence errors
+func (t TaskService) AdjustCategoryPrices(category int, multiplier float64) (int, error) {
+	if !(multiplier > 0) || math.IsInf(multiplier, 0) {
+		t.logger.Error("SERVICE: Invalid price multiplier", "multiplier", multiplier)
+		return 0, service_errors.InvalidMultiplier
+	}
+
+	err := t.checkCategory(category)
+	if err != nil {
+		return 0, err
+	}
+
+	adjusted, err := t.TaskRepository.AdjustCategoryPrices(category, multiplier, t.rounding)
+	if err != nil {
+		t.logger.Error("SERVICE: AdjustCategoryPrices method failed", "category", category, "multiplier", multiplier, "error", err)
+		return 0, err
+	}
+
+	t.logger.Info("SERVICE: Successfully adjusted category prices", "category", category, "multiplier", multiplier, "adjusted", adjusted)
+	return adjusted, nil
+}
+
+// normalizeTasks validates tasks that are about to be imported and normalizes
+// their prices. Each category is looked up only once.
+//
+// Parameters:
+//   - tasks: Tasks to validate
+//
+// Returns:
+//   - []models.Task: Tasks with normalized prices
+//   - error: Validation errors for the first invalid task
+func (t TaskService) normalizeTasks(tasks []models.Task) ([]models.Task, error) {
+	var err error
+	normalized := make([]models.Task, 0, len(tasks))
+	checkedCategories := make(map[int]bool)
+	for _, task := range tasks {
+		task.PricePerSingle, err = t.normalizePrice(task.PricePerSingle)
+		if err != nil {
+			return nil, err
+		}
+
+		if !validName(task.Name) || !validPrice(task.PricePerSingle) {
+			t.logger.Error("SERVICE: Invalid input", "task", task)
+			return nil, fmt.Errorf("SERVICE: Invalid input")
+		}
+
+		if !checkedCategories[task.Category] {
+			err = t.checkCategory(task.Category)
+			if err != nil {
+				return nil, err
+			}
+			checkedCategories[task.Category] = true
+		}
+
+		normalized = append(normalized, task)
+	}
+
+	return normalized, nil
+}
+
+// UpsertByName imports a list of tasks in one transaction: new tasks are created
+// and the price and category of tasks with the same name (ignoring case) are
+// updated. Every task is validated before anything is written.
+//
+// Parameters:
+//   - tasks: Tasks to import
+//
+// Returns:
+//   - created: Number of created tasks
+//   - updated: Number of updated tasks
+//   - err: Validation or persistence errors if they occur
+func (t TaskService) UpsertByName(tasks []models.Task) (created int, updated int, err error) {
+	normalized, err := t.normalizeTasks(tasks)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	created, updated, err = t.TaskRepository.UpsertByName(normalized)
+	if err != nil {
+		t.logger.Error("SERVICE: UpsertByName method failed", "error", err)
+		return 0, 0, err
+	}
+
+	t.logger.Info("SERVICE: Successfully imported tasks", "created", created, "updated", updated)
+	return created, updated, nil
+}
+
+// GetPriceTiers retrieves the volume discounts of a task.
+//
+// Parameters:
+//   - taskID: UUID of the task
+//
+// Returns:
+//   - []models.PriceTier: Price tiers of the task, smallest minimum quantity first
+//   - error: Any retrieval errors
+func (t TaskService) GetPriceTiers(taskID uuid.UUID) ([]models.PriceTier, error) {
+	_, err := t.TaskRepository.GetTaskByID(taskID)
+	if err != nil {
+		t.logger.Error("SERVICE: GetTaskByID method failed", "id", taskID, "error", err)
+		return nil, err
+	}
+
+	tiers, err := t.TaskRepository.GetPriceTiers(taskID)
+	if err != nil {
+		t.logger.Error("SERVICE: GetPriceTiers method failed", "id", taskID, "error", err)
+		return nil, err
+	}
+
+	t.logger.Info("SERVICE: Successfully got price tiers", "id", taskID, "tiers", len(tiers))
+	return tiers, nil
+}
+
+// SetPriceTiers replaces the volume discounts of a task. Every tier must start
+// at a quantity of at least two, minimum quantities must be distinct, and tier
+// prices follow the same rules as regular task prices.
+//
+// Parameters:
+//   - taskID: UUID of the task
+//   - tiers: New price tiers, empty to remove all discounts
+//
+// Returns:
+//   - error: Validation or persistence errors if they occur
+func (t TaskService) SetPriceTiers(taskID uuid.UUID, tiers []models.PriceTier) error {
+	_, err := t.TaskRepository.GetTaskByID(taskID)
+	if err != nil {
+		t.logger.Error("SERVICE: GetTaskByID method failed", "id", taskID, "error", err)
+		return err
+	}
+
+	normalized := make([]models.PriceTier, 0, len(tiers))
+	minQuantities := make(map[int]bool, len(tiers))
+	for _, tier := range tiers {
+		tier.Price, err = t.normalizePrice(tier.Price)
+		if err != nil {
+			return err
+		}
+
+		if tier.MinQuantity < 2 || minQuantities[tier.MinQuantity] || !validPrice(tier.Price) {
+			t.logger.Error("SERVICE: Invalid input", "tier", tier)
+			return fmt.Errorf("SERVICE: Invalid input")
+		}
+		minQuantities[tier.MinQuantity] = true
+
+		normalized = append(normalized, tier)
+	}
+
+	err = t.TaskRepository.SetPriceTiers(taskID, normalized)
+	if err != nil {
+		t.logger.Error("SERVICE: SetPriceTiers method failed", "id", taskID, "error", err)
+		return err
+	}
+
+	t.logger.Info("SERVICE: Successfully set price tiers", "id", taskID, "tiers", len(normalized))
+	return nil
+}
+
+// catalogTask is an entry of the JSON catalog used by ExportCatalog and ImportCatalog.
+type catalogTask struct {
+	Name     string  `json:"name"`
+	Price    float64 `json:"price"`
+	Category int     `json:"category"`
+}
+
+// ExportCatalog writes all tasks that are not archived as a JSON array of
+// their names, prices and categories.
+//
+// Parameters:
+//   - w: Destination of the JSON document
+//
+// Returns:
+//   - error: Any retrieval or writing errors
+func (t TaskService) ExportCatalog(w io.Writer) error {
+	tasks, err := t.TaskRepository.GetAllTasks()
+	if err != nil {
+		t.logger.Error("SERVICE: GetAllTasks method failed", "error", err)
+		return err
+	}
+
+	catalog := make([]catalogTask, 0, len(tasks))
+	for _, task := range tasks {
+		catalog = append(catalog, catalogTask{Name: task.Name, Price: task.PricePerSingle, Category: task.Category})
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if err = encoder.Encode(catalog); err != nil {
+		t.logger.Error("SERVICE: Failed to write catalog", "error", err)
+		return err
+	}
+
+	t.logger.Info("SERVICE: Successfully exported catalog", "tasks", len(catalog))
+	return nil
+}
+
+// ImportCatalog reads a catalog produced by ExportCatalog. Every entry is
+// validated like in UpsertByName before anything is written, and all changes
+// are saved in one transaction. Tasks with a name that is not in the catalog
+// yet, ignoring case, are created, while existing ones are updated when
+// overwrite is set and skipped otherwise.
+//
+// Parameters:
+//   - r: Source of the JSON document
+//   - overwrite: Whether existing tasks with the same name are updated
+//
+// Returns:
+//   - models.CatalogImportSummary: Numbers of created, updated and skipped tasks
+//   - error: Validation, retrieval or persistence errors if they occur
+func (t TaskService) ImportCatalog(r io.Reader, overwrite bool) (models.CatalogImportSummary, error) {
+	var catalog []catalogTask
+	if err := json.NewDecoder(r).Decode(&catalog); err != nil {
+		t.logger.Error("SERVICE: Invalid input", "error", err)
+		return models.CatalogImportSummary{}, fmt.Errorf("SERVICE: Invalid input")
+	}
+
+	tasks := make([]models.Task, 0, len(catalog))
+	for _, entry := range catalog {
+		tasks = append(tasks, models.Task{Name: entry.Name, PricePerSingle: entry.Price, Category: entry.Category})
+	}
+
+	tasks, err := t.normalizeTasks(tasks)
+	if err != nil {
+		return models.CatalogImportSummary{}, err
+	}
+
+	var summary models.CatalogImportSummary
+	if overwrite {
+		summary.Created, summary.Updated, err = t.TaskRepository.UpsertByName(tasks)
+		if err != nil {
+			t.logger.Error("SERVICE: UpsertByName method failed", "error", err)
+			return models.CatalogImportSummary{}, err
+		}
+	} else {
+		summary.Created, summary.Skipped, err = t.TaskRepository.InsertMissingByName(tasks)
+		if err != nil {
+			t.logger.Error("SERVICE: InsertMissingByName method failed", "error", err)
+			return models.CatalogImportSummary{}, err
+		}
+	}
+
+	t.logger.Info("SERVICE: Successfully imported catalog", "created", summary.Created, "updated", summary.Updated, "skipped", summary.Skipped)
+	return summary, nil
+}
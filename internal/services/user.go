@@ -10,9 +10,11 @@ import (
 	"fmt"
 	"github.com/charmbracelet/log"
 	"github.com/google/uuid"
+	"teamdev/auth"
 	"teamdev/internal/models"
 	"teamdev/internal/repository/repository_errors"
 	"teamdev/internal/repository/repository_interfaces"
+	"teamdev/internal/services/service_errors"
 	"teamdev/internal/services/service_interfaces"
 	"teamdev/password_hash"
 )
@@ -24,6 +26,8 @@ type UserService struct {
 	UserRepository repository_interfaces.IUserRepository // Repository for persistent user operations
 	hash           password_hash.PasswordHash            // Utility for password hashing and verification
 	logger         *log.Logger                           // Logger for recording service activity
+	loginAttempts  *loginAttempts                        // Failed login counter used for the lockout
+	tokens         auth.TokenIssuer                      // Issues session tokens, nil if tokens are not used
 }
 
 // NewUserService creates a new UserService instance with the provided dependencies.
@@ -32,14 +36,18 @@ type UserService struct {
 //   - UserRepository: Repository for user data access operations
 //   - hash: Password hashing utility for secure password storage
 //   - logger: Logger for recording service activity and errors
+//   - lockout: Threshold and window after which failed logins lock the email
+//   - tokens: Issuer of session tokens (nil means no tokens are issued)
 //
 // Returns:
 //   - service_interfaces.IUserService: A fully initialized user service
-func NewUserService(UserRepository repository_interfaces.IUserRepository, hash password_hash.PasswordHash, logger *log.Logger) service_interfaces.IUserService {
+func NewUserService(UserRepository repository_interfaces.IUserRepository, hash password_hash.PasswordHash, logger *log.Logger, lockout models.LockoutSettings, tokens auth.TokenIssuer) service_interfaces.IUserService {
 	return &UserService{
 		UserRepository: UserRepository,
 		hash:           hash,
 		logger:         logger,
+		loginAttempts:  newLoginAttempts(lockout),
+		tokens:         tokens,
 	}
 }
 
@@ -108,6 +116,7 @@ func (u UserService) checkIfUserWithEmailExists(email string) (*models.User, err
 }
 
 // Register creates a new user account with validated information and a secure password.
+// The phone number is stored in E.164 form.
 //
 // Parameters:
 //   - user: User entity with personal information
@@ -115,37 +124,41 @@ func (u UserService) checkIfUserWithEmailExists(email string) (*models.User, err
 //
 // Returns:
 //   - *models.User: Created user with assigned ID if successful
-//   - error: Validation or persistence errors if they occur
+//   - error: service_errors.InvalidName, InvalidEmail, InvalidAddress, InvalidPhoneNumber
+//     or InvalidPassword for the first invalid field, service_errors.NotUnique if the
+//     email is already taken, persistence errors if they occur
 func (u UserService) Register(user *models.User, password string) (*models.User, error) {
 	u.logger.Infof("SERVICE: validate user with email %s", user.Email)
 	if !validName(user.Name) {
 		u.logger.Error("SERVICE: Invalid name")
-		return nil, fmt.Errorf("SERVICE: Invalid name")
+		return nil, service_errors.InvalidName
 	}
 
 	if !validName(user.Surname) {
 		u.logger.Error("SERVICE: Invalid surname")
-		return nil, fmt.Errorf("SERVICE: Invalid surname")
+		return nil, fmt.Errorf("%w: surname", service_errors.InvalidName)
 	}
 
 	if !validEmail(user.Email) {
 		u.logger.Error("SERVICE: Invalid email")
-		return nil, fmt.Errorf("SERVICE: Invalid email")
+		return nil, service_errors.InvalidEmail
 	}
 
 	if !validAddress(user.Address) {
 		u.logger.Error("SERVICE: Invalid address")
-		return nil, fmt.Errorf("SERVICE: Invalid address")
+		return nil, service_errors.InvalidAddress
 	}
 
-	if !validPhoneNumber(user.PhoneNumber) {
+	phoneNumber, err := normalizePhoneNumber(user.PhoneNumber)
+	if err != nil {
 		u.logger.Error("SERVICE: Invalid phone number")
-		return nil, fmt.Errorf("SERVICE: Invalid phone number")
+		return nil, err
 	}
+	user.PhoneNumber = phoneNumber
 
 	if !validPassword(password) {
 		u.logger.Error("SERVICE: Invalid password")
-		return nil, fmt.Errorf("SERVICE: Invalid password")
+		return nil, service_errors.InvalidPassword
 	}
 
 	u.logger.Infof("SERVICE: Checking if user with email %s exists", user.Email)
@@ -155,7 +168,7 @@ func (u UserService) Register(user *models.User, password string) (*models.User,
 		return nil, err
 	} else if tempUser != nil {
 		u.logger.Info("SERVICE: User with email exists", "email", user.Email)
-		return nil, fmt.Errorf("SERVICE: User with email exists")
+		return nil, service_errors.NotUnique
 	}
 
 	u.logger.Infof("SERVICE: Creating new user: %s %s", user.Name, user.Surname)
@@ -168,7 +181,11 @@ func (u UserService) Register(user *models.User, password string) (*models.User,
 	}
 
 	createdUser, err := u.UserRepository.Create(user)
-	if err != nil {
+	if errors.Is(err, repository_errors.AlreadyExists) {
+		// another registration with the same email won the race after the check above
+		u.logger.Info("SERVICE: User with email exists", "email", user.Email)
+		return nil, service_errors.NotUnique
+	} else if err != nil {
 		u.logger.Error("SERVICE: Create method failed", "error", err)
 		return nil, err
 	}
@@ -178,7 +195,10 @@ func (u UserService) Register(user *models.User, password string) (*models.User,
 	return createdUser, nil
 }
 
-// Login authenticates a user with email and password.
+// Login authenticates a user with email and password. A password hash computed
+// with a lower cost than the configured one is replaced with a stronger one;
+// failing to store it does not fail the login. After too many failed attempts
+// within the lockout window the email is locked out until the window elapses.
 //
 // Parameters:
 //   - email: User's email address
@@ -186,8 +206,13 @@ func (u UserService) Register(user *models.User, password string) (*models.User,
 //
 // Returns:
 //   - *models.User: Authenticated user entity if successful
-//   - error: Authentication errors if credentials are invalid
+//   - error: Authentication errors if credentials are invalid, LoginLocked if the email is locked out
 func (u UserService) Login(email, password string) (*models.User, error) {
+	if u.loginAttempts.isLocked(email) {
+		u.logger.Info("SERVICE: Login is locked for user with email", "email", email)
+		return nil, service_errors.LoginLocked
+	}
+
 	u.logger.Infof("SERVICE: Checking if user with email %s exists", email)
 	tempUser, err := u.checkIfUserWithEmailExists(email)
 	if err != nil {
@@ -195,21 +220,75 @@ func (u UserService) Login(email, password string) (*models.User, error) {
 		return nil, err
 	} else if tempUser == nil {
 		u.logger.Info("SERVICE: User with email does not exist", "email", email)
+		u.recordFailedLogin(email)
 		return nil, fmt.Errorf("SERVICE: User with email does not exist")
 	}
 
 	u.logger.Infof("SERVICE: Checking if password is correct for user with email %s", email)
-	isPasswordCorrect := u.hash.CompareHashAndPassword(tempUser.Password, password)
+	newHash, isPasswordCorrect := u.hash.CompareAndMaybeRehash(tempUser.Password, password)
 	if !isPasswordCorrect {
 		u.logger.Info("SERVICE: Password is incorrect for user with email", "email", email)
+		u.recordFailedLogin(email)
 		return nil, fmt.Errorf("SERVICE: Password is incorrect for user with email")
 	}
 
+	if newHash != "" {
+		err = u.UserRepository.UpdatePassword(tempUser.ID, newHash)
+		if err != nil {
+			u.logger.Error("SERVICE: UpdatePassword method failed while upgrading password hash", "id", tempUser.ID, "error", err)
+		} else {
+			tempUser.Password = newHash
+			u.logger.Info("SERVICE: Upgraded password hash of user", "id", tempUser.ID)
+		}
+	}
+
+	u.loginAttempts.reset(email)
 	u.logger.Info("SERVICE: Successfully logged in user with email", "email", email)
 	return tempUser, nil
 }
 
+// LoginWithToken authenticates a user like Login and issues a session token
+// with the client role for them.
+//
+// Parameters:
+//   - email: User's email address
+//   - password: Plain text password to validate
+//
+// Returns:
+//   - *models.User: Authenticated user data
+//   - string: Session token, empty if no token issuer is configured
+//   - error: Any authentication or token generation errors
+func (u UserService) LoginWithToken(email, password string) (*models.User, string, error) {
+	user, err := u.Login(email, password)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if u.tokens == nil {
+		return user, "", nil
+	}
+
+	token, err := u.tokens.GenerateToken(user.ID, auth.ClientRole)
+	if err != nil {
+		u.logger.Error("SERVICE: GenerateToken method failed", "id", user.ID, "error", err)
+		return nil, "", err
+	}
+
+	return user, token, nil
+}
+
+// recordFailedLogin counts a failed login and logs when it locks the email.
+//
+// Parameters:
+//   - email: Email used for the failed login
+func (u UserService) recordFailedLogin(email string) {
+	if u.loginAttempts.fail(email) {
+		u.logger.Warn("SERVICE: Too many failed logins, locking user with email", "email", email)
+	}
+}
+
 // Update modifies an existing user's information with validated data.
+// The phone number is stored in E.164 form and the stored password hash is kept as is.
 //
 // Parameters:
 //   - id: UUID of the user to update
@@ -218,19 +297,19 @@ func (u UserService) Login(email, password string) (*models.User, error) {
 //   - email: New email address
 //   - address: New physical address
 //   - phoneNumber: New contact phone number
-//   - password: New password (will be hashed if changed)
 //
 // Returns:
 //   - *models.User: Updated user after changes
 //   - error: Validation or persistence errors if they occur
-func (u UserService) Update(id uuid.UUID, name string, surname string, email string, address string, phoneNumber string, password string) (*models.User, error) {
+func (u UserService) Update(id uuid.UUID, name string, surname string, email string, address string, phoneNumber string) (*models.User, error) {
 	user, err := u.UserRepository.GetUserByID(id)
 	if err != nil {
 		u.logger.Error("SERVICE: GetUserByID method failed", "id", id, "error", err)
 		return nil, err
 	}
 
-	if !validName(name) || !validName(surname) || !validEmail(email) || !validAddress(address) || !validPhoneNumber(phoneNumber) || !validPassword(password) {
+	phoneNumber, phoneErr := normalizePhoneNumber(phoneNumber)
+	if !validName(name) || !validName(surname) || !validEmail(email) || !validAddress(address) || phoneErr != nil {
 		u.logger.Error("SERVICE: Invalid input")
 		return nil, fmt.Errorf("SERVICE: Invalid input")
 	}
@@ -241,16 +320,6 @@ func (u UserService) Update(id uuid.UUID, name string, surname string, email str
 	user.Address = address
 	user.PhoneNumber = phoneNumber
 
-	if user.Password != password {
-		hashedPassword, hashErr := u.hash.GetHash(password)
-		if hashErr != nil {
-			u.logger.Error("SERVICE: Error occurred during password hashing")
-			return nil, hashErr
-		} else {
-			user.Password = hashedPassword
-		}
-	}
-
 	user, err = u.UserRepository.Update(user)
 	if err != nil {
 		u.logger.Error("SERVICE: Update method failed", "error", err)
@@ -260,3 +329,110 @@ func (u UserService) Update(id uuid.UUID, name string, surname string, email str
 	u.logger.Info("SERVICE: Successfully updated user personal information", "user", user)
 	return user, nil
 }
+
+// ChangePassword replaces the user's password after verifying the current one.
+//
+// Parameters:
+//   - id: UUID of the user
+//   - oldPassword: Current plain text password
+//   - newPassword: New plain text password
+//
+// Returns:
+//   - error: service_errors.MismatchedPassword if the current password is wrong,
+//     validation, hashing or persistence errors if they occur
+func (u UserService) ChangePassword(id uuid.UUID, oldPassword string, newPassword string) error {
+	user, err := u.UserRepository.GetUserByID(id)
+	if err != nil {
+		u.logger.Error("SERVICE: GetUserByID method failed", "id", id, "error", err)
+		return err
+	}
+
+	if !u.hash.CompareHashAndPassword(user.Password, oldPassword) {
+		u.logger.Error("SERVICE: Current password is incorrect", "id", id)
+		return service_errors.MismatchedPassword
+	}
+
+	if !validPassword(newPassword) {
+		u.logger.Error("SERVICE: Invalid input")
+		return fmt.Errorf("SERVICE: Invalid input")
+	}
+
+	hashedPassword, err := u.hash.GetHash(newPassword)
+	if err != nil {
+		u.logger.Error("SERVICE: Error occurred during password hashing")
+		return err
+	}
+	user.Password = hashedPassword
+
+	_, err = u.UserRepository.Update(user)
+	if err != nil {
+		u.logger.Error("SERVICE: Update method failed", "error", err)
+		return err
+	}
+
+	u.logger.Info("SERVICE: Successfully changed user password", "id", id)
+	return nil
+}
+
+// GetCustomersWithCompletedOrders retrieves users who have at least one completed order.
+//
+// Returns:
+//   - []models.User: Slice of users with completed orders, without passwords
+//   - error: Any retrieval errors
+func (u UserService) GetCustomersWithCompletedOrders() ([]models.User, error) {
+	users, err := u.UserRepository.GetCustomersWithCompletedOrders()
+	if err != nil {
+		u.logger.Error("SERVICE-REPOSITORY: GetCustomersWithCompletedOrders method failed", "error", err)
+		return nil, err
+	}
+
+	u.logger.Info("SERVICE: Successfully got customers with completed orders", "count", len(users))
+	return users, nil
+}
+
+// GetAllUsers retrieves all clients. The result is converted to models.UserPublic,
+// so callers cannot receive or accidentally save a password.
+//
+// Returns:
+//   - []models.UserPublic: Slice of all clients ordered by surname and name
+//   - error: Any retrieval errors
+func (u UserService) GetAllUsers() ([]models.UserPublic, error) {
+	users, err := u.UserRepository.GetAllUsers()
+	if err != nil {
+		u.logger.Error("SERVICE-REPOSITORY: GetAllUsers method failed", "error", err)
+		return nil, err
+	}
+
+	public := make([]models.UserPublic, len(users))
+	for i, user := range users {
+		public[i] = user.Public()
+	}
+
+	u.logger.Info("SERVICE: Successfully got all users", "count", len(public))
+	return public, nil
+}
+
+// GetOrderStatistics summarizes the orders of a user for their profile.
+//
+// Parameters:
+//   - userID: UUID of the user
+//
+// Returns:
+//   - *models.UserStats: Order statistics, zero for a user without orders
+//   - error: repository_errors.DoesNotExist if the user is not found, any other retrieval errors
+func (u UserService) GetOrderStatistics(userID uuid.UUID) (*models.UserStats, error) {
+	_, err := u.UserRepository.GetUserByID(userID)
+	if err != nil {
+		u.logger.Error("SERVICE-REPOSITORY: GetUserByID method failed", "id", userID, "error", err)
+		return nil, err
+	}
+
+	stats, err := u.UserRepository.GetOrderStatistics(userID)
+	if err != nil {
+		u.logger.Error("SERVICE-REPOSITORY: GetOrderStatistics method failed", "id", userID, "error", err)
+		return nil, err
+	}
+
+	u.logger.Info("SERVICE: Successfully got user order statistics", "id", userID, "total_orders", stats.TotalOrders)
+	return stats, nil
+}
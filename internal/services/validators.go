@@ -4,11 +4,16 @@
 package interfaces
 
 import (
+	"fmt"
 	"github.com/google/uuid"
+	"math"
 	"net/mail"
 	"regexp"
+	"strings"
 	"teamdev/internal/models"
+	"teamdev/internal/services/service_errors"
 	"time"
+	"unicode/utf8"
 )
 
 // validName checks if a name (first name or surname) is valid.
@@ -35,16 +40,17 @@ func validPrice(price float64) bool {
 	return price > 0
 }
 
-// validCategory checks if a category ID is valid.
-// A valid category ID must be between 1 and 8 inclusive.
+// validPricePrecision checks that a price has at most two decimal places.
+// Differences below a millionth of a cent are treated as floating point noise.
 //
 // Parameters:
-//   - category: The category ID to validate
+//   - price: The price value to validate
 //
 // Returns:
-//   - bool: True if the category ID is valid, false otherwise
-func validCategory(category int) bool {
-	return category > 0 && category < 9
+//   - bool: True if the price is a whole number of cents, false otherwise
+func validPricePrecision(price float64) bool {
+	cents := price * 100
+	return math.Abs(cents-math.Round(cents)) < 1e-6
 }
 
 // validEmail checks if an email address is valid.
@@ -86,6 +92,30 @@ func validPhoneNumber(phoneNumber string) bool {
 	return re.MatchString(phoneNumber)
 }
 
+// phoneNumberSeparators lists the characters people put between digits of a
+// phone number for readability.
+var phoneNumberSeparators = strings.NewReplacer(" ", "", "-", "", "(", "", ")", "")
+
+// normalizePhoneNumber brings a phone number to the canonical E.164 form in
+// which it is stored, so the same number entered with or without separators
+// compares equal. Spaces, dashes and parentheses are removed.
+// Example: "+7 (912) 345-67-89" becomes "+79123456789"
+//
+// Parameters:
+//   - raw: The phone number as entered
+//
+// Returns:
+//   - string: The phone number in E.164 form
+//   - error: service_errors.InvalidPhoneNumber if the result is not a valid phone number
+func normalizePhoneNumber(raw string) (string, error) {
+	phoneNumber := phoneNumberSeparators.Replace(strings.TrimSpace(raw))
+	if !validPhoneNumber(phoneNumber) {
+		return "", service_errors.InvalidPhoneNumber
+	}
+
+	return phoneNumber, nil
+}
+
 // validPassword checks if a password is valid.
 // A valid password must be at least 8 characters long and contain
 // at least one letter and one number.
@@ -119,15 +149,27 @@ func validRole(role int) bool {
 }
 
 // validDeadline checks if an order deadline is valid.
-// A valid deadline must be in the future.
+// A valid deadline must be in the future, at least the minimum lead time away
+// and not further than the maximum horizon. Zero bounds are not checked.
 //
 // Parameters:
 //   - deadline: The deadline time to validate
+//   - now: Time the order is placed at
+//   - bounds: Minimum lead time and maximum horizon of the deadline
 //
 // Returns:
-//   - bool: True if the deadline is valid, false otherwise
-func validDeadline(deadline time.Time) bool {
-	return deadline.After(time.Now())
+//   - error: service_errors.InvalidDeadlineOrder wrapping service_errors.DeadlineInPast,
+//     service_errors.DeadlineTooSoon or service_errors.DeadlineTooFar, nil if the deadline is valid
+func validDeadline(deadline time.Time, now time.Time, bounds models.DeadlineSettings) error {
+	if !deadline.After(now) {
+		return fmt.Errorf("%w: %w", service_errors.InvalidDeadlineOrder, service_errors.DeadlineInPast)
+	} else if bounds.MinLeadTime > 0 && deadline.Sub(now) < bounds.MinLeadTime {
+		return fmt.Errorf("%w: %w, at least %s ahead is required", service_errors.InvalidDeadlineOrder, service_errors.DeadlineTooSoon, bounds.MinLeadTime)
+	} else if bounds.MaxHorizon > 0 && deadline.Sub(now) > bounds.MaxHorizon {
+		return fmt.Errorf("%w: %w, at most %s ahead is allowed", service_errors.InvalidDeadlineOrder, service_errors.DeadlineTooFar, bounds.MaxHorizon)
+	}
+
+	return nil
 }
 
 // validTasksNumber checks if an order contains at least one task.
@@ -154,6 +196,36 @@ func validStatus(status int) bool {
 	return status == models.NewOrderStatus || status == models.InProgressOrderStatus || status == models.CompletedOrderStatus || status == models.CancelledOrderStatus
 }
 
+// allowedStatusTransitions lists the statuses an order may move to from each status.
+// Completed and cancelled orders are terminal and can only be reopened explicitly.
+var allowedStatusTransitions = map[int][]int{
+	models.NewOrderStatus:        {models.InProgressOrderStatus, models.CancelledOrderStatus},
+	models.InProgressOrderStatus: {models.NewOrderStatus, models.CompletedOrderStatus, models.CancelledOrderStatus},
+}
+
+// validStatusTransition checks if an order may move from one status to another.
+// Keeping the current status is always allowed.
+//
+// Parameters:
+//   - from: Current status of the order
+//   - to: Requested status of the order
+//
+// Returns:
+//   - bool: True if the transition is allowed, false otherwise
+func validStatusTransition(from int, to int) bool {
+	if from == to {
+		return true
+	}
+
+	for _, status := range allowedStatusTransitions[from] {
+		if status == to {
+			return true
+		}
+	}
+
+	return false
+}
+
 // validRate checks if a user rating is valid.
 // A valid rating must be between 0 and 5 inclusive.
 //
@@ -166,6 +238,24 @@ func validRate(rate int) bool {
 	return rate >= 0 && rate <= 5
 }
 
+// maxTagLength is the maximum number of characters in an order tag.
+const maxTagLength = 32
+
+// normalizeTag brings an order tag to its stored form: surrounding spaces are
+// trimmed and letters are lowercased, so "VIP " and "vip" are the same tag.
+//
+// Parameters:
+//   - tag: The tag entered by a manager
+//
+// Returns:
+//   - string: The normalized tag
+//   - bool: True if the normalized tag is not empty and at most maxTagLength characters long
+func normalizeTag(tag string) (string, bool) {
+	tag = strings.ToLower(strings.TrimSpace(tag))
+	length := utf8.RuneCountInString(tag)
+	return tag, length > 0 && length <= maxTagLength
+}
+
 // taskIsAttachedToOrder checks if a task is attached to an order.
 // This validates whether a specific task is associated with an order
 // when managing order-task relationships.
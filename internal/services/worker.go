@@ -4,13 +4,24 @@
 package interfaces
 
 import (
+	"bytes"
+	"encoding/csv"
+	"errors"
 	"fmt"
 	"github.com/charmbracelet/log"
 	"github.com/google/uuid"
+	"math"
+	"strconv"
+	"strings"
+	"teamdev/auth"
 	"teamdev/internal/models"
+	"teamdev/internal/repository/repository_errors"
 	"teamdev/internal/repository/repository_interfaces"
+	"teamdev/internal/services/service_errors"
 	"teamdev/internal/services/service_interfaces"
 	"teamdev/password_hash"
+	"teamdev/second_factor"
+	"time"
 )
 
 // WorkerService implements the IWorkerService interface to handle worker-related
@@ -20,6 +31,11 @@ type WorkerService struct {
 	WorkerRepository repository_interfaces.IWorkerRepository // Repository for worker persistence
 	hash             password_hash.PasswordHash              // Password hashing utility
 	logger           *log.Logger                             // Logger for tracking operations
+	maxActiveOrders  int                                     // Maximum number of active orders per master (0 means unlimited)
+	secondFactor     second_factor.Provider                  // Verifies second factor codes
+	requireSecond    bool                                    // Whether login requires a second factor
+	loginAttempts    *loginAttempts                          // Failed login counter used for the lockout
+	tokens           auth.TokenIssuer                        // Issues session tokens, nil if tokens are not used
 }
 
 // NewWorkerService creates and initializes a new WorkerService with the provided dependencies.
@@ -28,17 +44,54 @@ type WorkerService struct {
 //   - WorkerRepository: Repository for accessing worker data
 //   - hash: Utility for password hashing and verification
 //   - logger: Logger for operation tracking and error reporting
+//   - maxActiveOrders: Maximum number of active orders a master may hold (0 means unlimited)
+//   - secondFactor: Provider verifying second factor codes (nil means none is configured)
+//   - requireSecondFactor: Whether a successful password check must be followed by a second factor
+//   - lockout: Threshold and window after which failed logins lock the email
+//   - tokens: Issuer of session tokens (nil means no tokens are issued)
 //
 // Returns:
 //   - service_interfaces.IWorkerService: Initialized worker service implementation
-func NewWorkerService(WorkerRepository repository_interfaces.IWorkerRepository, hash password_hash.PasswordHash, logger *log.Logger) service_interfaces.IWorkerService {
+func NewWorkerService(WorkerRepository repository_interfaces.IWorkerRepository, hash password_hash.PasswordHash, logger *log.Logger, maxActiveOrders int, secondFactor second_factor.Provider, requireSecondFactor bool, lockout models.LockoutSettings, tokens auth.TokenIssuer) service_interfaces.IWorkerService {
+	if secondFactor == nil {
+		secondFactor = second_factor.NewUnconfiguredProvider()
+	}
+
 	return &WorkerService{
 		WorkerRepository: WorkerRepository,
 		hash:             hash,
 		logger:           logger,
+		maxActiveOrders:  maxActiveOrders,
+		secondFactor:     secondFactor,
+		requireSecond:    requireSecondFactor,
+		loginAttempts:    newLoginAttempts(lockout),
+		tokens:           tokens,
 	}
 }
 
+// workerHasCapacity checks whether a worker may take one more active order.
+//
+// Parameters:
+//   - workerRepository: Repository used to count the worker's active orders
+//   - workerID: UUID of the worker to check
+//   - maxActiveOrders: Maximum number of active orders per worker (0 means unlimited)
+//
+// Returns:
+//   - bool: true if the worker holds fewer active orders than the limit
+//   - error: Repository error if counting fails
+func workerHasCapacity(workerRepository repository_interfaces.IWorkerRepository, workerID uuid.UUID, maxActiveOrders int) (bool, error) {
+	if maxActiveOrders <= 0 {
+		return true, nil
+	}
+
+	activeOrders, err := workerRepository.GetActiveOrdersCount(workerID)
+	if err != nil {
+		return false, err
+	}
+
+	return activeOrders < maxActiveOrders, nil
+}
+
 // checkIfWorkerWithEmailExists verifies if a worker with the specified email exists in the system.
 //
 // Parameters:
@@ -51,7 +104,7 @@ func (w WorkerService) checkIfWorkerWithEmailExists(email string) (*models.Worke
 	w.logger.Info("SERVICE: Checking if worker with email exists", "email", email)
 	tempWorker, err := w.WorkerRepository.GetWorkerByEmail(email)
 
-	if err != nil && err.Error() == "GET operation has failed. Such row does not exist" {
+	if err != nil && errors.Is(err, repository_errors.DoesNotExist) {
 		w.logger.Info("SERVICE: Worker with email does not exist", "email", email)
 		return nil, nil
 	} else if err != nil {
@@ -63,7 +116,48 @@ func (w WorkerService) checkIfWorkerWithEmailExists(email string) (*models.Worke
 	}
 }
 
+// validateWorkerFields checks the personal information and the role of a worker
+// and brings the phone number to E.164 form.
+//
+// Parameters:
+//   - name: First name of the worker
+//   - surname: Last name of the worker
+//   - email: Email address of the worker
+//   - address: Home address of the worker
+//   - phoneNumber: Phone number as entered
+//   - role: Role of the worker
+//
+// Returns:
+//   - string: Phone number in E.164 form
+//   - error: service_errors sentinel of the first invalid field, nil if all are valid
+func validateWorkerFields(name string, surname string, email string, address string, phoneNumber string, role int) (string, error) {
+	if !validName(name) {
+		return "", service_errors.InvalidName
+	} else if !validName(surname) {
+		return "", fmt.Errorf("%w: surname", service_errors.InvalidName)
+	} else if !validEmail(email) {
+		return "", service_errors.InvalidEmail
+	} else if !validAddress(address) {
+		return "", service_errors.InvalidAddress
+	}
+
+	phoneNumber, err := normalizePhoneNumber(phoneNumber)
+	if err != nil {
+		return "", err
+	}
+
+	if !validRole(role) {
+		return "", service_errors.InvalidRole
+	}
+
+	return phoneNumber, nil
+}
+
 // Login authenticates a worker using email and password credentials.
+// The time of a successful login is recorded as the worker's last login.
+// A password hash computed with a lower cost than the configured one is replaced
+// with a stronger one; failing to store it does not fail the login. After too many
+// failed attempts within the lockout window the email is locked out until the window elapses.
 //
 // Parameters:
 //   - email: Worker's email address for identification
@@ -71,44 +165,243 @@ func (w WorkerService) checkIfWorkerWithEmailExists(email string) (*models.Worke
 //
 // Returns:
 //   - *models.Worker: Authenticated worker if credentials are valid
-//   - error: Authentication error or repository error, nil if successful
-func (w WorkerService) Login(email, password string) (*models.Worker, error) {
+//   - error: Authentication error, LoginLocked if the email is locked out, or repository error; nil if successful
+func (w WorkerService) Login(email, password string) (*models.Worker, bool, error) {
+	if w.loginAttempts.isLocked(email) {
+		w.logger.Info("SERVICE: Login is locked for worker with email", "email", email)
+		return nil, false, service_errors.LoginLocked
+	}
+
 	w.logger.Infof("SERVICE: Checking if worker with email %s exists", email)
 	tempWorker, err := w.checkIfWorkerWithEmailExists(email)
 	if err != nil {
 		w.logger.Error("SERVICE: Error occurred during checking if worker with email exists")
-		return nil, err
+		return nil, false, err
 	} else if tempWorker == nil {
 		w.logger.Info("SERVICE: Worker with email does not exist")
-		return nil, fmt.Errorf("SERVICE: Worker with email does not exist")
+		w.recordFailedLogin(email)
+		return nil, false, fmt.Errorf("SERVICE: Worker with email does not exist")
 	}
 
 	w.logger.Infof("SERVICE: Checking if password is correct for worker with email %s", email)
-	isPasswordCorrect := w.hash.CompareHashAndPassword(tempWorker.Password, password)
+	newHash, isPasswordCorrect := w.hash.CompareAndMaybeRehash(tempWorker.Password, password)
 	if !isPasswordCorrect {
 		w.logger.Info("SERVICE: Password is incorrect for worker with email")
-		return nil, fmt.Errorf("SERVICE: Password is incorrect for worker with email")
+		w.recordFailedLogin(email)
+		return nil, false, fmt.Errorf("SERVICE: Password is incorrect for worker with email")
+	}
+
+	if newHash != "" {
+		err = w.WorkerRepository.UpdatePassword(tempWorker.ID, newHash)
+		if err != nil {
+			w.logger.Error("SERVICE: UpdatePassword method failed while upgrading password hash", "id", tempWorker.ID, "error", err)
+		} else {
+			tempWorker.Password = newHash
+			w.logger.Info("SERVICE: Upgraded password hash of worker", "id", tempWorker.ID)
+		}
+	}
+
+	w.loginAttempts.reset(email)
+
+	if w.requireSecond {
+		w.logger.Info("SERVICE: Second factor is required for worker with email", "email", email)
+		return tempWorker, true, nil
+	}
+
+	err = w.completeLogin(tempWorker)
+	if err != nil {
+		return nil, false, err
 	}
 
 	w.logger.Info("SERVICE: Successfully logged in worker with email", "email", email)
-	return tempWorker, nil
+	return tempWorker, false, nil
+}
+
+// recordFailedLogin counts a failed login and logs when it locks the email.
+//
+// Parameters:
+//   - email: Email used for the failed login
+func (w WorkerService) recordFailedLogin(email string) {
+	if w.loginAttempts.fail(email) {
+		w.logger.Warn("SERVICE: Too many failed logins, locking worker with email", "email", email)
+	}
+}
+
+// completeLogin records the moment of a finished login for the worker.
+//
+// Parameters:
+//   - worker: Authenticated worker, its LastLoginAt field is updated on success
+//
+// Returns:
+//   - error: Repository error if the login time cannot be stored
+func (w WorkerService) completeLogin(worker *models.Worker) error {
+	loggedInAt := time.Now()
+	err := w.WorkerRepository.UpdateLastLogin(worker.ID, loggedInAt)
+	if err != nil {
+		w.logger.Error("SERVICE: UpdateLastLogin method failed", "id", worker.ID, "error", err)
+		return err
+	}
+	worker.LastLoginAt = &loggedInAt
+
+	return nil
+}
+
+// VerifySecondFactor checks the second factor code of a worker whose password
+// has already been verified and completes the login on success.
+//
+// Parameters:
+//   - workerID: UUID of the worker finishing the login
+//   - code: Second factor code entered by the worker
+//
+// Returns:
+//   - error: InvalidSecondFactorCode if the code is empty or rejected,
+//     or an error from the provider or repository
+func (w WorkerService) VerifySecondFactor(workerID uuid.UUID, code string) error {
+	_, err := w.verifySecondFactor(workerID, code)
+	return err
+}
+
+// verifySecondFactor checks the second factor code of a worker and completes the login.
+//
+// Parameters:
+//   - workerID: UUID of the worker finishing the login
+//   - code: Second factor code entered by the worker
+//
+// Returns:
+//   - *models.Worker: The worker whose login was completed
+//   - error: InvalidSecondFactorCode if the code is empty or rejected,
+//     or an error from the provider or repository
+func (w WorkerService) verifySecondFactor(workerID uuid.UUID, code string) (*models.Worker, error) {
+	code = strings.TrimSpace(code)
+	if code == "" {
+		w.logger.Error("SERVICE: Empty second factor code", "id", workerID)
+		return nil, service_errors.InvalidSecondFactorCode
+	}
+
+	valid, err := w.secondFactor.Verify(workerID, code)
+	if err != nil {
+		w.logger.Error("SERVICE: Verify method failed", "id", workerID, "error", err)
+		return nil, err
+	} else if !valid {
+		w.logger.Info("SERVICE: Second factor code is incorrect", "id", workerID)
+		return nil, service_errors.InvalidSecondFactorCode
+	}
+
+	worker, err := w.WorkerRepository.GetWorkerByID(workerID)
+	if err != nil {
+		w.logger.Error("SERVICE: GetWorkerByID method failed", "id", workerID, "error", err)
+		return nil, err
+	}
+
+	err = w.completeLogin(worker)
+	if err != nil {
+		return nil, err
+	}
+
+	w.logger.Info("SERVICE: Successfully verified second factor", "id", workerID)
+	return worker, nil
+}
+
+// LoginWithToken authenticates a worker like Login and issues a session token
+// carrying the worker's role once the login is complete. While a second factor
+// is pending no token is issued, VerifySecondFactorWithToken returns it.
+//
+// Parameters:
+//   - email: Worker's email address
+//   - password: Plain text password to validate
+//
+// Returns:
+//   - *models.Worker: Authenticated worker data
+//   - bool: true if a second factor must be verified to finish the login
+//   - string: Session token, empty if the login is not complete or no token issuer is configured
+//   - error: Any authentication or token generation errors
+func (w WorkerService) LoginWithToken(email, password string) (*models.Worker, bool, string, error) {
+	worker, secondFactorRequired, err := w.Login(email, password)
+	if err != nil || secondFactorRequired {
+		return worker, secondFactorRequired, "", err
+	}
+
+	token, err := w.issueToken(worker)
+	if err != nil {
+		return nil, false, "", err
+	}
+
+	return worker, false, token, nil
+}
+
+// VerifySecondFactorWithToken completes the login like VerifySecondFactor and
+// issues a session token for the worker.
+//
+// Parameters:
+//   - workerID: UUID of the worker finishing the login
+//   - code: Second factor code entered by the worker
+//
+// Returns:
+//   - string: Session token, empty if no token issuer is configured
+//   - error: InvalidSecondFactorCode if the code is rejected, any verification
+//     or token generation errors
+func (w WorkerService) VerifySecondFactorWithToken(workerID uuid.UUID, code string) (string, error) {
+	worker, err := w.verifySecondFactor(workerID, code)
+	if err != nil {
+		return "", err
+	}
+
+	return w.issueToken(worker)
+}
+
+// issueToken issues a session token for a worker who has completed the login.
+//
+// Parameters:
+//   - worker: The logged in worker
+//
+// Returns:
+//   - string: Session token, empty if no token issuer is configured
+//   - error: Error if the token cannot be generated
+func (w WorkerService) issueToken(worker *models.Worker) (string, error) {
+	if w.tokens == nil {
+		return "", nil
+	}
+
+	token, err := w.tokens.GenerateToken(worker.ID, worker.Role)
+	if err != nil {
+		w.logger.Error("SERVICE: GenerateToken method failed", "id", worker.ID, "error", err)
+		return "", err
+	}
+
+	return token, nil
 }
 
 // Create registers a new worker in the system with validation of input data.
+// Only a manager can create workers. The first manager is created without an
+// editor while the system has no managers yet. The phone number is stored in E.164 form.
 //
 // Parameters:
+//   - editor: Worker performing the operation, nil only when bootstrapping the first manager
 //   - worker: Worker model with personal information to be registered
 //   - password: Plain text password to be hashed and stored
 //
 // Returns:
 //   - *models.Worker: Created worker with assigned ID if successful
-//   - error: Validation error or repository error, nil if successful
-func (w WorkerService) Create(worker *models.Worker, password string) (*models.Worker, error) {
+//   - error: service_errors.InvalidRole if the editor is not allowed to create workers,
+//     service_errors.NotUnique if the email is already taken, the service_errors
+//     sentinel of the first invalid field or repository error, nil if successful
+func (w WorkerService) Create(editor *models.Worker, worker *models.Worker, password string) (*models.Worker, error) {
+	if err := w.checkCanCreate(editor, worker); err != nil {
+		return nil, err
+	}
+
 	w.logger.Info("SERVICE: Validating data")
-	if !validName(worker.Name) || !validName(worker.Surname) || !validEmail(worker.Email) || !validAddress(worker.Address) || !validPhoneNumber(worker.PhoneNumber) || !validRole(worker.Role) || !validPassword(password) {
-		w.logger.Error("SERVICE: Invalid input")
-		return nil, fmt.Errorf("SERVICE: Invalid input")
+	phoneNumber, err := validateWorkerFields(worker.Name, worker.Surname, worker.Email, worker.Address, worker.PhoneNumber, worker.Role)
+	if err != nil {
+		w.logger.Error("SERVICE: Invalid input", "error", err)
+		return nil, err
+	}
+
+	if !validPassword(password) {
+		w.logger.Error("SERVICE: Invalid password")
+		return nil, service_errors.InvalidPassword
 	}
+	worker.PhoneNumber = phoneNumber
 
 	w.logger.Infof("SERVICE: Checking if worker with email %s exists", worker.Email)
 	tempWorker, err := w.checkIfWorkerWithEmailExists(worker.Email)
@@ -117,7 +410,7 @@ func (w WorkerService) Create(worker *models.Worker, password string) (*models.W
 		return nil, err
 	} else if tempWorker != nil {
 		w.logger.Info("SERVICE: Worker with email exists", "email", worker.Email)
-		return nil, fmt.Errorf("SERVICE: Worker with email already exists")
+		return nil, service_errors.NotUnique
 	}
 
 	w.logger.Infof("SERVICE: Creating new worker: %s %s", worker.Name, worker.Surname)
@@ -130,7 +423,11 @@ func (w WorkerService) Create(worker *models.Worker, password string) (*models.W
 	}
 
 	createdWorker, err := w.WorkerRepository.Create(worker)
-	if err != nil {
+	if errors.Is(err, repository_errors.AlreadyExists) {
+		// another worker with the same email was created after the check above
+		w.logger.Info("SERVICE: Worker with email exists", "email", worker.Email)
+		return nil, service_errors.NotUnique
+	} else if err != nil {
 		w.logger.Error("SERVICE: Create method failed", "error", err)
 		return nil, err
 	}
@@ -140,38 +437,142 @@ func (w WorkerService) Create(worker *models.Worker, password string) (*models.W
 	return createdWorker, nil
 }
 
+// checkCanCreate verifies that the editor may create the worker. A manager may
+// create any worker. Without an editor only the first manager may be created,
+// which is how the default administrator is set up on an empty system.
+//
+// Parameters:
+//   - editor: Worker performing the operation, may be nil
+//   - worker: Worker to be created
+//
+// Returns:
+//   - error: service_errors.InvalidRole if the creation is not allowed,
+//     repository error if the managers cannot be retrieved
+func (w WorkerService) checkCanCreate(editor *models.Worker, worker *models.Worker) error {
+	if isManager(editor) {
+		return nil
+	}
+
+	if editor != nil || worker.Role != models.ManagerRole {
+		w.logger.Error("SERVICE: Only a manager can create workers", "editor_id", editorID(editor))
+		return service_errors.InvalidRole
+	}
+
+	managers, err := w.WorkerRepository.GetWorkersByRole(models.ManagerRole, uuid.Nil)
+	if err != nil {
+		w.logger.Error("SERVICE: GetWorkersByRole method failed", "error", err)
+		return err
+	} else if len(managers) > 0 {
+		w.logger.Error("SERVICE: Managers already exist, an editor is required")
+		return service_errors.InvalidRole
+	}
+
+	return nil
+}
+
 // Delete removes a worker record from the system by ID.
+// A worker with active orders is only deleted when forced; the orders are then
+// unassigned in the same transaction as the deletion. Only a manager can delete workers.
 //
 // Parameters:
+//   - editor: Worker performing the operation
 //   - id: UUID of the worker to be deleted
+//   - force: Whether to unassign active orders instead of refusing the deletion
 //
 // Returns:
-//   - error: Repository error if deletion fails, nil if successful
-func (w WorkerService) Delete(id uuid.UUID) error {
+//   - error: service_errors.InvalidRole if the editor is not a manager,
+//     service_errors.WorkerHasActiveOrders if the worker has active orders and
+//     force is not set, repository error if deletion fails, nil if successful
+func (w WorkerService) Delete(editor *models.Worker, id uuid.UUID, force bool) error {
+	if !isManager(editor) {
+		w.logger.Error("SERVICE: Only a manager can delete workers", "editor_id", editorID(editor), "id", id)
+		return service_errors.InvalidRole
+	}
+
 	_, err := w.WorkerRepository.GetWorkerByID(id)
 	if err != nil {
 		w.logger.Error("SERVICE: GetWorkerByID method failed", "id", id, "error", err)
 		return err
 	}
 
+	if !force {
+		activeOrders, err := w.WorkerRepository.GetActiveOrdersCount(id)
+		if err != nil {
+			w.logger.Error("SERVICE: GetActiveOrdersCount method failed", "id", id, "error", err)
+			return err
+		} else if activeOrders > 0 {
+			w.logger.Error("SERVICE: Worker has active orders", "id", id, "active_orders", activeOrders)
+			return service_errors.WorkerHasActiveOrders
+		}
+	}
+
 	err = w.WorkerRepository.Delete(id)
 	if err != nil {
-		w.logger.Error("SERVICE: Delete method failed", "error", err)
+		w.logger.Error("SERVICE: Delete method failed", "id", id, "error", err)
+		return err
 	}
 
-	w.logger.Info("SERVICE: Successfully deleted worker", "id", id)
+	w.logger.Info("SERVICE: Successfully deleted worker", "id", id, "force", force)
 	return nil
 }
 
-// GetWorkerByID retrieves a worker by their unique identifier.
+// canAccessProfile checks whether the editor may view or change the profile of
+// the worker with the given ID. Managers may access any profile, other workers
+// only their own.
+//
+// Parameters:
+//   - editor: Worker performing the operation
+//   - id: UUID of the worker whose profile is accessed
+//
+// Returns:
+//   - bool: true if access is allowed
+func canAccessProfile(editor *models.Worker, id uuid.UUID) bool {
+	return editor != nil && (editor.Role == models.ManagerRole || editor.ID == id)
+}
+
+// isManager checks whether the editor may perform actions reserved for managers.
+//
+// Parameters:
+//   - editor: Worker performing the operation
+//
+// Returns:
+//   - bool: true if the editor is a manager
+func isManager(editor *models.Worker) bool {
+	return editor != nil && editor.Role == models.ManagerRole
+}
+
+// editorID returns the identifier of the editor for logging, so that log
+// records never contain the editor's password hash.
+//
+// Parameters:
+//   - editor: Worker performing the operation
+//
+// Returns:
+//   - uuid.UUID: ID of the editor, uuid.Nil if there is none
+func editorID(editor *models.Worker) uuid.UUID {
+	if editor == nil {
+		return uuid.Nil
+	}
+	return editor.ID
+}
+
+// GetWorkerByID retrieves a worker by their unique identifier. Only managers
+// may retrieve profiles of other workers.
 //
 // Parameters:
+//   - editor: Worker performing the operation
 //   - id: UUID of the worker to retrieve
 //
 // Returns:
 //   - *models.Worker: Retrieved worker if found
-//   - error: Repository error if retrieval fails, nil if successful
-func (w WorkerService) GetWorkerByID(id uuid.UUID) (*models.Worker, error) {
+//   - error: service_errors.PermissionDenied if the editor may not view the profile,
+//     repository error if retrieval fails, nil if successful
+func (w WorkerService) GetWorkerByID(editor *models.Worker, id uuid.UUID) (*models.Worker, error) {
+	if !canAccessProfile(editor, id) {
+		w.logger.Error("SERVICE: Worker is not allowed to view the profile", "editor_id", editorID(editor), "id", id)
+		return nil, service_errors.PermissionDenied
+	}
+
 	worker, err := w.WorkerRepository.GetWorkerByID(id)
 
 	if err != nil {
@@ -183,6 +584,36 @@ func (w WorkerService) GetWorkerByID(id uuid.UUID) (*models.Worker, error) {
 	return worker, nil
 }
 
+// GetWorkerByEmail retrieves a worker by their email address. Like with
+// GetWorkerByID, only managers may retrieve profiles of other workers. The
+// password hash is never returned.
+//
+// Parameters:
+//   - editor: Worker performing the operation
+//   - email: Email address of the worker to retrieve
+//
+// Returns:
+//   - *models.Worker: Retrieved worker entity without the password
+//   - error: service_errors.PermissionDenied if the editor may not view the profile,
+//     repository_errors.DoesNotExist if there is no such worker, any other retrieval errors
+func (w WorkerService) GetWorkerByEmail(editor *models.Worker, email string) (*models.Worker, error) {
+	worker, err := w.WorkerRepository.GetWorkerByEmail(email)
+
+	if err != nil {
+		w.logger.Error("SERVICE: GetWorkerByEmail method failed", "email", email, "error", err)
+		return nil, err
+	}
+
+	if !canAccessProfile(editor, worker.ID) {
+		w.logger.Error("SERVICE: Worker is not allowed to view the profile", "editor_id", editorID(editor), "id", worker.ID)
+		return nil, service_errors.PermissionDenied
+	}
+	worker.Password = ""
+
+	w.logger.Info("SERVICE: Successfully got worker with GetWorkerByEmail", "email", email)
+	return worker, nil
+}
+
 // GetAllWorkers retrieves all workers registered in the system.
 //
 // Returns:
@@ -200,9 +631,43 @@ func (w WorkerService) GetAllWorkers() ([]models.Worker, error) {
 	return workers, nil
 }
 
+// GetRoster retrieves the team split into managers and masters with a single
+// query. Password hashes are never included.
+//
+// Returns:
+//   - managers: Workers with the manager role
+//   - masters: Workers with the master role
+//   - err: Repository error if retrieval fails, nil if successful
+func (w WorkerService) GetRoster() (managers, masters []models.Worker, err error) {
+	workers, err := w.WorkerRepository.GetAllWorkers()
+	if err != nil {
+		w.logger.Error("SERVICE: GetAllWorkers method failed", "error", err)
+		return nil, nil, err
+	}
+
+	managers = make([]models.Worker, 0)
+	masters = make([]models.Worker, 0)
+	for _, worker := range workers {
+		worker.Password = ""
+		switch worker.Role {
+		case models.ManagerRole:
+			managers = append(managers, worker)
+		case models.MasterRole:
+			masters = append(masters, worker)
+		}
+	}
+
+	w.logger.Info("SERVICE: Successfully got team roster", "managers", len(managers), "masters", len(masters))
+	return managers, masters, nil
+}
+
 // Update modifies a worker's information after validating the new data.
+// Only managers may update profiles of other workers or change roles,
+// and the last remaining manager cannot be given another role.
+// The phone number is stored in E.164 form and the stored password hash is kept as is.
 //
 // Parameters:
+//   - editor: Worker performing the operation
 //   - id: UUID of the worker to update
 //   - name: New first name
 //   - surname: New last name
@@ -210,40 +675,54 @@ func (w WorkerService) GetAllWorkers() ([]models.Worker, error) {
 //   - address: New physical address
 //   - phoneNumber: New contact phone number
 //   - role: New role identifier
-//   - password: New password (will be hashed if different from current)
 //
 // Returns:
 //   - *models.Worker: Updated worker record
-//   - error: Validation error or repository error, nil if successful
-func (w WorkerService) Update(id uuid.UUID, name string, surname string, email string, address string, phoneNumber string, role int, password string) (*models.Worker, error) {
+//   - error: service_errors.PermissionDenied if the editor may not make the change,
+//     service_errors.LastManager if the only manager would be demoted,
+//     validation error or repository error, nil if successful
+func (w WorkerService) Update(editor *models.Worker, id uuid.UUID, name string, surname string, email string, address string, phoneNumber string, role int) (*models.Worker, error) {
+	if !canAccessProfile(editor, id) {
+		w.logger.Error("SERVICE: Worker is not allowed to update the profile", "editor_id", editorID(editor), "id", id)
+		return nil, service_errors.PermissionDenied
+	}
+
 	worker, err := w.WorkerRepository.GetWorkerByID(id)
 	if err != nil {
 		w.logger.Error("SERVICE: GetUserByID method failed", "id", id, "error", err)
 		return nil, err
 	}
 
-	if !validName(name) || !validName(surname) || !validEmail(email) || !validAddress(address) || !validPhoneNumber(phoneNumber) || !validRole(role) || !validPassword(password) {
-		w.logger.Error("SERVICE: Invalid input")
-		return nil, fmt.Errorf("SERVICE: Invalid input")
-	} else {
-		worker.Name = name
-		worker.Surname = surname
-		worker.Email = email
-		worker.Address = address
-		worker.PhoneNumber = phoneNumber
-		worker.Role = role
-
-		if password != worker.Password {
-			hashedPassword, hashErr := w.hash.GetHash(password)
-			if hashErr != nil {
-				w.logger.Error("SERVICE: Error occurred during password hashing")
-				return nil, hashErr
-			} else {
-				worker.Password = hashedPassword
-			}
+	if editor.Role != models.ManagerRole && role != worker.Role {
+		w.logger.Error("SERVICE: Worker is not allowed to change the role", "editor_id", editorID(editor), "id", id)
+		return nil, service_errors.PermissionDenied
+	}
+
+	phoneNumber, err = validateWorkerFields(name, surname, email, address, phoneNumber, role)
+	if err != nil {
+		w.logger.Error("SERVICE: Invalid input", "error", err)
+		return nil, err
+	}
+
+	if worker.Role == models.ManagerRole && role != models.ManagerRole {
+		managers, managersErr := w.WorkerRepository.GetWorkersByRole(models.ManagerRole, worker.ID)
+		if managersErr != nil {
+			w.logger.Error("SERVICE: GetWorkersByRole method failed", "error", managersErr)
+			return nil, managersErr
+		}
+		if len(managers) == 0 {
+			w.logger.Error("SERVICE: Cannot demote the last manager", "id", id)
+			return nil, service_errors.LastManager
 		}
 	}
 
+	worker.Name = name
+	worker.Surname = surname
+	worker.Email = email
+	worker.Address = address
+	worker.PhoneNumber = phoneNumber
+	worker.Role = role
+
 	worker, err = w.WorkerRepository.Update(worker)
 	if err != nil {
 		w.logger.Error("SERVICE: Update method failed", "error", err)
@@ -254,16 +733,87 @@ func (w WorkerService) Update(id uuid.UUID, name string, surname string, email s
 	return worker, nil
 }
 
+// ChangePassword replaces the worker's password after verifying the current one.
+//
+// Parameters:
+//   - id: UUID of the worker
+//   - oldPassword: Current plain text password
+//   - newPassword: New plain text password
+//
+// Returns:
+//   - error: service_errors.MismatchedPassword if the current password is wrong,
+//     validation, hashing or repository error, nil if successful
+func (w WorkerService) ChangePassword(id uuid.UUID, oldPassword string, newPassword string) error {
+	worker, err := w.WorkerRepository.GetWorkerByID(id)
+	if err != nil {
+		w.logger.Error("SERVICE: GetWorkerByID method failed", "id", id, "error", err)
+		return err
+	}
+
+	if !w.hash.CompareHashAndPassword(worker.Password, oldPassword) {
+		w.logger.Error("SERVICE: Current password is incorrect", "id", id)
+		return service_errors.MismatchedPassword
+	}
+
+	if !validPassword(newPassword) {
+		w.logger.Error("SERVICE: Invalid input")
+		return fmt.Errorf("SERVICE: Invalid input")
+	}
+
+	hashedPassword, err := w.hash.GetHash(newPassword)
+	if err != nil {
+		w.logger.Error("SERVICE: Error occurred during password hashing")
+		return err
+	}
+	worker.Password = hashedPassword
+
+	_, err = w.WorkerRepository.Update(worker)
+	if err != nil {
+		w.logger.Error("SERVICE: Update method failed", "error", err)
+		return err
+	}
+
+	w.logger.Info("SERVICE: Successfully changed worker password", "id", id)
+	return nil
+}
+
+// SetNotificationsOptOut records whether a worker wants to receive notifications.
+//
+// Parameters:
+//   - id: UUID of the worker
+//   - optOut: true to stop notifications, false to receive them again
+//
+// Returns:
+//   - error: Repository error if the worker is not found or update fails, nil if successful
+func (w WorkerService) SetNotificationsOptOut(id uuid.UUID, optOut bool) error {
+	worker, err := w.WorkerRepository.GetWorkerByID(id)
+	if err != nil {
+		w.logger.Error("SERVICE: GetWorkerByID method failed", "id", id, "error", err)
+		return err
+	}
+
+	worker.NotificationsOptOut = optOut
+	_, err = w.WorkerRepository.Update(worker)
+	if err != nil {
+		w.logger.Error("SERVICE: Update method failed", "error", err)
+		return err
+	}
+
+	w.logger.Info("SERVICE: Successfully changed notification preference", "id", id, "opt_out", optOut)
+	return nil
+}
+
 // GetWorkersByRole retrieves all workers with a specific role.
 //
 // Parameters:
 //   - role: Role identifier to filter by
+//   - excludeID: UUID of a worker to leave out, e.g. the acting manager (uuid.Nil to keep everyone)
 //
 // Returns:
 //   - []models.Worker: Slice of workers with the specified role
 //   - error: Repository error if retrieval fails, nil if successful
-func (w WorkerService) GetWorkersByRole(role int) ([]models.Worker, error) {
-	workers, err := w.WorkerRepository.GetWorkersByRole(role)
+func (w WorkerService) GetWorkersByRole(role int, excludeID uuid.UUID) ([]models.Worker, error) {
+	workers, err := w.WorkerRepository.GetWorkersByRole(role, excludeID)
 
 	if err != nil {
 		w.logger.Error("SERVICE: GetWorkersByRole method failed", "error", err)
@@ -299,3 +849,445 @@ func (w WorkerService) GetAverageOrderRate(worker *models.Worker) (float64, erro
 	w.logger.Info("SERVICE: Successfully got average order rate for worker", "worker", worker)
 	return workerRate, nil
 }
+
+// workersCSVHeader lists the columns of the worker roster produced by ExportCSV.
+var workersCSVHeader = []string{"name", "surname", "email", "phone_number", "address", "role", "average_rating"}
+
+// ExportCSV builds a CSV roster of all workers with their contact details,
+// role label and average rating. Passwords are never included.
+//
+// Returns:
+//   - []byte: CSV document including a header row
+//   - error: Repository error if retrieval fails or encoding error, nil if successful
+func (w WorkerService) ExportCSV() ([]byte, error) {
+	workers, err := w.WorkerRepository.GetAllWorkers()
+	if err != nil {
+		w.logger.Error("SERVICE: GetAllWorkers method failed", "error", err)
+		return nil, err
+	}
+
+	var buffer bytes.Buffer
+	writer := csv.NewWriter(&buffer)
+
+	err = writer.Write(workersCSVHeader)
+	if err != nil {
+		w.logger.Error("SERVICE: Error occurred during writing csv header", "error", err)
+		return nil, err
+	}
+
+	for i := range workers {
+		rate, rateErr := w.WorkerRepository.GetAverageOrderRate(&workers[i])
+		if rateErr != nil {
+			w.logger.Error("SERVICE: GetAverageOrderRate method failed", "id", workers[i].ID, "error", rateErr)
+			return nil, rateErr
+		}
+
+		err = writer.Write([]string{
+			workers[i].Name,
+			workers[i].Surname,
+			workers[i].Email,
+			workers[i].PhoneNumber,
+			workers[i].Address,
+			workers[i].DisplayRole(),
+			strconv.FormatFloat(rate, 'f', 2, 64),
+		})
+		if err != nil {
+			w.logger.Error("SERVICE: Error occurred during writing csv row", "id", workers[i].ID, "error", err)
+			return nil, err
+		}
+	}
+
+	writer.Flush()
+	if err = writer.Error(); err != nil {
+		w.logger.Error("SERVICE: Error occurred during flushing csv", "error", err)
+		return nil, err
+	}
+
+	w.logger.Info("SERVICE: Successfully exported workers to csv", "count", len(workers))
+	return buffer.Bytes(), nil
+}
+
+// earningsCSVHeader lists the columns of the statement produced by ExportEarningsCSV.
+var earningsCSVHeader = []string{"order_id", "completed_at", "address", "total"}
+
+// ExportEarningsCSV builds a CSV statement of the orders a worker completed
+// in a calendar year, with completion dates, totals and a closing year total row.
+//
+// Parameters:
+//   - workerID: UUID of the worker
+//   - year: Calendar year of completion
+//
+// Returns:
+//   - []byte: CSV document including a header row and a total row
+//   - error: Validation error for an invalid year, repository error if the worker
+//     does not exist or retrieval fails, encoding error otherwise, nil if successful
+func (w WorkerService) ExportEarningsCSV(workerID uuid.UUID, year int) ([]byte, error) {
+	if year <= 0 {
+		w.logger.Error("SERVICE: Invalid input", "year", year)
+		return nil, fmt.Errorf("SERVICE: Invalid input")
+	}
+
+	_, err := w.WorkerRepository.GetWorkerByID(workerID)
+	if err != nil {
+		w.logger.Error("SERVICE: GetWorkerByID method failed", "id", workerID, "error", err)
+		return nil, err
+	}
+
+	orders, err := w.WorkerRepository.GetCompletedOrdersInYear(workerID, year)
+	if err != nil {
+		w.logger.Error("SERVICE: GetCompletedOrdersInYear method failed", "id", workerID, "year", year, "error", err)
+		return nil, err
+	}
+
+	var buffer bytes.Buffer
+	writer := csv.NewWriter(&buffer)
+
+	err = writer.Write(earningsCSVHeader)
+	if err != nil {
+		w.logger.Error("SERVICE: Error occurred during writing csv header", "error", err)
+		return nil, err
+	}
+
+	var total float64
+	for _, order := range orders {
+		completedAt := ""
+		if order.CompletedAt != nil {
+			completedAt = order.CompletedAt.Format(time.DateOnly)
+		}
+
+		err = writer.Write([]string{
+			order.ID.String(),
+			completedAt,
+			order.Address,
+			strconv.FormatFloat(order.QuotedTotal, 'f', 2, 64),
+		})
+		if err != nil {
+			w.logger.Error("SERVICE: Error occurred during writing csv row", "id", order.ID, "error", err)
+			return nil, err
+		}
+		total += order.QuotedTotal
+	}
+
+	err = writer.Write([]string{"total", strconv.Itoa(year), "", strconv.FormatFloat(total, 'f', 2, 64)})
+	if err != nil {
+		w.logger.Error("SERVICE: Error occurred during writing csv total row", "error", err)
+		return nil, err
+	}
+
+	writer.Flush()
+	if err = writer.Error(); err != nil {
+		w.logger.Error("SERVICE: Error occurred during flushing csv", "error", err)
+		return nil, err
+	}
+
+	w.logger.Info("SERVICE: Successfully exported worker earnings to csv", "id", workerID, "year", year, "count", len(orders))
+	return buffer.Bytes(), nil
+}
+
+// HasCapacity checks whether a worker may be assigned one more active order
+// without exceeding the configured limit.
+//
+// Parameters:
+//   - workerID: UUID of the worker to check
+//
+// Returns:
+//   - bool: true if the worker can take another order
+//   - error: Repository error if the worker does not exist or counting fails
+func (w WorkerService) HasCapacity(workerID uuid.UUID) (bool, error) {
+	_, err := w.WorkerRepository.GetWorkerByID(workerID)
+	if err != nil {
+		w.logger.Error("SERVICE: GetWorkerByID method failed", "id", workerID, "error", err)
+		return false, err
+	}
+
+	hasCapacity, err := workerHasCapacity(w.WorkerRepository, workerID, w.maxActiveOrders)
+	if err != nil {
+		w.logger.Error("SERVICE: GetActiveOrdersCount method failed", "id", workerID, "error", err)
+		return false, err
+	}
+
+	w.logger.Info("SERVICE: Successfully checked worker capacity", "id", workerID, "has_capacity", hasCapacity)
+	return hasCapacity, nil
+}
+
+// GetWorkerWorkload counts the new and in-progress orders assigned to a worker.
+//
+// Parameters:
+//   - workerID: UUID of the worker
+//
+// Returns:
+//   - int: Number of active orders of the worker
+//   - error: Repository error if the worker does not exist or counting fails
+func (w WorkerService) GetWorkerWorkload(workerID uuid.UUID) (int, error) {
+	_, err := w.WorkerRepository.GetWorkerByID(workerID)
+	if err != nil {
+		w.logger.Error("SERVICE: GetWorkerByID method failed", "id", workerID, "error", err)
+		return 0, err
+	}
+
+	workload, err := w.WorkerRepository.GetActiveOrdersCount(workerID)
+	if err != nil {
+		w.logger.Error("SERVICE: GetActiveOrdersCount method failed", "id", workerID, "error", err)
+		return 0, err
+	}
+
+	w.logger.Info("SERVICE: Successfully got worker workload", "id", workerID, "workload", workload)
+	return workload, nil
+}
+
+// GetLeastBusyWorker finds the master with the fewest new and in-progress orders,
+// so that an order can be assigned in one step. Ties are resolved by the lowest
+// worker ID, which keeps the choice stable between calls.
+//
+// Returns:
+//   - *models.Worker: Least busy master without the password hash
+//   - error: service_errors.NoMasters if there are no masters, repository error otherwise
+func (w WorkerService) GetLeastBusyWorker() (*models.Worker, error) {
+	masters, err := w.WorkerRepository.GetWorkersByRole(models.MasterRole, uuid.Nil)
+	if err != nil {
+		w.logger.Error("SERVICE: GetWorkersByRole method failed", "error", err)
+		return nil, err
+	}
+
+	if len(masters) == 0 {
+		w.logger.Error("SERVICE: There are no masters")
+		return nil, service_errors.NoMasters
+	}
+
+	var leastBusy *models.Worker
+	leastWorkload := 0
+	for i := range masters {
+		workload, err := w.WorkerRepository.GetActiveOrdersCount(masters[i].ID)
+		if err != nil {
+			w.logger.Error("SERVICE: GetActiveOrdersCount method failed", "id", masters[i].ID, "error", err)
+			return nil, err
+		}
+
+		if leastBusy == nil || workload < leastWorkload ||
+			(workload == leastWorkload && bytes.Compare(masters[i].ID[:], leastBusy.ID[:]) < 0) {
+			leastBusy = &masters[i]
+			leastWorkload = workload
+		}
+	}
+
+	leastBusy.Password = ""
+
+	w.logger.Info("SERVICE: Successfully found least busy worker", "id", leastBusy.ID, "workload", leastWorkload)
+	return leastBusy, nil
+}
+
+// GetRevenueBetween sums the totals of completed orders assigned to a worker
+// and completed within the given period.
+//
+// Parameters:
+//   - workerID: UUID of the worker
+//   - from: Start of the period (inclusive)
+//   - to: End of the period (inclusive)
+//
+// Returns:
+//   - float64: Total revenue of the worker in the period
+//   - error: Validation error if the period is invalid, repository error otherwise, nil if successful
+func (w WorkerService) GetRevenueBetween(workerID uuid.UUID, from time.Time, to time.Time) (float64, error) {
+	if to.Before(from) {
+		w.logger.Error("SERVICE: Invalid input", "from", from, "to", to)
+		return 0, fmt.Errorf("SERVICE: Invalid input")
+	}
+
+	_, err := w.WorkerRepository.GetWorkerByID(workerID)
+	if err != nil {
+		w.logger.Error("SERVICE: GetWorkerByID method failed", "id", workerID, "error", err)
+		return 0, err
+	}
+
+	revenue, err := w.WorkerRepository.GetRevenueBetween(workerID, from, to)
+	if err != nil {
+		w.logger.Error("SERVICE: GetRevenueBetween method failed", "id", workerID, "error", err)
+		return 0, err
+	}
+
+	w.logger.Info("SERVICE: Successfully got worker revenue", "id", workerID, "revenue", revenue)
+	return revenue, nil
+}
+
+// GetTeamComparison ranks every master relative to the team by average rating
+// and number of completed orders.
+//
+// Returns:
+//   - []models.WorkerComparison: Comparison of every master, best rated first
+//   - error: Repository error if retrieval fails, nil if successful
+func (w WorkerService) GetTeamComparison() ([]models.WorkerComparison, error) {
+	comparisons, err := w.WorkerRepository.GetTeamComparison()
+	if err != nil {
+		w.logger.Error("SERVICE: GetTeamComparison method failed", "error", err)
+		return nil, err
+	}
+
+	w.logger.Info("SERVICE: Successfully got team comparison", "workers", len(comparisons))
+	return comparisons, nil
+}
+
+// GetAssignmentCandidates lists the masters rated at or above the threshold who
+// have not reached the maximum number of active orders. Masters without rated
+// orders have a rating of 0, so they are only included for a threshold of 0.
+//
+// Parameters:
+//   - minRating: Minimum average rating of a candidate (0-5)
+//
+// Returns:
+//   - []models.WorkerWithRating: Candidates, best rated first, then least busy first
+//   - error: service_errors.RatingOutOfRange for an invalid threshold, retrieval error otherwise
+func (w WorkerService) GetAssignmentCandidates(minRating float64) ([]models.WorkerWithRating, error) {
+	if minRating < 0 || minRating > 5 {
+		w.logger.Error("SERVICE: Rating threshold is out of range", "min_rating", minRating)
+		return nil, service_errors.RatingOutOfRange
+	}
+
+	candidates, err := w.WorkerRepository.GetAssignmentCandidates(minRating, w.maxActiveOrders)
+	if err != nil {
+		w.logger.Error("SERVICE: GetAssignmentCandidates method failed", "min_rating", minRating, "error", err)
+		return nil, err
+	}
+
+	w.logger.Info("SERVICE: Successfully got assignment candidates", "min_rating", minRating, "candidates", len(candidates))
+	return candidates, nil
+}
+
+// GetPerformanceReport summarizes the productivity of every master: the numbers
+// of completed and cancelled orders, the average rating and the revenue.
+//
+// Returns:
+//   - []models.WorkerPerformance: Report row of every master, best rated first
+//   - error: Repository error if retrieval fails, nil if successful
+func (w WorkerService) GetPerformanceReport() ([]models.WorkerPerformance, error) {
+	report, err := w.WorkerRepository.GetPerformanceReport()
+	if err != nil {
+		w.logger.Error("SERVICE: GetPerformanceReport method failed", "error", err)
+		return nil, err
+	}
+
+	w.logger.Info("SERVICE: Successfully got performance report", "workers", len(report))
+	return report, nil
+}
+
+// GetRecencyWeightedRating computes the average rating of a worker's completed
+// orders where every rating is weighted by 0.5^(age/halfLife), so an order
+// completed one half-life ago counts half as much as one completed now. Ages are
+// measured from the newest rating, which gives the same average as measuring them
+// from now but keeps the weights from vanishing when every rating is old.
+//
+// Parameters:
+//   - workerID: UUID of the worker
+//   - halfLife: Age at which a rating loses half of its weight
+//
+// Returns:
+//   - float64: Weighted average rating, 0 if the worker has no rated orders
+//   - error: Validation error if the half-life is not positive, repository error otherwise, nil if successful
+func (w WorkerService) GetRecencyWeightedRating(workerID uuid.UUID, halfLife time.Duration) (float64, error) {
+	if halfLife <= 0 {
+		w.logger.Error("SERVICE: Invalid input", "halfLife", halfLife)
+		return 0, fmt.Errorf("SERVICE: Invalid input")
+	}
+
+	_, err := w.WorkerRepository.GetWorkerByID(workerID)
+	if err != nil {
+		w.logger.Error("SERVICE: GetWorkerByID method failed", "id", workerID, "error", err)
+		return 0, err
+	}
+
+	ratings, err := w.WorkerRepository.GetCompletedOrderRatings(workerID)
+	if err != nil {
+		w.logger.Error("SERVICE: GetCompletedOrderRatings method failed", "id", workerID, "error", err)
+		return 0, err
+	}
+
+	var newest time.Time
+	for _, rating := range ratings {
+		if rating.CompletedAt.After(newest) {
+			newest = rating.CompletedAt
+		}
+	}
+
+	var weightedSum, weightsSum float64
+	for _, rating := range ratings {
+		age := newest.Sub(rating.CompletedAt)
+		weight := math.Pow(0.5, float64(age)/float64(halfLife))
+		weightedSum += weight * float64(rating.Rate)
+		weightsSum += weight
+	}
+
+	var weightedRating float64
+	if weightsSum > 0 {
+		weightedRating = weightedSum / weightsSum
+	}
+
+	w.logger.Info("SERVICE: Successfully got recency weighted rating", "id", workerID, "rating", weightedRating)
+	return weightedRating, nil
+}
+
+// SetAvailability marks a worker as available or unavailable during a period.
+// The latest call wins where periods overlap, so a day off can be taken back by
+// marking the same period as available again. Only a manager can change the
+// availability.
+//
+// Parameters:
+//   - editor: Worker performing the operation
+//   - workerID: UUID of the worker
+//   - from: Start of the period (inclusive)
+//   - to: End of the period (exclusive)
+//   - available: Whether the worker can take orders during the period
+//
+// Returns:
+//   - error: service_errors.InvalidRole if the editor is not a manager,
+//     service_errors.InvalidPeriod if the period is empty, repository error otherwise, nil if successful
+func (w WorkerService) SetAvailability(editor *models.Worker, workerID uuid.UUID, from time.Time, to time.Time, available bool) error {
+	if !isManager(editor) {
+		w.logger.Error("SERVICE: Only a manager can change worker availability", "editor_id", editorID(editor), "id", workerID)
+		return service_errors.InvalidRole
+	}
+
+	if !from.Before(to) {
+		w.logger.Error("SERVICE: Invalid period", "from", from, "to", to)
+		return service_errors.InvalidPeriod
+	}
+
+	_, err := w.WorkerRepository.GetWorkerByID(workerID)
+	if err != nil {
+		w.logger.Error("SERVICE: GetWorkerByID method failed", "id", workerID, "error", err)
+		return err
+	}
+
+	err = w.WorkerRepository.SetAvailability(workerID, from, to, available)
+	if err != nil {
+		w.logger.Error("SERVICE: SetAvailability method failed", "id", workerID, "error", err)
+		return err
+	}
+
+	w.logger.Info("SERVICE: Successfully set worker availability", "id", workerID, "from", from, "to", to, "available", available)
+	return nil
+}
+
+// IsAvailable reports whether a worker is available at the given moment.
+// Workers are available unless a period covering the moment marks them otherwise.
+//
+// Parameters:
+//   - workerID: UUID of the worker
+//   - at: Moment to check
+//
+// Returns:
+//   - bool: Whether the worker is available
+//   - error: Repository error if the worker is not found or retrieval fails, nil if successful
+func (w WorkerService) IsAvailable(workerID uuid.UUID, at time.Time) (bool, error) {
+	_, err := w.WorkerRepository.GetWorkerByID(workerID)
+	if err != nil {
+		w.logger.Error("SERVICE: GetWorkerByID method failed", "id", workerID, "error", err)
+		return false, err
+	}
+
+	available, err := w.WorkerRepository.IsAvailable(workerID, at)
+	if err != nil {
+		w.logger.Error("SERVICE: IsAvailable method failed", "id", workerID, "error", err)
+		return false, err
+	}
+
+	return available, nil
+}
@@ -10,11 +10,12 @@
 //
 // It initializes the application configuration, sets up service dependencies,
 // ensures a default admin user exists, and runs the application in the
-// configured mode (currently supporting command-line interface).
+// configured mode: the command-line interface or the HTTP/REST server.
 package main
 
 import (
 	"fmt"
+	"github.com/google/uuid"
 	"os"
 	"teamdev/cmd"
 	"teamdev/internal/models"
@@ -27,8 +28,8 @@ import (
 // main is the entry point for the TeamDev application.
 // It initializes the application, sets up dependencies,
 // creates a default admin user if one doesn't exist,
-// and runs either in command-line mode or exits with an error
-// if an invalid mode is specified.
+// and runs either in command-line mode or as an HTTP server, logging an
+// error if an invalid mode is specified.
 func main() {
 	app := registry.App{}
 
@@ -42,7 +43,7 @@ func main() {
 		log.Fatal(err)
 	}
 
-	err = app.Run()
+	err = app.Init()
 
 	if err != nil {
 		fmt.Println("Error")
@@ -55,19 +56,27 @@ func main() {
 		return
 	}
 
-	if app.Config.Mode == "cmd" {
+	switch app.Config.Mode {
+	case "cmd":
 		cmdErr := cmd.RunMenu(app.Services)
 		if cmdErr != nil {
 			log.Fatal(cmdErr)
 			return
 		}
-	} else {
+	case "http":
+		httpErr := app.Run()
+		if httpErr != nil {
+			log.Fatal(httpErr)
+			return
+		}
+	default:
 		log.Error("Wrong app mode", "mode", app.Config.Mode)
 	}
 }
 
 // initAdmin ensures there is at least one admin user in the system.
 // If no admin users exist, it creates a default admin user using environment variables.
+// The worker service allows creating the first manager without an acting manager.
 //
 // Parameters:
 //   - services: Application services registry containing worker service
@@ -83,7 +92,7 @@ func main() {
 //   - ADMIN_ADDRESS: Physical address of the default admin
 //   - ADMIN_PASSWORD: Password for the default admin user
 func initAdmin(services *registry.Services) error {
-	admins, err := services.WorkerService.GetWorkersByRole(models.ManagerRole)
+	admins, err := services.WorkerService.GetWorkersByRole(models.ManagerRole, uuid.Nil)
 	if err != nil {
 		return err
 	}
@@ -97,7 +106,7 @@ func initAdmin(services *registry.Services) error {
 			PhoneNumber: os.Getenv("ADMIN_PHONE"),
 			Address:     os.Getenv("ADMIN_ADDRESS"),
 		}
-		_, err = services.WorkerService.Create(defaultAdmin, os.Getenv("ADMIN_PASSWORD"))
+		_, err = services.WorkerService.Create(nil, defaultAdmin, os.Getenv("ADMIN_PASSWORD"))
 		if err != nil {
 			return err
 		}
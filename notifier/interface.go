@@ -0,0 +1,33 @@
+package notifier
+
+import "teamdev/internal/models"
+
+// Notifier defines the interface for delivering messages to workers and
+// publishing order events to external systems.
+type Notifier interface {
+	// NotifyWorker delivers the message to the given worker.
+	// Returns an error if the message could not be delivered.
+	NotifyWorker(worker models.Worker, message string) error
+
+	// NotifyAssignment informs the worker about an order assigned to them,
+	// including the address, deadline and tasks of the order.
+	// Returns an error if the notification could not be delivered.
+	NotifyAssignment(worker models.Worker, info models.DispatchInfo) error
+
+	// NotifyOrderEvent publishes a change of an order, such as its completion,
+	// to external systems.
+	// Returns an error if the event could not be delivered.
+	NotifyOrderEvent(event models.OrderEvent) error
+}
+
+// OrderNotifier defines the hooks invoked after changes of an order, so that
+// customers and workers can be told about them, for example by email or SMS.
+// The hooks are called after the change is stored and cannot undo it.
+type OrderNotifier interface {
+	// OrderStatusChanged is called after the status of the order changed
+	// from oldStatus to newStatus.
+	OrderStatusChanged(order *models.Order, oldStatus int, newStatus int)
+
+	// OrderAssigned is called after the worker was assigned to the order.
+	OrderAssigned(order *models.Order, worker *models.Worker)
+}
@@ -0,0 +1,86 @@
+// Package notifier provides delivery of alerts to workers, such as letting
+// managers know that a new order is waiting for assignment and sending
+// masters the details of orders assigned to them, publishing order events,
+// such as completion with the final amount, to external systems, and hooks
+// invoked when an order changes status or gets assigned.
+package notifier
+
+import (
+	"fmt"
+	"strings"
+	"teamdev/internal/models"
+
+	"github.com/charmbracelet/log"
+)
+
+// logNotifier implements the Notifier interface by writing messages to the
+// application log. It is used until a real delivery channel is configured.
+type logNotifier struct {
+	logger *log.Logger
+}
+
+// NewLogNotifier creates a Notifier that records messages in the given logger.
+func NewLogNotifier(logger *log.Logger) Notifier {
+	return &logNotifier{logger: logger}
+}
+
+// NotifyWorker writes the message addressed to the worker to the log.
+func (l *logNotifier) NotifyWorker(worker models.Worker, message string) error {
+	l.logger.Info("NOTIFIER: Message to worker", "id", worker.ID, "email", worker.Email, "message", message)
+	return nil
+}
+
+// NotifyAssignment writes the details of the order assigned to the worker to the log.
+func (l *logNotifier) NotifyAssignment(worker models.Worker, info models.DispatchInfo) error {
+	tasks := make([]string, 0, len(info.Tasks))
+	for _, task := range info.Tasks {
+		tasks = append(tasks, fmt.Sprintf("%s x%d", task.Task.Name, task.Quantity))
+	}
+
+	l.logger.Info("NOTIFIER: Order assigned to worker", "id", worker.ID, "email", worker.Email,
+		"order_id", info.OrderID, "address", info.Address, "deadline", info.Deadline, "tasks", strings.Join(tasks, ", "))
+	return nil
+}
+
+// NotifyOrderEvent writes the order event to the log.
+func (l *logNotifier) NotifyOrderEvent(event models.OrderEvent) error {
+	l.logger.Info("NOTIFIER: Order event", "type", event.Type, "order_id", event.OrderID,
+		"grand_total", event.GrandTotal, "occurred_at", event.OccurredAt)
+	return nil
+}
+
+// nopOrderNotifier implements the OrderNotifier interface by ignoring every change.
+type nopOrderNotifier struct{}
+
+// NewNopOrderNotifier creates an OrderNotifier that does nothing.
+func NewNopOrderNotifier() OrderNotifier {
+	return nopOrderNotifier{}
+}
+
+// OrderStatusChanged ignores the status change.
+func (nopOrderNotifier) OrderStatusChanged(*models.Order, int, int) {}
+
+// OrderAssigned ignores the assignment.
+func (nopOrderNotifier) OrderAssigned(*models.Order, *models.Worker) {}
+
+// logOrderNotifier implements the OrderNotifier interface by writing order
+// changes to the application log.
+type logOrderNotifier struct {
+	logger *log.Logger
+}
+
+// NewLogOrderNotifier creates an OrderNotifier that records order changes in the given logger.
+func NewLogOrderNotifier(logger *log.Logger) OrderNotifier {
+	return &logOrderNotifier{logger: logger}
+}
+
+// OrderStatusChanged writes the status change of the order to the log.
+func (l *logOrderNotifier) OrderStatusChanged(order *models.Order, oldStatus int, newStatus int) {
+	l.logger.Info("NOTIFIER: Order status changed", "order_id", order.ID, "user_id", order.UserID,
+		"from", oldStatus, "to", newStatus)
+}
+
+// OrderAssigned writes the assignment of the order to the log.
+func (l *logOrderNotifier) OrderAssigned(order *models.Order, worker *models.Worker) {
+	l.logger.Info("NOTIFIER: Order assigned", "order_id", order.ID, "worker_id", worker.ID, "email", worker.Email)
+}
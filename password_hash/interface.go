@@ -9,4 +9,9 @@ type PasswordHash interface {
 	// CompareHashAndPassword verifies if a plaintext password matches a hashed password.
 	// Returns true if the passwords match, false otherwise.
 	CompareHashAndPassword(hashedPassword, plainPassword string) bool
+
+	// CompareAndMaybeRehash verifies a plaintext password like CompareHashAndPassword.
+	// When the password matches but the hash is weaker than the configured one,
+	// a new hash of the password is returned as well, otherwise newHash is empty.
+	CompareAndMaybeRehash(hashedPassword, plainPassword string) (newHash string, ok bool)
 }
@@ -6,20 +6,39 @@ import (
 	"golang.org/x/crypto/bcrypt"
 )
 
+// DefaultCost is the bcrypt cost used when no other cost is configured.
+const DefaultCost = bcrypt.DefaultCost
+
+// MinCost and MaxCost bound the bcrypt cost that can be configured.
+const (
+	MinCost = bcrypt.MinCost
+	MaxCost = bcrypt.MaxCost
+)
+
 // bcryptHash implements the PasswordHash interface using bcrypt algorithm.
 type bcryptHash struct {
+	cost int // bcrypt cost of newly generated hashes
 }
 
 // NewPasswordHash creates and returns a new PasswordHash implementation.
 // This is the entry point for creating password hashers.
 func NewPasswordHash() PasswordHash {
-	return &bcryptHash{}
+	return &bcryptHash{cost: DefaultCost}
+}
+
+// NewPasswordHashWithCost creates a PasswordHash that generates hashes with the given
+// bcrypt cost. A cost outside of MinCost and MaxCost is replaced with DefaultCost.
+func NewPasswordHashWithCost(cost int) PasswordHash {
+	if cost < MinCost || cost > MaxCost {
+		cost = DefaultCost
+	}
+	return &bcryptHash{cost: cost}
 }
 
 // GetHash generates a secure bcrypt hash from a plaintext string.
-// It uses the default cost for the hash computation.
+// It uses the configured cost for the hash computation.
 func (b *bcryptHash) GetHash(stringToHash string) (string, error) {
-	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(stringToHash), bcrypt.DefaultCost)
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(stringToHash), b.cost)
 	return string(hashedPassword), err
 }
 
@@ -29,3 +48,25 @@ func (b *bcryptHash) CompareHashAndPassword(hashedPassword, plainPassword string
 	res := bcrypt.CompareHashAndPassword([]byte(hashedPassword), []byte(plainPassword))
 	return res == nil
 }
+
+// CompareAndMaybeRehash verifies a plaintext password and, if it matches a hash
+// computed with a lower cost than the configured one, hashes it again with the
+// configured cost. A failed rehash does not fail the comparison, the old hash
+// simply stays in use.
+func (b *bcryptHash) CompareAndMaybeRehash(hashedPassword, plainPassword string) (string, bool) {
+	if !b.CompareHashAndPassword(hashedPassword, plainPassword) {
+		return "", false
+	}
+
+	cost, err := bcrypt.Cost([]byte(hashedPassword))
+	if err != nil || cost >= b.cost {
+		return "", true
+	}
+
+	newHash, err := b.GetHash(plainPassword)
+	if err != nil {
+		return "", true
+	}
+
+	return newHash, true
+}
@@ -0,0 +1,10 @@
+package second_factor
+
+import "github.com/google/uuid"
+
+// Provider defines the interface for checking second factor codes of workers.
+type Provider interface {
+	// Verify reports whether the code is a valid second factor for the worker.
+	// Returns NotConfigured if no second factor mechanism is available.
+	Verify(workerID uuid.UUID, code string) (bool, error)
+}
@@ -0,0 +1,27 @@
+// Package second_factor provides verification of second authentication factors
+// used after a worker has entered a correct password.
+package second_factor
+
+import (
+	"errors"
+
+	"github.com/google/uuid"
+)
+
+// NotConfigured indicates that no second factor mechanism has been set up.
+var NotConfigured = errors.New("second factor is not configured")
+
+// unconfiguredProvider implements the Provider interface by rejecting every code.
+// It is used until a real second factor mechanism is plugged in.
+type unconfiguredProvider struct {
+}
+
+// NewUnconfiguredProvider creates a Provider that refuses all codes.
+func NewUnconfiguredProvider() Provider {
+	return &unconfiguredProvider{}
+}
+
+// Verify always returns NotConfigured.
+func (u *unconfiguredProvider) Verify(workerID uuid.UUID, code string) (bool, error) {
+	return false, NotConfigured
+}
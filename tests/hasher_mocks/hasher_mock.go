@@ -38,6 +38,21 @@ func (m *MockPasswordHash) EXPECT() *MockPasswordHashMockRecorder {
 	return m.recorder
 }
 
+// CompareAndMaybeRehash mocks base method.
+func (m *MockPasswordHash) CompareAndMaybeRehash(hashedPassword, plainPassword string) (string, bool) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CompareAndMaybeRehash", hashedPassword, plainPassword)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(bool)
+	return ret0, ret1
+}
+
+// CompareAndMaybeRehash indicates an expected call of CompareAndMaybeRehash.
+func (mr *MockPasswordHashMockRecorder) CompareAndMaybeRehash(hashedPassword, plainPassword interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompareAndMaybeRehash", reflect.TypeOf((*MockPasswordHash)(nil).CompareAndMaybeRehash), hashedPassword, plainPassword)
+}
+
 // CompareHashAndPassword mocks base method.
 func (m *MockPasswordHash) CompareHashAndPassword(hashedPassword, plainPassword string) bool {
 	m.ctrl.T.Helper()
@@ -47,7 +62,7 @@ func (m *MockPasswordHash) CompareHashAndPassword(hashedPassword, plainPassword
 }
 
 // CompareHashAndPassword indicates an expected call of CompareHashAndPassword.
-func (mr *MockPasswordHashMockRecorder) CompareHashAndPassword(hashedPassword, plainPassword any) *gomock.Call {
+func (mr *MockPasswordHashMockRecorder) CompareHashAndPassword(hashedPassword, plainPassword interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompareHashAndPassword", reflect.TypeOf((*MockPasswordHash)(nil).CompareHashAndPassword), hashedPassword, plainPassword)
 }
@@ -62,7 +77,7 @@ func (m *MockPasswordHash) GetHash(stringToHash string) (string, error) {
 }
 
 // GetHash indicates an expected call of GetHash.
-func (mr *MockPasswordHashMockRecorder) GetHash(stringToHash any) *gomock.Call {
+func (mr *MockPasswordHashMockRecorder) GetHash(stringToHash interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHash", reflect.TypeOf((*MockPasswordHash)(nil).GetHash), stringToHash)
 }
@@ -63,6 +63,36 @@ func (mr *MockICategoryRepositoryMockRecorder) Delete(id interface{}) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockICategoryRepository)(nil).Delete), id)
 }
 
+// DeleteIfEmpty mocks base method.
+func (m *MockICategoryRepository) DeleteIfEmpty(id int) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteIfEmpty", id)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteIfEmpty indicates an expected call of DeleteIfEmpty.
+func (mr *MockICategoryRepositoryMockRecorder) DeleteIfEmpty(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteIfEmpty", reflect.TypeOf((*MockICategoryRepository)(nil).DeleteIfEmpty), id)
+}
+
+// DeleteMovingTasks mocks base method.
+func (m *MockICategoryRepository) DeleteMovingTasks(id, moveTo int) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteMovingTasks", id, moveTo)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteMovingTasks indicates an expected call of DeleteMovingTasks.
+func (mr *MockICategoryRepositoryMockRecorder) DeleteMovingTasks(id, moveTo interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteMovingTasks", reflect.TypeOf((*MockICategoryRepository)(nil).DeleteMovingTasks), id, moveTo)
+}
+
 // GetAll mocks base method.
 func (m *MockICategoryRepository) GetAll() ([]models.Category, error) {
 	m.ctrl.T.Helper()
@@ -93,6 +123,21 @@ func (mr *MockICategoryRepositoryMockRecorder) GetByID(id interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockICategoryRepository)(nil).GetByID), id)
 }
 
+// SearchByName mocks base method.
+func (m *MockICategoryRepository) SearchByName(substring string) ([]models.Category, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchByName", substring)
+	ret0, _ := ret[0].([]models.Category)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SearchByName indicates an expected call of SearchByName.
+func (mr *MockICategoryRepositoryMockRecorder) SearchByName(substring interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchByName", reflect.TypeOf((*MockICategoryRepository)(nil).SearchByName), substring)
+}
+
 // Update mocks base method.
 func (m *MockICategoryRepository) Update(category *models.Category) (*models.Category, error) {
 	m.ctrl.T.Helper()
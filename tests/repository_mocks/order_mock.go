@@ -7,6 +7,7 @@ package mock_repository_interfaces
 import (
 	reflect "reflect"
 	models "teamdev/internal/models"
+	time "time"
 
 	gomock "github.com/golang/mock/gomock"
 	uuid "github.com/google/uuid"
@@ -35,18 +36,46 @@ func (m *MockIOrderRepository) EXPECT() *MockIOrderRepositoryMockRecorder {
 	return m.recorder
 }
 
+// AddTag mocks base method.
+func (m *MockIOrderRepository) AddTag(orderID uuid.UUID, tag string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddTag", orderID, tag)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddTag indicates an expected call of AddTag.
+func (mr *MockIOrderRepositoryMockRecorder) AddTag(orderID, tag interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddTag", reflect.TypeOf((*MockIOrderRepository)(nil).AddTag), orderID, tag)
+}
+
 // AddTaskToOrder mocks base method.
-func (m *MockIOrderRepository) AddTaskToOrder(orderID, taskID uuid.UUID) error {
+func (m *MockIOrderRepository) AddTaskToOrder(orderID, taskID uuid.UUID, quantity int) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "AddTaskToOrder", orderID, taskID)
+	ret := m.ctrl.Call(m, "AddTaskToOrder", orderID, taskID, quantity)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // AddTaskToOrder indicates an expected call of AddTaskToOrder.
-func (mr *MockIOrderRepositoryMockRecorder) AddTaskToOrder(orderID, taskID interface{}) *gomock.Call {
+func (mr *MockIOrderRepositoryMockRecorder) AddTaskToOrder(orderID, taskID, quantity interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddTaskToOrder", reflect.TypeOf((*MockIOrderRepository)(nil).AddTaskToOrder), orderID, taskID, quantity)
+}
+
+// AddTasksToOrder mocks base method.
+func (m *MockIOrderRepository) AddTasksToOrder(orderID uuid.UUID, orderedTasks []models.OrderedTask) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddTasksToOrder", orderID, orderedTasks)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddTasksToOrder indicates an expected call of AddTasksToOrder.
+func (mr *MockIOrderRepositoryMockRecorder) AddTasksToOrder(orderID, orderedTasks interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddTaskToOrder", reflect.TypeOf((*MockIOrderRepository)(nil).AddTaskToOrder), orderID, taskID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddTasksToOrder", reflect.TypeOf((*MockIOrderRepository)(nil).AddTasksToOrder), orderID, orderedTasks)
 }
 
 // Create mocks base method.
@@ -64,6 +93,21 @@ func (mr *MockIOrderRepositoryMockRecorder) Create(order, orderedTasks interface
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockIOrderRepository)(nil).Create), order, orderedTasks)
 }
 
+// DecrementTaskQuantity mocks base method.
+func (m *MockIOrderRepository) DecrementTaskQuantity(orderID, taskID uuid.UUID) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DecrementTaskQuantity", orderID, taskID)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DecrementTaskQuantity indicates an expected call of DecrementTaskQuantity.
+func (mr *MockIOrderRepositoryMockRecorder) DecrementTaskQuantity(orderID, taskID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DecrementTaskQuantity", reflect.TypeOf((*MockIOrderRepository)(nil).DecrementTaskQuantity), orderID, taskID)
+}
+
 // Delete mocks base method.
 func (m *MockIOrderRepository) Delete(id uuid.UUID) error {
 	m.ctrl.T.Helper()
@@ -78,19 +122,48 @@ func (mr *MockIOrderRepositoryMockRecorder) Delete(id interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockIOrderRepository)(nil).Delete), id)
 }
 
+// DeleteDraft mocks base method.
+func (m *MockIOrderRepository) DeleteDraft(userID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteDraft", userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteDraft indicates an expected call of DeleteDraft.
+func (mr *MockIOrderRepositoryMockRecorder) DeleteDraft(userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteDraft", reflect.TypeOf((*MockIOrderRepository)(nil).DeleteDraft), userID)
+}
+
 // Filter mocks base method.
-func (m *MockIOrderRepository) Filter(params map[string]string) ([]models.Order, error) {
+func (m *MockIOrderRepository) Filter(params map[string]string, limit, offset int, includeDeleted bool) ([]models.Order, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Filter", params)
+	ret := m.ctrl.Call(m, "Filter", params, limit, offset, includeDeleted)
 	ret0, _ := ret[0].([]models.Order)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Filter indicates an expected call of Filter.
-func (mr *MockIOrderRepositoryMockRecorder) Filter(params interface{}) *gomock.Call {
+func (mr *MockIOrderRepositoryMockRecorder) Filter(params, limit, offset, includeDeleted interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Filter", reflect.TypeOf((*MockIOrderRepository)(nil).Filter), params)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Filter", reflect.TypeOf((*MockIOrderRepository)(nil).Filter), params, limit, offset, includeDeleted)
+}
+
+// FilterByStatusAndDate mocks base method.
+func (m *MockIOrderRepository) FilterByStatusAndDate(userID uuid.UUID, statuses []int, from, to time.Time) ([]models.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FilterByStatusAndDate", userID, statuses, from, to)
+	ret0, _ := ret[0].([]models.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FilterByStatusAndDate indicates an expected call of FilterByStatusAndDate.
+func (mr *MockIOrderRepositoryMockRecorder) FilterByStatusAndDate(userID, statuses, from, to interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FilterByStatusAndDate", reflect.TypeOf((*MockIOrderRepository)(nil).FilterByStatusAndDate), userID, statuses, from, to)
 }
 
 // GetAllOrdersByUserID mocks base method.
@@ -108,6 +181,36 @@ func (mr *MockIOrderRepositoryMockRecorder) GetAllOrdersByUserID(id interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllOrdersByUserID", reflect.TypeOf((*MockIOrderRepository)(nil).GetAllOrdersByUserID), id)
 }
 
+// GetAllOrdersByWorkerID mocks base method.
+func (m *MockIOrderRepository) GetAllOrdersByWorkerID(id uuid.UUID) ([]models.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllOrdersByWorkerID", id)
+	ret0, _ := ret[0].([]models.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAllOrdersByWorkerID indicates an expected call of GetAllOrdersByWorkerID.
+func (mr *MockIOrderRepositoryMockRecorder) GetAllOrdersByWorkerID(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllOrdersByWorkerID", reflect.TypeOf((*MockIOrderRepository)(nil).GetAllOrdersByWorkerID), id)
+}
+
+// GetAverageOrderValue mocks base method.
+func (m *MockIOrderRepository) GetAverageOrderValue(from, to time.Time) (float64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAverageOrderValue", from, to)
+	ret0, _ := ret[0].(float64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAverageOrderValue indicates an expected call of GetAverageOrderValue.
+func (mr *MockIOrderRepositoryMockRecorder) GetAverageOrderValue(from, to interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAverageOrderValue", reflect.TypeOf((*MockIOrderRepository)(nil).GetAverageOrderValue), from, to)
+}
+
 // GetCurrentOrderByUserID mocks base method.
 func (m *MockIOrderRepository) GetCurrentOrderByUserID(id uuid.UUID) (*models.Order, error) {
 	m.ctrl.T.Helper()
@@ -123,6 +226,51 @@ func (mr *MockIOrderRepositoryMockRecorder) GetCurrentOrderByUserID(id interface
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCurrentOrderByUserID", reflect.TypeOf((*MockIOrderRepository)(nil).GetCurrentOrderByUserID), id)
 }
 
+// GetDeadlineDayOfWeekCounts mocks base method.
+func (m *MockIOrderRepository) GetDeadlineDayOfWeekCounts(from, to time.Time) (map[time.Weekday]int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDeadlineDayOfWeekCounts", from, to)
+	ret0, _ := ret[0].(map[time.Weekday]int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDeadlineDayOfWeekCounts indicates an expected call of GetDeadlineDayOfWeekCounts.
+func (mr *MockIOrderRepositoryMockRecorder) GetDeadlineDayOfWeekCounts(from, to interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDeadlineDayOfWeekCounts", reflect.TypeOf((*MockIOrderRepository)(nil).GetDeadlineDayOfWeekCounts), from, to)
+}
+
+// GetDigestBetween mocks base method.
+func (m *MockIOrderRepository) GetDigestBetween(from, to time.Time) (*models.DailyDigest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDigestBetween", from, to)
+	ret0, _ := ret[0].(*models.DailyDigest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDigestBetween indicates an expected call of GetDigestBetween.
+func (mr *MockIOrderRepositoryMockRecorder) GetDigestBetween(from, to interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDigestBetween", reflect.TypeOf((*MockIOrderRepository)(nil).GetDigestBetween), from, to)
+}
+
+// GetDraft mocks base method.
+func (m *MockIOrderRepository) GetDraft(userID uuid.UUID) (*models.DraftOrder, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDraft", userID)
+	ret0, _ := ret[0].(*models.DraftOrder)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDraft indicates an expected call of GetDraft.
+func (mr *MockIOrderRepositoryMockRecorder) GetDraft(userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDraft", reflect.TypeOf((*MockIOrderRepository)(nil).GetDraft), userID)
+}
+
 // GetOrderByID mocks base method.
 func (m *MockIOrderRepository) GetOrderByID(id uuid.UUID) (*models.Order, error) {
 	m.ctrl.T.Helper()
@@ -138,6 +286,142 @@ func (mr *MockIOrderRepositoryMockRecorder) GetOrderByID(id interface{}) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrderByID", reflect.TypeOf((*MockIOrderRepository)(nil).GetOrderByID), id)
 }
 
+// GetOrderTotalPrice mocks base method.
+func (m *MockIOrderRepository) GetOrderTotalPrice(orderID uuid.UUID) (float64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrderTotalPrice", orderID)
+	ret0, _ := ret[0].(float64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOrderTotalPrice indicates an expected call of GetOrderTotalPrice.
+func (mr *MockIOrderRepositoryMockRecorder) GetOrderTotalPrice(orderID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrderTotalPrice", reflect.TypeOf((*MockIOrderRepository)(nil).GetOrderTotalPrice), orderID)
+}
+
+// GetOrderedTasks mocks base method.
+func (m *MockIOrderRepository) GetOrderedTasks(orderID uuid.UUID) ([]models.OrderedTask, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrderedTasks", orderID)
+	ret0, _ := ret[0].([]models.OrderedTask)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOrderedTasks indicates an expected call of GetOrderedTasks.
+func (mr *MockIOrderRepositoryMockRecorder) GetOrderedTasks(orderID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrderedTasks", reflect.TypeOf((*MockIOrderRepository)(nil).GetOrderedTasks), orderID)
+}
+
+// GetOrdersByIDs mocks base method.
+func (m *MockIOrderRepository) GetOrdersByIDs(ids []uuid.UUID) ([]models.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrdersByIDs", ids)
+	ret0, _ := ret[0].([]models.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOrdersByIDs indicates an expected call of GetOrdersByIDs.
+func (mr *MockIOrderRepositoryMockRecorder) GetOrdersByIDs(ids interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrdersByIDs", reflect.TypeOf((*MockIOrderRepository)(nil).GetOrdersByIDs), ids)
+}
+
+// GetOrdersByRateBelow mocks base method.
+func (m *MockIOrderRepository) GetOrdersByRateBelow(threshold int) ([]models.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrdersByRateBelow", threshold)
+	ret0, _ := ret[0].([]models.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOrdersByRateBelow indicates an expected call of GetOrdersByRateBelow.
+func (mr *MockIOrderRepositoryMockRecorder) GetOrdersByRateBelow(threshold interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrdersByRateBelow", reflect.TypeOf((*MockIOrderRepository)(nil).GetOrdersByRateBelow), threshold)
+}
+
+// GetOrdersByTag mocks base method.
+func (m *MockIOrderRepository) GetOrdersByTag(tag string) ([]models.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrdersByTag", tag)
+	ret0, _ := ret[0].([]models.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOrdersByTag indicates an expected call of GetOrdersByTag.
+func (mr *MockIOrderRepositoryMockRecorder) GetOrdersByTag(tag interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrdersByTag", reflect.TypeOf((*MockIOrderRepository)(nil).GetOrdersByTag), tag)
+}
+
+// GetOrdersByUserIDPaged mocks base method.
+func (m *MockIOrderRepository) GetOrdersByUserIDPaged(userID uuid.UUID, limit, offset int) ([]models.Order, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrdersByUserIDPaged", userID, limit, offset)
+	ret0, _ := ret[0].([]models.Order)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetOrdersByUserIDPaged indicates an expected call of GetOrdersByUserIDPaged.
+func (mr *MockIOrderRepositoryMockRecorder) GetOrdersByUserIDPaged(userID, limit, offset interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrdersByUserIDPaged", reflect.TypeOf((*MockIOrderRepository)(nil).GetOrdersByUserIDPaged), userID, limit, offset)
+}
+
+// GetOverdueOrders mocks base method.
+func (m *MockIOrderRepository) GetOverdueOrders(now time.Time) ([]models.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOverdueOrders", now)
+	ret0, _ := ret[0].([]models.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOverdueOrders indicates an expected call of GetOverdueOrders.
+func (mr *MockIOrderRepositoryMockRecorder) GetOverdueOrders(now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOverdueOrders", reflect.TypeOf((*MockIOrderRepository)(nil).GetOverdueOrders), now)
+}
+
+// GetRevenueSummary mocks base method.
+func (m *MockIOrderRepository) GetRevenueSummary(from, to time.Time) (*models.RevenueSummary, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRevenueSummary", from, to)
+	ret0, _ := ret[0].(*models.RevenueSummary)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRevenueSummary indicates an expected call of GetRevenueSummary.
+func (mr *MockIOrderRepositoryMockRecorder) GetRevenueSummary(from, to interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRevenueSummary", reflect.TypeOf((*MockIOrderRepository)(nil).GetRevenueSummary), from, to)
+}
+
+// GetTags mocks base method.
+func (m *MockIOrderRepository) GetTags(orderID uuid.UUID) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTags", orderID)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTags indicates an expected call of GetTags.
+func (mr *MockIOrderRepositoryMockRecorder) GetTags(orderID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTags", reflect.TypeOf((*MockIOrderRepository)(nil).GetTags), orderID)
+}
+
 // GetTaskQuantity mocks base method.
 func (m *MockIOrderRepository) GetTaskQuantity(orderID, taskID uuid.UUID) (int, error) {
 	m.ctrl.T.Helper()
@@ -168,6 +452,78 @@ func (mr *MockIOrderRepositoryMockRecorder) GetTasksInOrder(id interface{}) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTasksInOrder", reflect.TypeOf((*MockIOrderRepository)(nil).GetTasksInOrder), id)
 }
 
+// HardDelete mocks base method.
+func (m *MockIOrderRepository) HardDelete(id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HardDelete", id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// HardDelete indicates an expected call of HardDelete.
+func (mr *MockIOrderRepositoryMockRecorder) HardDelete(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HardDelete", reflect.TypeOf((*MockIOrderRepository)(nil).HardDelete), id)
+}
+
+// ImportOrders mocks base method.
+func (m *MockIOrderRepository) ImportOrders(orders []models.OrderWithTasks) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ImportOrders", orders)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ImportOrders indicates an expected call of ImportOrders.
+func (mr *MockIOrderRepositoryMockRecorder) ImportOrders(orders interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportOrders", reflect.TypeOf((*MockIOrderRepository)(nil).ImportOrders), orders)
+}
+
+// IncrementTaskQuantity mocks base method.
+func (m *MockIOrderRepository) IncrementTaskQuantity(orderID, taskID uuid.UUID) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IncrementTaskQuantity", orderID, taskID)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IncrementTaskQuantity indicates an expected call of IncrementTaskQuantity.
+func (mr *MockIOrderRepositoryMockRecorder) IncrementTaskQuantity(orderID, taskID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IncrementTaskQuantity", reflect.TypeOf((*MockIOrderRepository)(nil).IncrementTaskQuantity), orderID, taskID)
+}
+
+// RecomputeNewOrderTotals mocks base method.
+func (m *MockIOrderRepository) RecomputeNewOrderTotals(rounding models.RoundingMode) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecomputeNewOrderTotals", rounding)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RecomputeNewOrderTotals indicates an expected call of RecomputeNewOrderTotals.
+func (mr *MockIOrderRepositoryMockRecorder) RecomputeNewOrderTotals(rounding interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecomputeNewOrderTotals", reflect.TypeOf((*MockIOrderRepository)(nil).RecomputeNewOrderTotals), rounding)
+}
+
+// RemoveTag mocks base method.
+func (m *MockIOrderRepository) RemoveTag(orderID uuid.UUID, tag string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveTag", orderID, tag)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveTag indicates an expected call of RemoveTag.
+func (mr *MockIOrderRepositoryMockRecorder) RemoveTag(orderID, tag interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveTag", reflect.TypeOf((*MockIOrderRepository)(nil).RemoveTag), orderID, tag)
+}
+
 // RemoveTaskFromOrder mocks base method.
 func (m *MockIOrderRepository) RemoveTaskFromOrder(orderID, taskID uuid.UUID) error {
 	m.ctrl.T.Helper()
@@ -182,6 +538,78 @@ func (mr *MockIOrderRepositoryMockRecorder) RemoveTaskFromOrder(orderID, taskID
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveTaskFromOrder", reflect.TypeOf((*MockIOrderRepository)(nil).RemoveTaskFromOrder), orderID, taskID)
 }
 
+// RequoteOrder mocks base method.
+func (m *MockIOrderRepository) RequoteOrder(orderID uuid.UUID, rounding models.RoundingMode) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RequoteOrder", orderID, rounding)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RequoteOrder indicates an expected call of RequoteOrder.
+func (mr *MockIOrderRepositoryMockRecorder) RequoteOrder(orderID, rounding interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequoteOrder", reflect.TypeOf((*MockIOrderRepository)(nil).RequoteOrder), orderID, rounding)
+}
+
+// Restore mocks base method.
+func (m *MockIOrderRepository) Restore(id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Restore", id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Restore indicates an expected call of Restore.
+func (mr *MockIOrderRepositoryMockRecorder) Restore(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Restore", reflect.TypeOf((*MockIOrderRepository)(nil).Restore), id)
+}
+
+// SaveDraft mocks base method.
+func (m *MockIOrderRepository) SaveDraft(draft *models.DraftOrder) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveDraft", draft)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveDraft indicates an expected call of SaveDraft.
+func (mr *MockIOrderRepositoryMockRecorder) SaveDraft(draft interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveDraft", reflect.TypeOf((*MockIOrderRepository)(nil).SaveDraft), draft)
+}
+
+// SearchByAddress mocks base method.
+func (m *MockIOrderRepository) SearchByAddress(substring string) ([]models.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchByAddress", substring)
+	ret0, _ := ret[0].([]models.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SearchByAddress indicates an expected call of SearchByAddress.
+func (mr *MockIOrderRepositoryMockRecorder) SearchByAddress(substring interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchByAddress", reflect.TypeOf((*MockIOrderRepository)(nil).SearchByAddress), substring)
+}
+
+// SearchByCustomerName mocks base method.
+func (m *MockIOrderRepository) SearchByCustomerName(substring string) ([]models.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchByCustomerName", substring)
+	ret0, _ := ret[0].([]models.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SearchByCustomerName indicates an expected call of SearchByCustomerName.
+func (mr *MockIOrderRepositoryMockRecorder) SearchByCustomerName(substring interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchByCustomerName", reflect.TypeOf((*MockIOrderRepository)(nil).SearchByCustomerName), substring)
+}
+
 // Update mocks base method.
 func (m *MockIOrderRepository) Update(order *models.Order) (*models.Order, error) {
 	m.ctrl.T.Helper()
@@ -7,6 +7,7 @@ package mock_repository_interfaces
 import (
 	reflect "reflect"
 	models "teamdev/internal/models"
+	time "time"
 
 	gomock "github.com/golang/mock/gomock"
 	uuid "github.com/google/uuid"
@@ -35,6 +36,36 @@ func (m *MockITaskRepository) EXPECT() *MockITaskRepositoryMockRecorder {
 	return m.recorder
 }
 
+// AdjustCategoryPrices mocks base method.
+func (m *MockITaskRepository) AdjustCategoryPrices(category int, multiplier float64, rounding models.RoundingMode) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AdjustCategoryPrices", category, multiplier, rounding)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AdjustCategoryPrices indicates an expected call of AdjustCategoryPrices.
+func (mr *MockITaskRepositoryMockRecorder) AdjustCategoryPrices(category, multiplier, rounding interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AdjustCategoryPrices", reflect.TypeOf((*MockITaskRepository)(nil).AdjustCategoryPrices), category, multiplier, rounding)
+}
+
+// ArchiveCategory mocks base method.
+func (m *MockITaskRepository) ArchiveCategory(category int, force bool) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ArchiveCategory", category, force)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ArchiveCategory indicates an expected call of ArchiveCategory.
+func (mr *MockITaskRepositoryMockRecorder) ArchiveCategory(category, force interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ArchiveCategory", reflect.TypeOf((*MockITaskRepository)(nil).ArchiveCategory), category, force)
+}
+
 // Create mocks base method.
 func (m *MockITaskRepository) Create(task *models.Task) (*models.Task, error) {
 	m.ctrl.T.Helper()
@@ -79,6 +110,82 @@ func (mr *MockITaskRepositoryMockRecorder) GetAllTasks() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllTasks", reflect.TypeOf((*MockITaskRepository)(nil).GetAllTasks))
 }
 
+// GetAllTasksPaged mocks base method.
+func (m *MockITaskRepository) GetAllTasksPaged(limit, offset int, sortBy string, asc bool) ([]models.Task, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllTasksPaged", limit, offset, sortBy, asc)
+	ret0, _ := ret[0].([]models.Task)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetAllTasksPaged indicates an expected call of GetAllTasksPaged.
+func (mr *MockITaskRepositoryMockRecorder) GetAllTasksPaged(limit, offset, sortBy, asc interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllTasksPaged", reflect.TypeOf((*MockITaskRepository)(nil).GetAllTasksPaged), limit, offset, sortBy, asc)
+}
+
+// GetAllTasksWithCategoryNames mocks base method.
+func (m *MockITaskRepository) GetAllTasksWithCategoryNames() ([]models.TaskWithCategory, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllTasksWithCategoryNames")
+	ret0, _ := ret[0].([]models.TaskWithCategory)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAllTasksWithCategoryNames indicates an expected call of GetAllTasksWithCategoryNames.
+func (mr *MockITaskRepositoryMockRecorder) GetAllTasksWithCategoryNames() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllTasksWithCategoryNames", reflect.TypeOf((*MockITaskRepository)(nil).GetAllTasksWithCategoryNames))
+}
+
+// GetMostOrderedTasks mocks base method.
+func (m *MockITaskRepository) GetMostOrderedTasks(limit int, from, to time.Time) ([]models.TaskPopularity, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMostOrderedTasks", limit, from, to)
+	ret0, _ := ret[0].([]models.TaskPopularity)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMostOrderedTasks indicates an expected call of GetMostOrderedTasks.
+func (mr *MockITaskRepositoryMockRecorder) GetMostOrderedTasks(limit, from, to interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMostOrderedTasks", reflect.TypeOf((*MockITaskRepository)(nil).GetMostOrderedTasks), limit, from, to)
+}
+
+// GetPriceTiers mocks base method.
+func (m *MockITaskRepository) GetPriceTiers(taskID uuid.UUID) ([]models.PriceTier, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPriceTiers", taskID)
+	ret0, _ := ret[0].([]models.PriceTier)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPriceTiers indicates an expected call of GetPriceTiers.
+func (mr *MockITaskRepositoryMockRecorder) GetPriceTiers(taskID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPriceTiers", reflect.TypeOf((*MockITaskRepository)(nil).GetPriceTiers), taskID)
+}
+
+// GetPriceTiersByTaskIDs mocks base method.
+func (m *MockITaskRepository) GetPriceTiersByTaskIDs(taskIDs []uuid.UUID) (map[uuid.UUID][]models.PriceTier, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPriceTiersByTaskIDs", taskIDs)
+	ret0, _ := ret[0].(map[uuid.UUID][]models.PriceTier)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPriceTiersByTaskIDs indicates an expected call of GetPriceTiersByTaskIDs.
+func (mr *MockITaskRepositoryMockRecorder) GetPriceTiersByTaskIDs(taskIDs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPriceTiersByTaskIDs", reflect.TypeOf((*MockITaskRepository)(nil).GetPriceTiersByTaskIDs), taskIDs)
+}
+
 // GetTaskByID mocks base method.
 func (m *MockITaskRepository) GetTaskByID(id uuid.UUID) (*models.Task, error) {
 	m.ctrl.T.Helper()
@@ -109,6 +216,21 @@ func (mr *MockITaskRepositoryMockRecorder) GetTaskByName(name interface{}) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTaskByName", reflect.TypeOf((*MockITaskRepository)(nil).GetTaskByName), name)
 }
 
+// GetTasksByIDs mocks base method.
+func (m *MockITaskRepository) GetTasksByIDs(ids []uuid.UUID) (map[uuid.UUID]models.Task, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTasksByIDs", ids)
+	ret0, _ := ret[0].(map[uuid.UUID]models.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTasksByIDs indicates an expected call of GetTasksByIDs.
+func (mr *MockITaskRepositoryMockRecorder) GetTasksByIDs(ids interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTasksByIDs", reflect.TypeOf((*MockITaskRepository)(nil).GetTasksByIDs), ids)
+}
+
 // GetTasksInCategory mocks base method.
 func (m *MockITaskRepository) GetTasksInCategory(category int) ([]models.Task, error) {
 	m.ctrl.T.Helper()
@@ -124,6 +246,81 @@ func (mr *MockITaskRepositoryMockRecorder) GetTasksInCategory(category interface
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTasksInCategory", reflect.TypeOf((*MockITaskRepository)(nil).GetTasksInCategory), category)
 }
 
+// GetTasksModifiedSince mocks base method.
+func (m *MockITaskRepository) GetTasksModifiedSince(since time.Time) ([]models.Task, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTasksModifiedSince", since)
+	ret0, _ := ret[0].([]models.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTasksModifiedSince indicates an expected call of GetTasksModifiedSince.
+func (mr *MockITaskRepositoryMockRecorder) GetTasksModifiedSince(since interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTasksModifiedSince", reflect.TypeOf((*MockITaskRepository)(nil).GetTasksModifiedSince), since)
+}
+
+// GetUnorderedTasks mocks base method.
+func (m *MockITaskRepository) GetUnorderedTasks() ([]models.Task, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUnorderedTasks")
+	ret0, _ := ret[0].([]models.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUnorderedTasks indicates an expected call of GetUnorderedTasks.
+func (mr *MockITaskRepositoryMockRecorder) GetUnorderedTasks() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUnorderedTasks", reflect.TypeOf((*MockITaskRepository)(nil).GetUnorderedTasks))
+}
+
+// InsertMissingByName mocks base method.
+func (m *MockITaskRepository) InsertMissingByName(tasks []models.Task) (int, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertMissingByName", tasks)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// InsertMissingByName indicates an expected call of InsertMissingByName.
+func (mr *MockITaskRepositoryMockRecorder) InsertMissingByName(tasks interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertMissingByName", reflect.TypeOf((*MockITaskRepository)(nil).InsertMissingByName), tasks)
+}
+
+// SearchTasksByName mocks base method.
+func (m *MockITaskRepository) SearchTasksByName(query string) ([]models.Task, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchTasksByName", query)
+	ret0, _ := ret[0].([]models.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SearchTasksByName indicates an expected call of SearchTasksByName.
+func (mr *MockITaskRepositoryMockRecorder) SearchTasksByName(query interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchTasksByName", reflect.TypeOf((*MockITaskRepository)(nil).SearchTasksByName), query)
+}
+
+// SetPriceTiers mocks base method.
+func (m *MockITaskRepository) SetPriceTiers(taskID uuid.UUID, tiers []models.PriceTier) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetPriceTiers", taskID, tiers)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetPriceTiers indicates an expected call of SetPriceTiers.
+func (mr *MockITaskRepositoryMockRecorder) SetPriceTiers(taskID, tiers interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetPriceTiers", reflect.TypeOf((*MockITaskRepository)(nil).SetPriceTiers), taskID, tiers)
+}
+
 // Update mocks base method.
 func (m *MockITaskRepository) Update(task *models.Task) (*models.Task, error) {
 	m.ctrl.T.Helper()
@@ -138,3 +335,19 @@ func (mr *MockITaskRepositoryMockRecorder) Update(task interface{}) *gomock.Call
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockITaskRepository)(nil).Update), task)
 }
+
+// UpsertByName mocks base method.
+func (m *MockITaskRepository) UpsertByName(tasks []models.Task) (int, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertByName", tasks)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// UpsertByName indicates an expected call of UpsertByName.
+func (mr *MockITaskRepositoryMockRecorder) UpsertByName(tasks interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertByName", reflect.TypeOf((*MockITaskRepository)(nil).UpsertByName), tasks)
+}
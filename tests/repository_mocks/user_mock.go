@@ -79,6 +79,36 @@ func (mr *MockIUserRepositoryMockRecorder) GetAllUsers() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllUsers", reflect.TypeOf((*MockIUserRepository)(nil).GetAllUsers))
 }
 
+// GetCustomersWithCompletedOrders mocks base method.
+func (m *MockIUserRepository) GetCustomersWithCompletedOrders() ([]models.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCustomersWithCompletedOrders")
+	ret0, _ := ret[0].([]models.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCustomersWithCompletedOrders indicates an expected call of GetCustomersWithCompletedOrders.
+func (mr *MockIUserRepositoryMockRecorder) GetCustomersWithCompletedOrders() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCustomersWithCompletedOrders", reflect.TypeOf((*MockIUserRepository)(nil).GetCustomersWithCompletedOrders))
+}
+
+// GetOrderStatistics mocks base method.
+func (m *MockIUserRepository) GetOrderStatistics(userID uuid.UUID) (*models.UserStats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrderStatistics", userID)
+	ret0, _ := ret[0].(*models.UserStats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOrderStatistics indicates an expected call of GetOrderStatistics.
+func (mr *MockIUserRepositoryMockRecorder) GetOrderStatistics(userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrderStatistics", reflect.TypeOf((*MockIUserRepository)(nil).GetOrderStatistics), userID)
+}
+
 // GetUserByEmail mocks base method.
 func (m *MockIUserRepository) GetUserByEmail(email string) (*models.User, error) {
 	m.ctrl.T.Helper()
@@ -123,3 +153,17 @@ func (mr *MockIUserRepositoryMockRecorder) Update(user interface{}) *gomock.Call
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockIUserRepository)(nil).Update), user)
 }
+
+// UpdatePassword mocks base method.
+func (m *MockIUserRepository) UpdatePassword(id uuid.UUID, password string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePassword", id, password)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdatePassword indicates an expected call of UpdatePassword.
+func (mr *MockIUserRepositoryMockRecorder) UpdatePassword(id, password interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePassword", reflect.TypeOf((*MockIUserRepository)(nil).UpdatePassword), id, password)
+}
@@ -7,6 +7,7 @@ package mock_repository_interfaces
 import (
 	reflect "reflect"
 	models "teamdev/internal/models"
+	time "time"
 
 	gomock "github.com/golang/mock/gomock"
 	uuid "github.com/google/uuid"
@@ -64,6 +65,21 @@ func (mr *MockIWorkerRepositoryMockRecorder) Delete(id interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockIWorkerRepository)(nil).Delete), id)
 }
 
+// GetActiveOrdersCount mocks base method.
+func (m *MockIWorkerRepository) GetActiveOrdersCount(workerID uuid.UUID) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetActiveOrdersCount", workerID)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetActiveOrdersCount indicates an expected call of GetActiveOrdersCount.
+func (mr *MockIWorkerRepositoryMockRecorder) GetActiveOrdersCount(workerID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetActiveOrdersCount", reflect.TypeOf((*MockIWorkerRepository)(nil).GetActiveOrdersCount), workerID)
+}
+
 // GetAllWorkers mocks base method.
 func (m *MockIWorkerRepository) GetAllWorkers() ([]models.Worker, error) {
 	m.ctrl.T.Helper()
@@ -79,6 +95,21 @@ func (mr *MockIWorkerRepositoryMockRecorder) GetAllWorkers() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllWorkers", reflect.TypeOf((*MockIWorkerRepository)(nil).GetAllWorkers))
 }
 
+// GetAssignmentCandidates mocks base method.
+func (m *MockIWorkerRepository) GetAssignmentCandidates(minRating float64, maxActiveOrders int) ([]models.WorkerWithRating, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAssignmentCandidates", minRating, maxActiveOrders)
+	ret0, _ := ret[0].([]models.WorkerWithRating)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAssignmentCandidates indicates an expected call of GetAssignmentCandidates.
+func (mr *MockIWorkerRepositoryMockRecorder) GetAssignmentCandidates(minRating, maxActiveOrders interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAssignmentCandidates", reflect.TypeOf((*MockIWorkerRepository)(nil).GetAssignmentCandidates), minRating, maxActiveOrders)
+}
+
 // GetAverageOrderRate mocks base method.
 func (m *MockIWorkerRepository) GetAverageOrderRate(worker *models.Worker) (float64, error) {
 	m.ctrl.T.Helper()
@@ -94,6 +125,81 @@ func (mr *MockIWorkerRepositoryMockRecorder) GetAverageOrderRate(worker interfac
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAverageOrderRate", reflect.TypeOf((*MockIWorkerRepository)(nil).GetAverageOrderRate), worker)
 }
 
+// GetCompletedOrderRatings mocks base method.
+func (m *MockIWorkerRepository) GetCompletedOrderRatings(workerID uuid.UUID) ([]models.OrderRating, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCompletedOrderRatings", workerID)
+	ret0, _ := ret[0].([]models.OrderRating)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCompletedOrderRatings indicates an expected call of GetCompletedOrderRatings.
+func (mr *MockIWorkerRepositoryMockRecorder) GetCompletedOrderRatings(workerID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCompletedOrderRatings", reflect.TypeOf((*MockIWorkerRepository)(nil).GetCompletedOrderRatings), workerID)
+}
+
+// GetCompletedOrdersInYear mocks base method.
+func (m *MockIWorkerRepository) GetCompletedOrdersInYear(workerID uuid.UUID, year int) ([]models.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCompletedOrdersInYear", workerID, year)
+	ret0, _ := ret[0].([]models.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCompletedOrdersInYear indicates an expected call of GetCompletedOrdersInYear.
+func (mr *MockIWorkerRepositoryMockRecorder) GetCompletedOrdersInYear(workerID, year interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCompletedOrdersInYear", reflect.TypeOf((*MockIWorkerRepository)(nil).GetCompletedOrdersInYear), workerID, year)
+}
+
+// GetPerformanceReport mocks base method.
+func (m *MockIWorkerRepository) GetPerformanceReport() ([]models.WorkerPerformance, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPerformanceReport")
+	ret0, _ := ret[0].([]models.WorkerPerformance)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPerformanceReport indicates an expected call of GetPerformanceReport.
+func (mr *MockIWorkerRepositoryMockRecorder) GetPerformanceReport() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPerformanceReport", reflect.TypeOf((*MockIWorkerRepository)(nil).GetPerformanceReport))
+}
+
+// GetRevenueBetween mocks base method.
+func (m *MockIWorkerRepository) GetRevenueBetween(workerID uuid.UUID, from, to time.Time) (float64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRevenueBetween", workerID, from, to)
+	ret0, _ := ret[0].(float64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRevenueBetween indicates an expected call of GetRevenueBetween.
+func (mr *MockIWorkerRepositoryMockRecorder) GetRevenueBetween(workerID, from, to interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRevenueBetween", reflect.TypeOf((*MockIWorkerRepository)(nil).GetRevenueBetween), workerID, from, to)
+}
+
+// GetTeamComparison mocks base method.
+func (m *MockIWorkerRepository) GetTeamComparison() ([]models.WorkerComparison, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTeamComparison")
+	ret0, _ := ret[0].([]models.WorkerComparison)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTeamComparison indicates an expected call of GetTeamComparison.
+func (mr *MockIWorkerRepositoryMockRecorder) GetTeamComparison() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTeamComparison", reflect.TypeOf((*MockIWorkerRepository)(nil).GetTeamComparison))
+}
+
 // GetWorkerByEmail mocks base method.
 func (m *MockIWorkerRepository) GetWorkerByEmail(email string) (*models.Worker, error) {
 	m.ctrl.T.Helper()
@@ -125,18 +231,47 @@ func (mr *MockIWorkerRepositoryMockRecorder) GetWorkerByID(id interface{}) *gomo
 }
 
 // GetWorkersByRole mocks base method.
-func (m *MockIWorkerRepository) GetWorkersByRole(role int) ([]models.Worker, error) {
+func (m *MockIWorkerRepository) GetWorkersByRole(role int, excludeID uuid.UUID) ([]models.Worker, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetWorkersByRole", role)
+	ret := m.ctrl.Call(m, "GetWorkersByRole", role, excludeID)
 	ret0, _ := ret[0].([]models.Worker)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetWorkersByRole indicates an expected call of GetWorkersByRole.
-func (mr *MockIWorkerRepositoryMockRecorder) GetWorkersByRole(role interface{}) *gomock.Call {
+func (mr *MockIWorkerRepositoryMockRecorder) GetWorkersByRole(role, excludeID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWorkersByRole", reflect.TypeOf((*MockIWorkerRepository)(nil).GetWorkersByRole), role, excludeID)
+}
+
+// IsAvailable mocks base method.
+func (m *MockIWorkerRepository) IsAvailable(workerID uuid.UUID, at time.Time) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsAvailable", workerID, at)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsAvailable indicates an expected call of IsAvailable.
+func (mr *MockIWorkerRepositoryMockRecorder) IsAvailable(workerID, at interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsAvailable", reflect.TypeOf((*MockIWorkerRepository)(nil).IsAvailable), workerID, at)
+}
+
+// SetAvailability mocks base method.
+func (m *MockIWorkerRepository) SetAvailability(workerID uuid.UUID, from, to time.Time, available bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetAvailability", workerID, from, to, available)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetAvailability indicates an expected call of SetAvailability.
+func (mr *MockIWorkerRepositoryMockRecorder) SetAvailability(workerID, from, to, available interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWorkersByRole", reflect.TypeOf((*MockIWorkerRepository)(nil).GetWorkersByRole), role)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetAvailability", reflect.TypeOf((*MockIWorkerRepository)(nil).SetAvailability), workerID, from, to, available)
 }
 
 // Update mocks base method.
@@ -153,3 +288,31 @@ func (mr *MockIWorkerRepositoryMockRecorder) Update(worker interface{}) *gomock.
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockIWorkerRepository)(nil).Update), worker)
 }
+
+// UpdateLastLogin mocks base method.
+func (m *MockIWorkerRepository) UpdateLastLogin(id uuid.UUID, loggedInAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateLastLogin", id, loggedInAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateLastLogin indicates an expected call of UpdateLastLogin.
+func (mr *MockIWorkerRepositoryMockRecorder) UpdateLastLogin(id, loggedInAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateLastLogin", reflect.TypeOf((*MockIWorkerRepository)(nil).UpdateLastLogin), id, loggedInAt)
+}
+
+// UpdatePassword mocks base method.
+func (m *MockIWorkerRepository) UpdatePassword(id uuid.UUID, password string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePassword", id, password)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdatePassword indicates an expected call of UpdatePassword.
+func (mr *MockIWorkerRepositoryMockRecorder) UpdatePassword(id, password interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePassword", reflect.TypeOf((*MockIWorkerRepository)(nil).UpdatePassword), id, password)
+}
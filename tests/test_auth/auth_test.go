@@ -0,0 +1,81 @@
+package test_auth
+
+import (
+	"encoding/base64"
+	"strings"
+	"teamdev/auth"
+	"teamdev/internal/models"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testSecret = "test-secret"
+
+func TestTokenRoundTrip(t *testing.T) {
+	issuer := auth.NewTokenIssuer(testSecret, time.Hour)
+	subjectID := uuid.New()
+
+	token, err := issuer.GenerateToken(subjectID, models.ManagerRole)
+	require.NoError(t, err)
+
+	claims, err := issuer.ParseToken(token)
+	require.NoError(t, err)
+	assert.Equal(t, subjectID, claims.SubjectID)
+	assert.Equal(t, models.ManagerRole, claims.Role)
+	assert.WithinDuration(t, time.Now(), claims.IssuedAt, time.Minute)
+	assert.WithinDuration(t, time.Now().Add(time.Hour), claims.ExpiresAt, time.Minute)
+}
+
+func TestTokenExpired(t *testing.T) {
+	expired, err := auth.NewTokenIssuer(testSecret, -time.Minute).GenerateToken(uuid.New(), auth.ClientRole)
+	require.NoError(t, err)
+
+	claims, err := auth.NewTokenIssuer(testSecret, time.Hour).ParseToken(expired)
+	assert.ErrorIs(t, err, auth.ExpiredToken)
+	assert.Nil(t, claims)
+}
+
+func TestTokenRejected(t *testing.T) {
+	issuer := auth.NewTokenIssuer(testSecret, time.Hour)
+	token, err := issuer.GenerateToken(uuid.New(), auth.ClientRole)
+	require.NoError(t, err)
+	parts := strings.Split(token, ".")
+
+	forgedPayload := base64.RawURLEncoding.EncodeToString([]byte(`{"sub":"` + uuid.NewString() + `","role":1,"iat":0,"exp":4102444800}`))
+	noneHeader := base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"none","typ":"JWT"}`))
+
+	tests := []struct {
+		TestName string
+		Token    string
+	}{
+		{TestName: "tampered payload", Token: parts[0] + "." + forgedPayload + "." + parts[2]},
+		{TestName: "tampered signature", Token: parts[0] + "." + parts[1] + "." + strings.Repeat("A", len(parts[2]))},
+		{TestName: "unsigned token", Token: noneHeader + "." + parts[1] + "."},
+		{TestName: "wrong number of segments", Token: parts[0] + "." + parts[1]},
+		{TestName: "garbage", Token: "not a token"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.TestName, func(t *testing.T) {
+			claims, err := issuer.ParseToken(test.Token)
+			assert.ErrorIs(t, err, auth.InvalidToken)
+			assert.Nil(t, claims)
+		})
+	}
+
+	t.Run("signed with another secret", func(t *testing.T) {
+		claims, err := auth.NewTokenIssuer("other-secret", time.Hour).ParseToken(token)
+		assert.ErrorIs(t, err, auth.InvalidToken)
+		assert.Nil(t, claims)
+	})
+}
+
+func TestTokenEmptySecret(t *testing.T) {
+	token, err := auth.NewTokenIssuer("", time.Hour).GenerateToken(uuid.New(), auth.ClientRole)
+	assert.ErrorIs(t, err, auth.EmptySecret)
+	assert.Empty(t, token)
+}
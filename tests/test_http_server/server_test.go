@@ -0,0 +1,345 @@
+package test_http_server
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"teamdev/auth"
+	"teamdev/http_server"
+	"teamdev/internal/models"
+	"teamdev/internal/services/service_errors"
+	"teamdev/internal/services/service_interfaces"
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/log"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeTaskService serves a fixed task catalog. Methods not used by the server
+// are left to the embedded interface and panic if called.
+type fakeTaskService struct {
+	service_interfaces.ITaskService
+	tasks []models.Task
+	err   error
+}
+
+func (f fakeTaskService) GetAllTasks() ([]models.Task, error) {
+	return f.tasks, f.err
+}
+
+// fakeOrderService records the order it is asked to create and returns the
+// configured result. Like the real service, it quotes orders at the prices of
+// its catalog rather than at prices sent by the client.
+type fakeOrderService struct {
+	service_interfaces.IOrderService
+	catalog      map[uuid.UUID]models.Task
+	orders       []models.Order
+	userID       uuid.UUID
+	address      string
+	deadline     time.Time
+	orderedTasks []models.OrderedTask
+	err          error
+}
+
+func (f *fakeOrderService) CreateOrder(userID uuid.UUID, address string, deadline time.Time, orderedTasks []models.OrderedTask) (*models.OrderWithTasks, error) {
+	f.userID, f.address, f.deadline, f.orderedTasks = userID, address, deadline, orderedTasks
+	if f.err != nil {
+		return nil, f.err
+	}
+
+	var quotedTotal float64
+	for _, task := range orderedTasks {
+		quotedTotal += f.catalog[task.Task.ID].PricePerSingle * float64(task.Quantity)
+	}
+
+	return &models.OrderWithTasks{
+		Order: models.Order{ID: uuid.New(), UserID: userID, Status: models.NewOrderStatus, Address: address, Deadline: deadline, QuotedTotal: quotedTotal},
+		Tasks: orderedTasks,
+	}, nil
+}
+
+func (f *fakeOrderService) GetAllOrdersByUserID(userID uuid.UUID) ([]models.Order, error) {
+	f.userID = userID
+	return f.orders, f.err
+}
+
+// testTokens signs the session tokens used by the tests.
+var testTokens = auth.NewTokenIssuer("test-secret", time.Hour)
+
+func newTestServer(taskService service_interfaces.ITaskService, orderService service_interfaces.IOrderService) *http_server.Server {
+	return http_server.NewServer(nil, orderService, taskService, testTokens, log.New(io.Discard))
+}
+
+// bearer returns the Authorization header value carrying a token for the subject.
+func bearer(t *testing.T, subjectID uuid.UUID, role int) string {
+	token, err := testTokens.GenerateToken(subjectID, role)
+	require.NoError(t, err)
+	return "Bearer " + token
+}
+
+func TestServerListTasks(t *testing.T) {
+	tasks := []models.Task{
+		{ID: uuid.New(), Name: "Wash windows", PricePerSingle: 100, Category: 1},
+		{ID: uuid.New(), Name: "Clean oven", PricePerSingle: 150.5, Category: 2},
+	}
+
+	tests := []struct {
+		TestName    string
+		Service     fakeTaskService
+		CheckOutput func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			TestName: "catalog is returned",
+			Service:  fakeTaskService{tasks: tasks},
+			CheckOutput: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				assert.Equal(t, "application/json", recorder.Header().Get("Content-Type"))
+
+				var body []map[string]any
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &body))
+				require.Len(t, body, 2)
+				assert.Equal(t, tasks[0].ID.String(), body[0]["id"])
+				assert.Equal(t, "Clean oven", body[1]["name"])
+				assert.Equal(t, 150.5, body[1]["price_per_single"])
+			},
+		},
+		{
+			TestName: "empty catalog is an empty array",
+			Service:  fakeTaskService{},
+			CheckOutput: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				assert.JSONEq(t, "[]", recorder.Body.String())
+			},
+		},
+		{
+			TestName: "service failure",
+			Service:  fakeTaskService{err: errors.New("DB ERROR: Select operation was not successful")},
+			CheckOutput: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+				assert.JSONEq(t, `{"error": "internal server error"}`, recorder.Body.String())
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.TestName, func(t *testing.T) {
+			server := newTestServer(test.Service, nil)
+			recorder := httptest.NewRecorder()
+
+			server.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/tasks", nil))
+
+			test.CheckOutput(t, recorder)
+		})
+	}
+}
+
+func TestServerCreateOrder(t *testing.T) {
+	userID := uuid.New()
+	taskID := uuid.New()
+	catalog := map[uuid.UUID]models.Task{taskID: {ID: taskID, Name: "Wash windows", PricePerSingle: 125}}
+	deadline := time.Date(2030, time.March, 14, 12, 0, 0, 0, time.UTC)
+	validBody := `{"address": "ул. Ленина, 1", "deadline": "2030-03-14T12:00:00Z",` +
+		` "tasks": [{"task_id": "` + taskID.String() + `", "quantity": 2}]}`
+
+	tests := []struct {
+		TestName      string
+		Body          string
+		Authorization func(t *testing.T) string
+		ServiceErr    error
+		CheckOutput   func(t *testing.T, service *fakeOrderService, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			TestName: "order is created",
+			Body:     validBody,
+			CheckOutput: func(t *testing.T, service *fakeOrderService, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusCreated, recorder.Code)
+
+				assert.Equal(t, userID, service.userID)
+				assert.Equal(t, "ул. Ленина, 1", service.address)
+				assert.True(t, deadline.Equal(service.deadline))
+				require.Len(t, service.orderedTasks, 1)
+				assert.Equal(t, taskID, service.orderedTasks[0].Task.ID)
+				assert.Equal(t, 2, service.orderedTasks[0].Quantity)
+
+				var body map[string]any
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &body))
+				assert.Equal(t, userID.String(), body["user_id"])
+				assert.Equal(t, float64(models.NewOrderStatus), body["status"])
+				assert.Equal(t, 250.0, body["quoted_total"])
+				assert.Equal(t, []any{map[string]any{"task_id": taskID.String(), "quantity": 2.0}}, body["tasks"])
+			},
+		},
+		{
+			TestName: "client in the body is ignored",
+			Body:     `{"user_id": "` + uuid.New().String() + `",` + validBody[1:],
+			CheckOutput: func(t *testing.T, service *fakeOrderService, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusCreated, recorder.Code)
+				assert.Equal(t, userID, service.userID)
+			},
+		},
+		{
+			TestName:      "missing token",
+			Body:          validBody,
+			Authorization: func(t *testing.T) string { return "" },
+			CheckOutput: func(t *testing.T, service *fakeOrderService, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+				assert.Nil(t, service.orderedTasks)
+			},
+		},
+		{
+			TestName:      "invalid token",
+			Body:          validBody,
+			Authorization: func(t *testing.T) string { return "Bearer not-a-token" },
+			CheckOutput: func(t *testing.T, service *fakeOrderService, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+				assert.Nil(t, service.orderedTasks)
+			},
+		},
+		{
+			TestName:      "worker token",
+			Body:          validBody,
+			Authorization: func(t *testing.T) string { return bearer(t, userID, models.ManagerRole) },
+			CheckOutput: func(t *testing.T, service *fakeOrderService, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+				assert.Nil(t, service.orderedTasks)
+			},
+		},
+		{
+			TestName: "body too large",
+			Body:     `{"address": "` + strings.Repeat("a", 2<<20) + `"}`,
+			CheckOutput: func(t *testing.T, service *fakeOrderService, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusRequestEntityTooLarge, recorder.Code)
+				assert.Nil(t, service.orderedTasks)
+			},
+		},
+		{
+			TestName: "malformed body",
+			Body:     `{"user_id": `,
+			CheckOutput: func(t *testing.T, service *fakeOrderService, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+				assert.Equal(t, uuid.Nil, service.userID)
+			},
+		},
+		{
+			TestName:   "validation error",
+			Body:       validBody,
+			ServiceErr: service_errors.EmptyTasksOrder,
+			CheckOutput: func(t *testing.T, service *fakeOrderService, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+				assert.JSONEq(t, `{"error": "order has no tasks"}`, recorder.Body.String())
+			},
+		},
+		{
+			TestName:   "deadline error",
+			Body:       validBody,
+			ServiceErr: service_errors.InvalidDeadlineOrder,
+			CheckOutput: func(t *testing.T, service *fakeOrderService, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			TestName:   "persistence error",
+			Body:       validBody,
+			ServiceErr: errors.New("DB ERROR: Insert operation was not successful"),
+			CheckOutput: func(t *testing.T, service *fakeOrderService, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+				assert.JSONEq(t, `{"error": "internal server error"}`, recorder.Body.String())
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.TestName, func(t *testing.T) {
+			service := &fakeOrderService{catalog: catalog, err: test.ServiceErr}
+			server := newTestServer(nil, service)
+			recorder := httptest.NewRecorder()
+
+			request := httptest.NewRequest(http.MethodPost, "/orders", strings.NewReader(test.Body))
+			if test.Authorization != nil {
+				request.Header.Set("Authorization", test.Authorization(t))
+			} else {
+				request.Header.Set("Authorization", bearer(t, userID, auth.ClientRole))
+			}
+			server.ServeHTTP(recorder, request)
+
+			test.CheckOutput(t, service, recorder)
+		})
+	}
+}
+
+func TestServerListUserOrders(t *testing.T) {
+	userID := uuid.New()
+	orders := []models.Order{{ID: uuid.New(), UserID: userID, Status: models.NewOrderStatus, Address: "ул. Ленина, 1", QuotedTotal: 250}}
+
+	tests := []struct {
+		TestName      string
+		Path          string
+		Authorization string
+		ServiceErr    error
+		CheckOutput   func(t *testing.T, service *fakeOrderService, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			TestName:      "own orders are listed",
+			Path:          "/users/" + userID.String() + "/orders",
+			Authorization: bearer(t, userID, auth.ClientRole),
+			CheckOutput: func(t *testing.T, service *fakeOrderService, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				assert.Equal(t, userID, service.userID)
+
+				var body []map[string]any
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &body))
+				require.Len(t, body, 1)
+				assert.Equal(t, orders[0].ID.String(), body[0]["id"])
+			},
+		},
+		{
+			TestName:      "orders of another client",
+			Path:          "/users/" + uuid.New().String() + "/orders",
+			Authorization: bearer(t, userID, auth.ClientRole),
+			CheckOutput: func(t *testing.T, service *fakeOrderService, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+				assert.Equal(t, uuid.Nil, service.userID)
+			},
+		},
+		{
+			TestName: "missing token",
+			Path:     "/users/" + userID.String() + "/orders",
+			CheckOutput: func(t *testing.T, service *fakeOrderService, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+				assert.Equal(t, uuid.Nil, service.userID)
+			},
+		},
+		{
+			TestName:      "service failure",
+			Path:          "/users/" + userID.String() + "/orders",
+			Authorization: bearer(t, userID, auth.ClientRole),
+			ServiceErr:    errors.New("DB ERROR: Select operation was not successful"),
+			CheckOutput: func(t *testing.T, service *fakeOrderService, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+				assert.JSONEq(t, `{"error": "internal server error"}`, recorder.Body.String())
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.TestName, func(t *testing.T) {
+			service := &fakeOrderService{orders: orders, err: test.ServiceErr}
+			server := newTestServer(nil, service)
+			recorder := httptest.NewRecorder()
+
+			request := httptest.NewRequest(http.MethodGet, test.Path, nil)
+			if test.Authorization != "" {
+				request.Header.Set("Authorization", test.Authorization)
+			}
+			server.ServeHTTP(recorder, request)
+
+			test.CheckOutput(t, service, recorder)
+		})
+	}
+}
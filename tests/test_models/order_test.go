@@ -0,0 +1,53 @@
+package test_models
+
+import (
+	"github.com/stretchr/testify/assert"
+	"teamdev/internal/models"
+	"testing"
+)
+
+var testOrderDisplayStatus = []struct {
+	testName string
+	status   int
+	expected string
+}{
+	{
+		testName: "no status",
+		status:   models.NoStatus,
+		expected: "Не определен",
+	},
+	{
+		testName: "new order",
+		status:   models.NewOrderStatus,
+		expected: "Новый",
+	},
+	{
+		testName: "order in progress",
+		status:   models.InProgressOrderStatus,
+		expected: "В работе",
+	},
+	{
+		testName: "completed order",
+		status:   models.CompletedOrderStatus,
+		expected: "Выполнен",
+	},
+	{
+		testName: "cancelled order",
+		status:   models.CancelledOrderStatus,
+		expected: "Отменён",
+	},
+	{
+		testName: "unknown status",
+		status:   42,
+		expected: "Неизвестный статус (42)",
+	},
+}
+
+func TestOrderDisplayStatus(t *testing.T) {
+	for _, tt := range testOrderDisplayStatus {
+		t.Run(tt.testName, func(t *testing.T) {
+			order := models.Order{Status: tt.status}
+			assert.Equal(t, tt.expected, order.DisplayStatus())
+		})
+	}
+}
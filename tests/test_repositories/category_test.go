@@ -0,0 +1,187 @@
+package test_repositories
+
+import (
+	"context"
+	"teamdev/internal/models"
+	"teamdev/internal/repository/postgres"
+	"teamdev/internal/repository/repository_errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"github.com/testcontainers/testcontainers-go"
+)
+
+var testCategoryRepositorySearchByName = []struct {
+	TestName  string
+	InputData struct {
+		substring string
+	}
+	CheckOutput func(t *testing.T, receivedCategories []models.Category, err error)
+}{
+	{
+		TestName:  "search matches substring",
+		InputData: struct{ substring string }{"alpha"},
+		CheckOutput: func(t *testing.T, receivedCategories []models.Category, err error) {
+			require.NoError(t, err)
+			require.Len(t, receivedCategories, 2)
+		},
+	},
+	{
+		TestName:  "search is case insensitive",
+		InputData: struct{ substring string }{"ALPHA"},
+		CheckOutput: func(t *testing.T, receivedCategories []models.Category, err error) {
+			require.NoError(t, err)
+			require.Len(t, receivedCategories, 2)
+		},
+	},
+	{
+		TestName:  "wildcards are matched literally",
+		InputData: struct{ substring string }{"%"},
+		CheckOutput: func(t *testing.T, receivedCategories []models.Category, err error) {
+			require.NoError(t, err)
+			require.Empty(t, receivedCategories)
+		},
+	},
+	{
+		TestName:  "no matches",
+		InputData: struct{ substring string }{"gamma"},
+		CheckOutput: func(t *testing.T, receivedCategories []models.Category, err error) {
+			require.NoError(t, err)
+			require.Empty(t, receivedCategories)
+		},
+	},
+}
+
+func TestCategoryRepositorySearchByName(t *testing.T) {
+	dbContainer, db := SetupTestDatabase()
+	defer func(dbContainer testcontainers.Container, ctx context.Context) {
+		err := dbContainer.Terminate(ctx)
+		if err != nil {
+			return
+		}
+	}(dbContainer, context.Background())
+
+	fields := postgres.PostgresConnection{DB: db}
+	categoryRepository := postgres.CreateCategoryRepository(&fields)
+
+	for _, name := range []string{"Alpha cleaning", "Deep alpha care", "Beta polishing"} {
+		_, err := categoryRepository.Create(&models.Category{Name: name})
+		require.NoError(t, err)
+	}
+
+	for _, test := range testCategoryRepositorySearchByName {
+		t.Run(test.TestName, func(t *testing.T) {
+			receivedCategories, err := categoryRepository.SearchByName(test.InputData.substring)
+			test.CheckOutput(t, receivedCategories, err)
+		})
+	}
+}
+
+func TestCategoryRepositoryGetByID(t *testing.T) {
+	dbContainer, db := SetupTestDatabase()
+	defer func(dbContainer testcontainers.Container, ctx context.Context) {
+		err := dbContainer.Terminate(ctx)
+		if err != nil {
+			return
+		}
+	}(dbContainer, context.Background())
+
+	fields := postgres.PostgresConnection{DB: db}
+	categoryRepository := postgres.CreateCategoryRepository(&fields)
+
+	created, err := categoryRepository.Create(&models.Category{Name: "Ninth category"})
+	require.NoError(t, err)
+
+	t.Run("created category is found", func(t *testing.T) {
+		category, err := categoryRepository.GetByID(created.ID)
+		require.NoError(t, err)
+		require.Equal(t, "Ninth category", category.Name)
+	})
+
+	t.Run("missing category", func(t *testing.T) {
+		category, err := categoryRepository.GetByID(created.ID + 1)
+		require.Equal(t, repository_errors.DoesNotExist, err)
+		require.Nil(t, category)
+	})
+}
+
+func TestCategoryRepositoryDeleteIfEmpty(t *testing.T) {
+	dbContainer, db := SetupTestDatabase()
+	defer func(dbContainer testcontainers.Container, ctx context.Context) {
+		err := dbContainer.Terminate(ctx)
+		if err != nil {
+			return
+		}
+	}(dbContainer, context.Background())
+
+	fields := postgres.PostgresConnection{DB: db}
+	categoryRepository := postgres.CreateCategoryRepository(&fields)
+	taskRepository := postgres.CreateTaskRepository(&fields)
+
+	kept, err := categoryRepository.Create(&models.Category{Name: "Ninth category"})
+	require.NoError(t, err)
+	empty, err := categoryRepository.Create(&models.Category{Name: "Tenth category"})
+	require.NoError(t, err)
+
+	// an archived task still keeps the category
+	_, err = taskRepository.Create(&models.Task{Name: "Archived task", PricePerSingle: 200, Category: kept.ID})
+	require.NoError(t, err)
+	_, err = taskRepository.ArchiveCategory(kept.ID, true)
+	require.NoError(t, err)
+
+	tasks, err := categoryRepository.DeleteIfEmpty(kept.ID)
+	require.NoError(t, err)
+	require.Equal(t, 1, tasks)
+	_, err = categoryRepository.GetByID(kept.ID)
+	require.NoError(t, err)
+
+	tasks, err = categoryRepository.DeleteIfEmpty(empty.ID)
+	require.NoError(t, err)
+	require.Equal(t, 0, tasks)
+	_, err = categoryRepository.GetByID(empty.ID)
+	require.Equal(t, repository_errors.DoesNotExist, err)
+}
+
+func TestCategoryRepositoryDeleteMovingTasks(t *testing.T) {
+	dbContainer, db := SetupTestDatabase()
+	defer func(dbContainer testcontainers.Container, ctx context.Context) {
+		err := dbContainer.Terminate(ctx)
+		if err != nil {
+			return
+		}
+	}(dbContainer, context.Background())
+
+	fields := postgres.PostgresConnection{DB: db}
+	categoryRepository := postgres.CreateCategoryRepository(&fields)
+	taskRepository := postgres.CreateTaskRepository(&fields)
+
+	deleted, err := categoryRepository.Create(&models.Category{Name: "Ninth category"})
+	require.NoError(t, err)
+	target, err := categoryRepository.Create(&models.Category{Name: models.UncategorizedCategoryName})
+	require.NoError(t, err)
+
+	// archived tasks are moved as well
+	archived, err := taskRepository.Create(&models.Task{Name: "Archived task", PricePerSingle: 200, Category: deleted.ID})
+	require.NoError(t, err)
+	_, err = taskRepository.ArchiveCategory(deleted.ID, true)
+	require.NoError(t, err)
+	active, err := taskRepository.Create(&models.Task{Name: "Active task", PricePerSingle: 100, Category: deleted.ID})
+	require.NoError(t, err)
+
+	moved, err := categoryRepository.DeleteMovingTasks(deleted.ID, target.ID)
+	require.NoError(t, err)
+	require.Equal(t, 2, moved)
+
+	_, err = categoryRepository.GetByID(deleted.ID)
+	require.Equal(t, repository_errors.DoesNotExist, err)
+
+	for _, id := range []uuid.UUID{active.ID, archived.ID} {
+		task, err := taskRepository.GetTaskByID(id)
+		require.NoError(t, err)
+		require.Equal(t, target.ID, task.Category)
+	}
+
+	_, err = categoryRepository.DeleteMovingTasks(deleted.ID, target.ID)
+	require.Equal(t, repository_errors.DoesNotExist, err)
+}
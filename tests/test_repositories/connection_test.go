@@ -0,0 +1,52 @@
+package test_repositories
+
+import (
+	"context"
+	"teamdev/config"
+	"teamdev/internal/repository/postgres"
+	"teamdev/internal/repository/repository_errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/testcontainers/testcontainers-go"
+)
+
+func TestPostgresConnectionPing(t *testing.T) {
+	dbContainer, db := SetupTestDatabase()
+	defer func(dbContainer testcontainers.Container, ctx context.Context) {
+		err := dbContainer.Terminate(ctx)
+		if err != nil {
+			return
+		}
+	}(dbContainer, context.Background())
+
+	fields := postgres.PostgresConnection{DB: db}
+
+	t.Run("reachable database", func(t *testing.T) {
+		require.NoError(t, fields.Ping(context.Background()))
+	})
+
+	t.Run("closed connection", func(t *testing.T) {
+		require.NoError(t, db.Close())
+		require.ErrorIs(t, fields.Ping(context.Background()), repository_errors.ConnectionError)
+	})
+}
+
+func TestPostgresConnectionConfigurePool(t *testing.T) {
+	dbContainer, db := SetupTestDatabase()
+	defer func(dbContainer testcontainers.Container, ctx context.Context) {
+		err := dbContainer.Terminate(ctx)
+		if err != nil {
+			return
+		}
+	}(dbContainer, context.Background())
+
+	fields := postgres.PostgresConnection{DB: db}
+	fields.Config.DBFlags = config.DbConnectionFlags{MaxOpenConns: 7, MaxIdleConns: 3, ConnMaxLifetimeMinutes: 5}
+
+	fields.ConfigurePool()
+
+	require.Equal(t, 7, db.Stats().MaxOpenConnections)
+	require.NoError(t, fields.Ping(context.Background()))
+	require.LessOrEqual(t, db.Stats().Idle, 3)
+}
@@ -2,8 +2,11 @@ package test_repositories
 
 import (
 	"context"
+	"sync"
 	"teamdev/internal/models"
 	"teamdev/internal/repository/postgres"
+	"teamdev/internal/repository/repository_errors"
+	"teamdev/internal/repository/repository_interfaces"
 	"testing"
 	"time"
 
@@ -71,6 +74,23 @@ func createTasks(fields *postgres.PostgresConnection) []models.OrderedTask {
 	return createdTasks
 }
 
+func createOrderWithStatus(fields *postgres.PostgresConnection, userID uuid.UUID, workerID uuid.UUID, status int, quotedTotal float64, completedAt *time.Time) *models.Order {
+	orderRepository := postgres.CreateOrderRepository(fields)
+	order, _ := orderRepository.Create(&models.Order{
+		UserID:      userID,
+		Status:      models.NewOrderStatus,
+		Address:     "Address",
+		Deadline:    time.Now().AddDate(0, 0, 1),
+		QuotedTotal: quotedTotal,
+	}, createTasks(fields))
+
+	order.WorkerID = workerID
+	order.Status = status
+	order.CompletedAt = completedAt
+	order, _ = orderRepository.Update(order)
+	return order
+}
+
 var testOrderRepositoryCreateSuccess = []struct {
 	TestName  string
 	InputData struct {
@@ -273,6 +293,166 @@ func TestOrderRepositoryDelete(t *testing.T) {
 	}
 }
 
+var testOrderRepositorySoftDelete = []struct {
+	TestName    string
+	CheckOutput func(t *testing.T, orderRepository repository_interfaces.IOrderRepository, order *models.Order, userID uuid.UUID)
+}{
+	{
+		TestName: "deleted order is hidden and can be restored",
+		CheckOutput: func(t *testing.T, orderRepository repository_interfaces.IOrderRepository, order *models.Order, userID uuid.UUID) {
+			userFilter := map[string]string{"user_id": userID.String()}
+
+			_, err := orderRepository.GetOrderByID(order.ID)
+			require.Equal(t, repository_errors.DoesNotExist, err)
+
+			orders, err := orderRepository.GetAllOrdersByUserID(userID)
+			require.NoError(t, err)
+			require.Empty(t, orders)
+
+			orders, err = orderRepository.Filter(userFilter, 0, 0, false)
+			require.NoError(t, err)
+			require.Empty(t, orders)
+
+			orders, err = orderRepository.Filter(userFilter, 0, 0, true)
+			require.NoError(t, err)
+			require.Len(t, orders, 1)
+			require.NotNil(t, orders[0].DeletedAt)
+
+			tasks, err := orderRepository.GetTasksInOrder(order.ID)
+			require.NoError(t, err)
+			require.NotEmpty(t, tasks)
+
+			require.Equal(t, repository_errors.DoesNotExist, orderRepository.Delete(order.ID))
+
+			require.NoError(t, orderRepository.Restore(order.ID))
+			restored, err := orderRepository.GetOrderByID(order.ID)
+			require.NoError(t, err)
+			require.Nil(t, restored.DeletedAt)
+
+			require.Equal(t, repository_errors.DoesNotExist, orderRepository.Restore(order.ID))
+		},
+	},
+}
+
+func TestOrderRepositorySoftDelete(t *testing.T) {
+	dbContainer, db := SetupTestDatabase()
+	defer func(dbContainer testcontainers.Container, ctx context.Context) {
+		err := dbContainer.Terminate(ctx)
+		if err != nil {
+			return
+		}
+	}(dbContainer, context.Background())
+
+	fields := postgres.PostgresConnection{DB: db}
+	orderRepository := postgres.CreateOrderRepository(&fields)
+
+	for _, test := range testOrderRepositorySoftDelete {
+		t.Run(test.TestName, func(t *testing.T) {
+			user := createUser(&fields)
+			worker := createWorker(&fields)
+			order := createOrderWithStatus(&fields, user.ID, worker.ID, models.InProgressOrderStatus, 100, nil)
+
+			err := orderRepository.Delete(order.ID)
+			require.NoError(t, err)
+
+			test.CheckOutput(t, orderRepository, order, user.ID)
+
+			err = orderRepository.HardDelete(order.ID)
+			require.NoError(t, err)
+			orders, err := orderRepository.Filter(map[string]string{"user_id": user.ID.String()}, 0, 0, true)
+			require.NoError(t, err)
+			require.Empty(t, orders)
+		})
+	}
+}
+
+func TestOrderRepositoryHardDelete(t *testing.T) {
+	dbContainer, db := SetupTestDatabase()
+	defer func(dbContainer testcontainers.Container, ctx context.Context) {
+		err := dbContainer.Terminate(ctx)
+		if err != nil {
+			return
+		}
+	}(dbContainer, context.Background())
+
+	fields := postgres.PostgresConnection{DB: db}
+	orderRepository := postgres.CreateOrderRepository(&fields)
+
+	user := createUser(&fields)
+	worker := createWorker(&fields)
+	order := createOrderWithStatus(&fields, user.ID, worker.ID, models.NewOrderStatus, 100, nil)
+
+	tasks, err := orderRepository.GetTasksInOrder(order.ID)
+	require.NoError(t, err)
+	require.NotEmpty(t, tasks)
+
+	err = orderRepository.HardDelete(order.ID)
+	require.NoError(t, err)
+
+	tasks, err = orderRepository.GetTasksInOrder(order.ID)
+	require.NoError(t, err)
+	require.Empty(t, tasks)
+
+	require.Equal(t, repository_errors.DoesNotExist, orderRepository.HardDelete(order.ID))
+	require.Equal(t, repository_errors.DoesNotExist, orderRepository.HardDelete(uuid.New()))
+}
+
+func TestOrderRepositoryTags(t *testing.T) {
+	dbContainer, db := SetupTestDatabase()
+	defer func(dbContainer testcontainers.Container, ctx context.Context) {
+		err := dbContainer.Terminate(ctx)
+		if err != nil {
+			return
+		}
+	}(dbContainer, context.Background())
+
+	fields := postgres.PostgresConnection{DB: db}
+	orderRepository := postgres.CreateOrderRepository(&fields)
+
+	user := createUser(&fields)
+	worker := createWorker(&fields)
+	first := createOrderWithStatus(&fields, user.ID, worker.ID, models.NewOrderStatus, 100, nil)
+	second := createOrderWithStatus(&fields, user.ID, worker.ID, models.InProgressOrderStatus, 200, nil)
+	untagged := createOrderWithStatus(&fields, user.ID, worker.ID, models.NewOrderStatus, 300, nil)
+
+	// adding the same tag twice keeps one copy
+	require.NoError(t, orderRepository.AddTag(first.ID, "vip"))
+	require.NoError(t, orderRepository.AddTag(first.ID, "vip"))
+	require.NoError(t, orderRepository.AddTag(first.ID, "urgent"))
+	require.NoError(t, orderRepository.AddTag(second.ID, "vip"))
+
+	tags, err := orderRepository.GetTags(first.ID)
+	require.NoError(t, err)
+	require.Equal(t, []string{"urgent", "vip"}, tags)
+
+	tags, err = orderRepository.GetTags(untagged.ID)
+	require.NoError(t, err)
+	require.Empty(t, tags)
+
+	orders, err := orderRepository.GetOrdersByTag("vip")
+	require.NoError(t, err)
+	require.Len(t, orders, 2)
+	require.ElementsMatch(t, []uuid.UUID{first.ID, second.ID}, []uuid.UUID{orders[0].ID, orders[1].ID})
+
+	require.NoError(t, orderRepository.RemoveTag(first.ID, "urgent"))
+	require.Equal(t, repository_errors.DoesNotExist, orderRepository.RemoveTag(first.ID, "urgent"))
+
+	tags, err = orderRepository.GetTags(first.ID)
+	require.NoError(t, err)
+	require.Equal(t, []string{"vip"}, tags)
+
+	orders, err = orderRepository.GetOrdersByTag("urgent")
+	require.NoError(t, err)
+	require.Empty(t, orders)
+
+	// tags are removed together with the order
+	require.NoError(t, orderRepository.HardDelete(second.ID))
+	orders, err = orderRepository.GetOrdersByTag("vip")
+	require.NoError(t, err)
+	require.Len(t, orders, 1)
+	require.Equal(t, first.ID, orders[0].ID)
+}
+
 var testOrderRepositoryUpdateSuccess = []struct {
 	TestName string
 
@@ -331,6 +511,214 @@ func TestOrderRepositoryUpdate(t *testing.T) {
 	}
 }
 
+func TestOrderRepositoryUpdateCancellationReason(t *testing.T) {
+	dbContainer, db := SetupTestDatabase()
+	defer func(dbContainer testcontainers.Container, ctx context.Context) {
+		err := dbContainer.Terminate(ctx)
+		if err != nil {
+			return
+		}
+	}(dbContainer, context.Background())
+
+	fields := postgres.PostgresConnection{DB: db}
+	orderRepository := postgres.CreateOrderRepository(&fields)
+
+	user := createUser(&fields)
+	worker := createWorker(&fields)
+	order := createOrderWithStatus(&fields, user.ID, worker.ID, models.NewOrderStatus, 100, nil)
+	require.Empty(t, order.CancellationReason)
+
+	cancelledAt := time.Now()
+	order.Status = models.CancelledOrderStatus
+	order.CancelledAt = &cancelledAt
+	order.CancellationReason = "Plans changed"
+
+	updatedOrder, err := orderRepository.Update(order)
+	require.NoError(t, err)
+	require.Equal(t, "Plans changed", updatedOrder.CancellationReason)
+
+	receivedOrder, err := orderRepository.GetOrderByID(order.ID)
+	require.NoError(t, err)
+	require.Equal(t, models.CancelledOrderStatus, receivedOrder.Status)
+	require.Equal(t, "Plans changed", receivedOrder.CancellationReason)
+	require.NotNil(t, receivedOrder.CancelledAt)
+}
+
+var testOrderRepositoryQuotedTotalSuccess = []struct {
+	TestName    string
+	CheckOutput func(t *testing.T, createdOrder *models.Order, receivedOrder *models.Order, err error)
+}{
+	{
+		TestName: "quoted total stays the same after task price change",
+		CheckOutput: func(t *testing.T, createdOrder *models.Order, receivedOrder *models.Order, err error) {
+			require.NoError(t, err)
+			require.Equal(t, 400.0, createdOrder.QuotedTotal)
+			require.Equal(t, createdOrder.QuotedTotal, receivedOrder.QuotedTotal)
+		},
+	},
+}
+
+func TestOrderRepositoryQuotedTotal(t *testing.T) {
+	dbContainer, db := SetupTestDatabase()
+	defer func(dbContainer testcontainers.Container, ctx context.Context) {
+		err := dbContainer.Terminate(ctx)
+		if err != nil {
+			return
+		}
+	}(dbContainer, context.Background())
+
+	fields := postgres.PostgresConnection{DB: db}
+
+	for _, test := range testOrderRepositoryQuotedTotalSuccess {
+		orderRepository := postgres.CreateOrderRepository(&fields)
+		taskRepository := postgres.CreateTaskRepository(&fields)
+		t.Run(test.TestName, func(t *testing.T) {
+			user := createUser(&fields)
+			tasks := createTasks(&fields)
+
+			createdOrder, err := orderRepository.Create(&models.Order{
+				UserID:      user.ID,
+				Status:      1,
+				Address:     "Address",
+				Deadline:    time.Now().AddDate(0, 0, 1),
+				QuotedTotal: 400,
+			}, tasks)
+			require.NoError(t, err)
+
+			tasks[0].Task.PricePerSingle = 1000
+			_, err = taskRepository.Update(tasks[0].Task)
+			require.NoError(t, err)
+
+			receivedOrder, err := orderRepository.GetOrderByID(createdOrder.ID)
+			test.CheckOutput(t, createdOrder, receivedOrder, err)
+		})
+	}
+}
+
+func TestOrderRepositoryRequoteOrder(t *testing.T) {
+	dbContainer, db := SetupTestDatabase()
+	defer func(dbContainer testcontainers.Container, ctx context.Context) {
+		err := dbContainer.Terminate(ctx)
+		if err != nil {
+			return
+		}
+	}(dbContainer, context.Background())
+
+	fields := postgres.PostgresConnection{DB: db}
+	orderRepository := postgres.CreateOrderRepository(&fields)
+
+	user := createUser(&fields)
+	worker := createWorker(&fields)
+
+	// createTasks attaches 2 x 100 and 1 x 200
+	order := createOrderWithStatus(&fields, user.ID, worker.ID, models.NewOrderStatus, 400, nil)
+
+	added, err := postgres.CreateTaskRepository(&fields).Create(&models.Task{
+		ID:             uuid.New(),
+		Name:           "Added Task",
+		PricePerSingle: 300,
+		Category:       1,
+	})
+	require.NoError(t, err)
+	require.NoError(t, orderRepository.AddTaskToOrder(order.ID, added.ID, 1))
+	require.NoError(t, orderRepository.RequoteOrder(order.ID, models.RoundHalfUp))
+
+	received, err := orderRepository.GetOrderByID(order.ID)
+	require.NoError(t, err)
+	require.Equal(t, 700.0, received.QuotedTotal)
+
+	completedAt := time.Date(2024, 1, 15, 12, 0, 0, 0, time.UTC)
+	received.Status = models.CompletedOrderStatus
+	received.CompletedAt = &completedAt
+	_, err = orderRepository.Update(received)
+	require.NoError(t, err)
+
+	// the receipt bills every line at the unit price stored with it
+	lines, err := orderRepository.GetOrderedTasks(order.ID)
+	require.NoError(t, err)
+	var receiptTotal float64
+	for _, line := range lines {
+		receiptTotal += line.Task.PricePerSingle * float64(line.Quantity)
+	}
+
+	summary, err := orderRepository.GetRevenueSummary(completedAt.Add(-time.Hour), completedAt.Add(time.Hour))
+	require.NoError(t, err)
+	require.Equal(t, receiptTotal, summary.Revenue)
+
+	t.Run("total is rounded with the given mode", func(t *testing.T) {
+		_, err := db.Exec(`UPDATE order_contains_tasks SET price_at_order = price_at_order + 0.0025 WHERE order_id = $1 AND task_id = $2;`, order.ID, added.ID)
+		require.NoError(t, err)
+
+		for mode, expected := range map[models.RoundingMode]float64{models.RoundUp: 700.01, models.RoundDown: 700} {
+			require.NoError(t, orderRepository.RequoteOrder(order.ID, mode))
+
+			received, err := orderRepository.GetOrderByID(order.ID)
+			require.NoError(t, err)
+			require.Equal(t, expected, received.QuotedTotal)
+		}
+	})
+}
+
+func TestOrderRepositoryPriceAtOrder(t *testing.T) {
+	dbContainer, db := SetupTestDatabase()
+	defer func(dbContainer testcontainers.Container, ctx context.Context) {
+		err := dbContainer.Terminate(ctx)
+		if err != nil {
+			return
+		}
+	}(dbContainer, context.Background())
+
+	fields := postgres.PostgresConnection{DB: db}
+	orderRepository := postgres.CreateOrderRepository(&fields)
+	taskRepository := postgres.CreateTaskRepository(&fields)
+
+	user := createUser(&fields)
+	tasks := createTasks(&fields)
+
+	createdOrder, err := orderRepository.Create(&models.Order{
+		UserID:   user.ID,
+		Status:   models.NewOrderStatus,
+		Address:  "Address",
+		Deadline: time.Now().AddDate(0, 0, 1),
+	}, tasks[:1])
+	require.NoError(t, err)
+	err = orderRepository.AddTaskToOrder(createdOrder.ID, tasks[1].Task.ID, 1)
+	require.NoError(t, err)
+
+	// createTasks attaches 2 x 100 and 1 x 200
+	for _, orderedTask := range tasks {
+		orderedTask.Task.PricePerSingle *= 10
+		_, err = taskRepository.Update(orderedTask.Task)
+		require.NoError(t, err)
+	}
+
+	total, err := orderRepository.GetOrderTotalPrice(createdOrder.ID)
+	require.NoError(t, err)
+	require.Equal(t, 400.0, total)
+
+	orderedTasks, err := orderRepository.GetOrderedTasks(createdOrder.ID)
+	require.NoError(t, err)
+	require.Len(t, orderedTasks, 2)
+	require.Equal(t, 100.0, orderedTasks[0].Task.PricePerSingle)
+	require.Equal(t, 200.0, orderedTasks[1].Task.PricePerSingle)
+
+	// lines stored before prices were kept fall back to the current price
+	_, err = db.Exec(`UPDATE order_contains_tasks SET price_at_order = NULL WHERE order_id = $1 AND task_id = $2;`, createdOrder.ID, tasks[1].Task.ID)
+	require.NoError(t, err)
+
+	total, err = orderRepository.GetOrderTotalPrice(createdOrder.ID)
+	require.NoError(t, err)
+	require.Equal(t, 2200.0, total)
+
+	// recomputing new orders brings the stored prices up to date
+	_, err = orderRepository.RecomputeNewOrderTotals(models.RoundHalfUp)
+	require.NoError(t, err)
+
+	total, err = orderRepository.GetOrderTotalPrice(createdOrder.ID)
+	require.NoError(t, err)
+	require.Equal(t, 4000.0, total)
+}
+
 var testOrderRepositoryGetTasksInOrderSuccess = []struct {
 	TestName    string
 	CheckOutput func(t *testing.T, createdTasks []models.OrderedTask, receivedTasks []models.Task, err error)
@@ -425,6 +813,43 @@ func TestOrderRepositoryGetCurrentOrderByUserID(t *testing.T) {
 	}
 }
 
+func TestOrderRepositoryGetCurrentOrderByUserIDPrefersActive(t *testing.T) {
+	dbContainer, db := SetupTestDatabase()
+	defer func(dbContainer testcontainers.Container, ctx context.Context) {
+		err := dbContainer.Terminate(ctx)
+		if err != nil {
+			return
+		}
+	}(dbContainer, context.Background())
+
+	fields := postgres.PostgresConnection{DB: db}
+	orderRepository := postgres.CreateOrderRepository(&fields)
+
+	user := createUser(&fields)
+	worker := createWorker(&fields)
+
+	inProgress := createOrderWithStatus(&fields, user.ID, worker.ID, models.InProgressOrderStatus, 100, nil)
+	cancelled := createOrderWithStatus(&fields, user.ID, worker.ID, models.CancelledOrderStatus, 100, nil)
+
+	t.Run("older in-progress order wins over a newer cancelled one", func(t *testing.T) {
+		receivedOrder, err := orderRepository.GetCurrentOrderByUserID(user.ID)
+		require.NoError(t, err)
+		require.Equal(t, inProgress.ID, receivedOrder.ID)
+	})
+
+	t.Run("latest order without active ones", func(t *testing.T) {
+		completedAt := time.Now()
+		inProgress.Status = models.CompletedOrderStatus
+		inProgress.CompletedAt = &completedAt
+		_, err := orderRepository.Update(inProgress)
+		require.NoError(t, err)
+
+		receivedOrder, err := orderRepository.GetCurrentOrderByUserID(user.ID)
+		require.NoError(t, err)
+		require.Equal(t, cancelled.ID, receivedOrder.ID)
+	})
+}
+
 var testOrderRepositoryGetAllOrdersByUserIDSuccess = []struct {
 	TestName    string
 	CheckOutput func(t *testing.T, createdOrders []models.Order, receivedOrders []models.Order, err error)
@@ -484,19 +909,7 @@ func TestOrderRepositoryGetAllOrdersByUserID(t *testing.T) {
 	}
 }
 
-var testOrderRepositoryAddTaskToOrderSuccess = []struct {
-	TestName    string
-	CheckOutput func(t *testing.T, createdOrder *models.Order, err error)
-}{
-	{
-		TestName: "add task to order success test",
-		CheckOutput: func(t *testing.T, createdOrder *models.Order, err error) {
-			require.NoError(t, err)
-		},
-	},
-}
-
-func TestOrderRepositoryAddTaskToOrder(t *testing.T) {
+func TestOrderRepositoryGetAllOrdersByWorkerID(t *testing.T) {
 	dbContainer, db := SetupTestDatabase()
 	defer func(dbContainer testcontainers.Container, ctx context.Context) {
 		err := dbContainer.Terminate(ctx)
@@ -506,56 +919,536 @@ func TestOrderRepositoryAddTaskToOrder(t *testing.T) {
 	}(dbContainer, context.Background())
 
 	fields := postgres.PostgresConnection{DB: db}
+	orderRepository := postgres.CreateOrderRepository(&fields)
 
-	for _, test := range testOrderRepositoryAddTaskToOrderSuccess {
-		orderRepository := postgres.CreateOrderRepository(&fields)
-		t.Run(test.TestName, func(t *testing.T) {
-			user := createUser(&fields)
-			worker := createWorker(&fields)
-			createdTasks := createTasks(&fields)
+	user := createUser(&fields)
+	worker := createWorker(&fields)
+	idleWorker, _ := postgres.CreateWorkerRepository(&fields).Create(&models.Worker{
+		ID:          uuid.New(),
+		Name:        "Idle Name",
+		Surname:     "Idle Surname",
+		Address:     "Idle Address",
+		PhoneNumber: "+79999999997",
+		Email:       "idle@email.com",
+		Password:    "hashed_password",
+		Role:        models.MasterRole,
+	})
 
-			createdOrder, _ := orderRepository.Create(&models.Order{
-				ID:       uuid.New(),
-				WorkerID: worker.ID,
-				UserID:   user.ID,
-				Status:   1,
-				Address:  "Address",
-				Deadline: time.Now().AddDate(0, 0, 1),
-				Rate:     0,
-			}, createdTasks)
+	first := createOrderWithStatus(&fields, user.ID, worker.ID, models.InProgressOrderStatus, 0, nil)
+	second := createOrderWithStatus(&fields, user.ID, worker.ID, models.CompletedOrderStatus, 0, nil)
+	deleted := createOrderWithStatus(&fields, user.ID, worker.ID, models.NewOrderStatus, 0, nil)
+	require.NoError(t, orderRepository.Delete(deleted.ID))
 
-			task := &models.Task{
-				ID:             uuid.New(),
-				Name:           "Task Name",
-				PricePerSingle: 100,
-				Category:       1,
-			}
+	t.Run("worker with several orders, newest first", func(t *testing.T) {
+		orders, err := orderRepository.GetAllOrdersByWorkerID(worker.ID)
+		require.NoError(t, err)
+		require.Len(t, orders, 2)
+		require.Equal(t, []uuid.UUID{second.ID, first.ID}, []uuid.UUID{orders[0].ID, orders[1].ID})
+	})
 
-			createdTask, _ := postgres.CreateTaskRepository(&fields).Create(task)
+	t.Run("worker without orders", func(t *testing.T) {
+		orders, err := orderRepository.GetAllOrdersByWorkerID(idleWorker.ID)
+		require.NoError(t, err)
+		require.Empty(t, orders)
+	})
+}
 
-			err := orderRepository.AddTaskToOrder(createdOrder.ID, createdTask.ID)
-			test.CheckOutput(t, createdOrder, err)
+func TestOrderRepositoryAddTasksToOrder(t *testing.T) {
+	dbContainer, db := SetupTestDatabase()
+	defer func(dbContainer testcontainers.Container, ctx context.Context) {
+		err := dbContainer.Terminate(ctx)
+		if err != nil {
+			return
+		}
+	}(dbContainer, context.Background())
 
-			tasks, err := orderRepository.GetTasksInOrder(createdOrder.ID)
-			require.NoError(t, err)
-			require.Equal(t, 3, len(tasks))
+	fields := postgres.PostgresConnection{DB: db}
+	orderRepository := postgres.CreateOrderRepository(&fields)
+	taskRepository := postgres.CreateTaskRepository(&fields)
+
+	user := createUser(&fields)
+	worker := createWorker(&fields)
+	order := createOrderWithStatus(&fields, user.ID, worker.ID, models.NewOrderStatus, 0, nil)
+
+	attached, err := orderRepository.GetTasksInOrder(order.ID)
+	require.NoError(t, err)
+	require.NotEmpty(t, attached)
+
+	newTask, err := taskRepository.Create(&models.Task{Name: "Batch Task", PricePerSingle: 100, Category: 1})
+	require.NoError(t, err)
+	otherTask, err := taskRepository.Create(&models.Task{Name: "Other Batch Task", PricePerSingle: 200, Category: 1})
+	require.NoError(t, err)
+
+	t.Run("partial failure inserts nothing", func(t *testing.T) {
+		err := orderRepository.AddTasksToOrder(order.ID, []models.OrderedTask{
+			{Task: newTask, Quantity: 2},
+			{Task: &attached[0], Quantity: 1},
 		})
-	}
-}
+		require.ErrorIs(t, err, repository_errors.AlreadyExists)
 
-var testOrderRepositoryRemoveTaskFromOrderSuccess = []struct {
-	TestName    string
-	CheckOutput func(t *testing.T, createdOrder *models.Order, err error)
-}{
-	{
-		TestName: "remove task from order success test",
-		CheckOutput: func(t *testing.T, createdOrder *models.Order, err error) {
-			require.NoError(t, err)
-		},
-	},
+		tasks, err := orderRepository.GetTasksInOrder(order.ID)
+		require.NoError(t, err)
+		require.Len(t, tasks, len(attached))
+	})
+
+	t.Run("task listed twice inserts nothing", func(t *testing.T) {
+		err := orderRepository.AddTasksToOrder(order.ID, []models.OrderedTask{
+			{Task: newTask, Quantity: 1},
+			{Task: newTask, Quantity: 1},
+		})
+		require.ErrorIs(t, err, repository_errors.AlreadyExists)
+
+		tasks, err := orderRepository.GetTasksInOrder(order.ID)
+		require.NoError(t, err)
+		require.Len(t, tasks, len(attached))
+	})
+
+	t.Run("all tasks are added", func(t *testing.T) {
+		err := orderRepository.AddTasksToOrder(order.ID, []models.OrderedTask{
+			{Task: newTask, Quantity: 2},
+			{Task: otherTask, Quantity: 3},
+		})
+		require.NoError(t, err)
+
+		tasks, err := orderRepository.GetTasksInOrder(order.ID)
+		require.NoError(t, err)
+		require.Len(t, tasks, len(attached)+2)
+
+		quantity, err := orderRepository.GetTaskQuantity(order.ID, otherTask.ID)
+		require.NoError(t, err)
+		require.Equal(t, 3, quantity)
+	})
 }
 
-func TestOrderRepositoryRemoveTaskFromOrder(t *testing.T) {
+func TestOrderRepositoryGetOrderTotalPrice(t *testing.T) {
+	dbContainer, db := SetupTestDatabase()
+	defer func(dbContainer testcontainers.Container, ctx context.Context) {
+		err := dbContainer.Terminate(ctx)
+		if err != nil {
+			return
+		}
+	}(dbContainer, context.Background())
+
+	fields := postgres.PostgresConnection{DB: db}
+	orderRepository := postgres.CreateOrderRepository(&fields)
+	taskRepository := postgres.CreateTaskRepository(&fields)
+
+	user := createUser(&fields)
+
+	var orderedTasks []models.OrderedTask
+	for _, seed := range []struct {
+		price    float64
+		quantity int
+	}{
+		{149.99, 1},
+		{20.5, 4},
+		{1000, 3},
+	} {
+		task, err := taskRepository.Create(&models.Task{Name: "Priced Task", PricePerSingle: seed.price, Category: 1})
+		require.NoError(t, err)
+		orderedTasks = append(orderedTasks, models.OrderedTask{Task: task, Quantity: seed.quantity})
+	}
+
+	order, err := orderRepository.Create(&models.Order{
+		UserID:   user.ID,
+		Status:   models.NewOrderStatus,
+		Address:  "Address",
+		Deadline: time.Now().AddDate(0, 0, 1),
+	}, orderedTasks)
+	require.NoError(t, err)
+
+	var expected float64
+	for _, task := range orderedTasks {
+		expected += task.Task.PricePerSingle * float64(task.Quantity)
+	}
+
+	t.Run("total matches the manual sum", func(t *testing.T) {
+		total, err := orderRepository.GetOrderTotalPrice(order.ID)
+		require.NoError(t, err)
+		require.InDelta(t, expected, total, 1e-9)
+	})
+
+	t.Run("order without tasks", func(t *testing.T) {
+		total, err := orderRepository.GetOrderTotalPrice(uuid.New())
+		require.NoError(t, err)
+		require.Equal(t, 0.0, total)
+	})
+}
+
+func TestOrderRepositoryConcurrentTaskQuantity(t *testing.T) {
+	dbContainer, db := SetupTestDatabase()
+	defer func(dbContainer testcontainers.Container, ctx context.Context) {
+		err := dbContainer.Terminate(ctx)
+		if err != nil {
+			return
+		}
+	}(dbContainer, context.Background())
+
+	fields := postgres.PostgresConnection{DB: db}
+	orderRepository := postgres.CreateOrderRepository(&fields)
+
+	user := createUser(&fields)
+	orderedTasks := createTasks(&fields)
+	order, err := orderRepository.Create(&models.Order{
+		UserID:   user.ID,
+		Status:   models.NewOrderStatus,
+		Address:  "Address",
+		Deadline: time.Now().AddDate(0, 0, 1),
+	}, orderedTasks)
+	require.NoError(t, err)
+
+	taskID := orderedTasks[0].Task.ID
+	initial := orderedTasks[0].Quantity
+	const workers = 20
+
+	t.Run("interleaved increments are not lost", func(t *testing.T) {
+		var wg sync.WaitGroup
+		errs := make(chan error, workers)
+		for i := 0; i < workers; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				_, err := orderRepository.IncrementTaskQuantity(order.ID, taskID)
+				errs <- err
+			}()
+		}
+		wg.Wait()
+		close(errs)
+
+		for err := range errs {
+			require.NoError(t, err)
+		}
+
+		quantity, err := orderRepository.GetTaskQuantity(order.ID, taskID)
+		require.NoError(t, err)
+		require.Equal(t, initial+workers, quantity)
+	})
+
+	t.Run("interleaved decrements stop at zero", func(t *testing.T) {
+		var wg sync.WaitGroup
+		errs := make(chan error, 2*(initial+workers))
+		for i := 0; i < 2*(initial+workers); i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				_, err := orderRepository.DecrementTaskQuantity(order.ID, taskID)
+				errs <- err
+			}()
+		}
+		wg.Wait()
+		close(errs)
+
+		succeeded := 0
+		for err := range errs {
+			if err == nil {
+				succeeded++
+			} else {
+				require.Equal(t, repository_errors.DoesNotExist, err)
+			}
+		}
+		require.Equal(t, initial+workers, succeeded)
+
+		quantity, err := orderRepository.GetTaskQuantity(order.ID, taskID)
+		require.NoError(t, err)
+		require.Equal(t, 0, quantity)
+	})
+
+	t.Run("task not in the order", func(t *testing.T) {
+		_, err := orderRepository.IncrementTaskQuantity(order.ID, uuid.New())
+		require.Equal(t, repository_errors.DoesNotExist, err)
+	})
+}
+
+func TestOrderRepositoryGetOrderTotalPriceWithTiers(t *testing.T) {
+	dbContainer, db := SetupTestDatabase()
+	defer func(dbContainer testcontainers.Container, ctx context.Context) {
+		err := dbContainer.Terminate(ctx)
+		if err != nil {
+			return
+		}
+	}(dbContainer, context.Background())
+
+	fields := postgres.PostgresConnection{DB: db}
+	orderRepository := postgres.CreateOrderRepository(&fields)
+	taskRepository := postgres.CreateTaskRepository(&fields)
+
+	user := createUser(&fields)
+
+	var orderedTasks []models.OrderedTask
+	for _, seed := range []struct {
+		price    float64
+		quantity int
+		tiers    []models.PriceTier
+	}{
+		// reaches the largest tier
+		{100, 12, []models.PriceTier{{MinQuantity: 5, Price: 80}, {MinQuantity: 10, Price: 70}}},
+		// stays below its tier
+		{50, 2, []models.PriceTier{{MinQuantity: 5, Price: 40}}},
+		// has no tiers
+		{30, 3, nil},
+	} {
+		task, err := taskRepository.Create(&models.Task{Name: "Tiered Task", PricePerSingle: seed.price, Category: 1})
+		require.NoError(t, err)
+		require.NoError(t, taskRepository.SetPriceTiers(task.ID, seed.tiers))
+		orderedTasks = append(orderedTasks, models.OrderedTask{Task: task, Quantity: seed.quantity})
+	}
+
+	order, err := orderRepository.Create(&models.Order{
+		UserID:   user.ID,
+		Status:   models.NewOrderStatus,
+		Address:  "Address",
+		Deadline: time.Now().AddDate(0, 0, 1),
+	}, orderedTasks)
+	require.NoError(t, err)
+
+	t.Run("tiered total", func(t *testing.T) {
+		total, err := orderRepository.GetOrderTotalPrice(order.ID)
+		require.NoError(t, err)
+		require.InDelta(t, 70.0*12+50*2+30*3, total, 1e-9)
+	})
+
+	t.Run("later tier changes do not affect the order", func(t *testing.T) {
+		require.NoError(t, taskRepository.SetPriceTiers(orderedTasks[0].Task.ID, []models.PriceTier{{MinQuantity: 5, Price: 60}}))
+
+		total, err := orderRepository.GetOrderTotalPrice(order.ID)
+		require.NoError(t, err)
+		require.InDelta(t, 70.0*12+50*2+30*3, total, 1e-9)
+
+		stored, err := orderRepository.GetOrderedTasks(order.ID)
+		require.NoError(t, err)
+		for _, task := range stored {
+			if task.Task.ID == orderedTasks[0].Task.ID {
+				require.Equal(t, 70.0, task.Task.PricePerSingle)
+			}
+		}
+	})
+
+	t.Run("recomputed quote", func(t *testing.T) {
+		_, err := orderRepository.RecomputeNewOrderTotals(models.RoundHalfUp)
+		require.NoError(t, err)
+
+		recomputed, err := orderRepository.GetOrderByID(order.ID)
+		require.NoError(t, err)
+		require.InDelta(t, 60.0*12+50*2+30*3, recomputed.QuotedTotal, 1e-9)
+
+		total, err := orderRepository.GetOrderTotalPrice(order.ID)
+		require.NoError(t, err)
+		require.InDelta(t, 60.0*12+50*2+30*3, total, 1e-9)
+	})
+}
+
+func TestOrderRepositoryImportOrders(t *testing.T) {
+	dbContainer, db := SetupTestDatabase()
+	defer func(dbContainer testcontainers.Container, ctx context.Context) {
+		err := dbContainer.Terminate(ctx)
+		if err != nil {
+			return
+		}
+	}(dbContainer, context.Background())
+
+	fields := postgres.PostgresConnection{DB: db}
+	orderRepository := postgres.CreateOrderRepository(&fields)
+
+	user := createUser(&fields)
+	worker := createWorker(&fields)
+	tasks := createTasks(&fields)
+
+	completedAt := time.Date(2024, 5, 2, 15, 0, 0, 0, time.UTC)
+	orders := []models.OrderWithTasks{
+		{
+			Order: models.Order{UserID: user.ID, Status: models.NewOrderStatus, Address: "First address",
+				CreationDate: time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC), Deadline: time.Date(2024, 6, 1, 10, 0, 0, 0, time.UTC), QuotedTotal: 300},
+			// ordered when the task was cheaper
+			Tasks: []models.OrderedTask{{Task: &models.Task{ID: tasks[0].Task.ID, PricePerSingle: 90}, Quantity: 3}},
+		},
+		{
+			Order: models.Order{UserID: user.ID, WorkerID: worker.ID, Status: models.CompletedOrderStatus, Address: "Second address", Rate: 4,
+				CreationDate: time.Date(2024, 4, 1, 10, 0, 0, 0, time.UTC), Deadline: time.Date(2024, 5, 3, 10, 0, 0, 0, time.UTC), QuotedTotal: 1200,
+				AssignedAt: &completedAt, CompletedAt: &completedAt},
+			Tasks: []models.OrderedTask{{Task: tasks[0].Task, Quantity: 1}, {Task: tasks[1].Task, Quantity: 5}},
+		},
+	}
+
+	t.Run("orders are recreated with their tasks", func(t *testing.T) {
+		err := orderRepository.ImportOrders(orders)
+		require.NoError(t, err)
+
+		for _, imported := range orders {
+			require.NotEqual(t, uuid.Nil, imported.Order.ID)
+
+			order, err := orderRepository.GetOrderByID(imported.Order.ID)
+			require.NoError(t, err)
+			require.Equal(t, imported.Order.Status, order.Status)
+			require.Equal(t, imported.Order.WorkerID, order.WorkerID)
+			require.Equal(t, imported.Order.Rate, order.Rate)
+			require.Equal(t, imported.Order.QuotedTotal, order.QuotedTotal)
+
+			for _, task := range imported.Tasks {
+				quantity, err := orderRepository.GetTaskQuantity(order.ID, task.Task.ID)
+				require.NoError(t, err)
+				require.Equal(t, task.Quantity, quantity)
+			}
+		}
+
+		stored, err := orderRepository.GetOrderedTasks(orders[0].Order.ID)
+		require.NoError(t, err)
+		require.Equal(t, 90.0, stored[0].Task.PricePerSingle)
+	})
+
+	t.Run("nothing is recreated when a task is missing", func(t *testing.T) {
+		before, err := orderRepository.GetAllOrdersByUserID(user.ID)
+		require.NoError(t, err)
+
+		err = orderRepository.ImportOrders([]models.OrderWithTasks{
+			{
+				Order: models.Order{UserID: user.ID, Status: models.NewOrderStatus, Address: "Address", Deadline: time.Now().AddDate(0, 0, 1)},
+				Tasks: []models.OrderedTask{{Task: tasks[0].Task, Quantity: 1}},
+			},
+			{
+				Order: models.Order{UserID: user.ID, Status: models.NewOrderStatus, Address: "Address", Deadline: time.Now().AddDate(0, 0, 1)},
+				Tasks: []models.OrderedTask{{Task: &models.Task{ID: uuid.New()}, Quantity: 1}},
+			},
+		})
+		require.Equal(t, repository_errors.InsertError, err)
+
+		after, err := orderRepository.GetAllOrdersByUserID(user.ID)
+		require.NoError(t, err)
+		require.Len(t, after, len(before))
+	})
+}
+
+func TestOrderRepositoryGetOrderedTasks(t *testing.T) {
+	dbContainer, db := SetupTestDatabase()
+	defer func(dbContainer testcontainers.Container, ctx context.Context) {
+		err := dbContainer.Terminate(ctx)
+		if err != nil {
+			return
+		}
+	}(dbContainer, context.Background())
+
+	fields := postgres.PostgresConnection{DB: db}
+	orderRepository := postgres.CreateOrderRepository(&fields)
+	taskRepository := postgres.CreateTaskRepository(&fields)
+
+	user := createUser(&fields)
+
+	quantities := map[string]int{"Dusting": 2, "Mopping": 5, "Windows": 1}
+	var orderedTasks []models.OrderedTask
+	for name, quantity := range quantities {
+		task, err := taskRepository.Create(&models.Task{Name: name, PricePerSingle: 100, Category: 1})
+		require.NoError(t, err)
+		orderedTasks = append(orderedTasks, models.OrderedTask{Task: task, Quantity: quantity})
+	}
+
+	order, err := orderRepository.Create(&models.Order{
+		UserID:   user.ID,
+		Status:   models.NewOrderStatus,
+		Address:  "Address",
+		Deadline: time.Now().AddDate(0, 0, 1),
+	}, orderedTasks)
+	require.NoError(t, err)
+
+	t.Run("tasks come with their quantities", func(t *testing.T) {
+		tasks, err := orderRepository.GetOrderedTasks(order.ID)
+		require.NoError(t, err)
+		require.Len(t, tasks, len(quantities))
+		for i, task := range tasks {
+			require.Equal(t, quantities[task.Task.Name], task.Quantity)
+			if i > 0 {
+				require.Less(t, tasks[i-1].Task.Name, task.Task.Name)
+			}
+		}
+	})
+
+	t.Run("order without tasks", func(t *testing.T) {
+		tasks, err := orderRepository.GetOrderedTasks(uuid.New())
+		require.NoError(t, err)
+		require.Empty(t, tasks)
+	})
+}
+
+var testOrderRepositoryAddTaskToOrderSuccess = []struct {
+	TestName    string
+	CheckOutput func(t *testing.T, createdOrder *models.Order, err error)
+}{
+	{
+		TestName: "add task to order success test",
+		CheckOutput: func(t *testing.T, createdOrder *models.Order, err error) {
+			require.NoError(t, err)
+		},
+	},
+}
+
+func TestOrderRepositoryAddTaskToOrder(t *testing.T) {
+	dbContainer, db := SetupTestDatabase()
+	defer func(dbContainer testcontainers.Container, ctx context.Context) {
+		err := dbContainer.Terminate(ctx)
+		if err != nil {
+			return
+		}
+	}(dbContainer, context.Background())
+
+	fields := postgres.PostgresConnection{DB: db}
+
+	for _, test := range testOrderRepositoryAddTaskToOrderSuccess {
+		orderRepository := postgres.CreateOrderRepository(&fields)
+		t.Run(test.TestName, func(t *testing.T) {
+			user := createUser(&fields)
+			worker := createWorker(&fields)
+			createdTasks := createTasks(&fields)
+
+			createdOrder, _ := orderRepository.Create(&models.Order{
+				ID:       uuid.New(),
+				WorkerID: worker.ID,
+				UserID:   user.ID,
+				Status:   1,
+				Address:  "Address",
+				Deadline: time.Now().AddDate(0, 0, 1),
+				Rate:     0,
+			}, createdTasks)
+
+			task := &models.Task{
+				ID:             uuid.New(),
+				Name:           "Task Name",
+				PricePerSingle: 100,
+				Category:       1,
+			}
+
+			createdTask, _ := postgres.CreateTaskRepository(&fields).Create(task)
+
+			err := orderRepository.AddTaskToOrder(createdOrder.ID, createdTask.ID, 1)
+			test.CheckOutput(t, createdOrder, err)
+
+			tasks, err := orderRepository.GetTasksInOrder(createdOrder.ID)
+			require.NoError(t, err)
+			require.Equal(t, 3, len(tasks))
+
+			quantity, err := orderRepository.GetTaskQuantity(createdOrder.ID, createdTask.ID)
+			require.NoError(t, err)
+			require.Equal(t, 1, quantity)
+
+			err = orderRepository.UpdateTaskQuantity(createdOrder.ID, createdTask.ID, quantity+1)
+			require.NoError(t, err)
+			quantity, err = orderRepository.GetTaskQuantity(createdOrder.ID, createdTask.ID)
+			require.NoError(t, err)
+			require.Equal(t, 2, quantity)
+		})
+	}
+}
+
+var testOrderRepositoryRemoveTaskFromOrderSuccess = []struct {
+	TestName    string
+	CheckOutput func(t *testing.T, createdOrder *models.Order, err error)
+}{
+	{
+		TestName: "remove task from order success test",
+		CheckOutput: func(t *testing.T, createdOrder *models.Order, err error) {
+			require.NoError(t, err)
+		},
+	},
+}
+
+func TestOrderRepositoryRemoveTaskFromOrder(t *testing.T) {
 	dbContainer, db := SetupTestDatabase()
 	defer func(dbContainer testcontainers.Container, ctx context.Context) {
 		err := dbContainer.Terminate(ctx)
@@ -692,3 +1585,998 @@ func TestOrderRepositoryGetTaskQuantity(t *testing.T) {
 		})
 	}
 }
+
+var testOrderRepositoryGetOrdersByIDsSuccess = []struct {
+	TestName    string
+	CheckOutput func(t *testing.T, createdOrders []*models.Order, receivedOrders []models.Order, emptyOrders []models.Order, err error)
+}{
+	{
+		TestName: "get orders by ids success test",
+		CheckOutput: func(t *testing.T, createdOrders []*models.Order, receivedOrders []models.Order, emptyOrders []models.Order, err error) {
+			require.NoError(t, err)
+			require.Len(t, receivedOrders, len(createdOrders))
+			for _, createdOrder := range createdOrders {
+				found := false
+				for _, receivedOrder := range receivedOrders {
+					if receivedOrder.ID == createdOrder.ID {
+						found = true
+					}
+				}
+				require.True(t, found)
+			}
+			require.Empty(t, emptyOrders)
+		},
+	},
+}
+
+func TestOrderRepositoryGetOrdersByIDs(t *testing.T) {
+	dbContainer, db := SetupTestDatabase()
+	defer func(dbContainer testcontainers.Container, ctx context.Context) {
+		err := dbContainer.Terminate(ctx)
+		if err != nil {
+			return
+		}
+	}(dbContainer, context.Background())
+
+	fields := postgres.PostgresConnection{DB: db}
+
+	for _, test := range testOrderRepositoryGetOrdersByIDsSuccess {
+		orderRepository := postgres.CreateOrderRepository(&fields)
+		t.Run(test.TestName, func(t *testing.T) {
+			user := createUser(&fields)
+			tasks := createTasks(&fields)
+
+			createdOrders := make([]*models.Order, 0)
+			ids := make([]uuid.UUID, 0)
+			for i := 0; i < 2; i++ {
+				createdOrder, err := orderRepository.Create(&models.Order{
+					UserID:   user.ID,
+					Status:   1,
+					Address:  "Address",
+					Deadline: time.Now().AddDate(0, 0, 1),
+				}, tasks)
+				require.NoError(t, err)
+				createdOrders = append(createdOrders, createdOrder)
+				ids = append(ids, createdOrder.ID, uuid.New())
+			}
+
+			receivedOrders, err := orderRepository.GetOrdersByIDs(ids)
+			require.NoError(t, err)
+			emptyOrders, err := orderRepository.GetOrdersByIDs([]uuid.UUID{})
+			test.CheckOutput(t, createdOrders, receivedOrders, emptyOrders, err)
+		})
+	}
+}
+
+var testOrderRepositoryFilterByStatusAndDate = []struct {
+	TestName string
+	Statuses []int
+	From     time.Time
+	To       time.Time
+	Expected int
+}{
+	{
+		TestName: "statuses and dates provided",
+		Statuses: []int{models.NewOrderStatus},
+		From:     time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		To:       time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC),
+		Expected: 1,
+	},
+	{
+		TestName: "only statuses provided",
+		Statuses: []int{models.NewOrderStatus},
+		Expected: 2,
+	},
+	{
+		TestName: "only dates provided",
+		From:     time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		To:       time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC),
+		Expected: 2,
+	},
+	{
+		TestName: "only start of period provided",
+		From:     time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC),
+		Expected: 1,
+	},
+	{
+		TestName: "no filters provided",
+		Expected: 3,
+	},
+}
+
+func TestOrderRepositoryFilterByStatusAndDate(t *testing.T) {
+	dbContainer, db := SetupTestDatabase()
+	defer func(dbContainer testcontainers.Container, ctx context.Context) {
+		err := dbContainer.Terminate(ctx)
+		if err != nil {
+			return
+		}
+	}(dbContainer, context.Background())
+
+	fields := postgres.PostgresConnection{DB: db}
+	orderRepository := postgres.CreateOrderRepository(&fields)
+
+	user := createUser(&fields)
+	worker := createWorker(&fields)
+	creationDates := []time.Time{
+		time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC),
+		time.Date(2024, 1, 20, 0, 0, 0, 0, time.UTC),
+		time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC),
+	}
+	statuses := []int{models.NewOrderStatus, models.CompletedOrderStatus, models.NewOrderStatus}
+	for i := range creationDates {
+		order := createOrderWithStatus(&fields, user.ID, worker.ID, statuses[i], 0, nil)
+		order.CreationDate = creationDates[i]
+		_, err := orderRepository.Update(order)
+		require.NoError(t, err)
+	}
+
+	for _, test := range testOrderRepositoryFilterByStatusAndDate {
+		t.Run(test.TestName, func(t *testing.T) {
+			orders, err := orderRepository.FilterByStatusAndDate(uuid.Nil, test.Statuses, test.From, test.To)
+			require.NoError(t, err)
+			require.Len(t, orders, test.Expected)
+		})
+	}
+
+	t.Run("newest first", func(t *testing.T) {
+		orders, err := orderRepository.FilterByStatusAndDate(uuid.Nil, nil, time.Time{}, time.Time{})
+		require.NoError(t, err)
+		require.Len(t, orders, 3)
+		require.True(t, orders[0].CreationDate.Equal(creationDates[2]))
+		require.True(t, orders[2].CreationDate.Equal(creationDates[0]))
+	})
+
+	t.Run("only orders of the user", func(t *testing.T) {
+		other, err := postgres.CreateUserRepository(&fields).Create(&models.User{
+			Name:        "Other",
+			Surname:     "Customer",
+			Address:     "Address",
+			PhoneNumber: "+79999999997",
+			Email:       "other@email.com",
+			Password:    "hashed_password",
+		})
+		require.NoError(t, err)
+		createOrderWithStatus(&fields, other.ID, worker.ID, models.NewOrderStatus, 0, nil)
+
+		orders, err := orderRepository.FilterByStatusAndDate(user.ID, []int{models.NewOrderStatus}, time.Time{}, time.Time{})
+		require.NoError(t, err)
+		require.Len(t, orders, 2)
+		for _, order := range orders {
+			require.Equal(t, user.ID, order.UserID)
+		}
+	})
+}
+
+var testOrderRepositoryFilter = []struct {
+	TestName    string
+	Params      map[string]string
+	CheckOutput func(t *testing.T, orders []models.Order, err error)
+}{
+	{
+		TestName: "value with a quote is matched literally",
+		Params:   map[string]string{"address": "O'Brien street"},
+		CheckOutput: func(t *testing.T, orders []models.Order, err error) {
+			require.NoError(t, err)
+			require.Len(t, orders, 1)
+			require.Equal(t, "O'Brien street", orders[0].Address)
+		},
+	},
+	{
+		TestName: "injected OR 1=1 in a text value matches nothing",
+		Params:   map[string]string{"address": "x' OR 1=1 --"},
+		CheckOutput: func(t *testing.T, orders []models.Order, err error) {
+			require.NoError(t, err)
+			require.Len(t, orders, 0)
+		},
+	},
+	{
+		TestName: "injected OR 1=1 in several values matches nothing",
+		Params:   map[string]string{"address": "x' OR '1'='1,y') OR (1=1"},
+		CheckOutput: func(t *testing.T, orders []models.Order, err error) {
+			require.NoError(t, err)
+			require.Len(t, orders, 0)
+		},
+	},
+	{
+		TestName: "injected OR 1=1 in status is rejected",
+		Params:   map[string]string{"status": "1 OR 1=1"},
+		CheckOutput: func(t *testing.T, orders []models.Order, err error) {
+			require.ErrorIs(t, err, repository_errors.SelectError)
+			require.Nil(t, orders)
+		},
+	},
+	{
+		TestName: "unknown field is rejected",
+		Params:   map[string]string{"1=1 OR status": "1"},
+		CheckOutput: func(t *testing.T, orders []models.Order, err error) {
+			require.ErrorIs(t, err, repository_errors.SelectError)
+			require.Nil(t, orders)
+		},
+	},
+	{
+		TestName: "several statuses are combined with OR",
+		Params:   map[string]string{"status": "1,3"},
+		CheckOutput: func(t *testing.T, orders []models.Order, err error) {
+			require.NoError(t, err)
+			require.Len(t, orders, 2)
+		},
+	},
+	{
+		TestName: "fields are combined with AND",
+		Params:   map[string]string{"status": "1,3", "address": "O'Brien street", "cancelled_at": "null"},
+		CheckOutput: func(t *testing.T, orders []models.Order, err error) {
+			require.NoError(t, err)
+			require.Len(t, orders, 1)
+			require.Equal(t, models.NewOrderStatus, orders[0].Status)
+		},
+	},
+}
+
+func TestOrderRepositoryFilter(t *testing.T) {
+	dbContainer, db := SetupTestDatabase()
+	defer func(dbContainer testcontainers.Container, ctx context.Context) {
+		err := dbContainer.Terminate(ctx)
+		if err != nil {
+			return
+		}
+	}(dbContainer, context.Background())
+
+	fields := postgres.PostgresConnection{DB: db}
+	orderRepository := postgres.CreateOrderRepository(&fields)
+
+	user := createUser(&fields)
+	worker := createWorker(&fields)
+	quoted := createOrderWithStatus(&fields, user.ID, worker.ID, models.NewOrderStatus, 0, nil)
+	quoted.Address = "O'Brien street"
+	_, err := orderRepository.Update(quoted)
+	require.NoError(t, err)
+	createOrderWithStatus(&fields, user.ID, worker.ID, models.CompletedOrderStatus, 0, nil)
+
+	for _, test := range testOrderRepositoryFilter {
+		t.Run(test.TestName, func(t *testing.T) {
+			orders, err := orderRepository.Filter(test.Params, 0, 0, false)
+			test.CheckOutput(t, orders, err)
+		})
+	}
+}
+
+var testOrderRepositoryPaged = []struct {
+	TestName      string
+	Limit         int
+	Offset        int
+	ExpectedCount int
+}{
+	{
+		TestName:      "full first page",
+		Limit:         10,
+		Offset:        0,
+		ExpectedCount: 10,
+	},
+	{
+		TestName:      "last partial page",
+		Limit:         10,
+		Offset:        10,
+		ExpectedCount: 2,
+	},
+	{
+		TestName:      "offset beyond row count",
+		Limit:         10,
+		Offset:        20,
+		ExpectedCount: 0,
+	},
+}
+
+func TestOrderRepositoryPaged(t *testing.T) {
+	dbContainer, db := SetupTestDatabase()
+	defer func(dbContainer testcontainers.Container, ctx context.Context) {
+		err := dbContainer.Terminate(ctx)
+		if err != nil {
+			return
+		}
+	}(dbContainer, context.Background())
+
+	fields := postgres.PostgresConnection{DB: db}
+	orderRepository := postgres.CreateOrderRepository(&fields)
+
+	user := createUser(&fields)
+	worker := createWorker(&fields)
+	for i := 0; i < 12; i++ {
+		order := createOrderWithStatus(&fields, user.ID, worker.ID, models.NewOrderStatus, 0, nil)
+		order.CreationDate = time.Date(2024, 1, i+1, 0, 0, 0, 0, time.UTC)
+		_, err := orderRepository.Update(order)
+		require.NoError(t, err)
+	}
+
+	for _, test := range testOrderRepositoryPaged {
+		t.Run(test.TestName, func(t *testing.T) {
+			orders, total, err := orderRepository.GetOrdersByUserIDPaged(user.ID, test.Limit, test.Offset)
+			require.NoError(t, err)
+			require.Len(t, orders, test.ExpectedCount)
+			require.Equal(t, 12, total)
+			for i := 1; i < len(orders); i++ {
+				require.True(t, orders[i-1].CreationDate.After(orders[i].CreationDate))
+			}
+
+			filtered, err := orderRepository.Filter(map[string]string{"user_id": user.ID.String()}, test.Limit, test.Offset, false)
+			require.NoError(t, err)
+			require.Equal(t, orders, filtered)
+		})
+	}
+}
+
+var testOrderRepositoryGetDigestBetween = []struct {
+	TestName    string
+	CheckOutput func(t *testing.T, digest *models.DailyDigest, err error)
+}{
+	{
+		TestName: "digest counts activity of the day",
+		CheckOutput: func(t *testing.T, digest *models.DailyDigest, err error) {
+			require.NoError(t, err)
+			require.Equal(t, 2, digest.NewOrders)
+			require.Equal(t, 1, digest.CompletedOrders)
+			require.Equal(t, 1, digest.CancelledOrders)
+			require.Equal(t, 150.0, digest.Revenue)
+			require.Equal(t, 1, digest.OverdueOrders)
+		},
+	},
+}
+
+func TestOrderRepositoryGetOverdueOrders(t *testing.T) {
+	dbContainer, db := SetupTestDatabase()
+	defer func(dbContainer testcontainers.Container, ctx context.Context) {
+		err := dbContainer.Terminate(ctx)
+		if err != nil {
+			return
+		}
+	}(dbContainer, context.Background())
+
+	fields := postgres.PostgresConnection{DB: db}
+	orderRepository := postgres.CreateOrderRepository(&fields)
+
+	user := createUser(&fields)
+	worker := createWorker(&fields)
+	now := time.Now()
+	completedAt := now.Add(-time.Hour)
+
+	setDeadline := func(order *models.Order, deadline time.Time) {
+		_, err := db.Exec("UPDATE orders SET deadline = $1 WHERE id = $2", deadline, order.ID)
+		require.NoError(t, err)
+	}
+
+	overdueInProgress := createOrderWithStatus(&fields, user.ID, worker.ID, models.InProgressOrderStatus, 100, nil)
+	setDeadline(overdueInProgress, now.Add(-48*time.Hour))
+	overdueNew := createOrderWithStatus(&fields, user.ID, uuid.Nil, models.NewOrderStatus, 100, nil)
+	setDeadline(overdueNew, now.Add(-2*time.Hour))
+	overdueCompleted := createOrderWithStatus(&fields, user.ID, worker.ID, models.CompletedOrderStatus, 100, &completedAt)
+	setDeadline(overdueCompleted, now.Add(-72*time.Hour))
+	overdueCancelled := createOrderWithStatus(&fields, user.ID, uuid.Nil, models.CancelledOrderStatus, 100, nil)
+	setDeadline(overdueCancelled, now.Add(-72*time.Hour))
+	upcoming := createOrderWithStatus(&fields, user.ID, worker.ID, models.InProgressOrderStatus, 100, nil)
+	setDeadline(upcoming, now.Add(24*time.Hour))
+
+	orders, err := orderRepository.GetOverdueOrders(now)
+	require.NoError(t, err)
+	require.Len(t, orders, 2)
+	require.Equal(t, overdueInProgress.ID, orders[0].ID)
+	require.Equal(t, overdueNew.ID, orders[1].ID)
+
+	orders, err = orderRepository.GetOverdueOrders(now.Add(-100 * time.Hour))
+	require.NoError(t, err)
+	require.Empty(t, orders)
+}
+
+func TestOrderRepositoryGetDigestBetween(t *testing.T) {
+	dbContainer, db := SetupTestDatabase()
+	defer func(dbContainer testcontainers.Container, ctx context.Context) {
+		err := dbContainer.Terminate(ctx)
+		if err != nil {
+			return
+		}
+	}(dbContainer, context.Background())
+
+	fields := postgres.PostgresConnection{DB: db}
+	orderRepository := postgres.CreateOrderRepository(&fields)
+
+	for _, test := range testOrderRepositoryGetDigestBetween {
+		t.Run(test.TestName, func(t *testing.T) {
+			user := createUser(&fields)
+			worker := createWorker(&fields)
+
+			day := time.Date(2024, 3, 10, 0, 0, 0, 0, time.UTC)
+			during := day.Add(10 * time.Hour)
+			dayBefore := day.Add(-14 * time.Hour)
+
+			seed := func(status int, creationDate time.Time, deadline time.Time, quotedTotal float64, completedAt *time.Time, cancelledAt *time.Time) *models.Order {
+				order := createOrderWithStatus(&fields, user.ID, worker.ID, status, quotedTotal, completedAt)
+				order.CreationDate = creationDate
+				order.Deadline = deadline
+				order.CancelledAt = cancelledAt
+				_, err := orderRepository.Update(order)
+				require.NoError(t, err)
+				return order
+			}
+
+			// created during the day and not yet due
+			seed(models.NewOrderStatus, during, day.AddDate(0, 0, 5), 0, nil, nil)
+			// completed during the day
+			seed(models.CompletedOrderStatus, day.AddDate(0, 0, -3), day.AddDate(0, 0, 2), 150, &during, nil)
+			// completed the day before
+			seed(models.CompletedOrderStatus, day.AddDate(0, 0, -3), day.AddDate(0, 0, 2), 300, &dayBefore, nil)
+			// created and cancelled during the day
+			seed(models.CancelledOrderStatus, during, day.AddDate(0, 0, 5), 0, nil, &during)
+			// in progress with a passed deadline
+			seed(models.InProgressOrderStatus, day.AddDate(0, 0, -5), dayBefore, 0, nil, nil)
+			// new with a deadline after the day
+			seed(models.NewOrderStatus, day.AddDate(0, 0, -5), day.AddDate(0, 0, 1), 0, nil, nil)
+			// deleted orders are not counted
+			deletedCompleted := seed(models.CompletedOrderStatus, during, dayBefore, 500, &during, nil)
+			require.NoError(t, orderRepository.Delete(deletedCompleted.ID))
+			deletedOverdue := seed(models.InProgressOrderStatus, during, dayBefore, 0, nil, nil)
+			require.NoError(t, orderRepository.Delete(deletedOverdue.ID))
+
+			digest, err := orderRepository.GetDigestBetween(day, day.AddDate(0, 0, 1))
+			test.CheckOutput(t, digest, err)
+		})
+	}
+}
+
+var testOrderRepositoryGetAverageOrderValue = []struct {
+	TestName string
+	From     time.Time
+	To       time.Time
+	Expected float64
+}{
+	{
+		TestName: "average of completed orders in the period",
+		From:     time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		To:       time.Date(2024, 1, 31, 23, 59, 59, 0, time.UTC),
+		Expected: 150,
+	},
+	{
+		TestName: "orders without a quote are billed at their task prices",
+		From:     time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC),
+		To:       time.Date(2024, 3, 31, 23, 59, 59, 0, time.UTC),
+		Expected: 400,
+	},
+	{
+		TestName: "no completed orders in the period",
+		From:     time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+		To:       time.Date(2023, 12, 31, 23, 59, 59, 0, time.UTC),
+		Expected: 0,
+	},
+}
+
+func TestOrderRepositoryGetAverageOrderValue(t *testing.T) {
+	dbContainer, db := SetupTestDatabase()
+	defer func(dbContainer testcontainers.Container, ctx context.Context) {
+		err := dbContainer.Terminate(ctx)
+		if err != nil {
+			return
+		}
+	}(dbContainer, context.Background())
+
+	fields := postgres.PostgresConnection{DB: db}
+	orderRepository := postgres.CreateOrderRepository(&fields)
+
+	user := createUser(&fields)
+	worker := createWorker(&fields)
+	inRange := time.Date(2024, 1, 15, 12, 0, 0, 0, time.UTC)
+	outOfRange := time.Date(2024, 2, 15, 12, 0, 0, 0, time.UTC)
+
+	createOrderWithStatus(&fields, user.ID, worker.ID, models.CompletedOrderStatus, 100, &inRange)
+	createOrderWithStatus(&fields, user.ID, worker.ID, models.CompletedOrderStatus, 200, &inRange)
+	createOrderWithStatus(&fields, user.ID, worker.ID, models.CompletedOrderStatus, 1000, &outOfRange)
+	createOrderWithStatus(&fields, user.ID, worker.ID, models.InProgressOrderStatus, 5000, nil)
+	createOrderWithStatus(&fields, user.ID, worker.ID, models.CancelledOrderStatus, 5000, &inRange)
+	unquoted := time.Date(2024, 3, 15, 12, 0, 0, 0, time.UTC)
+	createOrderWithStatus(&fields, user.ID, worker.ID, models.CompletedOrderStatus, 0, &unquoted)
+
+	for _, test := range testOrderRepositoryGetAverageOrderValue {
+		t.Run(test.TestName, func(t *testing.T) {
+			average, err := orderRepository.GetAverageOrderValue(test.From, test.To)
+			require.NoError(t, err)
+			require.Equal(t, test.Expected, average)
+		})
+	}
+}
+
+var testOrderRepositoryGetRevenueSummary = []struct {
+	TestName string
+	From     time.Time
+	To       time.Time
+	Expected models.RevenueSummary
+}{
+	{
+		TestName: "completed orders in the period without the cancelled one",
+		From:     time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		To:       time.Date(2024, 1, 31, 23, 59, 59, 0, time.UTC),
+		Expected: models.RevenueSummary{Revenue: 300, OrderCount: 2, AverageOrderValue: 150},
+	},
+	{
+		TestName: "empty period",
+		From:     time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+		To:       time.Date(2023, 12, 31, 23, 59, 59, 0, time.UTC),
+		Expected: models.RevenueSummary{},
+	},
+}
+
+func TestOrderRepositoryGetRevenueSummary(t *testing.T) {
+	dbContainer, db := SetupTestDatabase()
+	defer func(dbContainer testcontainers.Container, ctx context.Context) {
+		err := dbContainer.Terminate(ctx)
+		if err != nil {
+			return
+		}
+	}(dbContainer, context.Background())
+
+	fields := postgres.PostgresConnection{DB: db}
+	orderRepository := postgres.CreateOrderRepository(&fields)
+
+	user := createUser(&fields)
+	worker := createWorker(&fields)
+	inRange := time.Date(2024, 1, 15, 12, 0, 0, 0, time.UTC)
+	outOfRange := time.Date(2024, 2, 15, 12, 0, 0, 0, time.UTC)
+
+	createOrderWithStatus(&fields, user.ID, worker.ID, models.CompletedOrderStatus, 100, &inRange)
+	createOrderWithStatus(&fields, user.ID, worker.ID, models.CompletedOrderStatus, 200, &inRange)
+	createOrderWithStatus(&fields, user.ID, worker.ID, models.CancelledOrderStatus, 5000, &inRange)
+	createOrderWithStatus(&fields, user.ID, worker.ID, models.CompletedOrderStatus, 1000, &outOfRange)
+
+	for _, test := range testOrderRepositoryGetRevenueSummary {
+		t.Run(test.TestName, func(t *testing.T) {
+			summary, err := orderRepository.GetRevenueSummary(test.From, test.To)
+			require.NoError(t, err)
+			require.Equal(t, test.Expected, *summary)
+		})
+	}
+}
+
+func TestOrderRepositoryRevenueReportsSkipDeletedOrders(t *testing.T) {
+	dbContainer, db := SetupTestDatabase()
+	defer func(dbContainer testcontainers.Container, ctx context.Context) {
+		err := dbContainer.Terminate(ctx)
+		if err != nil {
+			return
+		}
+	}(dbContainer, context.Background())
+
+	fields := postgres.PostgresConnection{DB: db}
+	orderRepository := postgres.CreateOrderRepository(&fields)
+	workerRepository := postgres.CreateWorkerRepository(&fields)
+	userRepository := postgres.CreateUserRepository(&fields)
+
+	user := createUser(&fields)
+	worker := createWorker(&fields)
+	completedAt := time.Date(2024, 1, 15, 12, 0, 0, 0, time.UTC)
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 1, 31, 23, 59, 59, 0, time.UTC)
+
+	kept := createOrderWithStatus(&fields, user.ID, worker.ID, models.CompletedOrderStatus, 100, &completedAt)
+	deleted := createOrderWithStatus(&fields, user.ID, worker.ID, models.CompletedOrderStatus, 1000, &completedAt)
+	require.NoError(t, orderRepository.Delete(deleted.ID))
+
+	summary, err := orderRepository.GetRevenueSummary(from, to)
+	require.NoError(t, err)
+	require.Equal(t, models.RevenueSummary{Revenue: 100, OrderCount: 1, AverageOrderValue: 100}, *summary)
+
+	average, err := orderRepository.GetAverageOrderValue(from, to)
+	require.NoError(t, err)
+	require.Equal(t, 100.0, average)
+
+	digest, err := orderRepository.GetDigestBetween(from, to)
+	require.NoError(t, err)
+	require.Equal(t, 1, digest.CompletedOrders)
+	require.Equal(t, 100.0, digest.Revenue)
+
+	revenue, err := workerRepository.GetRevenueBetween(worker.ID, from, to)
+	require.NoError(t, err)
+	require.Equal(t, 100.0, revenue)
+
+	orders, err := workerRepository.GetCompletedOrdersInYear(worker.ID, 2024)
+	require.NoError(t, err)
+	require.Len(t, orders, 1)
+	require.Equal(t, kept.ID, orders[0].ID)
+
+	report, err := workerRepository.GetPerformanceReport()
+	require.NoError(t, err)
+	require.Len(t, report, 1)
+	require.Equal(t, 1, report[0].CompletedOrders)
+	require.Equal(t, 100.0, report[0].Revenue)
+
+	stats, err := userRepository.GetOrderStatistics(user.ID)
+	require.NoError(t, err)
+	require.Equal(t, 1, stats.CompletedOrders)
+	require.Equal(t, 100.0, stats.TotalSpent)
+}
+
+func TestOrderRepositoryDigestRevenueWithoutQuote(t *testing.T) {
+	dbContainer, db := SetupTestDatabase()
+	defer func(dbContainer testcontainers.Container, ctx context.Context) {
+		err := dbContainer.Terminate(ctx)
+		if err != nil {
+			return
+		}
+	}(dbContainer, context.Background())
+
+	fields := postgres.PostgresConnection{DB: db}
+	orderRepository := postgres.CreateOrderRepository(&fields)
+
+	user := createUser(&fields)
+	worker := createWorker(&fields)
+	completedAt := time.Date(2024, 1, 15, 12, 0, 0, 0, time.UTC)
+	from := time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)
+
+	// createTasks attaches 2 x 100 and 1 x 200
+	createOrderWithStatus(&fields, user.ID, worker.ID, models.CompletedOrderStatus, 0, &completedAt)
+
+	digest, err := orderRepository.GetDigestBetween(from, from.AddDate(0, 0, 1))
+	require.NoError(t, err)
+	require.Equal(t, 400.0, digest.Revenue)
+
+	summary, err := orderRepository.GetRevenueSummary(from, from.AddDate(0, 0, 1))
+	require.NoError(t, err)
+	require.Equal(t, summary.Revenue, digest.Revenue)
+}
+
+var testOrderRepositoryGetOrdersByRateBelow = []struct {
+	TestName  string
+	Threshold int
+	Expected  []int
+}{
+	{
+		TestName:  "only rated orders below the threshold",
+		Threshold: 3,
+		Expected:  []int{1, 2},
+	},
+	{
+		TestName:  "no order is rated below one",
+		Threshold: 1,
+		Expected:  nil,
+	},
+}
+
+func TestOrderRepositoryGetOrdersByRateBelow(t *testing.T) {
+	dbContainer, db := SetupTestDatabase()
+	defer func(dbContainer testcontainers.Container, ctx context.Context) {
+		err := dbContainer.Terminate(ctx)
+		if err != nil {
+			return
+		}
+	}(dbContainer, context.Background())
+
+	fields := postgres.PostgresConnection{DB: db}
+	orderRepository := postgres.CreateOrderRepository(&fields)
+
+	user := createUser(&fields)
+	worker := createWorker(&fields)
+	completedAt := time.Date(2024, 1, 15, 12, 0, 0, 0, time.UTC)
+
+	for _, seed := range []struct {
+		status int
+		rate   int
+	}{
+		{models.CompletedOrderStatus, 2},
+		{models.CompletedOrderStatus, 1},
+		{models.CompletedOrderStatus, 5},
+		{models.CompletedOrderStatus, 3},
+		{models.CompletedOrderStatus, 0},
+		{models.CancelledOrderStatus, 1},
+	} {
+		order := createOrderWithStatus(&fields, user.ID, worker.ID, seed.status, 100, &completedAt)
+		order.Rate = seed.rate
+		_, err := orderRepository.Update(order)
+		require.NoError(t, err)
+	}
+
+	for _, test := range testOrderRepositoryGetOrdersByRateBelow {
+		t.Run(test.TestName, func(t *testing.T) {
+			orders, err := orderRepository.GetOrdersByRateBelow(test.Threshold)
+			require.NoError(t, err)
+
+			var rates []int
+			for _, order := range orders {
+				require.Equal(t, models.CompletedOrderStatus, order.Status)
+				rates = append(rates, order.Rate)
+			}
+			require.Equal(t, test.Expected, rates)
+		})
+	}
+}
+
+var testOrderRepositorySearchByCustomerName = []struct {
+	TestName  string
+	Substring string
+	Expected  int
+}{
+	{
+		TestName:  "match by surname ignoring case",
+		Substring: "petrov",
+		Expected:  2,
+	},
+	{
+		TestName:  "match by full name",
+		Substring: "Anna Pet",
+		Expected:  2,
+	},
+	{
+		TestName:  "no matching customer",
+		Substring: "Ivanov",
+		Expected:  0,
+	},
+	{
+		TestName:  "wildcard characters are matched literally",
+		Substring: "%",
+		Expected:  0,
+	},
+}
+
+func TestOrderRepositorySearchByCustomerName(t *testing.T) {
+	dbContainer, db := SetupTestDatabase()
+	defer func(dbContainer testcontainers.Container, ctx context.Context) {
+		err := dbContainer.Terminate(ctx)
+		if err != nil {
+			return
+		}
+	}(dbContainer, context.Background())
+
+	fields := postgres.PostgresConnection{DB: db}
+	orderRepository := postgres.CreateOrderRepository(&fields)
+
+	otherUser := createUser(&fields)
+	customer, err := postgres.CreateUserRepository(&fields).Create(&models.User{
+		Name:        "Anna",
+		Surname:     "Petrova",
+		Address:     "Address",
+		PhoneNumber: "+79999999997",
+		Email:       "anna@email.com",
+		Password:    "hashed_password",
+	})
+	require.NoError(t, err)
+
+	for _, userID := range []uuid.UUID{customer.ID, customer.ID, otherUser.ID} {
+		_, err := orderRepository.Create(&models.Order{
+			UserID:   userID,
+			Status:   models.NewOrderStatus,
+			Address:  "Address",
+			Deadline: time.Now().AddDate(0, 0, 1),
+		}, createTasks(&fields))
+		require.NoError(t, err)
+	}
+
+	for _, test := range testOrderRepositorySearchByCustomerName {
+		t.Run(test.TestName, func(t *testing.T) {
+			orders, err := orderRepository.SearchByCustomerName(test.Substring)
+			require.NoError(t, err)
+			require.Len(t, orders, test.Expected)
+			for _, order := range orders {
+				require.Equal(t, customer.ID, order.UserID)
+			}
+		})
+	}
+}
+
+var testOrderRepositorySearchByAddress = []struct {
+	TestName  string
+	Substring string
+	Expected  int
+}{
+	{
+		TestName:  "match part of the address ignoring case",
+		Substring: "lenina",
+		Expected:  2,
+	},
+	{
+		TestName:  "match house number",
+		Substring: "Lenina 5",
+		Expected:  1,
+	},
+	{
+		TestName:  "no matching address",
+		Substring: "Pushkina",
+		Expected:  0,
+	},
+	{
+		TestName:  "wildcard characters are matched literally",
+		Substring: "_",
+		Expected:  0,
+	},
+}
+
+func TestOrderRepositorySearchByAddress(t *testing.T) {
+	dbContainer, db := SetupTestDatabase()
+	defer func(dbContainer testcontainers.Container, ctx context.Context) {
+		err := dbContainer.Terminate(ctx)
+		if err != nil {
+			return
+		}
+	}(dbContainer, context.Background())
+
+	fields := postgres.PostgresConnection{DB: db}
+	orderRepository := postgres.CreateOrderRepository(&fields)
+
+	user := createUser(&fields)
+	for _, address := range []string{"Lenina 5", "Lenina 17", "Gagarina 3"} {
+		_, err := orderRepository.Create(&models.Order{
+			UserID:   user.ID,
+			Status:   models.NewOrderStatus,
+			Address:  address,
+			Deadline: time.Now().AddDate(0, 0, 1),
+		}, createTasks(&fields))
+		require.NoError(t, err)
+	}
+
+	for _, test := range testOrderRepositorySearchByAddress {
+		t.Run(test.TestName, func(t *testing.T) {
+			orders, err := orderRepository.SearchByAddress(test.Substring)
+			require.NoError(t, err)
+			require.Len(t, orders, test.Expected)
+		})
+	}
+}
+
+func TestOrderRepositoryDraft(t *testing.T) {
+	dbContainer, db := SetupTestDatabase()
+	defer func(dbContainer testcontainers.Container, ctx context.Context) {
+		err := dbContainer.Terminate(ctx)
+		if err != nil {
+			return
+		}
+	}(dbContainer, context.Background())
+
+	fields := postgres.PostgresConnection{DB: db}
+	orderRepository := postgres.CreateOrderRepository(&fields)
+
+	user := createUser(&fields)
+	tasks := createTasks(&fields)
+
+	_, err := orderRepository.GetDraft(user.ID)
+	require.ErrorIs(t, err, repository_errors.DoesNotExist)
+
+	err = orderRepository.SaveDraft(&models.DraftOrder{UserID: user.ID, Address: "First address", Tasks: tasks})
+	require.NoError(t, err)
+
+	draft, err := orderRepository.GetDraft(user.ID)
+	require.NoError(t, err)
+	require.Equal(t, "First address", draft.Address)
+	require.Len(t, draft.Tasks, 2)
+	require.Equal(t, tasks[0].Task.ID, draft.Tasks[0].Task.ID)
+	require.Equal(t, tasks[0].Quantity, draft.Tasks[0].Quantity)
+
+	err = orderRepository.SaveDraft(&models.DraftOrder{
+		UserID:  user.ID,
+		Address: "Second address",
+		Tasks:   []models.OrderedTask{{Task: tasks[1].Task, Quantity: 5}},
+	})
+	require.NoError(t, err)
+
+	draft, err = orderRepository.GetDraft(user.ID)
+	require.NoError(t, err)
+	require.Equal(t, "Second address", draft.Address)
+	require.Len(t, draft.Tasks, 1)
+	require.Equal(t, tasks[1].Task.ID, draft.Tasks[0].Task.ID)
+	require.Equal(t, 5, draft.Tasks[0].Quantity)
+
+	err = orderRepository.DeleteDraft(user.ID)
+	require.NoError(t, err)
+
+	_, err = orderRepository.GetDraft(user.ID)
+	require.ErrorIs(t, err, repository_errors.DoesNotExist)
+
+	err = orderRepository.DeleteDraft(user.ID)
+	require.NoError(t, err)
+}
+
+func TestOrderRepositoryGetDeadlineDayOfWeekCounts(t *testing.T) {
+	dbContainer, db := SetupTestDatabase()
+	defer func(dbContainer testcontainers.Container, ctx context.Context) {
+		err := dbContainer.Terminate(ctx)
+		if err != nil {
+			return
+		}
+	}(dbContainer, context.Background())
+
+	fields := postgres.PostgresConnection{DB: db}
+	orderRepository := postgres.CreateOrderRepository(&fields)
+
+	user := createUser(&fields)
+	noon := func(day int, month time.Month) time.Time {
+		return time.Date(2024, month, day, 12, 0, 0, 0, time.UTC)
+	}
+
+	for _, seed := range []struct {
+		deadline time.Time
+		status   int
+		deleted  bool
+	}{
+		{noon(1, time.January), models.NewOrderStatus, false},        // Monday
+		{noon(8, time.January), models.CompletedOrderStatus, false},  // Monday
+		{noon(3, time.January), models.InProgressOrderStatus, false}, // Wednesday
+		{noon(5, time.January), models.CancelledOrderStatus, false},  // Friday, cancelled
+		{noon(5, time.February), models.NewOrderStatus, false},       // Monday, out of range
+		{noon(4, time.January), models.NewOrderStatus, true},         // Thursday, deleted
+	} {
+		order, err := orderRepository.Create(&models.Order{
+			UserID:   user.ID,
+			Status:   models.NewOrderStatus,
+			Address:  "Address",
+			Deadline: seed.deadline,
+		}, createTasks(&fields))
+		require.NoError(t, err)
+
+		order.Status = seed.status
+		_, err = orderRepository.Update(order)
+		require.NoError(t, err)
+
+		if seed.deleted {
+			require.NoError(t, orderRepository.Delete(order.ID))
+		}
+	}
+
+	counts, err := orderRepository.GetDeadlineDayOfWeekCounts(noon(1, time.January), noon(31, time.January))
+	require.NoError(t, err)
+	require.Equal(t, map[time.Weekday]int{time.Monday: 2, time.Wednesday: 1}, counts)
+}
+
+func TestOrderRepositoryRecomputeNewOrderTotals(t *testing.T) {
+	dbContainer, db := SetupTestDatabase()
+	defer func(dbContainer testcontainers.Container, ctx context.Context) {
+		err := dbContainer.Terminate(ctx)
+		if err != nil {
+			return
+		}
+	}(dbContainer, context.Background())
+
+	fields := postgres.PostgresConnection{DB: db}
+	orderRepository := postgres.CreateOrderRepository(&fields)
+
+	user := createUser(&fields)
+	worker := createWorker(&fields)
+	completedAt := time.Now()
+
+	newOrder := createOrderWithStatus(&fields, user.ID, uuid.Nil, models.NewOrderStatus, 1, nil)
+	inProgressOrder := createOrderWithStatus(&fields, user.ID, worker.ID, models.InProgressOrderStatus, 1, nil)
+	completedOrder := createOrderWithStatus(&fields, user.ID, worker.ID, models.CompletedOrderStatus, 1, &completedAt)
+	deletedOrder := createOrderWithStatus(&fields, user.ID, uuid.Nil, models.NewOrderStatus, 1, nil)
+	require.NoError(t, orderRepository.Delete(deletedOrder.ID))
+	_, err := db.Exec(`UPDATE order_contains_tasks SET price_at_order = 1 WHERE order_id = $1;`, deletedOrder.ID)
+	require.NoError(t, err)
+
+	updated, err := orderRepository.RecomputeNewOrderTotals(models.RoundHalfUp)
+	require.NoError(t, err)
+	require.Equal(t, 1, updated)
+
+	// createTasks attaches 2 x 100 and 1 x 200
+	order, err := orderRepository.GetOrderByID(newOrder.ID)
+	require.NoError(t, err)
+	require.Equal(t, 400.0, order.QuotedTotal)
+
+	for _, id := range []uuid.UUID{inProgressOrder.ID, completedOrder.ID} {
+		order, err = orderRepository.GetOrderByID(id)
+		require.NoError(t, err)
+		require.Equal(t, 1.0, order.QuotedTotal)
+	}
+
+	t.Run("deleted orders are left alone", func(t *testing.T) {
+		var quotedTotal, pricesTotal float64
+		err := db.QueryRow(`SELECT quoted_total FROM orders WHERE id = $1;`, deletedOrder.ID).Scan(&quotedTotal)
+		require.NoError(t, err)
+		require.Equal(t, 1.0, quotedTotal)
+
+		err = db.QueryRow(`SELECT SUM(price_at_order) FROM order_contains_tasks WHERE order_id = $1;`, deletedOrder.ID).Scan(&pricesTotal)
+		require.NoError(t, err)
+		require.Equal(t, 2.0, pricesTotal)
+	})
+
+	t.Run("totals are rounded with the given mode", func(t *testing.T) {
+		_, err := db.Exec(`UPDATE tasks SET price_per_single = price_per_single + 0.0025;`)
+		require.NoError(t, err)
+
+		_, err = orderRepository.RecomputeNewOrderTotals(models.RoundUp)
+		require.NoError(t, err)
+
+		order, err := orderRepository.GetOrderByID(newOrder.ID)
+		require.NoError(t, err)
+		require.Equal(t, 400.01, order.QuotedTotal)
+	})
+}
@@ -2,9 +2,12 @@ package test_repositories
 
 import (
 	"context"
+	"fmt"
 	"teamdev/internal/models"
 	"teamdev/internal/repository/postgres"
+	"teamdev/internal/repository/repository_errors"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/require"
@@ -118,6 +121,36 @@ func TestTaskRepositoryGetByID(t *testing.T) {
 	}
 }
 
+func TestTaskRepositoryGetTasksByIDs(t *testing.T) {
+	dbContainer, db := SetupTestDatabase()
+	defer func(dbContainer testcontainers.Container, ctx context.Context) {
+		err := dbContainer.Terminate(ctx)
+		if err != nil {
+			return
+		}
+	}(dbContainer, context.Background())
+
+	fields := postgres.PostgresConnection{DB: db}
+	taskRepository := postgres.CreateTaskRepository(&fields)
+
+	first, err := taskRepository.Create(&models.Task{Name: "TaskName", PricePerSingle: 100.0, Category: 1})
+	require.NoError(t, err)
+	second, err := taskRepository.Create(&models.Task{Name: "TaskName 2", PricePerSingle: 200.0, Category: 2})
+	require.NoError(t, err)
+	missingID := uuid.New()
+
+	tasks, err := taskRepository.GetTasksByIDs([]uuid.UUID{first.ID, missingID, second.ID})
+	require.NoError(t, err)
+	require.Len(t, tasks, 2)
+	require.Equal(t, first.Name, tasks[first.ID].Name)
+	require.Equal(t, second.PricePerSingle, tasks[second.ID].PricePerSingle)
+	require.NotContains(t, tasks, missingID)
+
+	tasks, err = taskRepository.GetTasksByIDs(nil)
+	require.NoError(t, err)
+	require.Empty(t, tasks)
+}
+
 var testTaskRepositoryDeleteSuccess = []struct {
 	TestName    string
 	CheckOutput func(t *testing.T, err error)
@@ -404,3 +437,600 @@ func TestTaskRepositoryGetAllTasksByCategory(t *testing.T) {
 		test.CheckOutput(t, createdTasks, receivedTasks, err)
 	}
 }
+
+var testTaskRepositoryGetUnorderedTasks = []struct {
+	TestName    string
+	CheckOutput func(t *testing.T, unorderedTasks []models.Task, receivedTasks []models.Task, err error)
+}{
+	{
+		TestName: "get unordered tasks success test",
+		CheckOutput: func(t *testing.T, unorderedTasks []models.Task, receivedTasks []models.Task, err error) {
+			require.NoError(t, err)
+			require.Equal(t, len(unorderedTasks), len(receivedTasks))
+			for i := range unorderedTasks {
+				require.Contains(t, receivedTasks, unorderedTasks[i])
+			}
+		},
+	},
+}
+
+func TestTaskRepositoryGetUnorderedTasks(t *testing.T) {
+	dbContainer, db := SetupTestDatabase()
+	defer func(dbContainer testcontainers.Container, ctx context.Context) {
+		err := dbContainer.Terminate(ctx)
+		if err != nil {
+			return
+		}
+	}(dbContainer, context.Background())
+
+	fields := postgres.PostgresConnection{DB: db}
+	taskRepository := postgres.CreateTaskRepository(&fields)
+	orderRepository := postgres.CreateOrderRepository(&fields)
+
+	// Clear tasks table before test
+	_, err := db.Exec("TRUNCATE tasks CASCADE")
+	require.NoError(t, err)
+
+	for _, test := range testTaskRepositoryGetUnorderedTasks {
+		t.Run(test.TestName, func(t *testing.T) {
+			orderedTasks := createTasks(&fields)
+
+			unorderedTasks := make([]models.Task, 0)
+			for i := 0; i < 2; i++ {
+				task, err := taskRepository.Create(&models.Task{
+					Name:           fmt.Sprintf("Unordered %d", i+1),
+					PricePerSingle: 100.0,
+					Category:       1,
+				})
+				require.NoError(t, err)
+				unorderedTasks = append(unorderedTasks, *task)
+			}
+
+			user := createUser(&fields)
+			_, err = orderRepository.Create(&models.Order{
+				UserID:   user.ID,
+				Status:   models.NewOrderStatus,
+				Address:  "Address",
+				Deadline: time.Now().AddDate(0, 0, 1),
+			}, orderedTasks)
+			require.NoError(t, err)
+
+			receivedTasks, err := taskRepository.GetUnorderedTasks()
+			test.CheckOutput(t, unorderedTasks, receivedTasks, err)
+		})
+	}
+}
+
+func TestTaskRepositoryGetAllTasksPaged(t *testing.T) {
+	dbContainer, db := SetupTestDatabase()
+	defer func(dbContainer testcontainers.Container, ctx context.Context) {
+		err := dbContainer.Terminate(ctx)
+		if err != nil {
+			return
+		}
+	}(dbContainer, context.Background())
+
+	fields := postgres.PostgresConnection{DB: db}
+	taskRepository := postgres.CreateTaskRepository(&fields)
+
+	_, err := db.Exec("TRUNCATE tasks CASCADE")
+	require.NoError(t, err)
+
+	prices := []float64{300, 900, 100, 500}
+	for i, price := range prices {
+		_, err := taskRepository.Create(&models.Task{Name: fmt.Sprintf("Task %d", i+1), PricePerSingle: price, Category: i%2 + 1})
+		require.NoError(t, err)
+	}
+	_, err = db.Exec("UPDATE tasks SET archived = true WHERE name = 'Task 2'")
+	require.NoError(t, err)
+
+	t.Run("sort by price descending", func(t *testing.T) {
+		tasks, total, err := taskRepository.GetAllTasksPaged(2, 0, models.TaskSortByPrice, false)
+		require.NoError(t, err)
+		require.Equal(t, 3, total)
+		require.Len(t, tasks, 2)
+		require.Equal(t, 500.0, tasks[0].PricePerSingle)
+		require.Equal(t, 300.0, tasks[1].PricePerSingle)
+
+		tasks, _, err = taskRepository.GetAllTasksPaged(2, 2, models.TaskSortByPrice, false)
+		require.NoError(t, err)
+		require.Len(t, tasks, 1)
+		require.Equal(t, 100.0, tasks[0].PricePerSingle)
+	})
+
+	t.Run("sort by name ascending", func(t *testing.T) {
+		tasks, _, err := taskRepository.GetAllTasksPaged(10, 0, models.TaskSortByName, true)
+		require.NoError(t, err)
+		require.Len(t, tasks, 3)
+		require.Equal(t, []string{"Task 1", "Task 3", "Task 4"}, []string{tasks[0].Name, tasks[1].Name, tasks[2].Name})
+	})
+
+	t.Run("invalid sort column", func(t *testing.T) {
+		tasks, total, err := taskRepository.GetAllTasksPaged(10, 0, "name; DROP TABLE tasks; --", true)
+		require.Equal(t, repository_errors.SelectError, err)
+		require.Nil(t, tasks)
+		require.Zero(t, total)
+
+		_, total, err = taskRepository.GetAllTasksPaged(10, 0, models.TaskSortByName, true)
+		require.NoError(t, err)
+		require.Equal(t, 3, total)
+	})
+}
+
+func TestTaskRepositoryAdjustCategoryPrices(t *testing.T) {
+	dbContainer, db := SetupTestDatabase()
+	defer func(dbContainer testcontainers.Container, ctx context.Context) {
+		err := dbContainer.Terminate(ctx)
+		if err != nil {
+			return
+		}
+	}(dbContainer, context.Background())
+
+	fields := postgres.PostgresConnection{DB: db}
+	taskRepository := postgres.CreateTaskRepository(&fields)
+
+	_, err := db.Exec("TRUNCATE tasks CASCADE")
+	require.NoError(t, err)
+
+	first, err := taskRepository.Create(&models.Task{Name: "Windows", PricePerSingle: 300, Category: 3})
+	require.NoError(t, err)
+	second, err := taskRepository.Create(&models.Task{Name: "Balcony windows", PricePerSingle: 455.5, Category: 3})
+	require.NoError(t, err)
+	other, err := taskRepository.Create(&models.Task{Name: "Carpets", PricePerSingle: 200, Category: 6})
+	require.NoError(t, err)
+
+	adjusted, err := taskRepository.AdjustCategoryPrices(3, 1.1, models.RoundHalfUp)
+	require.NoError(t, err)
+	require.Equal(t, 2, adjusted)
+
+	for id, expected := range map[uuid.UUID]float64{first.ID: 330, second.ID: 501.05, other.ID: 200} {
+		task, err := taskRepository.GetTaskByID(id)
+		require.NoError(t, err)
+		require.InDelta(t, expected, task.PricePerSingle, 1e-9)
+	}
+
+	// 501.05 * 1.001 = 501.55105 is rounded with the given mode
+	adjusted, err = taskRepository.AdjustCategoryPrices(3, 1.001, models.RoundUp)
+	require.NoError(t, err)
+	require.Equal(t, 2, adjusted)
+
+	for id, expected := range map[uuid.UUID]float64{first.ID: 330.33, second.ID: 501.56, other.ID: 200} {
+		task, err := taskRepository.GetTaskByID(id)
+		require.NoError(t, err)
+		require.InDelta(t, expected, task.PricePerSingle, 1e-9)
+	}
+
+	adjusted, err = taskRepository.AdjustCategoryPrices(8, 1.1, models.RoundHalfUp)
+	require.NoError(t, err)
+	require.Equal(t, 0, adjusted)
+}
+
+func TestTaskRepositoryGetMostOrderedTasks(t *testing.T) {
+	dbContainer, db := SetupTestDatabase()
+	defer func(dbContainer testcontainers.Container, ctx context.Context) {
+		err := dbContainer.Terminate(ctx)
+		if err != nil {
+			return
+		}
+	}(dbContainer, context.Background())
+
+	fields := postgres.PostgresConnection{DB: db}
+	taskRepository := postgres.CreateTaskRepository(&fields)
+	orderRepository := postgres.CreateOrderRepository(&fields)
+
+	_, err := db.Exec("TRUNCATE tasks CASCADE")
+	require.NoError(t, err)
+
+	tasks := make([]*models.Task, 0, 4)
+	for i := 0; i < 4; i++ {
+		task, err := taskRepository.Create(&models.Task{Name: fmt.Sprintf("Task %d", i+1), PricePerSingle: 100, Category: 1})
+		require.NoError(t, err)
+		tasks = append(tasks, task)
+	}
+
+	user := createUser(&fields)
+	placeOrder := func(status int, orderedTasks ...models.OrderedTask) {
+		order, err := orderRepository.Create(&models.Order{
+			UserID:   user.ID,
+			Status:   models.NewOrderStatus,
+			Address:  "Address",
+			Deadline: time.Now().AddDate(0, 0, 1),
+		}, orderedTasks)
+		require.NoError(t, err)
+
+		order.Status = status
+		_, err = orderRepository.Update(order)
+		require.NoError(t, err)
+	}
+
+	placeOrder(models.NewOrderStatus, models.OrderedTask{Task: tasks[0], Quantity: 1}, models.OrderedTask{Task: tasks[1], Quantity: 3})
+	placeOrder(models.CompletedOrderStatus, models.OrderedTask{Task: tasks[0], Quantity: 1}, models.OrderedTask{Task: tasks[2], Quantity: 4})
+	placeOrder(models.InProgressOrderStatus, models.OrderedTask{Task: tasks[0], Quantity: 1})
+	// cancelled orders are not counted
+	placeOrder(models.CancelledOrderStatus, models.OrderedTask{Task: tasks[3], Quantity: 10})
+
+	popularity, err := taskRepository.GetMostOrderedTasks(10, time.Time{}, time.Time{})
+	require.NoError(t, err)
+	require.Len(t, popularity, 3)
+	require.Equal(t, tasks[2].ID, popularity[0].Task.ID)
+	require.Equal(t, 4, popularity[0].TotalQuantity)
+	require.Equal(t, 1, popularity[0].OrderCount)
+	// equal quantities are ranked by the number of orders
+	require.Equal(t, tasks[0].ID, popularity[1].Task.ID)
+	require.Equal(t, 3, popularity[1].TotalQuantity)
+	require.Equal(t, 3, popularity[1].OrderCount)
+	require.Equal(t, tasks[1].ID, popularity[2].Task.ID)
+	require.Equal(t, tasks[1].Name, popularity[2].Task.Name)
+
+	popularity, err = taskRepository.GetMostOrderedTasks(2, time.Time{}, time.Time{})
+	require.NoError(t, err)
+	require.Len(t, popularity, 2)
+	require.Equal(t, tasks[2].ID, popularity[0].Task.ID)
+	require.Equal(t, tasks[0].ID, popularity[1].Task.ID)
+
+	popularity, err = taskRepository.GetMostOrderedTasks(10, time.Now().Add(time.Hour), time.Time{})
+	require.NoError(t, err)
+	require.Empty(t, popularity)
+}
+
+var testTaskRepositoryArchiveCategory = []struct {
+	TestName  string
+	Category  int
+	Force     bool
+	Expected  int
+	Err       error
+	Remaining int
+}{
+	{
+		TestName:  "tasks of open orders are not archived",
+		Category:  3,
+		Force:     false,
+		Expected:  0,
+		Err:       repository_errors.ReferencedByOpenOrders,
+		Remaining: 2,
+	},
+	{
+		TestName:  "forced archive of tasks of open orders",
+		Category:  3,
+		Force:     true,
+		Expected:  2,
+		Remaining: 0,
+	},
+	{
+		TestName:  "clean archive",
+		Category:  4,
+		Force:     false,
+		Expected:  1,
+		Remaining: 0,
+	},
+}
+
+func TestTaskRepositoryArchiveCategory(t *testing.T) {
+	dbContainer, db := SetupTestDatabase()
+	defer func(dbContainer testcontainers.Container, ctx context.Context) {
+		err := dbContainer.Terminate(ctx)
+		if err != nil {
+			return
+		}
+	}(dbContainer, context.Background())
+
+	fields := postgres.PostgresConnection{DB: db}
+	taskRepository := postgres.CreateTaskRepository(&fields)
+	orderRepository := postgres.CreateOrderRepository(&fields)
+	user := createUser(&fields)
+
+	createTask := func(name string, category int) *models.Task {
+		task, err := taskRepository.Create(&models.Task{Name: name, PricePerSingle: 100, Category: category})
+		require.NoError(t, err)
+		return task
+	}
+	createOrder := func(status int, task *models.Task) {
+		order, err := orderRepository.Create(&models.Order{
+			UserID:   user.ID,
+			Status:   models.NewOrderStatus,
+			Address:  "Address",
+			Deadline: time.Now().AddDate(0, 0, 1),
+		}, []models.OrderedTask{{Task: task, Quantity: 1}})
+		require.NoError(t, err)
+		order.Status = status
+		_, err = orderRepository.Update(order)
+		require.NoError(t, err)
+	}
+
+	// category 3 has a task in an open order, category 4 only in a completed one
+	createOrder(models.InProgressOrderStatus, createTask("Open Task", 3))
+	createTask("Unordered Task", 3)
+	createOrder(models.CompletedOrderStatus, createTask("Completed Task", 4))
+
+	for _, test := range testTaskRepositoryArchiveCategory {
+		t.Run(test.TestName, func(t *testing.T) {
+			archived, err := taskRepository.ArchiveCategory(test.Category, test.Force)
+			if test.Err != nil {
+				require.ErrorIs(t, err, test.Err)
+			} else {
+				require.NoError(t, err)
+			}
+			require.Equal(t, test.Expected, archived)
+
+			remaining, err := taskRepository.GetTasksInCategory(test.Category)
+			require.NoError(t, err)
+			require.Len(t, remaining, test.Remaining)
+		})
+	}
+}
+
+func TestTaskRepositoryGetAllTasksWithCategoryNames(t *testing.T) {
+	dbContainer, db := SetupTestDatabase()
+	defer func(dbContainer testcontainers.Container, ctx context.Context) {
+		err := dbContainer.Terminate(ctx)
+		if err != nil {
+			return
+		}
+	}(dbContainer, context.Background())
+
+	fields := postgres.PostgresConnection{DB: db}
+	taskRepository := postgres.CreateTaskRepository(&fields)
+
+	_, err := db.Exec("TRUNCATE tasks CASCADE")
+	require.NoError(t, err)
+
+	windows, err := postgres.CreateCategoryRepository(&fields).Create(&models.Category{Name: "Окна"})
+	require.NoError(t, err)
+	removed, err := postgres.CreateCategoryRepository(&fields).Create(&models.Category{Name: "Удаленная"})
+	require.NoError(t, err)
+
+	windowsTask, err := taskRepository.Create(&models.Task{Name: "Мытье стекол", PricePerSingle: 100, Category: windows.ID})
+	require.NoError(t, err)
+	orphanTask, err := taskRepository.Create(&models.Task{Name: "Сиротская услуга", PricePerSingle: 200, Category: removed.ID})
+	require.NoError(t, err)
+
+	err = postgres.CreateCategoryRepository(&fields).Delete(removed.ID)
+	require.NoError(t, err)
+
+	tasks, err := taskRepository.GetAllTasksWithCategoryNames()
+	require.NoError(t, err)
+	require.Len(t, tasks, 2)
+
+	names := make(map[uuid.UUID]string)
+	for _, task := range tasks {
+		names[task.Task.ID] = task.CategoryName
+	}
+	require.Equal(t, "Окна", names[windowsTask.ID])
+	require.Equal(t, models.UnknownCategoryName, names[orphanTask.ID])
+}
+
+func TestTaskRepositoryUpsertByName(t *testing.T) {
+	dbContainer, db := SetupTestDatabase()
+	defer func(dbContainer testcontainers.Container, ctx context.Context) {
+		err := dbContainer.Terminate(ctx)
+		if err != nil {
+			return
+		}
+	}(dbContainer, context.Background())
+
+	fields := postgres.PostgresConnection{DB: db}
+	taskRepository := postgres.CreateTaskRepository(&fields)
+
+	existing, err := taskRepository.Create(&models.Task{Name: "Window Cleaning", PricePerSingle: 100, Category: 1})
+	require.NoError(t, err)
+
+	created, updated, err := taskRepository.UpsertByName([]models.Task{
+		{Name: "window cleaning", PricePerSingle: 150, Category: 2},
+		{Name: "Oven Cleaning", PricePerSingle: 200, Category: 1},
+		{Name: "Carpet Cleaning", PricePerSingle: 300, Category: 3},
+	})
+	require.NoError(t, err)
+	require.Equal(t, 2, created)
+	require.Equal(t, 1, updated)
+
+	task, err := taskRepository.GetTaskByID(existing.ID)
+	require.NoError(t, err)
+	require.Equal(t, "Window Cleaning", task.Name)
+	require.Equal(t, 150.0, task.PricePerSingle)
+	require.Equal(t, 2, task.Category)
+
+	_, err = taskRepository.GetTaskByName("Oven Cleaning")
+	require.NoError(t, err)
+
+	created, updated, err = taskRepository.UpsertByName([]models.Task{
+		{Name: "OVEN CLEANING", PricePerSingle: 250, Category: 1},
+	})
+	require.NoError(t, err)
+	require.Equal(t, 0, created)
+	require.Equal(t, 1, updated)
+}
+
+func TestTaskRepositoryInsertMissingByName(t *testing.T) {
+	dbContainer, db := SetupTestDatabase()
+	defer func(dbContainer testcontainers.Container, ctx context.Context) {
+		err := dbContainer.Terminate(ctx)
+		if err != nil {
+			return
+		}
+	}(dbContainer, context.Background())
+
+	fields := postgres.PostgresConnection{DB: db}
+	taskRepository := postgres.CreateTaskRepository(&fields)
+
+	existing, err := taskRepository.Create(&models.Task{Name: "Window Cleaning", PricePerSingle: 100, Category: 1})
+	require.NoError(t, err)
+
+	created, skipped, err := taskRepository.InsertMissingByName([]models.Task{
+		{Name: "WINDOW CLEANING", PricePerSingle: 150, Category: 2},
+		{Name: "Oven Cleaning", PricePerSingle: 200, Category: 1},
+	})
+	require.NoError(t, err)
+	require.Equal(t, 1, created)
+	require.Equal(t, 1, skipped)
+
+	task, err := taskRepository.GetTaskByID(existing.ID)
+	require.NoError(t, err)
+	require.Equal(t, 100.0, task.PricePerSingle)
+	require.Equal(t, 1, task.Category)
+
+	_, err = taskRepository.GetTaskByName("Oven Cleaning")
+	require.NoError(t, err)
+}
+
+var testTaskRepositorySearchTasksByName = []struct {
+	TestName string
+	Query    string
+	Expected []string
+}{
+	{
+		TestName: "partial match ignores case",
+		Query:    "window",
+		Expected: []string{"Cleaning Window Frames", "Window Cleaning"},
+	},
+	{
+		TestName: "wildcards are matched literally",
+		Query:    "100%",
+		Expected: []string{"Oven Cleaning 100%"},
+	},
+	{
+		TestName: "no match",
+		Query:    "garage",
+		Expected: nil,
+	},
+}
+
+func TestTaskRepositorySearchTasksByName(t *testing.T) {
+	dbContainer, db := SetupTestDatabase()
+	defer func(dbContainer testcontainers.Container, ctx context.Context) {
+		err := dbContainer.Terminate(ctx)
+		if err != nil {
+			return
+		}
+	}(dbContainer, context.Background())
+
+	fields := postgres.PostgresConnection{DB: db}
+	taskRepository := postgres.CreateTaskRepository(&fields)
+
+	for _, name := range []string{"Window Cleaning", "Oven Cleaning 100%", "Cleaning Window Frames", "Carpet Cleaning"} {
+		_, err := taskRepository.Create(&models.Task{Name: name, PricePerSingle: 100, Category: 1})
+		require.NoError(t, err)
+	}
+
+	t.Run("empty query returns all tasks", func(t *testing.T) {
+		allTasks, err := taskRepository.GetAllTasks()
+		require.NoError(t, err)
+
+		tasks, err := taskRepository.SearchTasksByName("")
+		require.NoError(t, err)
+		require.Len(t, tasks, len(allTasks))
+	})
+
+	for _, test := range testTaskRepositorySearchTasksByName {
+		t.Run(test.TestName, func(t *testing.T) {
+			tasks, err := taskRepository.SearchTasksByName(test.Query)
+			require.NoError(t, err)
+
+			var names []string
+			for _, task := range tasks {
+				names = append(names, task.Name)
+			}
+			require.Equal(t, test.Expected, names)
+		})
+	}
+}
+
+func TestTaskRepositoryGetTasksModifiedSince(t *testing.T) {
+	dbContainer, db := SetupTestDatabase()
+	defer func(dbContainer testcontainers.Container, ctx context.Context) {
+		err := dbContainer.Terminate(ctx)
+		if err != nil {
+			return
+		}
+	}(dbContainer, context.Background())
+
+	fields := postgres.PostgresConnection{DB: db}
+	taskRepository := postgres.CreateTaskRepository(&fields)
+
+	unchanged, err := taskRepository.Create(&models.Task{Name: "Unchanged Task", PricePerSingle: 100, Category: 1})
+	require.NoError(t, err)
+	changed, err := taskRepository.Create(&models.Task{Name: "Changed Task", PricePerSingle: 100, Category: 1})
+	require.NoError(t, err)
+
+	require.False(t, changed.UpdatedAt.Before(unchanged.UpdatedAt))
+
+	since := changed.UpdatedAt.Add(time.Millisecond)
+	time.Sleep(10 * time.Millisecond)
+
+	changed.PricePerSingle = 150
+	_, err = taskRepository.Update(changed)
+	require.NoError(t, err)
+	created, err := taskRepository.Create(&models.Task{Name: "New Task", PricePerSingle: 200, Category: 2})
+	require.NoError(t, err)
+
+	tasks, err := taskRepository.GetTasksModifiedSince(since)
+	require.NoError(t, err)
+	require.Len(t, tasks, 2)
+	require.Equal(t, changed.ID, tasks[0].ID)
+	require.Equal(t, 150.0, tasks[0].PricePerSingle)
+	require.Equal(t, created.ID, tasks[1].ID)
+	require.False(t, tasks[0].UpdatedAt.Before(since))
+}
+
+func TestTaskRepositoryPriceTiers(t *testing.T) {
+	dbContainer, db := SetupTestDatabase()
+	defer func(dbContainer testcontainers.Container, ctx context.Context) {
+		err := dbContainer.Terminate(ctx)
+		if err != nil {
+			return
+		}
+	}(dbContainer, context.Background())
+
+	fields := postgres.PostgresConnection{DB: db}
+	taskRepository := postgres.CreateTaskRepository(&fields)
+
+	task, err := taskRepository.Create(&models.Task{Name: "Tiered Task", PricePerSingle: 100, Category: 1})
+	require.NoError(t, err)
+
+	t.Run("tiers are replaced", func(t *testing.T) {
+		require.NoError(t, taskRepository.SetPriceTiers(task.ID, []models.PriceTier{{MinQuantity: 10, Price: 70}, {MinQuantity: 5, Price: 80}}))
+		require.NoError(t, taskRepository.SetPriceTiers(task.ID, []models.PriceTier{{MinQuantity: 20, Price: 60}, {MinQuantity: 5, Price: 85}}))
+
+		tiers, err := taskRepository.GetPriceTiers(task.ID)
+		require.NoError(t, err)
+		require.Equal(t, []models.PriceTier{{MinQuantity: 5, Price: 85}, {MinQuantity: 20, Price: 60}}, tiers)
+	})
+
+	t.Run("tiers of several tasks are loaded together", func(t *testing.T) {
+		other, err := taskRepository.Create(&models.Task{Name: "Plain Task", PricePerSingle: 50, Category: 1})
+		require.NoError(t, err)
+
+		tiers, err := taskRepository.GetPriceTiersByTaskIDs([]uuid.UUID{task.ID, other.ID})
+		require.NoError(t, err)
+		require.Equal(t, map[uuid.UUID][]models.PriceTier{
+			task.ID: {{MinQuantity: 5, Price: 85}, {MinQuantity: 20, Price: 60}},
+		}, tiers)
+	})
+
+	t.Run("changing tiers bumps the task", func(t *testing.T) {
+		before, err := taskRepository.GetTaskByID(task.ID)
+		require.NoError(t, err)
+
+		require.NoError(t, taskRepository.SetPriceTiers(task.ID, []models.PriceTier{{MinQuantity: 5, Price: 85}, {MinQuantity: 20, Price: 60}}))
+
+		after, err := taskRepository.GetTaskByID(task.ID)
+		require.NoError(t, err)
+		require.True(t, after.UpdatedAt.After(before.UpdatedAt))
+	})
+
+	t.Run("duplicate minimum quantity changes nothing", func(t *testing.T) {
+		err := taskRepository.SetPriceTiers(task.ID, []models.PriceTier{{MinQuantity: 3, Price: 90}, {MinQuantity: 3, Price: 95}})
+		require.Error(t, err)
+
+		tiers, err := taskRepository.GetPriceTiers(task.ID)
+		require.NoError(t, err)
+		require.Len(t, tiers, 2)
+	})
+
+	t.Run("tiers are removed", func(t *testing.T) {
+		require.NoError(t, taskRepository.SetPriceTiers(task.ID, nil))
+
+		tiers, err := taskRepository.GetPriceTiers(task.ID)
+		require.NoError(t, err)
+		require.Empty(t, tiers)
+	})
+}
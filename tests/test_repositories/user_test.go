@@ -5,7 +5,9 @@ import (
 	"fmt"
 	"teamdev/internal/models"
 	"teamdev/internal/repository/postgres"
+	"teamdev/internal/repository/repository_errors"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/require"
@@ -66,6 +68,34 @@ func TestUserRepositoryCreate(t *testing.T) {
 	}
 }
 
+func TestUserRepositoryCreateDuplicateEmail(t *testing.T) {
+	dbContainer, db := SetupTestDatabase()
+	defer func(dbContainer testcontainers.Container, ctx context.Context) {
+		err := dbContainer.Terminate(ctx)
+		if err != nil {
+			return
+		}
+	}(dbContainer, context.Background())
+
+	fields := postgres.PostgresConnection{DB: db}
+	userRepository := postgres.CreateUserRepository(&fields)
+
+	user := &models.User{
+		Name:        "First Name",
+		Surname:     "Last Name",
+		Address:     "Address",
+		PhoneNumber: "+79999999999",
+		Email:       "duplicate@email.com",
+		Password:    "hashed_password",
+	}
+
+	_, err := userRepository.Create(user)
+	require.NoError(t, err)
+
+	_, err = userRepository.Create(user)
+	require.Equal(t, repository_errors.AlreadyExists, err)
+}
+
 var testUserRepositoryGetByIDSuccess = []struct {
 	TestName    string
 	CheckOutput func(t *testing.T, createdUser *models.User, receivedUser *models.User, err error)
@@ -285,6 +315,10 @@ var testUserRepositoryGetAllSuccess = []struct {
 		CheckOutput: func(t *testing.T, createdUsers []models.User, receivedUsers []models.User, err error) {
 			require.NoError(t, err)
 			require.Equal(t, len(createdUsers), len(receivedUsers))
+			for _, user := range receivedUsers {
+				require.NotEqual(t, uuid.Nil, user.ID)
+				require.Empty(t, user.Password)
+			}
 		},
 	},
 }
@@ -321,3 +355,141 @@ func TestUserRepositoryGetAll(t *testing.T) {
 		})
 	}
 }
+
+var testUserRepositoryGetCustomersWithCompletedOrdersSuccess = []struct {
+	TestName    string
+	CheckOutput func(t *testing.T, expectedUser *models.User, receivedUsers []models.User, err error)
+}{
+	{
+		TestName: "get customers with completed orders success test",
+		CheckOutput: func(t *testing.T, expectedUser *models.User, receivedUsers []models.User, err error) {
+			require.NoError(t, err)
+			require.Len(t, receivedUsers, 1)
+			require.Equal(t, expectedUser.ID, receivedUsers[0].ID)
+			require.Empty(t, receivedUsers[0].Password)
+		},
+	},
+}
+
+func TestUserRepositoryGetCustomersWithCompletedOrders(t *testing.T) {
+	dbContainer, db := SetupTestDatabase()
+	defer func(dbContainer testcontainers.Container, ctx context.Context) {
+		err := dbContainer.Terminate(ctx)
+		if err != nil {
+			return
+		}
+	}(dbContainer, context.Background())
+
+	fields := postgres.PostgresConnection{DB: db}
+	userRepository := postgres.CreateUserRepository(&fields)
+	orderRepository := postgres.CreateOrderRepository(&fields)
+
+	for _, test := range testUserRepositoryGetCustomersWithCompletedOrdersSuccess {
+		t.Run(test.TestName, func(t *testing.T) {
+			tasks := createTasks(&fields)
+			statuses := []int{models.CompletedOrderStatus, models.NewOrderStatus, models.CancelledOrderStatus}
+
+			createdUsers := make([]*models.User, 0)
+			for i, status := range statuses {
+				user, err := userRepository.Create(&models.User{
+					Name:        fmt.Sprintf("First Name %d", i+1),
+					Surname:     fmt.Sprintf("Last Name %d", i+1),
+					Address:     fmt.Sprintf("Address   %d", i+1),
+					PhoneNumber: fmt.Sprintf("+7999999999%d", i),
+					Email:       fmt.Sprintf("customer%d@email.com", i),
+					Password:    "hashed_password",
+				})
+				require.NoError(t, err)
+				createdUsers = append(createdUsers, user)
+
+				_, err = orderRepository.Create(&models.Order{
+					UserID:   user.ID,
+					Status:   status,
+					Address:  "Address",
+					Deadline: time.Now().AddDate(0, 0, 1),
+				}, tasks)
+				require.NoError(t, err)
+			}
+
+			receivedUsers, err := userRepository.GetCustomersWithCompletedOrders()
+			test.CheckOutput(t, createdUsers[0], receivedUsers, err)
+		})
+	}
+}
+
+func TestUserRepositoryGetOrderStatistics(t *testing.T) {
+	dbContainer, db := SetupTestDatabase()
+	defer func(dbContainer testcontainers.Container, ctx context.Context) {
+		err := dbContainer.Terminate(ctx)
+		if err != nil {
+			return
+		}
+	}(dbContainer, context.Background())
+
+	fields := postgres.PostgresConnection{DB: db}
+	userRepository := postgres.CreateUserRepository(&fields)
+	orderRepository := postgres.CreateOrderRepository(&fields)
+
+	user := createUser(&fields)
+	worker := createWorker(&fields)
+	completedAt := time.Now()
+
+	// createTasks attaches 2 x 100 and 1 x 200, so an order without a quoted total costs 400
+	quoted := createOrderWithStatus(&fields, user.ID, worker.ID, models.CompletedOrderStatus, 300, &completedAt)
+	quoted.Rate = 5
+	_, err := orderRepository.Update(quoted)
+	require.NoError(t, err)
+	unquoted := createOrderWithStatus(&fields, user.ID, worker.ID, models.CompletedOrderStatus, 0, &completedAt)
+	unquoted.Rate = 2
+	_, err = orderRepository.Update(unquoted)
+	require.NoError(t, err)
+	createOrderWithStatus(&fields, user.ID, worker.ID, models.CancelledOrderStatus, 500, nil)
+	createOrderWithStatus(&fields, user.ID, uuid.Nil, models.NewOrderStatus, 500, nil)
+	deleted := createOrderWithStatus(&fields, user.ID, worker.ID, models.CompletedOrderStatus, 1000, &completedAt)
+	require.NoError(t, orderRepository.Delete(deleted.ID))
+
+	stats, err := userRepository.GetOrderStatistics(user.ID)
+	require.NoError(t, err)
+	require.Equal(t, 4, stats.TotalOrders)
+	require.Equal(t, 2, stats.CompletedOrders)
+	require.Equal(t, 1, stats.CancelledOrders)
+	require.Equal(t, 700.0, stats.TotalSpent)
+	require.Equal(t, 3.5, stats.AverageRating)
+
+	newUser, err := userRepository.Create(&models.User{
+		Name:        "New",
+		Surname:     "Customer",
+		Address:     "Address",
+		PhoneNumber: "+79990000000",
+		Email:       "new.customer@email.com",
+		Password:    "hashed_password",
+	})
+	require.NoError(t, err)
+
+	stats, err = userRepository.GetOrderStatistics(newUser.ID)
+	require.NoError(t, err)
+	require.Equal(t, models.UserStats{}, *stats)
+}
+
+func TestUserRepositoryUpdatePassword(t *testing.T) {
+	dbContainer, db := SetupTestDatabase()
+	defer func(dbContainer testcontainers.Container, ctx context.Context) {
+		err := dbContainer.Terminate(ctx)
+		if err != nil {
+			return
+		}
+	}(dbContainer, context.Background())
+
+	fields := postgres.PostgresConnection{DB: db}
+	userRepository := postgres.CreateUserRepository(&fields)
+
+	user := createUser(&fields)
+	err := userRepository.UpdatePassword(user.ID, "new_hash")
+	require.NoError(t, err)
+
+	updated, err := userRepository.GetUserByID(user.ID)
+	require.NoError(t, err)
+	require.Equal(t, "new_hash", updated.Password)
+	require.Equal(t, user.Email, updated.Email)
+	require.Equal(t, user.Name, updated.Name)
+}
@@ -5,7 +5,9 @@ import (
 	"fmt"
 	"teamdev/internal/models"
 	"teamdev/internal/repository/postgres"
+	"teamdev/internal/repository/repository_errors"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/require"
@@ -68,6 +70,35 @@ func TestWorkerRepositoryCreate(t *testing.T) {
 	}
 }
 
+func TestWorkerRepositoryCreateDuplicateEmail(t *testing.T) {
+	dbContainer, db := SetupTestDatabase()
+	defer func(dbContainer testcontainers.Container, ctx context.Context) {
+		err := dbContainer.Terminate(ctx)
+		if err != nil {
+			return
+		}
+	}(dbContainer, context.Background())
+
+	fields := postgres.PostgresConnection{DB: db}
+	workerRepository := postgres.CreateWorkerRepository(&fields)
+
+	worker := &models.Worker{
+		Name:        "First Name",
+		Surname:     "Last Name",
+		Address:     "Address",
+		PhoneNumber: "+79999999999",
+		Email:       "duplicate@email.com",
+		Password:    "hashed_password",
+		Role:        models.MasterRole,
+	}
+
+	_, err := workerRepository.Create(worker)
+	require.NoError(t, err)
+
+	_, err = workerRepository.Create(worker)
+	require.Equal(t, repository_errors.AlreadyExists, err)
+}
+
 var testWorkerRepositoryGetByIDSuccess = []struct {
 	TestName    string
 	CheckOutput func(t *testing.T, createdWorker *models.Worker, receivedWorker *models.Worker, err error)
@@ -347,3 +378,656 @@ func TestWorkerRepositoryGetAll(t *testing.T) {
 		})
 	}
 }
+
+var testWorkerRepositoryGetRevenueBetween = []struct {
+	TestName    string
+	CheckOutput func(t *testing.T, revenue float64, err error)
+}{
+	{
+		TestName: "revenue excludes out of range and not completed orders",
+		CheckOutput: func(t *testing.T, revenue float64, err error) {
+			require.NoError(t, err)
+			require.Equal(t, 300.0, revenue)
+		},
+	},
+}
+
+func TestWorkerRepositoryGetRevenueBetween(t *testing.T) {
+	dbContainer, db := SetupTestDatabase()
+	defer func(dbContainer testcontainers.Container, ctx context.Context) {
+		err := dbContainer.Terminate(ctx)
+		if err != nil {
+			return
+		}
+	}(dbContainer, context.Background())
+
+	fields := postgres.PostgresConnection{DB: db}
+	workerRepository := postgres.CreateWorkerRepository(&fields)
+
+	for _, test := range testWorkerRepositoryGetRevenueBetween {
+		t.Run(test.TestName, func(t *testing.T) {
+			user := createUser(&fields)
+			worker := createWorker(&fields)
+
+			from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+			to := time.Date(2024, 1, 31, 23, 59, 59, 0, time.UTC)
+			inRange := time.Date(2024, 1, 15, 12, 0, 0, 0, time.UTC)
+			outOfRange := time.Date(2024, 2, 15, 12, 0, 0, 0, time.UTC)
+
+			createOrderWithStatus(&fields, user.ID, worker.ID, models.CompletedOrderStatus, 100, &inRange)
+			createOrderWithStatus(&fields, user.ID, worker.ID, models.CompletedOrderStatus, 200, &inRange)
+			createOrderWithStatus(&fields, user.ID, worker.ID, models.CompletedOrderStatus, 400, &outOfRange)
+			createOrderWithStatus(&fields, user.ID, worker.ID, models.InProgressOrderStatus, 800, nil)
+			createOrderWithStatus(&fields, user.ID, worker.ID, models.CancelledOrderStatus, 1600, &inRange)
+
+			revenue, err := workerRepository.GetRevenueBetween(worker.ID, from, to)
+			test.CheckOutput(t, revenue, err)
+		})
+	}
+}
+
+var testWorkerRepositoryGetCompletedOrdersInYear = []struct {
+	TestName    string
+	CheckOutput func(t *testing.T, orders []models.Order, expected []*models.Order, err error)
+}{
+	{
+		TestName: "only completed orders of the year are returned",
+		CheckOutput: func(t *testing.T, orders []models.Order, expected []*models.Order, err error) {
+			require.NoError(t, err)
+			require.Len(t, orders, len(expected))
+			for i := range expected {
+				require.Equal(t, expected[i].ID, orders[i].ID)
+				require.Equal(t, expected[i].QuotedTotal, orders[i].QuotedTotal)
+			}
+		},
+	},
+}
+
+func TestWorkerRepositoryGetCompletedOrdersInYear(t *testing.T) {
+	dbContainer, db := SetupTestDatabase()
+	defer func(dbContainer testcontainers.Container, ctx context.Context) {
+		err := dbContainer.Terminate(ctx)
+		if err != nil {
+			return
+		}
+	}(dbContainer, context.Background())
+
+	fields := postgres.PostgresConnection{DB: db}
+	workerRepository := postgres.CreateWorkerRepository(&fields)
+
+	for _, test := range testWorkerRepositoryGetCompletedOrdersInYear {
+		t.Run(test.TestName, func(t *testing.T) {
+			user := createUser(&fields)
+			worker := createWorker(&fields)
+
+			march := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+			december := time.Date(2024, 12, 31, 23, 0, 0, 0, time.UTC)
+			nextYear := time.Date(2025, 1, 1, 1, 0, 0, 0, time.UTC)
+
+			second := createOrderWithStatus(&fields, user.ID, worker.ID, models.CompletedOrderStatus, 200, &december)
+			first := createOrderWithStatus(&fields, user.ID, worker.ID, models.CompletedOrderStatus, 100, &march)
+			createOrderWithStatus(&fields, user.ID, worker.ID, models.CompletedOrderStatus, 400, &nextYear)
+			createOrderWithStatus(&fields, user.ID, worker.ID, models.CancelledOrderStatus, 800, &march)
+
+			orders, err := workerRepository.GetCompletedOrdersInYear(worker.ID, 2024)
+			test.CheckOutput(t, orders, []*models.Order{first, second}, err)
+		})
+	}
+}
+
+var testWorkerRepositoryUpdateLastLogin = []struct {
+	TestName    string
+	CheckOutput func(t *testing.T, before *models.Worker, after *models.Worker, loggedInAt time.Time)
+}{
+	{
+		TestName: "last login is recorded",
+		CheckOutput: func(t *testing.T, before *models.Worker, after *models.Worker, loggedInAt time.Time) {
+			require.Nil(t, before.LastLoginAt)
+			require.NotNil(t, after.LastLoginAt)
+			require.WithinDuration(t, loggedInAt, *after.LastLoginAt, time.Second)
+		},
+	},
+}
+
+func TestWorkerRepositoryUpdateLastLogin(t *testing.T) {
+	dbContainer, db := SetupTestDatabase()
+	defer func(dbContainer testcontainers.Container, ctx context.Context) {
+		err := dbContainer.Terminate(ctx)
+		if err != nil {
+			return
+		}
+	}(dbContainer, context.Background())
+
+	fields := postgres.PostgresConnection{DB: db}
+	workerRepository := postgres.CreateWorkerRepository(&fields)
+
+	for _, test := range testWorkerRepositoryUpdateLastLogin {
+		t.Run(test.TestName, func(t *testing.T) {
+			worker := createWorker(&fields)
+			before, err := workerRepository.GetWorkerByID(worker.ID)
+			require.NoError(t, err)
+
+			loggedInAt := time.Date(2024, 1, 15, 12, 0, 0, 0, time.UTC)
+			err = workerRepository.UpdateLastLogin(worker.ID, loggedInAt)
+			require.NoError(t, err)
+
+			after, err := workerRepository.GetWorkerByID(worker.ID)
+			require.NoError(t, err)
+			test.CheckOutput(t, before, after, loggedInAt)
+		})
+	}
+}
+
+func TestWorkerRepositoryUpdatePassword(t *testing.T) {
+	dbContainer, db := SetupTestDatabase()
+	defer func(dbContainer testcontainers.Container, ctx context.Context) {
+		err := dbContainer.Terminate(ctx)
+		if err != nil {
+			return
+		}
+	}(dbContainer, context.Background())
+
+	fields := postgres.PostgresConnection{DB: db}
+	workerRepository := postgres.CreateWorkerRepository(&fields)
+
+	worker := createWorker(&fields)
+	err := workerRepository.UpdatePassword(worker.ID, "new_hash")
+	require.NoError(t, err)
+
+	updated, err := workerRepository.GetWorkerByID(worker.ID)
+	require.NoError(t, err)
+	require.Equal(t, "new_hash", updated.Password)
+	require.Equal(t, worker.Email, updated.Email)
+	require.Equal(t, worker.Role, updated.Role)
+}
+
+var testWorkerRepositoryDeleteWithActiveOrders = []struct {
+	TestName    string
+	BlockDelete bool
+	CheckOutput func(t *testing.T, err error)
+	CheckOrders func(t *testing.T, workerID uuid.UUID, active *models.Order, completed *models.Order)
+}{
+	{
+		TestName:    "active orders are unassigned",
+		BlockDelete: false,
+		CheckOutput: func(t *testing.T, err error) {
+			require.NoError(t, err)
+		},
+		CheckOrders: func(t *testing.T, workerID uuid.UUID, active *models.Order, completed *models.Order) {
+			require.Equal(t, uuid.Nil, active.WorkerID)
+			require.Equal(t, models.NewOrderStatus, active.Status)
+			require.Nil(t, active.AssignedAt)
+			require.Equal(t, models.CompletedOrderStatus, completed.Status)
+		},
+	},
+	{
+		TestName:    "unassignment is rolled back when delete fails",
+		BlockDelete: true,
+		CheckOutput: func(t *testing.T, err error) {
+			require.Error(t, err)
+		},
+		CheckOrders: func(t *testing.T, workerID uuid.UUID, active *models.Order, completed *models.Order) {
+			require.Equal(t, workerID, active.WorkerID)
+			require.Equal(t, models.InProgressOrderStatus, active.Status)
+			require.Equal(t, workerID, completed.WorkerID)
+		},
+	},
+}
+
+func TestWorkerRepositoryDeleteWithActiveOrders(t *testing.T) {
+	dbContainer, db := SetupTestDatabase()
+	defer func(dbContainer testcontainers.Container, ctx context.Context) {
+		err := dbContainer.Terminate(ctx)
+		if err != nil {
+			return
+		}
+	}(dbContainer, context.Background())
+
+	fields := postgres.PostgresConnection{DB: db}
+	workerRepository := postgres.CreateWorkerRepository(&fields)
+	orderRepository := postgres.CreateOrderRepository(&fields)
+
+	// a table restricting deletion of referenced workers makes the delete statement fail
+	_, err := db.Exec(`CREATE TABLE worker_delete_blocks (worker_id uuid REFERENCES workers (id) ON DELETE RESTRICT);`)
+	require.NoError(t, err)
+
+	user := createUser(&fields)
+	for _, test := range testWorkerRepositoryDeleteWithActiveOrders {
+		t.Run(test.TestName, func(t *testing.T) {
+			worker := createWorker(&fields)
+			now := time.Now()
+
+			active := createOrderWithStatus(&fields, user.ID, worker.ID, models.InProgressOrderStatus, 0, nil)
+			completed := createOrderWithStatus(&fields, user.ID, worker.ID, models.CompletedOrderStatus, 0, &now)
+
+			if test.BlockDelete {
+				_, err := db.Exec(`INSERT INTO worker_delete_blocks (worker_id) VALUES ($1);`, worker.ID)
+				require.NoError(t, err)
+			}
+
+			err := workerRepository.Delete(worker.ID)
+			test.CheckOutput(t, err)
+
+			active, err = orderRepository.GetOrderByID(active.ID)
+			require.NoError(t, err)
+			completed, err = orderRepository.GetOrderByID(completed.ID)
+			require.NoError(t, err)
+			test.CheckOrders(t, worker.ID, active, completed)
+		})
+	}
+}
+
+var testWorkerRepositoryGetTeamComparison = []struct {
+	TestName    string
+	CheckOutput func(t *testing.T, masters []*models.Worker, comparisons []models.WorkerComparison, err error)
+}{
+	{
+		TestName: "percentiles and team averages",
+		CheckOutput: func(t *testing.T, masters []*models.Worker, comparisons []models.WorkerComparison, err error) {
+			require.NoError(t, err)
+			require.Len(t, comparisons, 3)
+
+			expected := []struct {
+				ID         uuid.UUID
+				Rating     float64
+				Completed  int
+				Percentile float64
+			}{
+				{masters[0].ID, 5, 2, 100},
+				{masters[1].ID, 3, 1, 50},
+				{masters[2].ID, 0, 0, 0},
+			}
+			for i, comparison := range comparisons {
+				require.Equal(t, expected[i].ID, comparison.Worker.ID)
+				require.InDelta(t, expected[i].Rating, comparison.AverageRating, 1e-9)
+				require.Equal(t, expected[i].Completed, comparison.CompletedOrders)
+				require.InDelta(t, expected[i].Percentile, comparison.RatingPercentile, 1e-9)
+				require.InDelta(t, 8.0/3.0, comparison.TeamAverageRating, 1e-9)
+				require.InDelta(t, 1.0, comparison.TeamAverageCompleted, 1e-9)
+				require.Empty(t, comparison.Worker.Password)
+			}
+		},
+	},
+}
+
+func TestWorkerRepositoryGetTeamComparison(t *testing.T) {
+	dbContainer, db := SetupTestDatabase()
+	defer func(dbContainer testcontainers.Container, ctx context.Context) {
+		err := dbContainer.Terminate(ctx)
+		if err != nil {
+			return
+		}
+	}(dbContainer, context.Background())
+
+	fields := postgres.PostgresConnection{DB: db}
+	workerRepository := postgres.CreateWorkerRepository(&fields)
+	orderRepository := postgres.CreateOrderRepository(&fields)
+
+	for _, test := range testWorkerRepositoryGetTeamComparison {
+		t.Run(test.TestName, func(t *testing.T) {
+			user := createUser(&fields)
+			// a manager is not part of the comparison
+			createWorker(&fields)
+
+			masters := make([]*models.Worker, 3)
+			for i := range masters {
+				master, err := workerRepository.Create(&models.Worker{
+					Name:        "Master",
+					Surname:     fmt.Sprintf("Surname %d", i),
+					Address:     "Address",
+					PhoneNumber: fmt.Sprintf("+7999999990%d", i),
+					Email:       fmt.Sprintf("master%d@email.com", i),
+					Password:    "hashed_password",
+					Role:        models.MasterRole,
+				})
+				require.NoError(t, err)
+				masters[i] = master
+			}
+
+			now := time.Now()
+			rate := func(workerID uuid.UUID, rate int) {
+				order := createOrderWithStatus(&fields, user.ID, workerID, models.CompletedOrderStatus, 0, &now)
+				order.Rate = rate
+				_, err := orderRepository.Update(order)
+				require.NoError(t, err)
+			}
+			rate(masters[0].ID, 5)
+			rate(masters[0].ID, 5)
+			rate(masters[1].ID, 3)
+			// an active order does not count as completed
+			createOrderWithStatus(&fields, user.ID, masters[2].ID, models.InProgressOrderStatus, 0, nil)
+
+			comparisons, err := workerRepository.GetTeamComparison()
+			test.CheckOutput(t, masters, comparisons, err)
+		})
+	}
+}
+
+func TestWorkerRepositoryGetAssignmentCandidates(t *testing.T) {
+	dbContainer, db := SetupTestDatabase()
+	defer func(dbContainer testcontainers.Container, ctx context.Context) {
+		err := dbContainer.Terminate(ctx)
+		if err != nil {
+			return
+		}
+	}(dbContainer, context.Background())
+
+	fields := postgres.PostgresConnection{DB: db}
+	workerRepository := postgres.CreateWorkerRepository(&fields)
+	orderRepository := postgres.CreateOrderRepository(&fields)
+
+	user := createUser(&fields)
+	manager := createWorker(&fields)
+
+	masters := make([]*models.Worker, 6)
+	for i := range masters {
+		master, err := workerRepository.Create(&models.Worker{
+			Name:        "Master",
+			Surname:     fmt.Sprintf("Surname %d", i),
+			Address:     "Address",
+			PhoneNumber: fmt.Sprintf("+7999999990%d", i),
+			Email:       fmt.Sprintf("master%d@email.com", i),
+			Password:    "hashed_password",
+			Role:        models.MasterRole,
+		})
+		require.NoError(t, err)
+		masters[i] = master
+	}
+
+	now := time.Now()
+	rate := func(workerID uuid.UUID, rate int) {
+		order := createOrderWithStatus(&fields, user.ID, workerID, models.CompletedOrderStatus, 0, &now)
+		order.Rate = rate
+		_, err := orderRepository.Update(order)
+		require.NoError(t, err)
+	}
+	rate(masters[0].ID, 5)
+	rate(masters[0].ID, 5)
+	rate(masters[1].ID, 4)
+	createOrderWithStatus(&fields, user.ID, masters[1].ID, models.InProgressOrderStatus, 0, nil)
+	rate(masters[2].ID, 4)
+	// rated below the threshold
+	rate(masters[3].ID, 2)
+	// well rated but at capacity
+	rate(masters[4].ID, 5)
+	createOrderWithStatus(&fields, user.ID, masters[4].ID, models.InProgressOrderStatus, 0, nil)
+	createOrderWithStatus(&fields, user.ID, masters[4].ID, models.NewOrderStatus, 0, nil)
+	// masters[5] has no rated orders, and a manager is never a candidate
+	rate(manager.ID, 5)
+
+	ids := func(candidates []models.WorkerWithRating) []uuid.UUID {
+		result := make([]uuid.UUID, len(candidates))
+		for i, candidate := range candidates {
+			result[i] = candidate.Worker.ID
+		}
+		return result
+	}
+
+	t.Run("only qualifying masters by rating then load", func(t *testing.T) {
+		candidates, err := workerRepository.GetAssignmentCandidates(3, 2)
+		require.NoError(t, err)
+		require.Equal(t, []uuid.UUID{masters[0].ID, masters[2].ID, masters[1].ID}, ids(candidates))
+		require.Equal(t, 5.0, candidates[0].AverageRating)
+		require.Equal(t, 1, candidates[2].ActiveOrders)
+		require.Empty(t, candidates[0].Worker.Password)
+	})
+
+	t.Run("unlimited capacity", func(t *testing.T) {
+		candidates, err := workerRepository.GetAssignmentCandidates(3, 0)
+		require.NoError(t, err)
+		require.Equal(t, []uuid.UUID{masters[0].ID, masters[4].ID, masters[2].ID, masters[1].ID}, ids(candidates))
+	})
+
+	t.Run("zero threshold includes unrated masters", func(t *testing.T) {
+		candidates, err := workerRepository.GetAssignmentCandidates(0, 2)
+		require.NoError(t, err)
+		require.Len(t, candidates, 5)
+		require.Equal(t, masters[5].ID, candidates[len(candidates)-1].Worker.ID)
+	})
+}
+
+func TestWorkerRepositoryGetPerformanceReport(t *testing.T) {
+	dbContainer, db := SetupTestDatabase()
+	defer func(dbContainer testcontainers.Container, ctx context.Context) {
+		err := dbContainer.Terminate(ctx)
+		if err != nil {
+			return
+		}
+	}(dbContainer, context.Background())
+
+	fields := postgres.PostgresConnection{DB: db}
+	workerRepository := postgres.CreateWorkerRepository(&fields)
+	orderRepository := postgres.CreateOrderRepository(&fields)
+
+	user := createUser(&fields)
+	manager := createWorker(&fields)
+
+	masters := make([]*models.Worker, 2)
+	for i := range masters {
+		master, err := workerRepository.Create(&models.Worker{
+			Name:        "Master",
+			Surname:     fmt.Sprintf("Surname %d", i),
+			Address:     "Address",
+			PhoneNumber: fmt.Sprintf("+7999999990%d", i),
+			Email:       fmt.Sprintf("master%d@email.com", i),
+			Password:    "hashed_password",
+			Role:        models.MasterRole,
+		})
+		require.NoError(t, err)
+		masters[i] = master
+	}
+
+	now := time.Now()
+	complete := func(workerID uuid.UUID, rate int, total float64) {
+		order := createOrderWithStatus(&fields, user.ID, workerID, models.CompletedOrderStatus, total, &now)
+		order.Rate = rate
+		_, err := orderRepository.Update(order)
+		require.NoError(t, err)
+	}
+	// masters[0] has more orders but a lower rating
+	complete(masters[0].ID, 3, 1000)
+	complete(masters[0].ID, 4, 1500)
+	complete(masters[0].ID, 0, 500)
+	createOrderWithStatus(&fields, user.ID, masters[0].ID, models.CancelledOrderStatus, 700, nil)
+	createOrderWithStatus(&fields, user.ID, masters[0].ID, models.InProgressOrderStatus, 300, nil)
+	complete(masters[1].ID, 5, 2000)
+	// deleted orders are not counted
+	deleted := createOrderWithStatus(&fields, user.ID, masters[1].ID, models.CompletedOrderStatus, 900, &now)
+	require.NoError(t, orderRepository.Delete(deleted.ID))
+	// a manager is not part of the report
+	complete(manager.ID, 5, 100)
+
+	report, err := workerRepository.GetPerformanceReport()
+	require.NoError(t, err)
+	require.Len(t, report, 2)
+
+	require.Equal(t, masters[1].ID, report[0].Worker.ID)
+	require.Equal(t, 1, report[0].CompletedOrders)
+	require.Equal(t, 0, report[0].CancelledOrders)
+	require.Equal(t, 5.0, report[0].AverageRating)
+	require.Equal(t, 2000.0, report[0].Revenue)
+	require.Empty(t, report[0].Worker.Password)
+
+	require.Equal(t, masters[0].ID, report[1].Worker.ID)
+	require.Equal(t, 3, report[1].CompletedOrders)
+	require.Equal(t, 1, report[1].CancelledOrders)
+	require.Equal(t, 3.5, report[1].AverageRating)
+	require.Equal(t, 3000.0, report[1].Revenue)
+}
+
+func TestWorkerRepositoryGetCompletedOrderRatings(t *testing.T) {
+	dbContainer, db := SetupTestDatabase()
+	defer func(dbContainer testcontainers.Container, ctx context.Context) {
+		err := dbContainer.Terminate(ctx)
+		if err != nil {
+			return
+		}
+	}(dbContainer, context.Background())
+
+	fields := postgres.PostgresConnection{DB: db}
+	workerRepository := postgres.CreateWorkerRepository(&fields)
+	orderRepository := postgres.CreateOrderRepository(&fields)
+
+	user := createUser(&fields)
+	worker := createWorker(&fields)
+
+	recent := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	old := time.Date(2023, 3, 1, 12, 0, 0, 0, time.UTC)
+	for _, seed := range []struct {
+		status      int
+		rate        int
+		completedAt *time.Time
+	}{
+		{models.CompletedOrderStatus, 2, &old},
+		{models.CompletedOrderStatus, 5, &recent},
+		{models.CompletedOrderStatus, 0, &recent},
+		{models.CancelledOrderStatus, 1, nil},
+	} {
+		order := createOrderWithStatus(&fields, user.ID, worker.ID, seed.status, 100, seed.completedAt)
+		order.Rate = seed.rate
+		_, err := orderRepository.Update(order)
+		require.NoError(t, err)
+	}
+
+	ratings, err := workerRepository.GetCompletedOrderRatings(worker.ID)
+	require.NoError(t, err)
+	require.Len(t, ratings, 2)
+	require.Equal(t, 5, ratings[0].Rate)
+	require.True(t, ratings[0].CompletedAt.Equal(recent))
+	require.Equal(t, 2, ratings[1].Rate)
+	require.True(t, ratings[1].CompletedAt.Equal(old))
+}
+
+func TestWorkerRepositoryGetWorkersByRoleExclude(t *testing.T) {
+	dbContainer, db := SetupTestDatabase()
+	defer func(dbContainer testcontainers.Container, ctx context.Context) {
+		err := dbContainer.Terminate(ctx)
+		if err != nil {
+			return
+		}
+	}(dbContainer, context.Background())
+
+	fields := postgres.PostgresConnection{DB: db}
+	workerRepository := postgres.CreateWorkerRepository(&fields)
+
+	var masters []*models.Worker
+	for i := 0; i < 3; i++ {
+		master, err := workerRepository.Create(&models.Worker{
+			Name:        "Master",
+			Surname:     "Surname",
+			Address:     "Address",
+			PhoneNumber: "+79999999999",
+			Email:       fmt.Sprintf("master%d@email.com", i),
+			Role:        models.MasterRole,
+			Password:    "hashed_password",
+		})
+		require.NoError(t, err)
+		masters = append(masters, master)
+	}
+
+	workers, err := workerRepository.GetWorkersByRole(models.MasterRole, uuid.Nil)
+	require.NoError(t, err)
+	require.Len(t, workers, 3)
+
+	workers, err = workerRepository.GetWorkersByRole(models.MasterRole, masters[1].ID)
+	require.NoError(t, err)
+	require.Len(t, workers, 2)
+	for _, worker := range workers {
+		require.NotEqual(t, masters[1].ID, worker.ID)
+	}
+	require.ElementsMatch(t, []uuid.UUID{masters[0].ID, masters[2].ID}, []uuid.UUID{workers[0].ID, workers[1].ID})
+}
+
+func TestWorkerRepositoryAverageOrderRateAfterDelete(t *testing.T) {
+	dbContainer, db := SetupTestDatabase()
+	defer func(dbContainer testcontainers.Container, ctx context.Context) {
+		err := dbContainer.Terminate(ctx)
+		if err != nil {
+			return
+		}
+	}(dbContainer, context.Background())
+
+	fields := postgres.PostgresConnection{DB: db}
+	workerRepository := postgres.CreateWorkerRepository(&fields)
+	orderRepository := postgres.CreateOrderRepository(&fields)
+
+	user := createUser(&fields)
+	worker := createWorker(&fields)
+
+	completedAt := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	var orders []*models.Order
+	for _, rate := range []int{5, 3, 0} {
+		order := createOrderWithStatus(&fields, user.ID, worker.ID, models.CompletedOrderStatus, 100, &completedAt)
+		order.Rate = rate
+		order, err := orderRepository.Update(order)
+		require.NoError(t, err)
+		orders = append(orders, order)
+	}
+
+	rate, err := workerRepository.GetAverageOrderRate(worker)
+	require.NoError(t, err)
+	require.InDelta(t, 4.0, rate, 1e-9)
+
+	t.Run("deleting an unrated order keeps the rating", func(t *testing.T) {
+		require.NoError(t, orderRepository.Delete(orders[2].ID))
+
+		rate, err := workerRepository.GetAverageOrderRate(worker)
+		require.NoError(t, err)
+		require.InDelta(t, 4.0, rate, 1e-9)
+	})
+
+	t.Run("deleting a rated order updates the rating", func(t *testing.T) {
+		require.NoError(t, orderRepository.Delete(orders[0].ID))
+
+		rate, err := workerRepository.GetAverageOrderRate(worker)
+		require.NoError(t, err)
+		require.InDelta(t, 3.0, rate, 1e-9)
+	})
+}
+
+func TestWorkerRepositoryAvailability(t *testing.T) {
+	dbContainer, db := SetupTestDatabase()
+	defer func(dbContainer testcontainers.Container, ctx context.Context) {
+		err := dbContainer.Terminate(ctx)
+		if err != nil {
+			return
+		}
+	}(dbContainer, context.Background())
+
+	fields := postgres.PostgresConnection{DB: db}
+	workerRepository := postgres.CreateWorkerRepository(&fields)
+
+	worker := createWorker(&fields)
+	vacationStart := time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC)
+	vacationEnd := time.Date(2024, 7, 15, 0, 0, 0, 0, time.UTC)
+	require.NoError(t, workerRepository.SetAvailability(worker.ID, vacationStart, vacationEnd, false))
+
+	t.Run("available without a covering period", func(t *testing.T) {
+		available, err := workerRepository.IsAvailable(worker.ID, vacationStart.Add(-time.Hour))
+		require.NoError(t, err)
+		require.True(t, available)
+
+		available, err = workerRepository.IsAvailable(worker.ID, vacationEnd)
+		require.NoError(t, err)
+		require.True(t, available)
+	})
+
+	t.Run("unavailable inside the period", func(t *testing.T) {
+		available, err := workerRepository.IsAvailable(worker.ID, vacationStart.AddDate(0, 0, 3))
+		require.NoError(t, err)
+		require.False(t, available)
+	})
+
+	t.Run("later period overrides an earlier one", func(t *testing.T) {
+		workday := vacationStart.AddDate(0, 0, 5)
+		require.NoError(t, workerRepository.SetAvailability(worker.ID, workday, workday.Add(24*time.Hour), true))
+
+		available, err := workerRepository.IsAvailable(worker.ID, workday.Add(12*time.Hour))
+		require.NoError(t, err)
+		require.True(t, available)
+
+		available, err = workerRepository.IsAvailable(worker.ID, vacationStart.AddDate(0, 0, 3))
+		require.NoError(t, err)
+		require.False(t, available)
+	})
+}
@@ -0,0 +1,219 @@
+package test_services
+
+import (
+	"github.com/charmbracelet/log"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"os"
+	"teamdev/internal/models"
+	"teamdev/internal/repository/repository_errors"
+	services "teamdev/internal/services"
+	"teamdev/internal/services/service_errors"
+	"teamdev/internal/services/service_interfaces"
+	mock_repository_interfaces "teamdev/tests/repository_mocks"
+	"testing"
+)
+
+type categoryServiceFields struct {
+	categoryRepoMock *mock_repository_interfaces.MockICategoryRepository
+	taskRepoMock     *mock_repository_interfaces.MockITaskRepository
+	logger           *log.Logger
+}
+
+func initCategoryServiceFields(ctrl *gomock.Controller) *categoryServiceFields {
+	categoryRepoMock := mock_repository_interfaces.NewMockICategoryRepository(ctrl)
+	taskRepoMock := mock_repository_interfaces.NewMockITaskRepository(ctrl)
+
+	f, err := os.OpenFile("tests.log", os.O_RDWR|os.O_CREATE|os.O_APPEND, 0666)
+	if err != nil {
+		log.Fatal(err)
+	}
+	logger := log.New(f)
+
+	return &categoryServiceFields{
+		categoryRepoMock: categoryRepoMock,
+		taskRepoMock:     taskRepoMock,
+		logger:           logger,
+	}
+}
+
+func initCategoryService(fields *categoryServiceFields) service_interfaces.ICategoryService {
+	return services.NewCategoryService(fields.categoryRepoMock, fields.taskRepoMock, fields.logger)
+}
+
+var testCategorySearchByName = []struct {
+	testName  string
+	inputData struct {
+		substring string
+	}
+	prepare     func(fields *categoryServiceFields)
+	checkOutput func(t *testing.T, categories []models.Category, err error)
+}{
+	{
+		testName:  "matching categories",
+		inputData: struct{ substring string }{"уборка"},
+		prepare: func(fields *categoryServiceFields) {
+			fields.categoryRepoMock.EXPECT().SearchByName("уборка").Return([]models.Category{
+				{ID: 2, Name: "Генеральная уборка"},
+				{ID: 4, Name: "Послестроительная уборка"},
+			}, nil)
+		},
+		checkOutput: func(t *testing.T, categories []models.Category, err error) {
+			assert.NoError(t, err)
+			assert.Len(t, categories, 2)
+		},
+	},
+	{
+		testName:  "empty substring",
+		inputData: struct{ substring string }{""},
+		prepare:   func(fields *categoryServiceFields) {},
+		checkOutput: func(t *testing.T, categories []models.Category, err error) {
+			assert.Error(t, err)
+			assert.Nil(t, categories)
+			assert.Equal(t, service_errors.InvalidName, err)
+		},
+	},
+	{
+		testName:  "whitespace substring",
+		inputData: struct{ substring string }{"   "},
+		prepare:   func(fields *categoryServiceFields) {},
+		checkOutput: func(t *testing.T, categories []models.Category, err error) {
+			assert.Error(t, err)
+			assert.Nil(t, categories)
+			assert.Equal(t, service_errors.InvalidName, err)
+		},
+	},
+	{
+		testName:  "repository error",
+		inputData: struct{ substring string }{"окна"},
+		prepare: func(fields *categoryServiceFields) {
+			fields.categoryRepoMock.EXPECT().SearchByName(gomock.Any()).Return(nil, repository_errors.SelectError)
+		},
+		checkOutput: func(t *testing.T, categories []models.Category, err error) {
+			assert.Error(t, err)
+			assert.Nil(t, categories)
+			assert.Equal(t, repository_errors.SelectError, err)
+		},
+	},
+}
+
+func TestCategoryService_SearchByName(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	fields := initCategoryServiceFields(ctrl)
+	service := initCategoryService(fields)
+
+	for _, tt := range testCategorySearchByName {
+		t.Run(tt.testName, func(t *testing.T) {
+			tt.prepare(fields)
+			categories, err := service.SearchByName(tt.inputData.substring)
+			tt.checkOutput(t, categories, err)
+		})
+	}
+}
+
+var testCategoryDelete = []struct {
+	testName    string
+	force       bool
+	prepare     func(fields *categoryServiceFields)
+	checkOutput func(t *testing.T, err error)
+}{
+	{
+		testName: "empty category is deleted",
+		prepare: func(fields *categoryServiceFields) {
+			fields.categoryRepoMock.EXPECT().DeleteIfEmpty(3).Return(0, nil)
+		},
+		checkOutput: func(t *testing.T, err error) {
+			assert.NoError(t, err)
+		},
+	},
+	{
+		testName: "category with tasks is kept without force",
+		prepare: func(fields *categoryServiceFields) {
+			fields.categoryRepoMock.EXPECT().DeleteIfEmpty(3).Return(2, nil)
+			fields.categoryRepoMock.EXPECT().DeleteMovingTasks(gomock.Any(), gomock.Any()).Times(0)
+		},
+		checkOutput: func(t *testing.T, err error) {
+			assert.ErrorIs(t, err, service_errors.CategoryHasTasks)
+			assert.Contains(t, err.Error(), "2 task(s)")
+		},
+	},
+	{
+		testName: "tasks are moved to the existing uncategorized category with force",
+		force:    true,
+		prepare: func(fields *categoryServiceFields) {
+			fields.categoryRepoMock.EXPECT().DeleteIfEmpty(3).Return(1, nil)
+			fields.categoryRepoMock.EXPECT().SearchByName(models.UncategorizedCategoryName).Return([]models.Category{{ID: 9, Name: models.UncategorizedCategoryName}}, nil)
+			fields.categoryRepoMock.EXPECT().Create(gomock.Any()).Times(0)
+			fields.categoryRepoMock.EXPECT().DeleteMovingTasks(3, 9).Return(1, nil)
+		},
+		checkOutput: func(t *testing.T, err error) {
+			assert.NoError(t, err)
+		},
+	},
+	{
+		testName: "uncategorized category is created on first use",
+		force:    true,
+		prepare: func(fields *categoryServiceFields) {
+			fields.categoryRepoMock.EXPECT().DeleteIfEmpty(3).Return(1, nil)
+			fields.categoryRepoMock.EXPECT().SearchByName(models.UncategorizedCategoryName).Return(nil, nil)
+			fields.categoryRepoMock.EXPECT().Create(&models.Category{Name: models.UncategorizedCategoryName}).Return(&models.Category{ID: 10, Name: models.UncategorizedCategoryName}, nil)
+			fields.categoryRepoMock.EXPECT().DeleteMovingTasks(3, 10).Return(1, nil)
+		},
+		checkOutput: func(t *testing.T, err error) {
+			assert.NoError(t, err)
+		},
+	},
+	{
+		testName: "repository failure while deleting",
+		prepare: func(fields *categoryServiceFields) {
+			fields.categoryRepoMock.EXPECT().DeleteIfEmpty(3).Return(0, repository_errors.DeleteError)
+		},
+		checkOutput: func(t *testing.T, err error) {
+			assert.Equal(t, repository_errors.DeleteError, err)
+		},
+	},
+	{
+		testName: "repository failure while moving tasks",
+		force:    true,
+		prepare: func(fields *categoryServiceFields) {
+			fields.categoryRepoMock.EXPECT().DeleteIfEmpty(3).Return(1, nil)
+			fields.categoryRepoMock.EXPECT().SearchByName(models.UncategorizedCategoryName).Return([]models.Category{{ID: 9, Name: models.UncategorizedCategoryName}}, nil)
+			fields.categoryRepoMock.EXPECT().DeleteMovingTasks(3, 9).Return(0, repository_errors.TransactionCommitError)
+		},
+		checkOutput: func(t *testing.T, err error) {
+			assert.Equal(t, repository_errors.TransactionCommitError, err)
+		},
+	},
+}
+
+func TestCategoryService_Delete(t *testing.T) {
+	for _, tt := range testCategoryDelete {
+		t.Run(tt.testName, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			fields := initCategoryServiceFields(ctrl)
+			service := initCategoryService(fields)
+			tt.prepare(fields)
+
+			err := service.Delete(3, tt.force)
+			tt.checkOutput(t, err)
+		})
+	}
+}
+
+func TestCategoryService_DeleteUncategorizedWithTasks(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	fields := initCategoryServiceFields(ctrl)
+	service := initCategoryService(fields)
+
+	fields.categoryRepoMock.EXPECT().DeleteIfEmpty(9).Return(1, nil)
+	fields.categoryRepoMock.EXPECT().SearchByName(models.UncategorizedCategoryName).Return([]models.Category{{ID: 9, Name: models.UncategorizedCategoryName}}, nil)
+	fields.categoryRepoMock.EXPECT().DeleteMovingTasks(gomock.Any(), gomock.Any()).Times(0)
+
+	assert.Equal(t, service_errors.InvalidCategory, service.Delete(9, true))
+}
@@ -7,6 +7,9 @@ import (
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"os"
+	"sort"
+	"strings"
+	"sync"
 	"teamdev/internal/models"
 	"teamdev/internal/repository/repository_errors"
 	services "teamdev/internal/services"
@@ -22,6 +25,7 @@ type orderServiceFields struct {
 	taskRepoMock   *mock_repository_interfaces.MockITaskRepository
 	workerRepoMock *mock_repository_interfaces.MockIWorkerRepository
 	userRepoMock   *mock_repository_interfaces.MockIUserRepository
+	priceTiers     map[uuid.UUID][]models.PriceTier
 	logger         *log.Logger
 }
 
@@ -37,17 +41,50 @@ func initOrderServiceFields(ctrl *gomock.Controller) *orderServiceFields {
 	}
 	logger := log.New(f)
 
+	// tasks have no volume tiers unless a test adds them
+	priceTiers := make(map[uuid.UUID][]models.PriceTier)
+	taskRepoMock.EXPECT().GetPriceTiersByTaskIDs(gomock.Any()).DoAndReturn(func(taskIDs []uuid.UUID) (map[uuid.UUID][]models.PriceTier, error) {
+		tiers := make(map[uuid.UUID][]models.PriceTier)
+		for _, id := range taskIDs {
+			if priceTiers[id] != nil {
+				tiers[id] = priceTiers[id]
+			}
+		}
+		return tiers, nil
+	}).AnyTimes()
+
 	return &orderServiceFields{
 		orderRepoMock:  orderRepoMock,
 		taskRepoMock:   taskRepoMock,
 		workerRepoMock: workerRepoMock,
 		userRepoMock:   userRepoMock,
+		priceTiers:     priceTiers,
 		logger:         logger,
 	}
 }
 
 func initOrderService(fields *orderServiceFields) service_interfaces.IOrderService {
-	return services.NewOrderService(fields.orderRepoMock, fields.workerRepoMock, fields.taskRepoMock, fields.userRepoMock, fields.logger)
+	return services.NewOrderService(fields.orderRepoMock, fields.workerRepoMock, fields.taskRepoMock, fields.userRepoMock, fields.logger, 0, models.TaxSettings{}, models.RoundHalfUp, models.DeadlineSettings{}, nil, nil)
+}
+
+// existingTasks stands in for TaskRepository.GetTasksByIDs when every requested task exists.
+func existingTasks(ids []uuid.UUID) (map[uuid.UUID]models.Task, error) {
+	tasks := make(map[uuid.UUID]models.Task, len(ids))
+	for _, id := range ids {
+		tasks[id] = models.Task{ID: id}
+	}
+	return tasks, nil
+}
+
+// storedCatalog stands in for TaskRepository.GetTasksByIDs with the given stored tasks.
+func storedCatalog(stored ...models.Task) func(ids []uuid.UUID) (map[uuid.UUID]models.Task, error) {
+	return func(ids []uuid.UUID) (map[uuid.UUID]models.Task, error) {
+		tasks := make(map[uuid.UUID]models.Task, len(stored))
+		for _, task := range stored {
+			tasks[task.ID] = task
+		}
+		return tasks, nil
+	}
 }
 
 var testOrderServiceCreate = []struct {
@@ -59,7 +96,7 @@ var testOrderServiceCreate = []struct {
 		tasks    []models.Task
 	}
 	prepare     func(fields *orderServiceFields)
-	checkOutput func(t *testing.T, order *models.Order, err error)
+	checkOutput func(t *testing.T, order *models.OrderWithTasks, err error)
 }{
 	{
 		testName: "create order success test",
@@ -75,13 +112,45 @@ var testOrderServiceCreate = []struct {
 			[]models.Task{{ID: uuid.New()}, {ID: uuid.New()}},
 		},
 		prepare: func(fields *orderServiceFields) {
-			fields.taskRepoMock.EXPECT().GetTaskByID(gomock.Any()).Return(&models.Task{}, nil).Times(2)
+			fields.taskRepoMock.EXPECT().GetTasksByIDs(gomock.Any()).DoAndReturn(existingTasks)
 			fields.userRepoMock.EXPECT().GetUserByID(gomock.Any()).Return(&models.User{ID: uuid.New()}, nil)
 			fields.orderRepoMock.EXPECT().Create(gomock.Any(), gomock.Any()).Return(&models.Order{ID: uuid.New()}, nil)
+			fields.orderRepoMock.EXPECT().DeleteDraft(gomock.Any()).Return(nil)
 		},
-		checkOutput: func(t *testing.T, order *models.Order, err error) {
+		checkOutput: func(t *testing.T, order *models.OrderWithTasks, err error) {
+			assert.NoError(t, err)
+			assert.NotNil(t, order)
+		},
+	},
+	{
+		testName: "quoted total is stored on creation",
+		inputData: struct {
+			userID   uuid.UUID
+			address  string
+			deadline time.Time
+			tasks    []models.Task
+		}{
+			uuid.New(),
+			"address",
+			time.Now().AddDate(0, 0, 1),
+			// prices sent with the order are ignored
+			[]models.Task{{ID: uuid.New(), PricePerSingle: 1}, {ID: uuid.New(), PricePerSingle: 2}},
+		},
+		prepare: func(fields *orderServiceFields) {
+			fields.taskRepoMock.EXPECT().GetTasksByIDs(gomock.Any()).DoAndReturn(func(ids []uuid.UUID) (map[uuid.UUID]models.Task, error) {
+				return storedCatalog(models.Task{ID: ids[0], PricePerSingle: 100}, models.Task{ID: ids[1], PricePerSingle: 250.5})(ids)
+			})
+			fields.userRepoMock.EXPECT().GetUserByID(gomock.Any()).Return(&models.User{ID: uuid.New()}, nil)
+			fields.orderRepoMock.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(order *models.Order, orderedTasks []models.OrderedTask) (*models.Order, error) {
+				order.ID = uuid.New()
+				return order, nil
+			})
+			fields.orderRepoMock.EXPECT().DeleteDraft(gomock.Any()).Return(nil)
+		},
+		checkOutput: func(t *testing.T, order *models.OrderWithTasks, err error) {
 			assert.NoError(t, err)
 			assert.NotNil(t, order)
+			assert.Equal(t, 350.5, order.Order.QuotedTotal)
 		},
 	},
 	{
@@ -98,10 +167,10 @@ var testOrderServiceCreate = []struct {
 			[]models.Task{},
 		},
 		prepare: func(fields *orderServiceFields) {},
-		checkOutput: func(t *testing.T, order *models.Order, err error) {
+		checkOutput: func(t *testing.T, order *models.OrderWithTasks, err error) {
 			assert.Error(t, err)
 			assert.Nil(t, order)
-			assert.Equal(t, fmt.Errorf("SERVICE: Invalid input"), err)
+			assert.ErrorIs(t, err, service_errors.EmptyTasksOrder)
 		},
 	},
 	{
@@ -118,10 +187,10 @@ var testOrderServiceCreate = []struct {
 			[]models.Task{{ID: uuid.New()}, {ID: uuid.New()}},
 		},
 		prepare: func(fields *orderServiceFields) {},
-		checkOutput: func(t *testing.T, order *models.Order, err error) {
+		checkOutput: func(t *testing.T, order *models.OrderWithTasks, err error) {
 			assert.Error(t, err)
 			assert.Nil(t, order)
-			assert.Equal(t, fmt.Errorf("SERVICE: Invalid input"), err)
+			assert.ErrorIs(t, err, service_errors.InvalidAddressOrder)
 		},
 	},
 	{
@@ -138,10 +207,10 @@ var testOrderServiceCreate = []struct {
 			[]models.Task{{ID: uuid.New()}, {ID: uuid.New()}},
 		},
 		prepare: func(fields *orderServiceFields) {},
-		checkOutput: func(t *testing.T, order *models.Order, err error) {
-			assert.Error(t, err)
+		checkOutput: func(t *testing.T, order *models.OrderWithTasks, err error) {
 			assert.Nil(t, order)
-			assert.Equal(t, fmt.Errorf("SERVICE: Invalid input"), err)
+			assert.ErrorIs(t, err, service_errors.InvalidDeadlineOrder)
+			assert.ErrorIs(t, err, service_errors.DeadlineInPast)
 		},
 	},
 	{
@@ -158,9 +227,9 @@ var testOrderServiceCreate = []struct {
 			[]models.Task{{ID: uuid.New()}, {ID: uuid.New()}},
 		},
 		prepare: func(fields *orderServiceFields) {
-			fields.taskRepoMock.EXPECT().GetTaskByID(gomock.Any()).Return(nil, service_errors.InvalidReference)
+			fields.taskRepoMock.EXPECT().GetTasksByIDs(gomock.Any()).Return(nil, service_errors.InvalidReference)
 		},
-		checkOutput: func(t *testing.T, order *models.Order, err error) {
+		checkOutput: func(t *testing.T, order *models.OrderWithTasks, err error) {
 			assert.Error(t, err)
 			assert.Nil(t, order)
 			assert.Equal(t, service_errors.InvalidReference, err)
@@ -180,10 +249,10 @@ var testOrderServiceCreate = []struct {
 			[]models.Task{{ID: uuid.New()}, {ID: uuid.New()}},
 		},
 		prepare: func(fields *orderServiceFields) {
-			fields.taskRepoMock.EXPECT().GetTaskByID(gomock.Any()).Return(&models.Task{}, nil).Times(2)
+			fields.taskRepoMock.EXPECT().GetTasksByIDs(gomock.Any()).DoAndReturn(existingTasks)
 			fields.userRepoMock.EXPECT().GetUserByID(gomock.Any()).Return(nil, service_errors.InvalidReference)
 		},
-		checkOutput: func(t *testing.T, order *models.Order, err error) {
+		checkOutput: func(t *testing.T, order *models.OrderWithTasks, err error) {
 			assert.Error(t, err)
 			assert.Nil(t, order)
 			assert.Equal(t, service_errors.InvalidReference, err)
@@ -203,11 +272,11 @@ var testOrderServiceCreate = []struct {
 			[]models.Task{{ID: uuid.New()}, {ID: uuid.New()}},
 		},
 		prepare: func(fields *orderServiceFields) {
-			fields.taskRepoMock.EXPECT().GetTaskByID(gomock.Any()).Return(&models.Task{}, nil).Times(2)
+			fields.taskRepoMock.EXPECT().GetTasksByIDs(gomock.Any()).DoAndReturn(existingTasks)
 			fields.userRepoMock.EXPECT().GetUserByID(gomock.Any()).Return(&models.User{ID: uuid.New()}, nil)
 			fields.orderRepoMock.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil, repository_errors.InsertError)
 		},
-		checkOutput: func(t *testing.T, order *models.Order, err error) {
+		checkOutput: func(t *testing.T, order *models.OrderWithTasks, err error) {
 			assert.Error(t, err)
 			assert.Nil(t, order)
 			assert.Equal(t, repository_errors.InsertError, err)
@@ -239,6 +308,113 @@ func TestOrderService_CreateOrder(t *testing.T) {
 	}
 }
 
+func TestOrderService_CreateOrderWithNonPositiveQuantity(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	fields := initOrderServiceFields(ctrl)
+	orderService := initOrderService(fields)
+
+	fields.taskRepoMock.EXPECT().GetTasksByIDs(gomock.Any()).Times(0)
+	fields.orderRepoMock.EXPECT().Create(gomock.Any(), gomock.Any()).Times(0)
+
+	for _, quantity := range []int{0, -2} {
+		tasks := []models.OrderedTask{{Task: &models.Task{ID: uuid.New(), PricePerSingle: 100}, Quantity: quantity}}
+		order, err := orderService.CreateOrder(uuid.New(), "address", time.Now().AddDate(0, 0, 1), tasks)
+		assert.ErrorIs(t, err, service_errors.NegativeQuantity)
+		assert.Nil(t, order)
+	}
+}
+
+func TestOrderService_CreateOrderWithMissingTask(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	fields := initOrderServiceFields(ctrl)
+	orderService := initOrderService(fields)
+
+	first := models.Task{ID: uuid.New(), PricePerSingle: 100}
+	missing := models.Task{ID: uuid.New(), PricePerSingle: 200}
+	last := models.Task{ID: uuid.New(), PricePerSingle: 300}
+	fields.taskRepoMock.EXPECT().GetTasksByIDs([]uuid.UUID{first.ID, missing.ID, last.ID}).Return(map[uuid.UUID]models.Task{first.ID: first, last.ID: last}, nil)
+	fields.taskRepoMock.EXPECT().GetTaskByID(gomock.Any()).Times(0)
+	fields.orderRepoMock.EXPECT().Create(gomock.Any(), gomock.Any()).Times(0)
+
+	order, err := orderService.CreateOrder(uuid.New(), "address", time.Now().AddDate(0, 0, 1), []models.OrderedTask{
+		{Task: &first, Quantity: 1},
+		{Task: &missing, Quantity: 2},
+		{Task: &last, Quantity: 1},
+	})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), missing.ID.String())
+	assert.NotContains(t, err.Error(), first.ID.String())
+	assert.Nil(t, order)
+}
+
+func TestOrderService_CreateOrderValidationErrors(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	fields := initOrderServiceFields(ctrl)
+	orderService := initOrderService(fields)
+	fields.orderRepoMock.EXPECT().Create(gomock.Any(), gomock.Any()).Times(0)
+
+	task := models.Task{ID: uuid.New(), PricePerSingle: 100}
+	tasks := []models.OrderedTask{{Task: &task, Quantity: 1}}
+	deadline := time.Now().AddDate(0, 0, 1)
+
+	_, err := orderService.CreateOrder(uuid.New(), "", deadline, tasks)
+	assert.ErrorIs(t, err, service_errors.InvalidAddressOrder)
+
+	_, err = orderService.CreateOrder(uuid.New(), "address", deadline, nil)
+	assert.ErrorIs(t, err, service_errors.EmptyTasksOrder)
+
+	_, err = orderService.CreateOrder(uuid.New(), "address", time.Now().AddDate(0, 0, -1), tasks)
+	assert.ErrorIs(t, err, service_errors.InvalidDeadlineOrder)
+
+	fields.taskRepoMock.EXPECT().GetTasksByIDs(gomock.Any()).Return(map[uuid.UUID]models.Task{}, nil)
+	_, err = orderService.CreateOrder(uuid.New(), "address", deadline, tasks)
+	assert.ErrorIs(t, err, service_errors.InvalidReference)
+
+	fields.taskRepoMock.EXPECT().GetTasksByIDs(gomock.Any()).DoAndReturn(existingTasks)
+	fields.userRepoMock.EXPECT().GetUserByID(gomock.Any()).Return(nil, repository_errors.DoesNotExist)
+	_, err = orderService.CreateOrder(uuid.New(), "address", deadline, tasks)
+	assert.ErrorIs(t, err, service_errors.InvalidReference)
+}
+
+func TestOrderService_CreateOrderReturnsTasks(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	fields := initOrderServiceFields(ctrl)
+	orderService := initOrderService(fields)
+
+	windows := models.Task{ID: uuid.New(), Name: "Windows", PricePerSingle: 100}
+	floors := models.Task{ID: uuid.New(), Name: "Floors", PricePerSingle: 50}
+	// clients like the HTTP API send only the task IDs, the prices come from the catalog
+	orderedTasks := []models.OrderedTask{{Task: &models.Task{ID: windows.ID}, Quantity: 3}, {Task: &models.Task{ID: floors.ID}, Quantity: 1}}
+	fields.priceTiers[windows.ID] = []models.PriceTier{{MinQuantity: 3, Price: 90}}
+
+	fields.taskRepoMock.EXPECT().GetTasksByIDs(gomock.Any()).DoAndReturn(storedCatalog(windows, floors))
+	fields.userRepoMock.EXPECT().GetUserByID(gomock.Any()).Return(&models.User{ID: uuid.New()}, nil)
+	fields.orderRepoMock.EXPECT().Create(gomock.Any(), orderedTasks).DoAndReturn(func(order *models.Order, orderedTasks []models.OrderedTask) (*models.Order, error) {
+		order.ID = uuid.New()
+		return order, nil
+	})
+	fields.orderRepoMock.EXPECT().DeleteDraft(gomock.Any()).Return(nil)
+
+	order, err := orderService.CreateOrder(uuid.New(), "address", time.Now().AddDate(0, 0, 1), orderedTasks)
+	assert.NoError(t, err)
+	assert.NotNil(t, order)
+	assert.NotEqual(t, uuid.Nil, order.Order.ID)
+	assert.Equal(t, 320.0, order.Order.QuotedTotal)
+	// the returned tasks are the stored ones at the unit prices of the order, not the caller's
+	tieredWindows := windows
+	tieredWindows.PricePerSingle = 90
+	assert.Equal(t, []models.OrderedTask{{Task: &tieredWindows, Quantity: 3}, {Task: &floors, Quantity: 1}}, order.Tasks)
+	assert.NotSame(t, orderedTasks[0].Task, order.Tasks[0].Task)
+}
+
 var testOrderServiceDelete = []struct {
 	testName  string
 	inputData struct {
@@ -248,16 +424,14 @@ var testOrderServiceDelete = []struct {
 	checkOutput func(t *testing.T, err error)
 }{
 	{
-		testName: "delete order success test",
+		testName: "delete order with tasks",
 		inputData: struct {
 			orderID uuid.UUID
 		}{
 			uuid.New(),
 		},
 		prepare: func(fields *orderServiceFields) {
-			fields.orderRepoMock.EXPECT().GetOrderByID(gomock.Any()).Return(&models.Order{ID: uuid.UUID{}}, nil)
-			fields.orderRepoMock.EXPECT().GetTasksInOrder(gomock.Any()).Return([]models.Task{{ID: uuid.UUID{}}}, nil)
-			fields.orderRepoMock.EXPECT().RemoveTaskFromOrder(gomock.Any(), gomock.Any()).Return(nil)
+			fields.orderRepoMock.EXPECT().RemoveTaskFromOrder(gomock.Any(), gomock.Any()).Times(0)
 			fields.orderRepoMock.EXPECT().Delete(gomock.Any()).Return(nil)
 		},
 		checkOutput: func(t *testing.T, err error) {
@@ -272,7 +446,7 @@ var testOrderServiceDelete = []struct {
 			uuid.New(),
 		},
 		prepare: func(fields *orderServiceFields) {
-			fields.orderRepoMock.EXPECT().GetOrderByID(gomock.Any()).Return(nil, repository_errors.DoesNotExist)
+			fields.orderRepoMock.EXPECT().Delete(gomock.Any()).Return(repository_errors.DoesNotExist)
 		},
 		checkOutput: func(t *testing.T, err error) {
 			assert.Error(t, err)
@@ -287,9 +461,7 @@ var testOrderServiceDelete = []struct {
 			uuid.New(),
 		},
 		prepare: func(fields *orderServiceFields) {
-			fields.orderRepoMock.EXPECT().GetOrderByID(gomock.Any()).Return(&models.Order{ID: uuid.UUID{}}, nil)
-			fields.orderRepoMock.EXPECT().GetTasksInOrder(gomock.Any()).Return([]models.Task{{ID: uuid.UUID{}}}, nil)
-			fields.orderRepoMock.EXPECT().RemoveTaskFromOrder(gomock.Any(), gomock.Any()).Return(nil)
+			fields.orderRepoMock.EXPECT().RemoveTaskFromOrder(gomock.Any(), gomock.Any()).Times(0)
 			fields.orderRepoMock.EXPECT().Delete(gomock.Any()).Return(repository_errors.DeleteError)
 		},
 		checkOutput: func(t *testing.T, err error) {
@@ -315,6 +487,48 @@ func TestOrderService_DeleteOrder(t *testing.T) {
 	}
 }
 
+var testOrderServiceRestore = []struct {
+	testName    string
+	prepare     func(fields *orderServiceFields, orderID uuid.UUID)
+	checkOutput func(t *testing.T, err error)
+}{
+	{
+		testName: "restore deleted order",
+		prepare: func(fields *orderServiceFields, orderID uuid.UUID) {
+			fields.orderRepoMock.EXPECT().Restore(orderID).Return(nil)
+		},
+		checkOutput: func(t *testing.T, err error) {
+			assert.NoError(t, err)
+		},
+	},
+	{
+		testName: "order is not deleted",
+		prepare: func(fields *orderServiceFields, orderID uuid.UUID) {
+			fields.orderRepoMock.EXPECT().Restore(orderID).Return(repository_errors.DoesNotExist)
+		},
+		checkOutput: func(t *testing.T, err error) {
+			assert.Equal(t, repository_errors.DoesNotExist, err)
+		},
+	},
+}
+
+func TestOrderService_RestoreOrder(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	fields := initOrderServiceFields(ctrl)
+	orderService := initOrderService(fields)
+
+	for _, tt := range testOrderServiceRestore {
+		t.Run(tt.testName, func(t *testing.T) {
+			orderID := uuid.New()
+			tt.prepare(fields, orderID)
+			err := orderService.RestoreOrder(orderID)
+			tt.checkOutput(t, err)
+		})
+	}
+}
+
 var testOrderServiceGetTasksInOrder = []struct {
 	testName  string
 	inputData struct {
@@ -542,6 +756,190 @@ func TestOrderService_GetAllOrdersByUserID(t *testing.T) {
 	}
 }
 
+var testOrderServiceGetAllOrdersByWorkerID = []struct {
+	testName  string
+	inputData struct {
+		workerID uuid.UUID
+	}
+	prepare     func(fields *orderServiceFields)
+	checkOutput func(t *testing.T, orders []models.Order, err error)
+}{
+	{
+		testName: "worker with several orders",
+		inputData: struct {
+			workerID uuid.UUID
+		}{
+			uuid.New(),
+		},
+		prepare: func(fields *orderServiceFields) {
+			fields.workerRepoMock.EXPECT().GetWorkerByID(gomock.Any()).Return(&models.Worker{ID: uuid.New()}, nil)
+			fields.orderRepoMock.EXPECT().GetAllOrdersByWorkerID(gomock.Any()).Return([]models.Order{{ID: uuid.New()}, {ID: uuid.New()}, {ID: uuid.New()}}, nil)
+		},
+		checkOutput: func(t *testing.T, orders []models.Order, err error) {
+			assert.NoError(t, err)
+			assert.Len(t, orders, 3)
+		},
+	},
+	{
+		testName: "worker without orders",
+		inputData: struct {
+			workerID uuid.UUID
+		}{
+			uuid.New(),
+		},
+		prepare: func(fields *orderServiceFields) {
+			fields.workerRepoMock.EXPECT().GetWorkerByID(gomock.Any()).Return(&models.Worker{ID: uuid.New()}, nil)
+			fields.orderRepoMock.EXPECT().GetAllOrdersByWorkerID(gomock.Any()).Return(nil, nil)
+		},
+		checkOutput: func(t *testing.T, orders []models.Order, err error) {
+			assert.NoError(t, err)
+			assert.Empty(t, orders)
+		},
+	},
+	{
+		testName: "worker not found",
+		inputData: struct {
+			workerID uuid.UUID
+		}{
+			uuid.New(),
+		},
+		prepare: func(fields *orderServiceFields) {
+			fields.workerRepoMock.EXPECT().GetWorkerByID(gomock.Any()).Return(nil, repository_errors.DoesNotExist)
+		},
+		checkOutput: func(t *testing.T, orders []models.Order, err error) {
+			assert.Equal(t, repository_errors.DoesNotExist, err)
+			assert.Nil(t, orders)
+		},
+	},
+	{
+		testName: "get all orders by worker id error",
+		inputData: struct {
+			workerID uuid.UUID
+		}{
+			uuid.New(),
+		},
+		prepare: func(fields *orderServiceFields) {
+			fields.workerRepoMock.EXPECT().GetWorkerByID(gomock.Any()).Return(&models.Worker{ID: uuid.New()}, nil)
+			fields.orderRepoMock.EXPECT().GetAllOrdersByWorkerID(gomock.Any()).Return(nil, repository_errors.SelectError)
+		},
+		checkOutput: func(t *testing.T, orders []models.Order, err error) {
+			assert.Equal(t, repository_errors.SelectError, err)
+			assert.Nil(t, orders)
+		},
+	},
+}
+
+func TestOrderService_GetAllOrdersByWorkerID(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	fields := initOrderServiceFields(ctrl)
+	orderService := initOrderService(fields)
+
+	for _, tt := range testOrderServiceGetAllOrdersByWorkerID {
+		t.Run(tt.testName, func(t *testing.T) {
+			tt.prepare(fields)
+			orders, err := orderService.GetAllOrdersByWorkerID(tt.inputData.workerID)
+			tt.checkOutput(t, orders, err)
+		})
+	}
+}
+
+var testOrderServiceGetOrdersByUserIDPaged = []struct {
+	testName  string
+	inputData struct {
+		limit  int
+		offset int
+	}
+	prepare     func(fields *orderServiceFields)
+	checkOutput func(t *testing.T, orders []models.Order, total int, err error)
+}{
+	{
+		testName: "last partial page",
+		inputData: struct {
+			limit  int
+			offset int
+		}{10, 10},
+		prepare: func(fields *orderServiceFields) {
+			fields.orderRepoMock.EXPECT().GetOrdersByUserIDPaged(gomock.Any(), 10, 10).Return([]models.Order{{ID: uuid.New()}, {ID: uuid.New()}}, 12, nil)
+		},
+		checkOutput: func(t *testing.T, orders []models.Order, total int, err error) {
+			assert.NoError(t, err)
+			assert.Len(t, orders, 2)
+			assert.Equal(t, 12, total)
+		},
+	},
+	{
+		testName: "offset beyond row count",
+		inputData: struct {
+			limit  int
+			offset int
+		}{10, 20},
+		prepare: func(fields *orderServiceFields) {
+			fields.orderRepoMock.EXPECT().GetOrdersByUserIDPaged(gomock.Any(), 10, 20).Return(nil, 12, nil)
+		},
+		checkOutput: func(t *testing.T, orders []models.Order, total int, err error) {
+			assert.NoError(t, err)
+			assert.Empty(t, orders)
+			assert.Equal(t, 12, total)
+		},
+	},
+	{
+		testName: "zero limit",
+		inputData: struct {
+			limit  int
+			offset int
+		}{0, 0},
+		prepare: func(fields *orderServiceFields) {},
+		checkOutput: func(t *testing.T, orders []models.Order, total int, err error) {
+			assert.Equal(t, fmt.Errorf("SERVICE: Invalid input"), err)
+			assert.Nil(t, orders)
+		},
+	},
+	{
+		testName: "negative offset",
+		inputData: struct {
+			limit  int
+			offset int
+		}{10, -1},
+		prepare: func(fields *orderServiceFields) {},
+		checkOutput: func(t *testing.T, orders []models.Order, total int, err error) {
+			assert.Equal(t, fmt.Errorf("SERVICE: Invalid input"), err)
+			assert.Nil(t, orders)
+		},
+	},
+	{
+		testName: "repository error",
+		inputData: struct {
+			limit  int
+			offset int
+		}{10, 0},
+		prepare: func(fields *orderServiceFields) {
+			fields.orderRepoMock.EXPECT().GetOrdersByUserIDPaged(gomock.Any(), 10, 0).Return(nil, 0, repository_errors.SelectError)
+		},
+		checkOutput: func(t *testing.T, orders []models.Order, total int, err error) {
+			assert.Equal(t, repository_errors.SelectError, err)
+			assert.Nil(t, orders)
+		},
+	},
+}
+
+func TestOrderService_GetOrdersByUserIDPaged(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	fields := initOrderServiceFields(ctrl)
+	orderService := initOrderService(fields)
+
+	for _, tt := range testOrderServiceGetOrdersByUserIDPaged {
+		t.Run(tt.testName, func(t *testing.T) {
+			tt.prepare(fields)
+			orders, total, err := orderService.GetOrdersByUserIDPaged(uuid.New(), tt.inputData.limit, tt.inputData.offset)
+			tt.checkOutput(t, orders, total, err)
+		})
+	}
+}
+
 var testOrderServiceChangeOrderStatus = []struct {
 	testName  string
 	inputData struct {
@@ -691,12 +1089,13 @@ func TestOrderService_ChangeOrderStatus(t *testing.T) {
 	defer ctrl.Finish()
 
 	fields := initOrderServiceFields(ctrl)
+	fields.workerRepoMock.EXPECT().IsAvailable(gomock.Any(), gomock.Any()).Return(true, nil).AnyTimes()
 	orderService := initOrderService(fields)
 
 	for _, tt := range testOrderServiceChangeOrderStatus {
 		t.Run(tt.testName, func(t *testing.T) {
 			tt.prepare(fields)
-			order, err := orderService.Update(tt.inputData.orderID, tt.inputData.status, tt.inputData.rate, tt.inputData.workerID)
+			order, err := orderService.Update(testManager, tt.inputData.orderID, tt.inputData.status, tt.inputData.rate, tt.inputData.workerID)
 			tt.checkOutput(t, order, err)
 		})
 	}
@@ -855,12 +1254,13 @@ func TestOrderService_RateOrder(t *testing.T) {
 	defer ctrl.Finish()
 
 	fields := initOrderServiceFields(ctrl)
+	fields.workerRepoMock.EXPECT().IsAvailable(gomock.Any(), gomock.Any()).Return(true, nil).AnyTimes()
 	orderService := initOrderService(fields)
 
 	for _, tt := range testOrderServiceRateOrder {
 		t.Run(tt.testName, func(t *testing.T) {
 			tt.prepare(fields)
-			order, err := orderService.Update(tt.inputData.orderID, tt.inputData.status, tt.inputData.rate, tt.inputData.workerID)
+			order, err := orderService.Update(testManager, tt.inputData.orderID, tt.inputData.status, tt.inputData.rate, tt.inputData.workerID)
 			tt.checkOutput(t, order, err)
 		})
 	}
@@ -987,12 +1387,13 @@ func TestOrderService_AttachWorkerToOrder(t *testing.T) {
 	defer ctrl.Finish()
 
 	fields := initOrderServiceFields(ctrl)
+	fields.workerRepoMock.EXPECT().IsAvailable(gomock.Any(), gomock.Any()).Return(true, nil).AnyTimes()
 	orderService := initOrderService(fields)
 
 	for _, tt := range testOrderServiceAttachWorkerToOrder {
 		t.Run(tt.testName, func(t *testing.T) {
 			tt.prepare(fields)
-			order, err := orderService.Update(tt.inputData.orderID, tt.inputData.status, tt.inputData.rate, tt.inputData.workerID)
+			order, err := orderService.Update(testManager, tt.inputData.orderID, tt.inputData.status, tt.inputData.rate, tt.inputData.workerID)
 			tt.checkOutput(t, order, err)
 		})
 	}
@@ -1092,12 +1493,13 @@ func TestOrderService_DetachWorkerFromOrder(t *testing.T) {
 	defer ctrl.Finish()
 
 	fields := initOrderServiceFields(ctrl)
+	fields.workerRepoMock.EXPECT().IsAvailable(gomock.Any(), gomock.Any()).Return(true, nil).AnyTimes()
 	orderService := initOrderService(fields)
 
 	for _, tt := range testOrderServiceDetachWorkerFromOrder {
 		t.Run(tt.testName, func(t *testing.T) {
 			tt.prepare(fields)
-			order, err := orderService.Update(tt.inputData.orderID, tt.inputData.status, tt.inputData.rate, tt.inputData.workerID)
+			order, err := orderService.Update(testManager, tt.inputData.orderID, tt.inputData.status, tt.inputData.rate, tt.inputData.workerID)
 			tt.checkOutput(t, order, err)
 		})
 	}
@@ -1162,26 +1564,113 @@ func TestOrderService_GetOrderByID(t *testing.T) {
 	}
 }
 
-var testOrderServiceIncrementTaskQuantity = []struct {
-	testName  string
-	inputData struct {
-		orderID uuid.UUID
-		taskID  uuid.UUID
-	}
-	prepare     func(fields *orderServiceFields)
-	checkOutput func(t *testing.T, quantity int, err error)
+var testOrderServiceAddTask = []struct {
+	testName    string
+	prepare     func(fields *orderServiceFields, orderID uuid.UUID, taskID uuid.UUID)
+	checkOutput func(t *testing.T, err error)
 }{
 	{
-		testName: "increment task quantity success test",
-		inputData: struct {
-			orderID uuid.UUID
+		testName: "task is added with quantity one",
+		prepare: func(fields *orderServiceFields, orderID uuid.UUID, taskID uuid.UUID) {
+			fields.orderRepoMock.EXPECT().GetOrderByID(orderID).Return(&models.Order{ID: orderID}, nil)
+			fields.orderRepoMock.EXPECT().GetTasksInOrder(orderID).Return([]models.Task{{ID: uuid.New()}}, nil)
+			fields.taskRepoMock.EXPECT().GetTaskByID(taskID).Return(&models.Task{ID: taskID}, nil)
+			fields.orderRepoMock.EXPECT().AddTaskToOrder(orderID, taskID, 1).Return(nil)
+			fields.orderRepoMock.EXPECT().RequoteOrder(orderID, models.RoundHalfUp).Return(nil)
+		},
+		checkOutput: func(t *testing.T, err error) {
+			assert.NoError(t, err)
+		},
+	},
+	{
+		testName: "task is already in the order",
+		prepare: func(fields *orderServiceFields, orderID uuid.UUID, taskID uuid.UUID) {
+			fields.orderRepoMock.EXPECT().GetOrderByID(orderID).Return(&models.Order{ID: orderID}, nil)
+			fields.orderRepoMock.EXPECT().GetTasksInOrder(orderID).Return([]models.Task{{ID: taskID}}, nil)
+			fields.taskRepoMock.EXPECT().GetTaskByID(taskID).Return(&models.Task{ID: taskID}, nil)
+			fields.orderRepoMock.EXPECT().AddTaskToOrder(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+		},
+		checkOutput: func(t *testing.T, err error) {
+			assert.ErrorIs(t, err, service_errors.TaskIsAlreadyAttachedToOrder)
+		},
+	},
+	{
+		testName: "tasks of the order cannot be read",
+		prepare: func(fields *orderServiceFields, orderID uuid.UUID, taskID uuid.UUID) {
+			fields.orderRepoMock.EXPECT().GetOrderByID(orderID).Return(&models.Order{ID: orderID}, nil)
+			fields.orderRepoMock.EXPECT().GetTasksInOrder(orderID).Return(nil, repository_errors.SelectError)
+		},
+		checkOutput: func(t *testing.T, err error) {
+			assert.Equal(t, repository_errors.SelectError, err)
+		},
+	},
+}
+
+func TestOrderService_AddTask(t *testing.T) {
+	for _, tt := range testOrderServiceAddTask {
+		t.Run(tt.testName, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			fields := initOrderServiceFields(ctrl)
+			orderService := initOrderService(fields)
+
+			orderID, taskID := uuid.New(), uuid.New()
+			tt.prepare(fields, orderID, taskID)
+			err := orderService.AddTask(orderID, taskID)
+			tt.checkOutput(t, err)
+		})
+	}
+}
+
+func TestOrderService_IncrementAddedTask(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	fields := initOrderServiceFields(ctrl)
+	orderService := initOrderService(fields)
+
+	orderID, taskID := uuid.New(), uuid.New()
+	quantity := 0
+	fields.orderRepoMock.EXPECT().GetOrderByID(orderID).Return(&models.Order{ID: orderID}, nil).Times(2)
+	fields.orderRepoMock.EXPECT().GetTasksInOrder(orderID).Return(nil, nil)
+	fields.taskRepoMock.EXPECT().GetTaskByID(taskID).Return(&models.Task{ID: taskID}, nil).Times(2)
+	fields.orderRepoMock.EXPECT().AddTaskToOrder(orderID, taskID, gomock.Any()).DoAndReturn(func(_ uuid.UUID, _ uuid.UUID, initial int) error {
+		quantity = initial
+		return nil
+	})
+	fields.orderRepoMock.EXPECT().IncrementTaskQuantity(orderID, taskID).DoAndReturn(func(_ uuid.UUID, _ uuid.UUID) (int, error) {
+		quantity++
+		return quantity, nil
+	})
+	fields.orderRepoMock.EXPECT().RequoteOrder(orderID, models.RoundHalfUp).Return(nil).Times(2)
+
+	assert.NoError(t, orderService.AddTask(orderID, taskID))
+	updated, err := orderService.IncrementTaskQuantity(orderID, taskID)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, updated)
+}
+
+var testOrderServiceIncrementTaskQuantity = []struct {
+	testName  string
+	inputData struct {
+		orderID uuid.UUID
+		taskID  uuid.UUID
+	}
+	prepare     func(fields *orderServiceFields)
+	checkOutput func(t *testing.T, quantity int, err error)
+}{
+	{
+		testName: "increment task quantity success test",
+		inputData: struct {
+			orderID uuid.UUID
 			taskID  uuid.UUID
 		}{uuid.New(), uuid.New()},
 		prepare: func(fields *orderServiceFields) {
 			fields.orderRepoMock.EXPECT().GetOrderByID(gomock.Any()).Return(&models.Order{ID: uuid.New(), Status: models.NewOrderStatus}, nil)
 			fields.taskRepoMock.EXPECT().GetTaskByID(gomock.Any()).Return(&models.Task{ID: uuid.New()}, nil)
-			fields.orderRepoMock.EXPECT().GetTaskQuantity(gomock.Any(), gomock.Any()).Return(1, nil)
-			fields.orderRepoMock.EXPECT().UpdateTaskQuantity(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+			fields.orderRepoMock.EXPECT().IncrementTaskQuantity(gomock.Any(), gomock.Any()).Return(2, nil)
+			fields.orderRepoMock.EXPECT().RequoteOrder(gomock.Any(), models.RoundHalfUp).Return(nil)
 		},
 		checkOutput: func(t *testing.T, quantity int, err error) {
 			assert.NoError(t, err)
@@ -1220,7 +1709,7 @@ var testOrderServiceIncrementTaskQuantity = []struct {
 		},
 	},
 	{
-		testName: "increment task quantity error",
+		testName: "task is not in the order",
 		inputData: struct {
 			orderID uuid.UUID
 			taskID  uuid.UUID
@@ -1228,11 +1717,11 @@ var testOrderServiceIncrementTaskQuantity = []struct {
 		prepare: func(fields *orderServiceFields) {
 			fields.orderRepoMock.EXPECT().GetOrderByID(gomock.Any()).Return(&models.Order{ID: uuid.New(), Status: models.NewOrderStatus}, nil)
 			fields.taskRepoMock.EXPECT().GetTaskByID(gomock.Any()).Return(&models.Task{ID: uuid.New()}, nil)
-			fields.orderRepoMock.EXPECT().GetTaskQuantity(gomock.Any(), gomock.Any()).Return(0, repository_errors.SelectError)
+			fields.orderRepoMock.EXPECT().IncrementTaskQuantity(gomock.Any(), gomock.Any()).Return(0, repository_errors.DoesNotExist)
 		},
 		checkOutput: func(t *testing.T, quantity int, err error) {
 			assert.Error(t, err)
-			assert.Equal(t, repository_errors.SelectError, err)
+			assert.Equal(t, repository_errors.DoesNotExist, err)
 			assert.Equal(t, 0, quantity)
 		},
 	},
@@ -1245,8 +1734,7 @@ var testOrderServiceIncrementTaskQuantity = []struct {
 		prepare: func(fields *orderServiceFields) {
 			fields.orderRepoMock.EXPECT().GetOrderByID(gomock.Any()).Return(&models.Order{ID: uuid.New(), Status: models.NewOrderStatus}, nil)
 			fields.taskRepoMock.EXPECT().GetTaskByID(gomock.Any()).Return(&models.Task{ID: uuid.New()}, nil)
-			fields.orderRepoMock.EXPECT().GetTaskQuantity(gomock.Any(), gomock.Any()).Return(1, nil)
-			fields.orderRepoMock.EXPECT().UpdateTaskQuantity(gomock.Any(), gomock.Any(), gomock.Any()).Return(repository_errors.UpdateError)
+			fields.orderRepoMock.EXPECT().IncrementTaskQuantity(gomock.Any(), gomock.Any()).Return(0, repository_errors.UpdateError)
 		},
 		checkOutput: func(t *testing.T, quantity int, err error) {
 			assert.Error(t, err)
@@ -1272,6 +1760,55 @@ func TestOrderService_IncrementTaskQuantity(t *testing.T) {
 	}
 }
 
+func TestOrderService_ConcurrentIncrementTaskQuantity(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	fields := initOrderServiceFields(ctrl)
+	orderService := initOrderService(fields)
+
+	const workers = 50
+	orderID, taskID := uuid.New(), uuid.New()
+
+	// the repository increments atomically, the service must not read and write back
+	var mu sync.Mutex
+	quantity := 1
+	fields.orderRepoMock.EXPECT().GetOrderByID(orderID).Return(&models.Order{ID: orderID}, nil).Times(workers)
+	fields.taskRepoMock.EXPECT().GetTaskByID(taskID).Return(&models.Task{ID: taskID}, nil).Times(workers)
+	fields.orderRepoMock.EXPECT().GetTaskQuantity(gomock.Any(), gomock.Any()).Times(0)
+	fields.orderRepoMock.EXPECT().UpdateTaskQuantity(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+	fields.orderRepoMock.EXPECT().IncrementTaskQuantity(orderID, taskID).DoAndReturn(func(_ uuid.UUID, _ uuid.UUID) (int, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		quantity++
+		return quantity, nil
+	}).Times(workers)
+	fields.orderRepoMock.EXPECT().RequoteOrder(orderID, models.RoundHalfUp).Return(nil).Times(workers)
+
+	var wg sync.WaitGroup
+	results := make(chan int, workers)
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			updated, err := orderService.IncrementTaskQuantity(orderID, taskID)
+			assert.NoError(t, err)
+			results <- updated
+		}()
+	}
+	wg.Wait()
+	close(results)
+
+	// every caller sees a distinct quantity, so no increment was lost
+	seen := make(map[int]bool, workers)
+	for updated := range results {
+		assert.False(t, seen[updated])
+		seen[updated] = true
+	}
+	assert.Len(t, seen, workers)
+	assert.Equal(t, 1+workers, quantity)
+}
+
 var testOrderServiceDecrementTaskQuantity = []struct {
 	testName  string
 	inputData struct {
@@ -1290,8 +1827,8 @@ var testOrderServiceDecrementTaskQuantity = []struct {
 		prepare: func(fields *orderServiceFields) {
 			fields.orderRepoMock.EXPECT().GetOrderByID(gomock.Any()).Return(&models.Order{ID: uuid.New(), Status: models.NewOrderStatus}, nil)
 			fields.taskRepoMock.EXPECT().GetTaskByID(gomock.Any()).Return(&models.Task{ID: uuid.New()}, nil)
-			fields.orderRepoMock.EXPECT().GetTaskQuantity(gomock.Any(), gomock.Any()).Return(2, nil)
-			fields.orderRepoMock.EXPECT().UpdateTaskQuantity(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+			fields.orderRepoMock.EXPECT().DecrementTaskQuantity(gomock.Any(), gomock.Any()).Return(1, nil)
+			fields.orderRepoMock.EXPECT().RequoteOrder(gomock.Any(), models.RoundHalfUp).Return(nil)
 		},
 		checkOutput: func(t *testing.T, quantity int, err error) {
 			assert.NoError(t, err)
@@ -1338,6 +1875,7 @@ var testOrderServiceDecrementTaskQuantity = []struct {
 		prepare: func(fields *orderServiceFields) {
 			fields.orderRepoMock.EXPECT().GetOrderByID(gomock.Any()).Return(&models.Order{ID: uuid.New(), Status: models.NewOrderStatus}, nil)
 			fields.taskRepoMock.EXPECT().GetTaskByID(gomock.Any()).Return(&models.Task{ID: uuid.New()}, nil)
+			fields.orderRepoMock.EXPECT().DecrementTaskQuantity(gomock.Any(), gomock.Any()).Return(0, repository_errors.DoesNotExist)
 			fields.orderRepoMock.EXPECT().GetTaskQuantity(gomock.Any(), gomock.Any()).Return(0, nil)
 		},
 		checkOutput: func(t *testing.T, quantity int, err error) {
@@ -1346,6 +1884,24 @@ var testOrderServiceDecrementTaskQuantity = []struct {
 			assert.Equal(t, 0, quantity)
 		},
 	},
+	{
+		testName: "task is not in the order",
+		inputData: struct {
+			orderID uuid.UUID
+			taskID  uuid.UUID
+		}{uuid.New(), uuid.New()},
+		prepare: func(fields *orderServiceFields) {
+			fields.orderRepoMock.EXPECT().GetOrderByID(gomock.Any()).Return(&models.Order{ID: uuid.New(), Status: models.NewOrderStatus}, nil)
+			fields.taskRepoMock.EXPECT().GetTaskByID(gomock.Any()).Return(&models.Task{ID: uuid.New()}, nil)
+			fields.orderRepoMock.EXPECT().DecrementTaskQuantity(gomock.Any(), gomock.Any()).Return(0, repository_errors.DoesNotExist)
+			fields.orderRepoMock.EXPECT().GetTaskQuantity(gomock.Any(), gomock.Any()).Return(0, repository_errors.DoesNotExist)
+		},
+		checkOutput: func(t *testing.T, quantity int, err error) {
+			assert.Error(t, err)
+			assert.Equal(t, repository_errors.DoesNotExist, err)
+			assert.Equal(t, 0, quantity)
+		},
+	},
 	{
 		testName: "decrement task quantity error",
 		inputData: struct {
@@ -1355,8 +1911,7 @@ var testOrderServiceDecrementTaskQuantity = []struct {
 		prepare: func(fields *orderServiceFields) {
 			fields.orderRepoMock.EXPECT().GetOrderByID(gomock.Any()).Return(&models.Order{ID: uuid.New(), Status: models.NewOrderStatus}, nil)
 			fields.taskRepoMock.EXPECT().GetTaskByID(gomock.Any()).Return(&models.Task{ID: uuid.New()}, nil)
-			fields.orderRepoMock.EXPECT().GetTaskQuantity(gomock.Any(), gomock.Any()).Return(1, nil)
-			fields.orderRepoMock.EXPECT().UpdateTaskQuantity(gomock.Any(), gomock.Any(), gomock.Any()).Return(repository_errors.UpdateError)
+			fields.orderRepoMock.EXPECT().DecrementTaskQuantity(gomock.Any(), gomock.Any()).Return(0, repository_errors.UpdateError)
 		},
 		checkOutput: func(t *testing.T, quantity int, err error) {
 			assert.Error(t, err)
@@ -1403,6 +1958,7 @@ var testOrderSetTaskQuantity = []struct {
 			fields.orderRepoMock.EXPECT().GetOrderByID(gomock.Any()).Return(&models.Order{ID: uuid.New(), Status: models.NewOrderStatus}, nil)
 			fields.taskRepoMock.EXPECT().GetTaskByID(gomock.Any()).Return(&models.Task{ID: uuid.New()}, nil)
 			fields.orderRepoMock.EXPECT().UpdateTaskQuantity(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+			fields.orderRepoMock.EXPECT().RequoteOrder(gomock.Any(), models.RoundHalfUp).Return(nil)
 		},
 		checkOutput: func(t *testing.T, err error) {
 			assert.NoError(t, err)
@@ -1449,7 +2005,7 @@ var testOrderSetTaskQuantity = []struct {
 		prepare: func(fields *orderServiceFields) {},
 		checkOutput: func(t *testing.T, err error) {
 			assert.Error(t, err)
-			assert.Equal(t, fmt.Errorf("SERVICE: Quantity is negative"), err)
+			assert.ErrorIs(t, err, service_errors.NegativeQuantity)
 		},
 	},
 	{
@@ -1577,3 +2133,3512 @@ func TestOrderService_GetTaskQuantity(t *testing.T) {
 		})
 	}
 }
+
+var testOrderServiceGetOrdersByIDs = []struct {
+	testName  string
+	inputData struct {
+		ids []uuid.UUID
+	}
+	prepare     func(fields *orderServiceFields)
+	checkOutput func(t *testing.T, orders []models.Order, err error)
+}{
+	{
+		testName: "mixed batch of present and absent ids",
+		inputData: struct {
+			ids []uuid.UUID
+		}{[]uuid.UUID{uuid.New(), uuid.New(), uuid.New()}},
+		prepare: func(fields *orderServiceFields) {
+			fields.orderRepoMock.EXPECT().GetOrdersByIDs(gomock.Len(3)).Return([]models.Order{{ID: uuid.New()}, {ID: uuid.New()}}, nil)
+		},
+		checkOutput: func(t *testing.T, orders []models.Order, err error) {
+			assert.NoError(t, err)
+			assert.Len(t, orders, 2)
+		},
+	},
+	{
+		testName: "empty input",
+		inputData: struct {
+			ids []uuid.UUID
+		}{[]uuid.UUID{}},
+		prepare: func(fields *orderServiceFields) {},
+		checkOutput: func(t *testing.T, orders []models.Order, err error) {
+			assert.NoError(t, err)
+			assert.Empty(t, orders)
+		},
+	},
+	{
+		testName: "get orders by ids error",
+		inputData: struct {
+			ids []uuid.UUID
+		}{[]uuid.UUID{uuid.New()}},
+		prepare: func(fields *orderServiceFields) {
+			fields.orderRepoMock.EXPECT().GetOrdersByIDs(gomock.Any()).Return(nil, repository_errors.SelectError)
+		},
+		checkOutput: func(t *testing.T, orders []models.Order, err error) {
+			assert.Error(t, err)
+			assert.Nil(t, orders)
+			assert.Equal(t, repository_errors.SelectError, err)
+		},
+	},
+}
+
+func TestOrderService_GetOrdersByIDs(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	fields := initOrderServiceFields(ctrl)
+	orderService := initOrderService(fields)
+
+	for _, tt := range testOrderServiceGetOrdersByIDs {
+		t.Run(tt.testName, func(t *testing.T) {
+			tt.prepare(fields)
+			orders, err := orderService.GetOrdersByIDs(tt.inputData.ids)
+			tt.checkOutput(t, orders, err)
+		})
+	}
+}
+
+var assignedWorkerID = uuid.New()
+
+var testOrderServiceTransitionTimestamps = []struct {
+	testName  string
+	inputData struct {
+		current  *models.Order
+		status   int
+		workerID uuid.UUID
+	}
+	checkOutput func(t *testing.T, order *models.Order, err error)
+}{
+	{
+		testName: "assigned at is set on assignment",
+		inputData: struct {
+			current  *models.Order
+			status   int
+			workerID uuid.UUID
+		}{
+			&models.Order{ID: uuid.New(), Status: models.NewOrderStatus},
+			models.InProgressOrderStatus,
+			assignedWorkerID,
+		},
+		checkOutput: func(t *testing.T, order *models.Order, err error) {
+			assert.NoError(t, err)
+			assert.NotNil(t, order.AssignedAt)
+			assert.Nil(t, order.CompletedAt)
+			assert.Nil(t, order.CancelledAt)
+		},
+	},
+	{
+		testName: "completed at is set on completion",
+		inputData: struct {
+			current  *models.Order
+			status   int
+			workerID uuid.UUID
+		}{
+			&models.Order{ID: uuid.New(), Status: models.InProgressOrderStatus, WorkerID: assignedWorkerID},
+			models.CompletedOrderStatus,
+			assignedWorkerID,
+		},
+		checkOutput: func(t *testing.T, order *models.Order, err error) {
+			assert.NoError(t, err)
+			assert.Nil(t, order.AssignedAt)
+			assert.NotNil(t, order.CompletedAt)
+			assert.Nil(t, order.CancelledAt)
+		},
+	},
+	{
+		testName: "cancelled at is set on cancellation",
+		inputData: struct {
+			current  *models.Order
+			status   int
+			workerID uuid.UUID
+		}{
+			&models.Order{ID: uuid.New(), Status: models.NewOrderStatus},
+			models.CancelledOrderStatus,
+			uuid.Nil,
+		},
+		checkOutput: func(t *testing.T, order *models.Order, err error) {
+			assert.NoError(t, err)
+			assert.Nil(t, order.AssignedAt)
+			assert.Nil(t, order.CompletedAt)
+			assert.NotNil(t, order.CancelledAt)
+		},
+	},
+	{
+		testName: "no timestamps on unassignment",
+		inputData: struct {
+			current  *models.Order
+			status   int
+			workerID uuid.UUID
+		}{
+			&models.Order{ID: uuid.New(), Status: models.InProgressOrderStatus, WorkerID: assignedWorkerID},
+			models.NewOrderStatus,
+			uuid.Nil,
+		},
+		checkOutput: func(t *testing.T, order *models.Order, err error) {
+			assert.NoError(t, err)
+			assert.Nil(t, order.AssignedAt)
+			assert.Nil(t, order.CompletedAt)
+			assert.Nil(t, order.CancelledAt)
+		},
+	},
+}
+
+func TestOrderService_TransitionTimestamps(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	fields := initOrderServiceFields(ctrl)
+	fields.workerRepoMock.EXPECT().IsAvailable(gomock.Any(), gomock.Any()).Return(true, nil).AnyTimes()
+	orderService := initOrderService(fields)
+
+	for _, tt := range testOrderServiceTransitionTimestamps {
+		t.Run(tt.testName, func(t *testing.T) {
+			fields.orderRepoMock.EXPECT().GetOrderByID(gomock.Any()).Return(tt.inputData.current, nil)
+			if tt.inputData.workerID != uuid.Nil {
+				fields.workerRepoMock.EXPECT().GetWorkerByID(gomock.Any()).Return(&models.Worker{ID: tt.inputData.workerID}, nil)
+			}
+			if tt.inputData.status == models.CompletedOrderStatus {
+				fields.orderRepoMock.EXPECT().GetTasksInOrder(gomock.Any()).Return([]models.Task{{ID: uuid.New(), PricePerSingle: 100}}, nil)
+				fields.orderRepoMock.EXPECT().GetTaskQuantity(gomock.Any(), gomock.Any()).Return(1, nil)
+				fields.orderRepoMock.EXPECT().GetOrderTotalPrice(gomock.Any()).Return(100.0, nil)
+			}
+			fields.orderRepoMock.EXPECT().Update(gomock.Any()).DoAndReturn(func(order *models.Order) (*models.Order, error) {
+				return order, nil
+			})
+
+			order, err := orderService.Update(testManager, tt.inputData.current.ID, tt.inputData.status, 0, tt.inputData.workerID)
+			tt.checkOutput(t, order, err)
+		})
+	}
+}
+
+var testOrderServiceAssignWithCapacity = []struct {
+	testName     string
+	activeOrders int
+	checkOutput  func(t *testing.T, order *models.Order, err error)
+}{
+	{
+		testName:     "worker under capacity is assigned",
+		activeOrders: 1,
+		checkOutput: func(t *testing.T, order *models.Order, err error) {
+			assert.NoError(t, err)
+			assert.NotNil(t, order)
+		},
+	},
+	{
+		testName:     "worker at capacity is refused",
+		activeOrders: 2,
+		checkOutput: func(t *testing.T, order *models.Order, err error) {
+			assert.Error(t, err)
+			assert.Nil(t, order)
+			assert.Equal(t, service_errors.WorkerAtCapacity, err)
+		},
+	},
+	{
+		testName:     "worker over capacity is refused",
+		activeOrders: 3,
+		checkOutput: func(t *testing.T, order *models.Order, err error) {
+			assert.Error(t, err)
+			assert.Nil(t, order)
+			assert.Equal(t, service_errors.WorkerAtCapacity, err)
+		},
+	},
+}
+
+func TestOrderService_AssignWithCapacity(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	fields := initOrderServiceFields(ctrl)
+	fields.workerRepoMock.EXPECT().IsAvailable(gomock.Any(), gomock.Any()).Return(true, nil).AnyTimes()
+	orderService := services.NewOrderService(fields.orderRepoMock, fields.workerRepoMock, fields.taskRepoMock, fields.userRepoMock, fields.logger, 2, models.TaxSettings{}, models.RoundHalfUp, models.DeadlineSettings{}, nil, nil)
+
+	for _, tt := range testOrderServiceAssignWithCapacity {
+		t.Run(tt.testName, func(t *testing.T) {
+			workerID := uuid.New()
+			fields.orderRepoMock.EXPECT().GetOrderByID(gomock.Any()).Return(&models.Order{ID: uuid.New(), Status: models.NewOrderStatus}, nil)
+			fields.workerRepoMock.EXPECT().GetWorkerByID(workerID).Return(&models.Worker{ID: workerID, Role: models.MasterRole}, nil)
+			fields.workerRepoMock.EXPECT().GetActiveOrdersCount(workerID).Return(tt.activeOrders, nil)
+			fields.orderRepoMock.EXPECT().Update(gomock.Any()).DoAndReturn(func(order *models.Order) (*models.Order, error) {
+				return order, nil
+			}).MaxTimes(1)
+
+			order, err := orderService.Update(testManager, uuid.New(), models.NewOrderStatus, 0, workerID)
+			tt.checkOutput(t, order, err)
+		})
+	}
+
+	t.Run("capacity applies only to masters", func(t *testing.T) {
+		workerID := uuid.New()
+		fields.orderRepoMock.EXPECT().GetOrderByID(gomock.Any()).Return(&models.Order{ID: uuid.New(), Status: models.NewOrderStatus}, nil)
+		fields.workerRepoMock.EXPECT().GetWorkerByID(workerID).Return(&models.Worker{ID: workerID, Role: models.ManagerRole}, nil)
+		fields.workerRepoMock.EXPECT().GetActiveOrdersCount(workerID).Times(0)
+		fields.orderRepoMock.EXPECT().Update(gomock.Any()).DoAndReturn(func(order *models.Order) (*models.Order, error) {
+			return order, nil
+		}).MaxTimes(1)
+
+		order, err := orderService.Update(testManager, uuid.New(), models.NewOrderStatus, 0, workerID)
+		assert.NoError(t, err)
+		assert.NotNil(t, order)
+	})
+}
+
+var testOrderServiceUpdateWorkerChange = []struct {
+	testName    string
+	editor      *models.Worker
+	available   bool
+	checkOutput func(t *testing.T, order *models.Order, err error)
+}{
+	{
+		testName:  "manager changes the worker",
+		editor:    testManager,
+		available: true,
+		checkOutput: func(t *testing.T, order *models.Order, err error) {
+			assert.NoError(t, err)
+			assert.NotNil(t, order)
+		},
+	},
+	{
+		testName:  "master cannot change the worker",
+		editor:    &models.Worker{ID: uuid.New(), Role: models.MasterRole},
+		available: true,
+		checkOutput: func(t *testing.T, order *models.Order, err error) {
+			assert.Equal(t, service_errors.InvalidRole, err)
+			assert.Nil(t, order)
+		},
+	},
+	{
+		testName:  "customer cannot change the worker",
+		editor:    nil,
+		available: true,
+		checkOutput: func(t *testing.T, order *models.Order, err error) {
+			assert.Equal(t, service_errors.InvalidRole, err)
+			assert.Nil(t, order)
+		},
+	},
+	{
+		testName:  "worker unavailable at the deadline",
+		editor:    testManager,
+		available: false,
+		checkOutput: func(t *testing.T, order *models.Order, err error) {
+			assert.Equal(t, service_errors.WorkerUnavailable, err)
+			assert.Nil(t, order)
+		},
+	},
+}
+
+func TestOrderService_UpdateWorkerChange(t *testing.T) {
+	for _, tt := range testOrderServiceUpdateWorkerChange {
+		t.Run(tt.testName, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			fields := initOrderServiceFields(ctrl)
+			orderService := initOrderService(fields)
+
+			order := models.Order{ID: uuid.New(), Status: models.NewOrderStatus, Deadline: time.Now().AddDate(0, 0, 3)}
+			workerID := uuid.New()
+			fields.orderRepoMock.EXPECT().GetOrderByID(order.ID).Return(&order, nil)
+			fields.workerRepoMock.EXPECT().GetWorkerByID(workerID).Return(&models.Worker{ID: workerID, Role: models.MasterRole}, nil).MaxTimes(1)
+			fields.workerRepoMock.EXPECT().IsAvailable(workerID, order.Deadline).Return(tt.available, nil).MaxTimes(1)
+			fields.workerRepoMock.EXPECT().GetActiveOrdersCount(workerID).Return(0, nil).MaxTimes(1)
+			fields.orderRepoMock.EXPECT().Update(gomock.Any()).DoAndReturn(func(order *models.Order) (*models.Order, error) {
+				return order, nil
+			}).MaxTimes(1)
+
+			updated, err := orderService.Update(tt.editor, order.ID, models.InProgressOrderStatus, 0, workerID)
+			tt.checkOutput(t, updated, err)
+		})
+	}
+}
+
+var filterFrom = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+var filterTo = time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC)
+
+var testOrderServiceFilterOrders = []struct {
+	testName  string
+	inputData struct {
+		statuses []int
+		from     time.Time
+		to       time.Time
+	}
+	prepare     func(fields *orderServiceFields)
+	checkOutput func(t *testing.T, orders []models.Order, err error)
+}{
+	{
+		testName: "statuses and dates provided",
+		inputData: struct {
+			statuses []int
+			from     time.Time
+			to       time.Time
+		}{[]int{models.NewOrderStatus, models.InProgressOrderStatus}, filterFrom, filterTo},
+		prepare: func(fields *orderServiceFields) {
+			fields.orderRepoMock.EXPECT().FilterByStatusAndDate(uuid.Nil, []int{models.NewOrderStatus, models.InProgressOrderStatus}, filterFrom, filterTo).Return([]models.Order{{ID: uuid.New()}}, nil)
+		},
+		checkOutput: func(t *testing.T, orders []models.Order, err error) {
+			assert.NoError(t, err)
+			assert.Len(t, orders, 1)
+		},
+	},
+	{
+		testName: "only statuses provided",
+		inputData: struct {
+			statuses []int
+			from     time.Time
+			to       time.Time
+		}{[]int{models.CompletedOrderStatus}, time.Time{}, time.Time{}},
+		prepare: func(fields *orderServiceFields) {
+			fields.orderRepoMock.EXPECT().FilterByStatusAndDate(uuid.Nil, []int{models.CompletedOrderStatus}, time.Time{}, time.Time{}).Return([]models.Order{{ID: uuid.New()}, {ID: uuid.New()}}, nil)
+		},
+		checkOutput: func(t *testing.T, orders []models.Order, err error) {
+			assert.NoError(t, err)
+			assert.Len(t, orders, 2)
+		},
+	},
+	{
+		testName: "only dates provided",
+		inputData: struct {
+			statuses []int
+			from     time.Time
+			to       time.Time
+		}{nil, filterFrom, filterTo},
+		prepare: func(fields *orderServiceFields) {
+			fields.orderRepoMock.EXPECT().FilterByStatusAndDate(uuid.Nil, gomock.Len(0), filterFrom, filterTo).Return([]models.Order{{ID: uuid.New()}}, nil)
+		},
+		checkOutput: func(t *testing.T, orders []models.Order, err error) {
+			assert.NoError(t, err)
+			assert.Len(t, orders, 1)
+		},
+	},
+	{
+		testName: "no filters provided",
+		inputData: struct {
+			statuses []int
+			from     time.Time
+			to       time.Time
+		}{[]int{}, time.Time{}, time.Time{}},
+		prepare: func(fields *orderServiceFields) {
+			fields.orderRepoMock.EXPECT().FilterByStatusAndDate(uuid.Nil, gomock.Len(0), time.Time{}, time.Time{}).Return([]models.Order{{ID: uuid.New()}, {ID: uuid.New()}, {ID: uuid.New()}}, nil)
+		},
+		checkOutput: func(t *testing.T, orders []models.Order, err error) {
+			assert.NoError(t, err)
+			assert.Len(t, orders, 3)
+		},
+	},
+	{
+		testName: "unknown status",
+		inputData: struct {
+			statuses []int
+			from     time.Time
+			to       time.Time
+		}{[]int{42}, time.Time{}, time.Time{}},
+		prepare: func(fields *orderServiceFields) {},
+		checkOutput: func(t *testing.T, orders []models.Order, err error) {
+			assert.Error(t, err)
+			assert.Nil(t, orders)
+		},
+	},
+	{
+		testName: "end of period before start",
+		inputData: struct {
+			statuses []int
+			from     time.Time
+			to       time.Time
+		}{nil, filterTo, filterFrom},
+		prepare: func(fields *orderServiceFields) {},
+		checkOutput: func(t *testing.T, orders []models.Order, err error) {
+			assert.Error(t, err)
+			assert.Nil(t, orders)
+		},
+	},
+	{
+		testName: "filter error",
+		inputData: struct {
+			statuses []int
+			from     time.Time
+			to       time.Time
+		}{nil, time.Time{}, time.Time{}},
+		prepare: func(fields *orderServiceFields) {
+			fields.orderRepoMock.EXPECT().FilterByStatusAndDate(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, repository_errors.SelectError)
+		},
+		checkOutput: func(t *testing.T, orders []models.Order, err error) {
+			assert.Error(t, err)
+			assert.Nil(t, orders)
+			assert.Equal(t, repository_errors.SelectError, err)
+		},
+	},
+}
+
+func TestOrderService_FilterOrders(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	fields := initOrderServiceFields(ctrl)
+	orderService := initOrderService(fields)
+
+	for _, tt := range testOrderServiceFilterOrders {
+		t.Run(tt.testName, func(t *testing.T) {
+			tt.prepare(fields)
+			orders, err := orderService.FilterOrders(tt.inputData.statuses, tt.inputData.from, tt.inputData.to)
+			tt.checkOutput(t, orders, err)
+		})
+	}
+}
+
+var testOrderServiceGetOrdersByStatus = []struct {
+	testName    string
+	statuses    []models.OrderStatus
+	prepare     func(fields *orderServiceFields)
+	checkOutput func(t *testing.T, orders []models.Order, err error)
+}{
+	{
+		testName: "single status",
+		statuses: []models.OrderStatus{models.CompletedOrderStatus},
+		prepare: func(fields *orderServiceFields) {
+			fields.orderRepoMock.EXPECT().FilterByStatusAndDate(uuid.Nil, []int{models.CompletedOrderStatus}, time.Time{}, time.Time{}).Return([]models.Order{{Status: models.CompletedOrderStatus}}, nil)
+		},
+		checkOutput: func(t *testing.T, orders []models.Order, err error) {
+			assert.NoError(t, err)
+			assert.Len(t, orders, 1)
+		},
+	},
+	{
+		testName: "multiple statuses",
+		statuses: []models.OrderStatus{models.NewOrderStatus, models.InProgressOrderStatus},
+		prepare: func(fields *orderServiceFields) {
+			fields.orderRepoMock.EXPECT().FilterByStatusAndDate(uuid.Nil, []int{models.NewOrderStatus, models.InProgressOrderStatus}, time.Time{}, time.Time{}).Return([]models.Order{{Status: models.NewOrderStatus}, {Status: models.InProgressOrderStatus}}, nil)
+		},
+		checkOutput: func(t *testing.T, orders []models.Order, err error) {
+			assert.NoError(t, err)
+			assert.Len(t, orders, 2)
+		},
+	},
+	{
+		testName: "no statuses",
+		statuses: nil,
+		prepare: func(fields *orderServiceFields) {
+			fields.orderRepoMock.EXPECT().FilterByStatusAndDate(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+		},
+		checkOutput: func(t *testing.T, orders []models.Order, err error) {
+			assert.Error(t, err)
+			assert.Nil(t, orders)
+		},
+	},
+	{
+		testName: "unknown status",
+		statuses: []models.OrderStatus{models.NewOrderStatus, 42},
+		prepare: func(fields *orderServiceFields) {
+			fields.orderRepoMock.EXPECT().FilterByStatusAndDate(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+		},
+		checkOutput: func(t *testing.T, orders []models.Order, err error) {
+			assert.Error(t, err)
+			assert.Nil(t, orders)
+		},
+	},
+}
+
+func TestOrderService_GetOrdersByStatus(t *testing.T) {
+	for _, tt := range testOrderServiceGetOrdersByStatus {
+		t.Run(tt.testName, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			fields := initOrderServiceFields(ctrl)
+			orderService := initOrderService(fields)
+
+			tt.prepare(fields)
+			orders, err := orderService.GetOrdersByStatus(tt.statuses...)
+			tt.checkOutput(t, orders, err)
+		})
+	}
+}
+
+func TestOrderService_GetUserOrdersByStatus(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	fields := initOrderServiceFields(ctrl)
+	orderService := initOrderService(fields)
+
+	userID := uuid.New()
+	fields.orderRepoMock.EXPECT().FilterByStatusAndDate(userID, []int{models.CompletedOrderStatus, models.CancelledOrderStatus}, time.Time{}, time.Time{}).Return([]models.Order{{UserID: userID, Status: models.CompletedOrderStatus}}, nil)
+
+	orders, err := orderService.GetUserOrdersByStatus(userID, models.CompletedOrderStatus, models.CancelledOrderStatus)
+	assert.NoError(t, err)
+	assert.Len(t, orders, 1)
+}
+
+var firstMasterID = uuid.New()
+var secondMasterID = uuid.New()
+
+func unassignedOrdersWithDeadlines(days ...int) []models.Order {
+	orders := make([]models.Order, len(days))
+	for i, day := range days {
+		orders[i] = models.Order{
+			ID:       uuid.New(),
+			Status:   models.NewOrderStatus,
+			Deadline: time.Date(2024, 1, day, 0, 0, 0, 0, time.UTC),
+		}
+	}
+	return orders
+}
+
+var testOrderServiceAutoAssignUnassigned = []struct {
+	testName    string
+	orders      []models.Order
+	load        map[uuid.UUID]int
+	unavailable map[uuid.UUID]int // Day of January on which a master is unavailable
+	checkOutput func(t *testing.T, orders []models.Order, assignments map[uuid.UUID]uuid.UUID, err error)
+}{
+	{
+		testName: "orders are spread across least loaded workers",
+		orders:   unassignedOrdersWithDeadlines(1, 2, 3),
+		load:     map[uuid.UUID]int{firstMasterID: 1, secondMasterID: 0},
+		checkOutput: func(t *testing.T, orders []models.Order, assignments map[uuid.UUID]uuid.UUID, err error) {
+			assert.NoError(t, err)
+			assert.Len(t, assignments, 3)
+			assert.Equal(t, secondMasterID, assignments[orders[0].ID])
+			assert.Equal(t, firstMasterID, assignments[orders[1].ID])
+			assert.Equal(t, secondMasterID, assignments[orders[2].ID])
+		},
+	},
+	{
+		testName: "orders are left unassigned when capacity is exhausted",
+		orders:   unassignedOrdersWithDeadlines(1, 2, 3),
+		load:     map[uuid.UUID]int{firstMasterID: 2, secondMasterID: 1},
+		checkOutput: func(t *testing.T, orders []models.Order, assignments map[uuid.UUID]uuid.UUID, err error) {
+			assert.NoError(t, err)
+			assert.Len(t, assignments, 1)
+			assert.Equal(t, secondMasterID, assignments[orders[0].ID])
+			assert.NotContains(t, assignments, orders[1].ID)
+			assert.NotContains(t, assignments, orders[2].ID)
+		},
+	},
+	{
+		testName:    "unavailable master is skipped for that order only",
+		orders:      unassignedOrdersWithDeadlines(1, 2),
+		load:        map[uuid.UUID]int{firstMasterID: 0, secondMasterID: 0},
+		unavailable: map[uuid.UUID]int{firstMasterID: 1},
+		checkOutput: func(t *testing.T, orders []models.Order, assignments map[uuid.UUID]uuid.UUID, err error) {
+			assert.NoError(t, err)
+			assert.Len(t, assignments, 2)
+			assert.Equal(t, secondMasterID, assignments[orders[0].ID])
+			assert.Equal(t, firstMasterID, assignments[orders[1].ID])
+		},
+	},
+	{
+		testName:    "order is left unassigned when every master is unavailable",
+		orders:      unassignedOrdersWithDeadlines(1),
+		load:        map[uuid.UUID]int{firstMasterID: 0, secondMasterID: 1},
+		unavailable: map[uuid.UUID]int{firstMasterID: 1, secondMasterID: 1},
+		checkOutput: func(t *testing.T, orders []models.Order, assignments map[uuid.UUID]uuid.UUID, err error) {
+			assert.NoError(t, err)
+			assert.Empty(t, assignments)
+		},
+	},
+	{
+		testName: "no unassigned orders",
+		orders:   []models.Order{},
+		load:     map[uuid.UUID]int{firstMasterID: 0, secondMasterID: 0},
+		checkOutput: func(t *testing.T, orders []models.Order, assignments map[uuid.UUID]uuid.UUID, err error) {
+			assert.NoError(t, err)
+			assert.Empty(t, assignments)
+		},
+	},
+}
+
+func TestOrderService_AutoAssignUnassigned(t *testing.T) {
+	for _, tt := range testOrderServiceAutoAssignUnassigned {
+		t.Run(tt.testName, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			fields := initOrderServiceFields(ctrl)
+			recorder := newRecordingOrderNotifier()
+			orderService := services.NewOrderService(fields.orderRepoMock, fields.workerRepoMock, fields.taskRepoMock, fields.userRepoMock, fields.logger, 2, models.TaxSettings{}, models.RoundHalfUp, models.DeadlineSettings{}, nil, nil, recorder)
+
+			orders := make([]models.Order, len(tt.orders))
+			copy(orders, tt.orders)
+
+			// the mocked repositories keep the orders and the load of the masters
+			stored := make(map[uuid.UUID]models.Order, len(tt.orders))
+			for _, order := range tt.orders {
+				stored[order.ID] = order
+			}
+			load := map[uuid.UUID]int{firstMasterID: tt.load[firstMasterID], secondMasterID: tt.load[secondMasterID]}
+
+			fields.orderRepoMock.EXPECT().Filter(gomock.Any(), 0, 0, false).Return(orders, nil)
+			fields.workerRepoMock.EXPECT().GetWorkersByRole(models.MasterRole, uuid.Nil).Return([]models.Worker{{ID: firstMasterID, Role: models.MasterRole}, {ID: secondMasterID, Role: models.MasterRole}}, nil)
+			fields.workerRepoMock.EXPECT().GetWorkerByID(gomock.Any()).DoAndReturn(func(id uuid.UUID) (*models.Worker, error) {
+				return &models.Worker{ID: id, Role: models.MasterRole}, nil
+			}).AnyTimes()
+			fields.workerRepoMock.EXPECT().GetActiveOrdersCount(gomock.Any()).DoAndReturn(func(id uuid.UUID) (int, error) {
+				return load[id], nil
+			}).AnyTimes()
+			fields.workerRepoMock.EXPECT().IsAvailable(gomock.Any(), gomock.Any()).DoAndReturn(func(workerID uuid.UUID, at time.Time) (bool, error) {
+				day, ok := tt.unavailable[workerID]
+				return !ok || at.Day() != day, nil
+			}).AnyTimes()
+			fields.orderRepoMock.EXPECT().GetOrderByID(gomock.Any()).DoAndReturn(func(id uuid.UUID) (*models.Order, error) {
+				order := stored[id]
+				return &order, nil
+			}).AnyTimes()
+			fields.orderRepoMock.EXPECT().Update(gomock.Any()).DoAndReturn(func(order *models.Order) (*models.Order, error) {
+				assert.NotNil(t, order.AssignedAt)
+				load[order.WorkerID]++
+				stored[order.ID] = *order
+				return order, nil
+			}).AnyTimes()
+
+			assignments, err := orderService.AutoAssignUnassigned(testManager)
+			tt.checkOutput(t, tt.orders, assignments, err)
+
+			var expectedChanges []statusChange
+			for _, order := range tt.orders {
+				if workerID, ok := assignments[order.ID]; ok {
+					assert.Equal(t, models.InProgressOrderStatus, stored[order.ID].Status)
+					assert.Equal(t, workerID, stored[order.ID].WorkerID)
+					expectedChanges = append(expectedChanges, statusChange{order.ID, models.NewOrderStatus, models.InProgressOrderStatus})
+				} else {
+					assert.Equal(t, models.NewOrderStatus, stored[order.ID].Status)
+					assert.Equal(t, uuid.Nil, stored[order.ID].WorkerID)
+				}
+			}
+			assert.Equal(t, expectedChanges, recorder.statusChanges)
+			assert.Equal(t, assignments, recorder.assignments)
+		})
+	}
+}
+
+func TestOrderService_AutoAssignUnassignedSkipsChangedOrders(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	fields := initOrderServiceFields(ctrl)
+	orderService := initOrderService(fields)
+
+	orders := unassignedOrdersWithDeadlines(1)
+	cancelled := orders[0]
+	cancelled.Status = models.CancelledOrderStatus
+
+	fields.orderRepoMock.EXPECT().Filter(gomock.Any(), 0, 0, false).Return(orders, nil)
+	fields.workerRepoMock.EXPECT().GetWorkersByRole(models.MasterRole, uuid.Nil).Return([]models.Worker{{ID: firstMasterID, Role: models.MasterRole}}, nil)
+	fields.workerRepoMock.EXPECT().GetActiveOrdersCount(firstMasterID).Return(0, nil)
+	fields.workerRepoMock.EXPECT().IsAvailable(firstMasterID, gomock.Any()).Return(true, nil)
+	// the order was cancelled after the unassigned orders were loaded
+	fields.orderRepoMock.EXPECT().GetOrderByID(cancelled.ID).Return(&cancelled, nil)
+	fields.orderRepoMock.EXPECT().Update(gomock.Any()).Times(0)
+
+	assignments, err := orderService.AutoAssignUnassigned(testManager)
+	assert.NoError(t, err)
+	assert.Empty(t, assignments)
+}
+
+func TestOrderService_AutoAssignUnassignedError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	fields := initOrderServiceFields(ctrl)
+	orderService := initOrderService(fields)
+
+	fields.orderRepoMock.EXPECT().Filter(gomock.Any(), 0, 0, false).Return(nil, repository_errors.SelectError)
+
+	assignments, err := orderService.AutoAssignUnassigned(testManager)
+	assert.Nil(t, assignments)
+	assert.Equal(t, repository_errors.SelectError, err)
+}
+
+var testOrderServiceBuildDailyDigest = []struct {
+	testName  string
+	inputData struct {
+		day time.Time
+	}
+	prepare     func(fields *orderServiceFields)
+	checkOutput func(t *testing.T, digest models.DailyDigest, err error)
+}{
+	{
+		testName: "digest covers the whole day",
+		inputData: struct {
+			day time.Time
+		}{time.Date(2024, 3, 10, 15, 30, 0, 0, time.UTC)},
+		prepare: func(fields *orderServiceFields) {
+			from := time.Date(2024, 3, 10, 0, 0, 0, 0, time.UTC)
+			fields.orderRepoMock.EXPECT().GetDigestBetween(from, from.AddDate(0, 0, 1)).Return(&models.DailyDigest{
+				Day:             from,
+				NewOrders:       2,
+				CompletedOrders: 1,
+				CancelledOrders: 1,
+				Revenue:         150,
+				OverdueOrders:   1,
+			}, nil)
+		},
+		checkOutput: func(t *testing.T, digest models.DailyDigest, err error) {
+			assert.NoError(t, err)
+			assert.Equal(t, time.Date(2024, 3, 10, 0, 0, 0, 0, time.UTC), digest.Day)
+			assert.Equal(t, 2, digest.NewOrders)
+			assert.Equal(t, 150.0, digest.Revenue)
+		},
+	},
+	{
+		testName: "day is not set",
+		inputData: struct {
+			day time.Time
+		}{time.Time{}},
+		prepare: func(fields *orderServiceFields) {},
+		checkOutput: func(t *testing.T, digest models.DailyDigest, err error) {
+			assert.Error(t, err)
+			assert.Equal(t, models.DailyDigest{}, digest)
+		},
+	},
+	{
+		testName: "get digest error",
+		inputData: struct {
+			day time.Time
+		}{time.Date(2024, 3, 10, 0, 0, 0, 0, time.UTC)},
+		prepare: func(fields *orderServiceFields) {
+			fields.orderRepoMock.EXPECT().GetDigestBetween(gomock.Any(), gomock.Any()).Return(nil, repository_errors.SelectError)
+		},
+		checkOutput: func(t *testing.T, digest models.DailyDigest, err error) {
+			assert.Error(t, err)
+			assert.Equal(t, repository_errors.SelectError, err)
+		},
+	},
+}
+
+func TestOrderService_BuildDailyDigest(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	fields := initOrderServiceFields(ctrl)
+	orderService := initOrderService(fields)
+
+	for _, tt := range testOrderServiceBuildDailyDigest {
+		t.Run(tt.testName, func(t *testing.T) {
+			tt.prepare(fields)
+			digest, err := orderService.BuildDailyDigest(tt.inputData.day)
+			tt.checkOutput(t, digest, err)
+		})
+	}
+}
+
+var testOrderServiceGetAverageOrderValue = []struct {
+	testName  string
+	inputData struct {
+		from time.Time
+		to   time.Time
+	}
+	prepare     func(fields *orderServiceFields)
+	checkOutput func(t *testing.T, average float64, err error)
+}{
+	{
+		testName: "average is rounded to cents",
+		inputData: struct {
+			from time.Time
+			to   time.Time
+		}{time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC)},
+		prepare: func(fields *orderServiceFields) {
+			fields.orderRepoMock.EXPECT().GetAverageOrderValue(gomock.Any(), gomock.Any()).Return(1000.0/3, nil)
+		},
+		checkOutput: func(t *testing.T, average float64, err error) {
+			assert.NoError(t, err)
+			assert.Equal(t, 333.33, average)
+		},
+	},
+	{
+		testName: "no completed orders",
+		inputData: struct {
+			from time.Time
+			to   time.Time
+		}{time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC)},
+		prepare: func(fields *orderServiceFields) {
+			fields.orderRepoMock.EXPECT().GetAverageOrderValue(gomock.Any(), gomock.Any()).Return(0.0, nil)
+		},
+		checkOutput: func(t *testing.T, average float64, err error) {
+			assert.NoError(t, err)
+			assert.Equal(t, 0.0, average)
+		},
+	},
+	{
+		testName: "period ends before it starts",
+		inputData: struct {
+			from time.Time
+			to   time.Time
+		}{time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)},
+		prepare: func(fields *orderServiceFields) {},
+		checkOutput: func(t *testing.T, average float64, err error) {
+			assert.Equal(t, fmt.Errorf("SERVICE: Invalid input"), err)
+		},
+	},
+	{
+		testName: "retrieval error",
+		inputData: struct {
+			from time.Time
+			to   time.Time
+		}{time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC)},
+		prepare: func(fields *orderServiceFields) {
+			fields.orderRepoMock.EXPECT().GetAverageOrderValue(gomock.Any(), gomock.Any()).Return(0.0, repository_errors.SelectError)
+		},
+		checkOutput: func(t *testing.T, average float64, err error) {
+			assert.Equal(t, repository_errors.SelectError, err)
+		},
+	},
+}
+
+func TestOrderService_GetAverageOrderValue(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	fields := initOrderServiceFields(ctrl)
+	orderService := initOrderService(fields)
+
+	for _, tt := range testOrderServiceGetAverageOrderValue {
+		t.Run(tt.testName, func(t *testing.T) {
+			tt.prepare(fields)
+			average, err := orderService.GetAverageOrderValue(tt.inputData.from, tt.inputData.to)
+			tt.checkOutput(t, average, err)
+		})
+	}
+}
+
+var testOrderServiceGetRevenueSummary = []struct {
+	testName  string
+	inputData struct {
+		from time.Time
+		to   time.Time
+	}
+	prepare     func(fields *orderServiceFields)
+	checkOutput func(t *testing.T, summary *models.RevenueSummary, err error)
+}{
+	{
+		testName: "two completed orders",
+		inputData: struct {
+			from time.Time
+			to   time.Time
+		}{time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC)},
+		prepare: func(fields *orderServiceFields) {
+			fields.orderRepoMock.EXPECT().GetRevenueSummary(gomock.Any(), gomock.Any()).
+				Return(&models.RevenueSummary{Revenue: 1000.006, OrderCount: 2, AverageOrderValue: 500.003}, nil)
+		},
+		checkOutput: func(t *testing.T, summary *models.RevenueSummary, err error) {
+			assert.NoError(t, err)
+			assert.Equal(t, &models.RevenueSummary{Revenue: 1000.01, OrderCount: 2, AverageOrderValue: 500}, summary)
+		},
+	},
+	{
+		testName: "empty range",
+		inputData: struct {
+			from time.Time
+			to   time.Time
+		}{time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)},
+		prepare: func(fields *orderServiceFields) {
+			fields.orderRepoMock.EXPECT().GetRevenueSummary(gomock.Any(), gomock.Any()).Return(&models.RevenueSummary{}, nil)
+		},
+		checkOutput: func(t *testing.T, summary *models.RevenueSummary, err error) {
+			assert.NoError(t, err)
+			assert.Equal(t, &models.RevenueSummary{}, summary)
+		},
+	},
+	{
+		testName: "period ends before it starts",
+		inputData: struct {
+			from time.Time
+			to   time.Time
+		}{time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)},
+		prepare: func(fields *orderServiceFields) {},
+		checkOutput: func(t *testing.T, summary *models.RevenueSummary, err error) {
+			assert.Equal(t, fmt.Errorf("SERVICE: Invalid input"), err)
+			assert.Nil(t, summary)
+		},
+	},
+	{
+		testName: "retrieval error",
+		inputData: struct {
+			from time.Time
+			to   time.Time
+		}{time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC)},
+		prepare: func(fields *orderServiceFields) {
+			fields.orderRepoMock.EXPECT().GetRevenueSummary(gomock.Any(), gomock.Any()).Return(nil, repository_errors.SelectError)
+		},
+		checkOutput: func(t *testing.T, summary *models.RevenueSummary, err error) {
+			assert.Equal(t, repository_errors.SelectError, err)
+			assert.Nil(t, summary)
+		},
+	},
+}
+
+func TestOrderService_GetRevenueSummary(t *testing.T) {
+	for _, tt := range testOrderServiceGetRevenueSummary {
+		t.Run(tt.testName, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			fields := initOrderServiceFields(ctrl)
+			orderService := initOrderService(fields)
+
+			tt.prepare(fields)
+			summary, err := orderService.GetRevenueSummary(tt.inputData.from, tt.inputData.to)
+			tt.checkOutput(t, summary, err)
+		})
+	}
+}
+
+var testOrderServiceSearchOrdersByCustomerName = []struct {
+	testName  string
+	inputData struct {
+		substring string
+	}
+	prepare     func(fields *orderServiceFields)
+	checkOutput func(t *testing.T, orders []models.Order, err error)
+}{
+	{
+		testName: "orders of matching customer",
+		inputData: struct {
+			substring string
+		}{" Petrov "},
+		prepare: func(fields *orderServiceFields) {
+			fields.orderRepoMock.EXPECT().SearchByCustomerName("Petrov").Return([]models.Order{{ID: uuid.New()}}, nil)
+		},
+		checkOutput: func(t *testing.T, orders []models.Order, err error) {
+			assert.NoError(t, err)
+			assert.Len(t, orders, 1)
+		},
+	},
+	{
+		testName: "empty input",
+		inputData: struct {
+			substring string
+		}{"   "},
+		prepare: func(fields *orderServiceFields) {},
+		checkOutput: func(t *testing.T, orders []models.Order, err error) {
+			assert.Error(t, err)
+			assert.Nil(t, orders)
+			assert.Equal(t, service_errors.InvalidName, err)
+		},
+	},
+	{
+		testName: "search error",
+		inputData: struct {
+			substring string
+		}{"Anna"},
+		prepare: func(fields *orderServiceFields) {
+			fields.orderRepoMock.EXPECT().SearchByCustomerName(gomock.Any()).Return(nil, repository_errors.SelectError)
+		},
+		checkOutput: func(t *testing.T, orders []models.Order, err error) {
+			assert.Error(t, err)
+			assert.Nil(t, orders)
+			assert.Equal(t, repository_errors.SelectError, err)
+		},
+	},
+}
+
+func TestOrderService_SearchOrdersByCustomerName(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	fields := initOrderServiceFields(ctrl)
+	orderService := initOrderService(fields)
+
+	for _, tt := range testOrderServiceSearchOrdersByCustomerName {
+		t.Run(tt.testName, func(t *testing.T) {
+			tt.prepare(fields)
+			orders, err := orderService.SearchOrdersByCustomerName(tt.inputData.substring)
+			tt.checkOutput(t, orders, err)
+		})
+	}
+}
+
+var (
+	searchOrderID      = uuid.MustParse("3f2a9c1e-7b4d-4e8a-9c2f-1a2b3c4d5e6f")
+	searchOtherOrderID = uuid.New()
+)
+
+var testOrderServiceSearch = []struct {
+	testName  string
+	inputData struct {
+		query string
+	}
+	prepare     func(fields *orderServiceFields)
+	checkOutput func(t *testing.T, orders []models.Order, err error)
+}{
+	{
+		testName: "full order id",
+		inputData: struct {
+			query string
+		}{searchOrderID.String()},
+		prepare: func(fields *orderServiceFields) {
+			fields.orderRepoMock.EXPECT().GetOrderByID(searchOrderID).Return(&models.Order{ID: searchOrderID}, nil)
+			fields.orderRepoMock.EXPECT().SearchByCustomerName(gomock.Any()).Times(0)
+			fields.orderRepoMock.EXPECT().SearchByAddress(gomock.Any()).Times(0)
+		},
+		checkOutput: func(t *testing.T, orders []models.Order, err error) {
+			assert.NoError(t, err)
+			assert.Equal(t, []models.Order{{ID: searchOrderID}}, orders)
+		},
+	},
+	{
+		testName: "unknown order id",
+		inputData: struct {
+			query string
+		}{searchOrderID.String()},
+		prepare: func(fields *orderServiceFields) {
+			fields.orderRepoMock.EXPECT().GetOrderByID(searchOrderID).Return(nil, repository_errors.DoesNotExist)
+		},
+		checkOutput: func(t *testing.T, orders []models.Order, err error) {
+			assert.NoError(t, err)
+			assert.Empty(t, orders)
+		},
+	},
+	{
+		testName: "order reference comes first",
+		inputData: struct {
+			query string
+		}{"#3F2A9C1E"},
+		prepare: func(fields *orderServiceFields) {
+			fields.orderRepoMock.EXPECT().Filter(map[string]string{"id": "3f2a9c1e%"}, 0, 0, false).Return([]models.Order{{ID: searchOrderID}}, nil)
+			fields.orderRepoMock.EXPECT().SearchByCustomerName("#3F2A9C1E").Return(nil, nil)
+			fields.orderRepoMock.EXPECT().SearchByAddress("#3F2A9C1E").Return([]models.Order{{ID: searchOtherOrderID}}, nil)
+		},
+		checkOutput: func(t *testing.T, orders []models.Order, err error) {
+			assert.NoError(t, err)
+			assert.Equal(t, []models.Order{{ID: searchOrderID}, {ID: searchOtherOrderID}}, orders)
+		},
+	},
+	{
+		testName: "customer name matches before address and are not repeated",
+		inputData: struct {
+			query string
+		}{" Petrov "},
+		prepare: func(fields *orderServiceFields) {
+			fields.orderRepoMock.EXPECT().Filter(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			fields.orderRepoMock.EXPECT().SearchByCustomerName("Petrov").Return([]models.Order{{ID: searchOrderID}}, nil)
+			fields.orderRepoMock.EXPECT().SearchByAddress("Petrov").Return([]models.Order{{ID: searchOtherOrderID}, {ID: searchOrderID}}, nil)
+		},
+		checkOutput: func(t *testing.T, orders []models.Order, err error) {
+			assert.NoError(t, err)
+			assert.Equal(t, []models.Order{{ID: searchOrderID}, {ID: searchOtherOrderID}}, orders)
+		},
+	},
+	{
+		testName: "address",
+		inputData: struct {
+			query string
+		}{"Lenina 5"},
+		prepare: func(fields *orderServiceFields) {
+			fields.orderRepoMock.EXPECT().SearchByCustomerName("Lenina 5").Return(nil, nil)
+			fields.orderRepoMock.EXPECT().SearchByAddress("Lenina 5").Return([]models.Order{{ID: searchOtherOrderID, Address: "Lenina 5"}}, nil)
+		},
+		checkOutput: func(t *testing.T, orders []models.Order, err error) {
+			assert.NoError(t, err)
+			assert.Equal(t, []models.Order{{ID: searchOtherOrderID, Address: "Lenina 5"}}, orders)
+		},
+	},
+	{
+		testName: "nothing found",
+		inputData: struct {
+			query string
+		}{"nobody"},
+		prepare: func(fields *orderServiceFields) {
+			fields.orderRepoMock.EXPECT().SearchByCustomerName("nobody").Return(nil, nil)
+			fields.orderRepoMock.EXPECT().SearchByAddress("nobody").Return(nil, nil)
+		},
+		checkOutput: func(t *testing.T, orders []models.Order, err error) {
+			assert.NoError(t, err)
+			assert.NotNil(t, orders)
+			assert.Empty(t, orders)
+		},
+	},
+	{
+		testName: "empty query",
+		inputData: struct {
+			query string
+		}{"  "},
+		prepare: func(fields *orderServiceFields) {},
+		checkOutput: func(t *testing.T, orders []models.Order, err error) {
+			assert.Nil(t, orders)
+			assert.Equal(t, fmt.Errorf("SERVICE: Invalid input"), err)
+		},
+	},
+	{
+		testName: "search error",
+		inputData: struct {
+			query string
+		}{"Anna"},
+		prepare: func(fields *orderServiceFields) {
+			fields.orderRepoMock.EXPECT().SearchByCustomerName("Anna").Return(nil, repository_errors.SelectError)
+		},
+		checkOutput: func(t *testing.T, orders []models.Order, err error) {
+			assert.Nil(t, orders)
+			assert.Equal(t, repository_errors.SelectError, err)
+		},
+	},
+}
+
+func TestOrderService_Search(t *testing.T) {
+	for _, tt := range testOrderServiceSearch {
+		t.Run(tt.testName, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			fields := initOrderServiceFields(ctrl)
+			orderService := initOrderService(fields)
+
+			tt.prepare(fields)
+			orders, err := orderService.Search(tt.inputData.query)
+			tt.checkOutput(t, orders, err)
+		})
+	}
+}
+
+var receiptTasks = []models.Task{
+	{ID: uuid.New(), Name: "Мытье окон", PricePerSingle: 1000},
+	{ID: uuid.New(), Name: "Химчистка ковра", PricePerSingle: 2500},
+}
+
+// orderedLines pairs tasks with quantities like OrderRepository.GetOrderedTasks returns them.
+func orderedLines(tasks []models.Task, quantities ...int) []models.OrderedTask {
+	lines := make([]models.OrderedTask, len(tasks))
+	for i := range tasks {
+		lines[i] = models.OrderedTask{Task: &tasks[i], Quantity: quantities[i]}
+	}
+	return lines
+}
+
+var testOrderServiceBuildReceipt = []struct {
+	testName    string
+	tax         models.TaxSettings
+	checkOutput func(t *testing.T, receipt *models.Receipt, err error)
+}{
+	{
+		testName: "tax is a separate line",
+		tax:      models.TaxSettings{Rate: 0.2},
+		checkOutput: func(t *testing.T, receipt *models.Receipt, err error) {
+			assert.NoError(t, err)
+			assert.False(t, receipt.TaxIncluded)
+			assert.InDelta(t, 1000.0, receipt.Lines[0].UnitPrice, 1e-9)
+			assert.InDelta(t, 2000.0, receipt.Lines[0].Total, 1e-9)
+			assert.InDelta(t, 4500.0, receipt.Subtotal, 1e-9)
+			assert.InDelta(t, 900.0, receipt.Tax, 1e-9)
+			assert.InDelta(t, 5400.0, receipt.GrandTotal, 1e-9)
+		},
+	},
+	{
+		testName: "line items include tax",
+		tax:      models.TaxSettings{Rate: 0.2, Inclusive: true},
+		checkOutput: func(t *testing.T, receipt *models.Receipt, err error) {
+			assert.NoError(t, err)
+			assert.True(t, receipt.TaxIncluded)
+			assert.InDelta(t, 1200.0, receipt.Lines[0].UnitPrice, 1e-9)
+			assert.InDelta(t, 2400.0, receipt.Lines[0].Total, 1e-9)
+			assert.InDelta(t, 5400.0, receipt.Subtotal, 1e-9)
+			assert.InDelta(t, 900.0, receipt.Tax, 1e-9)
+			assert.InDelta(t, 5400.0, receipt.GrandTotal, 1e-9)
+		},
+	},
+	{
+		testName: "no tax configured",
+		tax:      models.TaxSettings{},
+		checkOutput: func(t *testing.T, receipt *models.Receipt, err error) {
+			assert.NoError(t, err)
+			assert.Equal(t, 0.0, receipt.Tax)
+			assert.InDelta(t, 4500.0, receipt.GrandTotal, 1e-9)
+		},
+	},
+}
+
+func TestOrderService_BuildReceipt(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	fields := initOrderServiceFields(ctrl)
+
+	for _, tt := range testOrderServiceBuildReceipt {
+		t.Run(tt.testName, func(t *testing.T) {
+			orderService := services.NewOrderService(fields.orderRepoMock, fields.workerRepoMock, fields.taskRepoMock, fields.userRepoMock, fields.logger, 0, tt.tax, models.RoundHalfUp, models.DeadlineSettings{}, nil, nil)
+			orderID := uuid.New()
+
+			fields.orderRepoMock.EXPECT().GetOrderedTasks(orderID).Return(orderedLines(receiptTasks, 2, 1), nil)
+
+			receipt, err := orderService.BuildReceipt(orderID)
+			tt.checkOutput(t, receipt, err)
+		})
+	}
+}
+
+func TestOrderService_BuildReceiptSameGrandTotal(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	fields := initOrderServiceFields(ctrl)
+	orderID := uuid.New()
+
+	fields.orderRepoMock.EXPECT().GetOrderedTasks(orderID).Return(orderedLines(receiptTasks, 3, 7), nil).Times(2)
+
+	exclusive, err := services.NewOrderService(fields.orderRepoMock, fields.workerRepoMock, fields.taskRepoMock, fields.userRepoMock, fields.logger, 0, models.TaxSettings{Rate: 0.13}, models.RoundHalfUp, models.DeadlineSettings{}, nil, nil).BuildReceipt(orderID)
+	assert.NoError(t, err)
+	inclusive, err := services.NewOrderService(fields.orderRepoMock, fields.workerRepoMock, fields.taskRepoMock, fields.userRepoMock, fields.logger, 0, models.TaxSettings{Rate: 0.13, Inclusive: true}, models.RoundHalfUp, models.DeadlineSettings{}, nil, nil).BuildReceipt(orderID)
+	assert.NoError(t, err)
+
+	assert.InDelta(t, exclusive.GrandTotal, inclusive.GrandTotal, 1e-6)
+	assert.InDelta(t, exclusive.Tax, inclusive.Tax, 1e-6)
+}
+
+var testRoundingModes = []struct {
+	testName string
+	mode     models.RoundingMode
+	amount   float64
+	expected float64
+}{
+	{testName: "half up rounds half cent up", mode: models.RoundHalfUp, amount: 1.005, expected: 1.01},
+	{testName: "half up with binary noise", mode: models.RoundHalfUp, amount: 2.675, expected: 2.68},
+	{testName: "half up rounds below half down", mode: models.RoundHalfUp, amount: 1.0049, expected: 1.00},
+	{testName: "half up negative half cent", mode: models.RoundHalfUp, amount: -1.005, expected: -1.01},
+	{testName: "half even rounds to even cent down", mode: models.RoundHalfEven, amount: 0.125, expected: 0.12},
+	{testName: "half even rounds to even cent up", mode: models.RoundHalfEven, amount: 0.135, expected: 0.14},
+	{testName: "half even with binary noise", mode: models.RoundHalfEven, amount: 1.005, expected: 1.00},
+	{testName: "up rounds any fraction up", mode: models.RoundUp, amount: 1.001, expected: 1.01},
+	{testName: "up ignores binary noise", mode: models.RoundUp, amount: 0.1 + 0.2, expected: 0.30},
+	{testName: "up keeps whole cents", mode: models.RoundUp, amount: 2, expected: 2},
+	{testName: "down drops any fraction", mode: models.RoundDown, amount: 1.999, expected: 1.99},
+	{testName: "down ignores binary noise", mode: models.RoundDown, amount: 1.15, expected: 1.15},
+}
+
+func TestRoundingMode_Round(t *testing.T) {
+	for _, tt := range testRoundingModes {
+		t.Run(tt.testName, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.mode.Round(tt.amount))
+		})
+	}
+}
+
+var testOrderServiceRounding = []struct {
+	testName      string
+	mode          models.RoundingMode
+	expectedTotal float64
+	expectedTax   float64
+}{
+	{testName: "half up", mode: models.RoundHalfUp, expectedTotal: 100.00, expectedTax: 1.31},
+	{testName: "half even", mode: models.RoundHalfEven, expectedTotal: 100.00, expectedTax: 1.31},
+	{testName: "up", mode: models.RoundUp, expectedTotal: 100.00, expectedTax: 1.31},
+	{testName: "down", mode: models.RoundDown, expectedTotal: 99.99, expectedTax: 1.30},
+}
+
+func TestOrderService_Rounding(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	fields := initOrderServiceFields(ctrl)
+	totalTask := models.Task{ID: uuid.New(), Name: "Мытье окон", PricePerSingle: 33.333}
+	taxTask := models.Task{ID: uuid.New(), Name: "Химчистка ковра", PricePerSingle: 10.05}
+
+	for _, tt := range testOrderServiceRounding {
+		t.Run(tt.testName, func(t *testing.T) {
+			orderService := services.NewOrderService(fields.orderRepoMock, fields.workerRepoMock, fields.taskRepoMock, fields.userRepoMock, fields.logger, 0, models.TaxSettings{Rate: 0.13}, tt.mode, models.DeadlineSettings{}, nil, nil)
+			orderID := uuid.New()
+
+			fields.orderRepoMock.EXPECT().GetOrderTotalPrice(orderID).Return(totalTask.PricePerSingle*3, nil)
+			total, err := orderService.GetTotalPrice(orderID)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedTotal, total)
+
+			fields.orderRepoMock.EXPECT().GetOrderedTasks(orderID).Return(orderedLines([]models.Task{taxTask}, 1), nil)
+			receipt, err := orderService.BuildReceipt(orderID)
+			assert.NoError(t, err)
+			assert.Equal(t, 10.05, receipt.Subtotal)
+			assert.Equal(t, tt.expectedTax, receipt.Tax)
+			assert.Equal(t, tt.mode.Round(10.05+tt.expectedTax), receipt.GrandTotal)
+		})
+	}
+}
+
+var zeroQuantityTask = models.Task{ID: uuid.New(), Name: "Мытье окон", PricePerSingle: 500}
+var positiveQuantityTask = models.Task{ID: uuid.New(), Name: "Химчистка ковра", PricePerSingle: 1500}
+
+var testOrderServiceCompleteTaskQuantities = []struct {
+	testName    string
+	quantities  map[uuid.UUID]int
+	checkOutput func(t *testing.T, order *models.Order, err error)
+}{
+	{
+		testName:   "task with zero quantity",
+		quantities: map[uuid.UUID]int{zeroQuantityTask.ID: 0, positiveQuantityTask.ID: 2},
+		checkOutput: func(t *testing.T, order *models.Order, err error) {
+			assert.Error(t, err)
+			assert.Nil(t, order)
+			assert.ErrorIs(t, err, service_errors.TasksWithoutQuantity)
+			assert.Contains(t, err.Error(), zeroQuantityTask.Name)
+			assert.NotContains(t, err.Error(), positiveQuantityTask.Name)
+		},
+	},
+	{
+		testName:   "all quantities are positive",
+		quantities: map[uuid.UUID]int{zeroQuantityTask.ID: 1, positiveQuantityTask.ID: 2},
+		checkOutput: func(t *testing.T, order *models.Order, err error) {
+			assert.NoError(t, err)
+			assert.Equal(t, models.CompletedOrderStatus, order.Status)
+		},
+	},
+}
+
+func TestOrderService_CompleteTaskQuantities(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	fields := initOrderServiceFields(ctrl)
+	orderService := initOrderService(fields)
+
+	for _, tt := range testOrderServiceCompleteTaskQuantities {
+		t.Run(tt.testName, func(t *testing.T) {
+			workerID := uuid.New()
+			orderID := uuid.New()
+			fields.orderRepoMock.EXPECT().GetOrderByID(orderID).Return(&models.Order{ID: orderID, Status: models.InProgressOrderStatus, WorkerID: workerID}, nil)
+			fields.workerRepoMock.EXPECT().GetWorkerByID(workerID).Return(&models.Worker{ID: workerID}, nil)
+			fields.orderRepoMock.EXPECT().GetTasksInOrder(orderID).Return([]models.Task{zeroQuantityTask, positiveQuantityTask}, nil)
+			fields.orderRepoMock.EXPECT().GetTaskQuantity(orderID, zeroQuantityTask.ID).Return(tt.quantities[zeroQuantityTask.ID], nil)
+			fields.orderRepoMock.EXPECT().GetTaskQuantity(orderID, positiveQuantityTask.ID).Return(tt.quantities[positiveQuantityTask.ID], nil)
+			fields.orderRepoMock.EXPECT().GetOrderTotalPrice(orderID).Return(3500.0, nil).MaxTimes(1)
+			fields.orderRepoMock.EXPECT().Update(gomock.Any()).DoAndReturn(func(order *models.Order) (*models.Order, error) {
+				return order, nil
+			}).MaxTimes(1)
+
+			order, err := orderService.Update(testManager, orderID, models.CompletedOrderStatus, 0, workerID)
+			tt.checkOutput(t, order, err)
+		})
+	}
+}
+
+// recordingNotifier collects delivered notifications and optionally fails every delivery.
+type recordingNotifier struct {
+	mu         sync.Mutex
+	recipients []uuid.UUID
+	dispatches []models.DispatchInfo
+	events     []models.OrderEvent
+	delivered  chan struct{}
+	err        error
+}
+
+func newRecordingNotifier(err error) *recordingNotifier {
+	return &recordingNotifier{delivered: make(chan struct{}, 16), err: err}
+}
+
+func (r *recordingNotifier) NotifyWorker(worker models.Worker, message string) error {
+	r.mu.Lock()
+	r.recipients = append(r.recipients, worker.ID)
+	r.mu.Unlock()
+	r.delivered <- struct{}{}
+	return r.err
+}
+
+func (r *recordingNotifier) NotifyAssignment(worker models.Worker, info models.DispatchInfo) error {
+	r.mu.Lock()
+	r.recipients = append(r.recipients, worker.ID)
+	r.dispatches = append(r.dispatches, info)
+	r.mu.Unlock()
+	r.delivered <- struct{}{}
+	return r.err
+}
+
+func (r *recordingNotifier) NotifyOrderEvent(event models.OrderEvent) error {
+	r.mu.Lock()
+	r.events = append(r.events, event)
+	r.mu.Unlock()
+	r.delivered <- struct{}{}
+	return r.err
+}
+
+func (r *recordingNotifier) waitFor(t *testing.T, count int) []uuid.UUID {
+	for i := 0; i < count; i++ {
+		select {
+		case <-r.delivered:
+		case <-time.After(time.Second):
+			t.Fatalf("expected %d notifications, got %d", count, i)
+		}
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]uuid.UUID(nil), r.recipients...)
+}
+
+var testOrderServiceCreateNotifiesManagers = []struct {
+	testName    string
+	notifyErr   error
+	managersErr error
+}{
+	{
+		testName: "managers are notified about new order",
+	},
+	{
+		testName:  "notifier failure does not fail create",
+		notifyErr: fmt.Errorf("delivery failed"),
+	},
+	{
+		testName:    "manager lookup failure does not fail create",
+		managersErr: fmt.Errorf("db error"),
+	},
+}
+
+func TestOrderService_CreateNotifiesManagers(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	fields := initOrderServiceFields(ctrl)
+
+	for _, tt := range testOrderServiceCreateNotifiesManagers {
+		t.Run(tt.testName, func(t *testing.T) {
+			notifier := newRecordingNotifier(tt.notifyErr)
+			orderService := services.NewOrderService(fields.orderRepoMock, fields.workerRepoMock, fields.taskRepoMock, fields.userRepoMock, fields.logger, 0, models.TaxSettings{}, models.RoundHalfUp, models.DeadlineSettings{}, nil, notifier)
+
+			managers := []models.Worker{{ID: uuid.New(), Role: models.ManagerRole}, {ID: uuid.New(), Role: models.ManagerRole}}
+			fields.taskRepoMock.EXPECT().GetTasksByIDs(gomock.Any()).DoAndReturn(existingTasks)
+			fields.userRepoMock.EXPECT().GetUserByID(gomock.Any()).Return(&models.User{ID: uuid.New()}, nil)
+			fields.orderRepoMock.EXPECT().Create(gomock.Any(), gomock.Any()).Return(&models.Order{ID: uuid.New(), Status: models.NewOrderStatus}, nil)
+			fields.orderRepoMock.EXPECT().DeleteDraft(gomock.Any()).Return(nil)
+			lookedUp := make(chan struct{})
+			fields.workerRepoMock.EXPECT().GetWorkersByRole(models.ManagerRole, uuid.Nil).DoAndReturn(func(role int, excludeID uuid.UUID) ([]models.Worker, error) {
+				defer close(lookedUp)
+				if tt.managersErr != nil {
+					return nil, tt.managersErr
+				}
+				return managers, nil
+			})
+
+			order, err := orderService.CreateOrder(uuid.New(), "address", time.Now().AddDate(0, 0, 1), []models.OrderedTask{{Task: &models.Task{ID: uuid.New()}, Quantity: 1}})
+			assert.NoError(t, err)
+			assert.NotNil(t, order)
+
+			select {
+			case <-lookedUp:
+			case <-time.After(time.Second):
+				t.Fatal("expected managers to be looked up")
+			}
+			if tt.managersErr != nil {
+				assert.Empty(t, notifier.waitFor(t, 0))
+				return
+			}
+			assert.ElementsMatch(t, []uuid.UUID{managers[0].ID, managers[1].ID}, notifier.waitFor(t, len(managers)))
+		})
+	}
+}
+
+var testOrderServiceUpdateUnchanged = []struct {
+	testName  string
+	inputData struct {
+		status   int
+		rate     int
+		workerID uuid.UUID
+	}
+	prepare     func(fields *orderServiceFields)
+	checkOutput func(t *testing.T, order *models.Order, err error)
+}{
+	{
+		testName: "unchanged update is a no-op",
+		inputData: struct {
+			status   int
+			rate     int
+			workerID uuid.UUID
+		}{models.CompletedOrderStatus, 5, assignedWorkerID},
+		prepare: func(fields *orderServiceFields) {
+			fields.orderRepoMock.EXPECT().Update(gomock.Any()).Times(0)
+		},
+		checkOutput: func(t *testing.T, order *models.Order, err error) {
+			assert.NoError(t, err)
+			assert.Equal(t, 5, order.Rate)
+			assert.Nil(t, order.CompletedAt)
+		},
+	},
+	{
+		testName: "changed rate is written",
+		inputData: struct {
+			status   int
+			rate     int
+			workerID uuid.UUID
+		}{models.CompletedOrderStatus, 4, assignedWorkerID},
+		prepare: func(fields *orderServiceFields) {
+			fields.workerRepoMock.EXPECT().GetWorkerByID(assignedWorkerID).Return(&models.Worker{ID: assignedWorkerID}, nil)
+			fields.orderRepoMock.EXPECT().Update(gomock.Any()).DoAndReturn(func(order *models.Order) (*models.Order, error) {
+				return order, nil
+			})
+		},
+		checkOutput: func(t *testing.T, order *models.Order, err error) {
+			assert.NoError(t, err)
+			assert.Equal(t, 4, order.Rate)
+		},
+	},
+}
+
+func TestOrderService_UpdateUnchanged(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	fields := initOrderServiceFields(ctrl)
+	notifier := newRecordingNotifier(nil)
+	orderService := services.NewOrderService(fields.orderRepoMock, fields.workerRepoMock, fields.taskRepoMock, fields.userRepoMock, fields.logger, 0, models.TaxSettings{}, models.RoundHalfUp, models.DeadlineSettings{}, nil, notifier)
+
+	for _, tt := range testOrderServiceUpdateUnchanged {
+		t.Run(tt.testName, func(t *testing.T) {
+			current := &models.Order{ID: uuid.New(), Status: models.CompletedOrderStatus, Rate: 5, WorkerID: assignedWorkerID}
+			fields.orderRepoMock.EXPECT().GetOrderByID(current.ID).Return(current, nil)
+			tt.prepare(fields)
+
+			order, err := orderService.Update(testManager, current.ID, tt.inputData.status, tt.inputData.rate, tt.inputData.workerID)
+			tt.checkOutput(t, order, err)
+			assert.Empty(t, notifier.waitFor(t, 0))
+		})
+	}
+}
+
+// statusChange is a status change recorded by recordingOrderNotifier.
+type statusChange struct {
+	orderID   uuid.UUID
+	oldStatus int
+	newStatus int
+}
+
+// recordingOrderNotifier records the invocations of the order notifier hooks.
+type recordingOrderNotifier struct {
+	statusChanges []statusChange
+	assignments   map[uuid.UUID]uuid.UUID
+}
+
+func newRecordingOrderNotifier() *recordingOrderNotifier {
+	return &recordingOrderNotifier{assignments: make(map[uuid.UUID]uuid.UUID)}
+}
+
+func (r *recordingOrderNotifier) OrderStatusChanged(order *models.Order, oldStatus int, newStatus int) {
+	r.statusChanges = append(r.statusChanges, statusChange{orderID: order.ID, oldStatus: oldStatus, newStatus: newStatus})
+}
+
+func (r *recordingOrderNotifier) OrderAssigned(order *models.Order, worker *models.Worker) {
+	r.assignments[order.ID] = worker.ID
+}
+
+func TestOrderService_OrderNotifiers(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	fields := initOrderServiceFields(ctrl)
+	recorder := newRecordingOrderNotifier()
+	// nil entries are skipped
+	orderService := services.NewOrderService(fields.orderRepoMock, fields.workerRepoMock, fields.taskRepoMock, fields.userRepoMock, fields.logger, 0, models.TaxSettings{}, models.RoundHalfUp, models.DeadlineSettings{}, nil, nil, nil, recorder)
+
+	storeOrder := func(order *models.Order) (*models.Order, error) {
+		return order, nil
+	}
+	master := &models.Worker{ID: assignedWorkerID, Role: models.MasterRole}
+	fields.workerRepoMock.EXPECT().GetWorkerByID(assignedWorkerID).Return(master, nil).AnyTimes()
+
+	t.Run("status change", func(t *testing.T) {
+		order := &models.Order{ID: uuid.New(), Status: models.NewOrderStatus, WorkerID: assignedWorkerID}
+		fields.orderRepoMock.EXPECT().GetOrderByID(order.ID).Return(order, nil)
+		fields.orderRepoMock.EXPECT().Update(gomock.Any()).DoAndReturn(storeOrder)
+
+		_, err := orderService.Update(testManager, order.ID, models.InProgressOrderStatus, 0, assignedWorkerID)
+		assert.NoError(t, err)
+		assert.Equal(t, []statusChange{{order.ID, models.NewOrderStatus, models.InProgressOrderStatus}}, recorder.statusChanges)
+		assert.Empty(t, recorder.assignments)
+	})
+
+	t.Run("no-op update", func(t *testing.T) {
+		recorder.statusChanges = nil
+		order := &models.Order{ID: uuid.New(), Status: models.InProgressOrderStatus, WorkerID: assignedWorkerID}
+		fields.orderRepoMock.EXPECT().GetOrderByID(order.ID).Return(order, nil)
+		fields.orderRepoMock.EXPECT().Update(gomock.Any()).Times(0)
+
+		_, err := orderService.Update(testManager, order.ID, models.InProgressOrderStatus, 0, assignedWorkerID)
+		assert.NoError(t, err)
+		assert.Empty(t, recorder.statusChanges)
+		assert.Empty(t, recorder.assignments)
+	})
+
+	t.Run("assignment", func(t *testing.T) {
+		recorder.statusChanges = nil
+		order := &models.Order{ID: uuid.New(), Status: models.NewOrderStatus}
+		fields.orderRepoMock.EXPECT().GetOrderByID(order.ID).Return(order, nil).Times(2)
+		fields.workerRepoMock.EXPECT().IsAvailable(assignedWorkerID, order.Deadline).Return(true, nil)
+		fields.orderRepoMock.EXPECT().Update(gomock.Any()).DoAndReturn(storeOrder)
+
+		err := orderService.AssignWorker(&models.Worker{Role: models.ManagerRole}, order.ID, assignedWorkerID)
+		assert.NoError(t, err)
+		assert.Equal(t, []statusChange{{order.ID, models.NewOrderStatus, models.InProgressOrderStatus}}, recorder.statusChanges)
+		assert.Equal(t, map[uuid.UUID]uuid.UUID{order.ID: assignedWorkerID}, recorder.assignments)
+	})
+
+	t.Run("cancellation", func(t *testing.T) {
+		recorder.statusChanges = nil
+		order := &models.Order{ID: uuid.New(), Status: models.InProgressOrderStatus, WorkerID: assignedWorkerID}
+		fields.orderRepoMock.EXPECT().GetOrderByID(order.ID).Return(order, nil)
+		fields.orderRepoMock.EXPECT().Update(gomock.Any()).DoAndReturn(storeOrder)
+
+		assert.NoError(t, orderService.CancelOrder(order.ID, "client changed plans"))
+		assert.Equal(t, []statusChange{{order.ID, models.InProgressOrderStatus, models.CancelledOrderStatus}}, recorder.statusChanges)
+	})
+
+	t.Run("failed update", func(t *testing.T) {
+		recorder.statusChanges = nil
+		order := &models.Order{ID: uuid.New(), Status: models.NewOrderStatus, WorkerID: assignedWorkerID}
+		fields.orderRepoMock.EXPECT().GetOrderByID(order.ID).Return(order, nil)
+		fields.orderRepoMock.EXPECT().Update(gomock.Any()).Return(nil, repository_errors.UpdateError)
+
+		_, err := orderService.Update(testManager, order.ID, models.InProgressOrderStatus, 0, assignedWorkerID)
+		assert.Equal(t, repository_errors.UpdateError, err)
+		assert.Empty(t, recorder.statusChanges)
+	})
+}
+
+var draftTask = models.Task{ID: uuid.New(), Name: "Мытье окон", PricePerSingle: 100}
+
+var testOrderServiceSaveDraft = []struct {
+	testName  string
+	inputData struct {
+		tasks []models.OrderedTask
+	}
+	prepare     func(fields *orderServiceFields, userID uuid.UUID)
+	checkOutput func(t *testing.T, err error)
+}{
+	{
+		testName: "draft is saved",
+		inputData: struct {
+			tasks []models.OrderedTask
+		}{[]models.OrderedTask{{Task: &draftTask, Quantity: 2}}},
+		prepare: func(fields *orderServiceFields, userID uuid.UUID) {
+			fields.taskRepoMock.EXPECT().GetTasksByIDs([]uuid.UUID{draftTask.ID}).Return(map[uuid.UUID]models.Task{draftTask.ID: draftTask}, nil)
+			fields.userRepoMock.EXPECT().GetUserByID(userID).Return(&models.User{ID: userID}, nil)
+			fields.orderRepoMock.EXPECT().SaveDraft(gomock.Any()).DoAndReturn(func(draft *models.DraftOrder) error {
+				if draft.UserID != userID || draft.Address != "address" || len(draft.Tasks) != 1 || draft.Tasks[0].Quantity != 2 {
+					return fmt.Errorf("unexpected draft %+v", draft)
+				}
+				return nil
+			})
+		},
+		checkOutput: func(t *testing.T, err error) {
+			assert.NoError(t, err)
+		},
+	},
+	{
+		testName: "invalid quantity",
+		inputData: struct {
+			tasks []models.OrderedTask
+		}{[]models.OrderedTask{{Task: &draftTask, Quantity: 0}}},
+		prepare: func(fields *orderServiceFields, userID uuid.UUID) {
+			fields.orderRepoMock.EXPECT().SaveDraft(gomock.Any()).Times(0)
+		},
+		checkOutput: func(t *testing.T, err error) {
+			assert.Error(t, err)
+		},
+	},
+	{
+		testName: "user does not exist",
+		inputData: struct {
+			tasks []models.OrderedTask
+		}{nil},
+		prepare: func(fields *orderServiceFields, userID uuid.UUID) {
+			fields.userRepoMock.EXPECT().GetUserByID(userID).Return(nil, repository_errors.DoesNotExist)
+			fields.orderRepoMock.EXPECT().SaveDraft(gomock.Any()).Times(0)
+		},
+		checkOutput: func(t *testing.T, err error) {
+			assert.Error(t, err)
+			assert.Equal(t, fmt.Errorf("SERVICE: User does not exist"), err)
+		},
+	},
+	{
+		testName: "save draft error",
+		inputData: struct {
+			tasks []models.OrderedTask
+		}{nil},
+		prepare: func(fields *orderServiceFields, userID uuid.UUID) {
+			fields.userRepoMock.EXPECT().GetUserByID(userID).Return(&models.User{ID: userID}, nil)
+			fields.orderRepoMock.EXPECT().SaveDraft(gomock.Any()).Return(repository_errors.InsertError)
+		},
+		checkOutput: func(t *testing.T, err error) {
+			assert.Equal(t, repository_errors.InsertError, err)
+		},
+	},
+}
+
+func TestOrderService_SaveDraft(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	fields := initOrderServiceFields(ctrl)
+	orderService := initOrderService(fields)
+
+	for _, tt := range testOrderServiceSaveDraft {
+		t.Run(tt.testName, func(t *testing.T) {
+			userID := uuid.New()
+			tt.prepare(fields, userID)
+			err := orderService.SaveDraft(userID, "address", tt.inputData.tasks)
+			tt.checkOutput(t, err)
+		})
+	}
+}
+
+var testOrderServiceGetDraft = []struct {
+	testName    string
+	prepare     func(fields *orderServiceFields, userID uuid.UUID)
+	checkOutput func(t *testing.T, draft *models.DraftOrder, err error)
+}{
+	{
+		testName: "draft is returned",
+		prepare: func(fields *orderServiceFields, userID uuid.UUID) {
+			fields.orderRepoMock.EXPECT().GetDraft(userID).Return(&models.DraftOrder{
+				UserID:  userID,
+				Address: "address",
+				Tasks:   []models.OrderedTask{{Task: &draftTask, Quantity: 3}},
+			}, nil)
+		},
+		checkOutput: func(t *testing.T, draft *models.DraftOrder, err error) {
+			assert.NoError(t, err)
+			assert.Equal(t, "address", draft.Address)
+			assert.Len(t, draft.Tasks, 1)
+			assert.Equal(t, 3, draft.Tasks[0].Quantity)
+		},
+	},
+	{
+		testName: "no draft",
+		prepare: func(fields *orderServiceFields, userID uuid.UUID) {
+			fields.orderRepoMock.EXPECT().GetDraft(userID).Return(nil, repository_errors.DoesNotExist)
+		},
+		checkOutput: func(t *testing.T, draft *models.DraftOrder, err error) {
+			assert.Nil(t, draft)
+			assert.Equal(t, repository_errors.DoesNotExist, err)
+		},
+	},
+}
+
+func TestOrderService_GetDraft(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	fields := initOrderServiceFields(ctrl)
+	orderService := initOrderService(fields)
+
+	for _, tt := range testOrderServiceGetDraft {
+		t.Run(tt.testName, func(t *testing.T) {
+			userID := uuid.New()
+			tt.prepare(fields, userID)
+			draft, err := orderService.GetDraft(userID)
+			tt.checkOutput(t, draft, err)
+		})
+	}
+}
+
+func TestOrderService_CreateOrderClearsDraft(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	fields := initOrderServiceFields(ctrl)
+	orderService := initOrderService(fields)
+
+	userID := uuid.New()
+	fields.taskRepoMock.EXPECT().GetTasksByIDs([]uuid.UUID{draftTask.ID}).Return(map[uuid.UUID]models.Task{draftTask.ID: draftTask}, nil)
+	fields.userRepoMock.EXPECT().GetUserByID(userID).Return(&models.User{ID: userID}, nil)
+	fields.orderRepoMock.EXPECT().Create(gomock.Any(), gomock.Any()).Return(&models.Order{ID: uuid.New()}, nil)
+	fields.orderRepoMock.EXPECT().DeleteDraft(userID).Return(repository_errors.DeleteError)
+
+	order, err := orderService.CreateOrder(userID, "address", time.Now().AddDate(0, 0, 1), []models.OrderedTask{{Task: &draftTask, Quantity: 1}})
+	assert.NoError(t, err)
+	assert.NotNil(t, order)
+}
+
+var testOrderServiceInProgressRequiresWorker = []struct {
+	testName  string
+	inputData struct {
+		current  *models.Order
+		workerID uuid.UUID
+	}
+	prepare     func(fields *orderServiceFields)
+	checkOutput func(t *testing.T, order *models.Order, err error)
+}{
+	{
+		testName: "in progress without worker is rejected",
+		inputData: struct {
+			current  *models.Order
+			workerID uuid.UUID
+		}{&models.Order{ID: uuid.New(), Status: models.NewOrderStatus}, uuid.Nil},
+		prepare: func(fields *orderServiceFields) {
+			fields.orderRepoMock.EXPECT().Update(gomock.Any()).Times(0)
+		},
+		checkOutput: func(t *testing.T, order *models.Order, err error) {
+			assert.Nil(t, order)
+			assert.ErrorIs(t, err, service_errors.InProgressWithoutWorker)
+		},
+	},
+	{
+		testName: "unassigning an order in progress is rejected",
+		inputData: struct {
+			current  *models.Order
+			workerID uuid.UUID
+		}{&models.Order{ID: uuid.New(), Status: models.InProgressOrderStatus, WorkerID: assignedWorkerID}, uuid.Nil},
+		prepare: func(fields *orderServiceFields) {
+			fields.orderRepoMock.EXPECT().Update(gomock.Any()).Times(0)
+		},
+		checkOutput: func(t *testing.T, order *models.Order, err error) {
+			assert.Nil(t, order)
+			assert.ErrorIs(t, err, service_errors.InProgressWithoutWorker)
+		},
+	},
+	{
+		testName: "in progress with worker is allowed",
+		inputData: struct {
+			current  *models.Order
+			workerID uuid.UUID
+		}{&models.Order{ID: uuid.New(), Status: models.NewOrderStatus}, assignedWorkerID},
+		prepare: func(fields *orderServiceFields) {
+			fields.workerRepoMock.EXPECT().GetWorkerByID(assignedWorkerID).Return(&models.Worker{ID: assignedWorkerID}, nil)
+			fields.orderRepoMock.EXPECT().Update(gomock.Any()).DoAndReturn(func(order *models.Order) (*models.Order, error) {
+				return order, nil
+			})
+		},
+		checkOutput: func(t *testing.T, order *models.Order, err error) {
+			assert.NoError(t, err)
+			assert.Equal(t, models.InProgressOrderStatus, order.Status)
+			assert.Equal(t, assignedWorkerID, order.WorkerID)
+		},
+	},
+}
+
+func TestOrderService_InProgressRequiresWorker(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	fields := initOrderServiceFields(ctrl)
+	fields.workerRepoMock.EXPECT().IsAvailable(gomock.Any(), gomock.Any()).Return(true, nil).AnyTimes()
+	orderService := initOrderService(fields)
+
+	for _, tt := range testOrderServiceInProgressRequiresWorker {
+		t.Run(tt.testName, func(t *testing.T) {
+			fields.orderRepoMock.EXPECT().GetOrderByID(tt.inputData.current.ID).Return(tt.inputData.current, nil)
+			tt.prepare(fields)
+			order, err := orderService.Update(testManager, tt.inputData.current.ID, models.InProgressOrderStatus, 0, tt.inputData.workerID)
+			tt.checkOutput(t, order, err)
+		})
+	}
+}
+
+var testOrderServiceGetDeadlineDayOfWeekCounts = []struct {
+	testName  string
+	inputData struct {
+		from time.Time
+		to   time.Time
+	}
+	prepare     func(fields *orderServiceFields)
+	checkOutput func(t *testing.T, counts map[time.Weekday]int, err error)
+}{
+	{
+		testName: "counts are returned",
+		inputData: struct {
+			from time.Time
+			to   time.Time
+		}{time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC)},
+		prepare: func(fields *orderServiceFields) {
+			fields.orderRepoMock.EXPECT().GetDeadlineDayOfWeekCounts(gomock.Any(), gomock.Any()).Return(map[time.Weekday]int{time.Monday: 2, time.Friday: 1}, nil)
+		},
+		checkOutput: func(t *testing.T, counts map[time.Weekday]int, err error) {
+			assert.NoError(t, err)
+			assert.Equal(t, 2, counts[time.Monday])
+			assert.Equal(t, 1, counts[time.Friday])
+			assert.Equal(t, 0, counts[time.Sunday])
+		},
+	},
+	{
+		testName: "invalid period",
+		inputData: struct {
+			from time.Time
+			to   time.Time
+		}{time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)},
+		prepare: func(fields *orderServiceFields) {
+			fields.orderRepoMock.EXPECT().GetDeadlineDayOfWeekCounts(gomock.Any(), gomock.Any()).Times(0)
+		},
+		checkOutput: func(t *testing.T, counts map[time.Weekday]int, err error) {
+			assert.Error(t, err)
+			assert.Nil(t, counts)
+		},
+	},
+	{
+		testName: "repository error",
+		inputData: struct {
+			from time.Time
+			to   time.Time
+		}{time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC)},
+		prepare: func(fields *orderServiceFields) {
+			fields.orderRepoMock.EXPECT().GetDeadlineDayOfWeekCounts(gomock.Any(), gomock.Any()).Return(nil, repository_errors.SelectError)
+		},
+		checkOutput: func(t *testing.T, counts map[time.Weekday]int, err error) {
+			assert.Equal(t, repository_errors.SelectError, err)
+			assert.Nil(t, counts)
+		},
+	},
+}
+
+func TestOrderService_GetDeadlineDayOfWeekCounts(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	fields := initOrderServiceFields(ctrl)
+	orderService := initOrderService(fields)
+
+	for _, tt := range testOrderServiceGetDeadlineDayOfWeekCounts {
+		t.Run(tt.testName, func(t *testing.T) {
+			tt.prepare(fields)
+			counts, err := orderService.GetDeadlineDayOfWeekCounts(tt.inputData.from, tt.inputData.to)
+			tt.checkOutput(t, counts, err)
+		})
+	}
+}
+
+var testOrderServiceRecomputeOpenOrderTotals = []struct {
+	testName    string
+	prepare     func(fields *orderServiceFields)
+	checkOutput func(t *testing.T, updated int, err error)
+}{
+	{
+		testName: "new orders are recomputed",
+		prepare: func(fields *orderServiceFields) {
+			fields.orderRepoMock.EXPECT().RecomputeNewOrderTotals(models.RoundHalfUp).Return(3, nil)
+		},
+		checkOutput: func(t *testing.T, updated int, err error) {
+			assert.NoError(t, err)
+			assert.Equal(t, 3, updated)
+		},
+	},
+	{
+		testName: "repository error",
+		prepare: func(fields *orderServiceFields) {
+			fields.orderRepoMock.EXPECT().RecomputeNewOrderTotals(models.RoundHalfUp).Return(0, repository_errors.UpdateError)
+		},
+		checkOutput: func(t *testing.T, updated int, err error) {
+			assert.Equal(t, repository_errors.UpdateError, err)
+			assert.Equal(t, 0, updated)
+		},
+	},
+}
+
+func TestOrderService_RecomputeOpenOrderTotals(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	fields := initOrderServiceFields(ctrl)
+	orderService := initOrderService(fields)
+
+	for _, tt := range testOrderServiceRecomputeOpenOrderTotals {
+		t.Run(tt.testName, func(t *testing.T) {
+			tt.prepare(fields)
+			updated, err := orderService.RecomputeOpenOrderTotals()
+			tt.checkOutput(t, updated, err)
+		})
+	}
+}
+
+var testOrderServiceAssignWorkerNotifies = []struct {
+	testName  string
+	previous  uuid.UUID
+	optOut    bool
+	notifyErr error
+	notified  bool
+}{
+	{
+		testName: "assigned master receives order details",
+		notified: true,
+	},
+	{
+		testName:  "notifier failure does not fail assignment",
+		notifyErr: fmt.Errorf("delivery failed"),
+		notified:  true,
+	},
+	{
+		testName: "opted out master is not notified",
+		optOut:   true,
+	},
+	{
+		testName: "reassigning the same master sends nothing",
+		previous: assignedWorkerID,
+	},
+}
+
+func TestOrderService_AssignWorkerNotifies(t *testing.T) {
+	for _, tt := range testOrderServiceAssignWorkerNotifies {
+		t.Run(tt.testName, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			fields := initOrderServiceFields(ctrl)
+			notifier := newRecordingNotifier(tt.notifyErr)
+			orderService := services.NewOrderService(fields.orderRepoMock, fields.workerRepoMock, fields.taskRepoMock, fields.userRepoMock, fields.logger, 0, models.TaxSettings{}, models.RoundHalfUp, models.DeadlineSettings{}, nil, notifier)
+
+			orderID := uuid.New()
+			deadline := time.Now().AddDate(0, 0, 1)
+			task := models.Task{ID: uuid.New(), Name: "window cleaning"}
+			worker := &models.Worker{ID: assignedWorkerID, Role: models.MasterRole, NotificationsOptOut: tt.optOut}
+
+			status := models.NewOrderStatus
+			if tt.previous != uuid.Nil {
+				status = models.InProgressOrderStatus
+			}
+
+			fields.orderRepoMock.EXPECT().GetOrderByID(orderID).Return(&models.Order{ID: orderID, WorkerID: tt.previous, Status: status, Address: "address", Deadline: deadline}, nil).Times(2)
+			fields.workerRepoMock.EXPECT().GetWorkerByID(assignedWorkerID).Return(worker, nil).AnyTimes()
+			fields.workerRepoMock.EXPECT().IsAvailable(assignedWorkerID, deadline).Return(true, nil).AnyTimes()
+			if tt.previous != assignedWorkerID {
+				fields.orderRepoMock.EXPECT().Update(gomock.Any()).DoAndReturn(func(order *models.Order) (*models.Order, error) {
+					return order, nil
+				})
+			}
+			if tt.notified {
+				fields.orderRepoMock.EXPECT().GetTasksInOrder(orderID).Return([]models.Task{task}, nil)
+				fields.orderRepoMock.EXPECT().GetTaskQuantity(orderID, task.ID).Return(3, nil)
+			}
+
+			err := orderService.AssignWorker(testManager, orderID, assignedWorkerID)
+			assert.NoError(t, err)
+
+			if !tt.notified {
+				assert.Empty(t, notifier.waitFor(t, 0))
+				return
+			}
+			assert.Equal(t, []uuid.UUID{assignedWorkerID}, notifier.waitFor(t, 1))
+			info := notifier.dispatches[0]
+			assert.Equal(t, orderID, info.OrderID)
+			assert.Equal(t, "address", info.Address)
+			assert.Equal(t, deadline, info.Deadline)
+			assert.Len(t, info.Tasks, 1)
+			assert.Equal(t, task.Name, info.Tasks[0].Task.Name)
+			assert.Equal(t, 3, info.Tasks[0].Quantity)
+		})
+	}
+}
+
+var testOrderServiceAssignWorker = []struct {
+	testName  string
+	inputData struct {
+		order  models.Order
+		worker models.Worker
+	}
+	prepare     func(fields *orderServiceFields, order models.Order, worker models.Worker)
+	checkOutput func(t *testing.T, updated *models.Order, err error)
+}{
+	{
+		testName: "new order moves to in progress",
+		inputData: struct {
+			order  models.Order
+			worker models.Worker
+		}{models.Order{ID: uuid.New(), Status: models.NewOrderStatus}, models.Worker{ID: uuid.New(), Role: models.MasterRole}},
+		prepare: func(fields *orderServiceFields, order models.Order, worker models.Worker) {
+			fields.orderRepoMock.EXPECT().GetOrderByID(order.ID).Return(&order, nil).Times(2)
+			fields.workerRepoMock.EXPECT().GetWorkerByID(worker.ID).Return(&worker, nil).Times(2)
+			fields.workerRepoMock.EXPECT().IsAvailable(worker.ID, order.Deadline).Return(true, nil)
+		},
+		checkOutput: func(t *testing.T, updated *models.Order, err error) {
+			assert.NoError(t, err)
+			assert.Equal(t, models.InProgressOrderStatus, updated.Status)
+			assert.NotNil(t, updated.AssignedAt)
+		},
+	},
+	{
+		testName: "assigned order is reassigned to another master",
+		inputData: struct {
+			order  models.Order
+			worker models.Worker
+		}{models.Order{ID: uuid.New(), Status: models.InProgressOrderStatus, WorkerID: uuid.New()}, models.Worker{ID: uuid.New(), Role: models.MasterRole}},
+		prepare: func(fields *orderServiceFields, order models.Order, worker models.Worker) {
+			fields.orderRepoMock.EXPECT().GetOrderByID(order.ID).Return(&order, nil).Times(2)
+			fields.workerRepoMock.EXPECT().GetWorkerByID(worker.ID).Return(&worker, nil).Times(2)
+			fields.workerRepoMock.EXPECT().IsAvailable(worker.ID, order.Deadline).Return(true, nil)
+		},
+		checkOutput: func(t *testing.T, updated *models.Order, err error) {
+			assert.NoError(t, err)
+			assert.Equal(t, models.InProgressOrderStatus, updated.Status)
+		},
+	},
+	{
+		testName: "master available at the deadline",
+		inputData: struct {
+			order  models.Order
+			worker models.Worker
+		}{models.Order{ID: uuid.New(), Status: models.NewOrderStatus, Deadline: time.Now().AddDate(0, 0, 3)}, models.Worker{ID: uuid.New(), Role: models.MasterRole}},
+		prepare: func(fields *orderServiceFields, order models.Order, worker models.Worker) {
+			fields.orderRepoMock.EXPECT().GetOrderByID(order.ID).Return(&order, nil).Times(2)
+			fields.workerRepoMock.EXPECT().GetWorkerByID(worker.ID).Return(&worker, nil).Times(2)
+			fields.workerRepoMock.EXPECT().IsAvailable(worker.ID, order.Deadline).Return(true, nil)
+		},
+		checkOutput: func(t *testing.T, updated *models.Order, err error) {
+			assert.NoError(t, err)
+			assert.Equal(t, models.InProgressOrderStatus, updated.Status)
+		},
+	},
+	{
+		testName: "master unavailable at the deadline",
+		inputData: struct {
+			order  models.Order
+			worker models.Worker
+		}{models.Order{ID: uuid.New(), Status: models.NewOrderStatus, Deadline: time.Now().AddDate(0, 0, 3)}, models.Worker{ID: uuid.New(), Role: models.MasterRole}},
+		prepare: func(fields *orderServiceFields, order models.Order, worker models.Worker) {
+			fields.orderRepoMock.EXPECT().GetOrderByID(order.ID).Return(&order, nil).Times(2)
+			fields.workerRepoMock.EXPECT().GetWorkerByID(worker.ID).Return(&worker, nil).Times(2)
+			fields.workerRepoMock.EXPECT().IsAvailable(worker.ID, order.Deadline).Return(false, nil)
+		},
+		checkOutput: func(t *testing.T, updated *models.Order, err error) {
+			assert.Equal(t, service_errors.WorkerUnavailable, err)
+			assert.Nil(t, updated)
+		},
+	},
+	{
+		testName: "manager cannot be assigned",
+		inputData: struct {
+			order  models.Order
+			worker models.Worker
+		}{models.Order{ID: uuid.New(), Status: models.NewOrderStatus}, models.Worker{ID: uuid.New(), Role: models.ManagerRole}},
+		prepare: func(fields *orderServiceFields, order models.Order, worker models.Worker) {
+			fields.orderRepoMock.EXPECT().GetOrderByID(order.ID).Return(&order, nil)
+			fields.workerRepoMock.EXPECT().GetWorkerByID(worker.ID).Return(&worker, nil)
+		},
+		checkOutput: func(t *testing.T, updated *models.Order, err error) {
+			assert.Equal(t, service_errors.InvalidRole, err)
+			assert.Nil(t, updated)
+		},
+	},
+	{
+		testName: "completed order",
+		inputData: struct {
+			order  models.Order
+			worker models.Worker
+		}{models.Order{ID: uuid.New(), Status: models.CompletedOrderStatus, WorkerID: uuid.New()}, models.Worker{ID: uuid.New(), Role: models.MasterRole}},
+		prepare: func(fields *orderServiceFields, order models.Order, worker models.Worker) {
+			fields.orderRepoMock.EXPECT().GetOrderByID(order.ID).Return(&order, nil)
+		},
+		checkOutput: func(t *testing.T, updated *models.Order, err error) {
+			assert.Equal(t, service_errors.OrderIsAlreadyCompleted, err)
+			assert.Nil(t, updated)
+		},
+	},
+	{
+		testName: "cancelled order",
+		inputData: struct {
+			order  models.Order
+			worker models.Worker
+		}{models.Order{ID: uuid.New(), Status: models.CancelledOrderStatus}, models.Worker{ID: uuid.New(), Role: models.MasterRole}},
+		prepare: func(fields *orderServiceFields, order models.Order, worker models.Worker) {
+			fields.orderRepoMock.EXPECT().GetOrderByID(order.ID).Return(&order, nil)
+		},
+		checkOutput: func(t *testing.T, updated *models.Order, err error) {
+			assert.Equal(t, service_errors.OrderIsCancelled, err)
+			assert.Nil(t, updated)
+		},
+	},
+	{
+		testName: "no worker",
+		inputData: struct {
+			order  models.Order
+			worker models.Worker
+		}{models.Order{ID: uuid.New(), Status: models.NewOrderStatus}, models.Worker{}},
+		prepare: func(fields *orderServiceFields, order models.Order, worker models.Worker) {},
+		checkOutput: func(t *testing.T, updated *models.Order, err error) {
+			assert.Equal(t, fmt.Errorf("SERVICE: Invalid input"), err)
+			assert.Nil(t, updated)
+		},
+	},
+}
+
+func TestOrderService_AssignWorker(t *testing.T) {
+	for _, tt := range testOrderServiceAssignWorker {
+		t.Run(tt.testName, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			fields := initOrderServiceFields(ctrl)
+			orderService := initOrderService(fields)
+
+			var updated *models.Order
+			fields.orderRepoMock.EXPECT().Update(gomock.Any()).DoAndReturn(func(order *models.Order) (*models.Order, error) {
+				updated = order
+				return order, nil
+			}).AnyTimes()
+			tt.prepare(fields, tt.inputData.order, tt.inputData.worker)
+
+			err := orderService.AssignWorker(testManager, tt.inputData.order.ID, tt.inputData.worker.ID)
+			if updated != nil {
+				assert.Equal(t, tt.inputData.worker.ID, updated.WorkerID)
+			}
+			tt.checkOutput(t, updated, err)
+		})
+	}
+}
+
+var testOrderServiceUnassignWorker = []struct {
+	testName    string
+	order       models.Order
+	checkOutput func(t *testing.T, updated *models.Order, err error)
+}{
+	{
+		testName: "order in progress moves back to new",
+		order:    models.Order{ID: uuid.New(), Status: models.InProgressOrderStatus, WorkerID: uuid.New()},
+		checkOutput: func(t *testing.T, updated *models.Order, err error) {
+			assert.NoError(t, err)
+			assert.Equal(t, uuid.Nil, updated.WorkerID)
+			assert.Equal(t, models.NewOrderStatus, updated.Status)
+		},
+	},
+	{
+		testName: "unassigned order is left as is",
+		order:    models.Order{ID: uuid.New(), Status: models.NewOrderStatus},
+		checkOutput: func(t *testing.T, updated *models.Order, err error) {
+			assert.NoError(t, err)
+			assert.Nil(t, updated)
+		},
+	},
+	{
+		testName: "completed order",
+		order:    models.Order{ID: uuid.New(), Status: models.CompletedOrderStatus, WorkerID: uuid.New()},
+		checkOutput: func(t *testing.T, updated *models.Order, err error) {
+			assert.Equal(t, service_errors.OrderIsAlreadyCompleted, err)
+			assert.Nil(t, updated)
+		},
+	},
+}
+
+func TestOrderService_UnassignWorker(t *testing.T) {
+	for _, tt := range testOrderServiceUnassignWorker {
+		t.Run(tt.testName, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			fields := initOrderServiceFields(ctrl)
+			orderService := initOrderService(fields)
+
+			order := tt.order
+			var updated *models.Order
+			fields.orderRepoMock.EXPECT().GetOrderByID(order.ID).Return(&order, nil).AnyTimes()
+			fields.orderRepoMock.EXPECT().Update(gomock.Any()).DoAndReturn(func(order *models.Order) (*models.Order, error) {
+				updated = order
+				return order, nil
+			}).AnyTimes()
+
+			err := orderService.UnassignWorker(testManager, order.ID)
+			tt.checkOutput(t, updated, err)
+		})
+	}
+}
+
+var testOrderServiceUpdateStatusTransitions = []struct {
+	testName string
+	from     int
+	to       int
+	allowed  bool
+}{
+	{"new to in progress", models.NewOrderStatus, models.InProgressOrderStatus, true},
+	{"new to cancelled", models.NewOrderStatus, models.CancelledOrderStatus, true},
+	{"new to completed", models.NewOrderStatus, models.CompletedOrderStatus, false},
+	{"in progress to new", models.InProgressOrderStatus, models.NewOrderStatus, true},
+	{"in progress to completed", models.InProgressOrderStatus, models.CompletedOrderStatus, true},
+	{"in progress to cancelled", models.InProgressOrderStatus, models.CancelledOrderStatus, true},
+	{"completed to new", models.CompletedOrderStatus, models.NewOrderStatus, false},
+	{"completed to in progress", models.CompletedOrderStatus, models.InProgressOrderStatus, false},
+	{"completed to cancelled", models.CompletedOrderStatus, models.CancelledOrderStatus, false},
+	{"cancelled to new", models.CancelledOrderStatus, models.NewOrderStatus, false},
+	{"cancelled to in progress", models.CancelledOrderStatus, models.InProgressOrderStatus, false},
+	{"cancelled to completed", models.CancelledOrderStatus, models.CompletedOrderStatus, false},
+}
+
+func TestOrderService_UpdateStatusTransitions(t *testing.T) {
+	for _, tt := range testOrderServiceUpdateStatusTransitions {
+		t.Run(tt.testName, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			fields := initOrderServiceFields(ctrl)
+			orderService := initOrderService(fields)
+
+			workerID := uuid.New()
+			order := models.Order{ID: uuid.New(), Status: tt.from, WorkerID: workerID}
+			fields.orderRepoMock.EXPECT().GetOrderByID(order.ID).Return(&order, nil)
+			fields.workerRepoMock.EXPECT().GetWorkerByID(workerID).Return(&models.Worker{ID: workerID, Role: models.MasterRole}, nil)
+			fields.orderRepoMock.EXPECT().GetTasksInOrder(order.ID).Return([]models.Task{{ID: uuid.New(), PricePerSingle: 100}}, nil).AnyTimes()
+			fields.orderRepoMock.EXPECT().GetTaskQuantity(order.ID, gomock.Any()).Return(1, nil).AnyTimes()
+			fields.orderRepoMock.EXPECT().GetOrderTotalPrice(order.ID).Return(100.0, nil).AnyTimes()
+			if tt.allowed {
+				fields.orderRepoMock.EXPECT().Update(gomock.Any()).DoAndReturn(func(order *models.Order) (*models.Order, error) {
+					return order, nil
+				})
+			} else {
+				fields.orderRepoMock.EXPECT().Update(gomock.Any()).Times(0)
+			}
+
+			updated, err := orderService.Update(testManager, order.ID, tt.to, 0, workerID)
+			if tt.allowed {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.to, updated.Status)
+			} else {
+				assert.Equal(t, service_errors.InvalidOrderStatus, err)
+				assert.Nil(t, updated)
+			}
+		})
+	}
+}
+
+var testOrderServiceReopenOrder = []struct {
+	testName    string
+	editor      *models.Worker
+	order       models.Order
+	checkOutput func(t *testing.T, order *models.Order, err error)
+}{
+	{
+		testName: "completed order with a worker goes back in progress",
+		order:    models.Order{ID: uuid.New(), Status: models.CompletedOrderStatus, WorkerID: uuid.New(), Rate: 5, CompletedAt: &time.Time{}},
+		checkOutput: func(t *testing.T, order *models.Order, err error) {
+			assert.NoError(t, err)
+			assert.Equal(t, models.InProgressOrderStatus, order.Status)
+			assert.Equal(t, 0, order.Rate)
+			assert.Nil(t, order.CompletedAt)
+		},
+	},
+	{
+		testName: "cancelled order without a worker becomes new",
+		order:    models.Order{ID: uuid.New(), Status: models.CancelledOrderStatus, CancelledAt: &time.Time{}},
+		checkOutput: func(t *testing.T, order *models.Order, err error) {
+			assert.NoError(t, err)
+			assert.Equal(t, models.NewOrderStatus, order.Status)
+			assert.Nil(t, order.CancelledAt)
+		},
+	},
+	{
+		testName: "open order cannot be reopened",
+		order:    models.Order{ID: uuid.New(), Status: models.InProgressOrderStatus, WorkerID: uuid.New()},
+		checkOutput: func(t *testing.T, order *models.Order, err error) {
+			assert.Equal(t, service_errors.InvalidOrderStatus, err)
+			assert.Nil(t, order)
+		},
+	},
+	{
+		testName: "master cannot reopen orders",
+		editor:   &models.Worker{ID: uuid.New(), Role: models.MasterRole},
+		order:    models.Order{ID: uuid.New(), Status: models.CompletedOrderStatus, WorkerID: uuid.New()},
+		checkOutput: func(t *testing.T, order *models.Order, err error) {
+			assert.Equal(t, service_errors.InvalidRole, err)
+			assert.Nil(t, order)
+		},
+	},
+}
+
+func TestOrderService_ReopenOrder(t *testing.T) {
+	for _, tt := range testOrderServiceReopenOrder {
+		t.Run(tt.testName, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			fields := initOrderServiceFields(ctrl)
+			orderService := initOrderService(fields)
+
+			editor := tt.editor
+			if editor == nil {
+				editor = testManager
+			}
+
+			order := tt.order
+			fields.orderRepoMock.EXPECT().GetOrderByID(order.ID).Return(&order, nil).MaxTimes(1)
+			fields.orderRepoMock.EXPECT().Update(gomock.Any()).DoAndReturn(func(order *models.Order) (*models.Order, error) {
+				return order, nil
+			}).AnyTimes()
+
+			reopened, err := orderService.ReopenOrder(editor, order.ID)
+			tt.checkOutput(t, reopened, err)
+		})
+	}
+}
+
+var testOrderServiceReschedule = []struct {
+	testName    string
+	order       models.Order
+	deadline    time.Time
+	address     string
+	updates     int
+	checkOutput func(t *testing.T, order *models.Order, err error)
+}{
+	{
+		testName: "new order is moved to the new deadline and address",
+		order:    models.Order{ID: uuid.New(), Status: models.NewOrderStatus, Address: "Old address", Deadline: time.Now().AddDate(0, 0, 2)},
+		deadline: time.Now().AddDate(0, 0, 5),
+		address:  "New address",
+		updates:  1,
+		checkOutput: func(t *testing.T, order *models.Order, err error) {
+			assert.NoError(t, err)
+			assert.Equal(t, "New address", order.Address)
+			assert.Equal(t, models.NewOrderStatus, order.Status)
+		},
+	},
+	{
+		testName: "completed order cannot be rescheduled",
+		order:    models.Order{ID: uuid.New(), Status: models.CompletedOrderStatus, Address: "Old address", Rate: 5},
+		deadline: time.Now().AddDate(0, 0, 5),
+		address:  "New address",
+		updates:  0,
+		checkOutput: func(t *testing.T, order *models.Order, err error) {
+			assert.Equal(t, service_errors.OrderIsAlreadyCompleted, err)
+			assert.Equal(t, "Old address", order.Address)
+		},
+	},
+	{
+		testName: "cancelled order cannot be rescheduled",
+		order:    models.Order{ID: uuid.New(), Status: models.CancelledOrderStatus, Address: "Old address"},
+		deadline: time.Now().AddDate(0, 0, 5),
+		address:  "New address",
+		updates:  0,
+		checkOutput: func(t *testing.T, order *models.Order, err error) {
+			assert.Equal(t, service_errors.OrderIsCancelled, err)
+		},
+	},
+}
+
+func TestOrderService_Reschedule(t *testing.T) {
+	for _, tt := range testOrderServiceReschedule {
+		t.Run(tt.testName, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			fields := initOrderServiceFields(ctrl)
+			orderService := initOrderService(fields)
+
+			order := tt.order
+			fields.orderRepoMock.EXPECT().GetOrderByID(order.ID).Return(&order, nil)
+			fields.orderRepoMock.EXPECT().Update(gomock.Any()).DoAndReturn(func(order *models.Order) (*models.Order, error) {
+				return order, nil
+			}).Times(tt.updates)
+
+			err := orderService.Reschedule(order.ID, tt.deadline, tt.address)
+			tt.checkOutput(t, &order, err)
+			if err == nil {
+				assert.True(t, tt.deadline.Equal(order.Deadline))
+			}
+		})
+	}
+}
+
+func TestOrderService_RescheduleInvalidInput(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	fields := initOrderServiceFields(ctrl)
+	orderService := initOrderService(fields)
+
+	fields.orderRepoMock.EXPECT().GetOrderByID(gomock.Any()).Times(0)
+	fields.orderRepoMock.EXPECT().Update(gomock.Any()).Times(0)
+
+	err := orderService.Reschedule(uuid.New(), time.Now().AddDate(0, 0, -1), "Address")
+	assert.ErrorIs(t, err, service_errors.DeadlineInPast)
+
+	err = orderService.Reschedule(uuid.New(), time.Now().AddDate(0, 0, 5), "")
+	assert.Error(t, err)
+}
+
+var testOrderServiceCancelOrder = []struct {
+	testName    string
+	order       models.Order
+	reason      string
+	updates     int
+	checkOutput func(t *testing.T, order *models.Order, err error)
+}{
+	{
+		testName: "new order is cancelled with a reason",
+		order:    models.Order{ID: uuid.New(), Status: models.NewOrderStatus, Address: "Address"},
+		reason:   "  Plans changed ",
+		updates:  1,
+		checkOutput: func(t *testing.T, order *models.Order, err error) {
+			assert.NoError(t, err)
+			assert.Equal(t, models.CancelledOrderStatus, order.Status)
+			assert.Equal(t, "Plans changed", order.CancellationReason)
+			assert.NotNil(t, order.CancelledAt)
+		},
+	},
+	{
+		testName: "completed order cannot be cancelled",
+		order:    models.Order{ID: uuid.New(), Status: models.CompletedOrderStatus, Address: "Address", Rate: 5},
+		reason:   "Plans changed",
+		updates:  0,
+		checkOutput: func(t *testing.T, order *models.Order, err error) {
+			assert.Equal(t, service_errors.OrderIsAlreadyCompleted, err)
+			assert.Equal(t, models.CompletedOrderStatus, order.Status)
+			assert.Empty(t, order.CancellationReason)
+			assert.Nil(t, order.CancelledAt)
+		},
+	},
+	{
+		testName: "cancelled order cannot be cancelled again",
+		order:    models.Order{ID: uuid.New(), Status: models.CancelledOrderStatus, CancellationReason: "First reason"},
+		reason:   "Second reason",
+		updates:  0,
+		checkOutput: func(t *testing.T, order *models.Order, err error) {
+			assert.Equal(t, service_errors.OrderIsCancelled, err)
+			assert.Equal(t, "First reason", order.CancellationReason)
+		},
+	},
+}
+
+func TestOrderService_CancelOrder(t *testing.T) {
+	for _, tt := range testOrderServiceCancelOrder {
+		t.Run(tt.testName, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			fields := initOrderServiceFields(ctrl)
+			orderService := initOrderService(fields)
+
+			order := tt.order
+			fields.orderRepoMock.EXPECT().GetOrderByID(order.ID).Return(&order, nil)
+			fields.orderRepoMock.EXPECT().Update(gomock.Any()).DoAndReturn(func(order *models.Order) (*models.Order, error) {
+				return order, nil
+			}).Times(tt.updates)
+
+			err := orderService.CancelOrder(order.ID, tt.reason)
+			tt.checkOutput(t, &order, err)
+		})
+	}
+}
+
+func TestOrderService_CancelOrderWithoutReason(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	fields := initOrderServiceFields(ctrl)
+	orderService := initOrderService(fields)
+
+	fields.orderRepoMock.EXPECT().GetOrderByID(gomock.Any()).Times(0)
+	fields.orderRepoMock.EXPECT().Update(gomock.Any()).Times(0)
+
+	err := orderService.CancelOrder(uuid.New(), "   ")
+	assert.Error(t, err)
+}
+
+var testOrderServiceCompletePositiveTotal = []struct {
+	testName    string
+	tasks       []models.Task
+	total       float64
+	checkOutput func(t *testing.T, order *models.Order, err error)
+}{
+	{
+		testName: "zero total is rejected",
+		tasks:    []models.Task{},
+		total:    0,
+		checkOutput: func(t *testing.T, order *models.Order, err error) {
+			assert.ErrorIs(t, err, service_errors.NonPositiveTotal)
+			assert.Nil(t, order)
+		},
+	},
+	{
+		testName: "positive total is completed",
+		tasks:    []models.Task{{ID: uuid.New(), PricePerSingle: 250}},
+		total:    500,
+		checkOutput: func(t *testing.T, order *models.Order, err error) {
+			assert.NoError(t, err)
+			assert.Equal(t, models.CompletedOrderStatus, order.Status)
+		},
+	},
+}
+
+func TestOrderService_CompletePositiveTotal(t *testing.T) {
+	for _, tt := range testOrderServiceCompletePositiveTotal {
+		t.Run(tt.testName, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			fields := initOrderServiceFields(ctrl)
+			orderService := initOrderService(fields)
+
+			workerID := uuid.New()
+			orderID := uuid.New()
+			fields.orderRepoMock.EXPECT().GetOrderByID(orderID).Return(&models.Order{ID: orderID, Status: models.InProgressOrderStatus, WorkerID: workerID}, nil)
+			fields.workerRepoMock.EXPECT().GetWorkerByID(workerID).Return(&models.Worker{ID: workerID}, nil)
+			fields.orderRepoMock.EXPECT().GetTasksInOrder(orderID).Return(tt.tasks, nil)
+			fields.orderRepoMock.EXPECT().GetTaskQuantity(orderID, gomock.Any()).Return(2, nil).Times(len(tt.tasks))
+			fields.orderRepoMock.EXPECT().GetOrderTotalPrice(orderID).Return(tt.total, nil)
+			fields.orderRepoMock.EXPECT().Update(gomock.Any()).DoAndReturn(func(order *models.Order) (*models.Order, error) {
+				return order, nil
+			}).MaxTimes(1)
+
+			order, err := orderService.Update(testManager, orderID, models.CompletedOrderStatus, 0, workerID)
+			tt.checkOutput(t, order, err)
+		})
+	}
+}
+
+var testOrderServiceGetOrdersByRateBelow = []struct {
+	testName    string
+	threshold   int
+	prepare     func(fields *orderServiceFields)
+	checkOutput func(t *testing.T, orders []models.Order, err error)
+}{
+	{
+		testName:  "low rated orders are returned",
+		threshold: 3,
+		prepare: func(fields *orderServiceFields) {
+			fields.orderRepoMock.EXPECT().GetOrdersByRateBelow(3).Return([]models.Order{{Rate: 1}, {Rate: 2}}, nil)
+		},
+		checkOutput: func(t *testing.T, orders []models.Order, err error) {
+			assert.NoError(t, err)
+			assert.Len(t, orders, 2)
+		},
+	},
+	{
+		testName:  "zero threshold",
+		threshold: 0,
+		prepare: func(fields *orderServiceFields) {
+			fields.orderRepoMock.EXPECT().GetOrdersByRateBelow(gomock.Any()).Times(0)
+		},
+		checkOutput: func(t *testing.T, orders []models.Order, err error) {
+			assert.Equal(t, service_errors.RatingOutOfRange, err)
+			assert.Nil(t, orders)
+		},
+	},
+	{
+		testName:  "threshold above the maximum rating",
+		threshold: 6,
+		prepare: func(fields *orderServiceFields) {
+			fields.orderRepoMock.EXPECT().GetOrdersByRateBelow(gomock.Any()).Times(0)
+		},
+		checkOutput: func(t *testing.T, orders []models.Order, err error) {
+			assert.Equal(t, service_errors.RatingOutOfRange, err)
+			assert.Nil(t, orders)
+		},
+	},
+	{
+		testName:  "retrieval error",
+		threshold: 3,
+		prepare: func(fields *orderServiceFields) {
+			fields.orderRepoMock.EXPECT().GetOrdersByRateBelow(3).Return(nil, repository_errors.SelectError)
+		},
+		checkOutput: func(t *testing.T, orders []models.Order, err error) {
+			assert.Equal(t, repository_errors.SelectError, err)
+			assert.Nil(t, orders)
+		},
+	},
+}
+
+func TestOrderService_GetOrdersByRateBelow(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	fields := initOrderServiceFields(ctrl)
+	orderService := initOrderService(fields)
+
+	for _, tt := range testOrderServiceGetOrdersByRateBelow {
+		t.Run(tt.testName, func(t *testing.T) {
+			tt.prepare(fields)
+			orders, err := orderService.GetOrdersByRateBelow(tt.threshold)
+			tt.checkOutput(t, orders, err)
+		})
+	}
+}
+
+var batchAttachedTask = models.Task{ID: uuid.New(), Name: "Мытье окон", PricePerSingle: 500}
+var batchNewTask = models.Task{ID: uuid.New(), Name: "Химчистка ковра", PricePerSingle: 1500}
+
+var testOrderServiceAddTasks = []struct {
+	testName    string
+	tasks       []models.OrderedTask
+	prepare     func(fields *orderServiceFields)
+	checkOutput func(t *testing.T, err error)
+}{
+	{
+		testName: "all tasks are added",
+		tasks:    []models.OrderedTask{{Task: &batchNewTask, Quantity: 2}},
+		prepare: func(fields *orderServiceFields) {
+			fields.orderRepoMock.EXPECT().AddTasksToOrder(gomock.Any(), []models.OrderedTask{{Task: &batchNewTask, Quantity: 2}}).Return(nil)
+			fields.orderRepoMock.EXPECT().RequoteOrder(gomock.Any(), models.RoundHalfUp).Return(nil)
+		},
+		checkOutput: func(t *testing.T, err error) {
+			assert.NoError(t, err)
+		},
+	},
+	{
+		testName: "already attached task fails the whole batch",
+		tasks:    []models.OrderedTask{{Task: &batchNewTask, Quantity: 1}, {Task: &batchAttachedTask, Quantity: 1}},
+		prepare: func(fields *orderServiceFields) {
+			fields.orderRepoMock.EXPECT().AddTasksToOrder(gomock.Any(), gomock.Any()).Times(0)
+		},
+		checkOutput: func(t *testing.T, err error) {
+			assert.Equal(t, service_errors.TaskIsAlreadyAttachedToOrder, err)
+		},
+	},
+	{
+		testName: "task listed twice",
+		tasks:    []models.OrderedTask{{Task: &batchNewTask, Quantity: 1}, {Task: &batchNewTask, Quantity: 3}},
+		prepare: func(fields *orderServiceFields) {
+			fields.orderRepoMock.EXPECT().AddTasksToOrder(gomock.Any(), gomock.Any()).Times(0)
+		},
+		checkOutput: func(t *testing.T, err error) {
+			assert.Equal(t, service_errors.TaskIsAlreadyAttachedToOrder, err)
+		},
+	},
+	{
+		testName: "non-positive quantity",
+		tasks:    []models.OrderedTask{{Task: &batchNewTask, Quantity: 0}},
+		prepare: func(fields *orderServiceFields) {
+			fields.orderRepoMock.EXPECT().AddTasksToOrder(gomock.Any(), gomock.Any()).Times(0)
+		},
+		checkOutput: func(t *testing.T, err error) {
+			assert.Error(t, err)
+		},
+	},
+	{
+		testName: "attached concurrently",
+		tasks:    []models.OrderedTask{{Task: &batchNewTask, Quantity: 1}},
+		prepare: func(fields *orderServiceFields) {
+			fields.orderRepoMock.EXPECT().AddTasksToOrder(gomock.Any(), gomock.Any()).Return(repository_errors.AlreadyExists)
+		},
+		checkOutput: func(t *testing.T, err error) {
+			assert.Equal(t, service_errors.TaskIsAlreadyAttachedToOrder, err)
+		},
+	},
+	{
+		testName: "empty batch",
+		tasks:    []models.OrderedTask{},
+		prepare: func(fields *orderServiceFields) {
+			fields.orderRepoMock.EXPECT().AddTasksToOrder(gomock.Any(), gomock.Any()).Times(0)
+		},
+		checkOutput: func(t *testing.T, err error) {
+			assert.Equal(t, service_errors.EmptyTasksOrder, err)
+		},
+	},
+}
+
+func TestOrderService_AddTasks(t *testing.T) {
+	for _, tt := range testOrderServiceAddTasks {
+		t.Run(tt.testName, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			fields := initOrderServiceFields(ctrl)
+			orderService := initOrderService(fields)
+
+			orderID := uuid.New()
+			fields.orderRepoMock.EXPECT().GetOrderByID(orderID).Return(&models.Order{ID: orderID, Status: models.NewOrderStatus}, nil).AnyTimes()
+			fields.orderRepoMock.EXPECT().GetTasksInOrder(orderID).Return([]models.Task{batchAttachedTask}, nil).AnyTimes()
+			fields.taskRepoMock.EXPECT().GetTasksByIDs(gomock.Any()).DoAndReturn(existingTasks).AnyTimes()
+			tt.prepare(fields)
+
+			err := orderService.AddTasks(orderID, tt.tasks)
+			tt.checkOutput(t, err)
+		})
+	}
+}
+
+var testOrderServiceCompletionEvent = []struct {
+	testName    string
+	from        int
+	to          int
+	expectEvent bool
+}{
+	{
+		testName:    "completion publishes event",
+		from:        models.InProgressOrderStatus,
+		to:          models.CompletedOrderStatus,
+		expectEvent: true,
+	},
+	{
+		testName: "taking into work publishes nothing",
+		from:     models.NewOrderStatus,
+		to:       models.InProgressOrderStatus,
+	},
+	{
+		testName: "cancellation publishes nothing",
+		from:     models.InProgressOrderStatus,
+		to:       models.CancelledOrderStatus,
+	},
+}
+
+func TestOrderService_CompletionEvent(t *testing.T) {
+	tasks := []models.Task{{ID: uuid.New(), PricePerSingle: 250}, {ID: uuid.New(), PricePerSingle: 100}}
+	quantities := map[uuid.UUID]int{tasks[0].ID: 2, tasks[1].ID: 1}
+
+	for _, tt := range testOrderServiceCompletionEvent {
+		t.Run(tt.testName, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			fields := initOrderServiceFields(ctrl)
+			notifier := newRecordingNotifier(nil)
+			orderService := services.NewOrderService(fields.orderRepoMock, fields.workerRepoMock, fields.taskRepoMock, fields.userRepoMock, fields.logger, 0, models.TaxSettings{Rate: 0.2}, models.RoundHalfUp, models.DeadlineSettings{}, nil, notifier)
+
+			workerID := uuid.New()
+			orderID := uuid.New()
+			fields.orderRepoMock.EXPECT().GetOrderByID(orderID).Return(&models.Order{ID: orderID, Status: tt.from, WorkerID: workerID}, nil)
+			fields.workerRepoMock.EXPECT().GetWorkerByID(workerID).Return(&models.Worker{ID: workerID}, nil)
+			fields.orderRepoMock.EXPECT().GetTasksInOrder(orderID).Return(tasks, nil).AnyTimes()
+			fields.orderRepoMock.EXPECT().GetTaskQuantity(orderID, gomock.Any()).DoAndReturn(func(_ uuid.UUID, taskID uuid.UUID) (int, error) {
+				return quantities[taskID], nil
+			}).AnyTimes()
+			fields.orderRepoMock.EXPECT().GetOrderedTasks(orderID).Return(orderedLines(tasks, 2, 1), nil).AnyTimes()
+			fields.orderRepoMock.EXPECT().GetOrderTotalPrice(orderID).Return(600.0, nil).AnyTimes()
+			fields.orderRepoMock.EXPECT().Update(gomock.Any()).DoAndReturn(func(order *models.Order) (*models.Order, error) {
+				return order, nil
+			})
+
+			order, err := orderService.Update(testManager, orderID, tt.to, 0, workerID)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.to, order.Status)
+
+			if !tt.expectEvent {
+				assert.Empty(t, notifier.events)
+				return
+			}
+
+			notifier.waitFor(t, 1)
+			notifier.mu.Lock()
+			defer notifier.mu.Unlock()
+			assert.Len(t, notifier.events, 1)
+			assert.Equal(t, models.OrderCompletedEvent, notifier.events[0].Type)
+			assert.Equal(t, orderID, notifier.events[0].OrderID)
+			assert.Equal(t, 720.0, notifier.events[0].GrandTotal)
+			assert.Equal(t, *order.CompletedAt, notifier.events[0].OccurredAt)
+		})
+	}
+}
+
+func TestOrderService_CompletionEventWithoutReceipt(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	fields := initOrderServiceFields(ctrl)
+	notifier := newRecordingNotifier(nil)
+	orderService := services.NewOrderService(fields.orderRepoMock, fields.workerRepoMock, fields.taskRepoMock, fields.userRepoMock, fields.logger, 0, models.TaxSettings{}, models.RoundHalfUp, models.DeadlineSettings{}, nil, notifier)
+
+	workerID := uuid.New()
+	orderID := uuid.New()
+	tasks := []models.Task{{ID: uuid.New(), PricePerSingle: 250}}
+	fields.orderRepoMock.EXPECT().GetOrderByID(orderID).Return(&models.Order{ID: orderID, Status: models.InProgressOrderStatus, WorkerID: workerID, QuotedTotal: 480}, nil)
+	fields.workerRepoMock.EXPECT().GetWorkerByID(workerID).Return(&models.Worker{ID: workerID}, nil)
+	fields.orderRepoMock.EXPECT().GetTasksInOrder(orderID).Return(tasks, nil)
+	fields.orderRepoMock.EXPECT().GetOrderedTasks(orderID).Return(nil, repository_errors.SelectError)
+	fields.orderRepoMock.EXPECT().GetTaskQuantity(orderID, gomock.Any()).Return(2, nil).AnyTimes()
+	fields.orderRepoMock.EXPECT().GetOrderTotalPrice(orderID).Return(500.0, nil).AnyTimes()
+	fields.orderRepoMock.EXPECT().Update(gomock.Any()).DoAndReturn(func(order *models.Order) (*models.Order, error) {
+		return order, nil
+	})
+
+	_, err := orderService.Update(testManager, orderID, models.CompletedOrderStatus, 0, workerID)
+	assert.NoError(t, err)
+
+	notifier.waitFor(t, 1)
+	notifier.mu.Lock()
+	defer notifier.mu.Unlock()
+	assert.Len(t, notifier.events, 1)
+	assert.Equal(t, 480.0, notifier.events[0].GrandTotal)
+}
+
+var testOrderServiceGetOrderDetails = []struct {
+	testName    string
+	order       models.Order
+	prepare     func(fields *orderServiceFields, order models.Order)
+	checkOutput func(t *testing.T, details *models.OrderDetails, err error)
+}{
+	{
+		testName: "assigned order with tasks",
+		order:    models.Order{ID: uuid.New(), WorkerID: uuid.New(), Status: models.InProgressOrderStatus},
+		prepare: func(fields *orderServiceFields, order models.Order) {
+			fields.orderRepoMock.EXPECT().GetOrderedTasks(order.ID).Return([]models.OrderedTask{
+				{Task: &models.Task{ID: uuid.New(), Name: "Dusting", PricePerSingle: 99.99}, Quantity: 3},
+				{Task: &models.Task{ID: uuid.New(), Name: "Mopping", PricePerSingle: 250}, Quantity: 2},
+			}, nil)
+			fields.workerRepoMock.EXPECT().GetWorkerByID(order.WorkerID).Return(&models.Worker{ID: order.WorkerID, Password: "hash"}, nil)
+		},
+		checkOutput: func(t *testing.T, details *models.OrderDetails, err error) {
+			assert.NoError(t, err)
+			assert.Len(t, details.Tasks, 2)
+			assert.Equal(t, 3, details.Tasks[0].Quantity)
+			assert.Equal(t, 2, details.Tasks[1].Quantity)
+			assert.Equal(t, 799.97, details.TotalPrice)
+			assert.Equal(t, details.Order.WorkerID, details.Worker.ID)
+			assert.Empty(t, details.Worker.Password)
+		},
+	},
+	{
+		testName: "unassigned order has no worker",
+		order:    models.Order{ID: uuid.New(), Status: models.NewOrderStatus},
+		prepare: func(fields *orderServiceFields, order models.Order) {
+			fields.orderRepoMock.EXPECT().GetOrderedTasks(order.ID).Return([]models.OrderedTask{
+				{Task: &models.Task{ID: uuid.New(), Name: "Windows", PricePerSingle: 300}, Quantity: 1},
+			}, nil)
+			fields.workerRepoMock.EXPECT().GetWorkerByID(gomock.Any()).Times(0)
+		},
+		checkOutput: func(t *testing.T, details *models.OrderDetails, err error) {
+			assert.NoError(t, err)
+			assert.Nil(t, details.Worker)
+			assert.Equal(t, 300.0, details.TotalPrice)
+		},
+	},
+	{
+		testName: "tasks retrieval fails",
+		order:    models.Order{ID: uuid.New(), Status: models.NewOrderStatus},
+		prepare: func(fields *orderServiceFields, order models.Order) {
+			fields.orderRepoMock.EXPECT().GetOrderedTasks(order.ID).Return(nil, repository_errors.SelectError)
+		},
+		checkOutput: func(t *testing.T, details *models.OrderDetails, err error) {
+			assert.Equal(t, repository_errors.SelectError, err)
+			assert.Nil(t, details)
+		},
+	},
+}
+
+func TestOrderService_GetOrderDetails(t *testing.T) {
+	for _, tt := range testOrderServiceGetOrderDetails {
+		t.Run(tt.testName, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			fields := initOrderServiceFields(ctrl)
+			orderService := initOrderService(fields)
+
+			order := tt.order
+			fields.orderRepoMock.EXPECT().GetOrderByID(order.ID).Return(&order, nil)
+			fields.orderRepoMock.EXPECT().GetTaskQuantity(gomock.Any(), gomock.Any()).Times(0)
+			tt.prepare(fields, order)
+
+			details, err := orderService.GetOrderDetails(order.ID)
+			tt.checkOutput(t, details, err)
+		})
+	}
+}
+
+func TestOrderService_PriceTiers(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	fields := initOrderServiceFields(ctrl)
+	orderService := initOrderService(fields)
+
+	// the first line reaches its largest tier, the second stays below its tier
+	// and the third task has no tiers at all
+	tieredTask := models.Task{ID: uuid.New(), Name: "Windows", PricePerSingle: 100}
+	belowTierTask := models.Task{ID: uuid.New(), Name: "Chairs", PricePerSingle: 50}
+	regularTask := models.Task{ID: uuid.New(), Name: "Carpets", PricePerSingle: 30}
+	fields.priceTiers[tieredTask.ID] = []models.PriceTier{{MinQuantity: 5, Price: 80}, {MinQuantity: 10, Price: 70}}
+	fields.priceTiers[belowTierTask.ID] = []models.PriceTier{{MinQuantity: 5, Price: 40}}
+
+	orderedTasks := []models.OrderedTask{
+		{Task: &tieredTask, Quantity: 12},
+		{Task: &belowTierTask, Quantity: 2},
+		{Task: &regularTask, Quantity: 3},
+	}
+	expectedTotal := 70.0*12 + 50*2 + 30*3
+
+	// the tiered line was ordered before the tiers were added, so its stored
+	// unit price is the regular one and the current tiers must not change it
+	storedTasks := []models.OrderedTask{
+		{Task: &tieredTask, Quantity: 12},
+		{Task: &models.Task{ID: belowTierTask.ID, Name: belowTierTask.Name, PricePerSingle: 45}, Quantity: 2},
+		{Task: &regularTask, Quantity: 3},
+	}
+	storedTotal := 100.0*12 + 45*2 + 30*3
+
+	orderID := uuid.New()
+	fields.orderRepoMock.EXPECT().GetOrderByID(orderID).Return(&models.Order{ID: orderID, Status: models.NewOrderStatus}, nil).AnyTimes()
+	fields.orderRepoMock.EXPECT().GetOrderedTasks(orderID).Return(storedTasks, nil).AnyTimes()
+
+	t.Run("receipt uses stored prices", func(t *testing.T) {
+		receipt, err := orderService.BuildReceipt(orderID)
+		assert.NoError(t, err)
+		assert.Equal(t, 100.0, receipt.Lines[0].UnitPrice)
+		assert.Equal(t, 45.0, receipt.Lines[1].UnitPrice)
+		assert.Equal(t, 30.0, receipt.Lines[2].UnitPrice)
+		assert.Equal(t, storedTotal, receipt.GrandTotal)
+	})
+
+	t.Run("order details use stored prices", func(t *testing.T) {
+		details, err := orderService.GetOrderDetails(orderID)
+		assert.NoError(t, err)
+		assert.Equal(t, storedTotal, details.TotalPrice)
+	})
+
+	t.Run("quote", func(t *testing.T) {
+		userID := uuid.New()
+		fields.taskRepoMock.EXPECT().GetTasksByIDs(gomock.Any()).DoAndReturn(storedCatalog(tieredTask, belowTierTask, regularTask))
+		fields.userRepoMock.EXPECT().GetUserByID(userID).Return(&models.User{ID: userID}, nil)
+		fields.orderRepoMock.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(order *models.Order, _ []models.OrderedTask) (*models.Order, error) {
+			return order, nil
+		})
+		fields.orderRepoMock.EXPECT().DeleteDraft(userID).Return(nil)
+
+		created, err := orderService.CreateOrder(userID, "address", time.Now().AddDate(0, 0, 1), orderedTasks)
+		assert.NoError(t, err)
+		assert.Equal(t, expectedTotal, created.Order.QuotedTotal)
+	})
+}
+
+var testOrderServiceDeadlineBounds = []struct {
+	testName    string
+	deadline    time.Time
+	checkOutput func(t *testing.T, order *models.OrderWithTasks, err error)
+}{
+	{
+		testName: "one hour out is too soon",
+		deadline: time.Now().Add(time.Hour),
+		checkOutput: func(t *testing.T, order *models.OrderWithTasks, err error) {
+			assert.Nil(t, order)
+			assert.ErrorIs(t, err, service_errors.InvalidDeadlineOrder)
+			assert.ErrorIs(t, err, service_errors.DeadlineTooSoon)
+		},
+	},
+	{
+		testName: "one year out is too far",
+		deadline: time.Now().AddDate(1, 0, 0),
+		checkOutput: func(t *testing.T, order *models.OrderWithTasks, err error) {
+			assert.Nil(t, order)
+			assert.ErrorIs(t, err, service_errors.InvalidDeadlineOrder)
+			assert.ErrorIs(t, err, service_errors.DeadlineTooFar)
+		},
+	},
+	{
+		testName: "three days out is accepted",
+		deadline: time.Now().AddDate(0, 0, 3),
+		checkOutput: func(t *testing.T, order *models.OrderWithTasks, err error) {
+			assert.NoError(t, err)
+			assert.NotNil(t, order)
+		},
+	},
+}
+
+func TestOrderService_DeadlineBounds(t *testing.T) {
+	for _, tt := range testOrderServiceDeadlineBounds {
+		t.Run(tt.testName, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			fields := initOrderServiceFields(ctrl)
+			deadlines := models.DeadlineSettings{MinLeadTime: 24 * time.Hour, MaxHorizon: 90 * 24 * time.Hour}
+			orderService := services.NewOrderService(fields.orderRepoMock, fields.workerRepoMock, fields.taskRepoMock, fields.userRepoMock, fields.logger, 0, models.TaxSettings{}, models.RoundHalfUp, deadlines, nil, nil)
+
+			task := models.Task{ID: uuid.New(), PricePerSingle: 100}
+			userID := uuid.New()
+			fields.taskRepoMock.EXPECT().GetTasksByIDs([]uuid.UUID{task.ID}).Return(map[uuid.UUID]models.Task{task.ID: task}, nil).AnyTimes()
+			fields.userRepoMock.EXPECT().GetUserByID(userID).Return(&models.User{ID: userID}, nil).AnyTimes()
+			fields.orderRepoMock.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(order *models.Order, _ []models.OrderedTask) (*models.Order, error) {
+				return order, nil
+			}).MaxTimes(1)
+			fields.orderRepoMock.EXPECT().DeleteDraft(userID).Return(nil).MaxTimes(1)
+
+			order, err := orderService.CreateOrder(userID, "address", tt.deadline, []models.OrderedTask{{Task: &task, Quantity: 1}})
+			tt.checkOutput(t, order, err)
+		})
+	}
+}
+
+var testServiceArea = []string{"Москва", " Химки ", "141"}
+
+var testOrderServiceServiceArea = []struct {
+	testName    string
+	address     string
+	checkOutput func(t *testing.T, order *models.OrderWithTasks, err error)
+}{
+	{
+		testName: "city in the service area",
+		address:  "г. Москва, ул. Ленина, д. 1",
+		checkOutput: func(t *testing.T, order *models.OrderWithTasks, err error) {
+			assert.NoError(t, err)
+			assert.NotNil(t, order)
+		},
+	},
+	{
+		testName: "postal code in the service area",
+		address:  "141407, Московская обл., ул. Победы, д. 5",
+		checkOutput: func(t *testing.T, order *models.OrderWithTasks, err error) {
+			assert.NoError(t, err)
+			assert.NotNil(t, order)
+		},
+	},
+	{
+		testName: "city name in a different case",
+		address:  "ХИМКИ, ул. Мира, д. 3",
+		checkOutput: func(t *testing.T, order *models.OrderWithTasks, err error) {
+			assert.NoError(t, err)
+			assert.NotNil(t, order)
+		},
+	},
+	{
+		testName: "address outside the service area",
+		address:  "190000, г. Санкт-Петербург, Невский пр., д. 1",
+		checkOutput: func(t *testing.T, order *models.OrderWithTasks, err error) {
+			assert.Nil(t, order)
+			assert.Equal(t, service_errors.InvalidAddressOrder, err)
+		},
+	},
+}
+
+func TestOrderService_ServiceArea(t *testing.T) {
+	for _, tt := range testOrderServiceServiceArea {
+		t.Run(tt.testName, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			fields := initOrderServiceFields(ctrl)
+			orderService := services.NewOrderService(fields.orderRepoMock, fields.workerRepoMock, fields.taskRepoMock, fields.userRepoMock, fields.logger, 0, models.TaxSettings{}, models.RoundHalfUp, models.DeadlineSettings{}, testServiceArea, nil)
+
+			task := models.Task{ID: uuid.New(), PricePerSingle: 100}
+			userID := uuid.New()
+			fields.taskRepoMock.EXPECT().GetTasksByIDs([]uuid.UUID{task.ID}).Return(map[uuid.UUID]models.Task{task.ID: task}, nil).MaxTimes(1)
+			fields.userRepoMock.EXPECT().GetUserByID(userID).Return(&models.User{ID: userID}, nil).MaxTimes(1)
+			fields.orderRepoMock.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(order *models.Order, _ []models.OrderedTask) (*models.Order, error) {
+				return order, nil
+			}).MaxTimes(1)
+			fields.orderRepoMock.EXPECT().DeleteDraft(userID).Return(nil).MaxTimes(1)
+
+			order, err := orderService.CreateOrder(userID, tt.address, time.Now().AddDate(0, 0, 1), []models.OrderedTask{{Task: &task, Quantity: 1}})
+			tt.checkOutput(t, order, err)
+		})
+	}
+}
+
+func TestOrderService_RescheduleOutsideServiceArea(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	fields := initOrderServiceFields(ctrl)
+	orderService := services.NewOrderService(fields.orderRepoMock, fields.workerRepoMock, fields.taskRepoMock, fields.userRepoMock, fields.logger, 0, models.TaxSettings{}, models.RoundHalfUp, models.DeadlineSettings{}, testServiceArea, nil)
+
+	fields.orderRepoMock.EXPECT().GetOrderByID(gomock.Any()).Times(0)
+	fields.orderRepoMock.EXPECT().Update(gomock.Any()).Times(0)
+
+	err := orderService.Reschedule(uuid.New(), time.Now().AddDate(0, 0, 2), "Тверь, ул. Советская, д. 10")
+	assert.Equal(t, service_errors.InvalidAddressOrder, err)
+}
+
+func TestOrderService_CloneOrder(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	fields := initOrderServiceFields(ctrl)
+	orderService := initOrderService(fields)
+
+	windows := models.Task{ID: uuid.New(), Name: "Windows", PricePerSingle: 100}
+	carpets := models.Task{ID: uuid.New(), Name: "Carpets", PricePerSingle: 250}
+	source := &models.Order{ID: uuid.New(), UserID: uuid.New(), WorkerID: uuid.New(), Status: models.CompletedOrderStatus,
+		Address: "address", Deadline: time.Now().AddDate(0, -1, 0), Rate: 5, QuotedTotal: 800}
+	sourceTasks := []models.OrderedTask{{Task: &windows, Quantity: 3}, {Task: &carpets, Quantity: 2}}
+	deadline := time.Now().AddDate(0, 0, 3)
+
+	fields.orderRepoMock.EXPECT().GetOrderByID(source.ID).Return(source, nil)
+	fields.orderRepoMock.EXPECT().GetOrderedTasks(source.ID).Return(sourceTasks, nil)
+	fields.taskRepoMock.EXPECT().GetTasksByIDs([]uuid.UUID{windows.ID, carpets.ID}).Return(map[uuid.UUID]models.Task{windows.ID: windows, carpets.ID: carpets}, nil).Times(2)
+	fields.userRepoMock.EXPECT().GetUserByID(source.UserID).Return(&models.User{ID: source.UserID}, nil)
+	fields.orderRepoMock.EXPECT().DeleteDraft(gomock.Any()).Times(0)
+
+	var created []models.OrderedTask
+	fields.orderRepoMock.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(order *models.Order, orderedTasks []models.OrderedTask) (*models.Order, error) {
+		created = orderedTasks
+		order.ID = uuid.New()
+		return order, nil
+	})
+
+	order, err := orderService.CloneOrder(source.ID, deadline)
+	assert.NoError(t, err)
+	assert.NotEqual(t, source.ID, order.ID)
+	assert.Equal(t, models.NewOrderStatus, order.Status)
+	assert.Equal(t, source.UserID, order.UserID)
+	assert.Equal(t, uuid.Nil, order.WorkerID)
+	assert.Equal(t, source.Address, order.Address)
+	assert.Equal(t, deadline, order.Deadline)
+	assert.Equal(t, 0, order.Rate)
+	assert.Equal(t, 800.0, order.QuotedTotal)
+
+	assert.Len(t, created, len(sourceTasks))
+	for i, task := range sourceTasks {
+		assert.Equal(t, task.Task.ID, created[i].Task.ID)
+		assert.Equal(t, task.Quantity, created[i].Quantity)
+	}
+}
+
+func TestOrderService_CloneOrderInvalidDeadline(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	fields := initOrderServiceFields(ctrl)
+	orderService := initOrderService(fields)
+
+	windows := models.Task{ID: uuid.New(), PricePerSingle: 100}
+	source := &models.Order{ID: uuid.New(), UserID: uuid.New(), Status: models.NewOrderStatus, Address: "address"}
+
+	fields.orderRepoMock.EXPECT().GetOrderByID(source.ID).Return(source, nil)
+	fields.orderRepoMock.EXPECT().GetOrderedTasks(source.ID).Return([]models.OrderedTask{{Task: &windows, Quantity: 1}}, nil)
+	fields.taskRepoMock.EXPECT().GetTasksByIDs(gomock.Any()).DoAndReturn(existingTasks)
+	fields.orderRepoMock.EXPECT().Create(gomock.Any(), gomock.Any()).Times(0)
+
+	order, err := orderService.CloneOrder(source.ID, time.Now().AddDate(0, 0, -1))
+	assert.Error(t, err)
+	assert.Nil(t, order)
+}
+
+func TestOrderService_CloneOrderArchivedTask(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	fields := initOrderServiceFields(ctrl)
+	orderService := initOrderService(fields)
+
+	windows := models.Task{ID: uuid.New(), PricePerSingle: 100}
+	archived := models.Task{ID: uuid.New(), PricePerSingle: 200, Archived: true}
+	source := &models.Order{ID: uuid.New(), UserID: uuid.New(), Status: models.CancelledOrderStatus, Address: "address"}
+
+	fields.orderRepoMock.EXPECT().GetOrderByID(source.ID).Return(source, nil)
+	fields.orderRepoMock.EXPECT().GetOrderedTasks(source.ID).Return([]models.OrderedTask{{Task: &windows, Quantity: 1}, {Task: &archived, Quantity: 1}}, nil)
+	fields.taskRepoMock.EXPECT().GetTasksByIDs(gomock.Any()).Return(map[uuid.UUID]models.Task{windows.ID: windows, archived.ID: archived}, nil)
+	fields.orderRepoMock.EXPECT().Create(gomock.Any(), gomock.Any()).Times(0)
+
+	order, err := orderService.CloneOrder(source.ID, time.Now().AddDate(0, 0, 3))
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), archived.ID.String())
+	assert.Nil(t, order)
+}
+
+func TestOrderService_ExportImportUserOrders(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	fields := initOrderServiceFields(ctrl)
+	orderService := initOrderService(fields)
+
+	sourceUserID := uuid.New()
+	targetUserID := uuid.New()
+	workerID := uuid.New()
+	completedAt := time.Date(2024, 5, 2, 15, 0, 0, 0, time.UTC)
+	windows := models.Task{ID: uuid.New(), Name: "Windows", PricePerSingle: 100}
+	carpets := models.Task{ID: uuid.New(), Name: "Carpets", PricePerSingle: 180}
+
+	orders := []models.Order{
+		{ID: uuid.New(), UserID: sourceUserID, Status: models.NewOrderStatus, Address: "First address",
+			CreationDate: time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC), Deadline: time.Date(2024, 6, 1, 10, 0, 0, 0, time.UTC), QuotedTotal: 300},
+		{ID: uuid.New(), UserID: sourceUserID, WorkerID: workerID, Status: models.CompletedOrderStatus, Address: "Second address", Rate: 4,
+			CreationDate: time.Date(2024, 4, 1, 10, 0, 0, 0, time.UTC), Deadline: time.Date(2024, 5, 3, 10, 0, 0, 0, time.UTC), QuotedTotal: 1200,
+			AssignedAt: &completedAt, CompletedAt: &completedAt},
+	}
+	orderedTasks := map[uuid.UUID][]models.OrderedTask{
+		orders[0].ID: {{Task: &windows, Quantity: 3}},
+		orders[1].ID: {{Task: &windows, Quantity: 1}, {Task: &carpets, Quantity: 5}},
+	}
+
+	fields.userRepoMock.EXPECT().GetUserByID(gomock.Any()).DoAndReturn(func(id uuid.UUID) (*models.User, error) {
+		return &models.User{ID: id}, nil
+	}).AnyTimes()
+	fields.orderRepoMock.EXPECT().GetAllOrdersByUserID(sourceUserID).Return(orders, nil)
+	fields.orderRepoMock.EXPECT().GetOrderedTasks(gomock.Any()).DoAndReturn(func(orderID uuid.UUID) ([]models.OrderedTask, error) {
+		return orderedTasks[orderID], nil
+	}).Times(len(orders))
+	fields.taskRepoMock.EXPECT().GetTasksByIDs(gomock.Any()).DoAndReturn(existingTasks).AnyTimes()
+	fields.workerRepoMock.EXPECT().GetWorkerByID(workerID).Return(&models.Worker{ID: workerID}, nil)
+
+	var imported []models.OrderWithTasks
+	fields.orderRepoMock.EXPECT().ImportOrders(gomock.Any()).DoAndReturn(func(orders []models.OrderWithTasks) error {
+		imported = orders
+		return nil
+	})
+
+	data, err := orderService.ExportUserOrders(sourceUserID)
+	assert.NoError(t, err)
+
+	count, err := orderService.ImportUserOrders(targetUserID, data)
+	assert.NoError(t, err)
+	assert.Equal(t, len(orders), count)
+	assert.Len(t, imported, len(orders))
+
+	for i, order := range orders {
+		restored := imported[i].Order
+		assert.Equal(t, targetUserID, restored.UserID)
+		assert.Equal(t, order.WorkerID, restored.WorkerID)
+		assert.Equal(t, order.Status, restored.Status)
+		assert.Equal(t, order.Address, restored.Address)
+		assert.Equal(t, order.Rate, restored.Rate)
+		assert.Equal(t, order.QuotedTotal, restored.QuotedTotal)
+		assert.True(t, order.CreationDate.Equal(restored.CreationDate))
+		assert.True(t, order.Deadline.Equal(restored.Deadline))
+		assert.Equal(t, order.CompletedAt == nil, restored.CompletedAt == nil)
+
+		assert.Len(t, imported[i].Tasks, len(orderedTasks[order.ID]))
+		for j, task := range orderedTasks[order.ID] {
+			assert.Equal(t, task.Task.ID, imported[i].Tasks[j].Task.ID)
+			assert.Equal(t, task.Quantity, imported[i].Tasks[j].Quantity)
+			assert.Equal(t, task.Task.PricePerSingle, imported[i].Tasks[j].Task.PricePerSingle)
+		}
+	}
+}
+
+var testOrderServiceImportUserOrdersFail = []struct {
+	testName string
+	data     string
+	prepare  func(fields *orderServiceFields)
+}{
+	{
+		testName: "malformed document",
+		data:     `{"orders": [`,
+		prepare:  func(fields *orderServiceFields) {},
+	},
+	{
+		testName: "order without tasks",
+		data:     `{"orders": [{"status": 1, "address": "Address", "tasks": []}]}`,
+		prepare:  func(fields *orderServiceFields) {},
+	},
+	{
+		testName: "rated order that is not completed",
+		data:     `{"orders": [{"status": 1, "address": "Address", "rate": 5, "tasks": [{"task_id": "` + uuid.NewString() + `", "quantity": 1}]}]}`,
+		prepare:  func(fields *orderServiceFields) {},
+	},
+	{
+		testName: "missing task",
+		data:     `{"orders": [{"status": 1, "address": "Address", "tasks": [{"task_id": "` + uuid.NewString() + `", "quantity": 1}]}]}`,
+		prepare: func(fields *orderServiceFields) {
+			fields.taskRepoMock.EXPECT().GetTasksByIDs(gomock.Any()).Return(map[uuid.UUID]models.Task{}, nil)
+		},
+	},
+}
+
+func TestOrderService_ImportUserOrdersFail(t *testing.T) {
+	for _, tt := range testOrderServiceImportUserOrdersFail {
+		t.Run(tt.testName, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			fields := initOrderServiceFields(ctrl)
+			orderService := initOrderService(fields)
+
+			userID := uuid.New()
+			fields.userRepoMock.EXPECT().GetUserByID(userID).Return(&models.User{ID: userID}, nil)
+			fields.orderRepoMock.EXPECT().ImportOrders(gomock.Any()).Times(0)
+			tt.prepare(fields)
+
+			count, err := orderService.ImportUserOrders(userID, []byte(tt.data))
+			assert.Error(t, err)
+			assert.Equal(t, 0, count)
+		})
+	}
+}
+
+func TestOrderService_AssignmentRequiresManager(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	fields := initOrderServiceFields(ctrl)
+	orderService := initOrderService(fields)
+
+	master := &models.Worker{ID: uuid.New(), Role: models.MasterRole}
+	fields.orderRepoMock.EXPECT().GetOrderByID(gomock.Any()).Times(0)
+	fields.orderRepoMock.EXPECT().Update(gomock.Any()).Times(0)
+	fields.orderRepoMock.EXPECT().Filter(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+
+	err := orderService.AssignWorker(master, uuid.New(), master.ID)
+	assert.Equal(t, service_errors.InvalidRole, err)
+
+	err = orderService.UnassignWorker(master, uuid.New())
+	assert.Equal(t, service_errors.InvalidRole, err)
+
+	assignments, err := orderService.AutoAssignUnassigned(nil)
+	assert.Equal(t, service_errors.InvalidRole, err)
+	assert.Nil(t, assignments)
+}
+
+func TestOrderService_Tags(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	fields := initOrderServiceFields(ctrl)
+	orderService := initOrderService(fields)
+
+	// the repository mock keeps tags in memory the way the order_tags table does
+	orderID := uuid.New()
+	stored := map[string]bool{}
+	fields.orderRepoMock.EXPECT().GetOrderByID(orderID).Return(&models.Order{ID: orderID}, nil).AnyTimes()
+	fields.orderRepoMock.EXPECT().AddTag(orderID, gomock.Any()).DoAndReturn(func(_ uuid.UUID, tag string) error {
+		stored[tag] = true
+		return nil
+	}).AnyTimes()
+	fields.orderRepoMock.EXPECT().RemoveTag(orderID, gomock.Any()).DoAndReturn(func(_ uuid.UUID, tag string) error {
+		if !stored[tag] {
+			return repository_errors.DoesNotExist
+		}
+		delete(stored, tag)
+		return nil
+	}).AnyTimes()
+	fields.orderRepoMock.EXPECT().GetTags(orderID).DoAndReturn(func(uuid.UUID) ([]string, error) {
+		tags := []string{}
+		for tag := range stored {
+			tags = append(tags, tag)
+		}
+		sort.Strings(tags)
+		return tags, nil
+	}).AnyTimes()
+
+	t.Run("duplicate tags are deduped", func(t *testing.T) {
+		assert.NoError(t, orderService.AddTag(orderID, "VIP"))
+		assert.NoError(t, orderService.AddTag(orderID, "  vip "))
+		assert.NoError(t, orderService.AddTag(orderID, "Urgent"))
+
+		tags, err := orderService.GetTags(orderID)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"urgent", "vip"}, tags)
+	})
+
+	t.Run("tag is removed", func(t *testing.T) {
+		assert.NoError(t, orderService.RemoveTag(orderID, "URGENT"))
+
+		tags, err := orderService.GetTags(orderID)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"vip"}, tags)
+
+		assert.Equal(t, repository_errors.DoesNotExist, orderService.RemoveTag(orderID, "urgent"))
+	})
+
+	t.Run("invalid tags", func(t *testing.T) {
+		assert.Equal(t, service_errors.InvalidTag, orderService.AddTag(orderID, "   "))
+		assert.Equal(t, service_errors.InvalidTag, orderService.AddTag(orderID, strings.Repeat("я", 33)))
+		assert.NoError(t, orderService.AddTag(orderID, strings.Repeat("я", 32)))
+		assert.Equal(t, service_errors.InvalidTag, orderService.RemoveTag(orderID, ""))
+	})
+
+	t.Run("missing order", func(t *testing.T) {
+		missingID := uuid.New()
+		fields.orderRepoMock.EXPECT().GetOrderByID(missingID).Return(nil, repository_errors.DoesNotExist).Times(2)
+
+		assert.Equal(t, repository_errors.DoesNotExist, orderService.AddTag(missingID, "vip"))
+		tags, err := orderService.GetTags(missingID)
+		assert.Equal(t, repository_errors.DoesNotExist, err)
+		assert.Nil(t, tags)
+	})
+}
+
+func TestOrderService_GetOrdersByTag(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	fields := initOrderServiceFields(ctrl)
+	orderService := initOrderService(fields)
+
+	tagged := []models.Order{{ID: uuid.New()}, {ID: uuid.New()}}
+	fields.orderRepoMock.EXPECT().GetOrdersByTag("complaint").Return(tagged, nil)
+
+	orders, err := orderService.GetOrdersByTag(" Complaint")
+	assert.NoError(t, err)
+	assert.Equal(t, tagged, orders)
+
+	fields.orderRepoMock.EXPECT().GetOrdersByTag(gomock.Any()).Times(0)
+	orders, err = orderService.GetOrdersByTag("")
+	assert.Equal(t, service_errors.InvalidTag, err)
+	assert.Nil(t, orders)
+}
+
+func TestOrderService_GetOverdueOrders(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	fields := initOrderServiceFields(ctrl)
+	orderService := initOrderService(fields)
+
+	overdue := []models.Order{
+		{ID: uuid.New(), Status: models.InProgressOrderStatus, Deadline: time.Now().Add(-48 * time.Hour)},
+		{ID: uuid.New(), Status: models.NewOrderStatus, Deadline: time.Now().Add(-time.Hour)},
+	}
+	fields.orderRepoMock.EXPECT().GetOverdueOrders(gomock.Any()).DoAndReturn(func(now time.Time) ([]models.Order, error) {
+		assert.WithinDuration(t, time.Now(), now, time.Minute)
+		return overdue, nil
+	})
+
+	orders, err := orderService.GetOverdueOrders()
+	assert.NoError(t, err)
+	assert.Equal(t, overdue, orders)
+
+	fields.orderRepoMock.EXPECT().GetOverdueOrders(gomock.Any()).Return(nil, repository_errors.SelectError)
+	orders, err = orderService.GetOverdueOrders()
+	assert.Equal(t, repository_errors.SelectError, err)
+	assert.Nil(t, orders)
+}
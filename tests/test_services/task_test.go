@@ -1,41 +1,73 @@
 package test_services
 
 import (
+	"bytes"
 	"github.com/charmbracelet/log"
 	"github.com/golang/mock/gomock"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
+	"math"
 	"os"
+	"strings"
+	"teamdev/exchange_rate"
 	"teamdev/internal/models"
 	"teamdev/internal/repository/repository_errors"
 	services "teamdev/internal/services"
 	"teamdev/internal/services/service_errors"
 	"teamdev/internal/services/service_interfaces"
+	mock_exchange_rate "teamdev/tests/exchange_rate_mocks"
 	mock_repository_interfaces "teamdev/tests/repository_mocks"
 	"testing"
+	"time"
 )
 
 type taskServiceFields struct {
-	taskRepoMock *mock_repository_interfaces.MockITaskRepository
-	logger       *log.Logger
+	taskRepoMock     *mock_repository_interfaces.MockITaskRepository
+	categoryRepoMock *mock_repository_interfaces.MockICategoryRepository
+	rateProviderMock *mock_exchange_rate.MockRateProvider
+	categories       map[int]models.Category
+	logger           *log.Logger
 }
 
 func initTaskServiceFields(ctrl *gomock.Controller) *taskServiceFields {
 	taskRepoMock := mock_repository_interfaces.NewMockITaskRepository(ctrl)
+	categoryRepoMock := mock_repository_interfaces.NewMockICategoryRepository(ctrl)
+	rateProviderMock := mock_exchange_rate.NewMockRateProvider(ctrl)
 	f, err := os.OpenFile("tests.log", os.O_RDWR|os.O_CREATE|os.O_APPEND, 0666)
 	if err != nil {
 		log.Fatal(err)
 	}
 	logger := log.New(f)
 
+	// the category repository behaves like the seeded categories table
+	categories := make(map[int]models.Category)
+	for i, name := range models.TaskCategories {
+		categories[i+1] = models.Category{ID: i + 1, Name: name}
+	}
+	categoryRepoMock.EXPECT().GetByID(gomock.Any()).DoAndReturn(func(id int) (*models.Category, error) {
+		category, ok := categories[id]
+		if !ok {
+			return nil, repository_errors.DoesNotExist
+		}
+		return &category, nil
+	}).AnyTimes()
+	categoryRepoMock.EXPECT().Create(gomock.Any()).DoAndReturn(func(category *models.Category) (*models.Category, error) {
+		category.ID = len(categories) + 1
+		categories[category.ID] = *category
+		return category, nil
+	}).AnyTimes()
+
 	return &taskServiceFields{
-		taskRepoMock: taskRepoMock,
-		logger:       logger,
+		taskRepoMock:     taskRepoMock,
+		categoryRepoMock: categoryRepoMock,
+		rateProviderMock: rateProviderMock,
+		categories:       categories,
+		logger:           logger,
 	}
 }
 
 func initTaskService(fields *taskServiceFields) service_interfaces.ITaskService {
-	return services.NewTaskService(fields.taskRepoMock, fields.logger)
+	return services.NewTaskService(fields.taskRepoMock, fields.categoryRepoMock, nil, fields.logger, false, models.RoundHalfUp)
 }
 
 var testTaskCreateSuccess = []struct {
@@ -117,7 +149,7 @@ var testTaskCreateFail = []struct {
 		}{name: "", price: 100.0, category: 1},
 		prepare: func(fields *taskServiceFields) {},
 		checkOutput: func(t *testing.T, task *models.Task, err error) {
-			assert.Error(t, err)
+			assert.ErrorIs(t, err, service_errors.InvalidName)
 			assert.Nil(t, task)
 		},
 	},
@@ -130,7 +162,7 @@ var testTaskCreateFail = []struct {
 		}{name: "Test Task", price: -1.0, category: 1},
 		prepare: func(fields *taskServiceFields) {},
 		checkOutput: func(t *testing.T, task *models.Task, err error) {
-			assert.Error(t, err)
+			assert.ErrorIs(t, err, service_errors.InvalidPrice)
 			assert.Nil(t, task)
 		},
 	},
@@ -666,6 +698,81 @@ func TestTaskServiceChangePrice(t *testing.T) {
 	}
 }
 
+var testTaskPricePrecision = []struct {
+	testName    string
+	price       float64
+	roundPrices bool
+	checkOutput func(t *testing.T, task *models.Task, err error)
+}{
+	{
+		testName: "two decimal places are accepted",
+		price:    10.99,
+		checkOutput: func(t *testing.T, task *models.Task, err error) {
+			assert.NoError(t, err)
+			assert.Equal(t, 10.99, task.PricePerSingle)
+		},
+	},
+	{
+		testName: "over-precise price is rejected",
+		price:    10.999,
+		checkOutput: func(t *testing.T, task *models.Task, err error) {
+			assert.Nil(t, task)
+			assert.Equal(t, service_errors.InvalidPrice, err)
+		},
+	},
+	{
+		testName:    "over-precise price is rounded",
+		price:       10.995,
+		roundPrices: true,
+		checkOutput: func(t *testing.T, task *models.Task, err error) {
+			assert.NoError(t, err)
+			assert.Equal(t, 11.0, task.PricePerSingle)
+		},
+	},
+	{
+		testName:    "price rounded to zero is rejected",
+		price:       0.001,
+		roundPrices: true,
+		checkOutput: func(t *testing.T, task *models.Task, err error) {
+			assert.Nil(t, task)
+			assert.Error(t, err)
+		},
+	},
+}
+
+func TestTaskServicePricePrecision(t *testing.T) {
+	for _, tt := range testTaskPricePrecision {
+		t.Run("create: "+tt.testName, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			fields := initTaskServiceFields(ctrl)
+			taskService := services.NewTaskService(fields.taskRepoMock, fields.categoryRepoMock, nil, fields.logger, tt.roundPrices, models.RoundHalfUp)
+			fields.taskRepoMock.EXPECT().Create(gomock.Any()).DoAndReturn(func(task *models.Task) (*models.Task, error) {
+				return task, nil
+			}).AnyTimes()
+
+			task, err := taskService.Create("Test Task", tt.price, 1)
+			tt.checkOutput(t, task, err)
+		})
+
+		t.Run("update: "+tt.testName, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			fields := initTaskServiceFields(ctrl)
+			taskService := services.NewTaskService(fields.taskRepoMock, fields.categoryRepoMock, nil, fields.logger, tt.roundPrices, models.RoundHalfUp)
+			fields.taskRepoMock.EXPECT().GetTaskByID(gomock.Any()).Return(&models.Task{ID: uuid.New(), Name: "Test Task", PricePerSingle: 5, Category: 1}, nil)
+			fields.taskRepoMock.EXPECT().Update(gomock.Any()).DoAndReturn(func(task *models.Task) (*models.Task, error) {
+				return task, nil
+			}).AnyTimes()
+
+			task, err := taskService.Update(uuid.New(), 1, "Test Task", tt.price)
+			tt.checkOutput(t, task, err)
+		})
+	}
+}
+
 var testTaskDeleteSuccess = []struct {
 	testName  string
 	inputData struct {
@@ -1106,3 +1213,914 @@ func TestTaskServiceGetInCategory(t *testing.T) {
 		})
 	}
 }
+
+var pricedTasks = []models.Task{
+	{ID: uuid.New(), Name: "Мытье окон", PricePerSingle: 1000, Category: 3},
+	{ID: uuid.New(), Name: "Химчистка ковра", PricePerSingle: 2500, Category: 6},
+}
+
+var testTaskServiceGetTasksInCurrency = []struct {
+	testName  string
+	inputData struct {
+		code string
+	}
+	prepare     func(fields *taskServiceFields)
+	checkOutput func(t *testing.T, tasks []models.TaskPriced, err error)
+}{
+	{
+		testName: "prices are converted",
+		inputData: struct {
+			code string
+		}{"usd"},
+		prepare: func(fields *taskServiceFields) {
+			fields.rateProviderMock.EXPECT().GetRate("USD").Return(0.01, nil)
+			fields.taskRepoMock.EXPECT().GetAllTasks().Return(pricedTasks, nil)
+		},
+		checkOutput: func(t *testing.T, tasks []models.TaskPriced, err error) {
+			assert.NoError(t, err)
+			assert.Len(t, tasks, 2)
+			assert.Equal(t, pricedTasks[0].ID, tasks[0].Task.ID)
+			assert.Equal(t, "USD", tasks[0].Currency)
+			assert.InDelta(t, 10.0, tasks[0].Price, 1e-9)
+			assert.InDelta(t, 25.0, tasks[1].Price, 1e-9)
+		},
+	},
+	{
+		testName: "unknown currency",
+		inputData: struct {
+			code string
+		}{"XYZ"},
+		prepare: func(fields *taskServiceFields) {
+			fields.rateProviderMock.EXPECT().GetRate("XYZ").Return(0.0, exchange_rate.UnknownCurrency)
+		},
+		checkOutput: func(t *testing.T, tasks []models.TaskPriced, err error) {
+			assert.Error(t, err)
+			assert.Nil(t, tasks)
+			assert.Equal(t, exchange_rate.UnknownCurrency, err)
+		},
+	},
+	{
+		testName: "empty currency code",
+		inputData: struct {
+			code string
+		}{"  "},
+		prepare: func(fields *taskServiceFields) {},
+		checkOutput: func(t *testing.T, tasks []models.TaskPriced, err error) {
+			assert.Error(t, err)
+			assert.Nil(t, tasks)
+		},
+	},
+	{
+		testName: "get all tasks error",
+		inputData: struct {
+			code string
+		}{"EUR"},
+		prepare: func(fields *taskServiceFields) {
+			fields.rateProviderMock.EXPECT().GetRate("EUR").Return(0.01, nil)
+			fields.taskRepoMock.EXPECT().GetAllTasks().Return(nil, repository_errors.SelectError)
+		},
+		checkOutput: func(t *testing.T, tasks []models.TaskPriced, err error) {
+			assert.Error(t, err)
+			assert.Nil(t, tasks)
+			assert.Equal(t, repository_errors.SelectError, err)
+		},
+	},
+}
+
+func TestTaskServiceGetTasksInCurrency(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	fields := initTaskServiceFields(ctrl)
+	taskService := services.NewTaskService(fields.taskRepoMock, fields.categoryRepoMock, fields.rateProviderMock, fields.logger, false, models.RoundHalfUp)
+
+	for _, tt := range testTaskServiceGetTasksInCurrency {
+		t.Run(tt.testName, func(t *testing.T) {
+			tt.prepare(fields)
+			tasks, err := taskService.GetTasksInCurrency(tt.inputData.code)
+			tt.checkOutput(t, tasks, err)
+		})
+	}
+}
+
+func TestTaskServiceGetTasksInCurrencyUnconfigured(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	fields := initTaskServiceFields(ctrl)
+	taskService := initTaskService(fields)
+
+	fields.taskRepoMock.EXPECT().GetAllTasks().Return(pricedTasks, nil)
+
+	tasks, err := taskService.GetTasksInCurrency("USD")
+	assert.NoError(t, err)
+	assert.Len(t, tasks, 2)
+	for i, task := range tasks {
+		assert.Equal(t, pricedTasks[i].PricePerSingle, task.Price)
+		assert.Equal(t, "USD", task.Currency)
+	}
+}
+
+var testTaskServiceArchiveCategory = []struct {
+	testName  string
+	inputData struct {
+		category int
+		force    bool
+	}
+	prepare     func(fields *taskServiceFields)
+	checkOutput func(t *testing.T, archived int, err error)
+}{
+	{
+		testName: "clean archive",
+		inputData: struct {
+			category int
+			force    bool
+		}{3, false},
+		prepare: func(fields *taskServiceFields) {
+			fields.taskRepoMock.EXPECT().ArchiveCategory(3, false).Return(4, nil)
+		},
+		checkOutput: func(t *testing.T, archived int, err error) {
+			assert.NoError(t, err)
+			assert.Equal(t, 4, archived)
+		},
+	},
+	{
+		testName: "tasks are part of open orders",
+		inputData: struct {
+			category int
+			force    bool
+		}{3, false},
+		prepare: func(fields *taskServiceFields) {
+			fields.taskRepoMock.EXPECT().ArchiveCategory(3, false).Return(0, repository_errors.ReferencedByOpenOrders)
+		},
+		checkOutput: func(t *testing.T, archived int, err error) {
+			assert.Error(t, err)
+			assert.Equal(t, 0, archived)
+			assert.Equal(t, service_errors.TasksInOpenOrders, err)
+		},
+	},
+	{
+		testName: "forced archive",
+		inputData: struct {
+			category int
+			force    bool
+		}{3, true},
+		prepare: func(fields *taskServiceFields) {
+			fields.taskRepoMock.EXPECT().ArchiveCategory(3, true).Return(4, nil)
+		},
+		checkOutput: func(t *testing.T, archived int, err error) {
+			assert.NoError(t, err)
+			assert.Equal(t, 4, archived)
+		},
+	},
+	{
+		testName: "invalid category",
+		inputData: struct {
+			category int
+			force    bool
+		}{42, false},
+		prepare: func(fields *taskServiceFields) {},
+		checkOutput: func(t *testing.T, archived int, err error) {
+			assert.Error(t, err)
+			assert.Equal(t, service_errors.InvalidCategory, err)
+		},
+	},
+	{
+		testName: "archive error",
+		inputData: struct {
+			category int
+			force    bool
+		}{3, true},
+		prepare: func(fields *taskServiceFields) {
+			fields.taskRepoMock.EXPECT().ArchiveCategory(3, true).Return(0, repository_errors.UpdateError)
+		},
+		checkOutput: func(t *testing.T, archived int, err error) {
+			assert.Error(t, err)
+			assert.Equal(t, repository_errors.UpdateError, err)
+		},
+	},
+}
+
+func TestTaskServiceArchiveCategory(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	fields := initTaskServiceFields(ctrl)
+	taskService := initTaskService(fields)
+
+	for _, tt := range testTaskServiceArchiveCategory {
+		t.Run(tt.testName, func(t *testing.T) {
+			tt.prepare(fields)
+			archived, err := taskService.ArchiveCategory(tt.inputData.category, tt.inputData.force)
+			tt.checkOutput(t, archived, err)
+		})
+	}
+}
+
+var testTaskServiceAdjustCategoryPrices = []struct {
+	testName  string
+	inputData struct {
+		category   int
+		multiplier float64
+	}
+	prepare     func(fields *taskServiceFields)
+	checkOutput func(t *testing.T, adjusted int, err error)
+}{
+	{
+		testName: "ten percent surcharge",
+		inputData: struct {
+			category   int
+			multiplier float64
+		}{3, 1.1},
+		prepare: func(fields *taskServiceFields) {
+			fields.taskRepoMock.EXPECT().AdjustCategoryPrices(3, 1.1, models.RoundHalfUp).Return(2, nil)
+		},
+		checkOutput: func(t *testing.T, adjusted int, err error) {
+			assert.NoError(t, err)
+			assert.Equal(t, 2, adjusted)
+		},
+	},
+	{
+		testName: "zero multiplier",
+		inputData: struct {
+			category   int
+			multiplier float64
+		}{3, 0},
+		prepare: func(fields *taskServiceFields) {},
+		checkOutput: func(t *testing.T, adjusted int, err error) {
+			assert.ErrorIs(t, err, service_errors.InvalidMultiplier)
+			assert.Equal(t, 0, adjusted)
+		},
+	},
+	{
+		testName: "negative multiplier",
+		inputData: struct {
+			category   int
+			multiplier float64
+		}{3, -1.1},
+		prepare: func(fields *taskServiceFields) {},
+		checkOutput: func(t *testing.T, adjusted int, err error) {
+			assert.ErrorIs(t, err, service_errors.InvalidMultiplier)
+		},
+	},
+	{
+		testName: "not a number multiplier",
+		inputData: struct {
+			category   int
+			multiplier float64
+		}{3, math.NaN()},
+		prepare: func(fields *taskServiceFields) {},
+		checkOutput: func(t *testing.T, adjusted int, err error) {
+			assert.ErrorIs(t, err, service_errors.InvalidMultiplier)
+		},
+	},
+	{
+		testName: "invalid category",
+		inputData: struct {
+			category   int
+			multiplier float64
+		}{42, 1.1},
+		prepare: func(fields *taskServiceFields) {},
+		checkOutput: func(t *testing.T, adjusted int, err error) {
+			assert.ErrorIs(t, err, service_errors.InvalidCategory)
+		},
+	},
+	{
+		testName: "update error",
+		inputData: struct {
+			category   int
+			multiplier float64
+		}{3, 0.9},
+		prepare: func(fields *taskServiceFields) {
+			fields.taskRepoMock.EXPECT().AdjustCategoryPrices(3, 0.9, models.RoundHalfUp).Return(0, repository_errors.UpdateError)
+		},
+		checkOutput: func(t *testing.T, adjusted int, err error) {
+			assert.Equal(t, repository_errors.UpdateError, err)
+			assert.Equal(t, 0, adjusted)
+		},
+	},
+}
+
+func TestTaskServiceAdjustCategoryPrices(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	fields := initTaskServiceFields(ctrl)
+	taskService := initTaskService(fields)
+
+	for _, tt := range testTaskServiceAdjustCategoryPrices {
+		t.Run(tt.testName, func(t *testing.T) {
+			tt.prepare(fields)
+			adjusted, err := taskService.AdjustCategoryPrices(tt.inputData.category, tt.inputData.multiplier)
+			tt.checkOutput(t, adjusted, err)
+		})
+	}
+
+	t.Run("configured rounding mode is used", func(t *testing.T) {
+		taskService := services.NewTaskService(fields.taskRepoMock, fields.categoryRepoMock, nil, fields.logger, true, models.RoundDown)
+		fields.taskRepoMock.EXPECT().AdjustCategoryPrices(3, 1.1, models.RoundDown).Return(2, nil)
+
+		adjusted, err := taskService.AdjustCategoryPrices(3, 1.1)
+		assert.NoError(t, err)
+		assert.Equal(t, 2, adjusted)
+	})
+}
+
+var testTaskGetAllWithCategoryNames = []struct {
+	testName    string
+	prepare     func(fields *taskServiceFields)
+	checkOutput func(t *testing.T, tasks []models.TaskWithCategory, err error)
+}{
+	{
+		testName: "category names are returned",
+		prepare: func(fields *taskServiceFields) {
+			fields.taskRepoMock.EXPECT().GetAllTasksWithCategoryNames().Return([]models.TaskWithCategory{
+				{Task: models.Task{ID: uuid.New(), Name: "Test Task 1", Category: 1}, CategoryName: "Мытье окон"},
+				{Task: models.Task{ID: uuid.New(), Name: "Test Task 2", Category: 42}, CategoryName: models.UnknownCategoryName},
+			}, nil)
+		},
+		checkOutput: func(t *testing.T, tasks []models.TaskWithCategory, err error) {
+			assert.NoError(t, err)
+			assert.Len(t, tasks, 2)
+			assert.Equal(t, "Мытье окон", tasks[0].CategoryName)
+			assert.Equal(t, models.UnknownCategoryName, tasks[1].CategoryName)
+		},
+	},
+	{
+		testName: "repository error",
+		prepare: func(fields *taskServiceFields) {
+			fields.taskRepoMock.EXPECT().GetAllTasksWithCategoryNames().Return(nil, repository_errors.SelectError)
+		},
+		checkOutput: func(t *testing.T, tasks []models.TaskWithCategory, err error) {
+			assert.Equal(t, repository_errors.SelectError, err)
+			assert.Nil(t, tasks)
+		},
+	},
+}
+
+func TestTaskServiceGetAllWithCategoryNames(t *testing.T) {
+	for _, tt := range testTaskGetAllWithCategoryNames {
+		t.Run(tt.testName, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			fields := initTaskServiceFields(ctrl)
+			taskService := initTaskService(fields)
+
+			tt.prepare(fields)
+
+			tasks, err := taskService.GetAllTasksWithCategoryNames()
+			tt.checkOutput(t, tasks, err)
+		})
+	}
+}
+
+var testTaskServiceUpsertByName = []struct {
+	testName    string
+	tasks       []models.Task
+	prepare     func(fields *taskServiceFields)
+	checkOutput func(t *testing.T, created int, updated int, err error)
+}{
+	{
+		testName: "mix of new and existing tasks",
+		tasks: []models.Task{
+			{Name: "Window cleaning", PricePerSingle: 150, Category: 1},
+			{Name: "carpet cleaning", PricePerSingle: 300, Category: 2},
+			{Name: "Oven cleaning", PricePerSingle: 200, Category: 1},
+		},
+		prepare: func(fields *taskServiceFields) {
+			fields.taskRepoMock.EXPECT().UpsertByName(gomock.Len(3)).Return(1, 2, nil)
+		},
+		checkOutput: func(t *testing.T, created int, updated int, err error) {
+			assert.NoError(t, err)
+			assert.Equal(t, 1, created)
+			assert.Equal(t, 2, updated)
+		},
+	},
+	{
+		testName: "invalid task aborts the import",
+		tasks: []models.Task{
+			{Name: "Window cleaning", PricePerSingle: 150, Category: 1},
+			{Name: "", PricePerSingle: 300, Category: 2},
+		},
+		prepare: func(fields *taskServiceFields) {
+			fields.taskRepoMock.EXPECT().UpsertByName(gomock.Any()).Times(0)
+		},
+		checkOutput: func(t *testing.T, created int, updated int, err error) {
+			assert.Error(t, err)
+			assert.Equal(t, 0, created)
+			assert.Equal(t, 0, updated)
+		},
+	},
+	{
+		testName: "over-precise price",
+		tasks: []models.Task{
+			{Name: "Window cleaning", PricePerSingle: 150.555, Category: 1},
+		},
+		prepare: func(fields *taskServiceFields) {
+			fields.taskRepoMock.EXPECT().UpsertByName(gomock.Any()).Times(0)
+		},
+		checkOutput: func(t *testing.T, created int, updated int, err error) {
+			assert.Equal(t, service_errors.InvalidPrice, err)
+		},
+	},
+	{
+		testName: "import error",
+		tasks: []models.Task{
+			{Name: "Window cleaning", PricePerSingle: 150, Category: 1},
+		},
+		prepare: func(fields *taskServiceFields) {
+			fields.taskRepoMock.EXPECT().UpsertByName(gomock.Any()).Return(0, 0, repository_errors.TransactionCommitError)
+		},
+		checkOutput: func(t *testing.T, created int, updated int, err error) {
+			assert.Equal(t, repository_errors.TransactionCommitError, err)
+			assert.Equal(t, 0, created)
+			assert.Equal(t, 0, updated)
+		},
+	},
+}
+
+func TestTaskServiceUpsertByName(t *testing.T) {
+	for _, tt := range testTaskServiceUpsertByName {
+		t.Run(tt.testName, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			fields := initTaskServiceFields(ctrl)
+			taskService := initTaskService(fields)
+
+			tt.prepare(fields)
+			created, updated, err := taskService.UpsertByName(tt.tasks)
+			tt.checkOutput(t, created, updated, err)
+		})
+	}
+}
+
+var testTaskServiceSearchTasksByName = []struct {
+	testName    string
+	query       string
+	prepare     func(fields *taskServiceFields)
+	checkOutput func(t *testing.T, tasks []models.Task, err error)
+}{
+	{
+		testName: "empty query returns all tasks",
+		query:    "",
+		prepare: func(fields *taskServiceFields) {
+			fields.taskRepoMock.EXPECT().SearchTasksByName("").Return([]models.Task{{Name: "Carpet Cleaning"}, {Name: "Window Cleaning"}}, nil)
+		},
+		checkOutput: func(t *testing.T, tasks []models.Task, err error) {
+			assert.NoError(t, err)
+			assert.Len(t, tasks, 2)
+		},
+	},
+	{
+		testName: "partial match is trimmed",
+		query:    "  window ",
+		prepare: func(fields *taskServiceFields) {
+			fields.taskRepoMock.EXPECT().SearchTasksByName("window").Return([]models.Task{{Name: "Window Cleaning"}}, nil)
+		},
+		checkOutput: func(t *testing.T, tasks []models.Task, err error) {
+			assert.NoError(t, err)
+			assert.Equal(t, []models.Task{{Name: "Window Cleaning"}}, tasks)
+		},
+	},
+	{
+		testName: "search error",
+		query:    "window",
+		prepare: func(fields *taskServiceFields) {
+			fields.taskRepoMock.EXPECT().SearchTasksByName("window").Return(nil, repository_errors.SelectError)
+		},
+		checkOutput: func(t *testing.T, tasks []models.Task, err error) {
+			assert.Equal(t, repository_errors.SelectError, err)
+			assert.Nil(t, tasks)
+		},
+	},
+}
+
+func TestTaskServiceSearchTasksByName(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	fields := initTaskServiceFields(ctrl)
+	taskService := initTaskService(fields)
+
+	for _, tt := range testTaskServiceSearchTasksByName {
+		t.Run(tt.testName, func(t *testing.T) {
+			tt.prepare(fields)
+			tasks, err := taskService.SearchTasksByName(tt.query)
+			tt.checkOutput(t, tasks, err)
+		})
+	}
+}
+
+var testTaskServiceGetTasksModifiedSince = []struct {
+	testName    string
+	prepare     func(fields *taskServiceFields)
+	checkOutput func(t *testing.T, tasks []models.Task, err error)
+}{
+	{
+		testName: "modified tasks are returned",
+		prepare: func(fields *taskServiceFields) {
+			fields.taskRepoMock.EXPECT().GetTasksModifiedSince(gomock.Any()).Return([]models.Task{{Name: "Window Cleaning"}, {Name: "Oven Cleaning", Archived: true}}, nil)
+		},
+		checkOutput: func(t *testing.T, tasks []models.Task, err error) {
+			assert.NoError(t, err)
+			assert.Len(t, tasks, 2)
+		},
+	},
+	{
+		testName: "retrieval error",
+		prepare: func(fields *taskServiceFields) {
+			fields.taskRepoMock.EXPECT().GetTasksModifiedSince(gomock.Any()).Return(nil, repository_errors.SelectError)
+		},
+		checkOutput: func(t *testing.T, tasks []models.Task, err error) {
+			assert.Equal(t, repository_errors.SelectError, err)
+			assert.Nil(t, tasks)
+		},
+	},
+}
+
+func TestTaskServiceGetTasksModifiedSince(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	fields := initTaskServiceFields(ctrl)
+	taskService := initTaskService(fields)
+
+	for _, tt := range testTaskServiceGetTasksModifiedSince {
+		t.Run(tt.testName, func(t *testing.T) {
+			tt.prepare(fields)
+			tasks, err := taskService.GetTasksModifiedSince(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+			tt.checkOutput(t, tasks, err)
+		})
+	}
+}
+
+func TestTaskServiceGetMostOrderedTasks(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	fields := initTaskServiceFields(ctrl)
+	taskService := initTaskService(fields)
+
+	ranking := []models.TaskPopularity{
+		{Task: models.Task{ID: uuid.New(), Name: "Windows"}, TotalQuantity: 12, OrderCount: 4},
+		{Task: models.Task{ID: uuid.New(), Name: "Carpets"}, TotalQuantity: 7, OrderCount: 5},
+	}
+	fields.taskRepoMock.EXPECT().GetMostOrderedTasks(2, time.Time{}, time.Time{}).Return(ranking, nil)
+
+	popularity, err := taskService.GetMostOrderedTasks(2)
+	assert.NoError(t, err)
+	assert.Equal(t, ranking, popularity)
+
+	for _, limit := range []int{0, -1} {
+		popularity, err = taskService.GetMostOrderedTasks(limit)
+		assert.Error(t, err)
+		assert.Nil(t, popularity)
+	}
+
+	fields.taskRepoMock.EXPECT().GetMostOrderedTasks(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, repository_errors.SelectError)
+	popularity, err = taskService.GetMostOrderedTasks(5)
+	assert.Equal(t, repository_errors.SelectError, err)
+	assert.Nil(t, popularity)
+}
+
+func TestTaskServiceGetMostOrderedTasksBetween(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	fields := initTaskServiceFields(ctrl)
+	taskService := initTaskService(fields)
+
+	from := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 5, 31, 23, 59, 59, 0, time.UTC)
+	ranking := []models.TaskPopularity{{Task: models.Task{ID: uuid.New(), Name: "Windows"}, TotalQuantity: 3, OrderCount: 2}}
+	fields.taskRepoMock.EXPECT().GetMostOrderedTasks(10, from, to).Return(ranking, nil)
+
+	popularity, err := taskService.GetMostOrderedTasksBetween(from, to, 10)
+	assert.NoError(t, err)
+	assert.Equal(t, ranking, popularity)
+
+	popularity, err = taskService.GetMostOrderedTasksBetween(to, from, 10)
+	assert.Error(t, err)
+	assert.Nil(t, popularity)
+
+	popularity, err = taskService.GetMostOrderedTasksBetween(time.Time{}, to, 10)
+	assert.Error(t, err)
+	assert.Nil(t, popularity)
+
+	popularity, err = taskService.GetMostOrderedTasksBetween(from, to, 0)
+	assert.Error(t, err)
+	assert.Nil(t, popularity)
+}
+
+func TestTaskServiceGetAllTasksPaged(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	fields := initTaskServiceFields(ctrl)
+	taskService := initTaskService(fields)
+
+	page := []models.Task{
+		{ID: uuid.New(), Name: "Deep cleaning", PricePerSingle: 900},
+		{ID: uuid.New(), Name: "Windows", PricePerSingle: 300},
+	}
+	fields.taskRepoMock.EXPECT().GetAllTasksPaged(2, 0, models.TaskSortByPrice, false).Return(page, 7, nil)
+
+	tasks, total, err := taskService.GetAllTasksPaged(2, 0, models.TaskSortByPrice, false)
+	assert.NoError(t, err)
+	assert.Equal(t, 7, total)
+	assert.Equal(t, page, tasks)
+	assert.GreaterOrEqual(t, tasks[0].PricePerSingle, tasks[1].PricePerSingle)
+
+	// unknown sort fields never reach the repository
+	for _, sortBy := range []string{"", "price_per_single", "name; DROP TABLE tasks"} {
+		tasks, total, err = taskService.GetAllTasksPaged(2, 0, sortBy, true)
+		assert.ErrorIs(t, err, service_errors.InvalidSortField)
+		assert.Nil(t, tasks)
+		assert.Zero(t, total)
+	}
+
+	for _, bounds := range [][2]int{{0, 0}, {-1, 0}, {5, -1}} {
+		tasks, _, err = taskService.GetAllTasksPaged(bounds[0], bounds[1], models.TaskSortByName, true)
+		assert.Error(t, err)
+		assert.Nil(t, tasks)
+	}
+
+	fields.taskRepoMock.EXPECT().GetAllTasksPaged(5, 10, models.TaskSortByCategory, true).Return(nil, 0, repository_errors.SelectError)
+	tasks, _, err = taskService.GetAllTasksPaged(5, 10, models.TaskSortByCategory, true)
+	assert.Equal(t, repository_errors.SelectError, err)
+	assert.Nil(t, tasks)
+}
+
+func TestTaskServiceCreateInNewCategory(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	fields := initTaskServiceFields(ctrl)
+	taskService := initTaskService(fields)
+	categoryService := services.NewCategoryService(fields.categoryRepoMock, fields.taskRepoMock, fields.logger)
+
+	category, err := categoryService.Create("Уборка после праздника")
+	assert.NoError(t, err)
+	assert.Equal(t, len(models.TaskCategories)+1, category.ID)
+
+	fields.taskRepoMock.EXPECT().Create(gomock.Any()).DoAndReturn(func(task *models.Task) (*models.Task, error) {
+		task.ID = uuid.New()
+		return task, nil
+	})
+
+	task, err := taskService.Create("Уборка конфетти", 500, category.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, category.ID, task.Category)
+}
+
+var testTaskServiceUnknownCategory = []struct {
+	testName string
+	call     func(taskService service_interfaces.ITaskService, fields *taskServiceFields, category int) error
+}{
+	{
+		testName: "create",
+		call: func(taskService service_interfaces.ITaskService, fields *taskServiceFields, category int) error {
+			fields.taskRepoMock.EXPECT().Create(gomock.Any()).Times(0)
+			_, err := taskService.Create("Test Task", 100, category)
+			return err
+		},
+	},
+	{
+		testName: "update",
+		call: func(taskService service_interfaces.ITaskService, fields *taskServiceFields, category int) error {
+			taskID := uuid.New()
+			fields.taskRepoMock.EXPECT().GetTaskByID(taskID).Return(&models.Task{ID: taskID, Name: "Test Task", PricePerSingle: 100, Category: 1}, nil)
+			fields.taskRepoMock.EXPECT().Update(gomock.Any()).Times(0)
+			_, err := taskService.Update(taskID, category, "Test Task", 100)
+			return err
+		},
+	},
+	{
+		testName: "import",
+		call: func(taskService service_interfaces.ITaskService, fields *taskServiceFields, category int) error {
+			fields.taskRepoMock.EXPECT().UpsertByName(gomock.Any()).Times(0)
+			_, _, err := taskService.UpsertByName([]models.Task{{Name: "Test Task", PricePerSingle: 100, Category: category}})
+			return err
+		},
+	},
+}
+
+func TestTaskServiceUnknownCategory(t *testing.T) {
+	for _, tt := range testTaskServiceUnknownCategory {
+		t.Run(tt.testName, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			fields := initTaskServiceFields(ctrl)
+			taskService := initTaskService(fields)
+
+			unknown := len(fields.categories) + 1
+			err := tt.call(taskService, fields, unknown)
+			assert.Equal(t, service_errors.InvalidCategory, err)
+		})
+	}
+}
+
+var testTaskServiceSetPriceTiers = []struct {
+	testName    string
+	tiers       []models.PriceTier
+	prepare     func(fields *taskServiceFields, taskID uuid.UUID)
+	checkOutput func(t *testing.T, err error)
+}{
+	{
+		testName: "tiers are saved",
+		tiers:    []models.PriceTier{{MinQuantity: 5, Price: 80}, {MinQuantity: 10, Price: 70}},
+		prepare: func(fields *taskServiceFields, taskID uuid.UUID) {
+			fields.taskRepoMock.EXPECT().SetPriceTiers(taskID, []models.PriceTier{{MinQuantity: 5, Price: 80}, {MinQuantity: 10, Price: 70}}).Return(nil)
+		},
+		checkOutput: func(t *testing.T, err error) {
+			assert.NoError(t, err)
+		},
+	},
+	{
+		testName: "removing all tiers",
+		tiers:    []models.PriceTier{},
+		prepare: func(fields *taskServiceFields, taskID uuid.UUID) {
+			fields.taskRepoMock.EXPECT().SetPriceTiers(taskID, []models.PriceTier{}).Return(nil)
+		},
+		checkOutput: func(t *testing.T, err error) {
+			assert.NoError(t, err)
+		},
+	},
+	{
+		testName: "tier for a single unit",
+		tiers:    []models.PriceTier{{MinQuantity: 1, Price: 80}},
+		prepare: func(fields *taskServiceFields, taskID uuid.UUID) {
+			fields.taskRepoMock.EXPECT().SetPriceTiers(gomock.Any(), gomock.Any()).Times(0)
+		},
+		checkOutput: func(t *testing.T, err error) {
+			assert.Error(t, err)
+		},
+	},
+	{
+		testName: "duplicate minimum quantity",
+		tiers:    []models.PriceTier{{MinQuantity: 5, Price: 80}, {MinQuantity: 5, Price: 70}},
+		prepare: func(fields *taskServiceFields, taskID uuid.UUID) {
+			fields.taskRepoMock.EXPECT().SetPriceTiers(gomock.Any(), gomock.Any()).Times(0)
+		},
+		checkOutput: func(t *testing.T, err error) {
+			assert.Error(t, err)
+		},
+	},
+	{
+		testName: "negative tier price",
+		tiers:    []models.PriceTier{{MinQuantity: 5, Price: -10}},
+		prepare: func(fields *taskServiceFields, taskID uuid.UUID) {
+			fields.taskRepoMock.EXPECT().SetPriceTiers(gomock.Any(), gomock.Any()).Times(0)
+		},
+		checkOutput: func(t *testing.T, err error) {
+			assert.Error(t, err)
+		},
+	},
+}
+
+func TestTaskServiceSetPriceTiers(t *testing.T) {
+	for _, tt := range testTaskServiceSetPriceTiers {
+		t.Run(tt.testName, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			fields := initTaskServiceFields(ctrl)
+			taskService := initTaskService(fields)
+
+			taskID := uuid.New()
+			fields.taskRepoMock.EXPECT().GetTaskByID(taskID).Return(&models.Task{ID: taskID, PricePerSingle: 100}, nil)
+			tt.prepare(fields, taskID)
+
+			err := taskService.SetPriceTiers(taskID, tt.tiers)
+			tt.checkOutput(t, err)
+		})
+	}
+}
+
+var catalogTasks = []models.Task{
+	{ID: uuid.New(), Name: "Window Cleaning", PricePerSingle: 150.5, Category: 3},
+	{ID: uuid.New(), Name: "Carpet Cleaning", PricePerSingle: 400, Category: 6},
+	{ID: uuid.New(), Name: "Office Cleaning", PricePerSingle: 1200, Category: 4},
+}
+
+var testTaskServiceCatalogRoundTrip = []struct {
+	testName    string
+	overwrite   bool
+	existing    map[string]bool
+	checkOutput func(t *testing.T, imported []models.Task, summary models.CatalogImportSummary, err error)
+}{
+	{
+		testName:  "empty catalog receives every task",
+		overwrite: false,
+		existing:  map[string]bool{},
+		checkOutput: func(t *testing.T, imported []models.Task, summary models.CatalogImportSummary, err error) {
+			assert.NoError(t, err)
+			assert.Equal(t, models.CatalogImportSummary{Created: 3}, summary)
+			assert.Len(t, imported, len(catalogTasks))
+			for i, task := range catalogTasks {
+				assert.Equal(t, task.Name, imported[i].Name)
+				assert.Equal(t, task.PricePerSingle, imported[i].PricePerSingle)
+				assert.Equal(t, task.Category, imported[i].Category)
+			}
+		},
+	},
+	{
+		testName:  "existing tasks are skipped",
+		overwrite: false,
+		existing:  map[string]bool{"window cleaning": true},
+		checkOutput: func(t *testing.T, imported []models.Task, summary models.CatalogImportSummary, err error) {
+			assert.NoError(t, err)
+			assert.Equal(t, models.CatalogImportSummary{Created: 2, Skipped: 1}, summary)
+			assert.Len(t, imported, len(catalogTasks))
+		},
+	},
+	{
+		testName:  "existing tasks are updated with overwrite",
+		overwrite: true,
+		existing:  map[string]bool{"window cleaning": true},
+		checkOutput: func(t *testing.T, imported []models.Task, summary models.CatalogImportSummary, err error) {
+			assert.NoError(t, err)
+			assert.Equal(t, models.CatalogImportSummary{Created: 2, Updated: 1}, summary)
+			assert.Len(t, imported, len(catalogTasks))
+		},
+	},
+}
+
+func TestTaskServiceCatalogRoundTrip(t *testing.T) {
+	for _, tt := range testTaskServiceCatalogRoundTrip {
+		t.Run(tt.testName, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			fields := initTaskServiceFields(ctrl)
+			taskService := initTaskService(fields)
+
+			fields.taskRepoMock.EXPECT().GetAllTasks().Return(catalogTasks, nil)
+			// existing names are matched ignoring case, like the repository does
+			var imported []models.Task
+			split := func(tasks []models.Task) (int, int, error) {
+				imported = tasks
+				matched := 0
+				for _, task := range tasks {
+					if tt.existing[strings.ToLower(task.Name)] {
+						matched++
+					}
+				}
+				return len(tasks) - matched, matched, nil
+			}
+			if tt.overwrite {
+				fields.taskRepoMock.EXPECT().UpsertByName(gomock.Any()).DoAndReturn(split)
+				fields.taskRepoMock.EXPECT().InsertMissingByName(gomock.Any()).Times(0)
+			} else {
+				fields.taskRepoMock.EXPECT().InsertMissingByName(gomock.Any()).DoAndReturn(split)
+				fields.taskRepoMock.EXPECT().UpsertByName(gomock.Any()).Times(0)
+			}
+
+			var buffer bytes.Buffer
+			err := taskService.ExportCatalog(&buffer)
+			assert.NoError(t, err)
+
+			summary, err := taskService.ImportCatalog(&buffer, tt.overwrite)
+			tt.checkOutput(t, imported, summary, err)
+		})
+	}
+}
+
+var testTaskServiceImportCatalogFail = []struct {
+	testName string
+	catalog  string
+}{
+	{
+		testName: "one entry with an invalid price",
+		catalog:  `[{"name": "Window Cleaning", "price": 150, "category": 3}, {"name": "Carpet Cleaning", "price": -400, "category": 6}]`,
+	},
+	{
+		testName: "malformed document",
+		catalog:  `[{"name": "Window Cleaning"`,
+	},
+	{
+		testName: "unknown category",
+		catalog:  `[{"name": "Window Cleaning", "price": 150, "category": 100}]`,
+	},
+}
+
+func TestTaskServiceImportCatalogFail(t *testing.T) {
+	for _, tt := range testTaskServiceImportCatalogFail {
+		t.Run(tt.testName, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			fields := initTaskServiceFields(ctrl)
+			taskService := initTaskService(fields)
+
+			fields.taskRepoMock.EXPECT().UpsertByName(gomock.Any()).Times(0)
+
+			summary, err := taskService.ImportCatalog(strings.NewReader(tt.catalog), true)
+			assert.Error(t, err)
+			assert.Equal(t, models.CatalogImportSummary{}, summary)
+		})
+	}
+}
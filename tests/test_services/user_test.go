@@ -6,14 +6,20 @@ import (
 	"github.com/golang/mock/gomock"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/bcrypt"
 	"os"
+	"reflect"
+	"teamdev/auth"
 	"teamdev/internal/models"
 	"teamdev/internal/repository/repository_errors"
 	services "teamdev/internal/services"
+	"teamdev/internal/services/service_errors"
 	"teamdev/internal/services/service_interfaces"
+	"teamdev/password_hash"
 	mock_password_hash "teamdev/tests/hasher_mocks"
 	mock_repository_interfaces "teamdev/tests/repository_mocks"
 	"testing"
+	"time"
 )
 
 type userServiceFields struct {
@@ -38,7 +44,7 @@ func initUserServiceFields(ctrl *gomock.Controller) *userServiceFields {
 }
 
 func initUserService(fields *userServiceFields) service_interfaces.IUserService {
-	return services.NewUserService(fields.userRepoMock, fields.hash, fields.logger)
+	return services.NewUserService(fields.userRepoMock, fields.hash, fields.logger, models.LockoutSettings{}, nil)
 }
 
 var testUserGetByIDSuccess = []struct {
@@ -135,7 +141,7 @@ var testUserLoginSuccess = []struct {
 		},
 		prepare: func(fields *userServiceFields) {
 			fields.userRepoMock.EXPECT().GetUserByEmail(gomock.Any()).Return(&models.User{Password: "password123"}, nil)
-			fields.hash.EXPECT().CompareHashAndPassword(gomock.Any(), gomock.Any()).Return(true)
+			fields.hash.EXPECT().CompareAndMaybeRehash(gomock.Any(), gomock.Any()).Return("", true)
 		},
 		checkOutput: func(t *testing.T, user *models.User, err error) {
 			assert.NoError(t, err)
@@ -181,7 +187,7 @@ var testUserLoginFail = []struct {
 		},
 		prepare: func(fields *userServiceFields) {
 			fields.userRepoMock.EXPECT().GetUserByEmail(gomock.Any()).Return(&models.User{Password: "password123"}, nil)
-			fields.hash.EXPECT().CompareHashAndPassword(gomock.Any(), gomock.Any()).Return(false)
+			fields.hash.EXPECT().CompareAndMaybeRehash(gomock.Any(), gomock.Any()).Return("", false)
 		},
 		checkOutput: func(t *testing.T, user *models.User, err error) {
 			assert.Error(t, err)
@@ -218,43 +224,37 @@ var testUserChangePasswordSuccess = []struct {
 	testName  string
 	inputData struct {
 		id          uuid.UUID
-		name        string
-		surname     string
-		email       string
-		address     string
-		phoneNumber string
+		oldPassword string
 		password    string
 	}
 	prepare     func(fields *userServiceFields)
-	checkOutput func(t *testing.T, user *models.User, err error)
+	checkOutput func(t *testing.T, err error)
 }{
 	{
 		testName: "basic change password",
 		inputData: struct {
 			id          uuid.UUID
-			name        string
-			surname     string
-			email       string
-			address     string
-			phoneNumber string
+			oldPassword string
 			password    string
 		}{
 			id:          uuid.New(),
-			name:        "Test",
-			surname:     "Test",
-			email:       "test@gmail.com",
-			address:     "Test",
-			phoneNumber: "+79999999999",
+			oldPassword: "oldPassword1",
 			password:    "password123",
 		},
 		prepare: func(fields *userServiceFields) {
 			fields.userRepoMock.EXPECT().GetUserByID(gomock.Any()).Return(&models.User{
-				Password: "password123",
+				Password: "old_hash",
 			}, nil)
-			//fields.hash.EXPECT().GetHash(gomock.Any()).Return("password123", nil)
-			fields.userRepoMock.EXPECT().Update(gomock.Any()).Return(&models.User{}, nil)
-		},
-		checkOutput: func(t *testing.T, user *models.User, err error) {
+			fields.hash.EXPECT().CompareHashAndPassword("old_hash", "oldPassword1").Return(true)
+			fields.hash.EXPECT().GetHash("password123").Return("new_hash", nil)
+			fields.userRepoMock.EXPECT().Update(gomock.Any()).DoAndReturn(func(user *models.User) (*models.User, error) {
+				if user.Password != "new_hash" {
+					return nil, repository_errors.UpdateError
+				}
+				return user, nil
+			})
+		},
+		checkOutput: func(t *testing.T, err error) {
 			assert.NoError(t, err)
 		},
 	},
@@ -264,72 +264,91 @@ var testUserChangePasswordFail = []struct {
 	testName  string
 	inputData struct {
 		id          uuid.UUID
-		name        string
-		surname     string
-		email       string
-		address     string
-		phoneNumber string
+		oldPassword string
 		password    string
 	}
 	prepare     func(fields *userServiceFields)
-	checkOutput func(t *testing.T, user *models.User, err error)
+	checkOutput func(t *testing.T, err error)
 }{
 	{
 		testName: "user not found",
 		inputData: struct {
 			id          uuid.UUID
-			name        string
-			surname     string
-			email       string
-			address     string
-			phoneNumber string
+			oldPassword string
 			password    string
 		}{
 			id:          uuid.New(),
-			name:        "Test",
-			surname:     "Test",
-			email:       "test@gmail.com",
-			address:     "Test",
-			phoneNumber: "+79999999999",
+			oldPassword: "oldPassword1",
 			password:    "password123",
 		},
 		prepare: func(fields *userServiceFields) {
 			fields.userRepoMock.EXPECT().GetUserByID(gomock.Any()).Return(nil, repository_errors.DoesNotExist)
-			//fields.hash.EXPECT().GetHash(gomock.Any()).Return("hashed_password", nil)
 		},
-		checkOutput: func(t *testing.T, user *models.User, err error) {
+		checkOutput: func(t *testing.T, err error) {
 			assert.Error(t, err)
-			assert.Equal(t, fmt.Errorf("GET operation has failed. Such row does not exist"), err)
+			assert.Equal(t, repository_errors.DoesNotExist, err)
+		},
+	},
+	{
+		testName: "wrong current password",
+		inputData: struct {
+			id          uuid.UUID
+			oldPassword string
+			password    string
+		}{
+			id:          uuid.New(),
+			oldPassword: "wrongPassword1",
+			password:    "password123",
+		},
+		prepare: func(fields *userServiceFields) {
+			fields.userRepoMock.EXPECT().GetUserByID(gomock.Any()).Return(&models.User{Password: "old_hash"}, nil)
+			fields.hash.EXPECT().CompareHashAndPassword("old_hash", "wrongPassword1").Return(false)
+			fields.userRepoMock.EXPECT().Update(gomock.Any()).Times(0)
+		},
+		checkOutput: func(t *testing.T, err error) {
+			assert.ErrorIs(t, err, service_errors.MismatchedPassword)
 		},
 	},
 	{
 		testName: "invalid password",
 		inputData: struct {
 			id          uuid.UUID
-			name        string
-			surname     string
-			email       string
-			address     string
-			phoneNumber string
+			oldPassword string
 			password    string
 		}{
 			id:          uuid.New(),
-			name:        "Test",
-			surname:     "Test",
-			email:       "test@gmail.com",
-			address:     "Test",
-			phoneNumber: "+79999999999",
+			oldPassword: "oldPassword1",
 			password:    "pass",
 		},
 		prepare: func(fields *userServiceFields) {
 			fields.userRepoMock.EXPECT().GetUserByID(gomock.Any()).Return(&models.User{}, nil)
-			//fields.hash.EXPECT().GetHash(gomock.Any()).Return("pass", nil)
+			fields.hash.EXPECT().CompareHashAndPassword(gomock.Any(), "oldPassword1").Return(true)
 		},
-		checkOutput: func(t *testing.T, user *models.User, err error) {
+		checkOutput: func(t *testing.T, err error) {
 			assert.Error(t, err)
 			assert.Equal(t, fmt.Errorf("SERVICE: Invalid input"), err)
 		},
 	},
+	{
+		testName: "hashing failed",
+		inputData: struct {
+			id          uuid.UUID
+			oldPassword string
+			password    string
+		}{
+			id:          uuid.New(),
+			oldPassword: "oldPassword1",
+			password:    "password123",
+		},
+		prepare: func(fields *userServiceFields) {
+			fields.userRepoMock.EXPECT().GetUserByID(gomock.Any()).Return(&models.User{}, nil)
+			fields.hash.EXPECT().CompareHashAndPassword(gomock.Any(), "oldPassword1").Return(true)
+			fields.hash.EXPECT().GetHash(gomock.Any()).Return("", fmt.Errorf("hash error"))
+		},
+		checkOutput: func(t *testing.T, err error) {
+			assert.Equal(t, fmt.Errorf("hash error"), err)
+		},
+	},
 }
 
 func TestUserServiceChangePassword(t *testing.T) {
@@ -342,16 +361,16 @@ func TestUserServiceChangePassword(t *testing.T) {
 	for _, test := range testUserChangePasswordSuccess {
 		t.Run(test.testName, func(t *testing.T) {
 			test.prepare(fields)
-			user, err := service.Update(test.inputData.id, test.inputData.name, test.inputData.surname, test.inputData.email, test.inputData.address, test.inputData.phoneNumber, test.inputData.password)
-			test.checkOutput(t, user, err)
+			err := service.ChangePassword(test.inputData.id, test.inputData.oldPassword, test.inputData.password)
+			test.checkOutput(t, err)
 		})
 	}
 
 	for _, test := range testUserChangePasswordFail {
 		t.Run(test.testName, func(t *testing.T) {
 			test.prepare(fields)
-			user, err := service.Update(test.inputData.id, test.inputData.name, test.inputData.surname, test.inputData.email, test.inputData.address, test.inputData.phoneNumber, test.inputData.password)
-			test.checkOutput(t, user, err)
+			err := service.ChangePassword(test.inputData.id, test.inputData.oldPassword, test.inputData.password)
+			test.checkOutput(t, err)
 		})
 	}
 }
@@ -390,6 +409,33 @@ var testUserRegisterSuccess = []struct {
 			assert.NotNil(t, user)
 		},
 	},
+	{
+		testName: "formatted phone number is stored in E.164",
+		inputData: struct {
+			user     *models.User
+			password string
+		}{
+			user: &models.User{
+				Email:       "test@gmail.com",
+				Name:        "Test",
+				Surname:     "Test",
+				Address:     "Test",
+				PhoneNumber: "+7 (912) 345-67-89",
+			},
+			password: "password123",
+		},
+		prepare: func(fields *userServiceFields) {
+			fields.userRepoMock.EXPECT().GetUserByEmail(gomock.Any()).Return(nil, repository_errors.DoesNotExist)
+			fields.hash.EXPECT().GetHash(gomock.Any()).Return("password123", nil)
+			fields.userRepoMock.EXPECT().Create(gomock.Any()).DoAndReturn(func(user *models.User) (*models.User, error) {
+				return user, nil
+			})
+		},
+		checkOutput: func(t *testing.T, user *models.User, err error) {
+			assert.NoError(t, err)
+			assert.Equal(t, "+79123456789", user.PhoneNumber)
+		},
+	},
 }
 
 var testUserRegisterFail = []struct {
@@ -401,6 +447,31 @@ var testUserRegisterFail = []struct {
 	prepare     func(fields *userServiceFields)
 	checkOutput func(t *testing.T, user *models.User, err error)
 }{
+	{
+		testName: "email taken by a concurrent registration",
+		inputData: struct {
+			user     *models.User
+			password string
+		}{
+			user: &models.User{
+				Email:       "test@gmail.com",
+				Name:        "Test",
+				Surname:     "Test",
+				Address:     "Test",
+				PhoneNumber: "+79999999999",
+			},
+			password: "password123",
+		},
+		prepare: func(fields *userServiceFields) {
+			fields.userRepoMock.EXPECT().GetUserByEmail(gomock.Any()).Return(nil, repository_errors.DoesNotExist)
+			fields.hash.EXPECT().GetHash(gomock.Any()).Return("password123", nil)
+			fields.userRepoMock.EXPECT().Create(gomock.Any()).Return(nil, repository_errors.AlreadyExists)
+		},
+		checkOutput: func(t *testing.T, user *models.User, err error) {
+			assert.Equal(t, service_errors.NotUnique, err)
+			assert.Nil(t, user)
+		},
+	},
 	{
 		testName: "user already exists",
 		inputData: struct {
@@ -421,7 +492,7 @@ var testUserRegisterFail = []struct {
 		},
 		checkOutput: func(t *testing.T, user *models.User, err error) {
 			assert.Error(t, err)
-			assert.Equal(t, fmt.Errorf("SERVICE: User with email exists"), err)
+			assert.ErrorIs(t, err, service_errors.NotUnique)
 		},
 	},
 	{
@@ -442,7 +513,7 @@ var testUserRegisterFail = []struct {
 		prepare: func(fields *userServiceFields) {},
 		checkOutput: func(t *testing.T, user *models.User, err error) {
 			assert.Error(t, err)
-			assert.Equal(t, fmt.Errorf("SERVICE: Invalid email"), err)
+			assert.ErrorIs(t, err, service_errors.InvalidEmail)
 		},
 	},
 	{
@@ -463,7 +534,7 @@ var testUserRegisterFail = []struct {
 		prepare: func(fields *userServiceFields) {},
 		checkOutput: func(t *testing.T, user *models.User, err error) {
 			assert.Error(t, err)
-			assert.Equal(t, fmt.Errorf("SERVICE: Invalid name"), err)
+			assert.ErrorIs(t, err, service_errors.InvalidName)
 		},
 	},
 	{
@@ -484,7 +555,7 @@ var testUserRegisterFail = []struct {
 		prepare: func(fields *userServiceFields) {},
 		checkOutput: func(t *testing.T, user *models.User, err error) {
 			assert.Error(t, err)
-			assert.Equal(t, fmt.Errorf("SERVICE: Invalid address"), err)
+			assert.ErrorIs(t, err, service_errors.InvalidAddress)
 		},
 	},
 	{
@@ -505,7 +576,28 @@ var testUserRegisterFail = []struct {
 		prepare: func(fields *userServiceFields) {},
 		checkOutput: func(t *testing.T, user *models.User, err error) {
 			assert.Error(t, err)
-			assert.Equal(t, fmt.Errorf("SERVICE: Invalid phone number"), err)
+			assert.ErrorIs(t, err, service_errors.InvalidPhoneNumber)
+		},
+	},
+	{
+		testName: "phone number with letters",
+		inputData: struct {
+			user     *models.User
+			password string
+		}{
+			user: &models.User{
+				PhoneNumber: "+7 (912) ABC-67-89",
+				Email:       "test@gmail.com",
+				Name:        "Test",
+				Surname:     "Test",
+				Address:     "Test",
+			},
+			password: "password123",
+		},
+		prepare: func(fields *userServiceFields) {},
+		checkOutput: func(t *testing.T, user *models.User, err error) {
+			assert.Error(t, err)
+			assert.ErrorIs(t, err, service_errors.InvalidPhoneNumber)
 		},
 	},
 	{
@@ -526,7 +618,7 @@ var testUserRegisterFail = []struct {
 		prepare: func(fields *userServiceFields) {},
 		checkOutput: func(t *testing.T, user *models.User, err error) {
 			assert.Error(t, err)
-			assert.Equal(t, fmt.Errorf("SERVICE: Invalid password"), err)
+			assert.ErrorIs(t, err, service_errors.InvalidPassword)
 		},
 	},
 }
@@ -564,7 +656,6 @@ var testUserUpdatePersonalInformationSuccess = []struct {
 		email       string
 		address     string
 		phoneNumber string
-		password    string
 	}
 	prepare     func(fields *userServiceFields)
 	checkOutput func(t *testing.T, user *models.User, err error)
@@ -578,7 +669,6 @@ var testUserUpdatePersonalInformationSuccess = []struct {
 			email       string
 			address     string
 			phoneNumber string
-			password    string
 		}{
 			id:          uuid.New(),
 			name:        "Test",
@@ -586,11 +676,9 @@ var testUserUpdatePersonalInformationSuccess = []struct {
 			email:       "test@gmail.com",
 			address:     "Test",
 			phoneNumber: "+79999999999",
-			password:    "password123",
 		},
 		prepare: func(fields *userServiceFields) {
 			fields.userRepoMock.EXPECT().GetUserByID(gomock.Any()).Return(&models.User{}, nil)
-			fields.hash.EXPECT().GetHash(gomock.Any()).Return("password123", nil)
 			fields.userRepoMock.EXPECT().Update(gomock.Any()).Return(&models.User{
 				Name:        "Test",
 				Surname:     "Test",
@@ -621,7 +709,6 @@ var testUserUpdatePersonalInformationFail = []struct {
 		email       string
 		address     string
 		phoneNumber string
-		password    string
 	}
 	prepare     func(fields *userServiceFields)
 	checkOutput func(t *testing.T, user *models.User, err error)
@@ -635,7 +722,6 @@ var testUserUpdatePersonalInformationFail = []struct {
 			email       string
 			address     string
 			phoneNumber string
-			password    string
 		}{
 			id:          uuid.New(),
 			name:        "Test",
@@ -643,7 +729,6 @@ var testUserUpdatePersonalInformationFail = []struct {
 			email:       "test@gmail.com",
 			address:     "Test",
 			phoneNumber: "+79999999999",
-			password:    "password123",
 		},
 		prepare: func(fields *userServiceFields) {
 			fields.userRepoMock.EXPECT().GetUserByID(gomock.Any()).Return(nil, repository_errors.DoesNotExist)
@@ -663,7 +748,6 @@ var testUserUpdatePersonalInformationFail = []struct {
 			email       string
 			address     string
 			phoneNumber string
-			password    string
 		}{
 			id:          uuid.New(),
 			name:        "", //invalid name
@@ -671,7 +755,6 @@ var testUserUpdatePersonalInformationFail = []struct {
 			email:       "test@gmail.com",
 			address:     "Test",
 			phoneNumber: "+79999999999",
-			password:    "password123",
 		},
 		prepare: func(fields *userServiceFields) {
 			fields.userRepoMock.EXPECT().GetUserByID(gomock.Any()).Return(&models.User{}, nil)
@@ -691,7 +774,6 @@ var testUserUpdatePersonalInformationFail = []struct {
 			email       string
 			address     string
 			phoneNumber string
-			password    string
 		}{
 			id:          uuid.New(),
 			name:        "Test",
@@ -699,7 +781,6 @@ var testUserUpdatePersonalInformationFail = []struct {
 			email:       "test@gmail.com",
 			address:     "Test",
 			phoneNumber: "+79999999999",
-			password:    "password123",
 		},
 		prepare: func(fields *userServiceFields) {
 			fields.userRepoMock.EXPECT().GetUserByID(gomock.Any()).Return(&models.User{}, nil)
@@ -719,7 +800,6 @@ var testUserUpdatePersonalInformationFail = []struct {
 			email       string
 			address     string
 			phoneNumber string
-			password    string
 		}{
 			id:          uuid.New(),
 			name:        "Test",
@@ -727,7 +807,6 @@ var testUserUpdatePersonalInformationFail = []struct {
 			email:       "not-valid", //invalid email
 			address:     "Test",
 			phoneNumber: "+79999999999",
-			password:    "password123",
 		},
 		prepare: func(fields *userServiceFields) {
 			fields.userRepoMock.EXPECT().GetUserByID(gomock.Any()).Return(&models.User{}, nil)
@@ -747,7 +826,6 @@ var testUserUpdatePersonalInformationFail = []struct {
 			email       string
 			address     string
 			phoneNumber string
-			password    string
 		}{
 			id:          uuid.New(),
 			name:        "Test",
@@ -755,7 +833,6 @@ var testUserUpdatePersonalInformationFail = []struct {
 			email:       "test@gmail.com",
 			address:     "", //invalid address
 			phoneNumber: "+79999999999",
-			password:    "password123",
 		},
 		prepare: func(fields *userServiceFields) {
 			fields.userRepoMock.EXPECT().GetUserByID(gomock.Any()).Return(&models.User{}, nil)
@@ -775,7 +852,6 @@ var testUserUpdatePersonalInformationFail = []struct {
 			email       string
 			address     string
 			phoneNumber string
-			password    string
 		}{
 			id:          uuid.New(),
 			name:        "Test",
@@ -783,7 +859,6 @@ var testUserUpdatePersonalInformationFail = []struct {
 			email:       "test@gmail.com",
 			address:     "Test",
 			phoneNumber: "123", //invalid phone number
-			password:    "password123",
 		},
 		prepare: func(fields *userServiceFields) {
 			fields.userRepoMock.EXPECT().GetUserByID(gomock.Any()).Return(&models.User{}, nil)
@@ -803,7 +878,6 @@ var testUserUpdatePersonalInformationFail = []struct {
 			email       string
 			address     string
 			phoneNumber string
-			password    string
 		}{
 			id:          uuid.New(),
 			name:        "Test",
@@ -811,11 +885,9 @@ var testUserUpdatePersonalInformationFail = []struct {
 			email:       "test@gmail.com",
 			address:     "Test",
 			phoneNumber: "+79999999999",
-			password:    "password123",
 		},
 		prepare: func(fields *userServiceFields) {
 			fields.userRepoMock.EXPECT().GetUserByID(gomock.Any()).Return(&models.User{}, nil)
-			fields.hash.EXPECT().GetHash(gomock.Any()).Return("password123", nil)
 			fields.userRepoMock.EXPECT().Update(gomock.Any()).Return(nil, repository_errors.UpdateError)
 		},
 		checkOutput: func(t *testing.T, user *models.User, err error) {
@@ -836,7 +908,7 @@ func TestUserServiceUpdatePersonalInformation(t *testing.T) {
 	for _, test := range testUserUpdatePersonalInformationSuccess {
 		t.Run(test.testName, func(t *testing.T) {
 			test.prepare(fields)
-			user, err := service.Update(test.inputData.id, test.inputData.name, test.inputData.surname, test.inputData.email, test.inputData.address, test.inputData.phoneNumber, test.inputData.password)
+			user, err := service.Update(test.inputData.id, test.inputData.name, test.inputData.surname, test.inputData.email, test.inputData.address, test.inputData.phoneNumber)
 			test.checkOutput(t, user, err)
 		})
 	}
@@ -844,8 +916,388 @@ func TestUserServiceUpdatePersonalInformation(t *testing.T) {
 	for _, test := range testUserUpdatePersonalInformationFail {
 		t.Run(test.testName, func(t *testing.T) {
 			test.prepare(fields)
-			user, err := service.Update(test.inputData.id, test.inputData.name, test.inputData.surname, test.inputData.email, test.inputData.address, test.inputData.phoneNumber, test.inputData.password)
+			user, err := service.Update(test.inputData.id, test.inputData.name, test.inputData.surname, test.inputData.email, test.inputData.address, test.inputData.phoneNumber)
 			test.checkOutput(t, user, err)
 		})
 	}
 }
+
+// testPasswordHashCost is the cost weak hashes are upgraded to in login tests,
+// kept low so the tests stay fast.
+const testPasswordHashCost = bcrypt.MinCost + 2
+
+func TestUserServiceLoginUpgradesPasswordHash(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	fields := initUserServiceFields(ctrl)
+	service := services.NewUserService(fields.userRepoMock, password_hash.NewPasswordHashWithCost(testPasswordHashCost), fields.logger, models.LockoutSettings{}, nil)
+
+	weakHash, err := password_hash.NewPasswordHashWithCost(bcrypt.MinCost).GetHash("password123")
+	assert.NoError(t, err)
+	stored := &models.User{ID: uuid.New(), Email: "test@gmail.com", Password: weakHash}
+
+	var upgradedHash string
+	fields.userRepoMock.EXPECT().GetUserByEmail(stored.Email).Return(stored, nil)
+	fields.userRepoMock.EXPECT().Update(gomock.Any()).Times(0)
+	fields.userRepoMock.EXPECT().UpdatePassword(stored.ID, gomock.Any()).DoAndReturn(func(id uuid.UUID, password string) error {
+		upgradedHash = password
+		return nil
+	})
+
+	user, err := service.Login(stored.Email, "password123")
+	assert.NoError(t, err)
+	assert.Equal(t, upgradedHash, user.Password)
+	cost, err := bcrypt.Cost([]byte(upgradedHash))
+	assert.NoError(t, err)
+	assert.Equal(t, testPasswordHashCost, cost)
+	assert.NoError(t, bcrypt.CompareHashAndPassword([]byte(upgradedHash), []byte("password123")))
+
+	// a hash at the configured cost is kept as is
+	fields.userRepoMock.EXPECT().GetUserByEmail(stored.Email).Return(&models.User{ID: stored.ID, Email: stored.Email, Password: upgradedHash}, nil)
+	fields.userRepoMock.EXPECT().UpdatePassword(gomock.Any(), gomock.Any()).Times(0)
+
+	user, err = service.Login(stored.Email, "password123")
+	assert.NoError(t, err)
+	assert.Equal(t, upgradedHash, user.Password)
+}
+
+func TestUserServiceLoginKeepsHashWhenUpgradeFails(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	fields := initUserServiceFields(ctrl)
+	service := services.NewUserService(fields.userRepoMock, password_hash.NewPasswordHashWithCost(testPasswordHashCost), fields.logger, models.LockoutSettings{}, nil)
+
+	weakHash, err := password_hash.NewPasswordHashWithCost(bcrypt.MinCost).GetHash("password123")
+	assert.NoError(t, err)
+
+	fields.userRepoMock.EXPECT().GetUserByEmail("test@gmail.com").Return(&models.User{ID: uuid.New(), Email: "test@gmail.com", Password: weakHash}, nil)
+	fields.userRepoMock.EXPECT().UpdatePassword(gomock.Any(), gomock.Any()).Return(repository_errors.UpdateError)
+
+	user, err := service.Login("test@gmail.com", "password123")
+	assert.NoError(t, err)
+	assert.Equal(t, weakHash, user.Password)
+}
+
+func TestUserServiceUpdateKeepsPasswordHash(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	fields := initUserServiceFields(ctrl)
+	hash := password_hash.NewPasswordHash()
+	service := services.NewUserService(fields.userRepoMock, hash, fields.logger, models.LockoutSettings{}, nil)
+
+	storedHash, err := hash.GetHash("password123")
+	assert.NoError(t, err)
+	stored := &models.User{
+		ID:          uuid.New(),
+		Name:        "Test",
+		Surname:     "Test",
+		Email:       "test@gmail.com",
+		Address:     "Test",
+		PhoneNumber: "+79999999999",
+		Password:    storedHash,
+	}
+
+	fields.userRepoMock.EXPECT().GetUserByID(stored.ID).Return(stored, nil)
+	fields.userRepoMock.EXPECT().Update(gomock.Any()).DoAndReturn(func(user *models.User) (*models.User, error) {
+		return user, nil
+	})
+	fields.userRepoMock.EXPECT().GetUserByEmail(stored.Email).Return(stored, nil)
+
+	updated, err := service.Update(stored.ID, "Renamed", stored.Surname, stored.Email, stored.Address, stored.PhoneNumber)
+	assert.NoError(t, err)
+	assert.Equal(t, "Renamed", updated.Name)
+	assert.Equal(t, storedHash, updated.Password)
+
+	user, err := service.Login(stored.Email, "password123")
+	assert.NoError(t, err)
+	assert.Equal(t, stored.ID, user.ID)
+}
+
+var testUserServiceGetAllUsers = []struct {
+	testName    string
+	prepare     func(fields *userServiceFields)
+	checkOutput func(t *testing.T, users []models.UserPublic, err error)
+}{
+	{
+		testName: "every stored user is returned without a password",
+		prepare: func(fields *userServiceFields) {
+			fields.userRepoMock.EXPECT().GetAllUsers().Return([]models.User{
+				{ID: uuid.New(), Name: "First", Email: "first@gmail.com", Password: "hash"},
+				{ID: uuid.New(), Name: "Second", Email: "second@gmail.com"},
+				{ID: uuid.New(), Name: "Third", Email: "third@gmail.com"},
+			}, nil)
+		},
+		checkOutput: func(t *testing.T, users []models.UserPublic, err error) {
+			assert.NoError(t, err)
+			assert.Len(t, users, 3)
+			assert.Equal(t, "First", users[0].Name)
+			assert.Equal(t, "first@gmail.com", users[0].Email)
+		},
+	},
+	{
+		testName: "no users",
+		prepare: func(fields *userServiceFields) {
+			fields.userRepoMock.EXPECT().GetAllUsers().Return(nil, nil)
+		},
+		checkOutput: func(t *testing.T, users []models.UserPublic, err error) {
+			assert.NoError(t, err)
+			assert.Empty(t, users)
+		},
+	},
+	{
+		testName: "retrieval error",
+		prepare: func(fields *userServiceFields) {
+			fields.userRepoMock.EXPECT().GetAllUsers().Return(nil, repository_errors.SelectError)
+		},
+		checkOutput: func(t *testing.T, users []models.UserPublic, err error) {
+			assert.Equal(t, repository_errors.SelectError, err)
+			assert.Nil(t, users)
+		},
+	},
+}
+
+func TestUserServiceGetAllUsers(t *testing.T) {
+	_, hasPassword := reflect.TypeOf(models.UserPublic{}).FieldByName("Password")
+	assert.False(t, hasPassword)
+
+	for _, tt := range testUserServiceGetAllUsers {
+		t.Run(tt.testName, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			fields := initUserServiceFields(ctrl)
+			service := initUserService(fields)
+
+			tt.prepare(fields)
+			users, err := service.GetAllUsers()
+			tt.checkOutput(t, users, err)
+		})
+	}
+}
+
+var testUserServiceGetOrderStatistics = []struct {
+	testName    string
+	prepare     func(fields *userServiceFields, userID uuid.UUID)
+	checkOutput func(t *testing.T, stats *models.UserStats, err error)
+}{
+	{
+		testName: "user with orders in several statuses",
+		prepare: func(fields *userServiceFields, userID uuid.UUID) {
+			fields.userRepoMock.EXPECT().GetUserByID(userID).Return(&models.User{ID: userID}, nil)
+			fields.userRepoMock.EXPECT().GetOrderStatistics(userID).Return(&models.UserStats{
+				TotalOrders:     4,
+				CompletedOrders: 2,
+				CancelledOrders: 1,
+				TotalSpent:      700,
+				AverageRating:   3.5,
+			}, nil)
+		},
+		checkOutput: func(t *testing.T, stats *models.UserStats, err error) {
+			assert.NoError(t, err)
+			assert.Equal(t, 4, stats.TotalOrders)
+			assert.Equal(t, 2, stats.CompletedOrders)
+			assert.Equal(t, 1, stats.CancelledOrders)
+			assert.Equal(t, 700.0, stats.TotalSpent)
+			assert.Equal(t, 3.5, stats.AverageRating)
+		},
+	},
+	{
+		testName: "new user without orders",
+		prepare: func(fields *userServiceFields, userID uuid.UUID) {
+			fields.userRepoMock.EXPECT().GetUserByID(userID).Return(&models.User{ID: userID}, nil)
+			fields.userRepoMock.EXPECT().GetOrderStatistics(userID).Return(&models.UserStats{}, nil)
+		},
+		checkOutput: func(t *testing.T, stats *models.UserStats, err error) {
+			assert.NoError(t, err)
+			assert.Equal(t, models.UserStats{}, *stats)
+		},
+	},
+	{
+		testName: "user not found",
+		prepare: func(fields *userServiceFields, userID uuid.UUID) {
+			fields.userRepoMock.EXPECT().GetUserByID(userID).Return(nil, repository_errors.DoesNotExist)
+			fields.userRepoMock.EXPECT().GetOrderStatistics(gomock.Any()).Times(0)
+		},
+		checkOutput: func(t *testing.T, stats *models.UserStats, err error) {
+			assert.Equal(t, repository_errors.DoesNotExist, err)
+			assert.Nil(t, stats)
+		},
+	},
+	{
+		testName: "retrieval error",
+		prepare: func(fields *userServiceFields, userID uuid.UUID) {
+			fields.userRepoMock.EXPECT().GetUserByID(userID).Return(&models.User{ID: userID}, nil)
+			fields.userRepoMock.EXPECT().GetOrderStatistics(userID).Return(nil, repository_errors.SelectError)
+		},
+		checkOutput: func(t *testing.T, stats *models.UserStats, err error) {
+			assert.Equal(t, repository_errors.SelectError, err)
+			assert.Nil(t, stats)
+		},
+	},
+}
+
+func TestUserServiceGetOrderStatistics(t *testing.T) {
+	for _, tt := range testUserServiceGetOrderStatistics {
+		t.Run(tt.testName, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			fields := initUserServiceFields(ctrl)
+			service := initUserService(fields)
+
+			userID := uuid.New()
+			tt.prepare(fields, userID)
+			stats, err := service.GetOrderStatistics(userID)
+			tt.checkOutput(t, stats, err)
+		})
+	}
+}
+
+var testLoginLockout = models.LockoutSettings{MaxFailures: 5, Window: time.Minute}
+
+func TestUserServiceLoginLockout(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	fields := initUserServiceFields(ctrl)
+	service := services.NewUserService(fields.userRepoMock, fields.hash, fields.logger, testLoginLockout, nil)
+
+	stored := &models.User{ID: uuid.New(), Email: "test@gmail.com", Password: "hashedPassword"}
+	fields.userRepoMock.EXPECT().GetUserByEmail(stored.Email).Return(stored, nil).Times(testLoginLockout.MaxFailures)
+	fields.hash.EXPECT().CompareAndMaybeRehash(stored.Password, "wrongPassword").Return("", false).Times(testLoginLockout.MaxFailures)
+
+	for i := 0; i < testLoginLockout.MaxFailures; i++ {
+		user, err := service.Login(stored.Email, "wrongPassword")
+		assert.Error(t, err)
+		assert.NotErrorIs(t, err, service_errors.LoginLocked)
+		assert.Nil(t, user)
+	}
+
+	// even the correct password is rejected without reaching the repository
+	user, err := service.Login(stored.Email, "password123")
+	assert.ErrorIs(t, err, service_errors.LoginLocked)
+	assert.Nil(t, user)
+
+	user, err = service.Login(" TEST@gmail.com ", "password123")
+	assert.ErrorIs(t, err, service_errors.LoginLocked)
+	assert.Nil(t, user)
+}
+
+func TestUserServiceLoginLockoutExpires(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	lockout := models.LockoutSettings{MaxFailures: 2, Window: 50 * time.Millisecond}
+	fields := initUserServiceFields(ctrl)
+	service := services.NewUserService(fields.userRepoMock, fields.hash, fields.logger, lockout, nil)
+
+	stored := &models.User{ID: uuid.New(), Email: "test@gmail.com", Password: "hashedPassword"}
+	other := &models.User{ID: uuid.New(), Email: "other@gmail.com", Password: "hashedPassword"}
+	fields.userRepoMock.EXPECT().GetUserByEmail(stored.Email).Return(stored, nil).AnyTimes()
+	fields.userRepoMock.EXPECT().GetUserByEmail(other.Email).Return(other, nil).AnyTimes()
+	fields.hash.EXPECT().CompareAndMaybeRehash(gomock.Any(), "wrongPassword").Return("", false).AnyTimes()
+	fields.hash.EXPECT().CompareAndMaybeRehash(gomock.Any(), "password123").Return("", true).AnyTimes()
+
+	for i := 0; i < lockout.MaxFailures; i++ {
+		_, err := service.Login(stored.Email, "wrongPassword")
+		assert.Error(t, err)
+	}
+	_, err := service.Login(stored.Email, "password123")
+	assert.ErrorIs(t, err, service_errors.LoginLocked)
+
+	time.Sleep(2 * lockout.Window)
+
+	// a failure for another email drops the expired lock as well
+	_, err = service.Login(other.Email, "wrongPassword")
+	assert.NotErrorIs(t, err, service_errors.LoginLocked)
+
+	user, err := service.Login(stored.Email, "password123")
+	assert.NoError(t, err)
+	assert.Equal(t, stored, user)
+}
+
+func TestUserServiceLoginResetsFailedAttempts(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	fields := initUserServiceFields(ctrl)
+	service := services.NewUserService(fields.userRepoMock, fields.hash, fields.logger, testLoginLockout, nil)
+
+	stored := &models.User{ID: uuid.New(), Email: "test@gmail.com", Password: "hashedPassword"}
+	fields.userRepoMock.EXPECT().GetUserByEmail(stored.Email).Return(stored, nil).AnyTimes()
+	fields.hash.EXPECT().CompareAndMaybeRehash(stored.Password, "wrongPassword").Return("", false).AnyTimes()
+	fields.hash.EXPECT().CompareAndMaybeRehash(stored.Password, "password123").Return("", true).AnyTimes()
+
+	for i := 0; i < testLoginLockout.MaxFailures-1; i++ {
+		_, err := service.Login(stored.Email, "wrongPassword")
+		assert.Error(t, err)
+	}
+
+	user, err := service.Login(stored.Email, "password123")
+	assert.NoError(t, err)
+	assert.Equal(t, stored, user)
+
+	// the successful login started the count over
+	for i := 0; i < testLoginLockout.MaxFailures-1; i++ {
+		_, err = service.Login(stored.Email, "wrongPassword")
+		assert.NotErrorIs(t, err, service_errors.LoginLocked)
+	}
+
+	user, err = service.Login(stored.Email, "password123")
+	assert.NoError(t, err)
+	assert.Equal(t, stored, user)
+}
+
+func TestUserServiceLoginWithToken(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	stored := &models.User{ID: uuid.New(), Email: "test@gmail.com", Password: "hashedPassword"}
+
+	t.Run("token identifies the client", func(t *testing.T) {
+		fields := initUserServiceFields(ctrl)
+		tokens := auth.NewTokenIssuer("test-secret", time.Hour)
+		service := services.NewUserService(fields.userRepoMock, fields.hash, fields.logger, models.LockoutSettings{}, tokens)
+
+		fields.userRepoMock.EXPECT().GetUserByEmail(stored.Email).Return(stored, nil)
+		fields.hash.EXPECT().CompareAndMaybeRehash(stored.Password, "password123").Return("", true)
+
+		user, token, err := service.LoginWithToken(stored.Email, "password123")
+		assert.NoError(t, err)
+		assert.Equal(t, stored.ID, user.ID)
+
+		claims, err := tokens.ParseToken(token)
+		assert.NoError(t, err)
+		assert.Equal(t, stored.ID, claims.SubjectID)
+		assert.Equal(t, auth.ClientRole, claims.Role)
+	})
+
+	t.Run("no issuer configured", func(t *testing.T) {
+		fields := initUserServiceFields(ctrl)
+		service := services.NewUserService(fields.userRepoMock, fields.hash, fields.logger, models.LockoutSettings{}, nil)
+
+		fields.userRepoMock.EXPECT().GetUserByEmail(stored.Email).Return(stored, nil)
+		fields.hash.EXPECT().CompareAndMaybeRehash(stored.Password, "password123").Return("", true)
+
+		user, token, err := service.LoginWithToken(stored.Email, "password123")
+		assert.NoError(t, err)
+		assert.NotNil(t, user)
+		assert.Empty(t, token)
+	})
+
+	t.Run("wrong password", func(t *testing.T) {
+		fields := initUserServiceFields(ctrl)
+		service := services.NewUserService(fields.userRepoMock, fields.hash, fields.logger, models.LockoutSettings{}, auth.NewTokenIssuer("test-secret", time.Hour))
+
+		fields.userRepoMock.EXPECT().GetUserByEmail(stored.Email).Return(stored, nil)
+		fields.hash.EXPECT().CompareAndMaybeRehash(stored.Password, "wrongPassword").Return("", false)
+
+		user, token, err := service.LoginWithToken(stored.Email, "wrongPassword")
+		assert.Error(t, err)
+		assert.Nil(t, user)
+		assert.Empty(t, token)
+	})
+}
@@ -6,21 +6,29 @@ import (
 	"github.com/golang/mock/gomock"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/bcrypt"
 	"os"
+	"strings"
+	"teamdev/auth"
 	"teamdev/internal/models"
 	"teamdev/internal/repository/repository_errors"
 	services "teamdev/internal/services"
 	"teamdev/internal/services/service_errors"
 	"teamdev/internal/services/service_interfaces"
+	"teamdev/password_hash"
+	"teamdev/second_factor"
 	mock_password_hash "teamdev/tests/hasher_mocks"
 	mock_repository_interfaces "teamdev/tests/repository_mocks"
+	mock_second_factor "teamdev/tests/second_factor_mocks"
 	"testing"
+	"time"
 )
 
 type workerServiceFields struct {
 	workerRepoMock *mock_repository_interfaces.MockIWorkerRepository
 	logger         *log.Logger
 	hash           *mock_password_hash.MockPasswordHash
+	secondFactor   *mock_second_factor.MockProvider
 }
 
 func initWorkerServiceFields(ctrl *gomock.Controller) *workerServiceFields {
@@ -34,12 +42,17 @@ func initWorkerServiceFields(ctrl *gomock.Controller) *workerServiceFields {
 	return &workerServiceFields{
 		workerRepoMock: workerRepoMock,
 		hash:           mock_password_hash.NewMockPasswordHash(ctrl),
+		secondFactor:   mock_second_factor.NewMockProvider(ctrl),
 		logger:         logger,
 	}
 }
 
+const testMaxActiveOrders = 2
+
+var testManager = &models.Worker{ID: uuid.New(), Role: models.ManagerRole}
+
 func initWorkerService(fields *workerServiceFields) service_interfaces.IWorkerService {
-	return services.NewWorkerService(fields.workerRepoMock, fields.hash, fields.logger)
+	return services.NewWorkerService(fields.workerRepoMock, fields.hash, fields.logger, testMaxActiveOrders, fields.secondFactor, false, models.LockoutSettings{}, nil)
 }
 
 var testWorkerGetByID = []struct {
@@ -104,7 +117,7 @@ func TestWorkerService_GetWorkerByID(t *testing.T) {
 	for _, tt := range testWorkerGetByID {
 		tt.prepare(fields)
 		t.Run(tt.testName, func(t *testing.T) {
-			worker, err := service.GetWorkerByID(tt.inputData.id)
+			worker, err := service.GetWorkerByID(testManager, tt.inputData.id)
 			tt.checkOutput(t, worker, err)
 		})
 	}
@@ -185,16 +198,21 @@ func TestWorkerService_GetAllWorkers(t *testing.T) {
 var testWorkerDelete = []struct {
 	testName  string
 	inputData struct {
-		id uuid.UUID
+		id    uuid.UUID
+		force bool
 	}
 	prepare   func(fields *workerServiceFields)
 	checkFunc func(t *testing.T, err error)
 }{
 	{
-		testName:  "Success",
-		inputData: struct{ id uuid.UUID }{id: uuid.New()},
+		testName: "Success",
+		inputData: struct {
+			id    uuid.UUID
+			force bool
+		}{id: uuid.New()},
 		prepare: func(fields *workerServiceFields) {
 			fields.workerRepoMock.EXPECT().GetWorkerByID(gomock.Any()).Return(&models.Worker{}, nil)
+			fields.workerRepoMock.EXPECT().GetActiveOrdersCount(gomock.Any()).Return(0, nil)
 			fields.workerRepoMock.EXPECT().Delete(gomock.Any()).Return(nil)
 		},
 		checkFunc: func(t *testing.T, err error) {
@@ -202,8 +220,11 @@ var testWorkerDelete = []struct {
 		},
 	},
 	{
-		testName:  "worker not found",
-		inputData: struct{ id uuid.UUID }{id: uuid.New()},
+		testName: "worker not found",
+		inputData: struct {
+			id    uuid.UUID
+			force bool
+		}{id: uuid.New()},
 		prepare: func(fields *workerServiceFields) {
 			fields.workerRepoMock.EXPECT().GetWorkerByID(gomock.Any()).Return(nil, repository_errors.DoesNotExist)
 		},
@@ -212,6 +233,50 @@ var testWorkerDelete = []struct {
 			assert.Equal(t, repository_errors.DoesNotExist, err)
 		},
 	},
+	{
+		testName: "worker has active orders",
+		inputData: struct {
+			id    uuid.UUID
+			force bool
+		}{id: uuid.New()},
+		prepare: func(fields *workerServiceFields) {
+			fields.workerRepoMock.EXPECT().GetWorkerByID(gomock.Any()).Return(&models.Worker{}, nil)
+			fields.workerRepoMock.EXPECT().GetActiveOrdersCount(gomock.Any()).Return(2, nil)
+		},
+		checkFunc: func(t *testing.T, err error) {
+			assert.Error(t, err)
+			assert.Equal(t, service_errors.WorkerHasActiveOrders, err)
+		},
+	},
+	{
+		testName: "forced deletion of worker with active orders",
+		inputData: struct {
+			id    uuid.UUID
+			force bool
+		}{id: uuid.New(), force: true},
+		prepare: func(fields *workerServiceFields) {
+			fields.workerRepoMock.EXPECT().GetWorkerByID(gomock.Any()).Return(&models.Worker{}, nil)
+			fields.workerRepoMock.EXPECT().Delete(gomock.Any()).Return(nil)
+		},
+		checkFunc: func(t *testing.T, err error) {
+			assert.NoError(t, err)
+		},
+	},
+	{
+		testName: "delete error",
+		inputData: struct {
+			id    uuid.UUID
+			force bool
+		}{id: uuid.New(), force: true},
+		prepare: func(fields *workerServiceFields) {
+			fields.workerRepoMock.EXPECT().GetWorkerByID(gomock.Any()).Return(&models.Worker{}, nil)
+			fields.workerRepoMock.EXPECT().Delete(gomock.Any()).Return(repository_errors.DeleteError)
+		},
+		checkFunc: func(t *testing.T, err error) {
+			assert.Error(t, err)
+			assert.Equal(t, repository_errors.DeleteError, err)
+		},
+	},
 }
 
 func TestWorkerService_Delete(t *testing.T) {
@@ -224,7 +289,7 @@ func TestWorkerService_Delete(t *testing.T) {
 	for _, tt := range testWorkerDelete {
 		tt.prepare(fields)
 		t.Run(tt.testName, func(t *testing.T) {
-			err := service.Delete(tt.inputData.id)
+			err := service.Delete(testManager, tt.inputData.id, tt.inputData.force)
 			tt.checkFunc(t, err)
 		})
 	}
@@ -235,82 +300,61 @@ var testWorkerChangePassword = []struct {
 	testName  string
 	inputData struct {
 		id          uuid.UUID
-		name        string
-		surname     string
-		email       string
-		address     string
-		phoneNumber string
-		role        int
+		oldPassword string
 		password    string
 	}
-	prepare   func(fields *workerServiceFields)
-	checkFunc func(t *testing.T, worker *models.Worker, err error)
+	prepare   func(fields *workerServiceFields, id uuid.UUID)
+	checkFunc func(t *testing.T, err error)
 }{
 	{
 		testName: "Success",
 		inputData: struct {
 			id          uuid.UUID
-			name        string
-			surname     string
-			email       string
-			address     string
-			phoneNumber string
-			role        int
+			oldPassword string
 			password    string
-		}{
-			id:          uuid.New(),
-			name:        "Test",
-			surname:     "Test",
-			email:       "test@gmail.com",
-			address:     "Test",
-			phoneNumber: "+79999999999",
-			role:        1,
-			password:    "password123", //change password from
-		},
-		prepare: func(fields *workerServiceFields) {
-			var worker = &models.Worker{
-				ID:          uuid.New(),
-				Name:        "Test",
-				Surname:     "Test",
-				Email:       "test@gmail.com",
-				Address:     "Test",
-				PhoneNumber: "+79999999999",
-				Role:        1,
-				Password:    "password", //to
-			}
-			fields.workerRepoMock.EXPECT().GetWorkerByID(gomock.Any()).Return(worker, nil)
-			fields.hash.EXPECT().GetHash(gomock.Any()).Return("hash", nil)
-			fields.workerRepoMock.EXPECT().Update(worker).Return(worker, nil)
+		}{uuid.New(), "oldPassword1", "password123"},
+		prepare: func(fields *workerServiceFields, id uuid.UUID) {
+			fields.workerRepoMock.EXPECT().GetWorkerByID(id).Return(&models.Worker{ID: id, Password: "old_hash"}, nil)
+			fields.hash.EXPECT().CompareHashAndPassword("old_hash", "oldPassword1").Return(true)
+			fields.hash.EXPECT().GetHash("password123").Return("new_hash", nil)
+			fields.workerRepoMock.EXPECT().Update(gomock.Any()).DoAndReturn(func(worker *models.Worker) (*models.Worker, error) {
+				if worker.Password != "new_hash" {
+					return nil, repository_errors.UpdateError
+				}
+				return worker, nil
+			})
 		},
-		checkFunc: func(t *testing.T, worker *models.Worker, err error) {
+		checkFunc: func(t *testing.T, err error) {
 			assert.NoError(t, err)
 		},
 	},
 	{
-		testName: "worker not found",
+		testName: "wrong current password",
 		inputData: struct {
 			id          uuid.UUID
-			name        string
-			surname     string
-			email       string
-			address     string
-			phoneNumber string
-			role        int
+			oldPassword string
 			password    string
-		}{
-			id:          uuid.New(),
-			name:        "Test",
-			surname:     "Test",
-			email:       "test@gmail.com",
-			address:     "Test",
-			phoneNumber: "+79999999999",
-			role:        1,
-			password:    "password123",
+		}{uuid.New(), "wrongPassword1", "password123"},
+		prepare: func(fields *workerServiceFields, id uuid.UUID) {
+			fields.workerRepoMock.EXPECT().GetWorkerByID(id).Return(&models.Worker{ID: id, Password: "old_hash"}, nil)
+			fields.hash.EXPECT().CompareHashAndPassword("old_hash", "wrongPassword1").Return(false)
+			fields.workerRepoMock.EXPECT().Update(gomock.Any()).Times(0)
 		},
-		prepare: func(fields *workerServiceFields) {
-			fields.workerRepoMock.EXPECT().GetWorkerByID(gomock.Any()).Return(nil, repository_errors.DoesNotExist)
+		checkFunc: func(t *testing.T, err error) {
+			assert.ErrorIs(t, err, service_errors.MismatchedPassword)
 		},
-		checkFunc: func(t *testing.T, worker *models.Worker, err error) {
+	},
+	{
+		testName: "worker not found",
+		inputData: struct {
+			id          uuid.UUID
+			oldPassword string
+			password    string
+		}{uuid.New(), "oldPassword1", "password123"},
+		prepare: func(fields *workerServiceFields, id uuid.UUID) {
+			fields.workerRepoMock.EXPECT().GetWorkerByID(id).Return(nil, repository_errors.DoesNotExist)
+		},
+		checkFunc: func(t *testing.T, err error) {
 			assert.Error(t, err)
 			assert.Equal(t, repository_errors.DoesNotExist, err)
 		},
@@ -319,27 +363,14 @@ var testWorkerChangePassword = []struct {
 		testName: "invalid password",
 		inputData: struct {
 			id          uuid.UUID
-			name        string
-			surname     string
-			email       string
-			address     string
-			phoneNumber string
-			role        int
+			oldPassword string
 			password    string
-		}{
-			id:          uuid.New(),
-			name:        "Test",
-			surname:     "Test",
-			email:       "test@gmail.com",
-			address:     "Test",
-			phoneNumber: "+79999999999",
-			role:        1,
-			password:    "123",
+		}{uuid.New(), "oldPassword1", "123"},
+		prepare: func(fields *workerServiceFields, id uuid.UUID) {
+			fields.workerRepoMock.EXPECT().GetWorkerByID(id).Return(&models.Worker{ID: id, Password: "old_hash"}, nil)
+			fields.hash.EXPECT().CompareHashAndPassword("old_hash", "oldPassword1").Return(true)
 		},
-		prepare: func(fields *workerServiceFields) {
-			fields.workerRepoMock.EXPECT().GetWorkerByID(gomock.Any()).Return(&models.Worker{}, nil)
-		},
-		checkFunc: func(t *testing.T, worker *models.Worker, err error) {
+		checkFunc: func(t *testing.T, err error) {
 			assert.Error(t, err)
 			assert.Equal(t, fmt.Errorf("SERVICE: Invalid input"), err)
 		},
@@ -354,10 +385,10 @@ func TestWorkerService_ChangePassword(t *testing.T) {
 	service := initWorkerService(fields)
 
 	for _, tt := range testWorkerChangePassword {
-		tt.prepare(fields)
 		t.Run(tt.testName, func(t *testing.T) {
-			worker, err := service.Update(tt.inputData.id, tt.inputData.name, tt.inputData.surname, tt.inputData.email, tt.inputData.address, tt.inputData.phoneNumber, tt.inputData.role, tt.inputData.password)
-			tt.checkFunc(t, worker, err)
+			tt.prepare(fields, tt.inputData.id)
+			err := service.ChangePassword(tt.inputData.id, tt.inputData.oldPassword, tt.inputData.password)
+			tt.checkFunc(t, err)
 		})
 	}
 }
@@ -372,7 +403,6 @@ var testWorkerUpdateRole = []struct {
 		address     string
 		phoneNumber string
 		role        int
-		password    string
 	}
 	prepare   func(fields *workerServiceFields)
 	checkFunc func(t *testing.T, worker *models.Worker, err error)
@@ -387,7 +417,6 @@ var testWorkerUpdateRole = []struct {
 			address     string
 			phoneNumber string
 			role        int
-			password    string
 		}{
 			id:          uuid.New(),
 			name:        "Test",
@@ -396,7 +425,6 @@ var testWorkerUpdateRole = []struct {
 			address:     "Test",
 			phoneNumber: "+79999999999",
 			role:        1,
-			password:    "password123",
 		},
 		prepare: func(fields *workerServiceFields) {
 			fields.workerRepoMock.EXPECT().GetWorkerByID(gomock.Any()).Return(&models.Worker{
@@ -436,7 +464,6 @@ var testWorkerUpdateRole = []struct {
 			address     string
 			phoneNumber string
 			role        int
-			password    string
 		}{
 			id:          uuid.New(),
 			name:        "Test",
@@ -445,7 +472,6 @@ var testWorkerUpdateRole = []struct {
 			address:     "Test",
 			phoneNumber: "+79999999999",
 			role:        1,
-			password:    "password123",
 		},
 		prepare: func(fields *workerServiceFields) {
 			fields.workerRepoMock.EXPECT().GetWorkerByID(gomock.Any()).Return(nil, repository_errors.DoesNotExist)
@@ -465,7 +491,6 @@ var testWorkerUpdateRole = []struct {
 			address     string
 			phoneNumber string
 			role        int
-			password    string
 		}{
 			id:          uuid.New(),
 			name:        "Test",
@@ -474,14 +499,13 @@ var testWorkerUpdateRole = []struct {
 			address:     "Test",
 			phoneNumber: "+79999999999",
 			role:        5, //invalid role
-			password:    "password123",
 		},
 		prepare: func(fields *workerServiceFields) {
 			fields.workerRepoMock.EXPECT().GetWorkerByID(gomock.Any()).Return(&models.Worker{}, nil)
 		},
 		checkFunc: func(t *testing.T, worker *models.Worker, err error) {
 			assert.Error(t, err)
-			assert.Equal(t, fmt.Errorf("SERVICE: Invalid input"), err)
+			assert.ErrorIs(t, err, service_errors.InvalidRole)
 		},
 	},
 }
@@ -496,7 +520,7 @@ func TestWorkerService_UpdateRole(t *testing.T) {
 	for _, tt := range testWorkerUpdateRole {
 		tt.prepare(fields)
 		t.Run(tt.testName, func(t *testing.T) {
-			worker, err := service.Update(tt.inputData.id, tt.inputData.name, tt.inputData.surname, tt.inputData.email, tt.inputData.address, tt.inputData.phoneNumber, tt.inputData.role, tt.inputData.password)
+			worker, err := service.Update(testManager, tt.inputData.id, tt.inputData.name, tt.inputData.surname, tt.inputData.email, tt.inputData.address, tt.inputData.phoneNumber, tt.inputData.role)
 			tt.checkFunc(t, worker, err)
 		})
 	}
@@ -512,7 +536,6 @@ var testWorkerUpdatePersonalInformation = []struct {
 		address     string
 		phoneNumber string
 		role        int
-		password    string
 	}
 	prepare   func(fields *workerServiceFields)
 	checkFunc func(t *testing.T, worker *models.Worker, err error)
@@ -527,7 +550,6 @@ var testWorkerUpdatePersonalInformation = []struct {
 			address     string
 			phoneNumber string
 			role        int
-			password    string
 		}{
 			id:          uuid.New(),
 			name:        "Test",
@@ -536,7 +558,6 @@ var testWorkerUpdatePersonalInformation = []struct {
 			address:     "Test",
 			phoneNumber: "+79999999999",
 			role:        1,
-			password:    "password123",
 		},
 		prepare: func(fields *workerServiceFields) {
 			fields.workerRepoMock.EXPECT().GetWorkerByID(gomock.Any()).Return(&models.Worker{
@@ -569,6 +590,68 @@ var testWorkerUpdatePersonalInformation = []struct {
 			assert.Equal(t, "test@email.com", worker.Email)
 		},
 	},
+	{
+		testName: "formatted phone number is stored in E.164",
+		inputData: struct {
+			id          uuid.UUID
+			name        string
+			surname     string
+			email       string
+			address     string
+			phoneNumber string
+			role        int
+		}{
+			id:          uuid.New(),
+			name:        "Test",
+			surname:     "Test",
+			email:       "test@gmail.com",
+			address:     "Test",
+			phoneNumber: "+7 (912) 345-67-89",
+			role:        1,
+		},
+		prepare: func(fields *workerServiceFields) {
+			fields.workerRepoMock.EXPECT().GetWorkerByID(gomock.Any()).Return(&models.Worker{
+				ID:          uuid.New(),
+				PhoneNumber: "+79999999999",
+				Role:        1,
+			}, nil)
+			fields.workerRepoMock.EXPECT().Update(gomock.Any()).DoAndReturn(func(worker *models.Worker) (*models.Worker, error) {
+				return worker, nil
+			})
+		},
+		checkFunc: func(t *testing.T, worker *models.Worker, err error) {
+			assert.NoError(t, err)
+			assert.Equal(t, "+79123456789", worker.PhoneNumber)
+		},
+	},
+	{
+		testName: "phone number that cannot be normalized",
+		inputData: struct {
+			id          uuid.UUID
+			name        string
+			surname     string
+			email       string
+			address     string
+			phoneNumber string
+			role        int
+		}{
+			id:          uuid.New(),
+			name:        "Test",
+			surname:     "Test",
+			email:       "test@gmail.com",
+			address:     "Test",
+			phoneNumber: "8 (912) 345-67-89",
+			role:        1,
+		},
+		prepare: func(fields *workerServiceFields) {
+			fields.workerRepoMock.EXPECT().GetWorkerByID(gomock.Any()).Return(&models.Worker{ID: uuid.New(), Role: 1}, nil)
+			fields.workerRepoMock.EXPECT().Update(gomock.Any()).Times(0)
+		},
+		checkFunc: func(t *testing.T, worker *models.Worker, err error) {
+			assert.ErrorIs(t, err, service_errors.InvalidPhoneNumber)
+			assert.Nil(t, worker)
+		},
+	},
 	{
 		testName: "worker not found",
 		inputData: struct {
@@ -579,7 +662,6 @@ var testWorkerUpdatePersonalInformation = []struct {
 			address     string
 			phoneNumber string
 			role        int
-			password    string
 		}{
 			id:          uuid.New(),
 			name:        "Test",
@@ -588,7 +670,6 @@ var testWorkerUpdatePersonalInformation = []struct {
 			address:     "Test",
 			phoneNumber: "+79999999999",
 			role:        1,
-			password:    "password123",
 		},
 		prepare: func(fields *workerServiceFields) {
 			fields.workerRepoMock.EXPECT().GetWorkerByID(gomock.Any()).Return(nil, repository_errors.DoesNotExist)
@@ -609,7 +690,6 @@ var testWorkerUpdatePersonalInformation = []struct {
 			address     string
 			phoneNumber string
 			role        int
-			password    string
 		}{
 			id:          uuid.New(),
 			name:        "", //invalid name
@@ -618,7 +698,6 @@ var testWorkerUpdatePersonalInformation = []struct {
 			address:     "Test",
 			phoneNumber: "+79999999999",
 			role:        1,
-			password:    "password123",
 		},
 		prepare: func(fields *workerServiceFields) {
 			fields.workerRepoMock.EXPECT().GetWorkerByID(gomock.Any()).Return(&models.Worker{}, nil)
@@ -626,7 +705,7 @@ var testWorkerUpdatePersonalInformation = []struct {
 		checkFunc: func(t *testing.T, worker *models.Worker, err error) {
 			assert.Error(t, err)
 			assert.Nil(t, worker)
-			assert.Equal(t, fmt.Errorf("SERVICE: Invalid input"), err)
+			assert.ErrorIs(t, err, service_errors.InvalidName)
 		},
 	},
 	{
@@ -639,7 +718,6 @@ var testWorkerUpdatePersonalInformation = []struct {
 			address     string
 			phoneNumber string
 			role        int
-			password    string
 		}{
 			id:          uuid.New(),
 			name:        "Test",
@@ -648,7 +726,6 @@ var testWorkerUpdatePersonalInformation = []struct {
 			address:     "Test",
 			phoneNumber: "+79999999999",
 			role:        1,
-			password:    "password123",
 		},
 		prepare: func(fields *workerServiceFields) {
 			fields.workerRepoMock.EXPECT().GetWorkerByID(gomock.Any()).Return(&models.Worker{}, nil)
@@ -656,7 +733,7 @@ var testWorkerUpdatePersonalInformation = []struct {
 		checkFunc: func(t *testing.T, worker *models.Worker, err error) {
 			assert.Error(t, err)
 			assert.Nil(t, worker)
-			assert.Equal(t, fmt.Errorf("SERVICE: Invalid input"), err)
+			assert.ErrorIs(t, err, service_errors.InvalidEmail)
 		},
 	},
 	{
@@ -669,7 +746,6 @@ var testWorkerUpdatePersonalInformation = []struct {
 			address     string
 			phoneNumber string
 			role        int
-			password    string
 		}{
 			id:          uuid.New(),
 			name:        "Test",
@@ -678,7 +754,6 @@ var testWorkerUpdatePersonalInformation = []struct {
 			address:     "", //invalid address
 			phoneNumber: "+79999999999",
 			role:        1,
-			password:    "password123",
 		},
 		prepare: func(fields *workerServiceFields) {
 			fields.workerRepoMock.EXPECT().GetWorkerByID(gomock.Any()).Return(&models.Worker{}, nil)
@@ -686,7 +761,7 @@ var testWorkerUpdatePersonalInformation = []struct {
 		checkFunc: func(t *testing.T, worker *models.Worker, err error) {
 			assert.Error(t, err)
 			assert.Nil(t, worker)
-			assert.Equal(t, fmt.Errorf("SERVICE: Invalid input"), err)
+			assert.ErrorIs(t, err, service_errors.InvalidAddress)
 
 		},
 	},
@@ -700,7 +775,6 @@ var testWorkerUpdatePersonalInformation = []struct {
 			address     string
 			phoneNumber string
 			role        int
-			password    string
 		}{
 			id:          uuid.New(),
 			name:        "Test",
@@ -709,7 +783,6 @@ var testWorkerUpdatePersonalInformation = []struct {
 			address:     "Test",
 			phoneNumber: "123", //invalid phone number
 			role:        1,
-			password:    "password123",
 		},
 		prepare: func(fields *workerServiceFields) {
 			fields.workerRepoMock.EXPECT().GetWorkerByID(gomock.Any()).Return(&models.Worker{}, nil)
@@ -717,7 +790,7 @@ var testWorkerUpdatePersonalInformation = []struct {
 		checkFunc: func(t *testing.T, worker *models.Worker, err error) {
 			assert.Error(t, err)
 			assert.Nil(t, worker)
-			assert.Equal(t, fmt.Errorf("SERVICE: Invalid input"), err)
+			assert.ErrorIs(t, err, service_errors.InvalidPhoneNumber)
 		},
 	},
 }
@@ -732,7 +805,7 @@ func TestWorkerService_UpdatePersonalInformation(t *testing.T) {
 	for _, tt := range testWorkerUpdatePersonalInformation {
 		tt.prepare(fields)
 		t.Run(tt.testName, func(t *testing.T) {
-			worker, err := service.Update(tt.inputData.id, tt.inputData.name, tt.inputData.surname, tt.inputData.email, tt.inputData.address, tt.inputData.phoneNumber, tt.inputData.role, tt.inputData.password)
+			worker, err := service.Update(testManager, tt.inputData.id, tt.inputData.name, tt.inputData.surname, tt.inputData.email, tt.inputData.address, tt.inputData.phoneNumber, tt.inputData.role)
 			tt.checkFunc(t, worker, err)
 		})
 	}
@@ -784,6 +857,32 @@ var testWorkerCreate = []struct {
 			assert.Equal(t, "test@email.com", worker.Email)
 		},
 	},
+	{
+		testName: "email taken by a concurrent create",
+		inputData: struct {
+			worker   *models.Worker
+			password string
+		}{
+			worker: &models.Worker{
+				Name:        "Test",
+				Surname:     "Test",
+				Email:       "test@email.com",
+				Address:     "Test",
+				PhoneNumber: "+79999999999",
+				Role:        1,
+			},
+			password: "password123",
+		},
+		prepare: func(fields *workerServiceFields) {
+			fields.workerRepoMock.EXPECT().GetWorkerByEmail(gomock.Any()).Return(nil, nil)
+			fields.hash.EXPECT().GetHash(gomock.Any()).Return("hash", nil)
+			fields.workerRepoMock.EXPECT().Create(gomock.Any()).Return(nil, repository_errors.AlreadyExists)
+		},
+		checkFunc: func(t *testing.T, worker *models.Worker, err error) {
+			assert.Equal(t, service_errors.NotUnique, err)
+			assert.Nil(t, worker)
+		},
+	},
 	{
 		testName: "worker already exists",
 		inputData: struct {
@@ -806,7 +905,7 @@ var testWorkerCreate = []struct {
 		checkFunc: func(t *testing.T, worker *models.Worker, err error) {
 			assert.Error(t, err)
 			assert.Nil(t, worker)
-			assert.Equal(t, fmt.Errorf("SERVICE: Worker with email already exists"), err)
+			assert.ErrorIs(t, err, service_errors.NotUnique)
 		},
 	},
 	{
@@ -829,7 +928,7 @@ var testWorkerCreate = []struct {
 		checkFunc: func(t *testing.T, worker *models.Worker, err error) {
 			assert.Error(t, err)
 			assert.Nil(t, worker)
-			assert.Equal(t, fmt.Errorf("SERVICE: Invalid input"), err)
+			assert.ErrorIs(t, err, service_errors.InvalidName)
 		},
 	},
 	{
@@ -852,7 +951,7 @@ var testWorkerCreate = []struct {
 		checkFunc: func(t *testing.T, worker *models.Worker, err error) {
 			assert.Error(t, err)
 			assert.Nil(t, worker)
-			assert.Equal(t, fmt.Errorf("SERVICE: Invalid input"), err)
+			assert.ErrorIs(t, err, service_errors.InvalidEmail)
 		},
 	},
 	{
@@ -875,7 +974,7 @@ var testWorkerCreate = []struct {
 		checkFunc: func(t *testing.T, worker *models.Worker, err error) {
 			assert.Error(t, err)
 			assert.Nil(t, worker)
-			assert.Equal(t, fmt.Errorf("SERVICE: Invalid input"), err)
+			assert.ErrorIs(t, err, service_errors.InvalidAddress)
 		},
 	},
 	{
@@ -899,7 +998,7 @@ var testWorkerCreate = []struct {
 		checkFunc: func(t *testing.T, worker *models.Worker, err error) {
 			assert.Error(t, err)
 			assert.Nil(t, worker)
-			assert.Equal(t, fmt.Errorf("SERVICE: Invalid input"), err)
+			assert.ErrorIs(t, err, service_errors.InvalidPhoneNumber)
 		},
 	},
 
@@ -923,11 +1022,48 @@ var testWorkerCreate = []struct {
 		checkFunc: func(t *testing.T, worker *models.Worker, err error) {
 			assert.Error(t, err)
 			assert.Nil(t, worker)
-			assert.Equal(t, fmt.Errorf("SERVICE: Invalid input"), err)
+			assert.ErrorIs(t, err, service_errors.InvalidPassword)
 		},
 	},
 }
 
+func TestWorkerServiceCreateValidationErrors(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	fields := initWorkerServiceFields(ctrl)
+	service := initWorkerService(fields)
+	fields.workerRepoMock.EXPECT().Create(gomock.Any()).Times(0)
+
+	valid := func() *models.Worker {
+		return &models.Worker{Name: "Test", Surname: "Test", Email: "test@email.com", Address: "Test", PhoneNumber: "+79999999999", Role: models.MasterRole}
+	}
+
+	for _, tt := range []struct {
+		field    string
+		modify   func(worker *models.Worker)
+		password string
+		expected error
+	}{
+		{"name", func(worker *models.Worker) { worker.Name = "" }, "password123", service_errors.InvalidName},
+		{"surname", func(worker *models.Worker) { worker.Surname = "" }, "password123", service_errors.InvalidName},
+		{"email", func(worker *models.Worker) { worker.Email = "email" }, "password123", service_errors.InvalidEmail},
+		{"address", func(worker *models.Worker) { worker.Address = "" }, "password123", service_errors.InvalidAddress},
+		{"phone number", func(worker *models.Worker) { worker.PhoneNumber = "8-800" }, "password123", service_errors.InvalidPhoneNumber},
+		{"role", func(worker *models.Worker) { worker.Role = 7 }, "password123", service_errors.InvalidRole},
+		{"password", func(worker *models.Worker) {}, "", service_errors.InvalidPassword},
+	} {
+		t.Run(tt.field, func(t *testing.T) {
+			worker := valid()
+			tt.modify(worker)
+
+			created, err := service.Create(testManager, worker, tt.password)
+			assert.ErrorIs(t, err, tt.expected)
+			assert.Nil(t, created)
+		})
+	}
+}
+
 func TestWorkerServiceCreate(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -938,50 +1074,172 @@ func TestWorkerServiceCreate(t *testing.T) {
 	for _, tt := range testWorkerCreate {
 		tt.prepare(fields)
 		t.Run(tt.testName, func(t *testing.T) {
-			worker, err := service.Create(tt.inputData.worker, tt.inputData.password)
+			worker, err := service.Create(testManager, tt.inputData.worker, tt.inputData.password)
 			tt.checkFunc(t, worker, err)
 		})
 	}
 }
 
-var testWorkerLogin = []struct {
+var testWorkerCreateAuthorization = []struct {
 	testName  string
-	inputData struct {
-		email    string
-		password string
-	}
+	editor    *models.Worker
+	role      int
 	prepare   func(fields *workerServiceFields)
 	checkFunc func(t *testing.T, worker *models.Worker, err error)
 }{
 	{
-		testName: "Success",
-		inputData: struct {
-			email    string
-			password string
-		}{
-			email:    "test@email.com",
-			password: "password123",
-		},
+		testName: "manager creates a master",
+		editor:   testManager,
+		role:     models.MasterRole,
 		prepare: func(fields *workerServiceFields) {
-			fields.workerRepoMock.EXPECT().GetWorkerByEmail(gomock.Any()).Return(&models.Worker{
-				Name:     "Test",
-				Surname:  "Test",
-				Email:    "test@email.com",
-				Address:  "Test",
-				Role:     1,
-				Password: "hash",
-			}, nil)
-			fields.hash.EXPECT().CompareHashAndPassword(gomock.Any(), gomock.Any()).Return(true)
+			fields.workerRepoMock.EXPECT().GetWorkerByEmail(gomock.Any()).Return(nil, nil)
+			fields.hash.EXPECT().GetHash(gomock.Any()).Return("hash", nil)
+			fields.workerRepoMock.EXPECT().Create(gomock.Any()).DoAndReturn(func(worker *models.Worker) (*models.Worker, error) {
+				worker.ID = uuid.New()
+				return worker, nil
+			})
 		},
 		checkFunc: func(t *testing.T, worker *models.Worker, err error) {
 			assert.NoError(t, err)
-			assert.Equal(t, "Test", worker.Name)
-			assert.Equal(t, "test@email.com", worker.Email)
-			assert.Equal(t, "hash", worker.Password)
+			assert.Equal(t, models.MasterRole, worker.Role)
 		},
 	},
 	{
-		testName: "worker not found",
+		testName: "master cannot create a worker",
+		editor:   testMaster,
+		role:     models.MasterRole,
+		prepare: func(fields *workerServiceFields) {
+			fields.workerRepoMock.EXPECT().Create(gomock.Any()).Times(0)
+		},
+		checkFunc: func(t *testing.T, worker *models.Worker, err error) {
+			assert.Equal(t, service_errors.InvalidRole, err)
+			assert.Nil(t, worker)
+		},
+	},
+	{
+		testName: "first manager is created without an editor",
+		editor:   nil,
+		role:     models.ManagerRole,
+		prepare: func(fields *workerServiceFields) {
+			fields.workerRepoMock.EXPECT().GetWorkersByRole(models.ManagerRole, uuid.Nil).Return(nil, nil)
+			fields.workerRepoMock.EXPECT().GetWorkerByEmail(gomock.Any()).Return(nil, nil)
+			fields.hash.EXPECT().GetHash(gomock.Any()).Return("hash", nil)
+			fields.workerRepoMock.EXPECT().Create(gomock.Any()).DoAndReturn(func(worker *models.Worker) (*models.Worker, error) {
+				worker.ID = uuid.New()
+				return worker, nil
+			})
+		},
+		checkFunc: func(t *testing.T, worker *models.Worker, err error) {
+			assert.NoError(t, err)
+			assert.Equal(t, models.ManagerRole, worker.Role)
+		},
+	},
+	{
+		testName: "editor is required once a manager exists",
+		editor:   nil,
+		role:     models.ManagerRole,
+		prepare: func(fields *workerServiceFields) {
+			fields.workerRepoMock.EXPECT().GetWorkersByRole(models.ManagerRole, uuid.Nil).Return([]models.Worker{*testManager}, nil)
+			fields.workerRepoMock.EXPECT().Create(gomock.Any()).Times(0)
+		},
+		checkFunc: func(t *testing.T, worker *models.Worker, err error) {
+			assert.Equal(t, service_errors.InvalidRole, err)
+			assert.Nil(t, worker)
+		},
+	},
+	{
+		testName: "master cannot be created without an editor",
+		editor:   nil,
+		role:     models.MasterRole,
+		prepare: func(fields *workerServiceFields) {
+			fields.workerRepoMock.EXPECT().Create(gomock.Any()).Times(0)
+		},
+		checkFunc: func(t *testing.T, worker *models.Worker, err error) {
+			assert.Equal(t, service_errors.InvalidRole, err)
+			assert.Nil(t, worker)
+		},
+	},
+}
+
+func TestWorkerServiceCreateAuthorization(t *testing.T) {
+	for _, tt := range testWorkerCreateAuthorization {
+		t.Run(tt.testName, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			fields := initWorkerServiceFields(ctrl)
+			service := initWorkerService(fields)
+			tt.prepare(fields)
+
+			worker, err := service.Create(tt.editor, &models.Worker{
+				Name:        "Test",
+				Surname:     "Test",
+				Email:       "test@email.com",
+				Address:     "Test",
+				PhoneNumber: "+79999999999",
+				Role:        tt.role,
+			}, "password123")
+			tt.checkFunc(t, worker, err)
+		})
+	}
+}
+
+func TestWorkerServiceDeleteByMaster(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	fields := initWorkerServiceFields(ctrl)
+	service := initWorkerService(fields)
+
+	fields.workerRepoMock.EXPECT().GetWorkerByID(gomock.Any()).Times(0)
+	fields.workerRepoMock.EXPECT().Delete(gomock.Any()).Times(0)
+
+	err := service.Delete(testMaster, uuid.New(), true)
+	assert.Equal(t, service_errors.InvalidRole, err)
+}
+
+var testWorkerLogin = []struct {
+	testName  string
+	inputData struct {
+		email    string
+		password string
+	}
+	prepare   func(fields *workerServiceFields)
+	checkFunc func(t *testing.T, worker *models.Worker, secondFactorRequired bool, err error)
+}{
+	{
+		testName: "Success",
+		inputData: struct {
+			email    string
+			password string
+		}{
+			email:    "test@email.com",
+			password: "password123",
+		},
+		prepare: func(fields *workerServiceFields) {
+			fields.workerRepoMock.EXPECT().GetWorkerByEmail(gomock.Any()).Return(&models.Worker{
+				Name:     "Test",
+				Surname:  "Test",
+				Email:    "test@email.com",
+				Address:  "Test",
+				Role:     1,
+				Password: "hash",
+			}, nil)
+			fields.hash.EXPECT().CompareAndMaybeRehash(gomock.Any(), gomock.Any()).Return("", true)
+			fields.workerRepoMock.EXPECT().UpdateLastLogin(gomock.Any(), gomock.Any()).Return(nil)
+		},
+		checkFunc: func(t *testing.T, worker *models.Worker, secondFactorRequired bool, err error) {
+			assert.NoError(t, err)
+			assert.False(t, secondFactorRequired)
+			assert.Equal(t, "Test", worker.Name)
+			assert.Equal(t, "test@email.com", worker.Email)
+			assert.Equal(t, "hash", worker.Password)
+			assert.NotNil(t, worker.LastLoginAt)
+			assert.WithinDuration(t, time.Now(), *worker.LastLoginAt, time.Minute)
+		},
+	},
+	{
+		testName: "worker not found",
 		inputData: struct {
 			email    string
 			password string
@@ -992,12 +1250,31 @@ var testWorkerLogin = []struct {
 		prepare: func(fields *workerServiceFields) {
 			fields.workerRepoMock.EXPECT().GetWorkerByEmail(gomock.Any()).Return(nil, service_errors.InvalidEmail)
 		},
-		checkFunc: func(t *testing.T, worker *models.Worker, err error) {
+		checkFunc: func(t *testing.T, worker *models.Worker, secondFactorRequired bool, err error) {
 			assert.Error(t, err)
 			assert.Nil(t, worker)
 			assert.Equal(t, service_errors.InvalidEmail, err)
 		},
 	},
+	{
+		testName: "wrapped does not exist error",
+		inputData: struct {
+			email    string
+			password string
+		}{
+			email:    "not@found.com",
+			password: "password123",
+		},
+		prepare: func(fields *workerServiceFields) {
+			fields.workerRepoMock.EXPECT().GetWorkerByEmail(gomock.Any()).Return(nil, fmt.Errorf("get worker by email: %w", repository_errors.DoesNotExist))
+			fields.hash.EXPECT().CompareAndMaybeRehash(gomock.Any(), gomock.Any()).Times(0)
+		},
+		checkFunc: func(t *testing.T, worker *models.Worker, secondFactorRequired bool, err error) {
+			assert.Error(t, err)
+			assert.Nil(t, worker)
+			assert.Equal(t, fmt.Errorf("SERVICE: Worker with email does not exist"), err)
+		},
+	},
 	{
 		testName: "invalid password",
 		inputData: struct {
@@ -1013,14 +1290,39 @@ var testWorkerLogin = []struct {
 				Surname: "Test",
 				Email:   "test@email.com",
 			}, nil)
-			fields.hash.EXPECT().CompareHashAndPassword(gomock.Any(), gomock.Any()).Return(false)
+			fields.hash.EXPECT().CompareAndMaybeRehash(gomock.Any(), gomock.Any()).Return("", false)
+			fields.workerRepoMock.EXPECT().UpdateLastLogin(gomock.Any(), gomock.Any()).Times(0)
 		},
-		checkFunc: func(t *testing.T, worker *models.Worker, err error) {
+		checkFunc: func(t *testing.T, worker *models.Worker, secondFactorRequired bool, err error) {
 			assert.Error(t, err)
 			assert.Nil(t, worker)
 			assert.Equal(t, fmt.Errorf("SERVICE: Password is incorrect for worker with email"), err)
 		},
 	},
+	{
+		testName: "update last login error",
+		inputData: struct {
+			email    string
+			password string
+		}{
+			email:    "test@email.com",
+			password: "password123",
+		},
+		prepare: func(fields *workerServiceFields) {
+			fields.workerRepoMock.EXPECT().GetWorkerByEmail(gomock.Any()).Return(&models.Worker{
+				Name:    "Test",
+				Surname: "Test",
+				Email:   "test@email.com",
+			}, nil)
+			fields.hash.EXPECT().CompareAndMaybeRehash(gomock.Any(), gomock.Any()).Return("", true)
+			fields.workerRepoMock.EXPECT().UpdateLastLogin(gomock.Any(), gomock.Any()).Return(repository_errors.UpdateError)
+		},
+		checkFunc: func(t *testing.T, worker *models.Worker, secondFactorRequired bool, err error) {
+			assert.Error(t, err)
+			assert.Nil(t, worker)
+			assert.Equal(t, repository_errors.UpdateError, err)
+		},
+	},
 }
 
 func TestWorkerServiceLogin(t *testing.T) {
@@ -1033,8 +1335,1365 @@ func TestWorkerServiceLogin(t *testing.T) {
 	for _, tt := range testWorkerLogin {
 		tt.prepare(fields)
 		t.Run(tt.testName, func(t *testing.T) {
-			worker, err := service.Login(tt.inputData.email, tt.inputData.password)
-			tt.checkFunc(t, worker, err)
+			worker, secondFactorRequired, err := service.Login(tt.inputData.email, tt.inputData.password)
+			tt.checkFunc(t, worker, secondFactorRequired, err)
+		})
+	}
+}
+
+func TestWorkerService_GetWorkerByEmail(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	fields := initWorkerServiceFields(ctrl)
+	service := initWorkerService(fields)
+
+	stored := models.Worker{ID: uuid.New(), Email: "master@pikaclean.ru", Role: models.MasterRole, Password: "hashedPassword"}
+	fields.workerRepoMock.EXPECT().GetWorkerByEmail(stored.Email).DoAndReturn(func(email string) (*models.Worker, error) {
+		worker := stored
+		return &worker, nil
+	}).Times(3)
+	fields.workerRepoMock.EXPECT().GetWorkerByEmail("missing@pikaclean.ru").Return(nil, repository_errors.DoesNotExist)
+
+	expected := stored
+	expected.Password = ""
+
+	worker, err := service.GetWorkerByEmail(testManager, stored.Email)
+	assert.NoError(t, err)
+	assert.Equal(t, &expected, worker)
+
+	// a master may look up their own profile only
+	worker, err = service.GetWorkerByEmail(&models.Worker{ID: stored.ID, Role: models.MasterRole}, stored.Email)
+	assert.NoError(t, err)
+	assert.Equal(t, &expected, worker)
+
+	worker, err = service.GetWorkerByEmail(&models.Worker{ID: uuid.New(), Role: models.MasterRole}, stored.Email)
+	assert.ErrorIs(t, err, service_errors.PermissionDenied)
+	assert.Nil(t, worker)
+
+	worker, err = service.GetWorkerByEmail(testManager, "missing@pikaclean.ru")
+	assert.ErrorIs(t, err, repository_errors.DoesNotExist)
+	assert.Nil(t, worker)
+}
+
+var testWorkerExportCSV = []struct {
+	testName  string
+	prepare   func(fields *workerServiceFields)
+	checkFunc func(t *testing.T, data []byte, err error)
+}{
+	{
+		testName: "Success",
+		prepare: func(fields *workerServiceFields) {
+			fields.workerRepoMock.EXPECT().GetAllWorkers().Return([]models.Worker{
+				{
+					Name:        "Test",
+					Surname:     "Test",
+					Email:       "test@gmail.com",
+					Address:     "Test address",
+					PhoneNumber: "+79999999999",
+					Role:        models.MasterRole,
+					Password:    "secret_hash",
+				},
+			}, nil)
+			fields.workerRepoMock.EXPECT().GetAverageOrderRate(gomock.Any()).Return(4.5, nil)
+		},
+		checkFunc: func(t *testing.T, data []byte, err error) {
+			assert.NoError(t, err)
+			lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+			assert.Equal(t, 2, len(lines))
+			assert.Equal(t, "name,surname,email,phone_number,address,role,average_rating", lines[0])
+			assert.Equal(t, "Test,Test,test@gmail.com,+79999999999,Test address,Мастер,4.50", lines[1])
+			assert.NotContains(t, string(data), "secret_hash")
+		},
+	},
+	{
+		testName: "get all workers error",
+		prepare: func(fields *workerServiceFields) {
+			fields.workerRepoMock.EXPECT().GetAllWorkers().Return(nil, repository_errors.SelectError)
+		},
+		checkFunc: func(t *testing.T, data []byte, err error) {
+			assert.Error(t, err)
+			assert.Nil(t, data)
+			assert.Equal(t, repository_errors.SelectError, err)
+		},
+	},
+	{
+		testName: "average rate error",
+		prepare: func(fields *workerServiceFields) {
+			fields.workerRepoMock.EXPECT().GetAllWorkers().Return([]models.Worker{{Name: "Test"}}, nil)
+			fields.workerRepoMock.EXPECT().GetAverageOrderRate(gomock.Any()).Return(0.0, repository_errors.SelectError)
+		},
+		checkFunc: func(t *testing.T, data []byte, err error) {
+			assert.Error(t, err)
+			assert.Nil(t, data)
+			assert.Equal(t, repository_errors.SelectError, err)
+		},
+	},
+}
+
+func TestWorkerService_ExportCSV(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	fields := initWorkerServiceFields(ctrl)
+	service := initWorkerService(fields)
+
+	for _, tt := range testWorkerExportCSV {
+		tt.prepare(fields)
+		t.Run(tt.testName, func(t *testing.T) {
+			data, err := service.ExportCSV()
+			tt.checkFunc(t, data, err)
+		})
+	}
+}
+
+var testWorkerExportEarningsCSV = []struct {
+	testName  string
+	year      int
+	prepare   func(fields *workerServiceFields)
+	checkFunc func(t *testing.T, data []byte, err error)
+}{
+	{
+		testName: "rows and year total",
+		year:     2024,
+		prepare: func(fields *workerServiceFields) {
+			first := time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC)
+			second := time.Date(2024, 11, 20, 18, 30, 0, 0, time.UTC)
+			fields.workerRepoMock.EXPECT().GetWorkerByID(gomock.Any()).Return(&models.Worker{}, nil)
+			fields.workerRepoMock.EXPECT().GetCompletedOrdersInYear(gomock.Any(), 2024).Return([]models.Order{
+				{ID: uuid.MustParse("11111111-1111-1111-1111-111111111111"), Address: "First address", QuotedTotal: 100.5, CompletedAt: &first},
+				{ID: uuid.MustParse("22222222-2222-2222-2222-222222222222"), Address: "Second address", QuotedTotal: 200.25, CompletedAt: &second},
+			}, nil)
+		},
+		checkFunc: func(t *testing.T, data []byte, err error) {
+			assert.NoError(t, err)
+			lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+			assert.Equal(t, []string{
+				"order_id,completed_at,address,total",
+				"11111111-1111-1111-1111-111111111111,2024-03-01,First address,100.50",
+				"22222222-2222-2222-2222-222222222222,2024-11-20,Second address,200.25",
+				"total,2024,,300.75",
+			}, lines)
+		},
+	},
+	{
+		testName: "year without orders",
+		year:     2023,
+		prepare: func(fields *workerServiceFields) {
+			fields.workerRepoMock.EXPECT().GetWorkerByID(gomock.Any()).Return(&models.Worker{}, nil)
+			fields.workerRepoMock.EXPECT().GetCompletedOrdersInYear(gomock.Any(), 2023).Return(nil, nil)
+		},
+		checkFunc: func(t *testing.T, data []byte, err error) {
+			assert.NoError(t, err)
+			lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+			assert.Equal(t, []string{"order_id,completed_at,address,total", "total,2023,,0.00"}, lines)
+		},
+	},
+	{
+		testName: "worker not found",
+		year:     2024,
+		prepare: func(fields *workerServiceFields) {
+			fields.workerRepoMock.EXPECT().GetWorkerByID(gomock.Any()).Return(nil, repository_errors.DoesNotExist)
+		},
+		checkFunc: func(t *testing.T, data []byte, err error) {
+			assert.Nil(t, data)
+			assert.Equal(t, repository_errors.DoesNotExist, err)
+		},
+	},
+	{
+		testName: "invalid year",
+		year:     0,
+		prepare:  func(fields *workerServiceFields) {},
+		checkFunc: func(t *testing.T, data []byte, err error) {
+			assert.Nil(t, data)
+			assert.Equal(t, fmt.Errorf("SERVICE: Invalid input"), err)
+		},
+	},
+	{
+		testName: "orders retrieval error",
+		year:     2024,
+		prepare: func(fields *workerServiceFields) {
+			fields.workerRepoMock.EXPECT().GetWorkerByID(gomock.Any()).Return(&models.Worker{}, nil)
+			fields.workerRepoMock.EXPECT().GetCompletedOrdersInYear(gomock.Any(), 2024).Return(nil, repository_errors.SelectError)
+		},
+		checkFunc: func(t *testing.T, data []byte, err error) {
+			assert.Nil(t, data)
+			assert.Equal(t, repository_errors.SelectError, err)
+		},
+	},
+}
+
+func TestWorkerService_ExportEarningsCSV(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	fields := initWorkerServiceFields(ctrl)
+	service := initWorkerService(fields)
+
+	for _, tt := range testWorkerExportEarningsCSV {
+		tt.prepare(fields)
+		t.Run(tt.testName, func(t *testing.T) {
+			data, err := service.ExportEarningsCSV(uuid.New(), tt.year)
+			tt.checkFunc(t, data, err)
 		})
 	}
 }
+
+var testWorkerHasCapacity = []struct {
+	testName  string
+	prepare   func(fields *workerServiceFields)
+	checkFunc func(t *testing.T, hasCapacity bool, err error)
+}{
+	{
+		testName: "under capacity",
+		prepare: func(fields *workerServiceFields) {
+			fields.workerRepoMock.EXPECT().GetWorkerByID(gomock.Any()).Return(&models.Worker{}, nil)
+			fields.workerRepoMock.EXPECT().GetActiveOrdersCount(gomock.Any()).Return(testMaxActiveOrders-1, nil)
+		},
+		checkFunc: func(t *testing.T, hasCapacity bool, err error) {
+			assert.NoError(t, err)
+			assert.True(t, hasCapacity)
+		},
+	},
+	{
+		testName: "at capacity",
+		prepare: func(fields *workerServiceFields) {
+			fields.workerRepoMock.EXPECT().GetWorkerByID(gomock.Any()).Return(&models.Worker{}, nil)
+			fields.workerRepoMock.EXPECT().GetActiveOrdersCount(gomock.Any()).Return(testMaxActiveOrders, nil)
+		},
+		checkFunc: func(t *testing.T, hasCapacity bool, err error) {
+			assert.NoError(t, err)
+			assert.False(t, hasCapacity)
+		},
+	},
+	{
+		testName: "over capacity",
+		prepare: func(fields *workerServiceFields) {
+			fields.workerRepoMock.EXPECT().GetWorkerByID(gomock.Any()).Return(&models.Worker{}, nil)
+			fields.workerRepoMock.EXPECT().GetActiveOrdersCount(gomock.Any()).Return(testMaxActiveOrders+1, nil)
+		},
+		checkFunc: func(t *testing.T, hasCapacity bool, err error) {
+			assert.NoError(t, err)
+			assert.False(t, hasCapacity)
+		},
+	},
+	{
+		testName: "worker not found",
+		prepare: func(fields *workerServiceFields) {
+			fields.workerRepoMock.EXPECT().GetWorkerByID(gomock.Any()).Return(nil, repository_errors.DoesNotExist)
+		},
+		checkFunc: func(t *testing.T, hasCapacity bool, err error) {
+			assert.Error(t, err)
+			assert.False(t, hasCapacity)
+			assert.Equal(t, repository_errors.DoesNotExist, err)
+		},
+	},
+}
+
+func TestWorkerService_HasCapacity(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	fields := initWorkerServiceFields(ctrl)
+	service := initWorkerService(fields)
+
+	for _, tt := range testWorkerHasCapacity {
+		tt.prepare(fields)
+		t.Run(tt.testName, func(t *testing.T) {
+			hasCapacity, err := service.HasCapacity(uuid.New())
+			tt.checkFunc(t, hasCapacity, err)
+		})
+	}
+}
+
+var testWorkerGetRevenueBetween = []struct {
+	testName  string
+	from      time.Time
+	to        time.Time
+	prepare   func(fields *workerServiceFields)
+	checkFunc func(t *testing.T, revenue float64, err error)
+}{
+	{
+		testName: "success",
+		from:     time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		to:       time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC),
+		prepare: func(fields *workerServiceFields) {
+			fields.workerRepoMock.EXPECT().GetWorkerByID(gomock.Any()).Return(&models.Worker{}, nil)
+			fields.workerRepoMock.EXPECT().GetRevenueBetween(gomock.Any(), gomock.Any(), gomock.Any()).Return(300.0, nil)
+		},
+		checkFunc: func(t *testing.T, revenue float64, err error) {
+			assert.NoError(t, err)
+			assert.Equal(t, 300.0, revenue)
+		},
+	},
+	{
+		testName: "end of period before start",
+		from:     time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC),
+		to:       time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		prepare:  func(fields *workerServiceFields) {},
+		checkFunc: func(t *testing.T, revenue float64, err error) {
+			assert.Error(t, err)
+			assert.Equal(t, 0.0, revenue)
+		},
+	},
+	{
+		testName: "worker not found",
+		from:     time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		to:       time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC),
+		prepare: func(fields *workerServiceFields) {
+			fields.workerRepoMock.EXPECT().GetWorkerByID(gomock.Any()).Return(nil, repository_errors.DoesNotExist)
+		},
+		checkFunc: func(t *testing.T, revenue float64, err error) {
+			assert.Error(t, err)
+			assert.Equal(t, repository_errors.DoesNotExist, err)
+		},
+	},
+}
+
+func TestWorkerService_GetRevenueBetween(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	fields := initWorkerServiceFields(ctrl)
+	service := initWorkerService(fields)
+
+	for _, tt := range testWorkerGetRevenueBetween {
+		tt.prepare(fields)
+		t.Run(tt.testName, func(t *testing.T) {
+			revenue, err := service.GetRevenueBetween(uuid.New(), tt.from, tt.to)
+			tt.checkFunc(t, revenue, err)
+		})
+	}
+}
+
+var testWorkerGetTeamComparison = []struct {
+	testName  string
+	prepare   func(fields *workerServiceFields)
+	checkFunc func(t *testing.T, comparisons []models.WorkerComparison, err error)
+}{
+	{
+		testName: "success",
+		prepare: func(fields *workerServiceFields) {
+			fields.workerRepoMock.EXPECT().GetTeamComparison().Return([]models.WorkerComparison{
+				{Worker: models.Worker{ID: uuid.New()}, AverageRating: 5, RatingPercentile: 100},
+				{Worker: models.Worker{ID: uuid.New()}, AverageRating: 3, RatingPercentile: 0},
+			}, nil)
+		},
+		checkFunc: func(t *testing.T, comparisons []models.WorkerComparison, err error) {
+			assert.NoError(t, err)
+			assert.Len(t, comparisons, 2)
+		},
+	},
+	{
+		testName: "repository error",
+		prepare: func(fields *workerServiceFields) {
+			fields.workerRepoMock.EXPECT().GetTeamComparison().Return(nil, repository_errors.SelectError)
+		},
+		checkFunc: func(t *testing.T, comparisons []models.WorkerComparison, err error) {
+			assert.Error(t, err)
+			assert.Nil(t, comparisons)
+			assert.Equal(t, repository_errors.SelectError, err)
+		},
+	},
+}
+
+func TestWorkerService_GetTeamComparison(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	fields := initWorkerServiceFields(ctrl)
+	service := initWorkerService(fields)
+
+	for _, tt := range testWorkerGetTeamComparison {
+		tt.prepare(fields)
+		t.Run(tt.testName, func(t *testing.T) {
+			comparisons, err := service.GetTeamComparison()
+			tt.checkFunc(t, comparisons, err)
+		})
+	}
+}
+
+func TestWorkerServiceLoginUpgradesPasswordHash(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	fields := initWorkerServiceFields(ctrl)
+	service := services.NewWorkerService(fields.workerRepoMock, password_hash.NewPasswordHashWithCost(testPasswordHashCost), fields.logger, testMaxActiveOrders, fields.secondFactor, false, models.LockoutSettings{}, nil)
+
+	weakHash, err := password_hash.NewPasswordHashWithCost(bcrypt.MinCost).GetHash("password123")
+	assert.NoError(t, err)
+	stored := &models.Worker{ID: uuid.New(), Email: "test@email.com", Password: weakHash}
+
+	var upgradedHash string
+	fields.workerRepoMock.EXPECT().GetWorkerByEmail(stored.Email).Return(stored, nil)
+	fields.workerRepoMock.EXPECT().Update(gomock.Any()).Times(0)
+	fields.workerRepoMock.EXPECT().UpdatePassword(stored.ID, gomock.Any()).DoAndReturn(func(id uuid.UUID, password string) error {
+		upgradedHash = password
+		return nil
+	})
+	fields.workerRepoMock.EXPECT().UpdateLastLogin(stored.ID, gomock.Any()).Return(nil)
+
+	worker, _, err := service.Login(stored.Email, "password123")
+	assert.NoError(t, err)
+	assert.Equal(t, upgradedHash, worker.Password)
+	cost, err := bcrypt.Cost([]byte(upgradedHash))
+	assert.NoError(t, err)
+	assert.Equal(t, testPasswordHashCost, cost)
+	assert.NoError(t, bcrypt.CompareHashAndPassword([]byte(upgradedHash), []byte("password123")))
+}
+
+func TestWorkerServiceLoginRequiresSecondFactor(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	fields := initWorkerServiceFields(ctrl)
+	service := services.NewWorkerService(fields.workerRepoMock, fields.hash, fields.logger, testMaxActiveOrders, fields.secondFactor, true, models.LockoutSettings{}, nil)
+
+	fields.workerRepoMock.EXPECT().GetWorkerByEmail(gomock.Any()).Return(&models.Worker{ID: uuid.New(), Email: "test@email.com", Password: "hash"}, nil)
+	fields.hash.EXPECT().CompareAndMaybeRehash(gomock.Any(), gomock.Any()).Return("", true)
+	fields.workerRepoMock.EXPECT().UpdateLastLogin(gomock.Any(), gomock.Any()).Times(0)
+
+	worker, secondFactorRequired, err := service.Login("test@email.com", "password123")
+	assert.NoError(t, err)
+	assert.True(t, secondFactorRequired)
+	assert.NotNil(t, worker)
+	assert.Nil(t, worker.LastLoginAt)
+}
+
+var testWorkerVerifySecondFactor = []struct {
+	testName  string
+	inputData struct {
+		code string
+	}
+	prepare   func(fields *workerServiceFields, workerID uuid.UUID)
+	checkFunc func(t *testing.T, err error)
+}{
+	{
+		testName: "valid code completes login",
+		inputData: struct {
+			code string
+		}{" 123456 "},
+		prepare: func(fields *workerServiceFields, workerID uuid.UUID) {
+			fields.secondFactor.EXPECT().Verify(workerID, "123456").Return(true, nil)
+			fields.workerRepoMock.EXPECT().GetWorkerByID(workerID).Return(&models.Worker{ID: workerID}, nil)
+			fields.workerRepoMock.EXPECT().UpdateLastLogin(workerID, gomock.Any()).Return(nil)
+		},
+		checkFunc: func(t *testing.T, err error) {
+			assert.NoError(t, err)
+		},
+	},
+	{
+		testName: "empty code",
+		inputData: struct {
+			code string
+		}{"  "},
+		prepare: func(fields *workerServiceFields, workerID uuid.UUID) {
+			fields.secondFactor.EXPECT().Verify(gomock.Any(), gomock.Any()).Times(0)
+		},
+		checkFunc: func(t *testing.T, err error) {
+			assert.ErrorIs(t, err, service_errors.InvalidSecondFactorCode)
+		},
+	},
+	{
+		testName: "rejected code",
+		inputData: struct {
+			code string
+		}{"000000"},
+		prepare: func(fields *workerServiceFields, workerID uuid.UUID) {
+			fields.secondFactor.EXPECT().Verify(workerID, "000000").Return(false, nil)
+			fields.workerRepoMock.EXPECT().UpdateLastLogin(gomock.Any(), gomock.Any()).Times(0)
+		},
+		checkFunc: func(t *testing.T, err error) {
+			assert.ErrorIs(t, err, service_errors.InvalidSecondFactorCode)
+		},
+	},
+	{
+		testName: "provider error",
+		inputData: struct {
+			code string
+		}{"123456"},
+		prepare: func(fields *workerServiceFields, workerID uuid.UUID) {
+			fields.secondFactor.EXPECT().Verify(workerID, "123456").Return(false, second_factor.NotConfigured)
+		},
+		checkFunc: func(t *testing.T, err error) {
+			assert.ErrorIs(t, err, second_factor.NotConfigured)
+		},
+	},
+}
+
+func TestWorkerServiceVerifySecondFactor(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	fields := initWorkerServiceFields(ctrl)
+	service := services.NewWorkerService(fields.workerRepoMock, fields.hash, fields.logger, testMaxActiveOrders, fields.secondFactor, true, models.LockoutSettings{}, nil)
+
+	for _, tt := range testWorkerVerifySecondFactor {
+		t.Run(tt.testName, func(t *testing.T) {
+			workerID := uuid.New()
+			tt.prepare(fields, workerID)
+			err := service.VerifySecondFactor(workerID, tt.inputData.code)
+			tt.checkFunc(t, err)
+		})
+	}
+}
+
+var testWorkerGetRecencyWeightedRating = []struct {
+	testName  string
+	inputData struct {
+		halfLife time.Duration
+	}
+	prepare   func(fields *workerServiceFields, workerID uuid.UUID)
+	checkFunc func(t *testing.T, rating float64, err error)
+}{
+	{
+		testName: "recent ratings dominate older ones",
+		inputData: struct {
+			halfLife time.Duration
+		}{7 * 24 * time.Hour},
+		prepare: func(fields *workerServiceFields, workerID uuid.UUID) {
+			now := time.Now()
+			fields.workerRepoMock.EXPECT().GetWorkerByID(workerID).Return(&models.Worker{ID: workerID}, nil)
+			fields.workerRepoMock.EXPECT().GetCompletedOrderRatings(workerID).Return([]models.OrderRating{
+				{Rate: 5, CompletedAt: now.AddDate(0, 0, -1)},
+				{Rate: 5, CompletedAt: now.AddDate(0, 0, -2)},
+				{Rate: 1, CompletedAt: now.AddDate(0, 0, -60)},
+				{Rate: 1, CompletedAt: now.AddDate(0, 0, -90)},
+			}, nil)
+		},
+		checkFunc: func(t *testing.T, rating float64, err error) {
+			assert.NoError(t, err)
+			assert.Greater(t, rating, 4.9)
+			assert.LessOrEqual(t, rating, 5.0)
+		},
+	},
+	{
+		testName: "old ratings still give an average",
+		inputData: struct {
+			halfLife time.Duration
+		}{time.Hour},
+		prepare: func(fields *workerServiceFields, workerID uuid.UUID) {
+			longAgo := time.Now().AddDate(-5, 0, 0)
+			fields.workerRepoMock.EXPECT().GetWorkerByID(workerID).Return(&models.Worker{ID: workerID}, nil)
+			fields.workerRepoMock.EXPECT().GetCompletedOrderRatings(workerID).Return([]models.OrderRating{
+				{Rate: 4, CompletedAt: longAgo},
+				{Rate: 2, CompletedAt: longAgo},
+			}, nil)
+		},
+		checkFunc: func(t *testing.T, rating float64, err error) {
+			assert.NoError(t, err)
+			assert.InDelta(t, 3.0, rating, 1e-9)
+		},
+	},
+	{
+		testName: "equal ages give plain average",
+		inputData: struct {
+			halfLife time.Duration
+		}{24 * time.Hour},
+		prepare: func(fields *workerServiceFields, workerID uuid.UUID) {
+			completedAt := time.Now().AddDate(0, 0, -3)
+			fields.workerRepoMock.EXPECT().GetWorkerByID(workerID).Return(&models.Worker{ID: workerID}, nil)
+			fields.workerRepoMock.EXPECT().GetCompletedOrderRatings(workerID).Return([]models.OrderRating{
+				{Rate: 4, CompletedAt: completedAt},
+				{Rate: 2, CompletedAt: completedAt},
+			}, nil)
+		},
+		checkFunc: func(t *testing.T, rating float64, err error) {
+			assert.NoError(t, err)
+			assert.InDelta(t, 3.0, rating, 1e-9)
+		},
+	},
+	{
+		testName: "no ratings",
+		inputData: struct {
+			halfLife time.Duration
+		}{24 * time.Hour},
+		prepare: func(fields *workerServiceFields, workerID uuid.UUID) {
+			fields.workerRepoMock.EXPECT().GetWorkerByID(workerID).Return(&models.Worker{ID: workerID}, nil)
+			fields.workerRepoMock.EXPECT().GetCompletedOrderRatings(workerID).Return(nil, nil)
+		},
+		checkFunc: func(t *testing.T, rating float64, err error) {
+			assert.NoError(t, err)
+			assert.Equal(t, 0.0, rating)
+		},
+	},
+	{
+		testName: "invalid half-life",
+		inputData: struct {
+			halfLife time.Duration
+		}{0},
+		prepare: func(fields *workerServiceFields, workerID uuid.UUID) {
+			fields.workerRepoMock.EXPECT().GetWorkerByID(gomock.Any()).Times(0)
+		},
+		checkFunc: func(t *testing.T, rating float64, err error) {
+			assert.Error(t, err)
+			assert.Equal(t, 0.0, rating)
+		},
+	},
+	{
+		testName: "worker not found",
+		inputData: struct {
+			halfLife time.Duration
+		}{24 * time.Hour},
+		prepare: func(fields *workerServiceFields, workerID uuid.UUID) {
+			fields.workerRepoMock.EXPECT().GetWorkerByID(workerID).Return(nil, repository_errors.DoesNotExist)
+		},
+		checkFunc: func(t *testing.T, rating float64, err error) {
+			assert.Equal(t, repository_errors.DoesNotExist, err)
+			assert.Equal(t, 0.0, rating)
+		},
+	},
+}
+
+func TestWorkerServiceGetRecencyWeightedRating(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	fields := initWorkerServiceFields(ctrl)
+	service := initWorkerService(fields)
+
+	for _, tt := range testWorkerGetRecencyWeightedRating {
+		t.Run(tt.testName, func(t *testing.T) {
+			workerID := uuid.New()
+			tt.prepare(fields, workerID)
+			rating, err := service.GetRecencyWeightedRating(workerID, tt.inputData.halfLife)
+			tt.checkFunc(t, rating, err)
+		})
+	}
+}
+
+var testMaster = &models.Worker{ID: uuid.New(), Role: models.MasterRole}
+
+var testWorkerProfileAccess = []struct {
+	testName  string
+	inputData struct {
+		editor *models.Worker
+		id     uuid.UUID
+		role   int
+	}
+	prepare   func(fields *workerServiceFields, id uuid.UUID)
+	checkFunc func(t *testing.T, worker *models.Worker, err error)
+}{
+	{
+		testName: "master updates own profile",
+		inputData: struct {
+			editor *models.Worker
+			id     uuid.UUID
+			role   int
+		}{testMaster, testMaster.ID, models.MasterRole},
+		prepare: func(fields *workerServiceFields, id uuid.UUID) {
+			fields.workerRepoMock.EXPECT().GetWorkerByID(id).Return(&models.Worker{ID: id, Role: models.MasterRole, Password: "Password123"}, nil)
+			fields.workerRepoMock.EXPECT().Update(gomock.Any()).DoAndReturn(func(worker *models.Worker) (*models.Worker, error) {
+				return worker, nil
+			})
+		},
+		checkFunc: func(t *testing.T, worker *models.Worker, err error) {
+			assert.NoError(t, err)
+			assert.Equal(t, "Иван", worker.Name)
+		},
+	},
+	{
+		testName: "master updates another profile",
+		inputData: struct {
+			editor *models.Worker
+			id     uuid.UUID
+			role   int
+		}{testMaster, uuid.New(), models.MasterRole},
+		prepare: func(fields *workerServiceFields, id uuid.UUID) {
+			fields.workerRepoMock.EXPECT().GetWorkerByID(gomock.Any()).Times(0)
+			fields.workerRepoMock.EXPECT().Update(gomock.Any()).Times(0)
+		},
+		checkFunc: func(t *testing.T, worker *models.Worker, err error) {
+			assert.Nil(t, worker)
+			assert.ErrorIs(t, err, service_errors.PermissionDenied)
+		},
+	},
+	{
+		testName: "master promotes self to manager",
+		inputData: struct {
+			editor *models.Worker
+			id     uuid.UUID
+			role   int
+		}{testMaster, testMaster.ID, models.ManagerRole},
+		prepare: func(fields *workerServiceFields, id uuid.UUID) {
+			fields.workerRepoMock.EXPECT().GetWorkerByID(id).Return(&models.Worker{ID: id, Role: models.MasterRole, Password: "Password123"}, nil)
+			fields.workerRepoMock.EXPECT().Update(gomock.Any()).Times(0)
+		},
+		checkFunc: func(t *testing.T, worker *models.Worker, err error) {
+			assert.Nil(t, worker)
+			assert.ErrorIs(t, err, service_errors.PermissionDenied)
+		},
+	},
+	{
+		testName: "manager updates any profile",
+		inputData: struct {
+			editor *models.Worker
+			id     uuid.UUID
+			role   int
+		}{testManager, testMaster.ID, models.ManagerRole},
+		prepare: func(fields *workerServiceFields, id uuid.UUID) {
+			fields.workerRepoMock.EXPECT().GetWorkerByID(id).Return(&models.Worker{ID: id, Role: models.MasterRole, Password: "Password123"}, nil)
+			fields.workerRepoMock.EXPECT().Update(gomock.Any()).DoAndReturn(func(worker *models.Worker) (*models.Worker, error) {
+				return worker, nil
+			})
+		},
+		checkFunc: func(t *testing.T, worker *models.Worker, err error) {
+			assert.NoError(t, err)
+			assert.Equal(t, models.ManagerRole, worker.Role)
+		},
+	},
+}
+
+func TestWorkerServiceProfileAccess(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	fields := initWorkerServiceFields(ctrl)
+	service := initWorkerService(fields)
+
+	for _, tt := range testWorkerProfileAccess {
+		t.Run(tt.testName, func(t *testing.T) {
+			tt.prepare(fields, tt.inputData.id)
+			worker, err := service.Update(tt.inputData.editor, tt.inputData.id, "Иван", "Иванов", "ivan@mail.ru", "Москва", "+79999999999", tt.inputData.role)
+			tt.checkFunc(t, worker, err)
+		})
+	}
+}
+
+var testWorkerDemoteManager = []struct {
+	testName  string
+	inputData struct {
+		id   uuid.UUID
+		role int
+	}
+	prepare   func(fields *workerServiceFields, id uuid.UUID)
+	checkFunc func(t *testing.T, worker *models.Worker, err error)
+}{
+	{
+		testName: "demote manager when other managers exist",
+		inputData: struct {
+			id   uuid.UUID
+			role int
+		}{uuid.New(), models.MasterRole},
+		prepare: func(fields *workerServiceFields, id uuid.UUID) {
+			fields.workerRepoMock.EXPECT().GetWorkerByID(id).Return(&models.Worker{ID: id, Role: models.ManagerRole, Password: "Password123"}, nil)
+			fields.workerRepoMock.EXPECT().GetWorkersByRole(models.ManagerRole, id).Return([]models.Worker{*testManager}, nil)
+			fields.workerRepoMock.EXPECT().Update(gomock.Any()).DoAndReturn(func(worker *models.Worker) (*models.Worker, error) {
+				return worker, nil
+			})
+		},
+		checkFunc: func(t *testing.T, worker *models.Worker, err error) {
+			assert.NoError(t, err)
+			assert.Equal(t, models.MasterRole, worker.Role)
+		},
+	},
+	{
+		testName: "demote last manager",
+		inputData: struct {
+			id   uuid.UUID
+			role int
+		}{testManager.ID, models.MasterRole},
+		prepare: func(fields *workerServiceFields, id uuid.UUID) {
+			fields.workerRepoMock.EXPECT().GetWorkerByID(id).Return(&models.Worker{ID: id, Role: models.ManagerRole, Password: "Password123"}, nil)
+			fields.workerRepoMock.EXPECT().GetWorkersByRole(models.ManagerRole, id).Return([]models.Worker{}, nil)
+			fields.workerRepoMock.EXPECT().Update(gomock.Any()).Times(0)
+		},
+		checkFunc: func(t *testing.T, worker *models.Worker, err error) {
+			assert.Nil(t, worker)
+			assert.ErrorIs(t, err, service_errors.LastManager)
+		},
+	},
+	{
+		testName: "manager lookup error",
+		inputData: struct {
+			id   uuid.UUID
+			role int
+		}{uuid.New(), models.MasterRole},
+		prepare: func(fields *workerServiceFields, id uuid.UUID) {
+			fields.workerRepoMock.EXPECT().GetWorkerByID(id).Return(&models.Worker{ID: id, Role: models.ManagerRole, Password: "Password123"}, nil)
+			fields.workerRepoMock.EXPECT().GetWorkersByRole(models.ManagerRole, id).Return(nil, repository_errors.SelectError)
+			fields.workerRepoMock.EXPECT().Update(gomock.Any()).Times(0)
+		},
+		checkFunc: func(t *testing.T, worker *models.Worker, err error) {
+			assert.Nil(t, worker)
+			assert.ErrorIs(t, err, repository_errors.SelectError)
+		},
+	},
+	{
+		testName: "last manager keeps the role",
+		inputData: struct {
+			id   uuid.UUID
+			role int
+		}{testManager.ID, models.ManagerRole},
+		prepare: func(fields *workerServiceFields, id uuid.UUID) {
+			fields.workerRepoMock.EXPECT().GetWorkerByID(id).Return(&models.Worker{ID: id, Role: models.ManagerRole, Password: "Password123"}, nil)
+			fields.workerRepoMock.EXPECT().Update(gomock.Any()).DoAndReturn(func(worker *models.Worker) (*models.Worker, error) {
+				return worker, nil
+			})
+		},
+		checkFunc: func(t *testing.T, worker *models.Worker, err error) {
+			assert.NoError(t, err)
+			assert.Equal(t, models.ManagerRole, worker.Role)
+		},
+	},
+}
+
+func TestWorkerServiceDemoteManager(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	fields := initWorkerServiceFields(ctrl)
+	service := initWorkerService(fields)
+
+	for _, tt := range testWorkerDemoteManager {
+		t.Run(tt.testName, func(t *testing.T) {
+			tt.prepare(fields, tt.inputData.id)
+			worker, err := service.Update(testManager, tt.inputData.id, "Иван", "Иванов", "ivan@mail.ru", "Москва", "+79999999999", tt.inputData.role)
+			tt.checkFunc(t, worker, err)
+		})
+	}
+}
+
+func TestWorkerServiceGetByIDAccess(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	fields := initWorkerServiceFields(ctrl)
+	service := initWorkerService(fields)
+
+	fields.workerRepoMock.EXPECT().GetWorkerByID(testMaster.ID).Return(testMaster, nil).Times(2)
+
+	worker, err := service.GetWorkerByID(testMaster, testMaster.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, testMaster.ID, worker.ID)
+
+	worker, err = service.GetWorkerByID(testManager, testMaster.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, testMaster.ID, worker.ID)
+
+	worker, err = service.GetWorkerByID(testMaster, testManager.ID)
+	assert.Nil(t, worker)
+	assert.ErrorIs(t, err, service_errors.PermissionDenied)
+}
+
+func TestWorkerServiceGetWorkersByRoleExclude(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	fields := initWorkerServiceFields(ctrl)
+	service := initWorkerService(fields)
+
+	remaining := []models.Worker{{ID: uuid.New(), Role: models.MasterRole}, {ID: uuid.New(), Role: models.MasterRole}}
+	fields.workerRepoMock.EXPECT().GetWorkersByRole(models.MasterRole, testManager.ID).Return(remaining, nil)
+
+	workers, err := service.GetWorkersByRole(models.MasterRole, testManager.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, remaining, workers)
+}
+
+var testWorkerSetNotificationsOptOut = []struct {
+	testName  string
+	inputData struct {
+		id     uuid.UUID
+		optOut bool
+	}
+	prepare   func(fields *workerServiceFields, id uuid.UUID)
+	checkFunc func(t *testing.T, err error)
+}{
+	{
+		testName: "opt out is saved",
+		inputData: struct {
+			id     uuid.UUID
+			optOut bool
+		}{uuid.New(), true},
+		prepare: func(fields *workerServiceFields, id uuid.UUID) {
+			fields.workerRepoMock.EXPECT().GetWorkerByID(id).Return(&models.Worker{ID: id}, nil)
+			fields.workerRepoMock.EXPECT().Update(&models.Worker{ID: id, NotificationsOptOut: true}).Return(&models.Worker{ID: id, NotificationsOptOut: true}, nil)
+		},
+		checkFunc: func(t *testing.T, err error) {
+			assert.NoError(t, err)
+		},
+	},
+	{
+		testName: "worker not found",
+		inputData: struct {
+			id     uuid.UUID
+			optOut bool
+		}{uuid.New(), true},
+		prepare: func(fields *workerServiceFields, id uuid.UUID) {
+			fields.workerRepoMock.EXPECT().GetWorkerByID(id).Return(nil, repository_errors.DoesNotExist)
+		},
+		checkFunc: func(t *testing.T, err error) {
+			assert.ErrorIs(t, err, repository_errors.DoesNotExist)
+		},
+	},
+}
+
+func TestWorkerService_SetNotificationsOptOut(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	fields := initWorkerServiceFields(ctrl)
+	service := initWorkerService(fields)
+
+	for _, tt := range testWorkerSetNotificationsOptOut {
+		t.Run(tt.testName, func(t *testing.T) {
+			tt.prepare(fields, tt.inputData.id)
+			err := service.SetNotificationsOptOut(tt.inputData.id, tt.inputData.optOut)
+			tt.checkFunc(t, err)
+		})
+	}
+}
+
+var testWorkerGetRoster = []struct {
+	testName  string
+	prepare   func(fields *workerServiceFields)
+	checkFunc func(t *testing.T, managers []models.Worker, masters []models.Worker, err error)
+}{
+	{
+		testName: "workers are grouped by role",
+		prepare: func(fields *workerServiceFields) {
+			fields.workerRepoMock.EXPECT().GetAllWorkers().Return([]models.Worker{
+				{Name: "Anna", Role: models.MasterRole, Password: "hash1"},
+				{Name: "Boris", Role: models.ManagerRole, Password: "hash2"},
+				{Name: "Vera", Role: models.MasterRole, Password: "hash3"},
+			}, nil)
+		},
+		checkFunc: func(t *testing.T, managers []models.Worker, masters []models.Worker, err error) {
+			assert.NoError(t, err)
+			assert.Equal(t, []models.Worker{{Name: "Boris", Role: models.ManagerRole}}, managers)
+			assert.Equal(t, []models.Worker{{Name: "Anna", Role: models.MasterRole}, {Name: "Vera", Role: models.MasterRole}}, masters)
+		},
+	},
+	{
+		testName: "team without masters",
+		prepare: func(fields *workerServiceFields) {
+			fields.workerRepoMock.EXPECT().GetAllWorkers().Return([]models.Worker{{Name: "Boris", Role: models.ManagerRole}}, nil)
+		},
+		checkFunc: func(t *testing.T, managers []models.Worker, masters []models.Worker, err error) {
+			assert.NoError(t, err)
+			assert.Len(t, managers, 1)
+			assert.NotNil(t, masters)
+			assert.Empty(t, masters)
+		},
+	},
+	{
+		testName: "retrieval error",
+		prepare: func(fields *workerServiceFields) {
+			fields.workerRepoMock.EXPECT().GetAllWorkers().Return(nil, repository_errors.SelectError)
+		},
+		checkFunc: func(t *testing.T, managers []models.Worker, masters []models.Worker, err error) {
+			assert.Equal(t, repository_errors.SelectError, err)
+			assert.Nil(t, managers)
+			assert.Nil(t, masters)
+		},
+	},
+}
+
+func TestWorkerService_GetRoster(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	fields := initWorkerServiceFields(ctrl)
+	service := initWorkerService(fields)
+
+	for _, tt := range testWorkerGetRoster {
+		tt.prepare(fields)
+		t.Run(tt.testName, func(t *testing.T) {
+			managers, masters, err := service.GetRoster()
+			tt.checkFunc(t, managers, masters, err)
+		})
+	}
+}
+
+var testWorkerGetWorkerWorkload = []struct {
+	testName  string
+	prepare   func(fields *workerServiceFields)
+	checkFunc func(t *testing.T, workload int, err error)
+}{
+	{
+		testName: "active orders are counted",
+		prepare: func(fields *workerServiceFields) {
+			fields.workerRepoMock.EXPECT().GetWorkerByID(gomock.Any()).Return(&models.Worker{Role: models.MasterRole}, nil)
+			fields.workerRepoMock.EXPECT().GetActiveOrdersCount(gomock.Any()).Return(3, nil)
+		},
+		checkFunc: func(t *testing.T, workload int, err error) {
+			assert.NoError(t, err)
+			assert.Equal(t, 3, workload)
+		},
+	},
+	{
+		testName: "worker does not exist",
+		prepare: func(fields *workerServiceFields) {
+			fields.workerRepoMock.EXPECT().GetWorkerByID(gomock.Any()).Return(nil, repository_errors.DoesNotExist)
+		},
+		checkFunc: func(t *testing.T, workload int, err error) {
+			assert.Equal(t, repository_errors.DoesNotExist, err)
+			assert.Equal(t, 0, workload)
+		},
+	},
+}
+
+func TestWorkerService_GetWorkerWorkload(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	fields := initWorkerServiceFields(ctrl)
+	service := initWorkerService(fields)
+
+	for _, tt := range testWorkerGetWorkerWorkload {
+		tt.prepare(fields)
+		t.Run(tt.testName, func(t *testing.T) {
+			workload, err := service.GetWorkerWorkload(uuid.New())
+			tt.checkFunc(t, workload, err)
+		})
+	}
+}
+
+var (
+	lowMasterID    = uuid.MustParse("00000000-0000-0000-0000-000000000001")
+	middleMasterID = uuid.MustParse("00000000-0000-0000-0000-000000000002")
+	highMasterID   = uuid.MustParse("00000000-0000-0000-0000-000000000003")
+)
+
+var testWorkerGetLeastBusyWorker = []struct {
+	testName  string
+	workloads map[uuid.UUID]int
+	masters   []models.Worker
+	checkFunc func(t *testing.T, worker *models.Worker, err error)
+}{
+	{
+		testName:  "master with the fewest active orders",
+		workloads: map[uuid.UUID]int{lowMasterID: 4, middleMasterID: 1, highMasterID: 2},
+		masters:   []models.Worker{{ID: lowMasterID, Password: "hash"}, {ID: middleMasterID, Password: "hash"}, {ID: highMasterID, Password: "hash"}},
+		checkFunc: func(t *testing.T, worker *models.Worker, err error) {
+			assert.NoError(t, err)
+			assert.Equal(t, middleMasterID, worker.ID)
+			assert.Empty(t, worker.Password)
+		},
+	},
+	{
+		testName:  "tie is resolved by the lowest id",
+		workloads: map[uuid.UUID]int{lowMasterID: 2, middleMasterID: 2, highMasterID: 2},
+		masters:   []models.Worker{{ID: highMasterID}, {ID: lowMasterID}, {ID: middleMasterID}},
+		checkFunc: func(t *testing.T, worker *models.Worker, err error) {
+			assert.NoError(t, err)
+			assert.Equal(t, lowMasterID, worker.ID)
+		},
+	},
+	{
+		testName:  "no masters",
+		workloads: map[uuid.UUID]int{},
+		masters:   []models.Worker{},
+		checkFunc: func(t *testing.T, worker *models.Worker, err error) {
+			assert.Equal(t, service_errors.NoMasters, err)
+			assert.Nil(t, worker)
+		},
+	},
+}
+
+func TestWorkerService_GetLeastBusyWorker(t *testing.T) {
+	for _, tt := range testWorkerGetLeastBusyWorker {
+		t.Run(tt.testName, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			fields := initWorkerServiceFields(ctrl)
+			service := initWorkerService(fields)
+
+			fields.workerRepoMock.EXPECT().GetWorkersByRole(models.MasterRole, uuid.Nil).Return(tt.masters, nil)
+			for id, workload := range tt.workloads {
+				fields.workerRepoMock.EXPECT().GetActiveOrdersCount(id).Return(workload, nil)
+			}
+
+			worker, err := service.GetLeastBusyWorker()
+			tt.checkFunc(t, worker, err)
+		})
+	}
+}
+
+var testWorkerGetAssignmentCandidates = []struct {
+	testName  string
+	minRating float64
+	prepare   func(fields *workerServiceFields)
+	checkFunc func(t *testing.T, candidates []models.WorkerWithRating, err error)
+}{
+	{
+		testName:  "candidates are returned with the capacity limit",
+		minRating: 4,
+		prepare: func(fields *workerServiceFields) {
+			fields.workerRepoMock.EXPECT().GetAssignmentCandidates(4.0, testMaxActiveOrders).Return([]models.WorkerWithRating{
+				{Worker: models.Worker{ID: uuid.New()}, AverageRating: 5},
+				{Worker: models.Worker{ID: uuid.New()}, AverageRating: 4, ActiveOrders: 1},
+			}, nil)
+		},
+		checkFunc: func(t *testing.T, candidates []models.WorkerWithRating, err error) {
+			assert.NoError(t, err)
+			assert.Len(t, candidates, 2)
+		},
+	},
+	{
+		testName:  "negative threshold",
+		minRating: -1,
+		prepare: func(fields *workerServiceFields) {
+			fields.workerRepoMock.EXPECT().GetAssignmentCandidates(gomock.Any(), gomock.Any()).Times(0)
+		},
+		checkFunc: func(t *testing.T, candidates []models.WorkerWithRating, err error) {
+			assert.Equal(t, service_errors.RatingOutOfRange, err)
+			assert.Nil(t, candidates)
+		},
+	},
+	{
+		testName:  "threshold above maximum rating",
+		minRating: 5.5,
+		prepare: func(fields *workerServiceFields) {
+			fields.workerRepoMock.EXPECT().GetAssignmentCandidates(gomock.Any(), gomock.Any()).Times(0)
+		},
+		checkFunc: func(t *testing.T, candidates []models.WorkerWithRating, err error) {
+			assert.Equal(t, service_errors.RatingOutOfRange, err)
+			assert.Nil(t, candidates)
+		},
+	},
+	{
+		testName:  "repository failure",
+		minRating: 0,
+		prepare: func(fields *workerServiceFields) {
+			fields.workerRepoMock.EXPECT().GetAssignmentCandidates(0.0, testMaxActiveOrders).Return(nil, repository_errors.SelectError)
+		},
+		checkFunc: func(t *testing.T, candidates []models.WorkerWithRating, err error) {
+			assert.Equal(t, repository_errors.SelectError, err)
+			assert.Nil(t, candidates)
+		},
+	},
+}
+
+func TestWorkerService_GetAssignmentCandidates(t *testing.T) {
+	for _, tt := range testWorkerGetAssignmentCandidates {
+		t.Run(tt.testName, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			fields := initWorkerServiceFields(ctrl)
+			service := initWorkerService(fields)
+			tt.prepare(fields)
+
+			candidates, err := service.GetAssignmentCandidates(tt.minRating)
+			tt.checkFunc(t, candidates, err)
+		})
+	}
+}
+
+var testWorkerGetPerformanceReport = []struct {
+	testName  string
+	prepare   func(fields *workerServiceFields)
+	checkFunc func(t *testing.T, report []models.WorkerPerformance, err error)
+}{
+	{
+		testName: "two masters with differing stats",
+		prepare: func(fields *workerServiceFields) {
+			fields.workerRepoMock.EXPECT().GetPerformanceReport().Return([]models.WorkerPerformance{
+				{Worker: models.Worker{Name: "Best"}, CompletedOrders: 3, AverageRating: 4.5, Revenue: 3000},
+				{Worker: models.Worker{Name: "Busy"}, CompletedOrders: 5, CancelledOrders: 2, AverageRating: 3, Revenue: 4500},
+			}, nil)
+		},
+		checkFunc: func(t *testing.T, report []models.WorkerPerformance, err error) {
+			assert.NoError(t, err)
+			assert.Len(t, report, 2)
+			assert.Equal(t, "Best", report[0].Worker.Name)
+			assert.Equal(t, 2, report[1].CancelledOrders)
+			assert.Equal(t, 4500.0, report[1].Revenue)
+		},
+	},
+	{
+		testName: "repository failure",
+		prepare: func(fields *workerServiceFields) {
+			fields.workerRepoMock.EXPECT().GetPerformanceReport().Return(nil, repository_errors.SelectError)
+		},
+		checkFunc: func(t *testing.T, report []models.WorkerPerformance, err error) {
+			assert.Equal(t, repository_errors.SelectError, err)
+			assert.Nil(t, report)
+		},
+	},
+}
+
+func TestWorkerService_GetPerformanceReport(t *testing.T) {
+	for _, tt := range testWorkerGetPerformanceReport {
+		t.Run(tt.testName, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			fields := initWorkerServiceFields(ctrl)
+			service := initWorkerService(fields)
+			tt.prepare(fields)
+
+			report, err := service.GetPerformanceReport()
+			tt.checkFunc(t, report, err)
+		})
+	}
+}
+
+func TestWorkerServiceLoginLockout(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	fields := initWorkerServiceFields(ctrl)
+	lockout := models.LockoutSettings{MaxFailures: 5, Window: time.Minute}
+	service := services.NewWorkerService(fields.workerRepoMock, fields.hash, fields.logger, testMaxActiveOrders, fields.secondFactor, false, lockout, nil)
+
+	// failures for an unknown email are counted as well
+	fields.workerRepoMock.EXPECT().GetWorkerByEmail("unknown@gmail.com").Return(nil, repository_errors.DoesNotExist).Times(lockout.MaxFailures)
+
+	for i := 0; i < lockout.MaxFailures; i++ {
+		_, _, err := service.Login("unknown@gmail.com", "password123")
+		assert.NotErrorIs(t, err, service_errors.LoginLocked)
+	}
+
+	worker, secondFactorRequired, err := service.Login("unknown@gmail.com", "password123")
+	assert.ErrorIs(t, err, service_errors.LoginLocked)
+	assert.Nil(t, worker)
+	assert.False(t, secondFactorRequired)
+
+	// other emails are not affected
+	stored := &models.Worker{ID: uuid.New(), Email: "worker@gmail.com", Password: "hashedPassword"}
+	fields.workerRepoMock.EXPECT().GetWorkerByEmail(stored.Email).Return(stored, nil)
+	fields.hash.EXPECT().CompareAndMaybeRehash(stored.Password, "password123").Return("", true)
+	fields.workerRepoMock.EXPECT().UpdateLastLogin(stored.ID, gomock.Any()).Return(nil)
+
+	worker, _, err = service.Login(stored.Email, "password123")
+	assert.NoError(t, err)
+	assert.Equal(t, stored.ID, worker.ID)
+}
+
+func TestWorkerServiceLoginWithToken(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tokens := auth.NewTokenIssuer("test-secret", time.Hour)
+	stored := &models.Worker{ID: uuid.New(), Email: "test@email.com", Password: "hash", Role: models.MasterRole}
+
+	t.Run("token carries the worker role", func(t *testing.T) {
+		fields := initWorkerServiceFields(ctrl)
+		service := services.NewWorkerService(fields.workerRepoMock, fields.hash, fields.logger, testMaxActiveOrders, fields.secondFactor, false, models.LockoutSettings{}, tokens)
+
+		fields.workerRepoMock.EXPECT().GetWorkerByEmail(stored.Email).Return(stored, nil)
+		fields.hash.EXPECT().CompareAndMaybeRehash(stored.Password, "password123").Return("", true)
+		fields.workerRepoMock.EXPECT().UpdateLastLogin(stored.ID, gomock.Any()).Return(nil)
+
+		worker, secondFactorRequired, token, err := service.LoginWithToken(stored.Email, "password123")
+		assert.NoError(t, err)
+		assert.False(t, secondFactorRequired)
+		assert.Equal(t, stored.ID, worker.ID)
+
+		claims, err := tokens.ParseToken(token)
+		assert.NoError(t, err)
+		assert.Equal(t, stored.ID, claims.SubjectID)
+		assert.Equal(t, models.MasterRole, claims.Role)
+	})
+
+	t.Run("no token while second factor is pending", func(t *testing.T) {
+		fields := initWorkerServiceFields(ctrl)
+		service := services.NewWorkerService(fields.workerRepoMock, fields.hash, fields.logger, testMaxActiveOrders, fields.secondFactor, true, models.LockoutSettings{}, tokens)
+
+		fields.workerRepoMock.EXPECT().GetWorkerByEmail(stored.Email).Return(stored, nil)
+		fields.hash.EXPECT().CompareAndMaybeRehash(stored.Password, "password123").Return("", true)
+
+		_, secondFactorRequired, token, err := service.LoginWithToken(stored.Email, "password123")
+		assert.NoError(t, err)
+		assert.True(t, secondFactorRequired)
+		assert.Empty(t, token)
+
+		fields.secondFactor.EXPECT().Verify(stored.ID, "123456").Return(true, nil)
+		fields.workerRepoMock.EXPECT().GetWorkerByID(stored.ID).Return(stored, nil)
+		fields.workerRepoMock.EXPECT().UpdateLastLogin(stored.ID, gomock.Any()).Return(nil)
+
+		token, err = service.VerifySecondFactorWithToken(stored.ID, "123456")
+		assert.NoError(t, err)
+
+		claims, err := tokens.ParseToken(token)
+		assert.NoError(t, err)
+		assert.Equal(t, stored.ID, claims.SubjectID)
+	})
+}
+
+var testWorkerSetAvailability = []struct {
+	testName  string
+	from      time.Time
+	to        time.Time
+	prepare   func(fields *workerServiceFields)
+	checkFunc func(t *testing.T, err error)
+}{
+	{
+		testName: "success",
+		from:     time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC),
+		to:       time.Date(2024, 3, 8, 0, 0, 0, 0, time.UTC),
+		prepare: func(fields *workerServiceFields) {
+			fields.workerRepoMock.EXPECT().GetWorkerByID(gomock.Any()).Return(&models.Worker{}, nil)
+			fields.workerRepoMock.EXPECT().SetAvailability(gomock.Any(), time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 3, 8, 0, 0, 0, 0, time.UTC), false).Return(nil)
+		},
+		checkFunc: func(t *testing.T, err error) {
+			assert.NoError(t, err)
+		},
+	},
+	{
+		testName: "empty period",
+		from:     time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC),
+		to:       time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC),
+		prepare:  func(fields *workerServiceFields) {},
+		checkFunc: func(t *testing.T, err error) {
+			assert.ErrorIs(t, err, service_errors.InvalidPeriod)
+		},
+	},
+	{
+		testName: "worker not found",
+		from:     time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC),
+		to:       time.Date(2024, 3, 8, 0, 0, 0, 0, time.UTC),
+		prepare: func(fields *workerServiceFields) {
+			fields.workerRepoMock.EXPECT().GetWorkerByID(gomock.Any()).Return(nil, repository_errors.DoesNotExist)
+		},
+		checkFunc: func(t *testing.T, err error) {
+			assert.Equal(t, repository_errors.DoesNotExist, err)
+		},
+	},
+}
+
+func TestWorkerService_SetAvailability(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	fields := initWorkerServiceFields(ctrl)
+	service := initWorkerService(fields)
+
+	for _, tt := range testWorkerSetAvailability {
+		tt.prepare(fields)
+		t.Run(tt.testName, func(t *testing.T) {
+			err := service.SetAvailability(testManager, uuid.New(), tt.from, tt.to, false)
+			tt.checkFunc(t, err)
+		})
+	}
+
+	t.Run("only a manager", func(t *testing.T) {
+		fields.workerRepoMock.EXPECT().SetAvailability(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+
+		for _, editor := range []*models.Worker{nil, {ID: uuid.New(), Role: models.MasterRole}} {
+			err := service.SetAvailability(editor, uuid.New(), time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 3, 8, 0, 0, 0, 0, time.UTC), false)
+			assert.ErrorIs(t, err, service_errors.InvalidRole)
+		}
+	})
+}
+
+func TestWorkerService_IsAvailable(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	fields := initWorkerServiceFields(ctrl)
+	service := initWorkerService(fields)
+
+	workerID := uuid.New()
+	at := time.Date(2024, 3, 4, 12, 0, 0, 0, time.UTC)
+	fields.workerRepoMock.EXPECT().GetWorkerByID(workerID).Return(&models.Worker{ID: workerID}, nil)
+	fields.workerRepoMock.EXPECT().IsAvailable(workerID, at).Return(false, nil)
+
+	available, err := service.IsAvailable(workerID, at)
+	assert.NoError(t, err)
+	assert.False(t, available)
+}